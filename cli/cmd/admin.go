@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"time"
+
+	injector "github.com/linkerd/linkerd2/controller/proxy-injector"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	injectionPausedReason  = "InjectionPaused"
+	injectionResumedReason = "InjectionResumed"
+)
+
+type injectionPauseOptions struct {
+	reason string
+}
+
+func newCmdAdmin() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Administrative commands for incident mitigation",
+		Long: `Administrative commands for incident mitigation.
+
+The admin subcommands change the behavior of the running control plane, e.g.
+to stop it from affecting workloads during an outage.`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdAdminPauseInjection())
+	cmd.AddCommand(newCmdAdminResumeInjection())
+
+	return cmd
+}
+
+func newCmdAdminPauseInjection() *cobra.Command {
+	options := &injectionPauseOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "pause-injection [flags]",
+		Short: "Stop the proxy injector from injecting pods cluster-wide",
+		Long: `Stop the proxy injector from injecting pods cluster-wide.
+
+The namespace selector of the proxy injector's webhook is changed to match no
+namespace, so that pods are created as if the proxy injector wasn't installed,
+until "linkerd admin resume-injection" is run. Injection stays paused when the
+proxy injector restarts. Pods already injected keep their proxy.
+
+An Event recording the change is created in the control plane's namespace.`,
+		Example: `  # Pause injection while investigating failing pod creations.
+  linkerd admin pause-injection --reason "pods stuck in ContainerCreating"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+			return setInjectionPaused(stdout, client, controlPlaneNamespace, true, options.reason)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.reason, "reason", options.reason, "Reason for pausing injection, recorded in the audit Event")
+
+	return cmd
+}
+
+func newCmdAdminResumeInjection() *cobra.Command {
+	options := &injectionPauseOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "resume-injection [flags]",
+		Short: "Resume proxy injection paused by pause-injection",
+		Long: `Resume proxy injection paused by pause-injection.
+
+The namespace selector of the proxy injector's webhook is restored. Pods
+created while injection was paused must be restarted to be injected.
+
+An Event recording the change is created in the control plane's namespace.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+			return setInjectionPaused(stdout, client, controlPlaneNamespace, false, options.reason)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.reason, "reason", options.reason, "Reason for resuming injection, recorded in the audit Event")
+
+	return cmd
+}
+
+// setInjectionPaused pauses or resumes the proxy injector of the control plane
+// in the given namespace, and records an Event for it.
+func setInjectionPaused(w io.Writer, client kubernetes.Interface, controllerNS string, paused bool, reason string) error {
+	scoped, err := checkProxyInjectorInstalled(client, controllerNS)
+	if err != nil {
+		return err
+	}
+	name := k8s.ProxyInjectorWebhookConfigName
+	if scoped {
+		name = k8s.ScopedWebhookConfigName(controllerNS, name)
+	}
+
+	webhooks := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+	config, err := webhooks.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	if paused {
+		changed = injector.PauseInjection(config)
+	} else {
+		changed = injector.ResumeInjection(config)
+	}
+	if !changed {
+		if paused {
+			fmt.Fprintln(w, "proxy injection is already paused")
+		} else {
+			fmt.Fprintln(w, "proxy injection isn't paused")
+		}
+		return nil
+	}
+
+	if _, err := webhooks.Update(config); err != nil {
+		return err
+	}
+
+	eventReason := injectionResumedReason
+	message := "proxy injection resumed"
+	if paused {
+		eventReason = injectionPausedReason
+		message = "proxy injection paused"
+	}
+	if u, err := user.Current(); err == nil {
+		message = fmt.Sprintf("%s by %s", message, u.Username)
+	}
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+
+	now := time.Now()
+	timestamp := metav1.NewTime(now)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", name, now.UnixNano()),
+			Namespace: controllerNS,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "admissionregistration.k8s.io/v1beta1",
+			Kind:       "MutatingWebhookConfiguration",
+			Name:       name,
+			UID:        config.UID,
+		},
+		Reason:         eventReason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "linkerd-cli"},
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Count:          1,
+	}
+	if _, err := client.CoreV1().Events(controllerNS).Create(event); err != nil {
+		fmt.Fprintf(stderr, "failed to record an event: %s\n", err)
+	}
+
+	fmt.Fprintln(w, message)
+	return nil
+}
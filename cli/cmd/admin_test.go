@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	injector "github.com/linkerd/linkerd2/controller/proxy-injector"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetInjectionPaused(t *testing.T) {
+	name := k8s.ScopedWebhookConfigName("linkerd", k8s.ProxyInjectorWebhookConfigName)
+	client := newMeshTestClient(&arv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Webhooks:   []arv1beta1.Webhook{{Name: "linkerd-proxy-injector.linkerd.io"}},
+	})
+
+	testCases := []struct {
+		paused   bool
+		reason   string
+		output   string
+		events   int
+		expected bool
+	}{
+		{true, "injection outage", "injection outage", 1, true},
+		{true, "", "already paused", 1, true},
+		{false, "", "proxy injection resumed", 2, false},
+		{false, "", "isn't paused", 2, false},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		var buf bytes.Buffer
+		if err := setInjectionPaused(&buf, client, "linkerd", tc.paused, tc.reason); err != nil {
+			t.Fatalf("test %d: Unexpected error: %s", i, err)
+		}
+		if !strings.Contains(buf.String(), tc.output) {
+			t.Fatalf("test %d: Expected output to contain [%s], got [%s]", i, tc.output, buf.String())
+		}
+
+		config, err := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Get(name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("test %d: Unexpected error: %s", i, err)
+		}
+		if injector.InjectionPaused(config) != tc.expected {
+			t.Fatalf("test %d: Expected injection paused to be %t", i, tc.expected)
+		}
+
+		events, err := client.CoreV1().Events("linkerd").List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("test %d: Unexpected error: %s", i, err)
+		}
+		if len(events.Items) != tc.events {
+			t.Fatalf("test %d: Expected %d events, got %d", i, tc.events, len(events.Items))
+		}
+	}
+}
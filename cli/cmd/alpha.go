@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newCmdAlpha() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alpha",
+		Short: "Experimental commands that may change or be removed in future releases",
+		Long: `Experimental commands that may change or be removed in future releases.
+
+The commands grouped under alpha are not covered by any compatibility
+guarantees. Their flags and output may change between releases.`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdClients())
+	cmd.AddCommand(newCmdTestkit())
+
+	return cmd
+}
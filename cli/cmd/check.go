@@ -13,13 +13,33 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	checkFailOnErrors   = "errors"
+	checkFailOnWarnings = "warnings"
+
+	// shortOutput prints one line per category of checks instead of the
+	// results of every check.
+	shortOutput = "short"
+
+	// The exit codes of `linkerd check`. When checks of several kinds fail,
+	// connectivity failures take precedence over RBAC failures, which take
+	// precedence over other failures.
+	checkExitFailure      = 2
+	checkExitConnectivity = 3
+	checkExitRBAC         = 4
+	checkExitWarnings     = 5
+)
+
 type checkOptions struct {
 	versionOverride string
 	preInstallOnly  bool
 	dataPlaneOnly   bool
+	waitForRollout  bool
 	wait            time.Duration
 	namespace       string
 	cniEnabled      bool
+	failOn          string
+	output          string
 }
 
 func newCheckOptions() *checkOptions {
@@ -27,9 +47,12 @@ func newCheckOptions() *checkOptions {
 		versionOverride: "",
 		preInstallOnly:  false,
 		dataPlaneOnly:   false,
+		waitForRollout:  false,
 		wait:            300 * time.Second,
 		namespace:       "",
 		cniEnabled:      false,
+		failOn:          checkFailOnErrors,
+		output:          tableOutput,
 	}
 }
 
@@ -44,7 +67,12 @@ func newCmdCheck() *cobra.Command {
 The check command will perform a series of checks to validate that the linkerd
 CLI and control plane are configured correctly. If the command encounters a
 failure it will print additional information about the failure and exit with a
-non-zero exit code.`,
+non-zero exit code:
+
+  2  a check failed
+  3  the Kubernetes API or the Linkerd control plane API couldn't be reached
+  4  the caller isn't authorized to perform an operation a check requires
+  5  only checks reported as warnings failed, and --fail-on=warnings is set`,
 		Example: `  # Check that the Linkerd control plane is up and running
   linkerd check
 
@@ -52,7 +80,16 @@ non-zero exit code.`,
   linkerd check --pre --linkerd-namespace test
 
   # Check that the Linkerd data plane proxies in the "app" namespace are up and running
-  linkerd check --proxy --namespace app`,
+  linkerd check --proxy --namespace app
+
+  # Wait for the injected pods in the "app" namespace to roll out, reporting the stuck ones
+  linkerd check --proxy --wait-for-rollout --namespace app --wait 10m
+
+  # Fail in CI when any check reports a warning
+  linkerd check --fail-on warnings
+
+  # Print a single line per category of checks, e.g. for a status page
+  linkerd check -o short`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return configureAndRunChecks(stdout, options)
@@ -63,9 +100,13 @@ non-zero exit code.`,
 	cmd.PersistentFlags().StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
 	cmd.PersistentFlags().BoolVar(&options.preInstallOnly, "pre", options.preInstallOnly, "Only run pre-installation checks, to determine if the control plane can be installed")
 	cmd.PersistentFlags().BoolVar(&options.dataPlaneOnly, "proxy", options.dataPlaneOnly, "Only run data-plane checks, to determine if the data plane is healthy")
+	cmd.PersistentFlags().BoolVar(&options.waitForRollout, "wait-for-rollout", options.waitForRollout, "When running data-plane checks (--proxy), wait for the injected pods to become ready and report why the pods that aren't are stuck")
 	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for all tests to pass")
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces)")
 	cmd.PersistentFlags().BoolVar(&options.cniEnabled, "linkerd-cni-enabled", options.cniEnabled, "When running pre-installation checks (--pre), assume the linkerd-cni plugin is already installed, and a NET_ADMIN check is not needed")
+	cmd.PersistentFlags().StringVar(&options.failOn, "fail-on", options.failOn, "Exit with a non-zero exit code on \"errors\", or on \"warnings\" as well")
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output, fmt.Sprintf("Output format; one of: \"%s\" or \"%s\"", tableOutput, shortOutput))
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, shortOutput)
 
 	return cmd
 }
@@ -86,13 +127,18 @@ func configureAndRunChecks(w io.Writer, options *checkOptions) error {
 		if !options.cniEnabled {
 			checks = append(checks, healthcheck.LinkerdPreInstallCapabilityChecks)
 		}
+		checks = append(checks, healthcheck.LinkerdPreInstallMeshConflictChecks)
 	} else {
 		checks = append(checks, healthcheck.LinkerdControlPlaneExistenceChecks)
 		checks = append(checks, healthcheck.LinkerdAPIChecks)
 
 		if options.dataPlaneOnly {
+			if options.waitForRollout {
+				checks = append(checks, healthcheck.LinkerdDataPlaneRolloutChecks)
+			}
 			checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
 		} else {
+			checks = append(checks, healthcheck.LinkerdControlPlaneProxyChecks)
 			checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
 		}
 	}
@@ -107,14 +153,18 @@ func configureAndRunChecks(w io.Writer, options *checkOptions) error {
 		RetryDeadline:         time.Now().Add(options.wait),
 	})
 
-	success := runChecks(w, hc)
+	exitCode := runChecks(w, hc, options.failOn, options.output)
 
 	// this empty line separates final results from the checks list in the output
 	fmt.Fprintln(w, "")
 
-	if !success {
+	if exitCode == checkExitWarnings {
+		fmt.Fprintf(w, "Status check results are %s\n", warnStatus)
+		os.Exit(exitCode)
+	}
+	if exitCode != 0 {
 		fmt.Fprintf(w, "Status check results are %s\n", failStatus)
-		os.Exit(2)
+		os.Exit(exitCode)
 	}
 
 	fmt.Fprintf(w, "Status check results are %s\n", okStatus)
@@ -126,11 +176,34 @@ func (o *checkOptions) validate() error {
 	if o.preInstallOnly && o.dataPlaneOnly {
 		return errors.New("--pre and --proxy flags are mutually exclusive")
 	}
+	if o.waitForRollout && !o.dataPlaneOnly {
+		return errors.New("--wait-for-rollout requires the --proxy flag")
+	}
+	if o.failOn != checkFailOnErrors && o.failOn != checkFailOnWarnings {
+		return fmt.Errorf("--fail-on must be one of: %s, %s", checkFailOnErrors, checkFailOnWarnings)
+	}
+	if o.output != tableOutput && o.output != shortOutput {
+		return fmt.Errorf("--output must be one of: %s, %s", tableOutput, shortOutput)
+	}
 	return nil
 }
 
-func runChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
+// runChecks prints the results of the checks to w in the given output format,
+// and returns the exit code for them, or 0 if the checks passed.
+func runChecks(w io.Writer, hc *healthcheck.HealthChecker, failOn, output string) int {
+	if output == shortOutput {
+		results := []*healthcheck.CheckResult{}
+		hc.RunChecks(func(result *healthcheck.CheckResult) {
+			if !result.Retry {
+				results = append(results, result)
+			}
+		})
+		printCheckSummaries(w, results)
+		return checkExitCode(results, failOn)
+	}
+
 	var lastCategory healthcheck.CategoryID
+	results := []*healthcheck.CheckResult{}
 	spin := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 	spin.Writer = w
 
@@ -153,6 +226,8 @@ func runChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
 			return
 		}
 
+		results = append(results, result)
+
 		status := okStatus
 		if result.Err != nil {
 			status = failStatus
@@ -170,5 +245,71 @@ func runChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
 		}
 	}
 
-	return hc.RunChecks(prettyPrintResults)
+	hc.RunChecks(prettyPrintResults)
+	return checkExitCode(results, failOn)
+}
+
+// printCheckSummaries prints a line per category of the results, with the
+// status of its worst result and the number of results of each status.
+func printCheckSummaries(w io.Writer, results []*healthcheck.CheckResult) {
+	type summary struct{ passed, warnings, failed int }
+	categories := []healthcheck.CategoryID{}
+	summaries := map[healthcheck.CategoryID]*summary{}
+
+	for _, result := range results {
+		s, ok := summaries[result.Category]
+		if !ok {
+			s = &summary{}
+			summaries[result.Category] = s
+			categories = append(categories, result.Category)
+		}
+		switch {
+		case result.Err == nil:
+			s.passed++
+		case result.Warning:
+			s.warnings++
+		default:
+			s.failed++
+		}
+	}
+
+	for _, category := range categories {
+		s := summaries[category]
+		status := okStatus
+		if s.failed > 0 {
+			status = failStatus
+		} else if s.warnings > 0 {
+			status = warnStatus
+		}
+		fmt.Fprintf(w, "%s %s: %d passed, %d warnings, %d failed\n", status, category, s.passed, s.warnings, s.failed)
+	}
+}
+
+// checkExitCode returns the exit code for the results of the checks, or 0 if
+// the checks passed.
+func checkExitCode(results []*healthcheck.CheckResult, failOn string) int {
+	failures := map[healthcheck.FailureKind]bool{}
+	warnings := false
+	for _, result := range results {
+		switch {
+		case result.Err == nil:
+		case result.Warning:
+			warnings = true
+		default:
+			failures[result.FailureKind] = true
+		}
+	}
+
+	switch {
+	case failures[healthcheck.ConnectivityFailure]:
+		return checkExitConnectivity
+	case failures[healthcheck.RBACFailure]:
+		return checkExitRBAC
+	case len(failures) > 0:
+		return checkExitFailure
+	case warnings && failOn == checkFailOnWarnings:
+		return checkExitWarnings
+	default:
+		return 0
+	}
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"testing"
@@ -24,7 +25,10 @@ func TestCheckStatus(t *testing.T) {
 		})
 
 		output := bytes.NewBufferString("")
-		runChecks(output, hc)
+		exitCode := runChecks(output, hc, checkFailOnErrors, tableOutput)
+		if exitCode != checkExitFailure {
+			t.Fatalf("Expected exit code %d, got %d", checkExitFailure, exitCode)
+		}
 
 		goldenFileBytes, err := ioutil.ReadFile("testdata/check_output.golden")
 		if err != nil {
@@ -38,3 +42,97 @@ func TestCheckStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestPrintCheckSummaries(t *testing.T) {
+	failed := errors.New("failed")
+	results := []*healthcheck.CheckResult{
+		{Category: "kubernetes-api", Description: "can initialize the client"},
+		{Category: "kubernetes-api", Description: "can query the Kubernetes API"},
+		{Category: "linkerd-version", Description: "cli is up-to-date", Err: failed, Warning: true},
+		{Category: "linkerd-api", Description: "control plane pods are ready"},
+		{Category: "linkerd-api", Description: "control plane self-check", Err: failed},
+		{Category: "linkerd-api", Description: "no invalid service profiles", Err: failed, Warning: true},
+	}
+
+	output := bytes.NewBufferString("")
+	printCheckSummaries(output, results)
+
+	expected := fmt.Sprintf(`%s kubernetes-api: 2 passed, 0 warnings, 0 failed
+%s linkerd-version: 0 passed, 1 warnings, 0 failed
+%s linkerd-api: 1 passed, 1 warnings, 1 failed
+`, okStatus, warnStatus, failStatus)
+	if output.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestCheckExitCode(t *testing.T) {
+	failed := errors.New("failed")
+
+	testCases := []struct {
+		results  []*healthcheck.CheckResult
+		failOn   string
+		exitCode int
+	}{
+		{
+			results:  []*healthcheck.CheckResult{{}},
+			failOn:   checkFailOnWarnings,
+			exitCode: 0,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, FailureKind: healthcheck.CheckFailure},
+			},
+			failOn:   checkFailOnErrors,
+			exitCode: checkExitFailure,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, FailureKind: healthcheck.CheckFailure},
+				{Err: failed, FailureKind: healthcheck.RBACFailure},
+			},
+			failOn:   checkFailOnErrors,
+			exitCode: checkExitRBAC,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, FailureKind: healthcheck.RBACFailure},
+				{Err: failed, FailureKind: healthcheck.ConnectivityFailure},
+			},
+			failOn:   checkFailOnErrors,
+			exitCode: checkExitConnectivity,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, Warning: true, FailureKind: healthcheck.CheckFailure},
+			},
+			failOn:   checkFailOnErrors,
+			exitCode: 0,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, Warning: true, FailureKind: healthcheck.CheckFailure},
+			},
+			failOn:   checkFailOnWarnings,
+			exitCode: checkExitWarnings,
+		},
+		{
+			results: []*healthcheck.CheckResult{
+				{Err: failed, Warning: true, FailureKind: healthcheck.CheckFailure},
+				{Err: failed, FailureKind: healthcheck.CheckFailure},
+			},
+			failOn:   checkFailOnWarnings,
+			exitCode: checkExitFailure,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			exitCode := checkExitCode(tc.results, tc.failOn)
+			if exitCode != tc.exitCode {
+				t.Fatalf("Expected exit code %d, got %d", tc.exitCode, exitCode)
+			}
+		})
+	}
+}
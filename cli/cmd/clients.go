@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type clientsOptions struct {
+	statOptionsBase
+}
+
+func newClientsOptions() *clientsOptions {
+	return &clientsOptions{
+		statOptionsBase: *newStatOptionsBase(),
+	}
+}
+
+func newCmdClients() *cobra.Command {
+	options := newClientsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "clients [flags] (RESOURCE)",
+		Short: "List the meshed clients sending traffic to a resource",
+		Long: `List the meshed clients sending traffic to a resource.
+
+Clients are identified by the TLS identity they presented to the resource's
+inbound proxies, so only meshed clients with identity enabled are listed.
+Services are resolved to the pods they currently select.
+
+TCP_CONNS is the number of connections the client opened in the time window.
+Clients with connections but no requests only sent raw TCP traffic, e.g. to a
+database.`,
+		Example: `  # List the clients of the web deployment in the emojivoto namespace.
+  linkerd alpha clients deploy/web -n emojivoto
+
+  # List the clients that called the web service in the last 10 minutes.
+  linkerd alpha clients svc/web -n emojivoto -t 10m`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildListClientsRequest(args[0], options)
+			if err != nil {
+				return fmt.Errorf("error creating clients request: %v", err)
+			}
+
+			output, err := requestClientsFromAPI(checkPublicAPIClientOrExit(), req, options)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Print(output)
+
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\" or \"%s\"", tableOutput, jsonOutput))
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, jsonOutput)
+
+	return cmd
+}
+
+func (o *clientsOptions) validateOutputFormat() error {
+	switch o.outputFormat {
+	case tableOutput, jsonOutput:
+		return nil
+	default:
+		return fmt.Errorf("--output currently only supports %s and %s", tableOutput, jsonOutput)
+	}
+}
+
+func buildListClientsRequest(resource string, options *clientsOptions) (*pb.ListClientsRequest, error) {
+	err := options.validateOutputFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := time.ParseDuration(options.timeWindow); err != nil {
+		return nil, err
+	}
+
+	target, err := util.BuildResource(options.namespace, resource)
+	if err != nil {
+		return nil, err
+	}
+	if target.GetName() == "" {
+		return nil, errors.New("a resource name is required, e.g. deploy/web")
+	}
+	if target.GetType() == k8s.Authority {
+		return nil, errors.New("authorities have no inbound proxies to list clients from")
+	}
+
+	return &pb.ListClientsRequest{
+		Resource:   &target,
+		TimeWindow: options.timeWindow,
+	}, nil
+}
+
+func requestClientsFromAPI(client pb.ApiClient, req *pb.ListClientsRequest, options *clientsOptions) (string, error) {
+	resp, err := client.ListClients(context.Background(), req)
+	if err != nil {
+		return "", fmt.Errorf("ListClients API error: %v", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return "", errors.New(e.Error)
+	}
+
+	clients := resp.GetOk().GetClients()
+	if len(clients) == 0 && options.outputFormat == tableOutput {
+		return "No meshed clients found.\n", nil
+	}
+
+	return renderClients(clients, options), nil
+}
+
+func renderClients(clients []*pb.Client, options *clientsOptions) string {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	writeClientsToBuffer(clients, w, options)
+	w.Flush()
+
+	return renderStats(buffer, &options.statOptionsBase)
+}
+
+func writeClientsToBuffer(clients []*pb.Client, w *tabwriter.Writer, options *clientsOptions) {
+	switch options.outputFormat {
+	case tableOutput:
+		printClientsTable(clients, w)
+	case jsonOutput:
+		printClientsJSON(clients, w)
+	}
+}
+
+func printClientsTable(clients []*pb.Client, w *tabwriter.Writer) {
+	identityWidth := len("IDENTITY")
+	namespaceWidth := len("NAMESPACE")
+	for _, client := range clients {
+		if len(client.GetIdentity()) > identityWidth {
+			identityWidth = len(client.GetIdentity())
+		}
+		if len(client.GetNamespace()) > namespaceWidth {
+			namespaceWidth = len(client.GetNamespace())
+		}
+	}
+	// left-align the identity and namespace columns
+	templateString := fmt.Sprintf("%%-%ds\t%%-%ds\t", identityWidth, namespaceWidth)
+
+	fmt.Fprintf(w, templateString+"RPS\tTCP_CONNS\t\n", "IDENTITY", "NAMESPACE")
+	for _, client := range clients {
+		namespace := client.GetNamespace()
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(w, templateString+"%.1frps\t%d\t\n",
+			client.GetIdentity(),
+			namespace,
+			getRequestRate(client.GetRequestCount(), 0, client.GetTimeWindow()),
+			client.GetTcpConnectionCount(),
+		)
+	}
+}
+
+type jsonClient struct {
+	Identity       string  `json:"identity"`
+	Namespace      string  `json:"namespace"`
+	Rps            float64 `json:"rps"`
+	TCPConnections uint64  `json:"tcpConnections"`
+}
+
+func printClientsJSON(clients []*pb.Client, w *tabwriter.Writer) {
+	// avoid nil initialization so that if there are no clients it gets marshalled as an empty array vs null
+	entries := []*jsonClient{}
+	for _, client := range clients {
+		entries = append(entries, &jsonClient{
+			Identity:       client.GetIdentity(),
+			Namespace:      client.GetNamespace(),
+			Rps:            getRequestRate(client.GetRequestCount(), 0, client.GetTimeWindow()),
+			TCPConnections: client.GetTcpConnectionCount(),
+		})
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestClients(t *testing.T) {
+	response := &pb.ListClientsResponse{
+		Response: &pb.ListClientsResponse_Ok_{
+			Ok: &pb.ListClientsResponse_Ok{
+				Clients: []*pb.Client{
+					{
+						Identity:           "admin.ops.serviceaccount.identity.linkerd.cluster.local",
+						Namespace:          "ops",
+						RequestCount:       30,
+						TimeWindow:         "1m",
+						TcpConnectionCount: 2,
+					},
+					{
+						Identity:           "postgres.db.serviceaccount.identity.linkerd.cluster.local",
+						Namespace:          "db",
+						TimeWindow:         "1m",
+						TcpConnectionCount: 4,
+					},
+					{
+						Identity:           "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+						Namespace:          "emojivoto",
+						RequestCount:       1200,
+						TimeWindow:         "1m",
+						TcpConnectionCount: 12,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		outputFormat string
+		file         string
+	}{
+		{tableOutput, "clients_output.golden"},
+		{jsonOutput, "clients_output_json.golden"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.outputFormat, func(t *testing.T) {
+			options := newClientsOptions()
+			options.outputFormat = tc.outputFormat
+
+			req, err := buildListClientsRequest("deploy/web", options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			mockClient := &public.MockAPIClient{ListClientsResponseToReturn: response}
+			output, err := requestClientsFromAPI(mockClient, req, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			diffTestdata(t, tc.file, output)
+		})
+	}
+
+	t.Run("Requires a named resource", func(t *testing.T) {
+		_, err := buildListClientsRequest("deploy", newClientsOptions())
+		expected := "a resource name is required, e.g. deploy/web"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s] instead got [%s]", expected, err)
+		}
+	})
+}
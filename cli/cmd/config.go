@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
+)
+
+// cliConfig holds the defaults read from the CLI configuration file. Flags
+// passed on the command line take precedence over them.
+type cliConfig struct {
+	Context          string `json:"context,omitempty"`
+	LinkerdNamespace string `json:"linkerdNamespace,omitempty"`
+	Output           string `json:"output,omitempty"`
+	APIAddr          string `json:"apiAddr,omitempty"`
+
+	// Flags holds defaults for any other flag, keyed by the flag's name, e.g.
+	// "success-rate-warn" for `linkerd stat`.
+	Flags map[string]interface{} `json:"flags,omitempty"`
+}
+
+// configPath returns the path of the CLI configuration file, which may be
+// overridden with the LINKERD_CONFIG environment variable.
+func configPath() string {
+	if path := os.Getenv("LINKERD_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(homedir.HomeDir(), ".linkerd", "config.yaml")
+}
+
+// readCLIConfig reads the CLI configuration file at the given path. A missing
+// file is treated as an empty configuration.
+func readCLIConfig(path string) (*cliConfig, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &cliConfig{}, nil
+		}
+		return nil, err
+	}
+
+	var config cliConfig
+	if err := yaml.UnmarshalStrict(bytes, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	return &config, nil
+}
+
+// defaults returns the flag defaults held by the configuration, keyed by flag
+// name.
+func (c *cliConfig) defaults() map[string]string {
+	defaults := map[string]string{}
+	for name, value := range c.Flags {
+		defaults[name] = fmt.Sprint(value)
+	}
+
+	named := map[string]string{
+		"context":           c.Context,
+		"linkerd-namespace": c.LinkerdNamespace,
+		"output":            c.Output,
+		"api-addr":          c.APIAddr,
+	}
+	for name, value := range named {
+		if value != "" {
+			defaults[name] = value
+		}
+	}
+	return defaults
+}
+
+// outputFormatsAnnotation annotates the --output flag of a command with the
+// output formats the command supports.
+const outputFormatsAnnotation = "linkerd.io/output-formats"
+
+// setOutputFormats records the output formats the --output flag of a command
+// supports, so that the output default of the configuration only applies to
+// the commands that support it.
+func setOutputFormats(flags *pflag.FlagSet, formats ...string) {
+	flags.SetAnnotation("output", outputFormatsAnnotation, formats)
+}
+
+// applyTo sets the flags that weren't passed on the command line to the
+// configuration's defaults. Defaults for flags the command doesn't have are
+// ignored, and so is the output default for commands that don't support that
+// format, so that e.g. `output: wide` applies to `linkerd stat` but not to
+// `linkerd check`.
+func (c *cliConfig) applyTo(flags *pflag.FlagSet) error {
+	defaults := c.defaults()
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if name == "output" && !supportsOutputFormat(flag, defaults[name]) {
+			continue
+		}
+		// Setting the value directly leaves the flag marked as unchanged, so
+		// that environment variables still take precedence over the file.
+		if err := flag.Value.Set(defaults[name]); err != nil {
+			return fmt.Errorf("invalid value %q for %s in %s: %s", defaults[name], name, configPath(), err)
+		}
+	}
+	return nil
+}
+
+func supportsOutputFormat(flag *pflag.Flag, format string) bool {
+	for _, f := range flag.Annotations[outputFormatsAnnotation] {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestReadCLIConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-config")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("Treats a missing file as empty", func(t *testing.T) {
+		config, err := readCLIConfig(filepath.Join(dir, "missing.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(config.defaults()) != 0 {
+			t.Fatalf("Expected no defaults, got %v", config.defaults())
+		}
+	})
+
+	t.Run("Reads named and generic defaults", func(t *testing.T) {
+		path := filepath.Join(dir, "config.yaml")
+		contents := `context: prod
+linkerdNamespace: linkerd-prod
+flags:
+  success-rate-warn: 99.9
+  latency-fail: 250ms
+`
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		config, err := readCLIConfig(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := map[string]string{
+			"context":           "prod",
+			"linkerd-namespace": "linkerd-prod",
+			"success-rate-warn": "99.9",
+			"latency-fail":      "250ms",
+		}
+		defaults := config.defaults()
+		if len(defaults) != len(expected) {
+			t.Fatalf("Expected defaults %v, got %v", expected, defaults)
+		}
+		for name, value := range expected {
+			if defaults[name] != value {
+				t.Fatalf("Expected %s to default to %q, got %q", name, value, defaults[name])
+			}
+		}
+	})
+
+	t.Run("Rejects unknown keys", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.yaml")
+		if err := ioutil.WriteFile(path, []byte("namespace: linkerd-prod\n"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if _, err := readCLIConfig(path); err == nil {
+			t.Fatal("Expected an error for an unknown key")
+		}
+	})
+}
+
+func TestCLIConfigApplyTo(t *testing.T) {
+	config := &cliConfig{
+		LinkerdNamespace: "linkerd-prod",
+		Output:           wideOutput,
+		Flags: map[string]interface{}{
+			"success-rate-warn": 99.9,
+		},
+	}
+
+	var namespace, output string
+	var successRateWarn float64
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&namespace, "linkerd-namespace", defaultNamespace, "")
+	flags.StringVar(&output, "output", tableOutput, "")
+	flags.Float64Var(&successRateWarn, "success-rate-warn", 99, "")
+	if err := flags.Parse([]string{"--output", jsonOutput}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if err := config.applyTo(flags); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if namespace != "linkerd-prod" {
+		t.Errorf("Expected namespace to be set from the config, got %s", namespace)
+	}
+	if output != jsonOutput {
+		t.Errorf("Expected the output flag to take precedence over the config, got %s", output)
+	}
+	if successRateWarn != 99.9 {
+		t.Errorf("Expected success-rate-warn to be set from the config, got %f", successRateWarn)
+	}
+	if flags.Changed("linkerd-namespace") {
+		t.Error("Expected linkerd-namespace to remain unchanged so that $LINKERD_NAMESPACE takes precedence")
+	}
+
+	// The output default only applies to the commands that support it.
+	testCases := []struct {
+		formats  []string
+		expected string
+	}{
+		{[]string{tableOutput, wideOutput}, wideOutput},
+		{[]string{tableOutput, shortOutput}, tableOutput},
+		{nil, tableOutput},
+	}
+	for _, tc := range testCases {
+		output = tableOutput
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringVar(&output, "output", tableOutput, "")
+		if tc.formats != nil {
+			setOutputFormats(flags, tc.formats...)
+		}
+		if err := config.applyTo(flags); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if output != tc.expected {
+			t.Errorf("Expected output %s for the formats %v, got %s", tc.expected, tc.formats, output)
+		}
+	}
+
+	config.Flags["success-rate-warn"] = "high"
+	successRateWarn = 99
+	if err := config.applyTo(flags); err == nil {
+		t.Fatal("Expected an error for an invalid flag value")
+	}
+}
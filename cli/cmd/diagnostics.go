@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	prometheusContainerName = "prometheus"
+	timestampHeader         = "TIMESTAMP"
+	valueHeader             = "VALUE"
+)
+
+type diagnosticsQueryOptions struct {
+	time         string
+	queryRange   time.Duration
+	step         time.Duration
+	timeout      time.Duration
+	outputFormat string
+}
+
+func newDiagnosticsQueryOptions() *diagnosticsQueryOptions {
+	return &diagnosticsQueryOptions{
+		time:         "",
+		queryRange:   0,
+		step:         15 * time.Second,
+		timeout:      30 * time.Second,
+		outputFormat: tableOutput,
+	}
+}
+
+// validate performs all validation on the command-line options.
+// It returns the first error encountered, or `nil` if the options are valid.
+func (o *diagnosticsQueryOptions) validate() error {
+	if o.outputFormat != tableOutput && o.outputFormat != jsonOutput {
+		return fmt.Errorf("--output currently only supports %s and %s", tableOutput, jsonOutput)
+	}
+	if o.time != "" {
+		if _, err := time.Parse(time.RFC3339, o.time); err != nil {
+			return fmt.Errorf("--time must be an RFC 3339 timestamp: %s", err)
+		}
+	}
+	if o.queryRange < 0 {
+		return fmt.Errorf("--range must not be negative")
+	}
+	if o.queryRange > 0 && o.step <= 0 {
+		return fmt.Errorf("--step must be positive")
+	}
+	return nil
+}
+
+// evaluationTime returns the time the query is evaluated at, or the end of the
+// range for range queries.
+func (o *diagnosticsQueryOptions) evaluationTime() time.Time {
+	if o.time == "" {
+		return time.Now()
+	}
+	t, _ := time.Parse(time.RFC3339, o.time)
+	return t
+}
+
+func newCmdDiagnostics() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnostics [flags]",
+		Short: "Commands used to diagnose Linkerd components",
+		Long: `Commands used to diagnose Linkerd components.
+
+These commands reach into the control plane directly, and are meant for
+debugging Linkerd itself rather than the applications it meshes.`,
+	}
+
+	cmd.AddCommand(newCmdDiagnosticsQuery())
+
+	return cmd
+}
+
+func newCmdDiagnosticsQuery() *cobra.Command {
+	options := newDiagnosticsQueryOptions()
+
+	cmd := &cobra.Command{
+		Use:   "query [flags] QUERY",
+		Short: "Run a PromQL query against the Linkerd Prometheus",
+		Long: `Run a PromQL query against the Linkerd Prometheus.
+
+The query is sent to the control plane's Prometheus through a port-forward.
+Instant queries print one row per series, and range queries, enabled with
+--range, print one row per sample.`,
+		Example: `  # Show the request rate of each meshed deployment
+  linkerd diagnostics query 'sum(rate(request_total[1m])) by (namespace, deployment)'
+
+  # Show the samples of the last 5 minutes, every 30 seconds, as JSON
+  linkerd diagnostics query --range 5m --step 30s -o json 'sum(rate(request_total[1m]))'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			api, stop, err := newPrometheusForward()
+			if err != nil {
+				return err
+			}
+			defer stop()
+
+			ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+			defer cancel()
+
+			value, err := options.query(ctx, api, args[0])
+			if err != nil {
+				return fmt.Errorf("Prometheus query failed: %s", err)
+			}
+
+			return renderPromValue(value, options.outputFormat, stdout)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.time, "time", options.time, "Evaluation time of the query, or end of the range with --range, as an RFC 3339 timestamp (default: now)")
+	cmd.PersistentFlags().DurationVar(&options.queryRange, "range", options.queryRange, "Run a range query over this duration, ending at --time (default: run an instant query)")
+	cmd.PersistentFlags().DurationVar(&options.step, "step", options.step, "Resolution of range queries")
+	cmd.PersistentFlags().DurationVar(&options.timeout, "timeout", options.timeout, "Maximum time to wait for the query to complete")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\" or \"%s\"", tableOutput, jsonOutput))
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, jsonOutput)
+
+	return cmd
+}
+
+func (o *diagnosticsQueryOptions) query(ctx context.Context, api promv1.API, query string) (model.Value, error) {
+	end := o.evaluationTime()
+	if o.queryRange == 0 {
+		return api.Query(ctx, query, end)
+	}
+	return api.QueryRange(ctx, query, promv1.Range{
+		Start: end.Add(-o.queryRange),
+		End:   end,
+		Step:  o.step,
+	})
+}
+
+// newPrometheusForward port-forwards to the control plane's Prometheus,
+// returning a client for it and a function closing the port-forward.
+func newPrometheusForward() (promv1.API, func(), error) {
+	config, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	podList, err := clientset.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pods := adminContainerPods(podList.Items, prometheusContainerName)
+	if len(pods) == 0 {
+		return nil, nil, fmt.Errorf("no running Prometheus pod found in the %s namespace", controlPlaneNamespace)
+	}
+
+	portforward, err := k8s.NewControllerAdminForward(config, clientset, pods[0], prometheusContainerName, verbose)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go func() {
+		err := portforward.Run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running port-forward: %s", err)
+			portforward.Stop()
+		}
+	}()
+
+	<-portforward.Ready()
+
+	client, err := promApi.NewClient(promApi.Config{Address: portforward.URLFor("")})
+	if err != nil {
+		portforward.Stop()
+		return nil, nil, err
+	}
+	return promv1.NewAPI(client), portforward.Stop, nil
+}
+
+func renderPromValue(value model.Value, outputFormat string, w io.Writer) error {
+	if outputFormat == jsonOutput {
+		// Mirror the data returned by the Prometheus HTTP API.
+		out, err := json.MarshalIndent(map[string]interface{}{
+			"resultType": value.Type().String(),
+			"result":     value,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	}
+
+	var rows [][]string
+	switch v := value.(type) {
+	case *model.Scalar:
+		rows = [][]string{
+			{timestampHeader, valueHeader},
+			{formatPromTime(v.Timestamp), v.Value.String()},
+		}
+	case *model.String:
+		rows = [][]string{
+			{timestampHeader, valueHeader},
+			{formatPromTime(v.Timestamp), v.Value},
+		}
+	case model.Vector:
+		if len(v) == 0 {
+			fmt.Fprintln(stderr, "No results found.")
+			return nil
+		}
+		sort.Slice(v, func(i, j int) bool { return v[i].Metric.String() < v[j].Metric.String() })
+
+		metrics := make([]model.Metric, len(v))
+		for i, sample := range v {
+			metrics[i] = sample.Metric
+		}
+		labels := promLabelNames(metrics)
+
+		rows = append(rows, append(promLabelHeaders(labels), valueHeader))
+		for _, sample := range v {
+			rows = append(rows, append(promLabelValues(sample.Metric, labels), sample.Value.String()))
+		}
+	case model.Matrix:
+		if len(v) == 0 {
+			fmt.Fprintln(stderr, "No results found.")
+			return nil
+		}
+		sort.Slice(v, func(i, j int) bool { return v[i].Metric.String() < v[j].Metric.String() })
+
+		metrics := make([]model.Metric, len(v))
+		for i, stream := range v {
+			metrics[i] = stream.Metric
+		}
+		labels := promLabelNames(metrics)
+
+		rows = append(rows, append(promLabelHeaders(labels), timestampHeader, valueHeader))
+		for _, stream := range v {
+			for _, sample := range stream.Values {
+				rows = append(rows, append(promLabelValues(stream.Metric, labels), formatPromTime(sample.Timestamp), sample.Value.String()))
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported result type: %s", value.Type())
+	}
+
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+	_, err := buffer.WriteTo(w)
+	return err
+}
+
+// promLabelNames returns the sorted names of the labels set on any of the
+// given metrics.
+func promLabelNames(metrics []model.Metric) []string {
+	names := map[string]struct{}{}
+	for _, metric := range metrics {
+		for name := range metric {
+			names[string(name)] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func promLabelHeaders(labels []string) []string {
+	headers := make([]string, len(labels))
+	for i, label := range labels {
+		if label == model.MetricNameLabel {
+			label = "metric"
+		}
+		headers[i] = strings.ToUpper(label)
+	}
+	return headers
+}
+
+// promLabelValues returns the values of the given labels of the metric, with
+// "-" for the labels it doesn't have.
+func promLabelValues(metric model.Metric, labels []string) []string {
+	values := make([]string, len(labels))
+	for i, label := range labels {
+		values[i] = "-"
+		if value, ok := metric[model.LabelName(label)]; ok {
+			values[i] = string(value)
+		}
+	}
+	return values
+}
+
+func formatPromTime(t model.Time) string {
+	return t.Time().UTC().Format(time.RFC3339)
+}
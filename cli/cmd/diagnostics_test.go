@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestRenderPromValue(t *testing.T) {
+	timestamp := model.TimeFromUnix(1554076800)
+
+	testCases := []struct {
+		name         string
+		value        model.Value
+		outputFormat string
+		expected     string
+	}{
+		{
+			name:         "scalar",
+			value:        &model.Scalar{Value: 1.5, Timestamp: timestamp},
+			outputFormat: tableOutput,
+			expected: `TIMESTAMP              VALUE
+2019-04-01T00:00:00Z   1.5
+`,
+		},
+		{
+			name: "vector",
+			value: model.Vector{
+				{Metric: model.Metric{"deployment": "web", "namespace": "emojivoto"}, Value: 2, Timestamp: timestamp},
+				{Metric: model.Metric{"deployment": "emoji"}, Value: 0.25, Timestamp: timestamp},
+			},
+			outputFormat: tableOutput,
+			expected: `DEPLOYMENT   NAMESPACE   VALUE
+emoji        -           0.25
+web          emojivoto   2
+`,
+		},
+		{
+			name: "vector without labels",
+			value: model.Vector{
+				{Metric: model.Metric{}, Value: 3, Timestamp: timestamp},
+			},
+			outputFormat: tableOutput,
+			expected: `VALUE
+3
+`,
+		},
+		{
+			name: "matrix",
+			value: model.Matrix{
+				{
+					Metric: model.Metric{model.MetricNameLabel: "request_total"},
+					Values: []model.SamplePair{
+						{Timestamp: timestamp, Value: 1},
+						{Timestamp: timestamp.Add(30 * time.Second), Value: 4},
+					},
+				},
+			},
+			outputFormat: tableOutput,
+			expected: `METRIC          TIMESTAMP              VALUE
+request_total   2019-04-01T00:00:00Z   1
+request_total   2019-04-01T00:00:30Z   4
+`,
+		},
+		{
+			name: "json",
+			value: model.Vector{
+				{Metric: model.Metric{"deployment": "web"}, Value: 2, Timestamp: timestamp},
+			},
+			outputFormat: jsonOutput,
+			expected: `{
+  "result": [
+    {
+      "metric": {
+        "deployment": "web"
+      },
+      "value": [
+        1554076800,
+        "2"
+      ]
+    }
+  ],
+  "resultType": "vector"
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderPromValue(tc.value, tc.outputFormat, &buf); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("Expected:\n%s\nGot:\n%s", tc.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestDiagnosticsQueryOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		options  *diagnosticsQueryOptions
+		expected string
+	}{
+		{&diagnosticsQueryOptions{outputFormat: wideOutput}, "--output currently only supports table and json"},
+		{&diagnosticsQueryOptions{outputFormat: tableOutput, time: "yesterday"}, `--time must be an RFC 3339 timestamp: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`},
+		{&diagnosticsQueryOptions{outputFormat: tableOutput, queryRange: time.Minute}, "--step must be positive"},
+		{&diagnosticsQueryOptions{outputFormat: jsonOutput, queryRange: time.Minute, step: time.Second, time: "2019-04-01T00:00:00Z"}, ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.expected, func(t *testing.T) {
+			err := tc.options.validate()
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/events"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const eventsLineFormat = "%-20s  %-13s  %-7s  %-16s  %-40s  %-20s  %s\n"
+
+var eventCategories = []string{
+	events.CategoryInjection,
+	events.CategoryIdentity,
+	events.CategoryWebhook,
+	events.CategoryConfig,
+	events.CategoryControlPlane,
+}
+
+type eventsOptions struct {
+	since      time.Duration
+	watch      bool
+	categories []string
+}
+
+func newEventsOptions() *eventsOptions {
+	return &eventsOptions{
+		since:      time.Hour,
+		watch:      false,
+		categories: []string{},
+	}
+}
+
+func (o *eventsOptions) validate() error {
+	for _, category := range o.categories {
+		if !containsString(eventCategories, category) {
+			return fmt.Errorf("invalid category %q, must be one of: %s", category, strings.Join(eventCategories, ", "))
+		}
+	}
+	return nil
+}
+
+func newCmdEvents() *cobra.Command {
+	options := newEventsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "events [flags]",
+		Short: "Display a timeline of the events of the mesh",
+		Long: `Display a timeline of the events of the mesh.
+
+The Kubernetes Events relevant to Linkerd are gathered from all namespaces,
+oldest first, and categorized as:
+  * injection: pods skipped by the proxy injector, injection paused or resumed
+  * identity: issuer certificates loaded by the identity service
+  * webhook: requests the proxy injector or service profile validator failed
+  * config: control plane configuration reloaded or rejected
+  * control-plane: other events recorded by Linkerd, and warnings about the
+    control plane's resources`,
+		Example: `  # Events of the last hour
+  linkerd events
+
+  # Stream the identity and webhook events of the last day, and the new ones
+  linkerd events --since 24h --category identity --category webhook -w`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			client, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+			return streamEvents(context.Background(), stdout, client, controlPlaneNamespace, time.Now(), options)
+		},
+	}
+
+	cmd.PersistentFlags().DurationVar(&options.since, "since", options.since, "Only display the events more recent than this duration")
+	cmd.PersistentFlags().BoolVarP(&options.watch, "watch", "w", options.watch, "Keep streaming new events after displaying the recent ones")
+	cmd.PersistentFlags().StringArrayVar(&options.categories, "category", options.categories, fmt.Sprintf("Only display the events of this category (%s); can be repeated", strings.Join(eventCategories, ", ")))
+
+	return cmd
+}
+
+func streamEvents(ctx context.Context, w io.Writer, client kubernetes.Interface, controllerNamespace string, now time.Time, options *eventsOptions) error {
+	fmt.Fprintf(w, eventsLineFormat, "TIME", "CATEGORY", "TYPE", "NAMESPACE", "OBJECT", "REASON", "MESSAGE")
+	return events.Stream(ctx, client, controllerNamespace, now.Add(-options.since), options.watch, func(e *events.Event) error {
+		if len(options.categories) > 0 && !containsString(options.categories, e.Category) {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, eventsLineFormat,
+			e.Timestamp.UTC().Format(time.RFC3339), e.Category, e.Type, e.Namespace, e.Object, e.Reason, e.Message)
+		return err
+	})
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
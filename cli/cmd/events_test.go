@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStreamEvents(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	newEvent := func(name, namespace, reason string, ts time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name, Namespace: namespace},
+			Reason:         reason,
+			Message:        reason + " message",
+			Type:           corev1.EventTypeNormal,
+			Source:         corev1.EventSource{Component: "linkerd-proxy-injector"},
+			LastTimestamp:  metav1.NewTime(ts),
+		}
+	}
+	client := newMeshTestClient(
+		newEvent("web", "emojivoto", "InjectionSkipped", now.Add(-time.Minute)),
+		newEvent("linkerd-config", "linkerd", "ConfigReloaded", now.Add(-2*time.Minute)),
+		newEvent("vote-bot", "emojivoto", "InjectionSkipped", now.Add(-2*time.Hour)),
+	)
+
+	testCases := []struct {
+		categories []string
+		expected   []string
+	}{
+		{[]string{}, []string{"pod/linkerd-config", "pod/web"}},
+		{[]string{"injection"}, []string{"pod/web"}},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		options := newEventsOptions()
+		options.categories = tc.categories
+
+		var buf bytes.Buffer
+		if err := streamEvents(context.Background(), &buf, client, "linkerd", now, options); err != nil {
+			t.Fatalf("test %d: Unexpected error: %s", i, err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != len(tc.expected)+1 {
+			t.Fatalf("test %d: Expected %d events, got:\n%s", i, len(tc.expected), buf.String())
+		}
+		for j, object := range tc.expected {
+			if !strings.Contains(lines[j+1], object) {
+				t.Fatalf("test %d: Expected line %d to contain [%s], got [%s]", i, j+1, object, lines[j+1])
+			}
+		}
+	}
+}
+
+func TestEventsOptionsValidate(t *testing.T) {
+	options := newEventsOptions()
+	options.categories = []string{"identity", "nope"}
+	if err := options.validate(); err == nil || !strings.Contains(err.Error(), `invalid category "nope"`) {
+		t.Fatalf("Expected an invalid category error, got %v", err)
+	}
+}
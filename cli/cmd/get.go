@@ -11,15 +11,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// meshedResource is the argument of `linkerd get` listing the inventory of the
+// meshed workloads.
+const meshedResource = "meshed"
+
 type getOptions struct {
 	namespace     string
 	allNamespaces bool
+	outputFormat  string
 }
 
 func newGetOptions() *getOptions {
 	return &getOptions{
 		namespace:     "default",
 		allNamespaces: false,
+		outputFormat:  tableOutput,
 	}
 }
 
@@ -27,18 +33,27 @@ func newCmdGet() *cobra.Command {
 	options := newGetOptions()
 
 	cmd := &cobra.Command{
-		Use:   "get [flags] pods",
+		Use:   "get [flags] (pods|meshed)",
 		Short: "Display one or many mesh resources",
 		Long: `Display one or many mesh resources.
 
-Only pod resources (aka pods, po) are supported.`,
+Pod resources (aka pods, po) are listed by name.
+
+The "meshed" resource is the inventory of the meshed workloads, for compliance
+reports and fleet dashboards: the namespace, kind and name of each workload
+with meshed pods, with the versions of their proxies, whether their traffic is
+secured with mTLS, and the proxy configuration overridden by their
+annotations.`,
 		Example: `  # get all pods
   linkerd get pods
 
   # get pods from namespace linkerd
-  linkerd get pods --namespace linkerd`,
+  linkerd get pods --namespace linkerd
+
+  # export the inventory of the meshed workloads of all namespaces
+  linkerd get meshed --all-namespaces -o yaml`,
 		Args:      cobra.ExactArgs(1),
-		ValidArgs: []string{k8s.Pod},
+		ValidArgs: []string{k8s.Pod, meshedResource},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("please specify a resource type")
@@ -49,10 +64,21 @@ Only pod resources (aka pods, po) are supported.`,
 			}
 
 			friendlyName := args[0]
+			if friendlyName == meshedResource {
+				if err := options.validateMeshedOutput(); err != nil {
+					return err
+				}
+				client, err := newKubernetesClient()
+				if err != nil {
+					return err
+				}
+				return getMeshed(stdout, client, controlPlaneNamespace, options)
+			}
+
 			resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(friendlyName)
 
 			if err != nil || resourceType != k8s.Pod {
-				return fmt.Errorf("invalid resource type %s, valid types: %s", friendlyName, k8s.Pod)
+				return fmt.Errorf("invalid resource type %s, valid types: %s, %s", friendlyName, k8s.Pod, meshedResource)
 			}
 
 			podNames, err := getPods(checkPublicAPIClientOrExit(), options)
@@ -75,6 +101,8 @@ Only pod resources (aka pods, po) are supported.`,
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of pods")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns pods across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format of the meshed inventory; one of: \"%s\", \"%s\" or \"%s\"", tableOutput, jsonOutput, yamlOutput))
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, jsonOutput, yamlOutput)
 	return cmd
 }
 
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	mtlsEnabled  = "enabled"
+	mtlsDisabled = "disabled"
+	mtlsPartial  = "partial"
+)
+
+// workloadLabels are the labels the proxy injector adds to meshed pods to
+// identify their workload, most specific first.
+var workloadLabels = []struct {
+	kind  string
+	label string
+}{
+	{k8s.Deployment, k8s.ProxyDeploymentLabel},
+	{k8s.StatefulSet, k8s.ProxyStatefulSetLabel},
+	{k8s.DaemonSet, k8s.ProxyDaemonSetLabel},
+	{k8s.Job, k8s.ProxyJobLabel},
+	{k8s.ReplicationController, k8s.ProxyReplicationControllerLabel},
+	{k8s.ReplicaSet, k8s.ProxyReplicaSetLabel},
+}
+
+// meshedWorkload is an entry of the inventory of the meshed workloads. MTLS is
+// "partial" when only some of the workload's proxies have an identity, e.g.
+// during a rollout.
+type meshedWorkload struct {
+	Namespace      string            `json:"namespace"`
+	Kind           string            `json:"kind"`
+	Name           string            `json:"name"`
+	Pods           int               `json:"pods"`
+	ProxyVersions  []string          `json:"proxyVersions"`
+	MTLS           string            `json:"mtls"`
+	ProxyOverrides map[string]string `json:"proxyOverrides"`
+}
+
+func (options *getOptions) validateMeshedOutput() error {
+	switch options.outputFormat {
+	case tableOutput, jsonOutput, yamlOutput:
+		return nil
+	default:
+		return fmt.Errorf("--output currently only supports %s, %s and %s", tableOutput, jsonOutput, yamlOutput)
+	}
+}
+
+func getMeshed(w io.Writer, client kubernetes.Interface, controllerNamespace string, options *getOptions) error {
+	namespace := options.namespace
+	if options.allNamespaces {
+		namespace = corev1.NamespaceAll
+	}
+
+	workloads, err := meshedWorkloads(client, namespace, controllerNamespace)
+	if err != nil {
+		return err
+	}
+
+	switch options.outputFormat {
+	case jsonOutput:
+		out, err := json.MarshalIndent(workloads, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	case yamlOutput:
+		out, err := yaml.Marshal(workloads)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		if len(workloads) == 0 {
+			fmt.Fprintln(stderr, "No resources found.")
+			return nil
+		}
+		return printMeshedTable(w, workloads)
+	}
+}
+
+// meshedWorkloads returns the inventory of the workloads of the namespace with
+// pods meshed by the control plane, sorted by namespace, kind and name. Pods
+// without a known workload are listed on their own.
+func meshedWorkloads(client kubernetes.Interface, namespace, controllerNamespace string) ([]*meshedWorkload, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Pods are visited from the oldest to the most recent, so that the
+	// overrides of the most recent pods of the workload win.
+	sort.Slice(pods.Items, func(i, j int) bool {
+		a, b := pods.Items[i], pods.Items[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+
+	byWorkload := map[string]*meshedWorkload{}
+	secured := map[string]int{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !k8s.IsMeshed(pod, controllerNamespace) {
+			continue
+		}
+
+		kind, name := podWorkload(pod)
+		key := fmt.Sprintf("%s/%s/%s", pod.Namespace, kind, name)
+		workload, ok := byWorkload[key]
+		if !ok {
+			workload = &meshedWorkload{
+				Namespace:      pod.Namespace,
+				Kind:           kind,
+				Name:           name,
+				ProxyVersions:  []string{},
+				ProxyOverrides: map[string]string{},
+			}
+			byWorkload[key] = workload
+		}
+
+		workload.Pods++
+		if version := pod.Annotations[k8s.ProxyVersionAnnotation]; version != "" && !containsString(workload.ProxyVersions, version) {
+			workload.ProxyVersions = append(workload.ProxyVersions, version)
+		}
+		if pod.Annotations[k8s.IdentityModeAnnotation] == k8s.IdentityModeDefault {
+			secured[key]++
+		}
+		for annotation, value := range pod.Annotations {
+			if strings.HasPrefix(annotation, k8s.ProxyConfigAnnotationsPrefix+"/") {
+				workload.ProxyOverrides[annotation] = value
+			}
+		}
+	}
+
+	workloads := []*meshedWorkload{}
+	for key, workload := range byWorkload {
+		sort.Strings(workload.ProxyVersions)
+		switch secured[key] {
+		case workload.Pods:
+			workload.MTLS = mtlsEnabled
+		case 0:
+			workload.MTLS = mtlsDisabled
+		default:
+			workload.MTLS = mtlsPartial
+		}
+		workloads = append(workloads, workload)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		a, b := workloads[i], workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return workloads, nil
+}
+
+func podWorkload(pod *corev1.Pod) (string, string) {
+	for _, w := range workloadLabels {
+		if name := pod.Labels[w.label]; name != "" {
+			return w.kind, name
+		}
+	}
+	return k8s.Pod, pod.Name
+}
+
+func printMeshedTable(w io.Writer, workloads []*meshedWorkload) error {
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tWORKLOAD\tPODS\tPROXY VERSIONS\tMTLS\tOVERRIDES")
+	for _, workload := range workloads {
+		fmt.Fprintf(tw, "%s\t%s/%s\t%d\t%s\t%s\t%d\n",
+			workload.Namespace,
+			workload.Kind,
+			workload.Name,
+			workload.Pods,
+			strings.Join(workload.ProxyVersions, ","),
+			workload.MTLS,
+			len(workload.ProxyOverrides),
+		)
+	}
+	return tw.Flush()
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetPods(t *testing.T) {
@@ -68,3 +72,67 @@ func TestGetPods(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMeshed(t *testing.T) {
+	newPod := func(name string, labels, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "emojivoto",
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+	}
+	client := newMeshTestClient(
+		newPod("web-1",
+			map[string]string{k8s.ControllerNSLabel: "linkerd", k8s.ProxyDeploymentLabel: "web", k8s.ProxyReplicaSetLabel: "web-5f86686c4d"},
+			map[string]string{k8s.ProxyVersionAnnotation: "stable-2.5.0", k8s.IdentityModeAnnotation: k8s.IdentityModeDefault, k8s.ProxyCPURequestAnnotation: "100m"}),
+		newPod("web-2",
+			map[string]string{k8s.ControllerNSLabel: "linkerd", k8s.ProxyDeploymentLabel: "web"},
+			map[string]string{k8s.ProxyVersionAnnotation: "stable-2.6.0", k8s.IdentityModeAnnotation: k8s.IdentityModeDisabled}),
+		newPod("vote-bot",
+			map[string]string{k8s.ControllerNSLabel: "linkerd"},
+			map[string]string{k8s.ProxyVersionAnnotation: "stable-2.6.0", k8s.IdentityModeAnnotation: k8s.IdentityModeDefault}),
+		newPod("emoji",
+			map[string]string{k8s.ProxyDeploymentLabel: "emoji"},
+			map[string]string{}),
+	)
+
+	expected := `- kind: deployment
+  mtls: partial
+  name: web
+  namespace: emojivoto
+  pods: 2
+  proxyOverrides:
+    config.linkerd.io/proxy-cpu-request: 100m
+  proxyVersions:
+  - stable-2.5.0
+  - stable-2.6.0
+- kind: pod
+  mtls: enabled
+  name: vote-bot
+  namespace: emojivoto
+  pods: 1
+  proxyOverrides: {}
+  proxyVersions:
+  - stable-2.6.0
+`
+
+	options := newGetOptions()
+	options.namespace = "emojivoto"
+	options.outputFormat = yamlOutput
+
+	var buf bytes.Buffer
+	if err := getMeshed(&buf, client, "linkerd", options); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	options.outputFormat = wideOutput
+	if err := options.validateMeshedOutput(); err == nil {
+		t.Fatalf("Expected an error for the %s output", wideOutput)
+	}
+}
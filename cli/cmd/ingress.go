@@ -0,0 +1,460 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ingressNginx      = "nginx"
+	ingressTraefik    = "traefik"
+	ingressAmbassador = "ambassador"
+
+	l5dDstOverrideHeader = "l5d-dst-override"
+
+	nginxSnippetAnnotation     = "nginx.ingress.kubernetes.io/configuration-snippet"
+	traefikHeadersAnnotation   = "ingress.kubernetes.io/custom-request-headers"
+	ambassadorConfigAnnotation = "getambassador.io/config"
+)
+
+var ingressControllers = []string{ingressNginx, ingressTraefik, ingressAmbassador}
+
+type ingressOptions struct {
+	namespace     string
+	clusterDomain string
+}
+
+// resourceTransformerIngress configures the resources read by ingress
+// controllers so that they set the l5d-dst-override header on the requests
+// they forward, which lets the controller's proxy route each request to the
+// service, and apply its service profile, instead of the endpoint the
+// controller picked.
+type resourceTransformerIngress struct {
+	controller    string
+	namespace     string
+	clusterDomain string
+	configured    []string
+	skipped       []string
+}
+
+func newIngressOptions() *ingressOptions {
+	return &ingressOptions{
+		namespace:     "default",
+		clusterDomain: "cluster.local",
+	}
+}
+
+func newCmdIngress() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingress [flags]",
+		Short: "Configure ingress controllers to route requests through the Linkerd mesh",
+		Long: `Configure ingress controllers to route requests through the Linkerd mesh.
+
+Meshed ingress controllers must tell their Linkerd proxy which service each
+request is for, by setting the l5d-dst-override header. Otherwise the proxy
+routes requests to the endpoint the controller picked, bypassing service
+profiles and load balancing.`,
+	}
+
+	cmd.AddCommand(newCmdIngressConfigure())
+
+	return cmd
+}
+
+func newCmdIngressConfigure() *cobra.Command {
+	options := newIngressOptions()
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("configure [flags] (%s) CONFIG-FILE", strings.Join(ingressControllers, "|")),
+		Short: "Add the l5d-dst-override header to the configuration of an ingress controller",
+		Long: `Add the l5d-dst-override header to the configuration of an ingress controller.
+
+The resources read by the given ingress controller are changed to set the
+header on the requests they route:
+
+  * nginx: Ingresses get a configuration snippet setting the header from the
+    $service_name, $namespace and $service_port variables.
+  * traefik: Ingresses get a custom request header. As traefik sets headers
+    per Ingress, Ingresses routing to more than one service are skipped and
+    must be split up first.
+  * ambassador: Mappings, either as resources or in the getambassador.io/config
+    annotation of Services, get the header added to their request headers.
+
+Resources that already set the header are left unchanged. You can configure
+resources contained in a single file, inside a folder and its sub-folders, or
+coming from stdin.`,
+		Example: `  # Configure all the Ingresses in the emojivoto namespace for nginx.
+  kubectl -n emojivoto get ingress -o yaml | linkerd ingress configure nginx - | kubectl apply -f -
+
+  # Configure the Ambassador Mappings of the services inside a folder.
+  linkerd ingress configure ambassador <folder> | kubectl apply -f -`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args[0]); err != nil {
+				return err
+			}
+
+			in, err := read(args[1])
+			if err != nil {
+				return err
+			}
+
+			transformer := &resourceTransformerIngress{
+				controller:    args[0],
+				namespace:     options.namespace,
+				clusterDomain: options.clusterDomain,
+			}
+			exitCode := transformInput(in, stderr, stdout, transformer)
+			os.Exit(exitCode)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the services referenced by resources that don't have one")
+	cmd.PersistentFlags().StringVar(&options.clusterDomain, "cluster-domain", options.clusterDomain, "Cluster domain used in the fully-qualified names of the services")
+
+	return cmd
+}
+
+func (options *ingressOptions) validate(controller string) error {
+	found := false
+	for _, c := range ingressControllers {
+		if c == controller {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unsupported ingress controller [%s], must be one of: %s", controller, strings.Join(ingressControllers, ", "))
+	}
+
+	if errs := validation.IsDNS1123Subdomain(options.clusterDomain); len(errs) != 0 {
+		return fmt.Errorf("invalid cluster domain %q: %v", options.clusterDomain, errs)
+	}
+	return nil
+}
+
+func (rt *resourceTransformerIngress) transform(bytes []byte) ([]byte, []inject.Report, error) {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(bytes, &meta); err != nil {
+		return nil, nil, err
+	}
+
+	var configure func(*unstructured.Unstructured) (bool, error)
+	switch {
+	case meta.Kind == "Ingress" && rt.controller == ingressNginx:
+		configure = rt.configureNginx
+	case meta.Kind == "Ingress" && rt.controller == ingressTraefik:
+		configure = rt.configureTraefik
+	case meta.Kind == "Service" && rt.controller == ingressAmbassador:
+		configure = rt.configureAmbassadorService
+	case meta.Kind == "Mapping" && rt.controller == ingressAmbassador && strings.HasPrefix(meta.APIVersion, "getambassador.io/"):
+		configure = rt.configureAmbassadorMapping
+	default:
+		return bytes, nil, nil
+	}
+
+	obj, err := yamlToUnstructured(bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	changed, err := configure(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !changed {
+		return bytes, nil, nil
+	}
+
+	rt.configured = append(rt.configured, rt.resName(obj))
+	jsonBytes, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := yaml.JSONToYAML(jsonBytes)
+	return result, nil, err
+}
+
+func (rt *resourceTransformerIngress) generateReport(_ []inject.Report, output io.Writer) {
+	// leading newline to separate from yaml output on stdout
+	output.Write([]byte("\n"))
+
+	for _, res := range rt.configured {
+		output.Write([]byte(fmt.Sprintf("%s configured for %s\n", res, rt.controller)))
+	}
+	for _, skipped := range rt.skipped {
+		output.Write([]byte(fmt.Sprintf("%s %s\n", warnStatus, skipped)))
+	}
+	if len(rt.configured) == 0 && len(rt.skipped) == 0 {
+		output.Write([]byte(fmt.Sprintf("%s no resources used by %s found\n", warnStatus, rt.controller)))
+	}
+
+	// trailing newline to separate from kubectl output if piping
+	output.Write([]byte("\n"))
+}
+
+func (rt *resourceTransformerIngress) resName(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s \"%s\"", strings.ToLower(obj.GetKind()), obj.GetName())
+}
+
+func (rt *resourceTransformerIngress) skip(obj *unstructured.Unstructured, reason string) {
+	rt.skipped = append(rt.skipped, fmt.Sprintf("%s skipped: %s", rt.resName(obj), reason))
+}
+
+func (rt *resourceTransformerIngress) resNamespace(obj *unstructured.Unstructured) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return ns
+	}
+	return rt.namespace
+}
+
+func (rt *resourceTransformerIngress) configureNginx(obj *unstructured.Unstructured) (bool, error) {
+	annotations := obj.GetAnnotations()
+	snippet := annotations[nginxSnippetAnnotation]
+	if strings.Contains(snippet, l5dDstOverrideHeader) {
+		rt.skip(obj, fmt.Sprintf("%s already sets %s", nginxSnippetAnnotation, l5dDstOverrideHeader))
+		return false, nil
+	}
+
+	if snippet != "" && !strings.HasSuffix(snippet, "\n") {
+		snippet += "\n"
+	}
+	override := fmt.Sprintf("$service_name.$namespace.svc.%s:$service_port", rt.clusterDomain)
+	snippet += fmt.Sprintf("proxy_set_header %s %s;\n", l5dDstOverrideHeader, override)
+	snippet += fmt.Sprintf("grpc_set_header %s %s;\n", l5dDstOverrideHeader, override)
+
+	setAnnotation(obj, nginxSnippetAnnotation, snippet)
+	return true, nil
+}
+
+func (rt *resourceTransformerIngress) configureTraefik(obj *unstructured.Unstructured) (bool, error) {
+	annotations := obj.GetAnnotations()
+	headers := annotations[traefikHeadersAnnotation]
+	if strings.Contains(headers, l5dDstOverrideHeader) {
+		rt.skip(obj, fmt.Sprintf("%s already sets %s", traefikHeadersAnnotation, l5dDstOverrideHeader))
+		return false, nil
+	}
+
+	backends, err := ingressBackends(obj)
+	if err != nil {
+		return false, err
+	}
+	if len(backends) != 1 {
+		rt.skip(obj, fmt.Sprintf("traefik sets headers per Ingress, and it routes to %d services; split it into one Ingress per service", len(backends)))
+		return false, nil
+	}
+	backend := backends[0]
+	if backend.port == "" {
+		rt.skip(obj, fmt.Sprintf("the port of service %s must be a number", backend.service))
+		return false, nil
+	}
+
+	if headers != "" {
+		headers += "||"
+	}
+	headers += fmt.Sprintf("%s:%s.%s.svc.%s:%s", l5dDstOverrideHeader, backend.service, rt.resNamespace(obj), rt.clusterDomain, backend.port)
+
+	setAnnotation(obj, traefikHeadersAnnotation, headers)
+	return true, nil
+}
+
+func (rt *resourceTransformerIngress) configureAmbassadorService(obj *unstructured.Unstructured) (bool, error) {
+	config, ok := obj.GetAnnotations()[ambassadorConfigAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReader(strings.NewReader(config)))
+	docs := []map[string]interface{}{}
+	changed := false
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to parse the %s annotation of %s: %s", ambassadorConfigAnnotation, rt.resName(obj), err)
+		}
+
+		doc := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return false, fmt.Errorf("failed to parse the %s annotation of %s: %s", ambassadorConfigAnnotation, rt.resName(obj), err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		if doc["kind"] == "Mapping" && rt.addAmbassadorHeader(obj, doc) {
+			changed = true
+		}
+		docs = append(docs, doc)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return false, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	setAnnotation(obj, ambassadorConfigAnnotation, buf.String())
+	return true, nil
+}
+
+func (rt *resourceTransformerIngress) configureAmbassadorMapping(obj *unstructured.Unstructured) (bool, error) {
+	spec, ok := obj.Object["spec"].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	return rt.addAmbassadorHeader(obj, spec), nil
+}
+
+// addAmbassadorHeader adds the l5d-dst-override header to the request headers
+// of the given Mapping, returning false if the Mapping was left unchanged.
+func (rt *resourceTransformerIngress) addAmbassadorHeader(obj *unstructured.Unstructured, mapping map[string]interface{}) bool {
+	service, _ := mapping["service"].(string)
+	if service == "" {
+		return false
+	}
+
+	headers, _ := mapping["add_request_headers"].(map[string]interface{})
+	if headers == nil {
+		headers = map[string]interface{}{}
+	}
+	if _, ok := headers[l5dDstOverrideHeader]; ok {
+		rt.skip(obj, fmt.Sprintf("the Mapping for %s already sets %s", service, l5dDstOverrideHeader))
+		return false
+	}
+
+	headers[l5dDstOverrideHeader] = ambassadorServiceAuthority(service, rt.resNamespace(obj), rt.clusterDomain)
+	mapping["add_request_headers"] = headers
+	return true
+}
+
+// ambassadorServiceAuthority returns the fully-qualified authority of the
+// service of an Ambassador Mapping, given as [scheme://]name[.namespace][:port].
+func ambassadorServiceAuthority(service, namespace, clusterDomain string) string {
+	port := "80"
+	if strings.HasPrefix(service, "https://") {
+		port = "443"
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(service, "http://"), "https://")
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host, port = host[:i], host[i+1:]
+	}
+
+	switch strings.Count(host, ".") {
+	case 0:
+		host = fmt.Sprintf("%s.%s.svc.%s", host, namespace, clusterDomain)
+	case 1:
+		host = fmt.Sprintf("%s.svc.%s", host, clusterDomain)
+	}
+	return fmt.Sprintf("%s:%s", host, port)
+}
+
+type ingressBackend struct {
+	service string
+	// port is empty for named service ports.
+	port string
+}
+
+// ingressBackends returns the distinct service backends of an Ingress, sorted
+// by service name.
+func ingressBackends(obj *unstructured.Unstructured) ([]ingressBackend, error) {
+	backendObjs := []map[string]interface{}{}
+	if backend, found, err := unstructured.NestedMap(obj.Object, "spec", "backend"); err != nil {
+		return nil, err
+	} else if found {
+		backendObjs = append(backendObjs, backend)
+	}
+
+	rules, _, err := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		rule, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paths, _, err := unstructured.NestedSlice(rule, "http", "paths")
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			path, ok := path.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if backend, found, err := unstructured.NestedMap(path, "backend"); err != nil {
+				return nil, err
+			} else if found {
+				backendObjs = append(backendObjs, backend)
+			}
+		}
+	}
+
+	seen := map[ingressBackend]bool{}
+	backends := []ingressBackend{}
+	for _, b := range backendObjs {
+		service, _ := b["serviceName"].(string)
+		backend := ingressBackend{service: service}
+		switch port := b["servicePort"].(type) {
+		case int64:
+			backend.port = fmt.Sprintf("%d", port)
+		case float64:
+			backend.port = fmt.Sprintf("%d", int64(port))
+		}
+		if !seen[backend] {
+			seen[backend] = true
+			backends = append(backends, backend)
+		}
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		if backends[i].service != backends[j].service {
+			return backends[i].service < backends[j].service
+		}
+		return backends[i].port < backends[j].port
+	})
+	return backends, nil
+}
+
+func setAnnotation(obj *unstructured.Unstructured, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
+
+func yamlToUnstructured(bytes []byte) (*unstructured.Unstructured, error) {
+	jsonBytes, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(jsonBytes); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestIngressConfigure(t *testing.T) {
+	testCases := []struct {
+		controller     string
+		inputFileName  string
+		goldenFileName string
+		reportFileName string
+	}{
+		{ingressNginx, "ingress_nginx.input.yml", "ingress_nginx.golden.yml", "ingress_nginx.report"},
+		{ingressTraefik, "ingress_traefik.input.yml", "ingress_traefik.golden.yml", "ingress_traefik.report"},
+		{ingressAmbassador, "ingress_ambassador.input.yml", "ingress_ambassador.golden.yml", "ingress_ambassador.report"},
+		{ingressAmbassador, "ingress_nginx.input.yml", "ingress_nginx.input.yml", "ingress_none.report"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s %s", i, tc.controller, tc.inputFileName), func(t *testing.T) {
+			file, err := os.Open("testdata/" + tc.inputFileName)
+			if err != nil {
+				t.Fatalf("error opening test input file: %v\n", err)
+			}
+
+			read := []io.Reader{bufio.NewReader(file)}
+
+			output := new(bytes.Buffer)
+			report := new(bytes.Buffer)
+			transformer := &resourceTransformerIngress{
+				controller:    tc.controller,
+				namespace:     "default",
+				clusterDomain: "cluster.local",
+			}
+
+			if exitCode := transformInput(read, report, output, transformer); exitCode != 0 {
+				t.Fatalf("Failed to configure %s: %s", tc.inputFileName, report.String())
+			}
+
+			diffTestdata(t, tc.goldenFileName, output.String())
+			diffTestdata(t, tc.reportFileName, report.String())
+		})
+	}
+}
+
+func TestAmbassadorServiceAuthority(t *testing.T) {
+	testCases := map[string]string{
+		"web-svc":                      "web-svc.ambassador.svc.cluster.local:80",
+		"web-svc:8080":                 "web-svc.ambassador.svc.cluster.local:8080",
+		"web-svc.emojivoto":            "web-svc.emojivoto.svc.cluster.local:80",
+		"https://web-svc.emojivoto":    "web-svc.emojivoto.svc.cluster.local:443",
+		"http://web-svc.emojivoto:81":  "web-svc.emojivoto.svc.cluster.local:81",
+		"web.emojivoto.svc.example.io": "web.emojivoto.svc.example.io:80",
+	}
+
+	for service, expected := range testCases {
+		if actual := ambassadorServiceAuthority(service, "ambassador", "cluster.local"); actual != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, service, actual)
+		}
+	}
+}
+
+func TestIngressOptionsValidate(t *testing.T) {
+	options := newIngressOptions()
+	if err := options.validate(ingressNginx); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := "unsupported ingress controller [haproxy], must be one of: nginx, traefik, ambassador"
+	if err := options.validate("haproxy"); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -61,7 +61,7 @@ func newCmdInject() *cobra.Command {
 		Long: `Add the Linkerd proxy to a Kubernetes config.
 
 You can inject resources contained in a single file, inside a folder and its
-sub-folders, or coming from stdin.`,
+sub-folders, served at an http(s) URL, or coming from stdin.`,
 		Example: `  # Inject all the deployments in the default namespace.
   kubectl get deploy -o yaml | linkerd inject - | kubectl apply -f -
 
@@ -380,6 +380,18 @@ func (options *proxyConfigOptions) overrideConfigs(configs *config.All, override
 		overrideAnnotations[k8s.ProxyEnableExternalProfilesAnnotation] = "true"
 	}
 
+	if len(options.proxyLabels) > 0 {
+		// Not checking for error because option proxyLabels was already validated
+		configs.Proxy.Labels, _ = inject.ParseProxyLabels(options.proxyLabels)
+		overrideAnnotations[k8s.ProxyLabelsAnnotation] = strings.Join(options.proxyLabels, ",")
+	}
+
+	if len(options.destinationGetSuffixes) > 0 {
+		// Not checking for error because option destinationGetSuffixes was already validated
+		configs.Proxy.DestinationGetSuffixes, _ = inject.ParseDestinationGetSuffixes(options.destinationGetSuffixes)
+		overrideAnnotations[k8s.ProxyDestinationGetSuffixesAnnotation] = strings.Join(configs.Proxy.DestinationGetSuffixes, ",")
+	}
+
 	if options.proxyCPURequest != "" {
 		configs.Proxy.Resource.RequestCpu = options.proxyCPURequest
 		overrideAnnotations[k8s.ProxyCPURequestAnnotation] = options.proxyCPURequest
@@ -353,33 +353,41 @@ func TestInjectFilePath(t *testing.T) {
 
 func TestWalk(t *testing.T) {
 	// create two data files, one in the root folder and the other in a subfolder.
-	// walk should be able to read the content of the two data files recursively.
+	// walk should be able to read the content of the two data files recursively,
+	// and skip the files that aren't YAML or JSON and the hidden folders.
 	var (
-		tmpFolderRoot = "linkerd-testdata"
-		tmpFolderData = filepath.Join(tmpFolderRoot, "data")
+		tmpFolderRoot   = "linkerd-testdata"
+		tmpFolderData   = filepath.Join(tmpFolderRoot, "data")
+		tmpFolderHidden = filepath.Join(tmpFolderRoot, ".git")
 	)
 
-	if err := os.MkdirAll(tmpFolderData, os.ModeDir|os.ModePerm); err != nil {
-		t.Fatal("Unexpected error: ", err)
+	for _, folder := range []string{tmpFolderData, tmpFolderHidden} {
+		if err := os.MkdirAll(folder, os.ModeDir|os.ModePerm); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
 	}
 	defer os.RemoveAll(tmpFolderRoot)
 
-	var (
-		data  = []byte(readTestdata(t, "inject_gettest_deployment.bad.input.yml"))
-		file1 = filepath.Join(tmpFolderRoot, "root.txt")
-		file2 = filepath.Join(tmpFolderData, "data.txt")
-	)
-	if err := ioutil.WriteFile(file1, data, 0644); err != nil {
-		t.Fatal("Unexpected error: ", err)
+	data := []byte(readTestdata(t, "inject_gettest_deployment.bad.input.yml"))
+	files := map[string][]byte{
+		filepath.Join(tmpFolderRoot, "root.yml"):    data,
+		filepath.Join(tmpFolderData, "data.json"):   data,
+		filepath.Join(tmpFolderRoot, "README.md"):   []byte("# README"),
+		filepath.Join(tmpFolderHidden, "HEAD.yaml"): []byte("ref: refs/heads/master"),
 	}
-	if err := ioutil.WriteFile(file2, data, 0644); err != nil {
-		t.Fatal("Unexpected error: ", err)
+	for file, content := range files {
+		if err := ioutil.WriteFile(file, content, 0644); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
 	}
 
 	actual, err := walk(tmpFolderRoot)
 	if err != nil {
 		t.Fatal("Unexpected error: ", err)
 	}
+	if len(actual) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(actual))
+	}
 
 	for _, r := range actual {
 		b := make([]byte, len(data))
@@ -5,8 +5,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/linkerd/linkerd2/pkg/inject"
 	corev1 "k8s.io/api/core/v1"
@@ -131,7 +135,7 @@ func processList(bytes []byte, rt resourceTransformer) ([]byte, []inject.Report,
 }
 
 // Read all the resource files found in path into a slice of readers.
-// path can be either a file, directory or stdin.
+// path can be either a file, directory, http(s) URL or stdin.
 func read(path string) ([]io.Reader, error) {
 	var (
 		in  []io.Reader
@@ -139,6 +143,11 @@ func read(path string) ([]io.Reader, error) {
 	)
 	if path == "-" {
 		in = append(in, os.Stdin)
+	} else if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		in, err = fetch(path)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		in, err = walk(path)
 		if err != nil {
@@ -149,8 +158,33 @@ func read(path string) ([]io.Reader, error) {
 	return in, nil
 }
 
+// fetchClient downloads the resources read from URLs, giving up on servers
+// that don't respond.
+var fetchClient = &http.Client{Timeout: 30 * time.Second}
+
+// fetch downloads the resources served at url.
+func fetch(url string) ([]io.Reader, error) {
+	resp, err := fetchClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return []io.Reader{bytes.NewReader(body)}, nil
+}
+
 // walk walks the file tree rooted at path. path may be a file or a directory.
-// Creates a reader for each file found.
+// Creates a reader for each YAML or JSON file found, skipping hidden
+// directories, e.g. .git.
 func walk(path string) ([]io.Reader, error) {
 	stat, err := os.Stat(path)
 	if err != nil {
@@ -167,12 +201,22 @@ func walk(path string) ([]io.Reader, error) {
 	}
 
 	var in []io.Reader
+	root := path
 	werr := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if info.IsDir() {
+			if path != root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
 			return nil
 		}
 
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -15,6 +16,7 @@ import (
 	"github.com/linkerd/linkerd2/cli/static"
 	pb "github.com/linkerd/linkerd2/controller/gen/config"
 	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/inject"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
@@ -34,26 +36,44 @@ import (
 
 type (
 	installValues struct {
-		Namespace                string
-		ControllerImage          string
-		WebImage                 string
-		PrometheusImage          string
-		GrafanaImage             string
-		ImagePullPolicy          string
-		UUID                     string
-		CliVersion               string
-		ControllerReplicas       uint
-		ControllerLogLevel       string
-		PrometheusLogLevel       string
-		ControllerComponentLabel string
-		CreatedByAnnotation      string
-		ProxyContainerName       string
-		ProxyAutoInjectEnabled   bool
-		ProxyInjectAnnotation    string
-		ProxyInjectDisabled      string
-		ControllerUID            int64
-		EnableH2Upgrade          bool
-		NoInitContainer          bool
+		Namespace                         string
+		ControllerImage                   string
+		WebImage                          string
+		PrometheusImage                   string
+		GrafanaImage                      string
+		ImagePullPolicy                   string
+		UUID                              string
+		CliVersion                        string
+		ControllerReplicas                uint
+		ControllerLogLevel                string
+		ControllerKubeAPIQPS              float32
+		ControllerKubeAPIBurst            int
+		PrometheusLogLevel                string
+		ControllerComponentLabel          string
+		CreatedByAnnotation               string
+		ProxyContainerName                string
+		HATopologyKey                     string
+		ProxyAutoInjectEnabled            bool
+		ScopedWebhooks                    bool
+		SMIMetricsEnabled                 bool
+		ControlPlaneTLS                   bool
+		ControllerNSLabel                 string
+		ProxyInjectAnnotation             string
+		ProxyIgnoreInboundPortsAnnotation string
+		ProxyInjectDisabled               string
+		AdmissionWebhooksLabel            string
+		AdmissionWebhooksDisabled         string
+		InjectorExcludedNamespaces        string
+		ControllerUID                     int64
+		EnableH2Upgrade                   bool
+		NoInitContainer                   bool
+		ClusterName                       string
+		GrafanaAPIKeySecret               string
+		AllowedNamespaces                 string
+		IdentityPeerNamespaces            string
+		PodMetricLabels                   string
+		CRDAPIVersion                     string
+		ResetCRDPreserveUnknownFields     bool
 
 		Configs configJSONs
 
@@ -68,6 +88,16 @@ type (
 		WebResources *resources
 
 		Identity *installIdentityValues
+
+		// Configuration replaced by an upgrade, saved for rollbacks.
+		PreviousConfigs *previousConfigValues
+
+		// JSON records of the installs and upgrades of the control plane.
+		History string
+
+		// Resources from --pre-install-manifest and --post-install-manifest,
+		// rendered around the control plane without being injected.
+		preInstallManifests, postInstallManifests [][]byte
 	}
 
 	configJSONs struct{ Global, Proxy, Install string }
@@ -101,14 +131,35 @@ type (
 	// in order to hold values for command line flags that apply to both inject and
 	// install.
 	installOptions struct {
-		controllerReplicas uint
-		controllerLogLevel string
-		proxyAutoInject    bool
-		highAvailability   bool
-		controllerUID      int64
-		disableH2Upgrade   bool
-		noInitContainer    bool
-		identityOptions    *installIdentityOptions
+		controllerReplicas     uint
+		controllerLogLevel     string
+		controllerKubeAPIQPS   float32
+		controllerKubeAPIBurst int
+		proxyAutoInject        bool
+		scopedWebhooks         bool
+		injectorExcluded       []string
+		smiMetrics             bool
+		controlPlaneTLS        bool
+		highAvailability       bool
+		haTopologyKey          string
+		controllerUID          int64
+		disableH2Upgrade       bool
+		noInitContainer        bool
+		clusterName            string
+		grafanaAPIKeySecret    string
+		allowedNamespaces      []string
+		podMetricLabels        []string
+		valuesFile             string
+		recordTimeAndUser      bool
+		overrides              valueOverrides
+		outputDir              string
+		outputFormat           string
+		outputValues           bool
+		validateCluster        bool
+		kubernetesVersion      string
+		preInstallManifests    []string
+		postInstallManifests   []string
+		identityOptions        *installIdentityOptions
 		*proxyConfigOptions
 
 		recordedFlags []*pb.Install_Flag
@@ -133,6 +184,7 @@ const (
 	prometheusProxyOutboundCapacity   = 10000
 	defaultControllerReplicas         = 1
 	defaultHAControllerReplicas       = 3
+	defaultHATopologyKey              = "failure-domain.beta.kubernetes.io/zone"
 	defaultIdentityTrustDomain        = "cluster.local"
 	defaultIdentityIssuanceLifetime   = 24 * time.Hour
 	defaultIdentityClockSkewAllowance = 20 * time.Second
@@ -148,8 +200,17 @@ const (
 	serviceprofileTemplateName = "templates/serviceprofile.yaml"
 	proxyInjectorTemplateName  = "templates/proxy_injector.yaml"
 	spValidatorTemplateName    = "templates/sp_validator.yaml"
+	smiMetricsTemplateName     = "templates/smi_metrics.yaml"
 )
 
+// crdV1MinVersion is the first Kubernetes version serving
+// apiextensions.k8s.io/v1 CustomResourceDefinitions.
+var crdV1MinVersion = [3]int{1, 16, 0}
+
+// controlPlaneAdminPorts are the ports of the admin servers of the control
+// plane containers, as rendered by the templates.
+var controlPlaneAdminPorts = []uint32{9990, 9993, 9994, 9995, 9996, 9997, 9998}
+
 // newInstallOptionsWithDefaults initializes install options with default
 // control plane and proxy options.
 //
@@ -161,10 +222,16 @@ func newInstallOptionsWithDefaults() *installOptions {
 		controllerReplicas: defaultControllerReplicas,
 		controllerLogLevel: "info",
 		proxyAutoInject:    false,
+		scopedWebhooks:     false,
+		injectorExcluded:   []string{"kube-system"},
+		smiMetrics:         false,
+		controlPlaneTLS:    false,
 		highAvailability:   false,
+		haTopologyKey:      defaultHATopologyKey,
 		controllerUID:      2103,
 		disableH2Upgrade:   false,
 		noInitContainer:    false,
+		outputFormat:       yamlOutput,
 		proxyConfigOptions: &proxyConfigOptions{
 			linkerdVersion:         version.Version,
 			ignoreCluster:          false,
@@ -220,6 +287,18 @@ func newCmdInstall() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !options.ignoreCluster {
 				exitIfClusterExists()
+
+				if options.kubernetesVersion == "" {
+					version, err := fetchKubernetesVersion()
+					if err != nil {
+						return fmt.Errorf("failed to detect the Kubernetes version, use --kubernetes-version to set it: %s", err)
+					}
+					options.kubernetesVersion = version
+				}
+			}
+
+			if err := options.validateOutputFormat(); err != nil {
+				return err
 			}
 
 			values, configs, err := options.validateAndBuild(flags)
@@ -227,7 +306,45 @@ func newCmdInstall() *cobra.Command {
 				return err
 			}
 
-			return values.render(os.Stdout, configs)
+			if options.outputValues {
+				if options.outputDir != "" || options.validateCluster {
+					return errors.New("--output-values can't be used with --output-dir or --validate")
+				}
+				if err := writeValues(os.Stdout, values); err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "%s %s\n", warnStatus, uninjectedValuesWarning)
+				return nil
+			}
+
+			if !options.validateCluster && options.outputDir == "" {
+				return values.render(os.Stdout, configs)
+			}
+
+			var buf bytes.Buffer
+			if err := values.render(&buf, configs); err != nil {
+				return err
+			}
+			if options.validateCluster {
+				var validated bytes.Buffer
+				if err := options.validateAgainstCluster(&buf, &validated, os.Stderr); err != nil {
+					return err
+				}
+				buf = validated
+			}
+			if options.outputDir == "" {
+				_, err = buf.WriteTo(os.Stdout)
+				return err
+			}
+
+			paths, err := writeOutput(options.outputDir, options.outputFormat, &buf)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				fmt.Fprintf(os.Stderr, "%s Wrote %s\n", okStatus, path)
+			}
+			return nil
 		},
 	}
 
@@ -240,6 +357,14 @@ func newCmdInstall() *cobra.Command {
 }
 
 func (options *installOptions) validateAndBuild(flags *pflag.FlagSet) (*installValues, *pb.All, error) {
+	fileFlags, err := readValuesFile(options.valuesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setFlagsFromValues(flags, fileFlags); err != nil {
+		return nil, nil, err
+	}
+
 	if err := options.validate(); err != nil {
 		return nil, nil, err
 	}
@@ -258,6 +383,11 @@ func (options *installOptions) validateAndBuild(flags *pflag.FlagSet) (*installV
 	}
 	values.Identity = identityValues
 
+	values.History, err = buildHistory(nil, "install", values, options.recordedFlags, options.recordTimeAndUser)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return values, configs, nil
 }
 
@@ -282,13 +412,41 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.controllerLogLevel, "controller-log-level", options.controllerLogLevel,
 		"Log level for the controller and web components",
 	)
+	flags.Float32Var(
+		&options.controllerKubeAPIQPS, "controller-kube-api-qps", options.controllerKubeAPIQPS,
+		"Maximum queries per second from each control plane component to the Kubernetes API (default: client-go default)",
+	)
+	flags.IntVar(
+		&options.controllerKubeAPIBurst, "controller-kube-api-burst", options.controllerKubeAPIBurst,
+		"Maximum burst of queries from each control plane component to the Kubernetes API (default: client-go default)",
+	)
 	flags.BoolVar(
 		&options.proxyAutoInject, "proxy-auto-inject", options.proxyAutoInject,
 		"Enable proxy sidecar auto-injection via a webhook (default false)",
 	)
+	flags.BoolVar(
+		&options.scopedWebhooks, "scoped-webhooks", options.scopedWebhooks,
+		"Only inject proxies and validate service profiles in namespaces labeled with linkerd.io/control-plane-ns=<namespace>, so that several control planes can share the cluster (default false)",
+	)
+	flags.StringSliceVar(
+		&options.injectorExcluded, "injector-excluded-namespaces", options.injectorExcluded,
+		"Namespaces excluded from the proxy injector's webhook, so that the API server never sends their pods to it; they're rendered labeled with config.linkerd.io/admission-webhooks=disabled, which must be removed by hand from the namespaces later removed from the list, and the control plane's namespace is always excluded",
+	)
+	flags.BoolVar(
+		&options.smiMetrics, "smi-metrics", options.smiMetrics,
+		"Experimental: Serve the SMI TrafficMetrics API (metrics.smi-spec.io) from the metrics of the control plane (default false)",
+	)
+	flags.BoolVar(
+		&options.controlPlaneTLS, "control-plane-tls", options.controlPlaneTLS,
+		"Experimental: Serve the destination and identity APIs over TLS, only to proxies with an identity issued by the trust anchors (default false)",
+	)
 	flags.BoolVar(
 		&options.highAvailability, "ha", options.highAvailability,
-		"Experimental: Enable HA deployment config for the control plane (default false)",
+		"Experimental: Enable HA deployment config for the control plane: replicas of the controller, identity and webhooks spread across nodes, PodDisruptionBudgets and resource requests (default false)",
+	)
+	flags.StringVar(
+		&options.haTopologyKey, "ha-topology-key", options.haTopologyKey,
+		"Node label across whose values the replicas of the control plane are spread with --ha",
 	)
 	flags.Int64Var(
 		&options.controllerUID, "controller-uid", options.controllerUID,
@@ -298,14 +456,46 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.disableH2Upgrade, "disable-h2-upgrade", options.disableH2Upgrade,
 		"Prevents the controller from instructing proxies to perform transparent HTTP/2 upgrading (default false)",
 	)
+	flags.StringVar(
+		&options.clusterName, "cluster-name", options.clusterName,
+		"Name of the cluster, added as a label to all metrics and reported by the public API (default none)",
+	)
+	flags.StringVar(
+		&options.grafanaAPIKeySecret, "grafana-api-key-secret", options.grafanaAPIKeySecret,
+		"Name of a Secret in the control plane's namespace holding a Grafana API key under the api-key key, used to embed Grafana panels in the dashboard when Grafana requires authentication (default none)",
+	)
+	flags.StringSliceVar(
+		&options.allowedNamespaces, "allowed-namespaces", options.allowedNamespaces,
+		"Namespaces the public API answers queries about and the dashboard shows, including through its Grafana proxy, so that a shared cluster can expose the dashboard to a team (default all)",
+	)
+	flags.StringSliceVar(
+		&options.podMetricLabels, "pod-metric-labels", options.podMetricLabels,
+		"Keys of the pod labels, e.g. version, that proxies add to the metrics of the requests sent to the pods (default none)",
+	)
+	flags.StringVar(
+		&options.kubernetesVersion, "kubernetes-version", options.kubernetesVersion,
+		"Kubernetes version to render configs for, e.g. v1.16.0 (default: the version of the current cluster, or the oldest supported version with --ignore-cluster)",
+	)
 	flags.DurationVar(
 		&options.identityOptions.issuanceLifetime, "identity-issuance-lifetime", options.identityOptions.issuanceLifetime,
-		"The amount of time for which the Identity issuer should certify identity",
+		fmt.Sprintf("The amount of time for which the Identity issuer should certify identity; namespaces can shorten it with the %s annotation", k8s.IdentityIssuanceLifetimeAnnotation),
 	)
 	flags.DurationVar(
 		&options.identityOptions.clockSkewAllowance, "identity-clock-skew-allowance", options.identityOptions.clockSkewAllowance,
 		"The amount of time to allow for clock skew within a Linkerd cluster",
 	)
+	flags.StringSliceVar(
+		&options.preInstallManifests, "pre-install-manifest", options.preInstallManifests,
+		"Path to a YAML file of resources to render after the namespace and before the control plane, e.g. RBAC the control plane depends on; may be repeated, and must be given again on upgrade",
+	)
+	flags.StringSliceVar(
+		&options.postInstallManifests, "post-install-manifest", options.postInstallManifests,
+		"Path to a YAML file of resources to render after the control plane; may be repeated, and must be given again on upgrade",
+	)
+	flags.Var(
+		&options.overrides, "set",
+		"Chart values to override, for the values that have no flag of their own, e.g. ImagePullPolicy=Always or WebResources.CPU.Limit=500m; may be repeated or comma-separated, and is kept by upgrades unless removed with a dash, e.g. ImagePullPolicy-",
+	)
 
 	return flags
 }
@@ -336,6 +526,31 @@ func (options *installOptions) installOnlyFlagSet() *pflag.FlagSet {
 		&options.ignoreCluster, "ignore-cluster", options.ignoreCluster,
 		"Ignore the current Kubernetes cluster when checking for existing cluster configuration (default false)",
 	)
+	flags.BoolVar(
+		&options.validateCluster, "validate", options.validateCluster,
+		"Check that the current Kubernetes cluster supports the rendered configs, rewriting them to API versions the cluster serves where possible (default false)",
+	)
+	flags.StringVar(
+		&options.outputDir, "output-dir", options.outputDir,
+		"Write the configs to this directory instead of stdout, one file per component of the control plane, numbered in the order they must be applied; a component keeps its number across renders, and only the files of a previous render are replaced",
+	)
+	flags.StringVarP(
+		&options.outputFormat, "output", "o", options.outputFormat,
+		fmt.Sprintf("Output format of the configs; one of: \"%s\" or \"%s\", which writes them to --output-dir as a Kustomize base, one file per resource listed by a kustomization.yaml", yamlOutput, kustomizeOutput),
+	)
+	setOutputFormats(flags, yamlOutput, kustomizeOutput)
+	flags.BoolVar(
+		&options.outputValues, "output-values", options.outputValues,
+		"Output the values of the linkerd Helm chart the configs are rendered from instead of the configs; Helm renders the control plane from them without proxies (default false)",
+	)
+	flags.StringVar(
+		&options.valuesFile, "values", options.valuesFile,
+		"A path to a YAML file of chart values, e.g. ControllerReplicas or ProxyAutoInjectEnabled, that set the flags they are rendered from unless those are set on the command line; the values that have no flag are overridden as with --set",
+	)
+	flags.BoolVar(
+		&options.recordTimeAndUser, "record-time-and-user", options.recordTimeAndUser,
+		recordTimeAndUserUsage,
+	)
 
 	return flags
 }
@@ -348,18 +563,30 @@ func (options *installOptions) recordFlags(flags *pflag.FlagSet) {
 	flags.VisitAll(func(f *pflag.Flag) {
 		if f.Changed {
 			switch f.Name {
-			case "ignore-cluster", "linkerd-version":
+			case "ignore-cluster", "kubernetes-version", "linkerd-version",
+				"pre-install-manifest", "post-install-manifest":
 				// These flags don't make sense to record.
 			default:
 				options.recordedFlags = append(options.recordedFlags, &pb.Install_Flag{
 					Name:  f.Name,
-					Value: f.Value.String(),
+					Value: flagValue(f),
 				})
 			}
 		}
 	})
 }
 
+// flagValue returns the value of a flag as it's recorded, so that it can be
+// set again: pflag prints the values of slice flags in brackets, e.g.
+// [version,app], which setting the flag would keep in the first and last
+// values, so they're recorded comma-separated instead.
+func flagValue(f *pflag.Flag) string {
+	if !strings.HasSuffix(f.Value.Type(), "Slice") {
+		return f.Value.String()
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(f.Value.String(), "["), "]")
+}
+
 func (options *installOptions) validate() error {
 	if options.identityOptions == nil {
 		// Programmer error: identityOptions may be empty, but it must be set by the constructor.
@@ -370,6 +597,54 @@ func (options *installOptions) validate() error {
 		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
 	}
 
+	if options.controllerKubeAPIQPS < 0 {
+		return errors.New("--controller-kube-api-qps must not be negative")
+	}
+
+	if options.controllerKubeAPIBurst < 0 {
+		return errors.New("--controller-kube-api-burst must not be negative")
+	}
+
+	if options.clusterName != "" {
+		if errs := validation.IsDNS1123Label(options.clusterName); len(errs) > 0 {
+			return fmt.Errorf("--cluster-name must be a valid DNS label: %s", errs[0])
+		}
+	}
+
+	if options.grafanaAPIKeySecret != "" {
+		if errs := validation.IsDNS1123Subdomain(options.grafanaAPIKeySecret); len(errs) > 0 {
+			return fmt.Errorf("--grafana-api-key-secret must be a valid Secret name: %s", errs[0])
+		}
+	}
+
+	for _, ns := range options.allowedNamespaces {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("--allowed-namespaces must be valid namespace names: %s", errs[0])
+		}
+	}
+
+	for _, key := range options.podMetricLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("--pod-metric-labels must be valid label keys: %s", errs[0])
+		}
+	}
+
+	for _, ns := range options.injectorExcluded {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("--injector-excluded-namespaces must be valid namespace names: %s", errs[0])
+		}
+	}
+
+	if errs := validation.IsQualifiedName(options.haTopologyKey); len(errs) > 0 {
+		return fmt.Errorf("--ha-topology-key must be a valid label key: %s", errs[0])
+	}
+
+	if options.kubernetesVersion != "" {
+		if _, err := k8s.IsVersionAtLeast(options.kubernetesVersion, crdV1MinVersion); err != nil {
+			return fmt.Errorf("--kubernetes-version must be a valid Kubernetes version: %s", err)
+		}
+	}
+
 	if err := options.proxyConfigOptions.validate(); err != nil {
 		return err
 	}
@@ -396,12 +671,48 @@ func (options *installOptions) validate() error {
 	return nil
 }
 
+// validateOutputFormat checks --output, which only changes how the configs are
+// written to --output-dir.
+func (options *installOptions) validateOutputFormat() error {
+	switch options.outputFormat {
+	case yamlOutput:
+		return nil
+	case kustomizeOutput:
+		if options.outputDir == "" {
+			return fmt.Errorf("--output %s requires --output-dir", kustomizeOutput)
+		}
+		return nil
+	default:
+		return fmt.Errorf("--output must be one of: %s, %s", yamlOutput, kustomizeOutput)
+	}
+}
+
 func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*installValues, error) {
 	globalJSON, proxyJSON, installJSON, err := config.ToJSON(configs)
 	if err != nil {
 		return nil, err
 	}
 
+	crdAPIVersion := "apiextensions.k8s.io/v1beta1"
+	if options.kubernetesVersion != "" {
+		crdV1, err := k8s.IsVersionAtLeast(options.kubernetesVersion, crdV1MinVersion)
+		if err != nil {
+			return nil, err
+		}
+		if crdV1 {
+			crdAPIVersion = "apiextensions.k8s.io/v1"
+		}
+	}
+
+	preInstallManifests, err := readManifests("pre-install-manifest", options.preInstallManifests)
+	if err != nil {
+		return nil, err
+	}
+	postInstallManifests, err := readManifests("post-install-manifest", options.postInstallManifests)
+	if err != nil {
+		return nil, err
+	}
+
 	values := &installValues{
 		// Container images:
 		ControllerImage: fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.linkerdVersion),
@@ -411,23 +722,38 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*ins
 		ImagePullPolicy: options.imagePullPolicy,
 
 		// Kubernetes labels/annotations/resourcse:
-		CreatedByAnnotation:      k8s.CreatedByAnnotation,
-		CliVersion:               k8s.CreatedByAnnotationValue(),
-		ControllerComponentLabel: k8s.ControllerComponentLabel,
-		ProxyContainerName:       k8s.ProxyContainerName,
-		ProxyInjectAnnotation:    k8s.ProxyInjectAnnotation,
-		ProxyInjectDisabled:      k8s.ProxyInjectDisabled,
+		CreatedByAnnotation:               k8s.CreatedByAnnotation,
+		CliVersion:                        k8s.CreatedByAnnotationValue(),
+		ControllerComponentLabel:          k8s.ControllerComponentLabel,
+		ProxyContainerName:                k8s.ProxyContainerName,
+		ControllerNSLabel:                 k8s.ControllerNSLabel,
+		ProxyInjectAnnotation:             k8s.ProxyInjectAnnotation,
+		ProxyIgnoreInboundPortsAnnotation: k8s.ProxyIgnoreInboundPortsAnnotation,
+		ProxyInjectDisabled:               k8s.ProxyInjectDisabled,
+		AdmissionWebhooksLabel:            k8s.AdmissionWebhooksLabel,
+		AdmissionWebhooksDisabled:         k8s.AdmissionWebhooksDisabled,
 
 		// Controller configuration:
-		Namespace:              controlPlaneNamespace,
-		UUID:                   configs.GetInstall().GetUuid(),
-		ControllerReplicas:     options.controllerReplicas,
-		ControllerLogLevel:     options.controllerLogLevel,
-		ControllerUID:          options.controllerUID,
-		EnableH2Upgrade:        !options.disableH2Upgrade,
-		NoInitContainer:        options.noInitContainer,
-		ProxyAutoInjectEnabled: options.proxyAutoInject,
-		PrometheusLogLevel:     toPromLogLevel(options.controllerLogLevel),
+		Namespace:                  controlPlaneNamespace,
+		UUID:                       configs.GetInstall().GetUuid(),
+		ControllerReplicas:         options.controllerReplicas,
+		ControllerLogLevel:         options.controllerLogLevel,
+		ControllerKubeAPIQPS:       options.controllerKubeAPIQPS,
+		ControllerKubeAPIBurst:     options.controllerKubeAPIBurst,
+		ControllerUID:              options.controllerUID,
+		EnableH2Upgrade:            !options.disableH2Upgrade,
+		NoInitContainer:            options.noInitContainer,
+		ClusterName:                options.clusterName,
+		GrafanaAPIKeySecret:        options.grafanaAPIKeySecret,
+		AllowedNamespaces:          strings.Join(options.allowedNamespaces, ","),
+		PodMetricLabels:            strings.Join(options.podMetricLabels, ","),
+		CRDAPIVersion:              crdAPIVersion,
+		ProxyAutoInjectEnabled:     options.proxyAutoInject,
+		ScopedWebhooks:             options.scopedWebhooks,
+		InjectorExcludedNamespaces: strings.Join(options.injectorExcluded, ","),
+		SMIMetricsEnabled:          options.smiMetrics,
+		ControlPlaneTLS:            options.controlPlaneTLS,
+		PrometheusLogLevel:         toPromLogLevel(options.controllerLogLevel),
 
 		Configs: configJSONs{
 			Global:  globalJSON,
@@ -444,9 +770,14 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*ins
 		SPValidatorResources:   &resources{},
 		TapResources:           &resources{},
 		WebResources:           &resources{},
+
+		preInstallManifests:  preInstallManifests,
+		postInstallManifests: postInstallManifests,
 	}
 
 	if options.highAvailability {
+		values.HATopologyKey = options.haTopologyKey
+
 		defaultConstraints := &resources{
 			CPU:    constraints{Request: "100m"},
 			Memory: constraints{Request: "50Mi"},
@@ -471,6 +802,10 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*ins
 		}
 	}
 
+	if err := options.overrides.apply(values); err != nil {
+		return nil, err
+	}
+
 	return values, nil
 }
 
@@ -494,6 +829,7 @@ func (values *installValues) render(w io.Writer, configs *pb.All) error {
 
 	files := []*chartutil.BufferedFile{
 		{Name: chartutil.ChartfileName},
+		{Name: chartutil.ValuesfileName},
 		{Name: nsTemplateName},
 		{Name: configTemplateName},
 		{Name: resourcesTemplateName},
@@ -505,6 +841,7 @@ func (values *installValues) render(w io.Writer, configs *pb.All) error {
 		{Name: grafanaTemplateName},
 		{Name: proxyInjectorTemplateName},
 		{Name: spValidatorTemplateName},
+		{Name: smiMetricsTemplateName},
 	}
 
 	// Read templates into bytes
@@ -538,11 +875,17 @@ func (values *installValues) render(w io.Writer, configs *pb.All) error {
 		return err
 	}
 
-	// Merge templates and inject
-	var buf bytes.Buffer
+	// Merge templates and inject. The namespace is kept separate so that the
+	// pre-install manifests, which may live in it, can be rendered between it
+	// and the rest of the control plane.
+	var namespace, buf bytes.Buffer
 	for _, tmpl := range files {
+		out := &buf
+		if tmpl.Name == nsTemplateName {
+			out = &namespace
+		}
 		t := path.Join(renderOpts.ReleaseOptions.Name, tmpl.Name)
-		if _, err := buf.WriteString(renderedTemplates[t]); err != nil {
+		if _, err := out.WriteString(renderedTemplates[t]); err != nil {
 			return err
 		}
 	}
@@ -552,12 +895,32 @@ func (values *installValues) render(w io.Writer, configs *pb.All) error {
 	// will guarantee the proxy is running prior to control-plane startup.
 	configs.Proxy.IgnoreOutboundPorts = append(configs.Proxy.IgnoreOutboundPorts, &pb.Port{Port: 443})
 
-	return processYAML(&buf, w, ioutil.Discard, resourceTransformerInject{
+	// Skip the inbound ports of the local admin servers, which the proxies
+	// would otherwise forward the requests of any client to, from the
+	// loopback interface they listen on.
+	for _, port := range controlPlaneAdminPorts {
+		configs.Proxy.IgnoreInboundPorts = append(configs.Proxy.IgnoreInboundPorts, &pb.Port{Port: port + k8s.ControllerAdminLocalPortOffset})
+	}
+
+	rt := resourceTransformerInject{
 		configs: configs,
 		proxyOutboundCapacity: map[string]uint{
 			values.PrometheusImage: prometheusProxyOutboundCapacity,
 		},
-	})
+	}
+	if err := processYAML(&namespace, w, ioutil.Discard, rt); err != nil {
+		return err
+	}
+	if err := writeManifests(w, values.preInstallManifests); err != nil {
+		return err
+	}
+	// processYAML keeps the separator the templates start with, which would
+	// duplicate the one written after the namespace.
+	rest := bytes.NewReader(bytes.TrimPrefix(buf.Bytes(), []byte("---\n")))
+	if err := processYAML(rest, w, ioutil.Discard, rt); err != nil {
+		return err
+	}
+	return writeManifests(w, values.postInstallManifests)
 }
 
 func readIntoBytes(filename string) ([]byte, error) {
@@ -593,6 +956,7 @@ func (options *installOptions) globalConfig(identity *pb.IdentityContext) *pb.Gl
 		CniEnabled:        options.noInitContainer,
 		Version:           options.linkerdVersion,
 		IdentityContext:   identity,
+		ClusterName:       options.clusterName,
 	}
 }
 
@@ -620,6 +984,15 @@ func (options *installOptions) proxyConfig() *pb.Proxy {
 		ignoreOutboundPorts = append(ignoreOutboundPorts, &pb.Port{Port: uint32(port)})
 	}
 
+	var labels map[string]string
+	if len(options.proxyLabels) > 0 {
+		// Not checking for error because option proxyLabels was already validated
+		labels, _ = inject.ParseProxyLabels(options.proxyLabels)
+	}
+
+	// Not checking for error because option destinationGetSuffixes was already validated
+	destinationGetSuffixes, _ := inject.ParseDestinationGetSuffixes(options.destinationGetSuffixes)
+
 	return &pb.Proxy{
 		ProxyImage: &pb.Image{
 			ImageName:  registryOverride(options.proxyImage, options.dockerRegistry),
@@ -654,6 +1027,8 @@ func (options *installOptions) proxyConfig() *pb.Proxy {
 			Level: options.proxyLogLevel,
 		},
 		DisableExternalProfiles: !options.enableExternalProfiles,
+		Labels:                  labels,
+		DestinationGetSuffixes:  destinationGetSuffixes,
 	}
 }
 
@@ -693,6 +1068,25 @@ func exitIfClusterExists() {
 	os.Exit(1)
 }
 
+// fetchKubernetesVersion returns the version of the current Kubernetes cluster.
+func fetchKubernetesVersion() (string, error) {
+	kubeConfig, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return "", err
+	}
+
+	k, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := k.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.GitVersion, nil
+}
+
 func (idopts *installIdentityOptions) validate() error {
 	if idopts == nil {
 		return nil
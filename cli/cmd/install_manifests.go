@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// readManifests reads the YAML documents in the given files, in order, for
+// --pre-install-manifest and --post-install-manifest. Empty documents are
+// dropped and every other document must be a Kubernetes resource.
+func readManifests(flag string, paths []string) ([][]byte, error) {
+	documents := [][]byte{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %s", flag, err)
+		}
+
+		reader := yamlDecoder.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+		for {
+			document, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("--%s: failed to read %s: %s", flag, path, err)
+			}
+
+			// The reader keeps leading separators, which writeManifests adds
+			// back after each document.
+			for bytes.HasPrefix(document, []byte("---\n")) {
+				document = bytes.TrimPrefix(document, []byte("---\n"))
+			}
+
+			var meta metav1.TypeMeta
+			if err := yaml.Unmarshal(document, &meta); err != nil {
+				return nil, fmt.Errorf("--%s: failed to parse %s: %s", flag, path, err)
+			}
+			if meta.Kind == "" {
+				if strings.TrimSpace(string(document)) == "" {
+					continue
+				}
+				return nil, fmt.Errorf("--%s: %s contains a document without a kind", flag, path)
+			}
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+// writeManifests writes the given YAML documents to w unchanged, in the same
+// format as processYAML.
+func writeManifests(w io.Writer, documents [][]byte) error {
+	for _, document := range documents {
+		if _, err := w.Write(document); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(document, []byte("\n")) {
+			if _, err := w.Write([]byte("\n")); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write([]byte("---\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
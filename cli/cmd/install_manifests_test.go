@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderInstallManifests(t *testing.T) {
+	options := testInstallOptions()
+	options.preInstallManifests = []string{filepath.Join("testdata", "install_pre_manifest.yml")}
+	options.postInstallManifests = []string{filepath.Join("testdata", "install_post_manifest.yml")}
+	values, configs, err := options.validateAndBuild(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error validating options: %v", err)
+	}
+	controlPlaneNamespace = configs.GetGlobal().GetLinkerdNamespace()
+
+	var buf bytes.Buffer
+	if err := values.render(&buf, configs); err != nil {
+		t.Fatalf("Failed to render templates: %v", err)
+	}
+	output := buf.String()
+
+	namespace := strings.Index(output, "kind: Namespace\n")
+	serviceAccount := strings.Index(output, "name: platform-agent\n")
+	controlPlane := strings.Index(output, "kind: ClusterRole\n")
+	configMap := strings.Index(output, "name: platform-config\n")
+	if namespace < 0 || serviceAccount < 0 || controlPlane < 0 || configMap < 0 {
+		t.Fatalf("Expected the namespace, the control plane and both manifests to be rendered, got:\n%s", output)
+	}
+	if !(namespace < serviceAccount && serviceAccount < controlPlane) {
+		t.Fatalf("Expected the pre-install manifest between the namespace and the control plane, got:\n%s", output)
+	}
+
+	post, err := ioutil.ReadFile(options.postInstallManifests[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(output, string(post)+"---\n") {
+		t.Fatalf("Expected the post-install manifest at the end of the output, got:\n%s", output)
+	}
+}
+
+func TestReadManifests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "install-manifests")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Reads documents from every file in order", func(t *testing.T) {
+		first := write("first.yml", "---\nkind: ServiceAccount\nmetadata:\n  name: a\n---\n---\nkind: Role\nmetadata:\n  name: b\n")
+		second := write("second.yml", "kind: RoleBinding\nmetadata:\n  name: c")
+
+		documents, err := readManifests("pre-install-manifest", []string{first, second})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := writeManifests(&buf, documents); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "kind: ServiceAccount\nmetadata:\n  name: a\n---\nkind: Role\nmetadata:\n  name: b\n---\nkind: RoleBinding\nmetadata:\n  name: c\n---\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("Fails for documents without a kind", func(t *testing.T) {
+		path := write("invalid.yml", "metadata:\n  name: a\n")
+
+		_, err := readManifests("post-install-manifest", []string{path})
+		expected := "--post-install-manifest: " + path + " contains a document without a kind"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Fails for missing files", func(t *testing.T) {
+		_, err := readManifests("pre-install-manifest", []string{filepath.Join(dir, "missing.yml")})
+		if err == nil {
+			t.Fatal("Expected an error for a missing file")
+		}
+	})
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// kustomizationFile is the name of the Kustomization written by
+// writeKustomizeDir.
+const kustomizationFile = "kustomization.yaml"
+
+// outputFiles are the files written by writeOutputDir, in the order the
+// templates render their resources, so that "kubectl apply -f" applies them in
+// the order they would have been applied as a single manifest. The numbers of
+// the files don't depend on what's rendered: a file whose resources aren't
+// rendered, e.g. 11-smi-metrics.yaml, is skipped rather than the following
+// files renumbered.
+var outputFiles = []string{
+	"namespace",
+	"pre-install",
+	"config",
+	"identity",
+	"controller",
+	"crds",
+	"web",
+	"prometheus",
+	"grafana",
+	"webhooks",
+	"smi-metrics",
+	"post-install",
+}
+
+// componentFiles maps the components of the control plane to the file of
+// outputFiles their resources are written to.
+var componentFiles = map[string]string{
+	"identity":       "identity",
+	"controller":     "controller",
+	"web":            "web",
+	"prometheus":     "prometheus",
+	"grafana":        "grafana",
+	"proxy-injector": "webhooks",
+	"sp-validator":   "webhooks",
+	"smi-metrics":    "smi-metrics",
+}
+
+// manifestDocument is a document of a rendered manifest, along with the
+// fields the documents are told apart by when written to a directory.
+type manifestDocument struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name      string            `json:"name"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"metadata"`
+
+	data []byte
+}
+
+// readManifestDocuments splits a rendered manifest into its documents,
+// skipping the empty ones.
+func readManifestDocuments(rendered io.Reader) ([]*manifestDocument, error) {
+	docs := []*manifestDocument{}
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(rendered, 4096))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		doc := &manifestDocument{}
+		if err := yaml.Unmarshal(document, doc); err != nil {
+			return nil, err
+		}
+		if doc.Kind == "" {
+			continue
+		}
+		for bytes.HasPrefix(document, []byte("---\n")) {
+			document = bytes.TrimPrefix(document, []byte("---\n"))
+		}
+		doc.data = document
+		docs = append(docs, doc)
+	}
+}
+
+// writeOutput writes the rendered manifest to dir in the given format, for
+// --output-dir: numbered files per component with writeOutputDir, or a
+// Kustomize base with writeKustomizeDir.
+func writeOutput(dir, format string, rendered io.Reader) ([]string, error) {
+	if format == kustomizeOutput {
+		return writeKustomizeDir(dir, rendered)
+	}
+	return writeOutputDir(dir, rendered)
+}
+
+// writeOutputDir writes the documents of the rendered manifest to dir, for
+// --output-dir, to the file of outputFiles of their component, e.g.
+// 04-identity.yaml or 10-webhooks.yaml. The files of outputFiles that a
+// previous render wrote but this one doesn't are removed, the other files of
+// dir being left alone, and the paths of the new ones returned.
+func writeOutputDir(dir string, rendered io.Reader) ([]string, error) {
+	files := map[string]*bytes.Buffer{}
+	controlPlaneSeen := false
+
+	docs, err := readManifestDocuments(rendered)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		name := componentFiles[doc.Metadata.Labels[k8s.ControllerComponentLabel]]
+		switch {
+		case doc.Kind == "Namespace":
+			name = "namespace"
+		case doc.Kind == "CustomResourceDefinition":
+			name = "crds"
+		case doc.Kind == "ConfigMap" && (doc.Metadata.Name == k8s.ConfigConfigMapName || doc.Metadata.Name == k8s.ConfigHistoryConfigMapName):
+			name = "config"
+		case name == "":
+			// The resources of --pre-install-manifest and --post-install-manifest
+			// aren't part of the control plane, and are told apart by where
+			// they're rendered.
+			name = "pre-install"
+			if controlPlaneSeen {
+				name = "post-install"
+			}
+		default:
+			controlPlaneSeen = true
+		}
+
+		buf, ok := files[name]
+		if !ok {
+			buf = &bytes.Buffer{}
+			files[name] = buf
+		}
+		buf.WriteString("---\n")
+		buf.Write(doc.data)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for i, name := range outputFiles {
+		path := filepath.Join(dir, fmt.Sprintf("%02d-%s.yaml", i+1, name))
+		buf, ok := files[name]
+		if !ok {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		// The identity file holds the private key of the issuer.
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// kustomization is the Kustomization written by writeKustomizeDir, listing
+// the files of the resources of the control plane.
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// writeKustomizeDir writes the documents of the rendered manifest to dir as a
+// Kustomize base, for --output kustomize: one file per resource, named after
+// its kind and name, e.g. deployment-linkerd-controller.yaml, and a
+// kustomization.yaml listing them in the order they were rendered. Overlays
+// include dir in their resources to patch the control plane. The files listed
+// by the kustomization.yaml of a previous render are removed, and the paths of
+// the new ones returned, kustomization.yaml last.
+func writeKustomizeDir(dir string, rendered io.Reader) ([]string, error) {
+	docs, err := readManifestDocuments(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	previous, err := ioutil.ReadFile(filepath.Join(dir, kustomizationFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var k kustomization
+		if err := yaml.Unmarshal(previous, &k); err != nil {
+			return nil, fmt.Errorf("failed to read the %s of a previous render: %s", kustomizationFile, err)
+		}
+		for _, name := range k.Resources {
+			// Only the files of the directory are removed; the resources of an
+			// edited kustomization.yaml may point elsewhere.
+			if filepath.Base(name) != name {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+	}
+
+	k := kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  []string{},
+	}
+	paths := []string{}
+	written := map[string]bool{}
+	for _, doc := range docs {
+		name := strings.ToLower(fmt.Sprintf("%s-%s.yaml", doc.Kind, doc.Metadata.Name))
+		if written[name] {
+			// Resources of --pre-install-manifest may share the kind and name of
+			// a resource in another namespace.
+			name = strings.ToLower(fmt.Sprintf("%s-%s-%s.yaml", doc.Kind, doc.Metadata.Namespace, doc.Metadata.Name))
+		}
+		for i := 2; written[name]; i++ {
+			name = strings.ToLower(fmt.Sprintf("%s-%s-%d.yaml", doc.Kind, doc.Metadata.Name, i))
+		}
+		written[name] = true
+
+		// The identity files hold the private key of the issuer.
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, append([]byte("---\n"), doc.data...), 0600); err != nil {
+			return nil, err
+		}
+		k.Resources = append(k.Resources, name)
+		paths = append(paths, path)
+	}
+
+	data, err := yaml.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, kustomizationFile)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+	return append(paths, path), nil
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestWriteOutputDir(t *testing.T) {
+	options := testInstallOptions()
+	options.preInstallManifests = []string{filepath.Join("testdata", "install_pre_manifest.yml")}
+	options.postInstallManifests = []string{filepath.Join("testdata", "install_post_manifest.yml")}
+	values, configs, err := options.validateAndBuild(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error validating options: %v", err)
+	}
+	controlPlaneNamespace = configs.GetGlobal().GetLinkerdNamespace()
+
+	var buf bytes.Buffer
+	if err := values.render(&buf, configs); err != nil {
+		t.Fatalf("Failed to render templates: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "install-output")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A file of a previous render, which must be removed, and a file that
+	// wasn't written by a render, which must be kept.
+	stale := filepath.Join(dir, "11-smi-metrics.yaml")
+	other := filepath.Join(dir, "11-custom.yaml")
+	for _, path := range []string{stale, other} {
+		if err := ioutil.WriteFile(path, []byte("kind: Deployment\n"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	paths, err := writeOutputDir(dir, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"01-namespace.yaml",
+		"02-pre-install.yaml",
+		"03-config.yaml",
+		"04-identity.yaml",
+		"05-controller.yaml",
+		"06-crds.yaml",
+		"07-web.yaml",
+		"08-prometheus.yaml",
+		"09-grafana.yaml",
+		"10-webhooks.yaml",
+		"12-post-install.yaml",
+	}
+	for i, name := range expected {
+		expected[i] = filepath.Join(dir, name)
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("Expected the files %v, got %v", expected, paths)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("Expected the file of the previous render to be removed")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Fatalf("Expected the files not written by a render to be kept: %v", err)
+	}
+
+	contents := map[string]string{}
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		contents[filepath.Base(path)] = string(data)
+	}
+	for name, resource := range map[string]string{
+		"02-pre-install.yaml":  "name: platform-agent\n",
+		"03-config.yaml":       "name: linkerd-config-history\n",
+		"04-identity.yaml":     "name: linkerd-identity-issuer\n",
+		"10-webhooks.yaml":     "name: linkerd-sp-validator\n",
+		"12-post-install.yaml": "name: platform-config\n",
+	} {
+		if !strings.Contains(contents[name], resource) {
+			t.Errorf("Expected %s to hold the resource with %s, got:\n%s", name, resource, contents[name])
+		}
+	}
+}
+
+func TestWriteKustomizeDir(t *testing.T) {
+	options := testInstallOptions()
+	values, configs, err := options.validateAndBuild(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error validating options: %v", err)
+	}
+	controlPlaneNamespace = configs.GetGlobal().GetLinkerdNamespace()
+
+	var buf bytes.Buffer
+	if err := values.render(&buf, configs); err != nil {
+		t.Fatalf("Failed to render templates: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "install-kustomize")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The files of a previous render, of which the ones it listed must be
+	// replaced.
+	previous := "resources:\n- deployment-linkerd-smi-metrics.yaml\n- ../other/patch.yaml\n"
+	for name, data := range map[string]string{
+		kustomizationFile:                     previous,
+		"deployment-linkerd-smi-metrics.yaml": "kind: Deployment\n",
+		"patch.yaml":                          "kind: Deployment\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	paths, err := writeKustomizeDir(dir, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "deployment-linkerd-smi-metrics.yaml")); !os.IsNotExist(err) {
+		t.Errorf("Expected the file of the previous render to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "patch.yaml")); err != nil {
+		t.Errorf("Expected the files not listed by the previous render to be kept: %v", err)
+	}
+	if paths[len(paths)-1] != filepath.Join(dir, kustomizationFile) {
+		t.Fatalf("Expected %s to be written last, got %v", kustomizationFile, paths)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, kustomizationFile))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var k kustomization
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if k.Kind != "Kustomization" || len(k.Resources) != len(paths)-1 {
+		t.Fatalf("Expected the Kustomization to list the %d resources, got:\n%s", len(paths)-1, data)
+	}
+	if k.Resources[0] != "namespace-linkerd.yaml" {
+		t.Errorf("Expected the namespace first, got %s", k.Resources[0])
+	}
+
+	// Each file holds a single resource, and all of them together hold the
+	// rendered manifest.
+	var all bytes.Buffer
+	for _, name := range k.Resources {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Count(string(data), "\nkind: ") != 1 {
+			t.Errorf("Expected %s to hold a single resource, got:\n%s", name, data)
+		}
+		all.Write(data)
+	}
+	for _, resource := range []string{"name: linkerd-identity-issuer\n", "name: linkerd-sp-validator\n"} {
+		if !strings.Contains(all.String(), resource) {
+			t.Errorf("Expected a file to hold the resource with %s", resource)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "deployment-linkerd-controller.yaml")); err != nil {
+		t.Errorf("Expected the resources to be named after their kind and name: %v", err)
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	testCases := []struct {
+		format    string
+		outputDir string
+		err       string
+	}{
+		{yamlOutput, "", ""},
+		{kustomizeOutput, "linkerd", ""},
+		{kustomizeOutput, "", "--output kustomize requires --output-dir"},
+		{jsonOutput, "linkerd", "--output must be one of: yaml, kustomize"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.format, func(t *testing.T) {
+			options := testInstallOptions()
+			options.outputFormat = tc.format
+			options.outputDir = tc.outputDir
+
+			err := options.validateOutputFormat()
+			if tc.err == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// overridableValues are the chart values --set can override. The other
+// values are either set by flags, or by the CLI along with the configuration
+// of the control plane, e.g. the images of its version or the names of the
+// labels and annotations its components look up.
+var overridableValues = map[string]bool{
+	"ImagePullPolicy":        true,
+	"PrometheusLogLevel":     true,
+	"DestinationResources":   true,
+	"GrafanaResources":       true,
+	"IdentityResources":      true,
+	"PrometheusResources":    true,
+	"ProxyInjectorResources": true,
+	"PublicAPIResources":     true,
+	"SPValidatorResources":   true,
+	"TapResources":           true,
+	"WebResources":           true,
+}
+
+// valueFlags maps the chart values set by flags that aren't in helmValueFlags
+// to those flags.
+var valueFlags = map[string]string{
+	"Namespace": "--linkerd-namespace",
+	"Identity":  "the --identity-* flags",
+}
+
+// valueOverrides holds the chart values set by --set, which override the
+// values the configs are rendered from after they're built from the flags,
+// for the values that have no flag of their own, e.g. ImagePullPolicy or
+// WebResources.CPU.Limit. The keys are paths of fields of installValues,
+// separated by dots, and the values YAML, e.g. WebResources.CPU.Limit=500m.
+//
+// As a flag, several comma-separated values may be set at once, and the flag
+// is recorded in the linkerd-config ConfigMap with all of its values, so that
+// upgrades keep overriding them. A key suffixed with a dash, e.g.
+// ImagePullPolicy-, removes the value recorded for it instead.
+type valueOverrides struct {
+	keys    []string
+	values  map[string]string
+	removed map[string]bool
+}
+
+// Set adds the comma-separated key=value pairs of s, replacing the values
+// of the keys already set, and removes the keys suffixed with a dash. Commas
+// in values are escaped with a backslash.
+func (o *valueOverrides) Set(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, pair := range splitEscaped(s, ',') {
+		if key := strings.TrimSuffix(pair, "-"); key != pair && !strings.Contains(pair, "=") {
+			if err := validateOverrideKey(key); err != nil {
+				return err
+			}
+			o.remove(key)
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("%q must be of the form key=value or key-", pair)
+		}
+		if err := validateOverrideKey(parts[0]); err != nil {
+			return err
+		}
+		o.set(parts[0], parts[1])
+	}
+	return nil
+}
+
+func (o *valueOverrides) set(key, value string) {
+	if o.values == nil {
+		o.values = map[string]string{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+	delete(o.removed, key)
+}
+
+func (o *valueOverrides) remove(key string) {
+	if o.removed == nil {
+		o.removed = map[string]bool{}
+	}
+	o.removed[key] = true
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// merge adds the comma-separated key=value pairs of s whose keys aren't set
+// or removed yet, e.g. the values recorded by a previous install, which those
+// set on the command line take precedence over.
+func (o *valueOverrides) merge(s string) error {
+	var other valueOverrides
+	if err := other.Set(s); err != nil {
+		return err
+	}
+	for _, key := range other.keys {
+		if _, ok := o.values[key]; !ok && !o.removed[key] {
+			o.set(key, other.values[key])
+		}
+	}
+	return nil
+}
+
+func (o *valueOverrides) String() string {
+	pairs := make([]string, len(o.keys))
+	for i, key := range o.keys {
+		pairs[i] = fmt.Sprintf("%s=%s", key, strings.Replace(o.values[key], ",", `\,`, -1))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (o *valueOverrides) Type() string {
+	return "key=value"
+}
+
+// apply overrides the given values with the values that were set. Keys that
+// don't name a field of the values, and values that don't fit the type of
+// their field, are rejected.
+func (o *valueOverrides) apply(values *installValues) error {
+	if len(o.keys) == 0 {
+		return nil
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	tree := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	for _, key := range o.keys {
+		var value interface{} = ""
+		if o.values[key] != "" {
+			if err := yaml.Unmarshal([]byte(o.values[key]), &value); err != nil {
+				return fmt.Errorf("invalid value for --set %s: %s", key, err)
+			}
+		}
+
+		node := tree
+		path := strings.Split(key, ".")
+		for _, name := range path[:len(path)-1] {
+			child, ok := node[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				node[name] = child
+			}
+			node = child
+		}
+		node[path[len(path)-1]] = value
+	}
+
+	data, err = yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	overridden := installValues{}
+	if err := yaml.UnmarshalStrict(data, &overridden); err != nil {
+		return fmt.Errorf("invalid --set values %s: %s", o.String(), err)
+	}
+	overridden.preInstallManifests = values.preInstallManifests
+	overridden.postInstallManifests = values.postInstallManifests
+	*values = overridden
+	return nil
+}
+
+// validateOverrideKey checks that a key of --set names a value the configs
+// are rendered from that can be overridden, i.e. that is in
+// overridableValues.
+func validateOverrideKey(key string) error {
+	for _, name := range strings.Split(key, ".") {
+		if name == "" {
+			return fmt.Errorf("invalid --set key %q", key)
+		}
+	}
+
+	value := strings.Split(key, ".")[0]
+	if field, ok := reflect.TypeOf(installValues{}).FieldByName(value); !ok || field.PkgPath != "" {
+		return fmt.Errorf("unknown value %s; see \"linkerd install --output-values\" for the values", value)
+	}
+	if overridableValues[value] {
+		return nil
+	}
+	if flag, ok := valueFlags[value]; ok {
+		return fmt.Errorf("%s can't be overridden; use %s instead", value, flag)
+	}
+	for _, m := range helmValueFlags {
+		if m.value == value {
+			return fmt.Errorf("%s can't be overridden; use --%s instead", value, m.flag)
+		}
+	}
+	return fmt.Errorf("%s can't be overridden, as it's set by the CLI", value)
+}
+
+// valuesOverrides returns the given chart values as --set pairs, sorted by
+// key, e.g. WebResources.CPU.Limit=1 for {WebResources: {CPU: {Limit: 1}}}.
+func valuesOverrides(values map[string]interface{}) ([]string, error) {
+	pairs := []string{}
+	var flatten func(prefix string, value interface{}) error
+	flatten = func(prefix string, value interface{}) error {
+		if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+			for k, v := range m {
+				if err := flatten(prefix+"."+k, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		encoded, err := encodeOverride(value)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", prefix, strings.Replace(encoded, ",", `\,`, -1)))
+		return nil
+	}
+
+	for key, value := range values {
+		if err := flatten(key, value); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(pairs)
+	return pairs, nil
+}
+
+// encodeOverride encodes a value for --set: strings are kept as they are,
+// unless they would be read back as another type, e.g. "true".
+func encodeOverride(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		var decoded interface{}
+		if s != "" && yaml.Unmarshal([]byte(s), &decoded) == nil && decoded == s {
+			return s, nil
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// splitEscaped splits s around the occurrences of sep that aren't escaped by
+// a backslash, unescaping them.
+func splitEscaped(s string, sep byte) []string {
+	parts := []string{}
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == sep {
+			current.WriteByte(sep)
+			i++
+			continue
+		}
+		if s[i] == sep {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(s[i])
+	}
+	return append(parts, current.String())
+}
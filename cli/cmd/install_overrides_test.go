@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestValueOverrides(t *testing.T) {
+	t.Run("Parses and prints comma-separated values", func(t *testing.T) {
+		var o valueOverrides
+		for _, s := range []string{`ImagePullPolicy=Never,PrometheusLogLevel=debug`, `ImagePullPolicy=Always`, `WebResources.CPU.Limit=a\,b=c`} {
+			if err := o.Set(s); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		expected := `ImagePullPolicy=Always,PrometheusLogLevel=debug,WebResources.CPU.Limit=a\,b=c`
+		if o.String() != expected {
+			t.Fatalf("Expected %s, got %s", expected, o.String())
+		}
+
+		var parsed valueOverrides
+		if err := parsed.Set(o.String()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(parsed, o) {
+			t.Fatalf("Expected %+v, got %+v", o, parsed)
+		}
+	})
+
+	t.Run("Removes the values of keys suffixed with a dash", func(t *testing.T) {
+		var o valueOverrides
+		if err := o.Set("ImagePullPolicy=Always,PrometheusLogLevel=debug,ImagePullPolicy-"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := o.merge("ImagePullPolicy=Never,WebResources.CPU.Limit=1"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "PrometheusLogLevel=debug,WebResources.CPU.Limit=1"
+		if o.String() != expected {
+			t.Fatalf("Expected %s, got %s", expected, o.String())
+		}
+	})
+
+	t.Run("Merges values under the ones already set", func(t *testing.T) {
+		var o valueOverrides
+		if err := o.Set("ImagePullPolicy=Always"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := o.merge("PrometheusLogLevel=debug,ImagePullPolicy=Never"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := "ImagePullPolicy=Always,PrometheusLogLevel=debug"
+		if o.String() != expected {
+			t.Fatalf("Expected %s, got %s", expected, o.String())
+		}
+	})
+
+	testCases := []struct {
+		value string
+		err   string
+	}{
+		{"ImagePullPolicy", `"ImagePullPolicy" must be of the form key=value or key-`},
+		{"WebResources..CPU=1", `invalid --set key "WebResources..CPU"`},
+		{"ImagePullPolicies=Always", `unknown value ImagePullPolicies; see "linkerd install --output-values" for the values`},
+		{"preInstallManifests=x", `unknown value preInstallManifests; see "linkerd install --output-values" for the values`},
+		{"UUID=x", "UUID can't be overridden, as it's set by the CLI"},
+		{"ControllerImage=gcr.io/linkerd-io/controller", "ControllerImage can't be overridden, as it's set by the CLI"},
+		{"ProxyInjectAnnotation=linkerd.io/inject", "ProxyInjectAnnotation can't be overridden, as it's set by the CLI"},
+		{"ResetCRDPreserveUnknownFields=true", "ResetCRDPreserveUnknownFields can't be overridden, as it's set by the CLI"},
+		{"ControllerImage-", "ControllerImage can't be overridden, as it's set by the CLI"},
+		{"Identity.TrustDomain=example.com", "Identity can't be overridden; use the --identity-* flags instead"},
+		{"ControllerReplicas=3", "ControllerReplicas can't be overridden; use --controller-replicas instead"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.value, func(t *testing.T) {
+			var o valueOverrides
+			err := o.Set(tc.value)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestApplyValueOverrides(t *testing.T) {
+	options := testInstallOptions()
+	options.highAvailability = true
+	flags := options.recordableFlagSet()
+	if err := flags.Set("set", "ImagePullPolicy=Always,WebResources.CPU.Limit=500m,PrometheusLogLevel=debug"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	values, _, err := options.validateAndBuild(flags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values.ImagePullPolicy != "Always" || values.PrometheusLogLevel != "debug" {
+		t.Errorf("Expected the values to be overridden, got %+v", values)
+	}
+	// The other constraints of the HA defaults are kept.
+	expected := resources{
+		CPU:    constraints{Request: "100m", Limit: "500m"},
+		Memory: constraints{Request: "50Mi"},
+	}
+	if !reflect.DeepEqual(*values.WebResources, expected) || values.TapResources.CPU.Limit != "" {
+		t.Errorf("Expected the web resources %+v, got %+v", expected, *values.WebResources)
+	}
+
+	options = testInstallOptions()
+	flags = options.recordableFlagSet()
+	if err := flags.Set("set", "ControllerUID=root"); err == nil {
+		t.Fatalf("Expected an error for a value with a flag")
+	}
+	if err := flags.Set("set", "WebResources.CPU=1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := options.validateAndBuild(flags); err == nil {
+		t.Fatalf("Expected an error for a value of the wrong type")
+	}
+}
+
+func TestUpgradeKeepsValueOverrides(t *testing.T) {
+	clientset, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","cniEnabled":false,"version":"edge-19.4.1","identityContext":null,"autoInjectContext":null}
+  proxy: |
+    {"proxyImage":{"imageName":"gcr.io/linkerd-io/proxy","pullPolicy":"IfNotPresent"},"proxyInitImage":{"imageName":"gcr.io/linkerd-io/proxy-init","pullPolicy":"IfNotPresent"},"controlPort":{"port":4190},"ignoreInboundPorts":[],"ignoreOutboundPorts":[],"inboundPort":{"port":4143},"adminPort":{"port":4191},"outboundPort":{"port":4140},"resource":{"requestCpu":"","requestMemory":"","limitCpu":"","limitMemory":""},"proxyUid":"2102","logLevel":{"level":"warn,linkerd2_proxy=info"},"disableExternalProfiles":true}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[{"name":"set","value":"ImagePullPolicy=Always,WebResources.CPU.Limit=500m"}]}`)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+	if err := flags.Set("set", "WebResources.CPU.Limit=1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	values, configs, err := options.validateAndBuild(clientset, flags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values.ImagePullPolicy != "Always" || values.WebResources.CPU.Limit != "1" {
+		t.Errorf("Expected the recorded values to be kept under the command line's, got %+v", values)
+	}
+
+	expected := []*pb.Install_Flag{{Name: "set", Value: "WebResources.CPU.Limit=1,ImagePullPolicy=Always"}}
+	if !reflect.DeepEqual(configs.GetInstall().GetFlags(), expected) {
+		t.Errorf("Expected the flags %v, got %v", expected, configs.GetInstall().GetFlags())
+	}
+
+	// A recorded value is dropped by setting its key with a dash.
+	options = testUpgradeOptions()
+	flags = options.recordableFlagSet()
+	if err := flags.Set("set", "ImagePullPolicy-"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	values, configs, err = options.validateAndBuild(clientset, flags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values.ImagePullPolicy != options.imagePullPolicy || values.WebResources.CPU.Limit != "500m" {
+		t.Errorf("Expected the recorded pull policy to be dropped, got %+v", values)
+	}
+
+	expected = []*pb.Install_Flag{{Name: "set", Value: "WebResources.CPU.Limit=500m"}}
+	if !reflect.DeepEqual(configs.GetInstall().GetFlags(), expected) {
+		t.Errorf("Expected the flags %v, got %v", expected, configs.GetInstall().GetFlags())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/gen/config"
@@ -22,25 +24,36 @@ func TestRender(t *testing.T) {
 	metaConfig := metaOptions.configs(nil)
 	metaConfig.Global.LinkerdNamespace = "Namespace"
 	metaValues := &installValues{
-		Namespace:                "Namespace",
-		ControllerImage:          "ControllerImage",
-		WebImage:                 "WebImage",
-		PrometheusImage:          "PrometheusImage",
-		GrafanaImage:             "GrafanaImage",
-		ImagePullPolicy:          "ImagePullPolicy",
-		UUID:                     "UUID",
-		CliVersion:               "CliVersion",
-		ControllerLogLevel:       "ControllerLogLevel",
-		PrometheusLogLevel:       "PrometheusLogLevel",
-		ControllerComponentLabel: "ControllerComponentLabel",
-		CreatedByAnnotation:      "CreatedByAnnotation",
-		ProxyContainerName:       "ProxyContainerName",
-		ProxyAutoInjectEnabled:   true,
-		ProxyInjectAnnotation:    "ProxyInjectAnnotation",
-		ProxyInjectDisabled:      "ProxyInjectDisabled",
-		ControllerUID:            2103,
-		EnableH2Upgrade:          true,
-		NoInitContainer:          false,
+		Namespace:                  "Namespace",
+		ControllerImage:            "ControllerImage",
+		WebImage:                   "WebImage",
+		PrometheusImage:            "PrometheusImage",
+		GrafanaImage:               "GrafanaImage",
+		ImagePullPolicy:            "ImagePullPolicy",
+		UUID:                       "UUID",
+		CliVersion:                 "CliVersion",
+		ControllerLogLevel:         "ControllerLogLevel",
+		ControllerKubeAPIQPS:       50,
+		ControllerKubeAPIBurst:     100,
+		PrometheusLogLevel:         "PrometheusLogLevel",
+		ControllerComponentLabel:   "ControllerComponentLabel",
+		ControllerNSLabel:          "ControllerNSLabel",
+		CreatedByAnnotation:        "CreatedByAnnotation",
+		ProxyContainerName:         "ProxyContainerName",
+		ProxyAutoInjectEnabled:     true,
+		ProxyInjectAnnotation:      "ProxyInjectAnnotation",
+		ProxyInjectDisabled:        "ProxyInjectDisabled",
+		AdmissionWebhooksLabel:     "AdmissionWebhooksLabel",
+		AdmissionWebhooksDisabled:  "AdmissionWebhooksDisabled",
+		InjectorExcludedNamespaces: "InjectorExcludedNamespaces",
+		ControllerUID:              2103,
+		EnableH2Upgrade:            true,
+		NoInitContainer:            false,
+		ClusterName:                "ClusterName",
+		GrafanaAPIKeySecret:        "GrafanaAPIKeySecret",
+		AllowedNamespaces:          "AllowedNamespaces",
+		IdentityPeerNamespaces:     "IdentityPeerNamespaces",
+		CRDAPIVersion:              "CRDAPIVersion",
 		Configs: configJSONs{
 			Global:  "GlobalConfig",
 			Proxy:   "ProxyConfig",
@@ -68,6 +81,15 @@ func TestRender(t *testing.T) {
 	haWithOverridesOptions.proxyMemoryRequest = "300Mi"
 	haWithOverridesValues, haWithOverridesConfig, _ := haWithOverridesOptions.validateAndBuild(nil)
 
+	haWithProxyAutoInjectOptions := testInstallOptions()
+	haWithProxyAutoInjectOptions.recordedFlags = []*config.Install_Flag{
+		{Name: "ha", Value: "true"},
+		{Name: "proxy-auto-inject", Value: "true"},
+	}
+	haWithProxyAutoInjectOptions.highAvailability = true
+	haWithProxyAutoInjectOptions.proxyAutoInject = true
+	haWithProxyAutoInjectValues, haWithProxyAutoInjectConfig, _ := haWithProxyAutoInjectOptions.validateAndBuild(nil)
+
 	noInitContainerOptions := testInstallOptions()
 	noInitContainerOptions.recordedFlags = []*config.Install_Flag{{Name: "linkerd-cni-enabled", Value: "true"}}
 	noInitContainerOptions.noInitContainer = true
@@ -82,6 +104,34 @@ func TestRender(t *testing.T) {
 	noInitContainerWithProxyAutoInjectOptions.proxyAutoInject = true
 	noInitContainerWithProxyAutoInjectValues, noInitContainerWithProxyAutoInjectConfig, _ := noInitContainerWithProxyAutoInjectOptions.validateAndBuild(nil)
 
+	kubernetesV116Options := testInstallOptions()
+	kubernetesV116Options.kubernetesVersion = "v1.16.0"
+	kubernetesV116Values, kubernetesV116Config, _ := kubernetesV116Options.validateAndBuild(nil)
+
+	scopedWebhooksOptions := testInstallOptions()
+	scopedWebhooksOptions.recordedFlags = []*config.Install_Flag{
+		{Name: "proxy-auto-inject", Value: "true"},
+		{Name: "scoped-webhooks", Value: "true"},
+	}
+	scopedWebhooksOptions.proxyAutoInject = true
+	scopedWebhooksOptions.scopedWebhooks = true
+	scopedWebhooksValues, scopedWebhooksConfig, _ := scopedWebhooksOptions.validateAndBuild(nil)
+
+	smiMetricsOptions := testInstallOptions()
+	smiMetricsOptions.recordedFlags = []*config.Install_Flag{{Name: "smi-metrics", Value: "true"}}
+	smiMetricsOptions.smiMetrics = true
+	smiMetricsValues, smiMetricsConfig, _ := smiMetricsOptions.validateAndBuild(nil)
+
+	controlPlaneTLSOptions := testInstallOptions()
+	controlPlaneTLSOptions.recordedFlags = []*config.Install_Flag{{Name: "control-plane-tls", Value: "true"}}
+	controlPlaneTLSOptions.controlPlaneTLS = true
+	controlPlaneTLSValues, controlPlaneTLSConfig, _ := controlPlaneTLSOptions.validateAndBuild(nil)
+
+	podMetricLabelsOptions := testInstallOptions()
+	podMetricLabelsOptions.recordedFlags = []*config.Install_Flag{{Name: "pod-metric-labels", Value: "[version,app.kubernetes.io/version]"}}
+	podMetricLabelsOptions.podMetricLabels = []string{"version", "app.kubernetes.io/version"}
+	podMetricLabelsValues, podMetricLabelsConfig, _ := podMetricLabelsOptions.validateAndBuild(nil)
+
 	testCases := []struct {
 		values         *installValues
 		configs        *config.All
@@ -91,8 +141,14 @@ func TestRender(t *testing.T) {
 		{metaValues, metaConfig, "install_output.golden"},
 		{haValues, haConfig, "install_ha_output.golden"},
 		{haWithOverridesValues, haWithOverridesConfig, "install_ha_with_overrides_output.golden"},
+		{haWithProxyAutoInjectValues, haWithProxyAutoInjectConfig, "install_ha_auto_inject.golden"},
 		{noInitContainerValues, noInitContainerConfig, "install_no_init_container.golden"},
 		{noInitContainerWithProxyAutoInjectValues, noInitContainerWithProxyAutoInjectConfig, "install_no_init_container_auto_inject.golden"},
+		{kubernetesV116Values, kubernetesV116Config, "install_kubernetes_v1_16.golden"},
+		{scopedWebhooksValues, scopedWebhooksConfig, "install_scoped_webhooks.golden"},
+		{smiMetricsValues, smiMetricsConfig, "install_smi_metrics.golden"},
+		{controlPlaneTLSValues, controlPlaneTLSConfig, "install_control_plane_tls.golden"},
+		{podMetricLabelsValues, podMetricLabelsConfig, "install_pod_metric_labels.golden"},
 	}
 
 	for i, tc := range testCases {
@@ -109,6 +165,44 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRenderCustomNamespace(t *testing.T) {
+	// Every component, webhook, RBAC subject and proxy of the control plane
+	// must follow --linkerd-namespace; none may refer to the default one.
+	defaultNamespacePattern := regexp.MustCompile(`(: |=|")` + defaultNamespace + `("|\n)|\.` + defaultNamespace + `\.(svc|serviceaccount)`)
+
+	for _, ha := range []bool{false, true} {
+		ha := ha // pin
+		t.Run(fmt.Sprintf("ha: %t", ha), func(t *testing.T) {
+			controlPlaneNamespace = "l5d-custom"
+			defer func() { controlPlaneNamespace = defaultNamespace }()
+
+			// the issuer credentials of testdata are issued for the default
+			// namespace, so the identity is generated instead
+			options := testInstallOptions()
+			options.identityOptions.crtPEMFile = ""
+			options.identityOptions.keyPEMFile = ""
+			options.identityOptions.trustPEMFile = ""
+			options.highAvailability = ha
+			options.proxyAutoInject = true
+			options.smiMetrics = true
+			values, configs, err := options.validateAndBuild(nil)
+			if err != nil {
+				t.Fatalf("Unexpected error validating options: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := values.render(&buf, configs); err != nil {
+				t.Fatalf("Failed to render templates: %v", err)
+			}
+			for i, line := range strings.Split(buf.String(), "\n") {
+				if defaultNamespacePattern.MatchString(line + "\n") {
+					t.Errorf("Line %d refers to the %s namespace: %s", i+1, defaultNamespace, line)
+				}
+			}
+		})
+	}
+}
+
 func testInstallOptions() *installOptions {
 	o := newInstallOptionsWithDefaults()
 	o.ignoreCluster = true
@@ -142,6 +236,87 @@ func TestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects negative Kubernetes API client rate limits", func(t *testing.T) {
+		options := testInstallOptions()
+		options.controllerKubeAPIQPS = -1
+		expected := "--controller-kube-api-qps must not be negative"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+
+		options = testInstallOptions()
+		options.controllerKubeAPIBurst = -1
+		expected = "--controller-kube-api-burst must not be negative"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects invalid cluster names", func(t *testing.T) {
+		options := testInstallOptions()
+		options.clusterName = "US_East"
+		expected := "--cluster-name must be a valid DNS label: a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects invalid pod metric labels", func(t *testing.T) {
+		options := testInstallOptions()
+		options.podMetricLabels = []string{"version", "-version"}
+
+		err := options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if !strings.HasPrefix(err.Error(), "--pod-metric-labels must be valid label keys: ") {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Rejects invalid HA topology keys", func(t *testing.T) {
+		options := testInstallOptions()
+		options.haTopologyKey = ""
+		expected := "--ha-topology-key must be a valid label key: name part must be non-empty"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects invalid Kubernetes versions", func(t *testing.T) {
+		options := testInstallOptions()
+		options.kubernetesVersion = "1.16"
+		expected := "--kubernetes-version must be a valid Kubernetes version: unknown version string format [1.16]"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
 	t.Run("Properly validates proxy log level", func(t *testing.T) {
 		testCases := []struct {
 			input string
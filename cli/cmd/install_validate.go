@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// apiVersionRewrites lists, for each group/version and kind rendered by
+// install, the group/versions it can be rewritten to when the cluster doesn't
+// serve it, in order of preference. Resources not listed here can't be
+// rewritten without changing their schema.
+var apiVersionRewrites = map[string][]string{
+	"extensions/v1beta1/Deployment":                        {"apps/v1", "apps/v1beta2"},
+	"apps/v1/Deployment":                                   {"apps/v1beta2", "extensions/v1beta1"},
+	"rbac.authorization.k8s.io/v1beta1/ClusterRole":        {"rbac.authorization.k8s.io/v1"},
+	"rbac.authorization.k8s.io/v1beta1/ClusterRoleBinding": {"rbac.authorization.k8s.io/v1"},
+	"rbac.authorization.k8s.io/v1beta1/Role":               {"rbac.authorization.k8s.io/v1"},
+	"rbac.authorization.k8s.io/v1beta1/RoleBinding":        {"rbac.authorization.k8s.io/v1"},
+	"rbac.authorization.k8s.io/v1/ClusterRole":             {"rbac.authorization.k8s.io/v1beta1"},
+	"rbac.authorization.k8s.io/v1/ClusterRoleBinding":      {"rbac.authorization.k8s.io/v1beta1"},
+	"rbac.authorization.k8s.io/v1/Role":                    {"rbac.authorization.k8s.io/v1beta1"},
+	"rbac.authorization.k8s.io/v1/RoleBinding":             {"rbac.authorization.k8s.io/v1beta1"},
+	"policy/v1beta1/PodSecurityPolicy":                     {"extensions/v1beta1"},
+	"extensions/v1beta1/PodSecurityPolicy":                 {"policy/v1beta1"},
+}
+
+// servedAPIs holds the kinds served by the cluster, keyed by group/version.
+type servedAPIs map[string]map[string]bool
+
+func (s servedAPIs) serves(groupVersion, kind string) bool {
+	return s[groupVersion][kind]
+}
+
+// resourceTransformerValidate checks that the cluster serves every rendered
+// resource, rewriting resources to a compatible group/version where possible.
+type resourceTransformerValidate struct {
+	served    servedAPIs
+	rewritten []string
+}
+
+func (rt *resourceTransformerValidate) transform(bytes []byte) ([]byte, []inject.Report, error) {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(bytes, &meta); err != nil {
+		return nil, nil, err
+	}
+
+	// Empty documents, e.g. from disabled templates, are passed through.
+	if meta.Kind == "" || rt.served.serves(meta.APIVersion, meta.Kind) {
+		return bytes, nil, nil
+	}
+
+	for _, groupVersion := range apiVersionRewrites[meta.APIVersion+"/"+meta.Kind] {
+		if !rt.served.serves(groupVersion, meta.Kind) {
+			continue
+		}
+
+		result, err := rewriteAPIVersion(bytes, groupVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		rt.rewritten = append(rt.rewritten, fmt.Sprintf("%s %s => %s", meta.Kind, meta.APIVersion, groupVersion))
+		return result, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("the cluster doesn't serve %s %s and it can't be rewritten to a supported API version", meta.Kind, meta.APIVersion)
+}
+
+func (rt *resourceTransformerValidate) generateReport(_ []inject.Report, output io.Writer) {
+	for _, rewrite := range rt.rewritten {
+		fmt.Fprintf(output, "rewrote %s\n", rewrite)
+	}
+}
+
+// rewriteAPIVersion sets the apiVersion of the given resource. Deployments
+// rewritten from extensions/v1beta1 get the selector the old API would have
+// defaulted, as it's required by later versions.
+func rewriteAPIVersion(bytes []byte, groupVersion string) ([]byte, error) {
+	jsonBytes, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj unstructured.Unstructured
+	if err := obj.UnmarshalJSON(jsonBytes); err != nil {
+		return nil, err
+	}
+
+	if obj.GetKind() == "Deployment" && obj.GetAPIVersion() == "extensions/v1beta1" {
+		if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "selector"); !found {
+			labels, _, err := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+			if err != nil {
+				return nil, err
+			}
+			if err := unstructured.SetNestedStringMap(obj.Object, labels, "spec", "selector", "matchLabels"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	obj.SetAPIVersion(groupVersion)
+
+	jsonBytes, err = obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsonBytes)
+}
+
+// fetchServedAPIs returns the kinds served by the cluster. Group/versions the
+// cluster fails to describe (e.g. unavailable aggregated APIs) are omitted.
+func fetchServedAPIs(client discovery.DiscoveryInterface) (servedAPIs, error) {
+	resources, err := client.ServerResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	served := servedAPIs{}
+	for _, list := range resources {
+		kinds := map[string]bool{}
+		for _, res := range list.APIResources {
+			kinds[res.Kind] = true
+		}
+		served[list.GroupVersion] = kinds
+	}
+	return served, nil
+}
+
+// validateClusterCapabilities checks that the cluster supports the resources
+// the control plane creates at runtime, which can't be rewritten, and returns
+// warnings for cluster configuration that may prevent the control plane from
+// starting.
+func (options *installOptions) validateClusterCapabilities(served servedAPIs, podSecurityPolicies int) ([]string, error) {
+	required := map[string]string{
+		"ValidatingWebhookConfiguration": "admissionregistration.k8s.io/v1beta1",
+	}
+	if options.proxyAutoInject {
+		required["MutatingWebhookConfiguration"] = "admissionregistration.k8s.io/v1beta1"
+	}
+
+	missing := []string{}
+	for kind, groupVersion := range required {
+		if !served.serves(groupVersion, kind) {
+			missing = append(missing, fmt.Sprintf("%s %s", kind, groupVersion))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("the cluster doesn't serve APIs required by the control plane: %s", strings.Join(missing, ", "))
+	}
+
+	warnings := []string{}
+	if podSecurityPolicies > 0 && !options.noInitContainer {
+		warnings = append(warnings, "the cluster has PodSecurityPolicies; make sure the control plane service accounts may use one that allows the NET_ADMIN capability, or install with --linkerd-cni-enabled")
+	}
+	return warnings, nil
+}
+
+// validateAgainstCluster checks the rendered manifests in r against the
+// capabilities of the current Kubernetes cluster, writing the manifests,
+// rewritten to API versions the cluster serves, to w.
+func (options *installOptions) validateAgainstCluster(r io.Reader, w, errWriter io.Writer) error {
+	kubeConfig, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return err
+	}
+
+	client, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	served, err := fetchServedAPIs(client.Discovery())
+	if err != nil {
+		return fmt.Errorf("failed to discover the cluster's APIs: %s", err)
+	}
+
+	podSecurityPolicies := 0
+	if served.serves("policy/v1beta1", "PodSecurityPolicy") {
+		psps, err := client.PolicyV1beta1().PodSecurityPolicies().List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		podSecurityPolicies = len(psps.Items)
+	}
+
+	warnings, err := options.validateClusterCapabilities(served, podSecurityPolicies)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(errWriter, "warning: %s\n", warning)
+	}
+
+	return processYAML(r, w, errWriter, &resourceTransformerValidate{served: served})
+}
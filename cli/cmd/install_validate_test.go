@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestResourceTransformerValidate(t *testing.T) {
+	deployment := `kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        linkerd.io/control-plane-component: controller
+`
+	clusterRole := `kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-linkerd-controller
+`
+
+	t.Run("Passes through resources the cluster serves", func(t *testing.T) {
+		rt := &resourceTransformerValidate{served: servedAPIs{
+			"extensions/v1beta1": {"Deployment": true},
+		}}
+
+		result, _, err := rt.transform([]byte(deployment))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if string(result) != deployment {
+			t.Fatalf("Expected the resource to be unchanged, got:\n%s", result)
+		}
+	})
+
+	t.Run("Rewrites Deployments to apps/v1 with a default selector", func(t *testing.T) {
+		rt := &resourceTransformerValidate{served: servedAPIs{
+			"apps/v1": {"Deployment": true},
+		}}
+
+		result, _, err := rt.transform([]byte(deployment))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      linkerd.io/control-plane-component: controller
+  template:
+    metadata:
+      labels:
+        linkerd.io/control-plane-component: controller
+`
+		if string(result) != expected {
+			t.Fatalf("Expected:\n%s\nGot:\n%s", expected, result)
+		}
+
+		var report bytes.Buffer
+		rt.generateReport(nil, &report)
+		if report.String() != "rewrote Deployment extensions/v1beta1 => apps/v1\n" {
+			t.Fatalf("Unexpected report: %s", report.String())
+		}
+	})
+
+	t.Run("Rewrites RBAC resources to v1", func(t *testing.T) {
+		rt := &resourceTransformerValidate{served: servedAPIs{
+			"rbac.authorization.k8s.io/v1": {"ClusterRole": true},
+		}}
+
+		result, _, err := rt.transform([]byte(clusterRole))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.HasPrefix(string(result), "apiVersion: rbac.authorization.k8s.io/v1\n") {
+			t.Fatalf("Expected ClusterRole to be rewritten to v1, got:\n%s", result)
+		}
+	})
+
+	t.Run("Fails for resources that can't be rewritten", func(t *testing.T) {
+		rt := &resourceTransformerValidate{served: servedAPIs{}}
+
+		_, _, err := rt.transform([]byte(clusterRole))
+		expected := "the cluster doesn't serve ClusterRole rbac.authorization.k8s.io/v1beta1 and it can't be rewritten to a supported API version"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+}
+
+func TestValidateClusterCapabilities(t *testing.T) {
+	served := servedAPIs{
+		"admissionregistration.k8s.io/v1beta1": {"ValidatingWebhookConfiguration": true},
+	}
+
+	t.Run("Accepts clusters serving the runtime APIs", func(t *testing.T) {
+		options := newInstallOptionsWithDefaults()
+		warnings, err := options.validateClusterCapabilities(served, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(warnings) != 0 {
+			t.Fatalf("Unexpected warnings: %v", warnings)
+		}
+	})
+
+	t.Run("Requires mutating webhooks when auto-inject is enabled", func(t *testing.T) {
+		options := newInstallOptionsWithDefaults()
+		options.proxyAutoInject = true
+
+		_, err := options.validateClusterCapabilities(served, 0)
+		expected := "the cluster doesn't serve APIs required by the control plane: MutatingWebhookConfiguration admissionregistration.k8s.io/v1beta1"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Warns about PodSecurityPolicies unless the CNI plugin is used", func(t *testing.T) {
+		options := newInstallOptionsWithDefaults()
+		warnings, err := options.validateClusterCapabilities(served, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(warnings) != 1 {
+			t.Fatalf("Expected 1 warning, got %v", warnings)
+		}
+
+		options.noInitContainer = true
+		warnings, err = options.validateClusterCapabilities(served, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(warnings) != 0 {
+			t.Fatalf("Unexpected warnings: %v", warnings)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// readValuesFile returns the install flags the chart values of the file given
+// by --values are rendered from. The values that don't map to a flag are
+// returned as the value of --set, which rejects the values that don't exist,
+// as the file is written by hand, unlike the values of a Helm release.
+func readValuesFile(path string) ([]*pb.Install_Flag, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("could not parse the values of %s: %s", path, err)
+	}
+
+	others := map[string]interface{}{}
+	for key, value := range values {
+		others[key] = value
+	}
+	for _, m := range helmValueFlags {
+		delete(others, m.value)
+	}
+	keys := []string{}
+	for key := range others {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateOverrideKey(key); err != nil {
+			return nil, fmt.Errorf("unsupported value in %s: %s", path, err)
+		}
+	}
+
+	flags := valuesFlags(values)
+	if len(others) > 0 {
+		pairs, err := valuesOverrides(others)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the values of %s: %s", path, err)
+		}
+		flags = append(flags, &pb.Install_Flag{Name: "set", Value: strings.Join(pairs, ",")})
+	}
+	return flags, nil
+}
+
+// setFlagsFromValues sets the flags that weren't set on the command line to
+// the values read by readValuesFile. As with the flags set on the command
+// line, they are recorded in the linkerd-config ConfigMap.
+func setFlagsFromValues(flags *pflag.FlagSet, installFlags []*pb.Install_Flag) error {
+	if flags == nil {
+		return nil
+	}
+
+	for _, i := range installFlags {
+		f := flags.Lookup(i.GetName())
+		if f == nil {
+			continue
+		}
+		if o, ok := f.Value.(*valueOverrides); ok && f.Changed {
+			// The values set on the command line take precedence.
+			if err := o.merge(i.GetValue()); err != nil {
+				return fmt.Errorf("invalid values for --set in the values file: %s", err)
+			}
+			continue
+		}
+		if f.Changed {
+			continue
+		}
+		if err := f.Value.Set(i.GetValue()); err != nil {
+			return fmt.Errorf("invalid value %q for --%s in the values file: %s", i.GetValue(), i.GetName(), err)
+		}
+		f.Changed = true
+	}
+	return nil
+}
+
+// uninjectedValuesWarning is printed along with the values written by
+// --output-values, as the proxies of the control plane are injected by the CLI
+// after rendering the chart, which Helm doesn't.
+const uninjectedValuesWarning = "The control plane rendered by Helm from these values isn't injected with the proxy; render it with the CLI, e.g. \"linkerd upgrade --from-helm-release <release>\", to mesh it"
+
+// writeValues writes the chart values the configs are rendered from, for
+// --output-values, e.g. to review them or to render the linkerd chart, which
+// the CLI renders the configs from.
+func writeValues(w io.Writer, values *installValues) error {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
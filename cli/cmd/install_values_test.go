@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"k8s.io/helm/pkg/chartutil"
+	"sigs.k8s.io/yaml"
+)
+
+func TestInstallValuesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "install-values")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Sets and records the flags of the values", func(t *testing.T) {
+		options := testInstallOptions()
+		options.valuesFile = write("values.yaml", `
+ControllerReplicas: 3
+ControllerLogLevel: info
+ControllerKubeAPIQPS: 1000000
+EnableH2Upgrade: false
+ClusterName: ""
+`)
+		flags := options.recordableFlagSet()
+		if err := flags.Set("controller-log-level", "debug"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		values, _, err := options.validateAndBuild(flags)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if values.ControllerReplicas != 3 || values.EnableH2Upgrade || values.ControllerKubeAPIQPS != 1000000 {
+			t.Errorf("Expected the values of the file to be rendered, got %+v", values)
+		}
+		if values.ControllerLogLevel != "debug" {
+			t.Errorf("Expected the command line to take precedence, got log level %s", values.ControllerLogLevel)
+		}
+
+		expected := []*pb.Install_Flag{
+			{Name: "controller-kube-api-qps", Value: "1e+06"},
+			{Name: "controller-log-level", Value: "debug"},
+			{Name: "controller-replicas", Value: "3"},
+			{Name: "disable-h2-upgrade", Value: "true"},
+		}
+		if !reflect.DeepEqual(options.recordedFlags, expected) {
+			t.Errorf("Expected recorded flags %v, got %v", expected, options.recordedFlags)
+		}
+	})
+
+	t.Run("Overrides and records the values that don't map to a flag", func(t *testing.T) {
+		options := testInstallOptions()
+		options.valuesFile = write("values.yaml", `
+ControllerReplicas: 3
+ImagePullPolicy: Always
+WebResources:
+  CPU:
+    Limit: 500m
+`)
+		flags := options.recordableFlagSet()
+		if err := flags.Set("set", "ImagePullPolicy=Never"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		values, _, err := options.validateAndBuild(flags)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if values.ControllerReplicas != 3 || values.WebResources.CPU.Limit != "500m" {
+			t.Errorf("Expected the values of the file to be rendered, got %+v", values)
+		}
+		if values.ImagePullPolicy != "Never" {
+			t.Errorf("Expected the command line to take precedence, got pull policy %s", values.ImagePullPolicy)
+		}
+
+		expected := []*pb.Install_Flag{
+			{Name: "controller-replicas", Value: "3"},
+			{Name: "set", Value: "ImagePullPolicy=Never,WebResources.CPU.Limit=500m"},
+		}
+		if !reflect.DeepEqual(options.recordedFlags, expected) {
+			t.Errorf("Expected recorded flags %v, got %v", expected, options.recordedFlags)
+		}
+	})
+
+	testCases := []struct {
+		desc     string
+		contents string
+		err      string
+	}{
+		{
+			desc:     "Rejects generated values",
+			contents: "ControllerReplicas: 3\nControllerImage: gcr.io/linkerd-io/controller\nNamespace: linkerd\n",
+			err:      "unsupported value in " + filepath.Join(dir, "values.yaml") + ": ControllerImage can't be overridden, as it's set by the CLI",
+		},
+		{
+			desc:     "Rejects unknown values",
+			contents: "ControllerImages: gcr.io/linkerd-io/controller\n",
+			err:      "unsupported value in " + filepath.Join(dir, "values.yaml") + ": unknown value ControllerImages",
+		},
+		{
+			desc:     "Rejects values of the wrong type",
+			contents: "WebResources:\n  CPU: 500m\n",
+			err:      "invalid --set values WebResources.CPU=500m",
+		},
+		{
+			desc:     "Rejects invalid values",
+			contents: "ControllerReplicas: many\n",
+			err:      `invalid value "many" for --controller-replicas in the values file`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			options := testInstallOptions()
+			options.valuesFile = write("values.yaml", tc.contents)
+
+			_, _, err := options.validateAndBuild(options.recordableFlagSet())
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("Expected error starting with %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestChartValues(t *testing.T) {
+	data, err := readIntoBytes(chartutil.ValuesfileName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defaults := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &defaults); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	values, _, err := testInstallOptions().validateAndBuild(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var buf strings.Builder
+	if err := writeValues(&buf, values); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(buf.String()), &output); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The values generated by the CLI have no default.
+	generated := map[string]bool{
+		"ControllerImage": true,
+		"WebImage":        true,
+		"GrafanaImage":    true,
+		"UUID":            true,
+		"CliVersion":      true,
+		"Configs":         true,
+		"History":         true,
+		"Identity":        true,
+	}
+	for key, value := range output {
+		def, ok := defaults[key]
+		if !ok && key != "PreviousConfigs" {
+			t.Errorf("Expected the chart to have a default for %s", key)
+			continue
+		}
+		if ok && !generated[key] && !reflect.DeepEqual(def, value) {
+			t.Errorf("Expected the default of %s to be the default of its flag, %v, got %v", key, value, def)
+		}
+	}
+	for key := range defaults {
+		if _, ok := output[key]; !ok {
+			t.Errorf("Unexpected value %s in the chart, which isn't rendered by the CLI", key)
+		}
+	}
+}
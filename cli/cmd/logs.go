@@ -2,20 +2,27 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/wercker/stern/stern"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 //This code replicates most of the functionality in https://github.com/wercker/stern/blob/master/cmd/cli.go
@@ -25,9 +32,13 @@ type logCmdConfig struct {
 }
 
 type logsOptions struct {
+	allContainers         bool
 	container             string
 	controlPlaneComponent string
+	namespace             string
 	noColor               bool
+	selector              string
+	setLevel              string
 	sinceSeconds          time.Duration
 	tail                  int64
 	timestamps            bool
@@ -35,9 +46,13 @@ type logsOptions struct {
 
 func newLogsOptions() *logsOptions {
 	return &logsOptions{
+		allContainers:         false,
 		container:             "",
 		controlPlaneComponent: "",
+		namespace:             "",
 		noColor:               false,
+		selector:              "",
+		setLevel:              "",
 		sinceSeconds:          48 * time.Hour,
 		tail:                  -1,
 		timestamps:            false,
@@ -47,6 +62,64 @@ func newLogsOptions() *logsOptions {
 func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers []string) (*stern.Config, error) {
 	config := &stern.Config{}
 
+	if o.allContainers && o.container != "" {
+		return nil, errors.New("--all-containers and --container are mutually exclusive")
+	}
+
+	if o.selector != "" {
+		if o.controlPlaneComponent != "" {
+			return nil, errors.New("--selector and --control-plane-component are mutually exclusive")
+		}
+		selector, err := labels.Parse(o.selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --selector value [%s]: %s", o.selector, err)
+		}
+		config.LabelSelector = selector
+		config.Namespace = o.namespace
+		config.AllNamespaces = o.namespace == ""
+	} else {
+		if o.namespace != "" {
+			return nil, errors.New("--namespace requires --selector, control plane logs are tailed from the control plane's namespace")
+		}
+		if err := o.controlPlaneSelector(config, controlPlaneComponents, availableContainers); err != nil {
+			return nil, err
+		}
+		config.Namespace = controlPlaneNamespace
+	}
+
+	// The pods selected with --selector are workloads, of which only the proxy
+	// logs are tailed unless asked otherwise.
+	container := o.container
+	if container == "" && o.selector != "" && !o.allContainers {
+		container = fmt.Sprintf("^%s$", k8s.ProxyContainerName)
+	}
+	containerFilterRgx, err := regexp.Compile(container)
+	if err != nil {
+		return nil, err
+	}
+	config.ContainerQuery = containerFilterRgx
+
+	if o.tail != -1 {
+		config.TailLines = &o.tail
+	}
+
+	// Do not use regex to filter pods. Instead, we provide the list of all control plane components and use
+	// the label selector to filter logs.
+	podFilterRgx, err := regexp.Compile("")
+	if err != nil {
+		return nil, err
+	}
+	config.PodQuery = podFilterRgx
+	config.Since = o.sinceSeconds
+	config.Timestamps = o.timestamps
+
+	return config, nil
+}
+
+// controlPlaneSelector sets the label selector of the config to select the
+// pods of --control-plane-component, after checking that it and --container
+// exist in the control plane.
+func (o *logsOptions) controlPlaneSelector(config *stern.Config, controlPlaneComponents, availableContainers []string) error {
 	if o.controlPlaneComponent == "" {
 		config.LabelSelector = labels.Everything()
 	} else {
@@ -59,11 +132,11 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 		}
 
 		if podExists == "" {
-			return nil, fmt.Errorf("control plane component [%s] does not exist. Must be one of %v", o.controlPlaneComponent, controlPlaneComponents)
+			return fmt.Errorf("control plane component [%s] does not exist. Must be one of %v", o.controlPlaneComponent, controlPlaneComponents)
 		}
 		selector, err := labels.Parse(fmt.Sprintf("linkerd.io/control-plane-component=%s", o.controlPlaneComponent))
 		if err != nil {
-			return nil, err
+			return err
 		}
 		config.LabelSelector = selector
 	}
@@ -77,32 +150,11 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 			}
 		}
 		if matchingContainer == "" {
-			return nil, fmt.Errorf("container [%s] does not exist in control plane [%s]", o.container, controlPlaneNamespace)
+			return fmt.Errorf("container [%s] does not exist in control plane [%s]", o.container, controlPlaneNamespace)
 		}
 	}
 
-	containerFilterRgx, err := regexp.Compile(o.container)
-	if err != nil {
-		return nil, err
-	}
-	config.ContainerQuery = containerFilterRgx
-
-	if o.tail != -1 {
-		config.TailLines = &o.tail
-	}
-
-	// Do not use regex to filter pods. Instead, we provide the list of all control plane components and use
-	// the label selector to filter logs.
-	podFilterRgx, err := regexp.Compile("")
-	if err != nil {
-		return nil, err
-	}
-	config.PodQuery = podFilterRgx
-	config.Since = o.sinceSeconds
-	config.Timestamps = o.timestamps
-	config.Namespace = controlPlaneNamespace
-
-	return config, nil
+	return nil
 }
 
 func getControlPlaneComponentsAndContainers(pods *corev1.PodList) ([]string, []string) {
@@ -127,12 +179,15 @@ func newLogCmdConfig(options *logsOptions, kubeconfigPath, kubeContext string) (
 		return nil, err
 	}
 
-	podList, err := clientset.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
+	var components, containers []string
+	if options.selector == "" {
+		podList, err := clientset.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
 
-	components, containers := getControlPlaneComponentsAndContainers(podList)
+		components, containers = getControlPlaneComponentsAndContainers(podList)
+	}
 
 	c, err := options.toSternConfig(components, containers)
 	if err != nil {
@@ -151,7 +206,16 @@ func newCmdLogs() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "logs [flags]",
 		Short: "Tail logs from containers in the Linkerd control plane",
-		Long:  `Tail logs from containers in the Linkerd control plane.`,
+		Long: `Tail logs from containers in the Linkerd control plane.
+
+  With --selector, logs are tailed from the pods matching the label selector
+  instead, in every namespace unless --namespace is set. Only the logs of their
+  linkerd-proxy container are tailed, unless --container or --all-containers is
+  set.
+
+  With --set-level, the log level of a control plane container is changed
+  through its local admin server instead, without restarting it. The change
+  lasts until the container restarts.`,
 		Example: `  # Tail logs from all containers in the prometheus control plane component
   linkerd logs --control-plane-component prometheus
 
@@ -163,8 +227,25 @@ func newCmdLogs() *cobra.Command {
 
   # Tail logs from the linkerd-proxy container in the controller component showing timestamps for each line
   linkerd logs --control-plane-component controller --container linkerd-proxy --timestamps
+
+  # Log debug messages from the destination container of every controller pod
+  linkerd logs --set-level destination=debug
+
+  # Tail the proxy logs of the last 10 minutes from the web pods of every namespace
+  linkerd logs --selector app=web --since 10m
+
+  # Tail the logs of every container of the web pods in the emojivoto namespace
+  linkerd logs --selector app=web --namespace emojivoto --all-containers
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.setLevel != "" {
+				container, level, err := parseSetLevel(options.setLevel)
+				if err != nil {
+					return err
+				}
+				return setControlPlaneLogLevel(container, level, os.Stdout)
+			}
+
 			color.NoColor = options.noColor
 
 			opts, err := newLogCmdConfig(options, kubeconfigPath, kubeContext)
@@ -177,9 +258,13 @@ func newCmdLogs() *cobra.Command {
 		},
 	}
 
+	cmd.PersistentFlags().BoolVar(&options.allContainers, "all-containers", options.allContainers, "Tail logs from every container of the pods selected with --selector, instead of only the linkerd-proxy container")
 	cmd.PersistentFlags().StringVarP(&options.container, "container", "c", options.container, "Tail logs from the specified container. Options are 'public-api', 'destination', 'tap', 'prometheus', 'grafana' or 'linkerd-proxy'")
 	cmd.PersistentFlags().StringVar(&options.controlPlaneComponent, "control-plane-component", options.controlPlaneComponent, "Tail logs from the specified control plane component. Default value (empty string) causes this command to tail logs from all resources marked with the 'linkerd.io/control-plane-component' label selector")
+	cmd.PersistentFlags().StringVar(&options.namespace, "namespace", options.namespace, "Namespace of the pods selected with --selector (default: all namespaces)")
 	cmd.PersistentFlags().BoolVarP(&options.noColor, "no-color", "n", options.noColor, "Disable colorized output") // needed until at least https://github.com/wercker/stern/issues/69 is resolved
+	cmd.PersistentFlags().StringVar(&options.selector, "selector", options.selector, "Tail logs from the pods matching this label selector, e.g. app=web, instead of the control plane")
+	cmd.PersistentFlags().StringVar(&options.setLevel, "set-level", options.setLevel, "Change the log level of a control plane container, as container=level, instead of tailing logs. Levels are 'panic', 'fatal', 'error', 'warn', 'info' or 'debug'")
 	cmd.PersistentFlags().DurationVarP(&options.sinceSeconds, "since", "s", options.sinceSeconds, "Duration of how far back logs should be retrieved")
 	cmd.PersistentFlags().Int64Var(&options.tail, "tail", options.tail, "Last number of log lines to show for a given container. -1 does not show previous log lines")
 	cmd.PersistentFlags().BoolVarP(&options.timestamps, "timestamps", "t", options.timestamps, "Print timestamps for each given log line")
@@ -222,10 +307,118 @@ func runLogOutput(opts *logCmdConfig) error {
 			if _, ok := tails[a.GetID()]; !ok {
 				tails[a.GetID()] = newTail
 			}
-			newTail.Start(ctx, podInterface)
+			// Logs are requested from the pod's own namespace, as pods are
+			// watched in every namespace with --selector.
+			newTail.Start(ctx, opts.clientset.CoreV1().Pods(a.Namespace))
 		}
 	}()
 
 	<-sigCh
 	return nil
 }
+
+// parseSetLevel parses a --set-level value of the form container=level.
+func parseSetLevel(value string) (string, log.Level, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", 0, fmt.Errorf("invalid --set-level value [%s], must be of the form container=level", value)
+	}
+
+	level, err := log.ParseLevel(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid log level [%s], must be one of: panic, fatal, error, warn, info, debug", parts[1])
+	}
+	return parts[0], level, nil
+}
+
+// adminContainerPods returns the running pods in which the given container
+// serves an admin endpoint.
+func adminContainerPods(pods []corev1.Pod, container string) []corev1.Pod {
+	matching := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name != container {
+				continue
+			}
+			for _, p := range c.Ports {
+				if p.Name == k8s.ControllerAdminPortName {
+					matching = append(matching, pod)
+				}
+			}
+		}
+	}
+	return matching
+}
+
+// setControlPlaneLogLevel changes the log level of the given container in
+// every control plane pod that runs it.
+func setControlPlaneLogLevel(container string, level log.Level, out io.Writer) error {
+	config, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	podList, err := clientset.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	pods := adminContainerPods(podList.Items, container)
+	if len(pods) == 0 {
+		return fmt.Errorf("no running control plane pods have an admin server for container [%s]", container)
+	}
+
+	for _, pod := range pods {
+		if err := setPodLogLevel(config, clientset, pod, container, level); err != nil {
+			return fmt.Errorf("failed to set the log level of %s/%s: %s", pod.GetName(), container, err)
+		}
+		fmt.Fprintf(out, "set the log level of %s/%s to %s\n", pod.GetName(), container, level)
+	}
+	return nil
+}
+
+func setPodLogLevel(config *rest.Config, clientset kubernetes.Interface, pod corev1.Pod, container string, level log.Level) error {
+	portforward, err := k8s.NewControllerAdminLocalForward(config, clientset, pod, container, verbose)
+	if err != nil {
+		return err
+	}
+
+	defer portforward.Stop()
+
+	go func() {
+		err := portforward.Run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running port-forward: %s", err)
+			portforward.Stop()
+		}
+	}()
+
+	<-portforward.Ready()
+
+	req, err := http.NewRequest(http.MethodPut, portforward.URLFor("/log-level"), strings.NewReader(level.String()))
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
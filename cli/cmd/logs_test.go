@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
 	"github.com/wercker/stern/stern"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -105,3 +108,118 @@ func TestNewSternConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSternConfigWithSelector(t *testing.T) {
+	testCases := []struct {
+		options           logsOptions
+		expectedErr       string
+		expectedSelector  string
+		expectedContainer string
+		expectedNamespace string
+	}{
+		{
+			options:           logsOptions{selector: "app=web"},
+			expectedSelector:  "app=web",
+			expectedContainer: "^linkerd-proxy$",
+		},
+		{
+			options:           logsOptions{selector: "app=web", namespace: "emojivoto", allContainers: true},
+			expectedSelector:  "app=web",
+			expectedNamespace: "emojivoto",
+		},
+		{
+			options:           logsOptions{selector: "app in (web,voting)", container: "web-svc"},
+			expectedSelector:  "app in (voting,web)",
+			expectedContainer: "web-svc",
+		},
+		{
+			options:     logsOptions{selector: "app=web", controlPlaneComponent: "grafana"},
+			expectedErr: "--selector and --control-plane-component are mutually exclusive",
+		},
+		{
+			options:     logsOptions{selector: "app=web", container: "web-svc", allContainers: true},
+			expectedErr: "--all-containers and --container are mutually exclusive",
+		},
+		{
+			options:     logsOptions{namespace: "emojivoto"},
+			expectedErr: "--namespace requires --selector, control plane logs are tailed from the control plane's namespace",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			tc.options.tail = -1
+			config, err := tc.options.toSternConfig(nil, nil)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if config.LabelSelector.String() != tc.expectedSelector {
+				t.Fatalf("Expected label selector [%s], got [%s]", tc.expectedSelector, config.LabelSelector)
+			}
+			if config.ContainerQuery.String() != tc.expectedContainer {
+				t.Fatalf("Expected container query [%s], got [%s]", tc.expectedContainer, config.ContainerQuery)
+			}
+			if config.Namespace != tc.expectedNamespace || config.AllNamespaces != (tc.expectedNamespace == "") {
+				t.Fatalf("Expected namespace [%s], got [%s] (all namespaces: %t)", tc.expectedNamespace, config.Namespace, config.AllNamespaces)
+			}
+		})
+	}
+}
+
+func TestParseSetLevel(t *testing.T) {
+	container, level, err := parseSetLevel("destination=debug")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if container != "destination" || level != log.DebugLevel {
+		t.Fatalf("Expected destination=debug, got %s=%s", container, level)
+	}
+
+	for value, expected := range map[string]string{
+		"destination":         "invalid --set-level value [destination], must be of the form container=level",
+		"=debug":              "invalid --set-level value [=debug], must be of the form container=level",
+		"destination=verbose": "invalid log level [verbose], must be one of: panic, fatal, error, warn, info, debug",
+	} {
+		_, _, err := parseSetLevel(value)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q for %s, got %v", expected, value, err)
+		}
+	}
+}
+
+func TestAdminContainerPods(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, portName string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "destination", Ports: []corev1.ContainerPort{{Name: portName}}},
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	pods := []corev1.Pod{
+		pod("controller-1", corev1.PodRunning, k8s.ControllerAdminPortName),
+		pod("controller-2", corev1.PodPending, k8s.ControllerAdminPortName),
+		pod("controller-3", corev1.PodRunning, "grpc"),
+		pod("controller-4", corev1.PodRunning, k8s.ControllerAdminPortName),
+	}
+
+	matching := adminContainerPods(pods, "destination")
+	if len(matching) != 2 || matching[0].Name != "controller-1" || matching[1].Name != "controller-4" {
+		t.Fatalf("Expected controller-1 and controller-4, got %v", matching)
+	}
+	if matching := adminContainerPods(pods, "tap"); len(matching) != 0 {
+		t.Fatalf("Expected no pods for tap, got %v", matching)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
@@ -24,6 +25,10 @@ func TestMain(m *testing.M) {
 	prettyDiff = os.Getenv("LINKERD_TEST_PRETTY_DIFF") != ""
 	flag.BoolVar(&prettyDiff, "pretty-diff", prettyDiff, "display the full text when diffing")
 	flag.Parse()
+
+	historyNow = func() time.Time { return time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC) }
+	historyUser = func() string { return "test" }
+
 	os.Exit(m.Run())
 }
 
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer("|", `\|`)
+
+// writeMarkdownTable writes the rows as a GitHub-flavored markdown table, as
+// rendered by GitHub and chat clients.
+func writeMarkdownTable(w io.Writer, headers []string, rows [][]string) {
+	writeMarkdownRow(w, headers)
+
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(w, separators)
+
+	for _, row := range rows {
+		writeMarkdownRow(w, row)
+	}
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = markdownEscaper.Replace(cell)
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+}
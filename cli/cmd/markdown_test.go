@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteMarkdownTable(t *testing.T) {
+	var buf bytes.Buffer
+	writeMarkdownTable(&buf, []string{"ROUTE", "RPS"}, [][]string{
+		{"GET /books/{id}", "1.5rps"},
+		{"GET /search?q=a|b", "0.5rps"},
+	})
+
+	expected := `| ROUTE | RPS |
+| --- | --- |
+| GET /books/{id} | 1.5rps |
+| GET /search?q=a\|b | 0.5rps |
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
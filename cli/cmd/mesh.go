@@ -0,0 +1,515 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartedAtAnnotation is the pod template annotation set by `kubectl rollout
+// restart`, reused so that restarts from either tool look the same.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+type meshOptions struct {
+	restart        bool
+	yes            bool
+	deleteProfiles bool
+	wait           time.Duration
+}
+
+func newMeshOptions() *meshOptions {
+	return &meshOptions{
+		restart:        false,
+		yes:            false,
+		deleteProfiles: false,
+		wait:           300 * time.Second,
+	}
+}
+
+// meshWorkload identifies a workload whose pods are rolled to add or remove
+// their proxies.
+type meshWorkload struct {
+	kind string
+	name string
+}
+
+func (w meshWorkload) String() string {
+	return fmt.Sprintf("%s/%s", w.kind, w.name)
+}
+
+func newCmdMesh() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mesh",
+		Short: "Manage the namespaces that are part of the mesh",
+		Long: `Manage the namespaces that are part of the mesh.
+
+The mesh subcommands configure a namespace for the proxy injector, roll its
+existing workloads and check its proxies, in a single step.`,
+		Args: cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdMeshEnable())
+	cmd.AddCommand(newCmdMeshDisable())
+
+	return cmd
+}
+
+func newCmdMeshEnable() *cobra.Command {
+	options := newMeshOptions()
+
+	cmd := &cobra.Command{
+		Use:   "enable [flags] (NAMESPACE)",
+		Short: "Enable proxy auto-injection for a namespace",
+		Long: `Enable proxy auto-injection for a namespace.
+
+The namespace is annotated with linkerd.io/inject=enabled, so that the proxy
+injector adds the proxy to every pod subsequently created in it. With
+--restart, the existing deployments, daemonsets and statefulsets of the
+namespace are restarted so that their pods are injected. The data plane checks
+are then run for the namespace.
+
+The control plane must have been installed with --proxy-auto-inject. If it was
+installed with --scoped-webhooks, the namespace is also labeled with
+linkerd.io/control-plane-ns, so that its proxy injector handles the namespace.`,
+		Example: `  # Enable auto-injection for the emojivoto namespace.
+  linkerd mesh enable ns/emojivoto
+
+  # Also restart the namespace's workloads, without asking for confirmation.
+  linkerd mesh enable emojivoto --restart --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := parseNamespaceArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+
+			scoped, err := checkProxyInjectorInstalled(client, controlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+
+			if scoped {
+				if err := setNamespaceControlPlane(client, namespace, controlPlaneNamespace); err != nil {
+					return err
+				}
+				fmt.Fprintf(stdout, "namespace \"%s\" labeled with %s=%s\n", namespace, k8s.ControllerNSLabel, controlPlaneNamespace)
+			}
+
+			if err := setNamespaceInjection(client, namespace, k8s.ProxyInjectEnabled); err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "namespace \"%s\" annotated with %s=%s\n", namespace, k8s.ProxyInjectAnnotation, k8s.ProxyInjectEnabled)
+
+			if options.restart {
+				workloads, err := uninjectedWorkloads(client, namespace)
+				if err != nil {
+					return err
+				}
+				if _, err := confirmAndRestart(client, namespace, workloads, options, os.Stdin, stdout); err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintln(stdout)
+			return configureAndRunChecks(stdout, &checkOptions{
+				dataPlaneOnly:  true,
+				waitForRollout: options.restart,
+				namespace:      namespace,
+				wait:           options.wait,
+				failOn:         checkFailOnErrors,
+				output:         tableOutput,
+			})
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&options.restart, "restart", options.restart, "Restart the namespace's existing workloads so that their pods are injected")
+	cmd.PersistentFlags().BoolVarP(&options.yes, "yes", "y", options.yes, "Restart workloads without asking for confirmation")
+	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for the data plane checks to pass")
+
+	return cmd
+}
+
+func newCmdMeshDisable() *cobra.Command {
+	options := newMeshOptions()
+
+	cmd := &cobra.Command{
+		Use:   "disable [flags] (NAMESPACE)",
+		Short: "Remove a namespace from the mesh",
+		Long: `Remove a namespace from the mesh.
+
+The linkerd.io/inject annotation is removed from the namespace, and the
+workloads whose pods were injected by the proxy injector are restarted so that
+their pods drop the proxy. With --delete-profiles, the ServiceProfiles of the
+namespace are deleted too. The command then waits until no pod in the
+namespace runs a proxy.
+
+Workloads that opt into injection in their pod template, or that were injected
+with 'linkerd inject', keep their proxies and have to be updated first.`,
+		Example: `  # Remove the emojivoto namespace from the mesh.
+  linkerd mesh disable ns/emojivoto
+
+  # Also delete its ServiceProfiles, without asking for confirmation.
+  linkerd mesh disable emojivoto --delete-profiles --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := parseNamespaceArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+
+			if err := setNamespaceInjection(client, namespace, ""); err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "annotation %s removed from namespace \"%s\"\n", k8s.ProxyInjectAnnotation, namespace)
+
+			workloads, pinned, err := injectedWorkloads(client, namespace)
+			if err != nil {
+				return err
+			}
+			for _, workload := range pinned {
+				fmt.Fprintf(stderr, "warning: %s keeps its proxy; remove it with 'linkerd uninject' or drop its %s annotation\n", workload, k8s.ProxyInjectAnnotation)
+			}
+
+			restarted, err := confirmAndRestart(client, namespace, workloads, options, os.Stdin, stdout)
+			if err != nil {
+				return err
+			}
+
+			if options.deleteProfiles {
+				spClient, err := newServiceProfileClient()
+				if err != nil {
+					return err
+				}
+				if err := deleteNamespaceProfiles(spClient, namespace, stdout); err != nil {
+					return err
+				}
+			}
+
+			if !restarted && len(workloads) > 0 {
+				fmt.Fprintln(stdout, "the namespace's pods keep their proxies until their workloads are restarted")
+				return nil
+			}
+
+			return waitForUnmeshedNamespace(client, namespace, pinned, time.Now().Add(options.wait), 5*time.Second, stdout)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(&options.yes, "yes", "y", options.yes, "Restart workloads without asking for confirmation")
+	cmd.PersistentFlags().BoolVar(&options.deleteProfiles, "delete-profiles", options.deleteProfiles, "Delete the ServiceProfiles of the namespace")
+	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for the namespace's proxies to be removed")
+
+	return cmd
+}
+
+// parseNamespaceArg accepts a namespace either by name or as a namespace
+// resource, e.g. "ns/emojivoto".
+func parseNamespaceArg(arg string) (string, error) {
+	if !strings.Contains(arg, "/") {
+		return arg, nil
+	}
+
+	res, err := util.BuildResource("", arg)
+	if err != nil {
+		return "", err
+	}
+	if res.GetType() != k8s.Namespace || res.GetName() == "" {
+		return "", fmt.Errorf("expected a namespace, e.g. ns/emojivoto, got %s", arg)
+	}
+	return res.GetName(), nil
+}
+
+func newKubernetesClient() (kubernetes.Interface, error) {
+	kubeConfig, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(kubeConfig)
+}
+
+// checkProxyInjectorInstalled returns whether the proxy injector of the control
+// plane in the given namespace is scoped to the namespaces labeled for it, or an
+// error if it isn't running.
+func checkProxyInjectorInstalled(client kubernetes.Interface, controllerNS string) (bool, error) {
+	webhooks := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+
+	_, err := webhooks.Get(k8s.ScopedWebhookConfigName(controllerNS, k8s.ProxyInjectorWebhookConfigName), metav1.GetOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return false, err
+	}
+
+	_, err = webhooks.Get(k8s.ProxyInjectorWebhookConfigName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, errors.New("the proxy injector isn't running; install the control plane with --proxy-auto-inject")
+	}
+	return false, err
+}
+
+// setNamespaceControlPlane labels the namespace for the control plane in the
+// given namespace, whose scoped webhooks only handle labeled namespaces.
+func setNamespaceControlPlane(client kubernetes.Interface, namespace, controllerNS string) error {
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[k8s.ControllerNSLabel] = controllerNS
+
+	_, err = client.CoreV1().Namespaces().Update(ns)
+	return err
+}
+
+// setNamespaceInjection sets the namespace's inject annotation to the given
+// value, or removes it if the value is empty.
+func setNamespaceInjection(client kubernetes.Interface, namespace, value string) error {
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		delete(ns.Annotations, k8s.ProxyInjectAnnotation)
+	} else {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[k8s.ProxyInjectAnnotation] = value
+	}
+
+	_, err = client.CoreV1().Namespaces().Update(ns)
+	return err
+}
+
+// uninjectedWorkloads returns the workloads of the namespace whose pods the
+// proxy injector would add the proxy to once restarted. Workloads that opt out
+// of injection or that already have the proxy in their template are skipped.
+func uninjectedWorkloads(client kubernetes.Interface, namespace string) ([]meshWorkload, error) {
+	templates, err := workloadTemplates(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := []meshWorkload{}
+	for workload, template := range templates {
+		if template.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectDisabled || hasProxyContainer(template.Spec) {
+			continue
+		}
+		workloads = append(workloads, workload)
+	}
+	sortWorkloads(workloads)
+	return workloads, nil
+}
+
+// injectedWorkloads returns the workloads of the namespace whose pods drop the
+// proxy once restarted, and the workloads that keep it regardless of the
+// namespace's annotation because their template has the proxy or opts into
+// injection.
+func injectedWorkloads(client kubernetes.Interface, namespace string) ([]meshWorkload, []meshWorkload, error) {
+	templates, err := workloadTemplates(client, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workloads := []meshWorkload{}
+	pinned := []meshWorkload{}
+	for workload, template := range templates {
+		switch {
+		case hasProxyContainer(template.Spec) || template.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectEnabled:
+			pinned = append(pinned, workload)
+		case template.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectDisabled:
+			// Never injected.
+		default:
+			workloads = append(workloads, workload)
+		}
+	}
+	sortWorkloads(workloads)
+	sortWorkloads(pinned)
+	return workloads, pinned, nil
+}
+
+func workloadTemplates(client kubernetes.Interface, namespace string) (map[meshWorkload]corev1.PodTemplateSpec, error) {
+	templates := map[meshWorkload]corev1.PodTemplateSpec{}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		templates[meshWorkload{k8s.Deployment, d.Name}] = d.Spec.Template
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range daemonSets.Items {
+		templates[meshWorkload{k8s.DaemonSet, ds.Name}] = ds.Spec.Template
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sts := range statefulSets.Items {
+		templates[meshWorkload{k8s.StatefulSet, sts.Name}] = sts.Spec.Template
+	}
+
+	return templates, nil
+}
+
+func hasProxyContainer(spec corev1.PodSpec) bool {
+	for _, container := range spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+func sortWorkloads(workloads []meshWorkload) {
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].String() < workloads[j].String()
+	})
+}
+
+// confirmAndRestart lists the workloads to restart and, once confirmed,
+// restarts them. It returns whether the workloads were restarted.
+func confirmAndRestart(client kubernetes.Interface, namespace string, workloads []meshWorkload, options *meshOptions, in io.Reader, out io.Writer) (bool, error) {
+	if len(workloads) == 0 {
+		fmt.Fprintln(out, "no workloads to restart")
+		return false, nil
+	}
+
+	fmt.Fprintf(out, "the following workloads in namespace \"%s\" will be restarted:\n", namespace)
+	for _, workload := range workloads {
+		fmt.Fprintf(out, "  %s\n", workload)
+	}
+	if !options.yes && !confirm(in, out, "restart them?") {
+		fmt.Fprintln(out, "skipped restarting workloads")
+		return false, nil
+	}
+
+	now := time.Now()
+	for _, workload := range workloads {
+		if err := restartWorkload(client, namespace, workload, now); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(out, "%s restarted\n", workload)
+	}
+	return true, nil
+}
+
+// confirm asks the given yes/no question, defaulting to no.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// restartWorkload rolls the workload's pods by updating an annotation of its
+// pod template.
+func restartWorkload(client kubernetes.Interface, namespace string, workload meshWorkload, now time.Time) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"%s":"%s"}}}}}`, restartedAtAnnotation, now.Format(time.RFC3339)))
+
+	var err error
+	switch workload.kind {
+	case k8s.Deployment:
+		_, err = client.AppsV1().Deployments(namespace).Patch(workload.name, types.StrategicMergePatchType, patch)
+	case k8s.DaemonSet:
+		_, err = client.AppsV1().DaemonSets(namespace).Patch(workload.name, types.StrategicMergePatchType, patch)
+	case k8s.StatefulSet:
+		_, err = client.AppsV1().StatefulSets(namespace).Patch(workload.name, types.StrategicMergePatchType, patch)
+	default:
+		err = fmt.Errorf("can't restart %s", workload)
+	}
+	return err
+}
+
+func deleteNamespaceProfiles(client spclient.Interface, namespace string, out io.Writer) error {
+	profiles, err := client.LinkerdV1alpha1().ServiceProfiles(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, profile := range profiles.Items {
+		if err := client.LinkerdV1alpha1().ServiceProfiles(namespace).Delete(profile.Name, &metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "serviceprofile \"%s\" deleted\n", profile.Name)
+	}
+	return nil
+}
+
+// waitForUnmeshedNamespace polls the namespace's pods until none of them runs
+// a proxy, so that no meshed traffic remains, or until the deadline passes.
+// The pods of the pinned workloads keep their proxy, and aren't waited for;
+// they're told apart by the workload labels added on injection.
+func waitForUnmeshedNamespace(client kubernetes.Interface, namespace string, pinned []meshWorkload, deadline time.Time, interval time.Duration, out io.Writer) error {
+	pinnedSet := map[meshWorkload]bool{}
+	for _, workload := range pinned {
+		pinnedSet[workload] = true
+	}
+
+	for {
+		pods, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		meshed := []string{}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || !hasProxyContainer(pod.Spec) {
+				continue
+			}
+			pod := pod // pin
+			if kind, name := podWorkload(&pod); pinnedSet[meshWorkload{kind, name}] {
+				continue
+			}
+			meshed = append(meshed, pod.Name)
+		}
+		if len(meshed) == 0 {
+			fmt.Fprintf(out, "no meshed pods remain in namespace \"%s\"\n", namespace)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			sort.Strings(meshed)
+			return fmt.Errorf("pods in namespace \"%s\" still run a proxy: %s", namespace, strings.Join(meshed, ", "))
+		}
+		time.Sleep(interval)
+	}
+}
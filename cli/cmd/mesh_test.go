@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	spfake "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func meshTestDeployment(name string, annotations map[string]string, containers ...string) *appsv1.Deployment {
+	spec := corev1.PodSpec{}
+	for _, container := range containers {
+		spec.Containers = append(spec.Containers, corev1.Container{Name: container})
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "emojivoto"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+				Spec:       spec,
+			},
+		},
+	}
+}
+
+func newMeshTestClient(objects ...runtime.Object) *fake.Clientset {
+	objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto"}})
+	return fake.NewSimpleClientset(objects...)
+}
+
+func TestParseNamespaceArg(t *testing.T) {
+	for arg, expected := range map[string]string{
+		"emojivoto":           "emojivoto",
+		"ns/emojivoto":        "emojivoto",
+		"namespace/emojivoto": "emojivoto",
+	} {
+		namespace, err := parseNamespaceArg(arg)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", arg, err)
+		}
+		if namespace != expected {
+			t.Fatalf("Expected %s to be parsed as %s, got %s", arg, expected, namespace)
+		}
+	}
+
+	if _, err := parseNamespaceArg("deploy/web"); err == nil {
+		t.Fatal("Expected an error for a non-namespace resource")
+	}
+}
+
+func TestSetNamespaceInjection(t *testing.T) {
+	client := newMeshTestClient()
+
+	if err := setNamespaceInjection(client, "emojivoto", k8s.ProxyInjectEnabled); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	ns, err := client.CoreV1().Namespaces().Get("emojivoto", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ns.Annotations[k8s.ProxyInjectAnnotation] != k8s.ProxyInjectEnabled {
+		t.Fatalf("Expected the namespace to be annotated, got %v", ns.Annotations)
+	}
+
+	if err := setNamespaceInjection(client, "missing", k8s.ProxyInjectEnabled); err == nil {
+		t.Fatal("Expected an error for a missing namespace")
+	}
+}
+
+func TestCheckProxyInjectorInstalled(t *testing.T) {
+	_, err := checkProxyInjectorInstalled(newMeshTestClient(), "linkerd")
+	expected := "the proxy injector isn't running; install the control plane with --proxy-auto-inject"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s] instead got [%s]", expected, err)
+	}
+
+	testCases := []struct {
+		name   string
+		scoped bool
+	}{
+		{"linkerd-proxy-injector-webhook-config", false},
+		{"linkerd-linkerd-b-proxy-injector-webhook-config", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			client := newMeshTestClient(&arv1beta1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.name},
+			})
+			scoped, err := checkProxyInjectorInstalled(client, "linkerd-b")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if scoped != tc.scoped {
+				t.Fatalf("Expected scoped to be %t, got %t", tc.scoped, scoped)
+			}
+		})
+	}
+}
+
+func TestSetNamespaceControlPlane(t *testing.T) {
+	client := newMeshTestClient()
+
+	if err := setNamespaceControlPlane(client, "emojivoto", "linkerd-b"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	ns, err := client.CoreV1().Namespaces().Get("emojivoto", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ns.Labels[k8s.ControllerNSLabel] != "linkerd-b" {
+		t.Fatalf("Expected the namespace to be labeled, got %v", ns.Labels)
+	}
+}
+
+func TestUninjectedWorkloads(t *testing.T) {
+	client := newMeshTestClient(
+		meshTestDeployment("web", nil, "web"),
+		meshTestDeployment("voting", nil, "voting"),
+		meshTestDeployment("emoji", nil, "emoji", k8s.ProxyContainerName),
+		meshTestDeployment("vote-bot", map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled}, "vote-bot"),
+	)
+
+	workloads, err := uninjectedWorkloads(client, "emojivoto")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []meshWorkload{{k8s.Deployment, "voting"}, {k8s.Deployment, "web"}}
+	if len(workloads) != len(expected) {
+		t.Fatalf("Expected workloads %v, got %v", expected, workloads)
+	}
+	for i := range expected {
+		if workloads[i] != expected[i] {
+			t.Fatalf("Expected workloads %v, got %v", expected, workloads)
+		}
+	}
+}
+
+func TestConfirmAndRestart(t *testing.T) {
+	workloads := []meshWorkload{{k8s.Deployment, "web"}}
+
+	t.Run("Restarts workloads once confirmed", func(t *testing.T) {
+		client := newMeshTestClient(meshTestDeployment("web", nil, "web"))
+
+		var out bytes.Buffer
+		restarted, err := confirmAndRestart(client, "emojivoto", workloads, newMeshOptions(), strings.NewReader("y\n"), &out)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !restarted {
+			t.Fatal("Expected the workloads to be restarted")
+		}
+
+		deploy, err := client.AppsV1().Deployments("emojivoto").Get("web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		restartedAt, err := time.Parse(time.RFC3339, deploy.Spec.Template.Annotations[restartedAtAnnotation])
+		if err != nil {
+			t.Fatalf("Expected the pod template to be annotated with a restart time: %s", err)
+		}
+		if time.Since(restartedAt) > time.Minute {
+			t.Fatalf("Unexpected restart time %s", restartedAt)
+		}
+		if !strings.HasSuffix(out.String(), "deployment/web restarted\n") {
+			t.Fatalf("Unexpected output: %s", out.String())
+		}
+	})
+
+	t.Run("Skips restarting workloads unless confirmed", func(t *testing.T) {
+		client := newMeshTestClient(meshTestDeployment("web", nil, "web"))
+
+		var out bytes.Buffer
+		restarted, err := confirmAndRestart(client, "emojivoto", workloads, newMeshOptions(), strings.NewReader("\n"), &out)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if restarted {
+			t.Fatal("Expected the workloads not to be restarted")
+		}
+
+		deploy, err := client.AppsV1().Deployments("emojivoto").Get("web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, ok := deploy.Spec.Template.Annotations[restartedAtAnnotation]; ok {
+			t.Fatal("Expected the deployment not to be restarted")
+		}
+		if !strings.HasSuffix(out.String(), "skipped restarting workloads\n") {
+			t.Fatalf("Unexpected output: %s", out.String())
+		}
+	})
+}
+
+func TestInjectedWorkloads(t *testing.T) {
+	client := newMeshTestClient(
+		meshTestDeployment("web", nil, "web"),
+		meshTestDeployment("emoji", nil, "emoji", k8s.ProxyContainerName),
+		meshTestDeployment("voting", map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}, "voting"),
+		meshTestDeployment("vote-bot", map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled}, "vote-bot"),
+	)
+
+	workloads, pinned, err := injectedWorkloads(client, "emojivoto")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(workloads) != 1 || workloads[0] != (meshWorkload{k8s.Deployment, "web"}) {
+		t.Fatalf("Expected only deployment/web to be restarted, got %v", workloads)
+	}
+	expectedPinned := []meshWorkload{{k8s.Deployment, "emoji"}, {k8s.Deployment, "voting"}}
+	if len(pinned) != len(expectedPinned) || pinned[0] != expectedPinned[0] || pinned[1] != expectedPinned[1] {
+		t.Fatalf("Expected pinned workloads %v, got %v", expectedPinned, pinned)
+	}
+}
+
+func TestDeleteNamespaceProfiles(t *testing.T) {
+	client := spfake.NewSimpleClientset(
+		&sp.ServiceProfile{ObjectMeta: metav1.ObjectMeta{Name: "web.emojivoto.svc.cluster.local", Namespace: "emojivoto"}},
+		&sp.ServiceProfile{ObjectMeta: metav1.ObjectMeta{Name: "books.booksapp.svc.cluster.local", Namespace: "booksapp"}},
+	)
+
+	var out bytes.Buffer
+	if err := deleteNamespaceProfiles(client, "emojivoto", &out); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.String() != "serviceprofile \"web.emojivoto.svc.cluster.local\" deleted\n" {
+		t.Fatalf("Unexpected output: %s", out.String())
+	}
+
+	for namespace, expected := range map[string]int{"emojivoto": 0, "booksapp": 1} {
+		profiles, err := client.LinkerdV1alpha1().ServiceProfiles(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(profiles.Items) != expected {
+			t.Fatalf("Expected %d profiles in %s, got %d", expected, namespace, len(profiles.Items))
+		}
+	}
+}
+
+func TestWaitForUnmeshedNamespace(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "emojivoto"},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		for _, container := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: container})
+		}
+		return p
+	}
+
+	t.Run("Succeeds once no pod runs a proxy", func(t *testing.T) {
+		client := newMeshTestClient(
+			pod("web-1", corev1.PodRunning, "web"),
+			pod("web-0", corev1.PodSucceeded, "web", k8s.ProxyContainerName),
+		)
+
+		var out bytes.Buffer
+		if err := waitForUnmeshedNamespace(client, "emojivoto", nil, time.Now(), time.Millisecond, &out); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Fails when meshed pods remain after the deadline", func(t *testing.T) {
+		client := newMeshTestClient(pod("emoji-1", corev1.PodRunning, "emoji", k8s.ProxyContainerName))
+
+		var out bytes.Buffer
+		err := waitForUnmeshedNamespace(client, "emojivoto", nil, time.Now(), time.Millisecond, &out)
+		expected := "pods in namespace \"emojivoto\" still run a proxy: emoji-1"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s] instead got [%s]", expected, err)
+		}
+	})
+	t.Run("Skips the pods of pinned workloads", func(t *testing.T) {
+		pinnedPod := pod("vote-bot-5b7f-1", corev1.PodRunning, "vote-bot", k8s.ProxyContainerName)
+		pinnedPod.Labels = map[string]string{k8s.ProxyDeploymentLabel: "vote-bot"}
+		client := newMeshTestClient(pinnedPod)
+
+		pinned := []meshWorkload{{k8s.Deployment, "vote-bot"}}
+		var out bytes.Buffer
+		if err := waitForUnmeshedNamespace(client, "emojivoto", pinned, time.Now(), time.Millisecond, &out); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := waitForUnmeshedNamespace(client, "emojivoto", nil, time.Now(), time.Millisecond, &out); err == nil {
+			t.Fatal("Expected an error for the pod of a workload that isn't pinned")
+		}
+	})
+}
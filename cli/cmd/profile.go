@@ -3,11 +3,19 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/profiles"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -20,6 +28,7 @@ type profileOptions struct {
 	tap           string
 	tapDuration   time.Duration
 	tapRouteLimit uint
+	alertRules    bool
 }
 
 func newProfileOptions() *profileOptions {
@@ -32,6 +41,7 @@ func newProfileOptions() *profileOptions {
 		tap:           "",
 		tapDuration:   5 * time.Second,
 		tapRouteLimit: 20,
+		alertRules:    false,
 	}
 }
 
@@ -49,8 +59,11 @@ func (options *profileOptions) validate() error {
 	if options.tap != "" {
 		outputs++
 	}
+	if options.alertRules {
+		outputs++
+	}
 	if outputs != 1 {
-		return errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+		return errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --alert-rules")
 	}
 
 	// a DNS-1035 label must consist of lower case alphanumeric characters or '-',
@@ -74,7 +87,7 @@ func newCmdProfile() *cobra.Command {
 	options := newProfileOptions()
 
 	cmd := &cobra.Command{
-		Use:   "profile [flags] (--template | --open-api file | --proto file | --tap resource) (SERVICE)",
+		Use:   "profile [flags] (--template | --open-api file | --proto file | --tap resource | --alert-rules) (SERVICE)",
 		Short: "Output service profile config for Kubernetes",
 		Long:  "Output service profile config for Kubernetes.",
 		Example: `  # Output a basic template to apply after modification.
@@ -88,6 +101,9 @@ func newCmdProfile() *cobra.Command {
 
   # Generate a profile by watching live traffic based off tap data.
   linkerd profile -n emojivoto web-svc --tap deploy/web --tap-duration 10s --tap-route-limit 5
+
+  # Generate Prometheus alerting rules from the route SLOs of an existing profile.
+  linkerd profile -n emojivoto --alert-rules web-svc
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -106,6 +122,8 @@ func newCmdProfile() *cobra.Command {
 				return profiles.RenderTapOutputProfile(checkPublicAPIClientOrExit(), options.tap, options.namespace, options.name, options.tapDuration, int(options.tapRouteLimit), os.Stdout)
 			} else if options.proto != "" {
 				return profiles.RenderProto(options.proto, options.namespace, options.name, os.Stdout)
+			} else if options.alertRules {
+				return renderAlertRules(options.namespace, options.name, os.Stdout)
 			}
 
 			// we should never get here
@@ -120,6 +138,247 @@ func newCmdProfile() *cobra.Command {
 	cmd.PersistentFlags().UintVar(&options.tapRouteLimit, "tap-route-limit", options.tapRouteLimit, "Max number of routes to add to the profile")
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
 	cmd.PersistentFlags().StringVar(&options.proto, "proto", options.proto, "Output a service profile based on the given Protobuf spec file")
+	cmd.PersistentFlags().BoolVar(&options.alertRules, "alert-rules", options.alertRules, "Output Prometheus alerting rules for the route SLOs of the service's existing service profile")
+
+	cmd.AddCommand(newCmdProfileExport())
+	cmd.AddCommand(newCmdProfileImport())
+	cmd.AddCommand(newCmdProfileConvert())
+
+	return cmd
+}
+
+type profileRewriteOptions struct {
+	rewriteNamespaces []string
+	clusterDomain     string
+}
+
+func (options *profileRewriteOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&options.rewriteNamespaces, "rewrite-namespace", options.rewriteNamespaces, "Move the profiles of a namespace to another namespace, given as FROM=TO (may be repeated)")
+	flags.StringVar(&options.clusterDomain, "cluster-domain", options.clusterDomain, "Replace the cluster domain in the names of the profiles (default: keep the source cluster domain)")
+}
+
+func (options *profileRewriteOptions) rewrite() (profiles.Rewrite, error) {
+	rewrite := profiles.Rewrite{
+		Namespaces:    map[string]string{},
+		ClusterDomain: options.clusterDomain,
+	}
+
+	for _, ns := range options.rewriteNamespaces {
+		parts := strings.Split(ns, "=")
+		if len(parts) != 2 {
+			return rewrite, fmt.Errorf("invalid --rewrite-namespace %q: must be of the form FROM=TO", ns)
+		}
+		for _, part := range parts {
+			if errs := validation.IsDNS1123Label(part); len(errs) != 0 {
+				return rewrite, fmt.Errorf("invalid namespace %q: %v", part, errs)
+			}
+		}
+		rewrite.Namespaces[parts[0]] = parts[1]
+	}
+
+	if options.clusterDomain != "" {
+		if errs := validation.IsDNS1123Subdomain(options.clusterDomain); len(errs) != 0 {
+			return rewrite, fmt.Errorf("invalid cluster domain %q: %v", options.clusterDomain, errs)
+		}
+	}
+
+	return rewrite, nil
+}
+
+func newCmdProfileExport() *cobra.Command {
+	options := &profileRewriteOptions{}
+	allNamespaces := false
+
+	cmd := &cobra.Command{
+		Use:   "export [flags] (NAMESPACE | --all-namespaces)",
+		Short: "Output the service profiles of a namespace",
+		Long: `Output the service profiles of a namespace.
+
+The profiles are output without their cluster-specific metadata, so that they
+can be applied to another cluster with "linkerd profile import".`,
+		Example: `  # Export the profiles of the emojivoto namespace.
+  linkerd profile export emojivoto > emojivoto-profiles.yaml
+
+  # Export the profiles of the staging namespace for the prod namespace of a
+  # cluster using the example.org cluster domain.
+  linkerd profile export staging --rewrite-namespace staging=prod --cluster-domain example.org`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if allNamespaces {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rewrite, err := options.rewrite()
+			if err != nil {
+				return err
+			}
+
+			namespace := metav1.NamespaceAll
+			if !allNamespaces {
+				namespace = args[0]
+			}
+
+			client, err := newServiceProfileClient()
+			if err != nil {
+				return err
+			}
+
+			list, err := client.LinkerdV1alpha1().ServiceProfiles(namespace).List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			exported := make([]*sp.ServiceProfile, len(list.Items))
+			for i := range list.Items {
+				exported[i] = &list.Items[i]
+			}
+			return profiles.RenderProfiles(exported, rewrite, os.Stdout)
+		},
+	}
+
+	options.addFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&allNamespaces, "all-namespaces", allNamespaces, "Export the profiles of all namespaces")
+
+	return cmd
+}
+
+func newCmdProfileImport() *cobra.Command {
+	options := &profileRewriteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import [flags] FILE",
+		Short: "Apply exported service profiles to the cluster",
+		Long: `Apply exported service profiles to the cluster.
+
+Profiles that already exist in the cluster are replaced. FILE may be "-" to
+read the profiles from stdin.`,
+		Example: `  # Import the profiles exported from another cluster.
+  linkerd profile import emojivoto-profiles.yaml
+
+  # Promote the profiles of a staging cluster to the prod namespace.
+  linkerd --context staging profile export staging | linkerd --context prod profile import --rewrite-namespace staging=prod -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rewrite, err := options.rewrite()
+			if err != nil {
+				return err
+			}
+
+			in := os.Stdin
+			if args[0] != "-" {
+				in, err = os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer in.Close()
+			}
+
+			imported, err := profiles.ReadProfiles(in, rewrite)
+			if err != nil {
+				return err
+			}
+
+			client, err := newServiceProfileClient()
+			if err != nil {
+				return err
+			}
+
+			return applyProfiles(client, imported, os.Stdout)
+		},
+	}
+
+	options.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newCmdProfileConvert() *cobra.Command {
+	virtualService := ""
+
+	cmd := &cobra.Command{
+		Use:   "convert [flags] --from-virtualservice FILE",
+		Short: "Output a service profile converted from an Istio VirtualService",
+		Long: `Output a service profile converted from an Istio VirtualService.
+
+The HTTP routes of the VirtualService are converted to the routes of the
+profile of its first host, along with their timeouts, and whether they're
+retried. The matches on URIs and methods are converted; the routes whose
+matches are all on other criteria, such as headers, are skipped. Retry
+attempts, traffic splits, redirects, rewrites, fault injections and traffic
+mirrors have no equivalent in service profiles: the rules that can't be
+converted are reported on stderr. FILE may be "-" to read the VirtualService
+from stdin.`,
+		Example: `  # Convert the VirtualService of the reviews service.
+  linkerd profile convert --from-virtualservice reviews-vs.yaml
+
+  # Convert a VirtualService from the cluster.
+  kubectl -n bookinfo get virtualservice reviews -o yaml | linkerd profile convert --from-virtualservice -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if virtualService == "" {
+				return errors.New("the --from-virtualservice flag is required")
+			}
+			return profiles.RenderVirtualService(virtualService, os.Stdout, os.Stderr)
+		},
+	}
+
+	cmd.Flags().StringVar(&virtualService, "from-virtualservice", virtualService, "Istio VirtualService file to convert")
 
 	return cmd
 }
+
+func newServiceProfileClient() (spclient.Interface, error) {
+	config, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return spclient.NewForConfig(config)
+}
+
+// applyProfiles creates the given ServiceProfiles, replacing the existing
+// profiles of the same name.
+func applyProfiles(client spclient.Interface, imported []*sp.ServiceProfile, w io.Writer) error {
+	for _, profile := range imported {
+		profileAPI := client.LinkerdV1alpha1().ServiceProfiles(profile.Namespace)
+
+		existing, err := profileAPI.Get(profile.Name, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+
+			if _, err := profileAPI.Create(profile); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "serviceprofile %s/%s created\n", profile.Namespace, profile.Name)
+			continue
+		}
+
+		profile.ResourceVersion = existing.ResourceVersion
+		if _, err := profileAPI.Update(profile); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "serviceprofile %s/%s configured\n", profile.Namespace, profile.Name)
+	}
+
+	return nil
+}
+
+// renderAlertRules fetches the ServiceProfile for the given service from
+// Kubernetes and renders Prometheus alerting rules from its route SLOs.
+func renderAlertRules(namespace, service string, w io.Writer) error {
+	client, err := newServiceProfileClient()
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace)
+	profile, err := client.LinkerdV1alpha1().ServiceProfiles(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	return profiles.RenderAlertRules(profile, w)
+}
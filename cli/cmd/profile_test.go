@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	spfake "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
 	"github.com/linkerd/linkerd2/pkg/profiles"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
@@ -35,7 +37,7 @@ func TestParseProfile(t *testing.T) {
 
 func TestValidateOptions(t *testing.T) {
 	options := newProfileOptions()
-	exp := errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+	exp := errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --alert-rules")
 	err := options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -44,7 +46,7 @@ func TestValidateOptions(t *testing.T) {
 	options = newProfileOptions()
 	options.template = true
 	options.openAPI = "openAPI"
-	exp = errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+	exp = errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --alert-rules")
 	err = options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -141,3 +143,51 @@ func TestValidateOptions(t *testing.T) {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
 	}
 }
+
+func TestApplyProfiles(t *testing.T) {
+	existing := profiles.GenServiceProfile("books", "prod")
+	existing.ResourceVersion = "1"
+	client := spfake.NewSimpleClientset(&existing)
+
+	updated := profiles.GenServiceProfile("books", "prod")
+	updated.Spec.Routes[0].Timeout = "1s"
+	created := profiles.GenServiceProfile("authors", "prod")
+
+	var buf bytes.Buffer
+	err := applyProfiles(client, []*v1alpha1.ServiceProfile{&updated, &created}, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `serviceprofile prod/books.prod.svc.cluster.local configured
+serviceprofile prod/authors.prod.svc.cluster.local created
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected output:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	profile, err := client.LinkerdV1alpha1().ServiceProfiles("prod").Get("books.prod.svc.cluster.local", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if profile.Spec.Routes[0].Timeout != "1s" {
+		t.Fatalf("Expected the existing profile to be replaced, got %+v", profile.Spec)
+	}
+}
+
+func TestProfileRewriteOptions(t *testing.T) {
+	options := &profileRewriteOptions{rewriteNamespaces: []string{"staging=prod"}, clusterDomain: "example.org"}
+	rewrite, err := options.rewrite()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if rewrite.Namespaces["staging"] != "prod" || rewrite.ClusterDomain != "example.org" {
+		t.Fatalf("Unexpected rewrite: %+v", rewrite)
+	}
+
+	options = &profileRewriteOptions{rewriteNamespaces: []string{"staging"}}
+	exp := errors.New("invalid --rewrite-namespace \"staging\": must be of the form FROM=TO")
+	if _, err := options.rewrite(); err == nil || err.Error() != exp.Error() {
+		t.Fatalf("rewrite returned unexpected error: %s (expected: %s)", err, exp)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/fatih/color"
+	"github.com/linkerd/linkerd2/pkg/inject"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
@@ -20,9 +21,12 @@ const (
 	defaultNamespace      = "linkerd"
 	defaultDockerRegistry = "gcr.io/linkerd-io"
 
-	jsonOutput  = "json"
-	tableOutput = "table"
-	wideOutput  = "wide"
+	jsonOutput      = "json"
+	kustomizeOutput = "kustomize"
+	markdownOutput  = "markdown"
+	tableOutput     = "table"
+	wideOutput      = "wide"
+	yamlOutput      = "yaml"
 )
 
 var (
@@ -64,7 +68,20 @@ var (
 var RootCmd = &cobra.Command{
 	Use:   "linkerd",
 	Short: "linkerd manages the Linkerd service mesh",
-	Long:  `linkerd manages the Linkerd service mesh.`,
+	Long: `linkerd manages the Linkerd service mesh.
+
+Defaults for the --context, --linkerd-namespace, --output and --api-addr flags,
+and for any other flag under "flags", can be set in ~/.linkerd/config.yaml
+[$LINKERD_CONFIG]:
+
+  context: my-cluster
+  linkerdNamespace: linkerd-prod
+  output: wide
+  flags:
+    success-rate-warn: 99.9
+
+The output default only applies to the commands that support that format.
+Flags passed on the command line take precedence over the file.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// enable / disable logging
 		if verbose {
@@ -73,8 +90,16 @@ var RootCmd = &cobra.Command{
 			log.SetLevel(log.PanicLevel)
 		}
 
+		config, err := readCLIConfig(configPath())
+		if err != nil {
+			return err
+		}
+		if err := config.applyTo(cmd.Flags()); err != nil {
+			return err
+		}
+
 		controlPlaneNamespaceFromEnv := os.Getenv("LINKERD_NAMESPACE")
-		if controlPlaneNamespace == defaultNamespace && controlPlaneNamespaceFromEnv != "" {
+		if !cmd.Flags().Changed("linkerd-namespace") && controlPlaneNamespaceFromEnv != "" {
 			controlPlaneNamespace = controlPlaneNamespaceFromEnv
 		}
 
@@ -93,17 +118,23 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing)")
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Turn on debug logging")
 
+	RootCmd.AddCommand(newCmdAdmin())
+	RootCmd.AddCommand(newCmdAlpha())
 	RootCmd.AddCommand(newCmdCheck())
 	RootCmd.AddCommand(newCmdCompletion())
 	RootCmd.AddCommand(newCmdDashboard())
+	RootCmd.AddCommand(newCmdDiagnostics())
 	RootCmd.AddCommand(newCmdDoc())
 	RootCmd.AddCommand(newCmdEndpoints())
+	RootCmd.AddCommand(newCmdEvents())
 	RootCmd.AddCommand(newCmdGet())
+	RootCmd.AddCommand(newCmdIngress())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
 	RootCmd.AddCommand(newCmdInstallCNIPlugin())
 	RootCmd.AddCommand(newCmdInstallSP())
 	RootCmd.AddCommand(newCmdLogs())
+	RootCmd.AddCommand(newCmdMesh())
 	RootCmd.AddCommand(newCmdMetrics())
 	RootCmd.AddCommand(newCmdProfile())
 	RootCmd.AddCommand(newCmdRoutes())
@@ -131,17 +162,17 @@ func newStatOptionsBase() *statOptionsBase {
 
 func (o *statOptionsBase) validateOutputFormat() error {
 	switch o.outputFormat {
-	case tableOutput, jsonOutput, wideOutput:
+	case tableOutput, jsonOutput, wideOutput, markdownOutput:
 		return nil
 	default:
-		return fmt.Errorf("--output currently only supports %s, %s and %s", tableOutput, jsonOutput, wideOutput)
+		return fmt.Errorf("--output currently only supports %s, %s, %s and %s", tableOutput, jsonOutput, wideOutput, markdownOutput)
 	}
 }
 
 func renderStats(buffer bytes.Buffer, options *statOptionsBase) string {
 	var out string
 	switch options.outputFormat {
-	case jsonOutput:
+	case jsonOutput, markdownOutput:
 		out = buffer.String()
 	default:
 		// strip left padding on the first column
@@ -192,6 +223,8 @@ type proxyConfigOptions struct {
 	proxyCPULimit          string
 	proxyMemoryLimit       string
 	enableExternalProfiles bool
+	proxyLabels            []string
+	destinationGetSuffixes []string
 	// ignoreCluster is not validated by validate().
 	ignoreCluster bool
 }
@@ -247,6 +280,14 @@ func (options *proxyConfigOptions) validate() error {
 		}
 	}
 
+	if _, err := inject.ParseProxyLabels(options.proxyLabels); err != nil {
+		return fmt.Errorf("%s for --proxy-label flag", err)
+	}
+
+	if _, err := inject.ParseDestinationGetSuffixes(options.destinationGetSuffixes); err != nil {
+		return fmt.Errorf("%s for --destination-get-suffixes flag", err)
+	}
+
 	if options.proxyLogLevel != "" && !validProxyLogLevel.MatchString(options.proxyLogLevel) {
 		return fmt.Errorf("\"%s\" is not a valid proxy log level - for allowed syntax check https://docs.rs/env_logger/0.6.0/env_logger/#enabling-logging",
 			options.proxyLogLevel)
@@ -281,6 +322,8 @@ func (options *proxyConfigOptions) flagSet(e pflag.ErrorHandling) *pflag.FlagSet
 	flags.StringVar(&options.proxyCPULimit, "proxy-cpu-limit", options.proxyCPULimit, "Maximum amount of CPU units that the proxy sidecar can use")
 	flags.StringVar(&options.proxyMemoryLimit, "proxy-memory-limit", options.proxyMemoryLimit, "Maximum amount of Memory that the proxy sidecar can use")
 	flags.BoolVar(&options.enableExternalProfiles, "enable-external-profiles", options.enableExternalProfiles, "Enable service profiles for non-Kubernetes services")
+	flags.StringSliceVar(&options.proxyLabels, "proxy-label", options.proxyLabels, "Extra key=value labels for the pods of injected workloads, exported as label_<key> in proxy metrics (e.g. for cost attribution)")
+	flags.StringSliceVar(&options.destinationGetSuffixes, "destination-get-suffixes", options.destinationGetSuffixes, "DNS suffixes of the names the proxy resolves through the destination service, other names being resolved with DNS only (e.g. \"svc.cluster.local.\" to avoid destination lookups of external names; default: the proxy's default)")
 
 	// Deprecated flags
 	flags.StringVar(&options.proxyMemoryRequest, "proxy-memory", options.proxyMemoryRequest, "Amount of Memory that the proxy sidecar requests")
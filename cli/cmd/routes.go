@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -51,7 +52,10 @@ This command will only display traffic which is sent to a service that has a Ser
   linkerd routes service/webapp -n test
 
   # Routes for calls from the traffic deployment to the webapp service in the test namespace.
-  linkerd routes deploy/traffic -n test --to svc/webapp`,
+  linkerd routes deploy/traffic -n test --to svc/webapp
+
+  # Routes for calls from the traffic deployment to any service in the payments namespace.
+  linkerd routes deploy/traffic -n test --to ns/payments`,
 		Args:      cobra.ExactArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -73,9 +77,10 @@ This command will only display traffic which is sent to a service that has a Ser
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
-	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, shows outbound stats to the specified resource")
+	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, shows outbound stats to the specified resource, or to every service in the specified namespace")
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
-	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\", \"%s\", or \"%s\"", tableOutput, wideOutput, jsonOutput))
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\", \"%s\", \"%s\", or \"%s\"", tableOutput, wideOutput, jsonOutput, markdownOutput))
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, wideOutput, jsonOutput, markdownOutput)
 
 	return cmd
 }
@@ -150,6 +155,16 @@ func writeRouteStatsToBuffer(resp *pb.TopRoutesResponse, w *tabwriter.Writer, op
 			printRouteTable(tables[resource], w, options)
 			fmt.Fprintln(w)
 		}
+	case markdownOutput:
+		for i, resource := range resources {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if len(tables) > 1 {
+				fmt.Fprintf(w, "**%s**\n\n", resource)
+			}
+			printRouteMarkdown(tables[resource], w, options)
+		}
 	case jsonOutput:
 		printRouteJSON(tables, w, options)
 	}
@@ -224,6 +239,31 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 	}
 }
 
+// printRouteMarkdown prints the routes as a markdown table, with the columns of
+// the table output.
+func printRouteMarkdown(stats []*routeRowStats, w io.Writer, options *routesOptions) {
+	authorityColumn := "AUTHORITY"
+	if options.dstIsService {
+		authorityColumn = "SERVICE"
+	}
+	headers := []string{"ROUTE", authorityColumn, "SUCCESS", "RPS", "LATENCY_P50", "LATENCY_P95", "LATENCY_P99"}
+
+	rows := make([][]string, len(stats))
+	for i, row := range stats {
+		rows[i] = []string{
+			row.route,
+			row.dst,
+			fmt.Sprintf("%.2f%%", row.successRate*100),
+			fmt.Sprintf("%.1frps", row.requestRate),
+			fmt.Sprintf("%dms", row.latencyP50),
+			fmt.Sprintf("%dms", row.latencyP95),
+			fmt.Sprintf("%dms", row.latencyP99),
+		}
+	}
+
+	writeMarkdownTable(w, headers, rows)
+}
+
 // Using pointers there where the value is NA and the corresponding json is null
 type jsonRouteStats struct {
 	Route            string   `json:"route"`
@@ -276,7 +316,7 @@ func printRouteJSON(tables map[string][]*routeRowStats, w *tabwriter.Writer, opt
 
 func (o *routesOptions) validateOutputFormat() error {
 	switch o.outputFormat {
-	case tableOutput, jsonOutput:
+	case tableOutput, jsonOutput, markdownOutput:
 		return nil
 	case wideOutput:
 		if o.toResource == "" {
@@ -284,7 +324,7 @@ func (o *routesOptions) validateOutputFormat() error {
 		}
 		return nil
 	default:
-		return fmt.Errorf("--output currently only supports %s, %s, and %s", tableOutput, wideOutput, jsonOutput)
+		return fmt.Errorf("--output currently only supports %s, %s, %s, and %s", tableOutput, wideOutput, jsonOutput, markdownOutput)
 	}
 }
 
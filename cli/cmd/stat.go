@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -25,6 +27,17 @@ type statOptions struct {
 	fromNamespace string
 	fromResource  string
 	allNamespaces bool
+	thresholds    statThresholds
+	baseline      statBaselineOptions
+}
+
+// statThresholds holds the thresholds at which the success rate and latency
+// columns of the table output are colored yellow or red.
+type statThresholds struct {
+	successRateWarn float64
+	successRateFail float64
+	latencyWarn     time.Duration
+	latencyFail     time.Duration
 }
 
 type indexedResults struct {
@@ -41,6 +54,12 @@ func newStatOptions() *statOptions {
 		fromNamespace:   "",
 		fromResource:    "",
 		allNamespaces:   false,
+		thresholds: statThresholds{
+			successRateWarn: 99,
+			successRateFail: 95,
+			latencyWarn:     500 * time.Millisecond,
+			latencyFail:     time.Second,
+		},
 	}
 }
 
@@ -81,11 +100,28 @@ func newCmdStat() *cobra.Command {
   * replicationcontrollers
   * statefulsets
   * authorities (not supported in --from)
-  * services (only supported if a --from is also specified, or as a --to)
+  * services (not supported in --from, or with a --to)
   * all (all resource types, not supported in --from or --to)
 
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
-If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE`,
+If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE
+
+When displaying pods with the wide or json output, the proxy container readiness, container restart count and
+last termination reason of each pod are included alongside its traffic stats.
+
+Services don't receive traffic themselves, so their stats are taken from the outbound metrics of
+their meshed clients. Requests from unmeshed clients aren't included.
+
+In the table and wide outputs, success rates and latencies are colored yellow
+or red when they cross the thresholds set by the --success-rate-* and
+--latency-* flags.
+
+With --baseline, the stats are shown next to their change from the stats saved
+with --save, and the command exits with status 1 when a success rate drops or a
+latency increases by more than set by --max-success-rate-drop or
+--max-latency-p95-increase, e.g. to gate canary rollouts in CI. When either is
+set, the resources of the baseline that are missing from the stats or have no
+traffic anymore also fail the command.`,
 		Example: `  # Get all deployments in the test namespace.
   linkerd stat deployments -n test
 
@@ -98,6 +134,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all inbound stats to the web deployment.
   linkerd stat deploy/web
 
+  # Get traffic stats and proxy health for all pods in the test namespace.
+  linkerd stat pods -n test -o wide
+
   # Getl all inbound stats to the pod1 and pod2 pods
   linkerd stat po pod1 pod2
 
@@ -110,6 +149,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all pods in all namespaces that call the hello1 service in the test namesapce.
   linkerd stat pods --to svc/hello1 --to-namespace test --all-namespaces
 
+  # Get the stats of all requests that meshed clients sent to the services in the test namespace.
+  linkerd stat services -n test
+
   # Get all services in all namespaces that receive calls from hello1 deployment in the test namespace.
   linkerd stat services --from deploy/hello1 --from-namespace test --all-namespaces
 
@@ -117,10 +159,22 @@ If no resource name is specified, displays stats about all resources of the spec
   linkerd stat namespaces --from ns/default
 
   # Get all inbound stats to the test namespace.
-  linkerd stat ns/test`,
+  linkerd stat ns/test
+
+  # Color success rates below 99.9% yellow and latencies above 100ms red.
+  linkerd stat deploy -n test --success-rate-warn 99.9 --latency-fail 100ms
+
+  # Save the stats of the deployments in the test namespace before a canary rollout...
+  linkerd stat deploy -n test --save before.json
+
+  # ...and fail if a success rate drops by more than 0.5% afterwards.
+  linkerd stat deploy -n test --baseline before.json --max-success-rate-drop 0.5`,
 		Args:      cobra.MinimumNArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			options.baseline.checkSuccessRate = cmd.Flags().Changed("max-success-rate-drop")
+			options.baseline.checkLatencyP95 = cmd.Flags().Changed("max-latency-p95-increase")
+
 			reqs, err := buildStatSummaryRequests(args, options)
 			if err != nil {
 				return fmt.Errorf("error creating metrics request while making stats request: %v", err)
@@ -150,8 +204,26 @@ If no resource name is specified, displays stats about all resources of the spec
 				}
 			}
 
+			if options.baseline.save != "" {
+				if err := saveStatSnapshot(options.baseline.save, totalRows); err != nil {
+					return fmt.Errorf("failed to save the stats: %s", err)
+				}
+			}
+
+			if options.baseline.baseline != "" {
+				baseline, err := readStatSnapshot(options.baseline.baseline)
+				if err != nil {
+					return err
+				}
+				if regressions := printStatBaseline(stdout, totalRows, baseline, options); len(regressions) > 0 {
+					fmt.Fprintf(stderr, "\nRegressions from the baseline:\n%s\n", strings.Join(regressions, "\n"))
+					os.Exit(1)
+				}
+				return nil
+			}
+
 			output := renderStatStats(totalRows, options)
-			_, err = fmt.Print(output)
+			_, err = fmt.Fprint(stdout, output)
 
 			return err
 		},
@@ -164,7 +236,16 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
 	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns stats across all namespaces, ignoring the \"--namespace\" flag")
-	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\"")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\" or \"markdown\"")
+	setOutputFormats(cmd.PersistentFlags(), tableOutput, jsonOutput, wideOutput, markdownOutput)
+	cmd.PersistentFlags().Float64Var(&options.thresholds.successRateWarn, "success-rate-warn", options.thresholds.successRateWarn, "Success rate percentage below which the SUCCESS column is colored yellow")
+	cmd.PersistentFlags().Float64Var(&options.thresholds.successRateFail, "success-rate-fail", options.thresholds.successRateFail, "Success rate percentage below which the SUCCESS column is colored red")
+	cmd.PersistentFlags().DurationVar(&options.thresholds.latencyWarn, "latency-warn", options.thresholds.latencyWarn, "Latency above which the LATENCY columns are colored yellow")
+	cmd.PersistentFlags().DurationVar(&options.thresholds.latencyFail, "latency-fail", options.thresholds.latencyFail, "Latency above which the LATENCY columns are colored red")
+	cmd.PersistentFlags().StringVar(&options.baseline.save, "save", options.baseline.save, "Save the stats to this file, in the json output format, to be used later with --baseline")
+	cmd.PersistentFlags().StringVar(&options.baseline.baseline, "baseline", options.baseline.baseline, "Compare the stats with the ones saved to this file with --save, showing how they changed")
+	cmd.PersistentFlags().Float64Var(&options.baseline.maxSuccessRateDrop, "max-success-rate-drop", options.baseline.maxSuccessRateDrop, "With --baseline, exit with status 1 if the success rate of a resource dropped by more than this many percentage points")
+	cmd.PersistentFlags().DurationVar(&options.baseline.maxLatencyP95Increase, "max-latency-p95-increase", options.baseline.maxLatencyP95Increase, "With --baseline, exit with status 1 if the p95 latency of a resource increased by more than this")
 
 	return cmd
 }
@@ -217,15 +298,45 @@ type rowStats struct {
 
 type row struct {
 	meshed string
+	*podHealth
 	*rowStats
 }
 
+type podHealth struct {
+	proxyReady      string
+	restarts        uint64
+	lastTermination string
+}
+
 var (
 	nameHeader      = "NAME"
 	namespaceHeader = "NAMESPACE"
 )
 
 func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer, options *statOptions) {
+	statTables, maxNameLength, maxNamespaceLength := buildStatTables(rows)
+
+	switch options.outputFormat {
+	case tableOutput, wideOutput:
+		if len(statTables) == 0 {
+			fmt.Fprintln(os.Stderr, "No traffic found.")
+			os.Exit(0)
+		}
+		printStatTables(statTables, w, maxNameLength, maxNamespaceLength, options)
+	case markdownOutput:
+		if len(statTables) == 0 {
+			fmt.Fprintln(os.Stderr, "No traffic found.")
+			os.Exit(0)
+		}
+		printStatMarkdown(statTables, w, options)
+	case jsonOutput:
+		printStatJSON(statTables, w)
+	}
+}
+
+// buildStatTables indexes the rows by resource type and namespace/name, and
+// returns the lengths of the longest name and namespace.
+func buildStatTables(rows []*pb.StatTable_PodGroup_Row) (map[string]map[string]*row, int, int) {
 	maxNameLength := len(nameHeader)
 	maxNamespaceLength := len(namespaceHeader)
 	statTables := make(map[string]map[string]*row)
@@ -270,6 +381,14 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 			meshed: meshedCount,
 		}
 
+		if resourceKey == k8s.Pod {
+			statTables[resourceKey][key].podHealth = &podHealth{
+				proxyReady:      fmt.Sprintf("%d/%d", r.ProxyReadyPodCount, r.MeshedPodCount),
+				restarts:        r.RestartCount,
+				lastTermination: r.LastTerminationReason,
+			}
+		}
+
 		if r.Stats != nil {
 			statTables[resourceKey][key].rowStats = &rowStats{
 				requestRate:        getRequestRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount(), r.TimeWindow),
@@ -284,16 +403,7 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 		}
 	}
 
-	switch options.outputFormat {
-	case tableOutput, wideOutput:
-		if len(statTables) == 0 {
-			fmt.Fprintln(os.Stderr, "No traffic found.")
-			os.Exit(0)
-		}
-		printStatTables(statTables, w, maxNameLength, maxNamespaceLength, options)
-	case jsonOutput:
-		printStatJSON(statTables, w)
-	}
+	return statTables, maxNameLength, maxNamespaceLength
 }
 
 func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
@@ -327,6 +437,11 @@ func showTCPConns(resourceType string) bool {
 	return resourceType != k8s.Authority
 }
 
+func showPodHealth(options *statOptions, resourceType string) bool {
+	return (options.outputFormat == wideOutput || options.outputFormat == jsonOutput) &&
+		resourceType == k8s.Pod
+}
+
 func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType string, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
 	headers := make([]string, 0)
 	if options.allNamespaces {
@@ -336,11 +451,11 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 	headers = append(headers, []string{
 		nameHeader + strings.Repeat(" ", maxNameLength-len(nameHeader)),
 		"MESHED",
-		"SUCCESS",
+		colorDefault("SUCCESS"),
 		"RPS",
-		"LATENCY_P50",
-		"LATENCY_P95",
-		"LATENCY_P99",
+		colorDefault("LATENCY_P50"),
+		colorDefault("LATENCY_P95"),
+		colorDefault("LATENCY_P99"),
 		"TCP_CONN",
 	}...)
 
@@ -351,6 +466,14 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}...)
 	}
 
+	if showPodHealth(options, resourceType) {
+		headers = append(headers, []string{
+			"PROXY_READY",
+			"RESTARTS",
+			"LAST_TERMINATION",
+		}...)
+	}
+
 	headers[len(headers)-1] = headers[len(headers)-1] + "\t" // trailing \t is required to format last column
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
@@ -359,17 +482,22 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 	for _, key := range sortedKeys {
 		namespace, name := namespaceName(resourceTypeLabel, key)
 		values := make([]interface{}, 0)
-		templateString := "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%d\t\n"
-		templateStringEmpty := "%s\t%s\t-\t-\t-\t-\t-\t-\t\n"
+		templateString := "%s\t%s\t%s\t%.1frps\t%s\t%s\t%s\t%d\t\n"
+		templateStringEmpty := "%s\t%s\t%s\t-\t%s\t%s\t%s\t-\t\n"
 
 		if showTCPBytes(options, resourceType) {
-			templateString = "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%d\t%.1fB/s\t%.1fB/s\t\n"
-			templateStringEmpty = "%s\t%s\t-\t-\t-\t-\t-\t-\t-\t-\t\n"
+			templateString = "%s\t%s\t%s\t%.1frps\t%s\t%s\t%s\t%d\t%.1fB/s\t%.1fB/s\t\n"
+			templateStringEmpty = "%s\t%s\t%s\t-\t%s\t%s\t%s\t-\t-\t-\t\n"
 		}
 
 		if !showTCPConns(resourceType) {
 			// always show TCP Connections as - for Authorities
-			templateString = "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t-\t\n"
+			templateString = "%s\t%s\t%s\t%.1frps\t%s\t%s\t%s\t-\t\n"
+		}
+
+		if showPodHealth(options, resourceType) {
+			templateString = strings.TrimSuffix(templateString, "\n") + "%s\t%d\t%s\t\n"
+			templateStringEmpty = strings.TrimSuffix(templateStringEmpty, "\n") + "%s\t%d\t%s\t\n"
 		}
 
 		if options.allNamespaces {
@@ -390,11 +518,11 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 
 		if stats[key].rowStats != nil {
 			values = append(values, []interface{}{
-				stats[key].successRate * 100,
+				options.thresholds.colorSuccessRate(stats[key].successRate),
 				stats[key].requestRate,
-				stats[key].latencyP50,
-				stats[key].latencyP95,
-				stats[key].latencyP99,
+				options.thresholds.colorLatency(stats[key].latencyP50),
+				options.thresholds.colorLatency(stats[key].latencyP95),
+				options.thresholds.colorLatency(stats[key].latencyP99),
 			}...)
 
 			if showTCPConns(resourceType) {
@@ -408,13 +536,78 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 				}...)
 			}
 
+			values = appendPodHealth(values, stats[key], options, resourceType)
 			fmt.Fprintf(w, templateString, values...)
 		} else {
+			values = append(values, []interface{}{
+				colorDefault("-"),
+				colorDefault("-"),
+				colorDefault("-"),
+				colorDefault("-"),
+			}...)
+			values = appendPodHealth(values, stats[key], options, resourceType)
 			fmt.Fprintf(w, templateStringEmpty, values...)
 		}
 	}
 }
 
+var (
+	colorOK   = color.New(color.FgGreen).SprintFunc()
+	colorWarn = color.New(color.FgYellow).SprintFunc()
+	colorFail = color.New(color.FgRed).SprintFunc()
+
+	// colorDefault wraps the uncolored cells of the colored columns in the
+	// terminal's default foreground color, so that every cell of those columns
+	// carries escape sequences of the same length and tabwriter keeps them
+	// aligned.
+	colorDefault = color.New(color.Attribute(39)).SprintFunc()
+)
+
+// colorSuccessRate formats the given success rate as a percentage, colored
+// according to the thresholds.
+func (t statThresholds) colorSuccessRate(successRate float64) string {
+	percent := successRate * 100
+	cell := fmt.Sprintf("%.2f%%", percent)
+	switch {
+	case percent < t.successRateFail:
+		return colorFail(cell)
+	case percent < t.successRateWarn:
+		return colorWarn(cell)
+	default:
+		return colorOK(cell)
+	}
+}
+
+// colorLatency formats the given latency in milliseconds, colored according
+// to the thresholds.
+func (t statThresholds) colorLatency(latencyMs uint64) string {
+	latency := time.Duration(latencyMs) * time.Millisecond
+	cell := fmt.Sprintf("%dms", latencyMs)
+	switch {
+	case latency > t.latencyFail:
+		return colorFail(cell)
+	case latency > t.latencyWarn:
+		return colorWarn(cell)
+	default:
+		return colorOK(cell)
+	}
+}
+
+func appendPodHealth(values []interface{}, r *row, options *statOptions, resourceType string) []interface{} {
+	if !showPodHealth(options, resourceType) || r.podHealth == nil {
+		return values
+	}
+	lastTermination := r.lastTermination
+	if lastTermination == "" {
+		lastTermination = "-"
+	}
+	return append(values, []interface{}{
+		r.proxyReady,
+		r.restarts,
+		lastTermination,
+	}...)
+}
+
 func namespaceName(resourceType string, key string) (string, string) {
 	parts := strings.Split(key, "/")
 	namespace := parts[0]
@@ -437,9 +630,23 @@ type jsonStats struct {
 	TCPConnections *uint64  `json:"tcp_open_connections"`
 	TCPReadBytes   *float64 `json:"tcp_read_bytes_rate"`
 	TCPWriteBytes  *float64 `json:"tcp_write_bytes_rate"`
+	ProxyReady     *string  `json:"proxy_ready,omitempty"`
+	Restarts       *uint64  `json:"restarts,omitempty"`
+	LastTerminated *string  `json:"last_termination_reason,omitempty"`
 }
 
 func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
+	b, err := json.MarshalIndent(statJSONEntries(statTables), "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
+
+// statJSONEntries returns the entries of the json output, also used by
+// --save and --baseline.
+func statJSONEntries(statTables map[string]map[string]*row) []*jsonStats {
 	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
 	entries := []*jsonStats{}
 	for _, resourceType := range k8s.AllResources {
@@ -453,6 +660,13 @@ func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 					Name:      name,
 					Meshed:    stats[key].meshed,
 				}
+				if stats[key].podHealth != nil {
+					entry.ProxyReady = &stats[key].proxyReady
+					entry.Restarts = &stats[key].restarts
+					if stats[key].lastTermination != "" {
+						entry.LastTerminated = &stats[key].lastTermination
+					}
+				}
 				if stats[key].rowStats != nil {
 					entry.Success = &stats[key].successRate
 					entry.Rps = &stats[key].requestRate
@@ -471,12 +685,62 @@ func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 			}
 		}
 	}
-	b, err := json.MarshalIndent(entries, "", "  ")
-	if err != nil {
-		log.Error(err.Error())
-		return
+	return entries
+}
+
+// printStatMarkdown prints a markdown table per resource type, with the columns
+// of the table output.
+func printStatMarkdown(statTables map[string]map[string]*row, w io.Writer, options *statOptions) {
+	usePrefix := len(statTables) > 1
+
+	firstDisplayedStat := true // don't print a newline before the first stat
+	for _, resourceType := range k8s.AllResources {
+		stats, ok := statTables[resourceType]
+		if !ok {
+			continue
+		}
+		if !firstDisplayedStat {
+			fmt.Fprintln(w)
+		}
+		firstDisplayedStat = false
+
+		resourceTypeLabel := ""
+		if usePrefix {
+			resourceTypeLabel = resourceType
+		}
+
+		headers := []string{nameHeader, "MESHED", "SUCCESS", "RPS", "LATENCY_P50", "LATENCY_P95", "LATENCY_P99", "TCP_CONN"}
+		if options.allNamespaces {
+			headers = append([]string{namespaceHeader}, headers...)
+		}
+
+		rows := [][]string{}
+		for _, key := range sortStatsKeys(stats) {
+			namespace, name := namespaceName(resourceTypeLabel, key)
+			cells := []string{name, stats[key].meshed, "-", "-", "-", "-", "-", "-"}
+			if r := stats[key].rowStats; r != nil {
+				cells = []string{
+					name,
+					stats[key].meshed,
+					fmt.Sprintf("%.2f%%", r.successRate*100),
+					fmt.Sprintf("%.1frps", r.requestRate),
+					fmt.Sprintf("%dms", r.latencyP50),
+					fmt.Sprintf("%dms", r.latencyP95),
+					fmt.Sprintf("%dms", r.latencyP99),
+					"-",
+				}
+				if showTCPConns(resourceType) {
+					cells[7] = fmt.Sprintf("%d", r.tcpOpenConnections)
+				}
+			}
+			if options.allNamespaces {
+				cells = append([]string{namespace}, cells...)
+			}
+			rows = append(rows, cells)
+		}
+
+		writeMarkdownTable(w, headers, rows)
 	}
-	fmt.Fprintf(w, "%s\n", b)
 }
 
 func getNamePrefix(resourceType string) string {
@@ -565,9 +829,39 @@ func (o *statOptions) validate(resourceType string) error {
 		}
 	}
 
+	err = o.validateThresholds()
+	if err != nil {
+		return err
+	}
+
+	err = o.baseline.validate(o.outputFormat)
+	if err != nil {
+		return err
+	}
+
 	return o.validateOutputFormat()
 }
 
+// validateThresholds validates that the coloring thresholds are in range and
+// that the warning thresholds are crossed before the failure thresholds.
+func (o *statOptions) validateThresholds() error {
+	t := o.thresholds
+	if t.successRateWarn < 0 || t.successRateWarn > 100 || t.successRateFail < 0 || t.successRateFail > 100 {
+		return fmt.Errorf("--success-rate-warn and --success-rate-fail must be percentages between 0 and 100")
+	}
+	if t.successRateFail > t.successRateWarn {
+		return fmt.Errorf("--success-rate-fail must not be greater than --success-rate-warn")
+	}
+	if t.latencyWarn < 0 || t.latencyFail < 0 {
+		return fmt.Errorf("--latency-warn and --latency-fail must not be negative")
+	}
+	if t.latencyWarn > t.latencyFail {
+		return fmt.Errorf("--latency-warn must not be greater than --latency-fail")
+	}
+
+	return nil
+}
+
 // validateConflictingFlags validates that the options do not contain mutually
 // exclusive flags.
 func (o *statOptions) validateConflictingFlags() error {
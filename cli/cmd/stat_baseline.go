@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+// statBaselineOptions holds the flags saving the stats to a snapshot file, and
+// comparing the stats with a previously saved snapshot.
+type statBaselineOptions struct {
+	save     string
+	baseline string
+
+	// The regressions from the baseline above which the command fails. They're
+	// only checked when set.
+	maxSuccessRateDrop    float64
+	maxLatencyP95Increase time.Duration
+	checkSuccessRate      bool
+	checkLatencyP95       bool
+}
+
+// saveStatSnapshot writes the stats of the rows to a file, in the json output
+// format.
+func saveStatSnapshot(path string, rows []*pb.StatTable_PodGroup_Row) error {
+	statTables, _, _ := buildStatTables(rows)
+	b, err := json.MarshalIndent(statJSONEntries(statTables), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// readStatSnapshot reads a file written by saveStatSnapshot, or by the json
+// output.
+func readStatSnapshot(path string) ([]*jsonStats, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*jsonStats{}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("invalid stat snapshot %s: %s", path, err)
+	}
+	return entries, nil
+}
+
+// printStatBaseline prints the stats of the rows next to their change from the
+// baseline, and returns a description of each regression beyond the maximums
+// set in the options. The rows of the baseline that are missing from the
+// stats, or that have no traffic anymore, are printed after the others, and
+// are regressions when any maximum is set.
+func printStatBaseline(w io.Writer, rows []*pb.StatTable_PodGroup_Row, baseline []*jsonStats, options *statOptions) []string {
+	statTables, _, _ := buildStatTables(rows)
+	current := statJSONEntries(statTables)
+
+	previous := map[string]*jsonStats{}
+	for _, entry := range baseline {
+		previous[statSnapshotKey(entry)] = entry
+	}
+
+	usePrefix := len(statTables) > 1
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	headers := "NAME\tSUCCESS\tSUCCESS_DELTA\tRPS\tRPS_DELTA\tLATENCY_P95\tLATENCY_P95_DELTA"
+	if options.allNamespaces {
+		headers = "NAMESPACE\t" + headers
+	}
+	fmt.Fprintln(tw, headers)
+
+	b := options.baseline
+	regressions := []string{}
+	seen := map[string]bool{}
+	for _, entry := range current {
+		name := entry.Name
+		if usePrefix {
+			name = getNamePrefix(entry.Kind) + name
+		}
+
+		cells := []string{name, "-", "-", "-", "-", "-", "-"}
+		if entry.Success != nil {
+			cells[1] = fmt.Sprintf("%.2f%%", *entry.Success*100)
+			cells[3] = fmt.Sprintf("%.1frps", *entry.Rps)
+			cells[5] = fmt.Sprintf("%dms", *entry.LatencyMSp95)
+		}
+
+		prev, ok := previous[statSnapshotKey(entry)]
+		seen[statSnapshotKey(entry)] = true
+		if ok && entry.Success == nil && prev.Success != nil && (b.checkSuccessRate || b.checkLatencyP95) {
+			regressions = append(regressions, fmt.Sprintf("%s: no traffic, while the baseline had some", name))
+		}
+		if ok && entry.Success != nil && prev.Success != nil {
+			successDelta := (*entry.Success - *prev.Success) * 100
+			latencyDelta := int64(*entry.LatencyMSp95) - int64(*prev.LatencyMSp95)
+			cells[2] = fmt.Sprintf("%+.2f%%", successDelta)
+			cells[4] = fmt.Sprintf("%+.1frps", *entry.Rps-*prev.Rps)
+			cells[6] = fmt.Sprintf("%+dms", latencyDelta)
+
+			if b.checkSuccessRate && -successDelta > b.maxSuccessRateDrop {
+				regressions = append(regressions, fmt.Sprintf("%s: success rate dropped by %.2f%%, more than %.2f%%", name, -successDelta, b.maxSuccessRateDrop))
+			}
+			if b.checkLatencyP95 && time.Duration(latencyDelta)*time.Millisecond > b.maxLatencyP95Increase {
+				regressions = append(regressions, fmt.Sprintf("%s: p95 latency increased by %dms, more than %s", name, latencyDelta, b.maxLatencyP95Increase))
+			}
+		}
+
+		if options.allNamespaces {
+			cells = append([]string{entry.Namespace}, cells...)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	for _, entry := range baseline {
+		if seen[statSnapshotKey(entry)] {
+			continue
+		}
+		name := entry.Name
+		if usePrefix {
+			name = getNamePrefix(entry.Kind) + name
+		}
+
+		cells := []string{name, "-", "missing", "-", "missing", "-", "missing"}
+		if entry.Success != nil && (b.checkSuccessRate || b.checkLatencyP95) {
+			regressions = append(regressions, fmt.Sprintf("%s: missing from the stats, while the baseline had traffic", name))
+		}
+
+		if options.allNamespaces {
+			cells = append([]string{entry.Namespace}, cells...)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+
+	return regressions
+}
+
+func statSnapshotKey(entry *jsonStats) string {
+	return fmt.Sprintf("%s/%s/%s", entry.Kind, entry.Namespace, entry.Name)
+}
+
+func (o *statBaselineOptions) validate(outputFormat string) error {
+	if o.baseline != "" && outputFormat != tableOutput {
+		return fmt.Errorf("--baseline only supports the table output")
+	}
+	if (o.checkSuccessRate || o.checkLatencyP95) && o.baseline == "" {
+		return fmt.Errorf("--max-success-rate-drop and --max-latency-p95-increase require --baseline")
+	}
+	if o.maxSuccessRateDrop < 0 || o.maxLatencyP95Increase < 0 {
+		return fmt.Errorf("--max-success-rate-drop and --max-latency-p95-increase must not be negative")
+	}
+	return nil
+}
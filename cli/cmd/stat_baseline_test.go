@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestStatBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stat-baseline")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	genRows := func(success, failure, latencyP95 uint64) []*pb.StatTable_PodGroup_Row {
+		return []*pb.StatTable_PodGroup_Row{
+			{
+				Resource:        &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: "web"},
+				TimeWindow:      "1m",
+				MeshedPodCount:  1,
+				RunningPodCount: 1,
+				Stats: &pb.BasicStats{
+					SuccessCount: success,
+					FailureCount: failure,
+					LatencyMsP95: latencyP95,
+				},
+			},
+		}
+	}
+
+	path := filepath.Join(dir, "before.json")
+	if err := saveStatSnapshot(path, genRows(100, 0, 100)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	baseline, err := readStatSnapshot(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	renamedRows := genRows(100, 0, 100)
+	renamedRows[0].Resource.Name = "vote-bot"
+	noTrafficRows := genRows(0, 0, 0)
+	noTrafficRows[0].Stats = nil
+
+	testCases := []struct {
+		name                string
+		rows                []*pb.StatTable_PodGroup_Row
+		baselineOptions     statBaselineOptions
+		expectedOutput      string
+		expectedRegressions []string
+	}{
+		{
+			"no regression checked",
+			genRows(90, 10, 150),
+			statBaselineOptions{},
+			`NAME   SUCCESS   SUCCESS_DELTA   RPS      RPS_DELTA   LATENCY_P95   LATENCY_P95_DELTA
+web    90.00%    -10.00%         1.7rps   +0.0rps     150ms         +50ms
+`,
+			[]string{},
+		},
+		{
+			"regressions within the maximums",
+			genRows(90, 10, 150),
+			statBaselineOptions{
+				maxSuccessRateDrop:    10,
+				maxLatencyP95Increase: 50 * time.Millisecond,
+				checkSuccessRate:      true,
+				checkLatencyP95:       true,
+			},
+			`NAME   SUCCESS   SUCCESS_DELTA   RPS      RPS_DELTA   LATENCY_P95   LATENCY_P95_DELTA
+web    90.00%    -10.00%         1.7rps   +0.0rps     150ms         +50ms
+`,
+			[]string{},
+		},
+		{
+			"regressions beyond the maximums",
+			genRows(90, 10, 150),
+			statBaselineOptions{
+				maxSuccessRateDrop:    0.5,
+				maxLatencyP95Increase: 10 * time.Millisecond,
+				checkSuccessRate:      true,
+				checkLatencyP95:       true,
+			},
+			`NAME   SUCCESS   SUCCESS_DELTA   RPS      RPS_DELTA   LATENCY_P95   LATENCY_P95_DELTA
+web    90.00%    -10.00%         1.7rps   +0.0rps     150ms         +50ms
+`,
+			[]string{
+				"web: success rate dropped by 10.00%, more than 0.50%",
+				"web: p95 latency increased by 50ms, more than 10ms",
+			},
+		},
+		{
+			"rows missing from the stats",
+			renamedRows,
+			statBaselineOptions{
+				maxSuccessRateDrop: 10,
+				checkSuccessRate:   true,
+			},
+			`NAME       SUCCESS   SUCCESS_DELTA   RPS      RPS_DELTA   LATENCY_P95   LATENCY_P95_DELTA
+vote-bot   100.00%   -               1.7rps   -           100ms         -
+web        -         missing         -        missing     -             missing
+`,
+			[]string{
+				"web: missing from the stats, while the baseline had traffic",
+			},
+		},
+		{
+			"rows without traffic",
+			noTrafficRows,
+			statBaselineOptions{
+				maxSuccessRateDrop: 10,
+				checkSuccessRate:   true,
+			},
+			`NAME   SUCCESS   SUCCESS_DELTA   RPS   RPS_DELTA   LATENCY_P95   LATENCY_P95_DELTA
+web    -         -               -     -           -             -
+`,
+			[]string{
+				"web: no traffic, while the baseline had some",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			options := newStatOptions()
+			options.baseline = tc.baselineOptions
+
+			var buf bytes.Buffer
+			regressions := printStatBaseline(&buf, tc.rows, baseline, options)
+
+			if buf.String() != tc.expectedOutput {
+				t.Errorf("Expected output:\n%s\ngot:\n%s", tc.expectedOutput, buf.String())
+			}
+			if strings.Join(regressions, "\n") != strings.Join(tc.expectedRegressions, "\n") {
+				t.Errorf("Expected regressions %v, got %v", tc.expectedRegressions, regressions)
+			}
+		})
+	}
+}
+
+func TestStatBaselineOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		options      statBaselineOptions
+		outputFormat string
+		valid        bool
+	}{
+		{statBaselineOptions{save: "before.json"}, jsonOutput, true},
+		{statBaselineOptions{baseline: "before.json", checkSuccessRate: true}, tableOutput, true},
+		{statBaselineOptions{baseline: "before.json"}, jsonOutput, false},
+		{statBaselineOptions{checkLatencyP95: true}, tableOutput, false},
+		{statBaselineOptions{baseline: "before.json", maxSuccessRateDrop: -1}, tableOutput, false},
+	}
+
+	for i, tc := range testCases {
+		err := tc.options.validate(tc.outputFormat)
+		if tc.valid && err != nil {
+			t.Errorf("Test case %d: unexpected error: %s", i, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("Test case %d: expected an error", i)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 )
@@ -11,6 +13,7 @@ type paramsExp struct {
 	counts  *public.PodCounts
 	options *statOptions
 	resNs   []string
+	resType string
 	file    string
 }
 
@@ -43,6 +46,20 @@ func TestStat(t *testing.T) {
 		}, t)
 	})
 
+	options.outputFormat = markdownOutput
+	t.Run("Returns namespace stats (markdown)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_output_markdown.golden",
+		}, t)
+	})
+
 	options = newStatOptions()
 	options.allNamespaces = true
 	t.Run("Returns all namespace stats", func(t *testing.T) {
@@ -87,6 +104,42 @@ func TestStat(t *testing.T) {
 		}, t)
 	})
 
+	options = newStatOptions()
+	options.outputFormat = wideOutput
+	t.Run("Returns pod health stats", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:            1,
+				RunningPods:           1,
+				FailedPods:            0,
+				ProxyReadyPods:        1,
+				Restarts:              3,
+				LastTerminationReason: "OOMKilled",
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			resType: k8s.Pod,
+			file:    "stat_one_pod_health_output.golden",
+		}, t)
+	})
+
+	options.outputFormat = jsonOutput
+	t.Run("Returns pod health stats (json)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:     1,
+				RunningPods:    1,
+				FailedPods:     0,
+				ProxyReadyPods: 0,
+				Restarts:       0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			resType: k8s.Pod,
+			file:    "stat_one_pod_health_output_json.golden",
+		}, t)
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -148,15 +201,75 @@ func TestStat(t *testing.T) {
 			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
 		}
 	})
+
+	t.Run("Rejects --success-rate-fail greater than --success-rate-warn", func(t *testing.T) {
+		options := newStatOptions()
+		options.thresholds.successRateFail = 99.5
+		args := []string{"po"}
+		expectedError := "--success-rate-fail must not be greater than --success-rate-warn"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --latency-warn greater than --latency-fail", func(t *testing.T) {
+		options := newStatOptions()
+		options.thresholds.latencyWarn = 2 * time.Second
+		args := []string{"po"}
+		expectedError := "--latency-warn must not be greater than --latency-fail"
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+}
+
+func TestStatThresholds(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	thresholds := newStatOptions().thresholds
+
+	successRates := map[float64]string{
+		1:     "\x1b[32m100.00%\x1b[0m",
+		0.99:  "\x1b[32m99.00%\x1b[0m",
+		0.985: "\x1b[33m98.50%\x1b[0m",
+		0.9:   "\x1b[31m90.00%\x1b[0m",
+	}
+	for successRate, expected := range successRates {
+		if cell := thresholds.colorSuccessRate(successRate); cell != expected {
+			t.Errorf("Expected success rate %f to be rendered as %q, got %q", successRate, expected, cell)
+		}
+	}
+
+	latencies := map[uint64]string{
+		10:   "\x1b[32m10ms\x1b[0m",
+		500:  "\x1b[32m500ms\x1b[0m",
+		750:  "\x1b[33m750ms\x1b[0m",
+		1500: "\x1b[31m1500ms\x1b[0m",
+	}
+	for latency, expected := range latencies {
+		if cell := thresholds.colorLatency(latency); cell != expected {
+			t.Errorf("Expected latency %dms to be rendered as %q, got %q", latency, expected, cell)
+		}
+	}
 }
 
 func testStatCall(exp paramsExp, t *testing.T) {
 	mockClient := &public.MockAPIClient{}
-	response := public.GenStatSummaryResponse("emoji", k8s.Namespace, exp.resNs, exp.counts, true, true)
+	resType := exp.resType
+	if resType == "" {
+		resType = k8s.Namespace
+	}
+	response := public.GenStatSummaryResponse("emoji", resType, exp.resNs, exp.counts, true, true)
 
 	mockClient.StatSummaryResponseToReturn = &response
 
-	args := []string{"ns"}
+	args := []string{resType}
 	reqs, err := buildStatSummaryRequests(args, exp.options)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
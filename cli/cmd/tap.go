@@ -18,28 +18,32 @@ import (
 )
 
 type tapOptions struct {
-	namespace   string
-	toResource  string
-	toNamespace string
-	maxRps      float32
-	scheme      string
-	method      string
-	authority   string
-	path        string
-	output      string
+	namespace     string
+	toResource    string
+	toNamespace   string
+	fromResource  string
+	fromNamespace string
+	maxRps        float32
+	scheme        string
+	method        string
+	authority     string
+	path          string
+	output        string
 }
 
 func newTapOptions() *tapOptions {
 	return &tapOptions{
-		namespace:   "default",
-		toResource:  "",
-		toNamespace: "",
-		maxRps:      100.0,
-		scheme:      "",
-		method:      "",
-		authority:   "",
-		path:        "",
-		output:      "",
+		namespace:     "default",
+		toResource:    "",
+		toNamespace:   "",
+		fromResource:  "",
+		fromNamespace: "",
+		maxRps:        100.0,
+		scheme:        "",
+		method:        "",
+		authority:     "",
+		path:          "",
+		output:        "",
 	}
 }
 
@@ -72,7 +76,8 @@ func newCmdTap() *cobra.Command {
   * pods
   * replicationcontrollers
   * statefulsets
-  * services (only supported as a --to resource)`,
+  * services (only supported as a --to resource)
+  * serviceaccounts (only supported as a --to or --from resource)`,
 		Example: `  # tap the web deployment in the default namespace
   linkerd tap deploy/web
 
@@ -80,20 +85,28 @@ func newCmdTap() *cobra.Command {
   linkerd tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd tap ns/test --to ns/prod`,
+  linkerd tap ns/test --to ns/prod
+
+  # tap the web deployment, filter by requests from the backup service account
+  linkerd tap deploy/web --from sa/backup
+
+  # tap the test namespace, filter by requests from the migrate job
+  linkerd tap ns/test --from job/migrate --from-namespace batch`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			requestParams := util.TapRequestParams{
-				Resource:    strings.Join(args, "/"),
-				Namespace:   options.namespace,
-				ToResource:  options.toResource,
-				ToNamespace: options.toNamespace,
-				MaxRps:      options.maxRps,
-				Scheme:      options.scheme,
-				Method:      options.method,
-				Authority:   options.authority,
-				Path:        options.path,
+				Resource:      strings.Join(args, "/"),
+				Namespace:     options.namespace,
+				ToResource:    options.toResource,
+				ToNamespace:   options.toNamespace,
+				FromResource:  options.fromResource,
+				FromNamespace: options.fromNamespace,
+				MaxRps:        options.maxRps,
+				Scheme:        options.scheme,
+				Method:        options.method,
+				Authority:     options.authority,
+				Path:          options.path,
 			}
 
 			req, err := util.BuildTapByResourceRequest(requestParams)
@@ -122,6 +135,10 @@ func newCmdTap() *cobra.Command {
 		"Display requests to this resource")
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
 		"Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource,
+		"Display requests from this resource")
+	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace,
+		"Sets the namespace used to lookup the \"--from\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
 		"Maximum requests per second to tap.")
 	cmd.PersistentFlags().StringVar(&options.scheme, "scheme", options.scheme,
@@ -134,6 +151,7 @@ func newCmdTap() *cobra.Command {
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
 		"Output format. One of: wide")
+	setOutputFormats(cmd.PersistentFlags(), wideOutput)
 
 	return cmd
 }
@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/testutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	testkitGatewayDeploy  = "testkit-gateway"
+	testkitTerminusDeploy = "testkit-terminus"
+	testkitGatewayPort    = 8080
+	testkitResponseText   = "BANANA"
+
+	// testkitApp is a small HTTP-to-gRPC application, used to validate that
+	// meshed traffic flows and is reported by the control plane.
+	testkitApp = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: testkit-terminus
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: testkit-terminus
+  template:
+    metadata:
+      labels:
+        app: testkit-terminus
+    spec:
+      containers:
+      - name: http-to-grpc
+        image: buoyantio/bb:v0.0.5
+        args: ["terminus", "--grpc-server-port", "9090", "--response-text", "BANANA"]
+        ports:
+        - containerPort: 9090
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: testkit-terminus-svc
+spec:
+  selector:
+    app: testkit-terminus
+  ports:
+  - name: grpc
+    port: 9090
+    targetPort: 9090
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: testkit-gateway
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: testkit-gateway
+  template:
+    metadata:
+      labels:
+        app: testkit-gateway
+    spec:
+      containers:
+      - name: http-to-grpc
+        image: buoyantio/bb:v0.0.5
+        args: ["point-to-point-channel", "--grpc-downstream-server", "testkit-terminus-svc:9090", "--h1-server-port", "8080"]
+        ports:
+        - containerPort: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: testkit-gateway-svc
+spec:
+  selector:
+    app: testkit-gateway
+  ports:
+  - name: http
+    port: 8080
+    targetPort: 8080
+`
+)
+
+type testkitOptions struct {
+	install     bool
+	installArgs []string
+	namespace   string
+	requests    uint
+	timeout     time.Duration
+	cleanup     bool
+}
+
+// testkitStep is a single stage of the testkit run. Steps are executed in
+// order, and the run stops at the first failing step, before the cleanup steps
+// which always run.
+type testkitStep struct {
+	description string
+	run         func() error
+}
+
+func newTestkitOptions() *testkitOptions {
+	return &testkitOptions{
+		install:     false,
+		installArgs: []string{},
+		namespace:   "linkerd-testkit",
+		requests:    20,
+		timeout:     5 * time.Minute,
+		cleanup:     true,
+	}
+}
+
+func (options *testkitOptions) validate() error {
+	if !alphaNumDash.MatchString(options.namespace) {
+		return fmt.Errorf("%s is not a valid namespace", options.namespace)
+	}
+	if options.requests == 0 {
+		return errors.New("--requests must be greater than 0")
+	}
+	if options.timeout <= 0 {
+		return errors.New("--timeout must be greater than 0")
+	}
+	return nil
+}
+
+func newCmdTestkit() *cobra.Command {
+	options := newTestkitOptions()
+
+	cmd := &cobra.Command{
+		Use:   "testkit [flags]",
+		Short: "Validate an end-to-end Linkerd installation on the current cluster",
+		Long: `Validate an end-to-end Linkerd installation on the current cluster.
+
+This command packages the Linkerd integration test helpers so that changes to a
+cluster or to the mesh configuration can be validated, e.g. in CI against an
+ephemeral cluster. It optionally installs the control plane, injects and
+deploys a sample application into its own namespace, generates traffic to it,
+and asserts that the control plane reports stats for the sample application.
+
+The sample application is removed once the run completes, unless --cleanup=false
+is set. The control plane is never removed.`,
+		Example: `  # Validate an existing Linkerd installation
+  linkerd alpha testkit
+
+  # Install Linkerd with HA settings, then validate it
+  linkerd alpha testkit --install --install-args=--ha`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			linkerd, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate the linkerd binary: %s", err)
+			}
+
+			h, err := testutil.NewGenericTestHelper(linkerd, controlPlaneNamespace, kubeContext, false)
+			if err != nil {
+				return err
+			}
+
+			if ok := runTestkitSteps(stdout, options.steps(h), options.cleanupSteps(h)); !ok {
+				os.Exit(2)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&options.install, "install", options.install, "Install the Linkerd control plane before running the validation")
+	cmd.PersistentFlags().StringSliceVar(&options.installArgs, "install-args", options.installArgs, "Additional flags passed to \"linkerd install\" when --install is set")
+	cmd.PersistentFlags().StringVar(&options.namespace, "app-namespace", options.namespace, "Namespace in which the sample application is deployed")
+	cmd.PersistentFlags().UintVar(&options.requests, "requests", options.requests, "Number of requests sent to the sample application")
+	cmd.PersistentFlags().DurationVar(&options.timeout, "timeout", options.timeout, "Maximum amount of time to wait for each step to succeed")
+	cmd.PersistentFlags().BoolVar(&options.cleanup, "cleanup", options.cleanup, "Delete the sample application namespace once the run completes")
+
+	return cmd
+}
+
+func (options *testkitOptions) steps(h *testutil.TestHelper) []testkitStep {
+	steps := []testkitStep{}
+
+	if options.install {
+		steps = append(steps, testkitStep{
+			description: "control plane is installed",
+			run: func() error {
+				args := append([]string{"install"}, options.installArgs...)
+				out, stderr, err := h.LinkerdRun(args...)
+				if err != nil {
+					return fmt.Errorf("linkerd install failed: %s\n%s", err, stderr)
+				}
+				if out, err := h.KubectlApply(out, h.GetLinkerdNamespace()); err != nil {
+					return fmt.Errorf("kubectl apply failed: %s\n%s", err, out)
+				}
+				return nil
+			},
+		})
+	}
+
+	steps = append(steps,
+		testkitStep{
+			description: "control plane is healthy",
+			run: func() error {
+				args := []string{"check", "--expected-version", h.GetVersion(), "--wait", options.timeout.String()}
+				if out, _, err := h.LinkerdRun(args...); err != nil {
+					return fmt.Errorf("linkerd check failed\n%s", out)
+				}
+				return nil
+			},
+		},
+		testkitStep{
+			description: "sample application is injected and deployed",
+			run: func() error {
+				out, stderr, err := h.PipeToLinkerdRun(testkitApp, "inject", "-")
+				if err != nil {
+					return fmt.Errorf("linkerd inject failed: %s\n%s", err, stderr)
+				}
+				if out, err := h.KubectlApply(out, options.namespace); err != nil {
+					return fmt.Errorf("kubectl apply failed: %s\n%s", err, out)
+				}
+				deadline := time.Now().Add(options.timeout)
+				for _, deploy := range []string{testkitTerminusDeploy, testkitGatewayDeploy} {
+					if err := h.CheckPodsFor(time.Until(deadline), options.namespace, deploy, 1); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		testkitStep{
+			description: "sample application serves meshed traffic",
+			run: func() error {
+				url, err := h.URLFor(options.namespace, testkitGatewayDeploy, testkitGatewayPort)
+				if err != nil {
+					return fmt.Errorf("failed to get URL for %s: %s", testkitGatewayDeploy, err)
+				}
+				for i := uint(0); i < options.requests; i++ {
+					body, err := h.HTTPGetURL(url)
+					if err != nil {
+						return err
+					}
+					if !strings.Contains(body, testkitResponseText) {
+						return fmt.Errorf("expected response to contain [%s], got [%s]", testkitResponseText, body)
+					}
+				}
+				return nil
+			},
+		},
+		testkitStep{
+			description: "control plane reports stats for the sample application",
+			run: func() error {
+				return h.RetryFor(options.timeout, func() error {
+					out, stderr, err := h.LinkerdRun("stat", "deploy", "--namespace", options.namespace)
+					if err != nil {
+						return fmt.Errorf("linkerd stat failed: %s\n%s", err, stderr)
+					}
+					return validateTestkitStats(out, []string{testkitGatewayDeploy, testkitTerminusDeploy})
+				})
+			},
+		},
+	)
+
+	return steps
+}
+
+func (options *testkitOptions) cleanupSteps(h *testutil.TestHelper) []testkitStep {
+	steps := []testkitStep{}
+
+	if options.cleanup {
+		steps = append(steps, testkitStep{
+			description: "sample application is removed",
+			run: func() error {
+				if out, err := h.Kubectl("", "delete", "namespace", options.namespace); err != nil {
+					return fmt.Errorf("kubectl delete failed: %s\n%s", err, out)
+				}
+				return nil
+			},
+		})
+	}
+
+	return steps
+}
+
+// runTestkitSteps executes steps in order until one of them fails, then the
+// cleanup steps, reporting the result of each one, and returns false if any
+// step failed.
+func runTestkitSteps(w io.Writer, steps, cleanup []testkitStep) bool {
+	success := runSteps(w, steps, true)
+	if !runSteps(w, cleanup, false) {
+		success = false
+	}
+
+	status := okStatus
+	if !success {
+		status = failStatus
+	}
+	fmt.Fprintf(w, "\nStatus check results are %s\n", status)
+	return success
+}
+
+// runSteps executes steps in order, reporting the result of each one, and
+// returns false if any step failed. With stopOnFailure, the steps following a
+// failing step aren't executed.
+func runSteps(w io.Writer, steps []testkitStep, stopOnFailure bool) bool {
+	success := true
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			fmt.Fprintf(w, "%s %s\n    %s\n", failStatus, step.description, err)
+			success = false
+			if stopOnFailure {
+				break
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n", okStatus, step.description)
+	}
+	return success
+}
+
+// validateTestkitStats verifies that the `linkerd stat` table output contains
+// a fully meshed row with a non-empty success rate for each deployment.
+func validateTestkitStats(out string, deployments []string) error {
+	rows := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			rows[fields[0]] = fields
+		}
+	}
+
+	for _, deploy := range deployments {
+		fields, ok := rows[deploy]
+		if !ok {
+			return fmt.Errorf("no stats found for deploy/%s", deploy)
+		}
+		if len(fields) < 3 {
+			return fmt.Errorf("unexpected stats for deploy/%s: %s", deploy, strings.Join(fields, " "))
+		}
+
+		meshed := strings.Split(fields[1], "/")
+		if len(meshed) != 2 || meshed[0] != meshed[1] || meshed[0] == "0" {
+			return fmt.Errorf("expected deploy/%s to be fully meshed, got %s", deploy, fields[1])
+		}
+		if fields[2] == "-" {
+			return fmt.Errorf("no traffic reported for deploy/%s", deploy)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateTestkitStats(t *testing.T) {
+	deploys := []string{testkitGatewayDeploy, testkitTerminusDeploy}
+
+	testCases := []struct {
+		out string
+		err error
+	}{
+		{
+			`NAME               MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99   TCP_CONN
+testkit-gateway       1/1   100.00%   0.3rps           1ms           2ms           2ms          1
+testkit-terminus      1/1   100.00%   0.3rps           1ms           1ms           1ms          1
+`,
+			nil,
+		},
+		{
+			`NAME               MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99   TCP_CONN
+testkit-gateway       1/1   100.00%   0.3rps           1ms           2ms           2ms          1
+`,
+			errors.New("no stats found for deploy/testkit-terminus"),
+		},
+		{
+			`NAME               MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99   TCP_CONN
+testkit-gateway       0/1         -        -             -             -             -          -
+testkit-terminus      1/1   100.00%   0.3rps           1ms           1ms           1ms          1
+`,
+			errors.New("expected deploy/testkit-gateway to be fully meshed, got 0/1"),
+		},
+		{
+			`NAME               MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99   TCP_CONN
+testkit-gateway       1/1         -        -             -             -             -          -
+testkit-terminus      1/1         -        -             -             -             -          -
+`,
+			errors.New("no traffic reported for deploy/testkit-gateway"),
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := validateTestkitStats(tc.out, deploys)
+			if fmt.Sprint(err) != fmt.Sprint(tc.err) {
+				t.Fatalf("Expected error [%v], got [%v]", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRunTestkitSteps(t *testing.T) {
+	ran := []string{}
+	step := func(name string, err error) testkitStep {
+		return testkitStep{
+			description: name,
+			run: func() error {
+				ran = append(ran, name)
+				return err
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	ok := runTestkitSteps(&buf, []testkitStep{
+		step("first", nil),
+		step("second", errors.New("boom")),
+		step("third", nil),
+	}, []testkitStep{
+		step("cleanup", nil),
+	})
+
+	if ok {
+		t.Fatal("Expected run to fail")
+	}
+	if strings.Join(ran, ",") != "first,second,cleanup" {
+		t.Fatalf("Expected steps to stop at the first failure before the cleanup, ran: %v", ran)
+	}
+
+	expected := fmt.Sprintf("%s first\n%s second\n    boom\n%s cleanup\n\nStatus check results are %s\n", okStatus, failStatus, okStatus, failStatus)
+	if buf.String() != expected {
+		t.Fatalf("Unexpected output:\nExpected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+
+	t.Run("Fails when the cleanup fails", func(t *testing.T) {
+		ran = []string{}
+		var buf bytes.Buffer
+		ok := runTestkitSteps(&buf, []testkitStep{
+			step("first", nil),
+		}, []testkitStep{
+			step("cleanup", errors.New("boom")),
+		})
+
+		if ok {
+			t.Fatal("Expected run to fail")
+		}
+		if strings.Join(ran, ",") != "first,cleanup" {
+			t.Fatalf("Expected all steps to run, ran: %v", ran)
+		}
+	})
+}
+
+func TestTestkitOptionsValidate(t *testing.T) {
+	options := newTestkitOptions()
+	options.requests = 0
+	if err := options.validate(); err == nil || err.Error() != "--requests must be greater than 0" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	options = newTestkitOptions()
+	options.namespace = "not_valid"
+	if err := options.validate(); err == nil || err.Error() != "not_valid is not a valid namespace" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
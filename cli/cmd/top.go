@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +32,8 @@ type topOptions struct {
 	path        string
 	hideSources bool
 	routes      bool
+	sortBy      string
+	pinned      []string
 }
 
 type topRequest struct {
@@ -93,6 +96,15 @@ func (r tableRow) merge(other tableRow) tableRow {
 	return r
 }
 
+// successRate returns the share of the responses that succeeded, or false if
+// there aren't any yet, e.g. while the first requests are in flight.
+func (r tableRow) successRate() (float32, bool) {
+	if r.successes+r.failures == 0 {
+		return 0, false
+	}
+	return float32(r.successes) / float32(r.successes+r.failures), true
+}
+
 type column int
 
 const (
@@ -110,13 +122,30 @@ const (
 	columnCount
 )
 
+const (
+	topSortRPS     = "rps"
+	topSortLatency = "latency"
+	topSortSuccess = "success"
+)
+
+var topSortKeys = []string{topSortRPS, topSortLatency, topSortSuccess}
+
 type topTable struct {
 	columns [columnCount]tableColumn
 	rows    []tableRow
+	sortBy  string
+	// Rows whose pinColumn value is in pinned are rendered at the top of the
+	// table, so that they keep their position as the table refreshes.
+	pinColumn column
+	pinned    map[string]bool
 }
 
 func newTopTable() *topTable {
-	table := topTable{}
+	table := topTable{
+		sortBy:    topSortRPS,
+		pinColumn: pathColumn,
+		pinned:    map[string]bool{},
+	}
 
 	table.columns[sourceColumn] =
 		tableColumn{
@@ -239,7 +268,11 @@ func newTopTable() *topTable {
 			flexible:   false,
 			rightAlign: true,
 			value: func(r tableRow) string {
-				return fmt.Sprintf("%.2f%%", 100.0*float32(r.successes)/float32(r.successes+r.failures))
+				sr, ok := r.successRate()
+				if !ok {
+					return "-"
+				}
+				return fmt.Sprintf("%.2f%%", 100.0*sr)
 			},
 		}
 
@@ -263,6 +296,8 @@ func newTopOptions() *topOptions {
 		path:        "",
 		hideSources: false,
 		routes:      false,
+		sortBy:      topSortRPS,
+		pinned:      []string{},
 	}
 }
 
@@ -297,15 +332,26 @@ func newCmdTop() *cobra.Command {
   * pods
   * replicationcontrollers
   * statefulsets
-  * services (only supported as a --to resource)`,
+  * services (only supported as a --to resource)
+
+  Rows are sorted by request count, worst latency or lowest success rate, as
+  set by --sort-by. Rows for the paths (or routes, with --routes) given to
+  --pin are always displayed at the top of the table.`,
 		Example: `  # display traffic for the web deployment in the default namespace
   linkerd top deploy/web
 
+  # display the slowest requests first, keeping the /api/vote path at the top
+  linkerd top deploy/web --sort-by latency --pin /api/vote
+
   # display traffic for the web-dlbvj pod in the default namespace
   linkerd top pod/web-dlbvj`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
 			requestParams := util.TapRequestParams{
 				Resource:    strings.Join(args, "/"),
 				Namespace:   options.namespace,
@@ -330,6 +376,12 @@ func newCmdTop() *cobra.Command {
 				table.columns[pathColumn].display = false
 				table.columns[routeColumn].key = true
 				table.columns[routeColumn].display = true
+				table.pinColumn = routeColumn
+			}
+
+			table.sortBy = options.sortBy
+			for _, pinned := range options.pinned {
+				table.pinned[pinned] = true
 			}
 
 			req, err := util.BuildTapByResourceRequest(requestParams)
@@ -359,10 +411,23 @@ func newCmdTop() *cobra.Command {
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
 	cmd.PersistentFlags().BoolVar(&options.routes, "routes", options.routes, "Display data per route instead of per path")
+	cmd.PersistentFlags().StringVar(&options.sortBy, "sort-by", options.sortBy,
+		fmt.Sprintf("Sort the table by this column; one of: %s", strings.Join(topSortKeys, ", ")))
+	cmd.PersistentFlags().StringSliceVar(&options.pinned, "pin", options.pinned,
+		"Keep the rows for these paths (or routes, with --routes) at the top of the table")
 
 	return cmd
 }
 
+func (o *topOptions) validate() error {
+	for _, key := range topSortKeys {
+		if o.sortBy == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --sort-by value [%s], must be one of: %s", o.sortBy, strings.Join(topSortKeys, ", "))
+}
+
 func getTrafficByResourceFromAPI(client pb.ApiClient, req *pb.TapByResourceRequest, table *topTable) error {
 	rsp, err := client.TapByResource(context.Background(), req)
 	if err != nil {
@@ -552,7 +617,11 @@ func (t *topTable) insert(req topRequest) {
 }
 
 func stripPort(address string) string {
-	return strings.Split(address, ":")[0]
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
 }
 
 func (t *topTable) renderHeaders() {
@@ -586,10 +655,36 @@ func (t *topTable) adjustColumnWidths() {
 	}
 }
 
-func (t *topTable) renderBody() {
+// sortRows sorts the rows with the pinned rows first, followed by the other
+// rows in the order of the table's sort key.
+func (t *topTable) sortRows() {
 	sort.SliceStable(t.rows, func(i, j int) bool {
-		return t.rows[i].count > t.rows[j].count
+		a, b := t.rows[i], t.rows[j]
+		pinnedA := t.pinned[t.columns[t.pinColumn].value(a)]
+		pinnedB := t.pinned[t.columns[t.pinColumn].value(b)]
+		if pinnedA != pinnedB {
+			return pinnedA
+		}
+
+		switch t.sortBy {
+		case topSortLatency:
+			return a.worst > b.worst
+		case topSortSuccess:
+			// Rows without responses are sorted last.
+			srA, okA := a.successRate()
+			srB, okB := b.successRate()
+			if okA != okB {
+				return okA
+			}
+			return srA < srB
+		default:
+			return a.count > b.count
+		}
 	})
+}
+
+func (t *topTable) renderBody() {
+	t.sortRows()
 
 	for i, row := range t.rows {
 		x := 0
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTopTableSortRows(t *testing.T) {
+	rows := []tableRow{
+		{path: "/fast", count: 10, worst: 5 * time.Millisecond, successes: 10},
+		{path: "/slow", count: 2, worst: 900 * time.Millisecond, successes: 2},
+		{path: "/failing", count: 5, worst: 50 * time.Millisecond, successes: 1, failures: 4},
+		{path: "/pending", count: 1, worst: 0},
+	}
+
+	paths := func(table *topTable) []string {
+		result := []string{}
+		for _, row := range table.rows {
+			result = append(result, row.path)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		sortBy   string
+		pinned   []string
+		expected []string
+	}{
+		{topSortRPS, nil, []string{"/fast", "/failing", "/slow", "/pending"}},
+		{topSortLatency, nil, []string{"/slow", "/failing", "/fast", "/pending"}},
+		{topSortSuccess, nil, []string{"/failing", "/fast", "/slow", "/pending"}},
+		{topSortRPS, []string{"/slow"}, []string{"/slow", "/fast", "/failing", "/pending"}},
+		{topSortLatency, []string{"/fast", "/failing"}, []string{"/failing", "/fast", "/slow", "/pending"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.sortBy, func(t *testing.T) {
+			table := newTopTable()
+			table.rows = append([]tableRow{}, rows...)
+			table.sortBy = tc.sortBy
+			for _, pinned := range tc.pinned {
+				table.pinned[pinned] = true
+			}
+
+			table.sortRows()
+			if actual := paths(table); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("Expected rows %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTopOptionsValidate(t *testing.T) {
+	options := newTopOptions()
+	if err := options.validate(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	options.sortBy = "count"
+	expected := "invalid --sort-by value [count], must be one of: rps, latency, success"
+	if err := options.validate(); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
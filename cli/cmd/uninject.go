@@ -33,7 +33,7 @@ func newCmdUninject() *cobra.Command {
 		Long: `Remove the Linkerd proxy from a Kubernetes config.
 
 You can uninject resources contained in a single file, inside a folder and its
-sub-folders, or coming from stdin.`,
+sub-folders, served at an http(s) URL, or coming from stdin.`,
 		Example: `  # Uninject all the deployments in the default namespace.
   kubectl get deploy -o yaml | linkerd uninject - | kubectl apply -f -
 
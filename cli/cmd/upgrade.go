@@ -2,18 +2,25 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	pb "github.com/linkerd/linkerd2/controller/gen/config"
 	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -23,11 +30,47 @@ const (
 )
 
 type (
-	upgradeOptions struct{ *installOptions }
+	upgradeOptions struct {
+		manifests       string
+		helmRelease     string
+		tillerNamespace string
+		dryRun          bool
+		apply           bool
+		force           bool
+		skipChecks      bool
+		merge           bool
+		summaryOutput   string
+		backupDir       string
+		backupKeyFile   string
+		yes             bool
+		canary          bool
+		canaryNamespace string
+		*installOptions
+
+		// Install flags derived from the values of the release given by
+		// --from-helm-release.
+		helmFlags []*pb.Install_Flag
+
+		// The documents read from --from-manifests, which are only read once
+		// as it may be stdin.
+		manifestsData []byte
+
+		// The flags of the upgrade, the version upgraded from and whether
+		// identity is generated anew, set by validateAndBuild.
+		flagChanges       []*flagChange
+		fromVersion       string
+		identityGenerated bool
+	}
 )
 
 func newUpgradeOptionsWithDefaults() *upgradeOptions {
-	return &upgradeOptions{newInstallOptionsWithDefaults()}
+	return &upgradeOptions{
+		manifests:       "",
+		helmRelease:     "",
+		tillerNamespace: defaultTillerNamespace,
+		summaryOutput:   tableOutput,
+		installOptions:  newInstallOptionsWithDefaults(),
+	}
 }
 
 func newCmdUpgrade() *cobra.Command {
@@ -41,45 +84,538 @@ func newCmdUpgrade() *cobra.Command {
 
 Note that the default flag values for this command come from the Linkerd control
 plane. The default values displayed in the Flags section below only apply to the
-install command.`,
+install command.
+
+With --dry-run, the resources of the upgrade are compared with the ones in the
+cluster instead of being output, and the changes the upgrade would make to each
+of them are shown. The contents of secrets are never shown.
+
+With --apply, the upgrade is applied to the cluster instead of being output, and
+the resources of the control plane that aren't part of it anymore are deleted.
+Those are the deployments, services, config maps, secrets, service accounts,
+cluster roles and cluster role bindings labeled with
+linkerd.io/control-plane-component. Use --dry-run along with --apply to also
+list the resources that would be deleted.
+
+Before rendering the upgrade, the health of the control plane, its
+configuration and issuer credentials, and the permissions to update its
+resources are checked, unless --skip-checks or --from-manifests is set.
+
+The ServiceProfile CRD in the cluster is compared with the rendered one: the
+upgrade fails if ServiceProfiles are stored in a version it doesn't serve
+anymore, resets preserveUnknownFields when the CRD is converted to
+apiextensions.k8s.io/v1, and warns about the stored ServiceProfiles the new
+schema doesn't validate.
+
+With --merge, the fields added to the resources of the control plane with
+kubectl apply, e.g. annotations, tolerations or sidecar containers, are merged
+into the upgrade, so that they aren't lost. Fields rendered by the upgrade take
+precedence, and containers are merged by name. As the fields applied last are
+kept, so are those the upgrade doesn't render anymore.
+
+With --from-helm-release, the configuration is read from the objects rendered
+by the deployed revision of a Helm release of the chart instead, and the flags
+of the upgrade default to the values of the release, so that a control plane
+installed with Helm can be upgraded with the CLI. Conversely, with
+--output-values, the values of the chart the upgrade would be rendered from
+are output instead of the configs. Helm renders the control plane from them
+without proxies, as they're only injected by the CLI.
+
+With --values, the flags are set from a YAML file of chart values, e.g.
+ControllerReplicas or ProxyAutoInjectEnabled, as with "linkerd install
+--values", so that the configuration can be kept in a file under review. The
+values take precedence over the recorded flags, and are recorded in turn.
+The values that have no flag of their own, e.g. ImagePullPolicy or
+WebResources.CPU.Limit, are overridden as with --set, whose values are
+recorded and kept by later upgrades, unless they're set again or removed with
+a dash, e.g. --set ImagePullPolicy-.
+
+With --identity-issuer-certificate-file and --identity-issuer-key-file, the
+issuer credentials of the control plane are replaced, e.g. before they expire.
+The new certificate must be signed by the trust anchors of the control plane,
+so that the proxies keep trusting each other during the rotation.
+
+The flags recorded by the previous install or upgrade apply unless they're set
+again. The flags whose value the upgrade changes are listed on stderr; with
+--summary-output json, every flag is listed along with its previous value and
+whether it came from the cluster, the command line or the Helm release, so that
+pipelines can check for unexpected changes.
+
+With --backup-dir, the linkerd-config ConfigMap and the identity issuer Secret
+are saved to a new directory before the upgrade is rendered, the Secret being
+encrypted with a key derived with scrypt from the secret held by the file
+given by --backup-key-file, and a salt saved with it. "linkerd upgrade restore"
+outputs them again to recover from a bad upgrade. "linkerd upgrade export"
+outputs them along with the recorded flags, to upgrade from with
+--from-manifests, e.g. on another cluster.
+
+With --canary, the upgrade is rendered as a canary control plane in the
+namespace given by --canary-namespace, next to the current one, so that the new
+version can be validated side-by-side before "linkerd upgrade promote" switches
+the meshed workloads over to it. The canary shares the trust anchors of the
+control plane and only injects the namespaces labeled for it; its issuer
+credentials must be given with --identity-issuer-certificate-file and
+--identity-issuer-key-file, signed by the trust anchors for the canary's
+namespace. The canary's destination service gives TLS identities to the pods
+meshed by either control plane, while the current one's only gives them to the
+pods it meshes, so requests from its proxies to the canary's pods aren't
+secured by TLS until they're promoted.
+
+With --output-dir, the configs are written to a directory instead, one file
+per component of the control plane, e.g. 04-identity.yaml or 10-webhooks.yaml,
+numbered in the order they must be applied, so that they can be applied in
+part or reviewed in a GitOps repository. A component keeps its number across
+upgrades. "linkerd install --output-dir" writes the same files.
+With --output kustomize, they're written as a Kustomize base instead, one file
+per resource listed by a kustomization.yaml, for overlays to patch.
+
+Once the upgrade is applied, "linkerd upgrade verify" waits for it to roll out
+to the control plane and the proxies.
+
+When run in a terminal, the versions upgraded from and to, and whether identity
+is generated, are shown and the upgrade asks for confirmation before outputting
+or applying anything, unless --yes or --dry-run is set.
+
+Upgrading a stable release requires upgrading to each minor version in turn,
+e.g. from stable-2.3 to stable-2.4 before stable-2.5, unless --force is set.
+
+When the linkerd-config ConfigMap is missing or corrupt, --force reconstructs
+the configuration from the flags of the upgrade and from what the deployments
+of the control plane tell: its version, the trust anchors and trust domain of
+its proxies, its registry and images, the replicas of the controller and
+whether proxies are injected automatically or without an init container. The
+ConfigMap is repaired by the upgrade; the issuer credentials are read from
+their Secret as usual.`,
+		Example: `  # Show the changes an upgrade would make to the control plane.
+  linkerd upgrade --dry-run
+
+  # Apply the upgrade, and wait for it to roll out.
+  linkerd upgrade | kubectl apply -f -
+  linkerd upgrade verify
+
+  # Apply the upgrade and delete the resources it doesn't render anymore.
+  linkerd upgrade --apply --dry-run
+  linkerd upgrade --apply
+
+  # Rotate the issuer credentials.
+  linkerd upgrade --identity-issuer-certificate-file issuer.crt --identity-issuer-key-file issuer.key | kubectl apply -f -
+
+  # Upgrade a control plane installed with the chart as the "linkerd" release.
+  linkerd upgrade --from-helm-release linkerd | kubectl apply -f -
+
+  # Validate the upgrade on a canary control plane before switching over to it.
+  linkerd upgrade --canary --identity-issuer-certificate-file canary.crt --identity-issuer-key-file canary.key | kubectl apply -f -
+  linkerd upgrade promote
+
+  # Back up the control plane before upgrading it.
+  linkerd upgrade --backup-dir backups --backup-key-file backup.key | kubectl apply -f -
+
+  # Apply the upgrade in two stages, e.g. with different credentials.
+  linkerd upgrade config | kubectl apply -f -
+  linkerd upgrade control-plane | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if options.ignoreCluster {
-				panic("ignore cluster must be unset") // Programmer error.
-			}
+			return upgradeRunE(options, flags, "")
+		},
+	}
 
-			// We need a Kubernetes client to fetch configs and issuer secrets.
-			c, err := k8s.GetConfig(kubeconfigPath, kubeContext)
-			if err != nil {
-				upgradeErrorf("Failed to get kubernetes config: %s", err)
-			}
+	outputFlags := options.outputFlagSet()
+	cmd.Flags().AddFlagSet(outputFlags)
 
-			k, err := kubernetes.NewForConfig(c)
-			if err != nil {
-				upgradeErrorf("Failed to create a kubernetes client: %s", err)
-			}
+	cmd.AddCommand(newCmdUpgradeStage(options, flags, outputFlags, configStage))
+	cmd.AddCommand(newCmdUpgradeStage(options, flags, outputFlags, controlPlaneStage))
+	cmd.AddCommand(newCmdUpgradeRollback(options))
+	cmd.AddCommand(newCmdUpgradeHistory(options))
+	cmd.AddCommand(newCmdUpgradeRestore(options))
+	cmd.AddCommand(newCmdUpgradePromote(options))
+	cmd.AddCommand(newCmdUpgradeVerify())
+	cmd.AddCommand(newCmdUpgradeExport())
+
+	cmd.PersistentFlags().AddFlagSet(flags)
+	cmd.PersistentFlags().StringVar(
+		&options.manifests, "from-manifests", options.manifests,
+		"Read config from a Linkerd install YAML rather than from Kubernetes; may be a file, a directory of YAML or JSON files, an http(s) URL, or \"-\" for stdin",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.outputValues, "output-values", options.outputValues,
+		"Output the values of the linkerd Helm chart the configs are rendered from instead of the configs; Helm renders the control plane from them without proxies (default false)",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.valuesFile, "values", options.valuesFile,
+		"A path to a YAML file of chart values that set the flags they are rendered from, over the recorded flags, unless those are set on the command line; the values that have no flag are overridden as with --set",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.helmRelease, "from-helm-release", options.helmRelease,
+		"Read config and flags from the deployed revision of the named Helm release rather than from the control plane",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.identityOptions.crtPEMFile, "identity-issuer-certificate-file", options.identityOptions.crtPEMFile,
+		"A path to a PEM-encoded file containing a new Linkerd Identity issuer certificate, signed by the existing trust anchors (default: the existing issuer)",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.identityOptions.keyPEMFile, "identity-issuer-key-file", options.identityOptions.keyPEMFile,
+		"A path to a PEM-encoded file containing the private key of the new Linkerd Identity issuer certificate",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.tillerNamespace, "tiller-namespace", options.tillerNamespace,
+		"Namespace in which Tiller stores the releases read with --from-helm-release",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.dryRun, "dry-run", options.dryRun,
+		"Show the changes the upgrade would make to the resources in the cluster, instead of outputting them",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.apply, "apply", options.apply,
+		"Apply the upgrade to the cluster instead of outputting it, deleting the resources of the control plane it doesn't render anymore",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.force, "force", options.force,
+		"Upgrade even if minor versions of the control plane would be skipped, or reconstruct the linkerd-config ConfigMap if it's missing or corrupt",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.skipChecks, "skip-checks", options.skipChecks,
+		"Skip the checks run against the control plane before rendering the upgrade",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.merge, "merge", options.merge,
+		"Merge the fields added to the resources of the control plane with kubectl apply into the upgrade",
+	)
+	cmd.PersistentFlags().BoolVarP(
+		&options.yes, "yes", "y", options.yes,
+		"Upgrade without asking for confirmation when run in a terminal",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.canary, "canary", options.canary,
+		"Render the upgrade as a canary control plane in the namespace given by --canary-namespace, next to the current one",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.canaryNamespace, "canary-namespace", options.canaryNamespace,
+		"Namespace of the canary control plane (default: the control plane's namespace suffixed with \"-canary\")",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.backupDir, "backup-dir", options.backupDir,
+		"Back up the configuration and the identity issuer credentials of the control plane to a new directory in this directory before rendering the upgrade",
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.backupKeyFile, "backup-key-file", options.backupKeyFile,
+		"Path to a file holding the secret the backed up identity issuer credentials are encrypted with",
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.recordTimeAndUser, "record-time-and-user", options.recordTimeAndUser,
+		recordTimeAndUserUsage,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.summaryOutput, "summary-output", options.summaryOutput,
+		fmt.Sprintf("Format of the summary of the flags changed by the upgrade, written to stderr; one of: \"%s\" or \"%s\" (lists every flag with its previous value and source)", tableOutput, jsonOutput),
+	)
+	return cmd
+}
+
+// outputFlagSet returns the flags of the commands that write the configs of
+// the upgrade, which the other upgrade subcommands don't have.
+func (options *upgradeOptions) outputFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("upgrade-output", pflag.ExitOnError)
+	flags.StringVar(
+		&options.outputDir, "output-dir", options.outputDir,
+		"Write the configs to this directory instead of stdout, one file per component of the control plane, numbered in the order they must be applied; a component keeps its number across renders, and only the files of a previous render are replaced",
+	)
+	flags.StringVarP(
+		&options.outputFormat, "output", "o", options.outputFormat,
+		fmt.Sprintf("Output format of the configs; one of: \"%s\" or \"%s\", which writes them to --output-dir as a Kustomize base, one file per resource listed by a kustomization.yaml", yamlOutput, kustomizeOutput),
+	)
+	setOutputFormats(flags, yamlOutput, kustomizeOutput)
+	return flags
+}
+
+func upgradeRunE(options *upgradeOptions, flags *pflag.FlagSet, stage string) error {
+	if options.ignoreCluster {
+		panic("ignore cluster must be unset") // Programmer error.
+	}
+
+	if options.apply && !options.dryRun && options.manifests != "" {
+		upgradeErrorf("--apply can't be used with --from-manifests, unless along with --dry-run")
+	}
+
+	if options.manifests != "" && options.helmRelease != "" {
+		upgradeErrorf("--from-manifests and --from-helm-release can't be used together")
+	}
+
+	if options.outputDir != "" && (options.apply || options.dryRun) {
+		upgradeErrorf("--output-dir can't be used with --apply or --dry-run")
+	}
+
+	if err := options.validateOutputFormat(); err != nil {
+		upgradeErrorf("%s", err)
+	}
+
+	if options.outputValues && (options.apply || options.dryRun || options.outputDir != "") {
+		upgradeErrorf("--output-values can't be used with --apply, --dry-run or --output-dir")
+	}
+
+	if options.summaryOutput != tableOutput && options.summaryOutput != jsonOutput {
+		upgradeErrorf("--summary-output currently only supports %s and %s", tableOutput, jsonOutput)
+	}
+
+	if options.canary {
+		options.setCanaryNamespace()
+		if options.canaryNamespace == controlPlaneNamespace {
+			upgradeErrorf("--canary-namespace must differ from the control plane's namespace")
+		}
+	}
+
+	var backupKey []byte
+	if options.backupDir != "" {
+		if options.manifests != "" || options.helmRelease != "" {
+			upgradeErrorf("--backup-dir can't be used with --from-manifests or --from-helm-release")
+		}
+		var err error
+		if backupKey, err = readBackupKey(options.backupKeyFile); err != nil {
+			upgradeErrorf("Failed to read the backup key: %s", err)
+		}
+	}
+
+	if !options.skipChecks && options.manifests == "" {
+		hc := healthcheck.NewHealthChecker(
+			[]healthcheck.CategoryID{healthcheck.KubernetesAPIChecks, healthcheck.LinkerdPreUpgradeChecks},
+			&healthcheck.Options{
+				ControlPlaneNamespace: controlPlaneNamespace,
+				KubeConfig:            kubeconfigPath,
+				KubeContext:           kubeContext,
+			},
+		)
+		if !runPreUpgradeChecks(os.Stderr, hc) {
+			upgradeErrorf("Pre-upgrade checks failed; fix the issues above, or use --skip-checks to upgrade anyway")
+		}
+	}
+
+	// We need a Kubernetes client to fetch configs and issuer secrets.
+	k, live := options.newClients()
+
+	if options.backupDir != "" {
+		path, err := backupControlPlane(k, options.backupDir, backupKey, time.Now())
+		if err != nil {
+			upgradeErrorf("Failed to back up the control plane: %s", err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Backed up the configuration and issuer credentials of the control plane to %s\n", okStatus, path)
+	}
+
+	values, configs, err := options.validateAndBuild(k, flags)
+	if err != nil {
+		upgradeErrorf("Failed to build upgrade configuration: %s", err)
+	}
 
-			values, configs, err := options.validateAndBuild(k, flags)
+	if err = printFlagChanges(os.Stderr, options.flagChanges, options.summaryOutput); err != nil {
+		upgradeErrorf("Could not write the summary of the flags: %s", err)
+	}
+
+	if err = checkUpgradeCRDs(os.Stderr, live, values); err != nil {
+		upgradeErrorf("Failed to upgrade the CRDs: %s", err)
+	}
+
+	// Ask before changing anything when run interactively, as e.g. generating
+	// identity for a control plane that didn't have it is surprising. A dry
+	// run changes nothing, so there's nothing to confirm.
+	if !options.yes && !options.dryRun && isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stderr.Fd()) {
+		if !options.confirmUpgrade(os.Stdin, os.Stderr) {
+			fmt.Fprintln(os.Stderr, "Upgrade cancelled")
+			os.Exit(1)
+		}
+	}
+
+	// The configuration was read from the control plane, but the resources
+	// are rendered, merged, compared and applied in the canary's namespace.
+	if options.canary {
+		controlPlaneNamespace = options.canaryNamespace
+	}
+
+	if options.outputValues {
+		if err = writeValues(os.Stdout, values); err != nil {
+			upgradeErrorf("Could not write the upgrade values: %s", err)
+		}
+		fmt.Fprintf(os.Stderr, "%s %s\n", warnStatus, uninjectedValuesWarning)
+		return nil
+	}
+
+	// rendering to a buffer and printing full contents of buffer after
+	// render is complete, to ensure that okStatus prints separately
+	var buf bytes.Buffer
+	if err = values.render(&buf, configs); err != nil {
+		upgradeErrorf("Could not render upgrade configuration: %s", err)
+	}
+
+	if stage != "" {
+		var staged bytes.Buffer
+		if err = filterUpgradeStage(&staged, &buf, stage); err != nil {
+			upgradeErrorf("Could not render the %s stage of the upgrade: %s", stage, err)
+		}
+		buf = staged
+	}
+
+	if options.merge && live != nil {
+		var merged bytes.Buffer
+		if err = mergeUpgrade(&merged, &buf, live); err != nil {
+			upgradeErrorf("Could not merge the upgrade with the resources of the control plane: %s", err)
+		}
+		buf = merged
+	}
+
+	if options.dryRun {
+		manifests := buf.Bytes()
+		summary, err := diffUpgrade(os.Stdout, bytes.NewReader(manifests), live)
+		if err != nil {
+			upgradeErrorf("Could not diff upgrade configuration: %s", err)
+		}
+		if options.apply {
+			pruned, err := pruneUpgrade(os.Stdout, manifests, live, stage, true)
 			if err != nil {
-				upgradeErrorf("Failed to build upgrade configuration: %s", err)
+				upgradeErrorf("Could not list the resources to prune: %s", err)
 			}
+			fmt.Fprintf(os.Stderr, "\n%s Upgrade dry run: %s, %d to prune; nothing was applied\n", okStatus, summary, pruned)
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "\n%s Upgrade dry run: %s; nothing was applied\n", okStatus, summary)
+		return nil
+	}
 
-			// rendering to a buffer and printing full contents of buffer after
-			// render is complete, to ensure that okStatus prints separately
-			var buf bytes.Buffer
-			if err = values.render(&buf, configs); err != nil {
-				upgradeErrorf("Could not render upgrade configuration: %s", err)
-			}
+	if options.apply {
+		summary, err := applyUpgrade(os.Stdout, buf.Bytes(), live, stage)
+		if err != nil {
+			upgradeErrorf("Could not apply upgrade configuration: %s", err)
+		}
+		fmt.Fprintf(os.Stderr, "\n%s Upgrade applied: %s\n", okStatus, summary)
+		return nil
+	}
 
-			buf.WriteTo(os.Stdout)
+	if options.outputDir != "" {
+		paths, err := writeOutput(options.outputDir, options.outputFormat, &buf)
+		if err != nil {
+			upgradeErrorf("Could not write the upgrade configuration to %s: %s", options.outputDir, err)
+		}
+		for _, path := range paths {
+			fmt.Fprintf(os.Stderr, "%s Wrote %s\n", okStatus, path)
+		}
+	} else {
+		buf.WriteTo(os.Stdout)
+	}
 
-			fmt.Fprintf(os.Stderr, "\n%s %s\n", okStatus, okMessage)
+	if options.canary {
+		fmt.Fprintf(os.Stderr, "\n%s The proxies of the current control plane don't use TLS with the pods meshed by the canary, as its destination service doesn't give them identities; the canary's proxies use TLS with both\n", warnStatus)
+		fmt.Fprintf(os.Stderr, "%s Once the canary is validated, run \"linkerd upgrade promote\" to switch the meshed workloads over to it\n", okStatus)
+		return nil
+	}
 
-			return nil
-		},
+	fmt.Fprintf(os.Stderr, "\n%s %s\n", okStatus, okMessage)
+
+	return nil
+}
+
+// runPreUpgradeChecks runs the checks of hc, printing the ones that fail along
+// with hints to fix them. It returns false if any check failed.
+func runPreUpgradeChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
+	return hc.RunChecks(func(result *healthcheck.CheckResult) {
+		if result.Retry || result.Err == nil {
+			return
+		}
+
+		status := failStatus
+		if result.Warning {
+			status = warnStatus
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", status, result.Description, result.Err)
+		if result.HintAnchor != "" {
+			fmt.Fprintf(w, "    see %s%s for hints\n", healthcheck.HintBaseURL, result.HintAnchor)
+		}
+	})
+}
+
+// newClients returns a client to fetch the control plane's configuration from
+// the cluster, or from --from-manifests or --from-helm-release, and a dynamic
+// client to fetch, merge with and apply the live resources of the control
+// plane, e.g. its CRDs.
+func (options *upgradeOptions) newClients() (kubernetes.Interface, dynamic.Interface) {
+	var k kubernetes.Interface
+	var live dynamic.Interface
+	var err error
+	if options.manifests != "" {
+		k, err = options.newFakeClientSetFromManifests()
+		if err != nil {
+			upgradeErrorf("Failed to parse Kubernetes objects from manifest %s: %s", options.manifests, err)
+		}
+		live, err = options.newFakeDynamicClientFromManifests()
+		if err != nil {
+			upgradeErrorf("Failed to parse Kubernetes objects from manifest %s: %s", options.manifests, err)
+		}
+		return k, live
 	}
 
-	cmd.PersistentFlags().AddFlagSet(flags)
-	return cmd
+	c, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+	if err != nil {
+		upgradeErrorf("Failed to get kubernetes config: %s", err)
+	}
+
+	k, err = kubernetes.NewForConfig(c)
+	if err != nil {
+		upgradeErrorf("Failed to create a kubernetes client: %s", err)
+	}
+
+	live, err = dynamic.NewForConfig(c)
+	if err != nil {
+		upgradeErrorf("Failed to create a kubernetes client: %s", err)
+	}
+
+	if options.kubernetesVersion == "" {
+		version, err := k.Discovery().ServerVersion()
+		if err != nil {
+			upgradeErrorf("Failed to detect the Kubernetes version, use --kubernetes-version to set it: %s", err)
+		}
+		options.kubernetesVersion = version.GitVersion
+	}
+
+	if options.helmRelease != "" {
+		k, err = options.newFakeClientSetFromHelmRelease(k)
+		if err != nil {
+			upgradeErrorf("Failed to read the %s Helm release: %s", options.helmRelease, err)
+		}
+	}
+
+	return k, live
+}
+
+// newFakeClientSetFromManifests serves the objects in the manifests given by
+// --from-manifests, so that an upgrade can be rendered offline from the output
+// of a previous install.
+func (options *upgradeOptions) newFakeClientSetFromManifests() (kubernetes.Interface, error) {
+	manifests, err := options.readFromManifests()
+	if err != nil {
+		return nil, err
+	}
+
+	k, _, _, err := k8s.NewFakeClientSetsFromManifests([]io.Reader{bytes.NewReader(manifests)})
+	return k, err
+}
+
+// readFromManifests returns the documents given by --from-manifests: a file, a
+// directory whose YAML and JSON files are read recursively, an http(s) URL, or
+// "-" for stdin.
+func (options *upgradeOptions) readFromManifests() ([]byte, error) {
+	if options.manifestsData != nil {
+		return options.manifestsData, nil
+	}
+
+	in, err := read(options.manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, r := range in {
+		if _, err := io.Copy(&buf, r); err != nil {
+			return nil, err
+		}
+		// Files don't necessarily end with a newline or a separator.
+		buf.WriteString("\n---\n")
+	}
+	options.manifestsData = buf.Bytes()
+	return options.manifestsData, nil
 }
 
 func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *pflag.FlagSet) (*installValues, *pb.All, error) {
@@ -92,9 +628,36 @@ func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *p
 	// this also serves as a passive check that we have privileges to access this
 	// control plane.
 	configs, err := fetchConfigs(k)
-	if err != nil {
+	if err != nil && configsDamaged(err) && options.force {
+		// The ConfigMap is repaired by the upgrade.
+		fmt.Fprintf(os.Stderr, "%s The %s ConfigMap is missing or corrupt (%s); reconstructing it from the control plane's deployments\n", warnStatus, k8s.ConfigConfigMapName, err)
+		configs, err = options.reconstructConfigs(k, flags, os.Stderr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not reconstruct the configuration of the control plane: %s", err)
+		}
+	} else if err != nil && configsDamaged(err) {
+		return nil, nil, fmt.Errorf("could not fetch configs from kubernetes: %s; use --force to reconstruct them from the control plane's deployments", err)
+	} else if err != nil {
 		return nil, nil, fmt.Errorf("could not fetch configs from kubernetes: %s", err)
 	}
+	previous := proto.Clone(configs).(*pb.All)
+	options.fromVersion = configs.GetInstall().GetCliVersion()
+
+	// Rendering the upgrade for another namespace than the one the control
+	// plane was installed for would leave the existing proxies pointing at it.
+	// Control planes installed before the namespace was recorded get it now.
+	if ns := configs.GetGlobal().GetLinkerdNamespace(); ns != "" && ns != controlPlaneNamespace {
+		return nil, nil, fmt.Errorf("the control plane in the \"%s\" namespace is configured for the \"%s\" namespace; re-install it to move it", controlPlaneNamespace, ns)
+	} else if ns == "" && configs.GetGlobal() != nil {
+		configs.GetGlobal().LinkerdNamespace = controlPlaneNamespace
+	}
+
+	// Minor versions may rely on migrations done by the previous one, so they
+	// can't be skipped.
+	if path := version.UpgradePath(configs.GetInstall().GetCliVersion(), options.linkerdVersion); len(path) > 0 && !options.force {
+		return nil, nil, fmt.Errorf("upgrading from %s to %s would skip minor versions; upgrade to %s first, or use --force to upgrade anyway",
+			configs.GetInstall().GetCliVersion(), options.linkerdVersion, strings.Join(path, ", then "))
+	}
 
 	// If the install config needs to be repaired--either because it did not
 	// exist or because it is missing expected fields, repair it.
@@ -105,11 +668,37 @@ func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *p
 	//
 	// This implies that the default flag values for the upgrade command come
 	// from the control-plane, and not from the defaults specified in the FlagSet.
+	commandLine := changedFlagNames(flags)
+
+	// The values of the file given by --values apply as if they were set on
+	// the command line, unless they are.
+	fileFlags, err := readValuesFile(options.valuesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setFlagsFromValues(flags, fileFlags); err != nil {
+		return nil, nil, err
+	}
+	for _, f := range fileFlags {
+		commandLine[f.GetName()] = true
+	}
+
 	setFlagsFromInstall(flags, configs.GetInstall().GetFlags())
 
+	// The values of a Helm release apply to the flags that weren't recorded,
+	// as the chart doesn't record them.
+	setFlagsFromInstall(flags, options.helmFlags)
+
+	// A canary must only inject the namespaces labeled for it, so that it
+	// doesn't compete with the control plane's proxy injector.
+	if options.canary {
+		flags.Set("scoped-webhooks", "true")
+	}
+
 	// Save off the updated set of flags into the installOptions so it gets
 	// persisted with the upgraded config.
 	options.recordFlags(flags)
+	options.flagChanges = buildFlagChanges(flags, previous.GetInstall().GetFlags(), commandLine, options.helmFlags)
 
 	// Update the configs from the synthesized options.
 	options.overrideConfigs(configs, map[string]string{})
@@ -118,9 +707,20 @@ func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *p
 	}
 	configs.GetInstall().Flags = options.recordedFlags
 
+	// A canary is rendered from the configuration of the control plane, for
+	// a namespace of its own.
+	namespace := controlPlaneNamespace
+	if options.canary {
+		namespace = options.canaryNamespace
+		configs.GetGlobal().LinkerdNamespace = namespace
+	}
+
 	var identity *installIdentityValues
 	idctx := configs.GetGlobal().GetIdentityContext()
-	if idctx.GetTrustDomain() == "" || idctx.GetTrustAnchorsPem() == "" {
+	if options.canary && (idctx.GetTrustDomain() == "" || idctx.GetTrustAnchorsPem() == "") {
+		// The proxies of the canary couldn't be trusted by the existing ones.
+		return nil, nil, errors.New("the control plane has no identity; upgrade it before rendering a canary")
+	} else if idctx.GetTrustDomain() == "" || idctx.GetTrustAnchorsPem() == "" {
 		// If there wasn't an idctx, or if it doesn't specify the required fields, we
 		// must be upgrading from a version that didn't support identity, so generate it anew...
 		identity, err = options.identityOptions.genValues()
@@ -128,6 +728,15 @@ func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *p
 			return nil, nil, fmt.Errorf("unable to generate issuer credentials: %s", err)
 		}
 		configs.GetGlobal().IdentityContext = identity.toIdentityContext()
+		options.identityGenerated = true
+	} else if options.canary && options.identityOptions.crtPEMFile == "" && options.identityOptions.keyPEMFile == "" {
+		return nil, nil, fmt.Errorf("a canary needs issuer credentials for identity.%s.%s; set --identity-issuer-certificate-file and --identity-issuer-key-file", namespace, idctx.GetTrustDomain())
+	} else if options.identityOptions.crtPEMFile != "" || options.identityOptions.keyPEMFile != "" {
+		// The issuer is being rotated, or the canary needs its own.
+		identity, err = options.readIssuerValues(idctx, namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read the new issuer credentials: %s", err)
+		}
 	} else {
 		identity, err = fetchIdentityValues(k, options.controllerReplicas, idctx)
 		if err != nil {
@@ -142,14 +751,53 @@ func (options *upgradeOptions) validateAndBuild(k kubernetes.Interface, flags *p
 		return nil, nil, fmt.Errorf("could not build install configuration: %s", err)
 	}
 	values.Identity = identity
+	values.Namespace = namespace
+
+	// The proxies of the control plane and of its canary share the trust
+	// anchors, so the canary's destination service gives identities to both.
+	if options.canary {
+		values.IdentityPeerNamespaces = controlPlaneNamespace
+	}
+
+	// A canary has no previous configuration to roll back to; it's deleted
+	// instead.
+	if !options.canary {
+		values.PreviousConfigs, err = buildPreviousConfigValues(k, previous, configs, options.kubernetesVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not save the previous configuration for rollbacks: %s", err)
+		}
+	}
+
+	history, err := fetchHistory(k)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not fetch the upgrade history: %s", err)
+	}
+	values.History, err = buildHistory(history, "upgrade", values, options.recordedFlags, options.recordTimeAndUser)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return values, configs, nil
 }
 
 func setFlagsFromInstall(flags *pflag.FlagSet, installFlags []*pb.Install_Flag) {
 	for _, i := range installFlags {
-		if f := flags.Lookup(i.GetName()); f != nil && !f.Changed {
-			f.Value.Set(i.GetValue())
+		f := flags.Lookup(i.GetName())
+		if f == nil {
+			continue
+		}
+		if o, ok := f.Value.(*valueOverrides); ok && f.Changed {
+			// The recorded --set values are kept, unless set again.
+			o.merge(i.GetValue())
+			continue
+		}
+		if !f.Changed {
+			value := i.GetValue()
+			if strings.HasSuffix(f.Value.Type(), "Slice") {
+				// Slice flags were recorded in brackets by earlier versions.
+				value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			}
+			f.Value.Set(value)
 			f.Changed = true
 		}
 	}
@@ -201,6 +849,39 @@ func fetchIdentityValues(k kubernetes.Interface, replicas uint, idctx *pb.Identi
 		return nil, err
 	}
 
+	return identityValuesWithIssuer(replicas, idctx, keyPEM, crtPEM, expiry), nil
+}
+
+// readIssuerValues reads the issuer credentials given by
+// --identity-issuer-certificate-file and --identity-issuer-key-file, replacing
+// the existing ones. They must be issued for the identity of the control plane
+// in the given namespace and signed by the trust anchors, which are left
+// unchanged.
+func (options *upgradeOptions) readIssuerValues(idctx *pb.IdentityContext, namespace string) (*installIdentityValues, error) {
+	idopts := options.identityOptions
+	if idopts.crtPEMFile == "" || idopts.keyPEMFile == "" {
+		return nil, errors.New("--identity-issuer-certificate-file and --identity-issuer-key-file must be set together")
+	}
+
+	creds, err := tls.ReadPEMCreds(idopts.keyPEMFile, idopts.crtPEMFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := tls.DecodePEMCertPool(idctx.GetTrustAnchorsPem())
+	if err != nil {
+		return nil, err
+	}
+
+	issuerName := fmt.Sprintf("identity.%s.%s", namespace, idctx.GetTrustDomain())
+	if err := creds.Verify(roots, issuerName); err != nil {
+		return nil, fmt.Errorf("the certificate must be valid for %s and signed by the control plane's trust anchors: %s", issuerName, err)
+	}
+
+	return identityValuesWithIssuer(options.controllerReplicas, idctx, creds.EncodePrivateKeyPEM(), creds.EncodeCertificatePEM(), creds.Crt.Certificate.NotAfter), nil
+}
+
+func identityValuesWithIssuer(replicas uint, idctx *pb.IdentityContext, keyPEM, crtPEM string, expiry time.Time) *installIdentityValues {
 	return &installIdentityValues{
 		Replicas:        replicas,
 		TrustDomain:     idctx.GetTrustDomain(),
@@ -214,7 +895,7 @@ func fetchIdentityValues(k kubernetes.Interface, replicas uint, idctx *pb.Identi
 			CrtPEM:    crtPEM,
 			CrtExpiry: expiry,
 		},
-	}, nil
+	}
 }
 
 func fetchIssuer(k kubernetes.Interface, trustPEM string) (string, string, time.Time, error) {
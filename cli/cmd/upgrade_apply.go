@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// prunableResources are the resources deleted by an upgrade applied with
+// --apply when they're labeled as a component of the control plane, but aren't
+// rendered anymore. Cluster-wide resources must also be labeled with the
+// control plane's namespace.
+var prunableResources = []struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}{
+	{schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true},
+	{schema.GroupVersionResource{Version: "v1", Resource: "services"}, true},
+	{schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true},
+	{schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, true},
+	{schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts"}, true},
+	{schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets"}, true},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}, false},
+	{schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"}, false},
+}
+
+// upgradeApplySummary counts the resources of an upgrade by the change applied
+// to them.
+type upgradeApplySummary struct {
+	created, configured, unchanged, pruned int
+}
+
+func (s upgradeApplySummary) String() string {
+	return fmt.Sprintf("%d created, %d configured, %d unchanged, %d pruned", s.created, s.configured, s.unchanged, s.pruned)
+}
+
+// applyUpgrade creates or updates each resource of the rendered upgrade in the
+// cluster, and then prunes the resources of the control plane that aren't
+// rendered anymore. Resources are updated the way `kubectl apply` does, so that
+// both can be used on the same control plane.
+func applyUpgrade(w io.Writer, rendered []byte, client dynamic.Interface, stage string) (upgradeApplySummary, error) {
+	summary := upgradeApplySummary{}
+
+	err := forEachManifest(bytes.NewReader(rendered), func(obj *unstructured.Unstructured) error {
+		resource, header := resourceClient(client, obj)
+
+		applied, err := json.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[lastAppliedAnnotation] = string(applied)
+		obj.SetAnnotations(annotations)
+
+		live, err := resource.Get(obj.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			if _, err := resource.Create(obj, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create %s: %s", header, err)
+			}
+			summary.created++
+			fmt.Fprintf(w, "%s created\n", header)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %s", header, err)
+		}
+
+		updated, err := mergeApplied(live, obj)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s: %s", header, err)
+		}
+		if updated == nil {
+			summary.unchanged++
+			fmt.Fprintf(w, "%s unchanged\n", header)
+			return nil
+		}
+
+		if _, err := resource.Update(updated, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update %s: %s", header, err)
+		}
+		summary.configured++
+		fmt.Fprintf(w, "%s configured\n", header)
+		return nil
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	summary.pruned, err = pruneUpgrade(w, rendered, client, stage, false)
+	return summary, err
+}
+
+// mergeApplied returns the live resource with the changes from the manifest
+// last applied to the applied one merged into it, or nil if there are none.
+// Fields set by Kubernetes or other controllers are kept, while fields removed
+// from the manifest are removed from the resource. Containers and the other
+// keyed lists of the built-in resources are merged by key.
+func mergeApplied(live, applied *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	original := []byte(live.GetAnnotations()[lastAppliedAnnotation])
+	if len(original) == 0 {
+		original = []byte("{}")
+	}
+	modified, err := json.Marshal(applied.Object)
+	if err != nil {
+		return nil, err
+	}
+	current, err := json.Marshal(live.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := threeWayMergePatch(applied.GroupVersionKind(), original, modified, current)
+	if err != nil {
+		return nil, err
+	}
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+
+	merged, err := applyMergePatch(applied.GroupVersionKind(), current, patch)
+	if err != nil {
+		return nil, err
+	}
+	updated := &unstructured.Unstructured{}
+	if err := updated.UnmarshalJSON(merged); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// pruneUpgrade deletes the resources of the control plane that aren't in the
+// rendered upgrade, or only lists them if dryRun is set, and returns how many
+// there are. The configuration stage only holds cluster-wide resources and the
+// control plane stage only namespaced ones, so only those are pruned with a
+// stage.
+func pruneUpgrade(w io.Writer, rendered []byte, client dynamic.Interface, stage string, dryRun bool) (int, error) {
+	keep := map[string]bool{}
+	err := forEachManifest(bytes.NewReader(rendered), func(obj *unstructured.Unstructured) error {
+		keep[pruneKey(obj)] = true
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, r := range prunableResources {
+		if (stage == configStage && r.namespaced) || (stage == controlPlaneStage && !r.namespaced) {
+			continue
+		}
+
+		var resource dynamic.ResourceInterface
+		var selector string
+		if r.namespaced {
+			resource = client.Resource(r.gvr).Namespace(controlPlaneNamespace)
+			selector = k8s.ControllerComponentLabel
+		} else {
+			resource = client.Resource(r.gvr)
+			selector = fmt.Sprintf("%s,%s=%s", k8s.ControllerComponentLabel, k8s.ControllerNSLabel, controlPlaneNamespace)
+		}
+
+		list, err := resource.List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return pruned, fmt.Errorf("failed to list %s: %s", r.gvr.Resource, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if keep[pruneKey(obj)] {
+				continue
+			}
+
+			_, header := resourceClient(client, obj)
+			pruned++
+			if dryRun {
+				fmt.Fprintf(w, "%s\n", diffRemoved(diffHeader("- "+header+" (pruned)")))
+				continue
+			}
+			if err := resource.Delete(obj.GetName(), &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return pruned, fmt.Errorf("failed to prune %s: %s", header, err)
+			}
+			fmt.Fprintf(w, "%s pruned\n", header)
+		}
+	}
+
+	return pruned, nil
+}
+
+// pruneKey identifies a resource regardless of the version of its API.
+func pruneKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const upgradeApplyLive = `
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1","data":{"global":"old","install":"old"},"kind":"ConfigMap","metadata":{"labels":{"linkerd.io/control-plane-component":"controller"},"name":"linkerd-config","namespace":"linkerd"}}'
+    linkerd.io/set-by-someone-else: "true"
+data:
+  global: old
+  install: old
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: web
+spec:
+  clusterIP: 10.0.0.1
+  ports:
+  - name: http
+    port: 8084
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-old
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: old
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: emojivoto
+  namespace: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-old
+  labels:
+    linkerd.io/control-plane-component: old
+    linkerd.io/control-plane-ns: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-other-old
+  labels:
+    linkerd.io/control-plane-component: old
+    linkerd.io/control-plane-ns: other
+`
+
+const upgradeApplyRendered = `
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+data:
+  global: new
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: web
+spec:
+  ports:
+  - name: http
+    port: 8084
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-web
+  labels:
+    linkerd.io/control-plane-component: web
+    linkerd.io/control-plane-ns: linkerd
+`
+
+func newUpgradeApplyClient(t *testing.T) dynamic.Interface {
+	objs := []runtime.Object{}
+	err := forEachManifest(strings.NewReader(upgradeApplyLive), func(obj *unstructured.Unstructured) error {
+		objs = append(objs, obj)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...)
+}
+
+func TestApplyUpgrade(t *testing.T) {
+	client := newUpgradeApplyClient(t)
+
+	var buf bytes.Buffer
+	summary, err := applyUpgrade(&buf, []byte(upgradeApplyRendered), client, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedOutput := `ConfigMap linkerd/linkerd-config configured
+Service linkerd/linkerd-web configured
+ClusterRole linkerd-linkerd-web created
+Deployment linkerd/linkerd-old pruned
+ClusterRole linkerd-linkerd-old pruned
+`
+	if buf.String() != expectedOutput {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", expectedOutput, buf.String())
+	}
+	if expected := (upgradeApplySummary{created: 1, configured: 2, pruned: 2}); summary != expected {
+		t.Errorf("Expected summary %s, got %s", expected, summary)
+	}
+
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	config, err := client.Resource(configMaps).Namespace("linkerd").Get("linkerd-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	data, _, _ := unstructured.NestedStringMap(config.Object, "data")
+	if len(data) != 1 || data["global"] != "new" {
+		t.Errorf("Expected the config's data to be replaced by the applied one, got %v", data)
+	}
+	if _, ok := config.GetAnnotations()["linkerd.io/set-by-someone-else"]; !ok {
+		t.Errorf("Expected the annotations not set by the upgrade to be kept, got %v", config.GetAnnotations())
+	}
+
+	services := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	service, err := client.Resource(services).Namespace("linkerd").Get("linkerd-web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ip, _, _ := unstructured.NestedString(service.Object, "spec", "clusterIP"); ip != "10.0.0.1" {
+		t.Errorf("Expected the service's cluster IP to be kept, got %q", ip)
+	}
+
+	// Applying the same upgrade again doesn't change anything.
+	buf.Reset()
+	summary, err = applyUpgrade(&buf, []byte(upgradeApplyRendered), client, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := (upgradeApplySummary{unchanged: 3}); summary != expected {
+		t.Errorf("Expected summary %s, got %s", expected, summary)
+	}
+}
+
+func TestPruneUpgrade(t *testing.T) {
+	testCases := []struct {
+		stage          string
+		expectedOutput string
+	}{
+		{
+			"",
+			`- Deployment linkerd/linkerd-old (pruned)
+- ClusterRole linkerd-linkerd-old (pruned)
+`,
+		},
+		{
+			configStage,
+			`- ClusterRole linkerd-linkerd-old (pruned)
+`,
+		},
+		{
+			controlPlaneStage,
+			`- Deployment linkerd/linkerd-old (pruned)
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.stage, func(t *testing.T) {
+			client := newUpgradeApplyClient(t)
+
+			var buf bytes.Buffer
+			pruned, err := pruneUpgrade(&buf, []byte(upgradeApplyRendered), client, tc.stage, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if buf.String() != tc.expectedOutput {
+				t.Errorf("Expected output:\n%s\ngot:\n%s", tc.expectedOutput, buf.String())
+			}
+			if pruned != strings.Count(tc.expectedOutput, "\n") {
+				t.Errorf("Expected %d resources to prune, got %d", strings.Count(tc.expectedOutput, "\n"), pruned)
+			}
+
+			// Nothing is deleted in a dry run.
+			deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+			if _, err := client.Resource(deployments).Namespace("linkerd").Get("linkerd-old", metav1.GetOptions{}); err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/scrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	backupConfigFile = "linkerd-config.yaml"
+	backupIssuerFile = "linkerd-identity-issuer.yaml.enc"
+
+	// The size of the salt the AES-256 key of a backup is derived from its
+	// secret with, and the scrypt parameters recommended for interactive use.
+	backupSaltSize = 16
+	backupScryptN  = 1 << 15
+	backupScryptR  = 8
+	backupScryptP  = 1
+	backupKeySize  = 32
+)
+
+func newCmdUpgradeRestore(options *upgradeOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [flags] BACKUP-DIR",
+		Short: "Output the Kubernetes configs backed up by upgrade --backup-dir",
+		Long: `Output the Kubernetes configs backed up by upgrade --backup-dir.
+
+The linkerd-config ConfigMap and the identity issuer Secret saved in the backup
+directory before an upgrade are output, the Secret being decrypted with the key
+given by --backup-key-file. Applying them restores the configuration and the
+issuer credentials of the control plane, after which "linkerd upgrade" renders
+it again from them.`,
+		Example: `  linkerd upgrade restore --backup-key-file backup.key backups/20191015T010203Z | kubectl apply -f -`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := readBackupKey(options.backupKeyFile)
+			if err != nil {
+				upgradeErrorf("Failed to read the backup key: %s", err)
+			}
+
+			if err = restoreBackup(os.Stdout, args[0], key); err != nil {
+				upgradeErrorf("Failed to restore the backup: %s", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// readBackupKey returns the secret held by the file given by
+// --backup-key-file, which the key of each backup is derived from.
+func readBackupKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("--backup-key-file must be set to encrypt and decrypt the identity issuer's private key")
+	}
+
+	secret, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secret = bytes.TrimSpace(secret)
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
+
+// backupControlPlane writes the linkerd-config ConfigMap and the identity
+// issuer Secret, encrypted with key, to a new directory in dir named after the
+// current time, and returns its path.
+func backupControlPlane(k kubernetes.Interface, dir string, key []byte, now time.Time) (string, error) {
+	configMap, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	configMap.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+	configMap.ObjectMeta = backupObjectMeta(configMap.ObjectMeta)
+
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	secret.ObjectMeta = backupObjectMeta(secret.ObjectMeta)
+
+	configYAML, err := yaml.Marshal(configMap)
+	if err != nil {
+		return "", err
+	}
+	secretYAML, err := yaml.Marshal(secret)
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := encryptBackup(key, secretYAML)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, now.UTC().Format("20060102T150405Z"))
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, backupConfigFile), configYAML, 0600); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, backupIssuerFile), encrypted, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// restoreBackup writes the objects saved by backupControlPlane in dir.
+func restoreBackup(w io.Writer, dir string, key []byte) error {
+	configYAML, err := ioutil.ReadFile(filepath.Join(dir, backupConfigFile))
+	if err != nil {
+		return err
+	}
+	encrypted, err := ioutil.ReadFile(filepath.Join(dir, backupIssuerFile))
+	if err != nil {
+		return err
+	}
+	secretYAML, err := decryptBackup(key, encrypted)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %s", backupIssuerFile, err)
+	}
+
+	// Check that the backup holds what it's expected to, before it's applied.
+	var configMap corev1.ConfigMap
+	if err := yaml.Unmarshal(configYAML, &configMap); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", backupConfigFile, err)
+	}
+	if configMap.Kind != "ConfigMap" || configMap.Name != k8s.ConfigConfigMapName {
+		return fmt.Errorf("%s doesn't hold the %s ConfigMap", backupConfigFile, k8s.ConfigConfigMapName)
+	}
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(secretYAML, &secret); err != nil {
+		return fmt.Errorf("failed to parse %s: %s", backupIssuerFile, err)
+	}
+	if secret.Kind != "Secret" || secret.Name != k8s.IdentityIssuerSecretName {
+		return fmt.Errorf("%s doesn't hold the %s Secret", backupIssuerFile, k8s.IdentityIssuerSecretName)
+	}
+
+	_, err = fmt.Fprintf(w, "---\n%s---\n%s", configYAML, secretYAML)
+	return err
+}
+
+// backupObjectMeta drops the fields set by the API server, so that the backed
+// up objects can be applied again.
+func backupObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// encryptBackup encrypts data with AES-GCM, with a key derived from secret
+// and a random salt with scrypt, prefixing it with the salt and the nonce.
+func encryptBackup(secret, data []byte) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newBackupCipher(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(append(salt, nonce...), nonce, data, nil), nil
+}
+
+func decryptBackup(secret, data []byte) ([]byte, error) {
+	if len(data) < backupSaltSize {
+		return nil, errors.New("the data is truncated")
+	}
+	salt, data := data[:backupSaltSize], data[backupSaltSize:]
+	gcm, err := newBackupCipher(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("the data is truncated")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("wrong key or corrupted data")
+	}
+	return plaintext, nil
+}
+
+func newBackupCipher(secret, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(secret, salt, backupScryptN, backupScryptR, backupScryptP, backupKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
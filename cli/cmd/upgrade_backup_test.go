@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestBackupControlPlane(t *testing.T) {
+	clientset, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+  resourceVersion: "42"
+  uid: 6c5d9a4e-0f43-4fd5-8c36-5d5a3d7d0bc3
+data:
+  global: |
+    {"linkerdNamespace":"linkerd"}`, `
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  crt.pem: Y3J0
+  key.pem: a2V5`)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "linkerd-backup")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "backup.key")
+	if err := ioutil.WriteFile(keyFile, []byte("s3cr3t\n"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	key, err := readBackupKey(keyFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	now := time.Date(2019, 10, 15, 1, 2, 3, 0, time.UTC)
+	path, err := backupControlPlane(clientset, dir, key, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if path != filepath.Join(dir, "20191015T010203Z") {
+		t.Fatalf("Unexpected backup path %s", path)
+	}
+
+	encrypted, err := ioutil.ReadFile(filepath.Join(path, backupIssuerFile))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if bytes.Contains(encrypted, []byte("a2V5")) {
+		t.Fatalf("Expected the issuer's private key to be encrypted")
+	}
+
+	var buf bytes.Buffer
+	if err := restoreBackup(&buf, path, key); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := `---
+apiVersion: v1
+data:
+  global: '{"linkerdNamespace":"linkerd"}'
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  name: linkerd-config
+  namespace: linkerd
+---
+apiVersion: v1
+data:
+  crt.pem: Y3J0
+  key.pem: a2V5
+kind: Secret
+metadata:
+  creationTimestamp: null
+  name: linkerd-identity-issuer
+  namespace: linkerd
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	otherKey, err := readBackupKey(filepath.Join("testdata", "crt.pem"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	err = restoreBackup(&buf, path, otherKey)
+	if err == nil || !strings.Contains(err.Error(), "wrong key") {
+		t.Fatalf("Expected a wrong key error, got %v", err)
+	}
+}
+
+func TestReadBackupKey(t *testing.T) {
+	if _, err := readBackupKey(""); err == nil {
+		t.Fatalf("Expected an error without a key file")
+	}
+}
+
+func TestEncryptBackupSaltsTheKey(t *testing.T) {
+	secret := []byte("s3cr3t")
+	first, err := encryptBackup(secret, []byte("data"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	second, err := encryptBackup(secret, []byte("data"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if bytes.Equal(first[:backupSaltSize], second[:backupSaltSize]) {
+		t.Fatalf("Expected each backup to be encrypted with a key of its own salt")
+	}
+
+	for _, encrypted := range [][]byte{first, second} {
+		data, err := decryptBackup(secret, encrypted)
+		if err != nil || string(data) != "data" {
+			t.Fatalf("Expected the data to be decrypted, got %q (%v)", data, err)
+		}
+	}
+}
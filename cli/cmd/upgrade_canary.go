@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const canaryNamespaceSuffix = "-canary"
+
+func newCmdUpgradePromote(options *upgradeOptions) *cobra.Command {
+	wait := 300 * time.Second
+
+	cmd := &cobra.Command{
+		Use:   "promote [flags]",
+		Short: "Switch the meshed workloads over to the canary control plane and retire the current one",
+		Long: `Switch the meshed workloads over to the canary control plane and retire the current one.
+
+The namespaces meshed by the current control plane are labeled for the canary
+rendered by "linkerd upgrade --canary", so that its proxy injector handles
+them, and their workloads are restarted so that their proxies use the canary's
+destination and identity services. Once no proxy of the current control plane
+remains, its webhooks and the resources labeled as its components are deleted;
+its namespace is left to delete with kubectl.
+
+Workloads injected with "linkerd inject" keep the addresses of the current
+control plane and must be injected again with --linkerd-namespace set to the
+canary's namespace first. The canary keeps running in its own namespace after
+the promotion, so subsequent commands need --linkerd-namespace too.`,
+		Example: `  # Promote the canary in the linkerd-canary namespace.
+  linkerd upgrade --canary | kubectl apply -f -
+  linkerd check --linkerd-namespace linkerd-canary
+  linkerd upgrade promote
+
+  # Promote without asking for confirmation.
+  linkerd upgrade promote --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.setCanaryNamespace()
+
+			config, err := k8s.GetConfig(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+			client, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+			live, err := dynamic.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			return promoteCanary(client, live, controlPlaneNamespace, options.canaryNamespace, &meshOptions{yes: options.yes, wait: wait}, os.Stdin, stdout)
+		},
+	}
+
+	cmd.Flags().DurationVar(&wait, "wait", wait, "Maximum allowed time for the proxies of the current control plane to be replaced")
+
+	return cmd
+}
+
+// setCanaryNamespace defaults the namespace of the canary to the one of the
+// control plane, suffixed with "-canary".
+func (options *upgradeOptions) setCanaryNamespace() {
+	if options.canaryNamespace == "" {
+		options.canaryNamespace = controlPlaneNamespace + canaryNamespaceSuffix
+	}
+}
+
+// promoteCanary moves the namespaces meshed by the control plane in
+// controllerNS over to the canary in canaryNS, restarting their workloads, and
+// then deletes the control plane.
+func promoteCanary(client kubernetes.Interface, live dynamic.Interface, controllerNS, canaryNS string, options *meshOptions, in io.Reader, out io.Writer) error {
+	scoped, err := checkProxyInjectorInstalled(client, canaryNS)
+	if err != nil || !scoped {
+		return fmt.Errorf("no canary control plane is running in the \"%s\" namespace; render it with \"linkerd upgrade --canary\"", canaryNS)
+	}
+
+	namespaces, err := meshedNamespaces(client, controllerNS, canaryNS)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		if err := setNamespaceControlPlane(client, namespace, canaryNS); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "namespace \"%s\" labeled with %s=%s\n", namespace, k8s.ControllerNSLabel, canaryNS)
+
+		workloads, injected, err := promotedWorkloads(client, namespace)
+		if err != nil {
+			return err
+		}
+		for _, workload := range injected {
+			fmt.Fprintf(stderr, "warning: %s was injected with 'linkerd inject'; inject it again with --linkerd-namespace %s\n", workload, canaryNS)
+		}
+
+		restarted, err := confirmAndRestart(client, namespace, workloads, options, in, out)
+		if err != nil {
+			return err
+		}
+		if !restarted && len(workloads) > 0 {
+			return fmt.Errorf("the workloads of namespace \"%s\" weren't restarted; the control plane in the \"%s\" namespace is kept until they are", namespace, controllerNS)
+		}
+	}
+
+	if err := waitForRetiredProxies(client, controllerNS, time.Now().Add(options.wait), 5*time.Second, out); err != nil {
+		return err
+	}
+
+	if !options.yes && !confirm(in, out, fmt.Sprintf("delete the control plane in the \"%s\" namespace?", controllerNS)) {
+		fmt.Fprintln(out, "skipped deleting the control plane")
+		return nil
+	}
+	return retireControlPlane(client, live, controllerNS, out)
+}
+
+// meshedNamespaces returns the namespaces handled by the proxy injector of the
+// control plane in controllerNS, or running pods meshed by it, besides the
+// namespaces of the control planes themselves.
+func meshedNamespaces(client kubernetes.Interface, controllerNS, canaryNS string) ([]string, error) {
+	selected := map[string]bool{}
+
+	pods, err := client.CoreV1().Pods(corev1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controllerNS),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		selected[pod.Namespace] = true
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces.Items {
+		label, labeled := ns.Labels[k8s.ControllerNSLabel]
+		if label == controllerNS || (!labeled && ns.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectEnabled) {
+			selected[ns.Name] = true
+		}
+	}
+
+	names := []string{}
+	for name := range selected {
+		if name != controllerNS && name != canaryNS {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// promotedWorkloads returns the workloads of the namespace whose pods the proxy
+// injector adds the proxy to once restarted, and the workloads that carry the
+// proxy in their template.
+func promotedWorkloads(client kubernetes.Interface, namespace string) ([]meshWorkload, []meshWorkload, error) {
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	enabled := ns.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectEnabled
+
+	templates, err := workloadTemplates(client, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workloads := []meshWorkload{}
+	injected := []meshWorkload{}
+	for workload, template := range templates {
+		annotation := template.Annotations[k8s.ProxyInjectAnnotation]
+		switch {
+		case hasProxyContainer(template.Spec):
+			injected = append(injected, workload)
+		case annotation == k8s.ProxyInjectEnabled || (enabled && annotation != k8s.ProxyInjectDisabled):
+			workloads = append(workloads, workload)
+		}
+	}
+	sortWorkloads(workloads)
+	sortWorkloads(injected)
+	return workloads, injected, nil
+}
+
+// waitForRetiredProxies polls the pods meshed by the control plane in
+// controllerNS, outside of its own namespace, until none of them remains or
+// until the deadline passes.
+func waitForRetiredProxies(client kubernetes.Interface, controllerNS string, deadline time.Time, interval time.Duration, out io.Writer) error {
+	for {
+		pods, err := client.CoreV1().Pods(corev1.NamespaceAll).List(metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controllerNS),
+		})
+		if err != nil {
+			return err
+		}
+
+		remaining := []string{}
+		for _, pod := range pods.Items {
+			if pod.Namespace != controllerNS && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+				remaining = append(remaining, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			}
+		}
+		if len(remaining) == 0 {
+			fmt.Fprintf(out, "no proxies of the control plane in the \"%s\" namespace remain\n", controllerNS)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			sort.Strings(remaining)
+			return fmt.Errorf("pods still use the control plane in the \"%s\" namespace: %s", controllerNS, strings.Join(remaining, ", "))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// retireControlPlane deletes the webhook configurations of the control plane
+// in controllerNS, and the resources labeled as its components.
+func retireControlPlane(client kubernetes.Interface, live dynamic.Interface, controllerNS string, out io.Writer) error {
+	mutating := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+	for _, name := range []string{k8s.ProxyInjectorWebhookConfigName, k8s.ScopedWebhookConfigName(controllerNS, k8s.ProxyInjectorWebhookConfigName)} {
+		config, err := mutating.Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(config.Webhooks) == 0 || config.Webhooks[0].ClientConfig.Service == nil || config.Webhooks[0].ClientConfig.Service.Namespace != controllerNS {
+			continue
+		}
+		if err := mutating.Delete(name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(out, "mutatingwebhookconfiguration \"%s\" deleted\n", name)
+	}
+
+	validating := client.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations()
+	for _, name := range []string{k8s.SPValidatorWebhookConfigName, k8s.ScopedWebhookConfigName(controllerNS, k8s.SPValidatorWebhookConfigName)} {
+		config, err := validating.Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(config.Webhooks) == 0 || config.Webhooks[0].ClientConfig.Service == nil || config.Webhooks[0].ClientConfig.Service.Namespace != controllerNS {
+			continue
+		}
+		if err := validating.Delete(name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(out, "validatingwebhookconfiguration \"%s\" deleted\n", name)
+	}
+
+	// pruneUpgrade prunes the control plane given by --linkerd-namespace,
+	// which is controllerNS; as nothing is rendered, all of it is pruned.
+	if _, err := pruneUpgrade(out, nil, live, "", false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "the control plane in the \"%s\" namespace is retired; delete the namespace with \"kubectl delete namespace %s\"\n", controllerNS, controllerNS)
+	return nil
+}
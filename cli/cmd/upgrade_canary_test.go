@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func canaryTestWebhook(name, namespace string) *arv1beta1.MutatingWebhookConfiguration {
+	return &arv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Webhooks: []arv1beta1.Webhook{{
+			Name: "linkerd-proxy-injector.linkerd.io",
+			ClientConfig: arv1beta1.WebhookClientConfig{
+				Service: &arv1beta1.ServiceReference{Name: k8s.ProxyInjectorWebhookServiceName, Namespace: namespace},
+			},
+		}},
+	}
+}
+
+func canaryTestNamespace(name string, labels, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+}
+
+func canaryTestPod(namespace, name, controllerNS string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{k8s.ControllerNSLabel: controllerNS},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestMeshedNamespaces(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		canaryTestNamespace("linkerd", nil, nil),
+		canaryTestNamespace("linkerd-canary", map[string]string{k8s.ControllerNSLabel: "linkerd-canary"}, nil),
+		canaryTestNamespace("emojivoto", nil, map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}),
+		canaryTestNamespace("books", map[string]string{k8s.ControllerNSLabel: "linkerd"}, nil),
+		canaryTestNamespace("manual", nil, nil),
+		canaryTestNamespace("other", map[string]string{k8s.ControllerNSLabel: "linkerd-b"}, map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}),
+		canaryTestNamespace("unmeshed", nil, nil),
+		canaryTestPod("linkerd", "linkerd-controller", "linkerd"),
+		canaryTestPod("manual", "web", "linkerd"),
+		canaryTestPod("other", "web", "linkerd-b"),
+	)
+
+	namespaces, err := meshedNamespaces(client, "linkerd", "linkerd-canary")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []string{"books", "emojivoto", "manual"}
+	if !reflect.DeepEqual(namespaces, expected) {
+		t.Fatalf("Expected %v, got %v", expected, namespaces)
+	}
+}
+
+func TestPromotedWorkloads(t *testing.T) {
+	client := newMeshTestClient(
+		meshTestDeployment("web", nil, "web"),
+		meshTestDeployment("voting", map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}, "voting"),
+		meshTestDeployment("emoji", nil, "emoji", k8s.ProxyContainerName),
+	)
+
+	workloads, injected, err := promotedWorkloads(client, "emojivoto")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := []meshWorkload{{k8s.Deployment, "voting"}}; !reflect.DeepEqual(workloads, expected) {
+		t.Errorf("Expected workloads %v, got %v", expected, workloads)
+	}
+	if expected := []meshWorkload{{k8s.Deployment, "emoji"}}; !reflect.DeepEqual(injected, expected) {
+		t.Errorf("Expected injected workloads %v, got %v", expected, injected)
+	}
+}
+
+func TestPromoteCanary(t *testing.T) {
+	objects := func(extra ...runtime.Object) []runtime.Object {
+		return append([]runtime.Object{
+			canaryTestWebhook(k8s.ProxyInjectorWebhookConfigName, "linkerd"),
+			canaryTestWebhook(k8s.ScopedWebhookConfigName("linkerd-canary", k8s.ProxyInjectorWebhookConfigName), "linkerd-canary"),
+			canaryTestNamespace("emojivoto", nil, map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}),
+			meshTestDeployment("web", nil, "web"),
+		}, extra...)
+	}
+
+	t.Run("requires a canary", func(t *testing.T) {
+		client := fake.NewSimpleClientset(canaryTestWebhook(k8s.ProxyInjectorWebhookConfigName, "linkerd"))
+		err := promoteCanary(client, newUpgradeApplyClient(t), "linkerd", "linkerd-canary", &meshOptions{yes: true}, strings.NewReader(""), &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "no canary control plane is running") {
+			t.Fatalf("Expected an error for a missing canary, got %v", err)
+		}
+	})
+
+	t.Run("keeps the control plane while its proxies remain", func(t *testing.T) {
+		client := fake.NewSimpleClientset(objects(canaryTestPod("emojivoto", "web-1", "linkerd"))...)
+		err := promoteCanary(client, newUpgradeApplyClient(t), "linkerd", "linkerd-canary", &meshOptions{yes: true}, strings.NewReader(""), &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "emojivoto/web-1") {
+			t.Fatalf("Expected an error for the remaining proxy, got %v", err)
+		}
+		if _, err := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Get(k8s.ProxyInjectorWebhookConfigName, metav1.GetOptions{}); err != nil {
+			t.Errorf("Expected the webhook of the control plane to be kept, got %s", err)
+		}
+	})
+
+	t.Run("retires the control plane", func(t *testing.T) {
+		client := fake.NewSimpleClientset(objects(canaryTestPod("linkerd", "linkerd-controller", "linkerd"))...)
+		live := newUpgradeApplyClient(t)
+
+		var buf bytes.Buffer
+		err := promoteCanary(client, live, "linkerd", "linkerd-canary", &meshOptions{yes: true, wait: time.Second}, strings.NewReader(""), &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, expected := range []string{
+			"namespace \"emojivoto\" labeled with linkerd.io/control-plane-ns=linkerd-canary\n",
+			"deployment/web restarted\n",
+			"mutatingwebhookconfiguration \"linkerd-proxy-injector-webhook-config\" deleted\n",
+			"Deployment linkerd/linkerd-old pruned\n",
+		} {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", expected, buf.String())
+			}
+		}
+
+		ns, err := client.CoreV1().Namespaces().Get("emojivoto", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if ns.Labels[k8s.ControllerNSLabel] != "linkerd-canary" {
+			t.Errorf("Expected the namespace to be labeled for the canary, got %v", ns.Labels)
+		}
+		if _, err := client.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Get(k8s.ScopedWebhookConfigName("linkerd-canary", k8s.ProxyInjectorWebhookConfigName), metav1.GetOptions{}); err != nil {
+			t.Errorf("Expected the webhook of the canary to be kept, got %s", err)
+		}
+		deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		if _, err := live.Resource(deployments).Namespace("linkerd").Get("linkerd-old", metav1.GetOptions{}); !kerrors.IsNotFound(err) {
+			t.Errorf("Expected the deployments of the control plane to be deleted, got %v", err)
+		}
+	})
+}
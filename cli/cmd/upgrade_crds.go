@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const serviceProfileCRDName = "serviceprofiles.linkerd.io"
+
+var (
+	// serviceProfileVersions are the versions of ServiceProfiles served by the
+	// CRD of the chart.
+	serviceProfileVersions = []string{"v1alpha1"}
+
+	// crdResources are the versions of the CRD API the CRDs of the control
+	// plane may have been created with, the newest first.
+	crdResources = []schema.GroupVersionResource{
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Resource: "customresourcedefinitions"},
+	}
+)
+
+// existingCRD is what an upgrade needs to know of a CRD in the cluster.
+type existingCRD struct {
+	storedVersions        []string
+	preserveUnknownFields bool
+}
+
+// checkUpgradeCRDs compares the CRDs rendered by the upgrade with the ones in
+// the cluster. An error is returned when the upgrade would stop serving a
+// version objects are stored with, as the API server would reject the CRD.
+// When a CRD created with apiextensions.k8s.io/v1beta1 is rendered with
+// apiextensions.k8s.io/v1, which doesn't allow preserving unknown fields, the
+// upgrade resets the field. The stored ServiceProfiles the new schema doesn't
+// validate are written to w, as they can't be updated without being fixed.
+func checkUpgradeCRDs(w io.Writer, live dynamic.Interface, values *installValues) error {
+	crd, err := fetchCRD(live, serviceProfileCRDName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the %s CRD: %s", serviceProfileCRDName, err)
+	}
+	if crd == nil {
+		return nil
+	}
+
+	dropped := []string{}
+	for _, stored := range crd.storedVersions {
+		if !containsString(serviceProfileVersions, stored) {
+			dropped = append(dropped, stored)
+		}
+	}
+	if len(dropped) > 0 {
+		return fmt.Errorf("ServiceProfiles are stored as %s, which this version doesn't serve (it serves %s); upgrade to a version serving them",
+			strings.Join(dropped, ", "), strings.Join(serviceProfileVersions, ", "))
+	}
+
+	if crd.preserveUnknownFields && values.CRDAPIVersion == "apiextensions.k8s.io/v1" {
+		values.ResetCRDPreserveUnknownFields = true
+		fmt.Fprintf(w, "%s The %s CRD is converted to %s: the fields of ServiceProfiles its schema doesn't define are dropped when they're next updated\n", warnStatus, serviceProfileCRDName, values.CRDAPIVersion)
+	}
+
+	invalid, err := invalidServiceProfiles(live)
+	if err != nil {
+		return fmt.Errorf("failed to validate the existing ServiceProfiles: %s", err)
+	}
+	for _, msg := range invalid {
+		fmt.Fprintf(w, "%s %s; it must be fixed before it can be updated\n", warnStatus, msg)
+	}
+	return nil
+}
+
+// fetchCRD returns the CRD of the given name, or nil if it doesn't exist.
+func fetchCRD(live dynamic.Interface, name string) (*existingCRD, error) {
+	for _, gvr := range crdResources {
+		obj, err := live.Resource(gvr).Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			// Either the CRD or this version of the API doesn't exist.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		storedVersions, _, err := unstructured.NestedStringSlice(obj.Object, "status", "storedVersions")
+		if err != nil {
+			return nil, err
+		}
+
+		// The field defaults to true in apiextensions.k8s.io/v1beta1.
+		preserveUnknownFields, found, err := unstructured.NestedBool(obj.Object, "spec", "preserveUnknownFields")
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			preserveUnknownFields = gvr.Version == "v1beta1"
+		}
+
+		return &existingCRD{storedVersions: storedVersions, preserveUnknownFields: preserveUnknownFields}, nil
+	}
+	return nil, nil
+}
+
+// invalidServiceProfiles returns why the stored ServiceProfiles are invalid,
+// for the ones that are.
+func invalidServiceProfiles(live dynamic.Interface) ([]string, error) {
+	invalid := []string{}
+	for _, version := range serviceProfileVersions {
+		gvr := schema.GroupVersionResource{Group: "linkerd.io", Version: version, Resource: "serviceprofiles"}
+		list, err := live.Resource(gvr).Namespace(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			data, err := json.Marshal(item.Object)
+			if err != nil {
+				return nil, err
+			}
+			if err := profiles.Validate(data); err != nil {
+				invalid = append(invalid, fmt.Sprintf("ServiceProfile %s/%s is invalid: %s", item.GetNamespace(), item.GetName(), err))
+			}
+		}
+	}
+	sort.Strings(invalid)
+	return invalid, nil
+}
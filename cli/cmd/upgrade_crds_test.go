@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	crdTestV1beta1CRD = `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: serviceprofiles.linkerd.io
+spec:
+  group: linkerd.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: serviceprofiles
+    singular: serviceprofile
+    kind: ServiceProfile
+status:
+  storedVersions:
+  - v1alpha1
+`
+
+	crdTestV1CRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: serviceprofiles.linkerd.io
+spec:
+  group: linkerd.io
+  scope: Namespaced
+  names:
+    plural: serviceprofiles
+    singular: serviceprofile
+    kind: ServiceProfile
+  versions:
+  - name: v1alpha2
+    served: true
+    storage: true
+status:
+  storedVersions:
+  - v1alpha1
+  - v1alpha2
+`
+
+	crdTestProfiles = `
+---
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: web.emojivoto.svc.cluster.local
+  namespace: emojivoto
+spec:
+  routes:
+  - name: GET /
+    condition:
+      method: GET
+---
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: voting.emojivoto.svc.cluster.local
+  namespace: emojivoto
+spec:
+  routes:
+  - name: POST /vote
+    condition:
+      method: POST
+    timeout: soon
+`
+)
+
+func TestCheckUpgradeCRDs(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		manifests     string
+		crdAPIVersion string
+		reset         bool
+		output        []string
+		err           string
+	}{
+		{
+			desc:          "no CRD",
+			manifests:     "",
+			crdAPIVersion: "apiextensions.k8s.io/v1",
+		},
+		{
+			desc:          "resets preserveUnknownFields when converting to apiextensions.k8s.io/v1",
+			manifests:     crdTestV1beta1CRD,
+			crdAPIVersion: "apiextensions.k8s.io/v1",
+			reset:         true,
+			output:        []string{"The serviceprofiles.linkerd.io CRD is converted to apiextensions.k8s.io/v1"},
+		},
+		{
+			desc:          "keeps an apiextensions.k8s.io/v1beta1 CRD",
+			manifests:     crdTestV1beta1CRD,
+			crdAPIVersion: "apiextensions.k8s.io/v1beta1",
+		},
+		{
+			desc:          "warns about invalid ServiceProfiles",
+			manifests:     crdTestV1beta1CRD + crdTestProfiles,
+			crdAPIVersion: "apiextensions.k8s.io/v1beta1",
+			output: []string{
+				"ServiceProfile emojivoto/voting.emojivoto.svc.cluster.local is invalid: ServiceProfile \"voting.emojivoto.svc.cluster.local\" has a route with an invalid timeout",
+			},
+		},
+		{
+			desc:          "refuses to stop serving stored versions",
+			manifests:     crdTestV1CRD,
+			crdAPIVersion: "apiextensions.k8s.io/v1",
+			err:           "ServiceProfiles are stored as v1alpha2, which this version doesn't serve (it serves v1alpha1); upgrade to a version serving them",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			objs := []runtime.Object{}
+			err := forEachManifest(strings.NewReader(tc.manifests), func(obj *unstructured.Unstructured) error {
+				objs = append(objs, obj)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			live := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...)
+
+			values := &installValues{CRDAPIVersion: tc.crdAPIVersion}
+			var out bytes.Buffer
+			err = checkUpgradeCRDs(&out, live, values)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error \"%s\", got \"%v\"", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if values.ResetCRDPreserveUnknownFields != tc.reset {
+				t.Errorf("Expected ResetCRDPreserveUnknownFields to be %t", tc.reset)
+			}
+			for _, expected := range tc.output {
+				if !strings.Contains(out.String(), expected) {
+					t.Errorf("Expected the output to contain \"%s\", got:\n%s", expected, out.String())
+				}
+			}
+			if len(tc.output) == 0 && out.Len() > 0 {
+				t.Errorf("Expected no output, got:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestServiceProfileCRDSchema(t *testing.T) {
+	options := testInstallOptions()
+	options.kubernetesVersion = "v1.16.0"
+	values, configs, err := options.validateAndBuild(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := values.render(&buf, configs); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var schema map[string]interface{}
+	err = forEachManifest(&buf, func(obj *unstructured.Unstructured) error {
+		if obj.GetKind() != "CustomResourceDefinition" {
+			return nil
+		}
+		versions, _, _ := unstructured.NestedSlice(obj.Object, "spec", "versions")
+		schema, _, _ = unstructured.NestedMap(versions[0].(map[string]interface{}), "schema", "openAPIV3Schema", "properties", "spec")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if schema == nil {
+		t.Fatalf("Expected the ServiceProfile CRD to have a schema")
+	}
+
+	// Fields missing from the schema would be pruned by the API server.
+	var check func(path string, typ reflect.Type, schema map[string]interface{})
+	check = func(path string, typ reflect.Type, schema map[string]interface{}) {
+		if preserve, _ := schema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+			return
+		}
+		switch typ.Kind() {
+		case reflect.Ptr:
+			check(path, typ.Elem(), schema)
+		case reflect.Slice:
+			items, _ := schema["items"].(map[string]interface{})
+			check(path+"[]", typ.Elem(), items)
+		case reflect.Struct:
+			properties, _ := schema["properties"].(map[string]interface{})
+			for i := 0; i < typ.NumField(); i++ {
+				name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+				property, ok := properties[name].(map[string]interface{})
+				if !ok {
+					t.Errorf("Expected the schema to have %s.%s", path, name)
+					continue
+				}
+				check(path+"."+name, typ.Field(i).Type, property)
+			}
+		}
+	}
+	check("spec", reflect.TypeOf(sp.ServiceProfileSpec{}), schema)
+}
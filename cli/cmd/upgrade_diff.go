@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// lastAppliedAnnotation holds the manifest last applied with `kubectl
+	// apply`, which is what an upgrade piped to `kubectl apply` is merged with.
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+	// diffContextLines is the number of unchanged lines shown around changes.
+	diffContextLines = 3
+)
+
+var (
+	diffAdded   = color.New(color.FgGreen).SprintFunc()
+	diffRemoved = color.New(color.FgRed).SprintFunc()
+	diffHeader  = color.New(color.Bold).SprintFunc()
+)
+
+// upgradeDiffSummary counts the resources of an upgrade by the change it makes
+// to them.
+type upgradeDiffSummary struct {
+	created, changed, unchanged int
+}
+
+func (s upgradeDiffSummary) String() string {
+	return fmt.Sprintf("%d to create, %d to change, %d unchanged", s.created, s.changed, s.unchanged)
+}
+
+// newFakeDynamicClientFromManifests serves the objects in the manifest file
+// given by --from-manifests as the live resources of an upgrade dry run.
+func (options *upgradeOptions) newFakeDynamicClientFromManifests() (dynamic.Interface, error) {
+	manifests, err := options.readFromManifests()
+	if err != nil {
+		return nil, err
+	}
+
+	objs := []runtime.Object{}
+	err = forEachManifest(bytes.NewReader(manifests), func(obj *unstructured.Unstructured) error {
+		objs = append(objs, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...), nil
+}
+
+// diffUpgrade prints, for each resource of the rendered upgrade, how it
+// differs from the resource in the cluster.
+func diffUpgrade(w io.Writer, rendered io.Reader, client dynamic.Interface) (upgradeDiffSummary, error) {
+	summary := upgradeDiffSummary{}
+
+	err := forEachManifest(rendered, func(obj *unstructured.Unstructured) error {
+		resource, header := resourceClient(client, obj)
+
+		live, err := resource.Get(obj.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			summary.created++
+			fmt.Fprintf(w, "%s\n", diffAdded(diffHeader("+ "+header+" (new)")))
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %s", header, err)
+		}
+
+		diff, err := diffObjects(liveObject(live, obj), obj.Object)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			summary.unchanged++
+			return nil
+		}
+
+		summary.changed++
+		fmt.Fprintf(w, "%s\n", diffHeader("~ "+header))
+		if obj.GetKind() == "Secret" {
+			// Secrets hold the identity issuer's private key, which mustn't be
+			// printed to the terminal.
+			fmt.Fprintln(w, "  (secret data changed, not shown)")
+			return nil
+		}
+		fmt.Fprint(w, diff)
+		return nil
+	})
+
+	return summary, err
+}
+
+// resourceClient returns the client of the resource of an object, and a header
+// naming the object in the output of an upgrade.
+func resourceClient(client dynamic.Interface, obj *unstructured.Unstructured) (dynamic.ResourceInterface, string) {
+	gvr, _ := meta.UnsafeGuessKindToResource(obj.GroupVersionKind())
+	if ns := obj.GetNamespace(); ns != "" {
+		return client.Resource(gvr).Namespace(ns), fmt.Sprintf("%s %s/%s", obj.GetKind(), ns, obj.GetName())
+	}
+	return client.Resource(gvr), fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+}
+
+// forEachManifest calls fn with each Kubernetes object of a multi-document
+// YAML manifest, skipping empty documents.
+func forEachManifest(r io.Reader, fn func(*unstructured.Unstructured) error) error {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(r, 4096))
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(bytes, &doc); err != nil {
+			return err
+		}
+		if len(doc) == 0 {
+			continue
+		}
+
+		if err := fn(&unstructured.Unstructured{Object: doc}); err != nil {
+			return err
+		}
+	}
+}
+
+// liveObject returns the content of a live resource to compare the rendered
+// object with. The manifest last applied by kubectl is used when available;
+// otherwise, fields absent from the rendered object are pruned from the live
+// resource, as they were defaulted or set by Kubernetes.
+func liveObject(live, rendered *unstructured.Unstructured) map[string]interface{} {
+	if applied, ok := live.GetAnnotations()[lastAppliedAnnotation]; ok {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(applied), &obj); err == nil {
+			return obj
+		}
+	}
+
+	obj := live.DeepCopy().Object
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"uid", "resourceVersion", "creationTimestamp", "generation", "selfLink"} {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, lastAppliedAnnotation)
+		}
+	}
+	return prune(obj, rendered.Object).(map[string]interface{})
+}
+
+// prune removes the fields of live that aren't in rendered, recursively.
+func prune(live, rendered interface{}) interface{} {
+	switch r := rendered.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := map[string]interface{}{}
+		for k, v := range l {
+			if rv, ok := r[k]; ok {
+				pruned[k] = prune(v, rv)
+			}
+		}
+		return pruned
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make([]interface{}, len(l))
+		for i, v := range l {
+			if i < len(r) {
+				pruned[i] = prune(v, r[i])
+			} else {
+				pruned[i] = v
+			}
+		}
+		return pruned
+	default:
+		return live
+	}
+}
+
+// diffObjects returns a line diff of the YAML of the two objects, or an empty
+// string if they're equal.
+func diffObjects(live, rendered map[string]interface{}) (string, error) {
+	liveYAML, err := yaml.Marshal(live)
+	if err != nil {
+		return "", err
+	}
+	renderedYAML, err := yaml.Marshal(rendered)
+	if err != nil {
+		return "", err
+	}
+	if string(liveYAML) == string(renderedYAML) {
+		return "", nil
+	}
+
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(string(liveYAML), string(renderedYAML))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	type line struct {
+		op   diffmatchpatch.Operation
+		text string
+	}
+	all := []line{}
+	for _, d := range diffs {
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				all = append(all, line{d.Type, strings.TrimSuffix(text, "\n")})
+			}
+		}
+	}
+
+	// Only show the unchanged lines close to a change.
+	show := make([]bool, len(all))
+	for i, l := range all {
+		if l.op == diffmatchpatch.DiffEqual {
+			continue
+		}
+		for j := i - diffContextLines; j <= i+diffContextLines; j++ {
+			if j >= 0 && j < len(all) {
+				show[j] = true
+			}
+		}
+	}
+
+	var out strings.Builder
+	skipped := false
+	for i, l := range all {
+		if !show[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			out.WriteString("  ...\n")
+			skipped = false
+		}
+		switch l.op {
+		case diffmatchpatch.DiffInsert:
+			out.WriteString(diffAdded("+ "+l.text) + "\n")
+		case diffmatchpatch.DiffDelete:
+			out.WriteString(diffRemoved("- "+l.text) + "\n")
+		default:
+			out.WriteString("  " + l.text + "\n")
+		}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestDiffUpgrade(t *testing.T) {
+	live := []string{`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+  uid: 8a5c1c1b-7b8a-4a4e-9f3b-0a8e4b6c2d1f
+  resourceVersion: "1234"
+data:
+  global: old`,
+		`
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+  uid: 5e1f7d7e-2a5b-4c1d-8e9f-3b2a1c0d4e5f
+secrets:
+- name: linkerd-web-token-abcde`,
+		`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  key.pem: b2xk`,
+	}
+
+	rendered := `
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: new
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+---
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  key.pem: bmV3
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-web
+`
+
+	objs := []runtime.Object{}
+	for _, config := range live {
+		err := forEachManifest(strings.NewReader(config), func(obj *unstructured.Unstructured) error {
+			objs = append(objs, obj)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...)
+
+	var buf bytes.Buffer
+	summary, err := diffUpgrade(&buf, strings.NewReader(rendered), client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedSummary := upgradeDiffSummary{created: 1, changed: 2, unchanged: 1}
+	if summary != expectedSummary {
+		t.Fatalf("Expected summary %+v, got %+v", expectedSummary, summary)
+	}
+
+	output := buf.String()
+	for _, expected := range []string{
+		"~ ConfigMap linkerd/linkerd-config\n",
+		"-   global: old\n",
+		"+   global: new\n",
+		"~ Secret linkerd/linkerd-identity-issuer\n",
+		"+ ClusterRole linkerd-linkerd-web (new)\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain [%s], got:\n%s", expected, output)
+		}
+	}
+	for _, unexpected := range []string{"ServiceAccount", "uid", "bmV3", "b2xk"} {
+		if strings.Contains(output, unexpected) {
+			t.Fatalf("Expected output not to contain [%s], got:\n%s", unexpected, output)
+		}
+	}
+}
+
+func TestDiffObjectsLastApplied(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "Service",
+		"metadata": map[string]interface{}{
+			"name": "linkerd-web",
+			"annotations": map[string]interface{}{
+				lastAppliedAnnotation: `{"kind":"Service","metadata":{"name":"linkerd-web"},"spec":{"type":"ClusterIP","ports":[{"port":8084}]}}`,
+			},
+		},
+		"spec": map[string]interface{}{"type": "ClusterIP", "clusterIP": "10.0.0.1"},
+	}}
+	rendered := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Service",
+		"metadata": map[string]interface{}{"name": "linkerd-web"},
+		"spec":     map[string]interface{}{"type": "ClusterIP"},
+	}}
+
+	diff, err := diffObjects(liveObject(live, rendered), rendered.Object)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.Contains(diff, "-   ports:\n") || strings.Contains(diff, "clusterIP") {
+		t.Fatalf("Expected the removed ports to be diffed against the last applied manifest, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+func newCmdUpgradeExport() *cobra.Command {
+	redactIssuerKey := false
+
+	cmd := &cobra.Command{
+		Use:   "export [flags]",
+		Short: "Output the configuration of the control plane, to upgrade it with --from-manifests",
+		Long: `Output the configuration of the control plane, to upgrade it with --from-manifests.
+
+The linkerd-config ConfigMap, which holds the flags recorded by the previous
+install or upgrade, the linkerd-config-history ConfigMap and the identity
+issuer Secret are output as a single YAML stream, so that the state of the
+control plane can be kept before a risky upgrade, or the control plane
+recreated on another cluster. The recorded flags are listed at the top.
+
+"linkerd upgrade --from-manifests" renders an upgrade from the output. With
+--redact-issuer-key, the private key of the issuer is left out, so that the
+output can be stored with less care; upgrading from it then requires
+--identity-issuer-certificate-file and --identity-issuer-key-file.`,
+		Example: `  linkerd upgrade export > linkerd-state.yaml
+
+  # Render the upgrade from the exported state, e.g. on another cluster.
+  linkerd upgrade --from-manifests linkerd-state.yaml | kubectl apply -f -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+
+			if err := exportControlPlane(k, os.Stdout, redactIssuerKey); err != nil {
+				upgradeErrorf("Failed to export the control plane: %s", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&redactIssuerKey, "redact-issuer-key", redactIssuerKey, "Leave the private key of the identity issuer out of the output")
+
+	return cmd
+}
+
+// exportControlPlane writes the objects an upgrade reads the configuration of
+// the control plane from, prefixed with the recorded install flags.
+func exportControlPlane(k kubernetes.Interface, w io.Writer, redactIssuerKey bool) error {
+	configMap, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	configs, err := config.FromConfigMap(configMap.Data)
+	if err != nil {
+		return fmt.Errorf("invalid %s ConfigMap: %s", k8s.ConfigConfigMapName, err)
+	}
+	configMap.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+	configMap.ObjectMeta = backupObjectMeta(configMap.ObjectMeta)
+	objs := []interface{}{configMap}
+
+	// The history isn't recorded by the versions before it was introduced.
+	history, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ConfigHistoryConfigMapName, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		history.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+		history.ObjectMeta = backupObjectMeta(history.ObjectMeta)
+		objs = append(objs, history)
+	}
+
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	secret.ObjectMeta = backupObjectMeta(secret.ObjectMeta)
+	if redactIssuerKey {
+		data := map[string][]byte{}
+		for name, value := range secret.Data {
+			if name != k8s.IdentityIssuerKeyName {
+				data[name] = value
+			}
+		}
+		secret.Data = data
+
+		// The manifest kubectl applied the Secret from holds the key too.
+		annotations := map[string]string{}
+		for name, value := range secret.Annotations {
+			if name != lastAppliedAnnotation {
+				annotations[name] = value
+			}
+		}
+		secret.Annotations = annotations
+	}
+	objs = append(objs, secret)
+
+	printExportHeader(w, configs, redactIssuerKey)
+	for _, obj := range objs {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printExportHeader(w io.Writer, configs *pb.All, redactIssuerKey bool) {
+	fmt.Fprintf(w, "# The configuration of the control plane in the \"%s\" namespace, version %s.\n",
+		controlPlaneNamespace, configs.GetInstall().GetCliVersion())
+
+	flags := configs.GetInstall().GetFlags()
+	if len(flags) == 0 {
+		fmt.Fprintln(w, "# No install flags are recorded.")
+	} else {
+		fmt.Fprintln(w, "# Recorded install flags:")
+		for _, f := range flags {
+			fmt.Fprintf(w, "#   --%s=%s\n", f.GetName(), f.GetValue())
+		}
+	}
+
+	if redactIssuerKey {
+		fmt.Fprintln(w, "# The private key of the identity issuer is redacted: upgrading from these")
+		fmt.Fprintln(w, "# manifests requires --identity-issuer-certificate-file and --identity-issuer-key-file.")
+	}
+}
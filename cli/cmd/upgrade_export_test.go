@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExportControlPlane(t *testing.T) {
+	controlPlaneNamespace = "linkerd"
+	clientset, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+  resourceVersion: "42"
+data:
+  global: |
+    {"linkerdNamespace":"linkerd"}
+  install: |
+    {"cliVersion":"stable-2.6.0","flags":[{"name":"ha","value":"true"}]}`, `
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"v1","data":{"crt.pem":"Y3J0","key.pem":"a2V5"},"kind":"Secret","metadata":{"annotations":{},"name":"linkerd-identity-issuer","namespace":"linkerd"}}
+data:
+  crt.pem: Y3J0
+  key.pem: a2V5`)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	testCases := []struct {
+		desc   string
+		redact bool
+		keys   []string
+	}{
+		{desc: "exports the issuer credentials", keys: []string{k8s.IdentityIssuerCrtName, k8s.IdentityIssuerKeyName}},
+		{desc: "redacts the issuer key", redact: true, keys: []string{k8s.IdentityIssuerCrtName}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := exportControlPlane(clientset, &buf, tc.redact); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			header := "# The configuration of the control plane in the \"linkerd\" namespace, version stable-2.6.0.\n# Recorded install flags:\n#   --ha=true\n"
+			if !strings.HasPrefix(buf.String(), header) {
+				t.Fatalf("Expected the output to start with the recorded flags, got:\n%s", buf.String())
+			}
+
+			if tc.redact && strings.Contains(buf.String(), "a2V5") {
+				t.Fatalf("Expected the issuer key to be redacted, got:\n%s", buf.String())
+			}
+
+			// The output must be readable by --from-manifests.
+			exported, _, _, err := k8s.NewFakeClientSetsFromManifests([]io.Reader{&buf})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			configs, err := fetchConfigs(exported)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if flags := configs.GetInstall().GetFlags(); len(flags) != 1 || flags[0].GetName() != "ha" {
+				t.Fatalf("Expected the recorded flags to be exported, got %v", flags)
+			}
+
+			secret, err := exported.CoreV1().Secrets("linkerd").Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(secret.Data) != len(tc.keys) {
+				t.Fatalf("Expected the secret to hold %v, got %v", tc.keys, secret.Data)
+			}
+			for _, key := range tc.keys {
+				if _, ok := secret.Data[key]; !ok {
+					t.Fatalf("Expected the secret to hold %s, got %v", key, secret.Data)
+				}
+			}
+		})
+	}
+}
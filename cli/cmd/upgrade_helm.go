@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultTillerNamespace = "kube-system"
+
+// helmValueFlags maps the chart values to the install flags they are rendered
+// from, so that the flags don't have to be reconstructed by hand when upgrading
+// a control plane installed with the chart, and so that a values file given by
+// --values configures the same control plane as the chart would.
+var helmValueFlags = []struct {
+	value, flag string
+	negate      bool
+}{
+	{value: "ControllerReplicas", flag: "controller-replicas"},
+	{value: "ControllerLogLevel", flag: "controller-log-level"},
+	{value: "ControllerKubeAPIQPS", flag: "controller-kube-api-qps"},
+	{value: "ControllerKubeAPIBurst", flag: "controller-kube-api-burst"},
+	{value: "ControllerUID", flag: "controller-uid"},
+	{value: "ProxyAutoInjectEnabled", flag: "proxy-auto-inject"},
+	{value: "ScopedWebhooks", flag: "scoped-webhooks"},
+	{value: "InjectorExcludedNamespaces", flag: "injector-excluded-namespaces"},
+	{value: "SMIMetricsEnabled", flag: "smi-metrics"},
+	{value: "ControlPlaneTLS", flag: "control-plane-tls"},
+	{value: "NoInitContainer", flag: "linkerd-cni-enabled"},
+	{value: "EnableH2Upgrade", flag: "disable-h2-upgrade", negate: true},
+	{value: "ClusterName", flag: "cluster-name"},
+	{value: "GrafanaAPIKeySecret", flag: "grafana-api-key-secret"},
+	{value: "AllowedNamespaces", flag: "allowed-namespaces"},
+	{value: "PodMetricLabels", flag: "pod-metric-labels"},
+	{value: "HATopologyKey", flag: "ha-topology-key"},
+}
+
+// fetchHelmRelease returns the deployed revision of a Helm release, from the
+// ConfigMaps or the Secrets Tiller stores its releases in.
+func fetchHelmRelease(k kubernetes.Interface, tillerNamespace, name string) (*release.Release, error) {
+	query := map[string]string{
+		"NAME":   name,
+		"OWNER":  "TILLER",
+		"STATUS": release.Status_DEPLOYED.String(),
+	}
+
+	releases, err := driver.NewConfigMaps(k.CoreV1().ConfigMaps(tillerNamespace)).Query(query)
+	if err != nil {
+		releases, err = driver.NewSecrets(k.CoreV1().Secrets(tillerNamespace)).Query(query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not find a deployed %s release in the \"%s\" namespace: %s", name, tillerNamespace, err)
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("could not decode the %s release", name)
+	}
+
+	// Only one revision should be deployed; the latest one wins otherwise.
+	latest := releases[0]
+	for _, r := range releases[1:] {
+		if r.GetVersion() > latest.GetVersion() {
+			latest = r
+		}
+	}
+	return latest, nil
+}
+
+// newFakeClientSetFromHelmRelease serves the objects rendered by the release
+// given by --from-helm-release, so that the configuration of the control plane
+// is read from the release rather than from the objects in the cluster. The
+// flags the values of the release were rendered from are saved as well.
+func (options *upgradeOptions) newFakeClientSetFromHelmRelease(k kubernetes.Interface) (kubernetes.Interface, error) {
+	r, err := fetchHelmRelease(k, options.tillerNamespace, options.helmRelease)
+	if err != nil {
+		return nil, err
+	}
+
+	options.helmFlags, err = helmReleaseFlags(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fake, _, _, err := k8s.NewFakeClientSetsFromManifests([]io.Reader{strings.NewReader(r.GetManifest())})
+	return fake, err
+}
+
+// helmReleaseFlags returns the install flags the values of a Helm release
+// would have been rendered from. Values that weren't set aren't returned.
+func helmReleaseFlags(r *release.Release) ([]*pb.Install_Flag, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(r.GetConfig().GetRaw()), &values); err != nil {
+		return nil, fmt.Errorf("could not parse the values of the %s release: %s", r.GetName(), err)
+	}
+	return valuesFlags(values), nil
+}
+
+// valuesFlags returns the install flags the given chart values are rendered
+// from, in the order of helmValueFlags. Values that aren't set are skipped.
+func valuesFlags(values map[string]interface{}) []*pb.Install_Flag {
+	flags := []*pb.Install_Flag{}
+	for _, m := range helmValueFlags {
+		v, ok := values[m.value]
+		if !ok || v == nil || v == "" {
+			continue
+		}
+
+		value := fmt.Sprint(v)
+		switch v := v.(type) {
+		case bool:
+			if m.negate {
+				value = fmt.Sprint(!v)
+			}
+		case float64:
+			// Numbers are parsed as floats, which fmt prints in exponent form
+			// once they're large.
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		flags = append(flags, &pb.Install_Flag{Name: m.flag, Value: value})
+
+		// The topology key is only set in the values of HA installs.
+		if m.value == "HATopologyKey" {
+			flags = append(flags, &pb.Install_Flag{Name: "ha", Value: "true"})
+		}
+	}
+	return flags
+}
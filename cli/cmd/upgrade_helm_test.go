@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestUpgradeFromHelmRelease(t *testing.T) {
+	k, _, err := k8s.NewFakeClientSets()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	releases := driver.NewConfigMaps(k.CoreV1().ConfigMaps(defaultTillerNamespace))
+	for _, r := range []*release.Release{
+		{
+			Name:    "linkerd",
+			Version: 1,
+			Info:    &release.Info{Status: &release.Status{Code: release.Status_SUPERSEDED}},
+			Config:  &chart.Config{Raw: "ControllerReplicas: 1\n"},
+		},
+		{
+			Name:    "linkerd",
+			Version: 2,
+			Info:    &release.Info{Status: &release.Status{Code: release.Status_DEPLOYED}},
+			Config: &chart.Config{Raw: `
+ControllerReplicas: 3
+ControllerLogLevel: debug
+ControllerUID: 2103
+ProxyAutoInjectEnabled: true
+EnableH2Upgrade: false
+HATopologyKey: kubernetes.io/hostname
+ClusterName: ""
+Namespace: linkerd
+`},
+			Manifest: `
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: '{"linkerdNamespace":"linkerd"}'
+  proxy: '{}'
+  install: '{"cliVersion":"stable-2.3.0"}'
+`,
+		},
+	} {
+		if err := releases.Create(fmt.Sprintf("%s.v%d", r.Name, r.Version), r); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	options := newUpgradeOptionsWithDefaults()
+	options.helmRelease = "linkerd"
+	fake, err := options.newFakeClientSetFromHelmRelease(k)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	configs, err := fetchConfigs(fake)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if configs.GetInstall().GetCliVersion() != "stable-2.3.0" {
+		t.Fatalf("Expected the configuration of the deployed release, got %v", configs)
+	}
+
+	expectedFlags := []*pb.Install_Flag{
+		{Name: "controller-replicas", Value: "3"},
+		{Name: "controller-log-level", Value: "debug"},
+		{Name: "controller-uid", Value: "2103"},
+		{Name: "proxy-auto-inject", Value: "true"},
+		{Name: "disable-h2-upgrade", Value: "true"},
+		{Name: "ha-topology-key", Value: "kubernetes.io/hostname"},
+		{Name: "ha", Value: "true"},
+	}
+	if !reflect.DeepEqual(options.helmFlags, expectedFlags) {
+		t.Fatalf("Expected flags %v, got %v", expectedFlags, options.helmFlags)
+	}
+
+	options.helmRelease = "other"
+	if _, err := options.newFakeClientSetFromHelmRelease(k); err == nil {
+		t.Fatal("Expected an error for a missing release")
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// maxHistoryRecords is the number of records kept in the history, so that
+	// it doesn't outgrow the ConfigMap holding it.
+	maxHistoryRecords = 50
+
+	recordTimeAndUserUsage = "Record the time the configs are rendered at and the user rendering them in the upgrade history; the configs then differ on each render, and the user's name is saved in the cluster (default false)"
+)
+
+var (
+	// historyNow and historyUser are replaced in tests, so that the rendered
+	// history doesn't depend on when and by whom the tests are run.
+	historyNow  = time.Now
+	historyUser = func() string {
+		if u, err := user.Current(); err == nil {
+			return u.Username
+		}
+		return ""
+	}
+)
+
+// historyRecord is a revision of the control plane, recorded in the
+// ConfigHistoryConfigMapName ConfigMap each time it's installed or upgraded.
+// The time and the user are only recorded with --record-time-and-user, so
+// that the rendered configs are the same each time by default.
+type historyRecord struct {
+	Revision     int        `json:"revision"`
+	Timestamp    *time.Time `json:"timestamp,omitempty"`
+	Command      string     `json:"command"`
+	User         string     `json:"user,omitempty"`
+	CliVersion   string     `json:"cliVersion"`
+	Flags        []string   `json:"flags"`
+	ConfigDigest string     `json:"configDigest"`
+}
+
+func newCmdUpgradeHistory(options *upgradeOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [flags]",
+		Short: "List the installs and upgrades of a Linkerd control plane",
+		Long: `List the installs and upgrades of a Linkerd control plane.
+
+Each install, upgrade and rollback records the version of the CLI, the flags
+recorded in the configuration, and the SHA-256 of the configuration, along
+with the time it was rendered at and the user rendering it when it's rendered
+with --record-time-and-user. The records are saved along with the
+configuration, so only the ones that were applied are listed, and only the
+last 50 are kept.`,
+		Example: `  linkerd upgrade history`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, _ := options.newClients()
+
+			history, err := fetchHistory(k)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the upgrade history: %s", err)
+			}
+			if len(history) == 0 {
+				fmt.Fprintln(os.Stderr, "No history was recorded for this control plane")
+				return nil
+			}
+
+			printHistory(stdout, history)
+			return nil
+		},
+	}
+}
+
+// buildHistory appends a record of the command rendering values to the history,
+// and returns it as JSON. The time and the user are only recorded if
+// recordTimeAndUser is set.
+func buildHistory(history []historyRecord, command string, values *installValues, flags []*pb.Install_Flag, recordTimeAndUser bool) (string, error) {
+	revision := 1
+	if len(history) > 0 {
+		revision = history[len(history)-1].Revision + 1
+	}
+
+	recordedFlags := []string{}
+	for _, f := range flags {
+		recordedFlags = append(recordedFlags, fmt.Sprintf("%s=%s", f.GetName(), f.GetValue()))
+	}
+
+	digest := sha256.Sum256([]byte(values.Configs.Global + values.Configs.Proxy + values.Configs.Install))
+
+	record := historyRecord{
+		Revision:     revision,
+		Command:      command,
+		CliVersion:   version.Version,
+		Flags:        recordedFlags,
+		ConfigDigest: fmt.Sprintf("%x", digest),
+	}
+	if recordTimeAndUser {
+		now := historyNow().UTC().Truncate(time.Second)
+		record.Timestamp = &now
+		record.User = historyUser()
+	}
+	history = append(history, record)
+	if len(history) > maxHistoryRecords {
+		history = history[len(history)-maxHistoryRecords:]
+	}
+
+	b, err := json.Marshal(history)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// fetchHistory returns the history of the control plane, which is empty if it
+// was installed before the history was recorded.
+func fetchHistory(k kubernetes.Interface) ([]historyRecord, error) {
+	configMap, err := k.CoreV1().
+		ConfigMaps(controlPlaneNamespace).
+		Get(k8s.ConfigHistoryConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history := []historyRecord{}
+	if err := json.Unmarshal([]byte(configMap.Data["history"]), &history); err != nil {
+		return nil, fmt.Errorf("invalid %s ConfigMap: %s", k8s.ConfigHistoryConfigMapName, err)
+	}
+	return history, nil
+}
+
+func printHistory(w io.Writer, history []historyRecord) {
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, "REVISION\tTIMESTAMP\tCOMMAND\tUSER\tVERSION\tCONFIG\tFLAGS")
+	for _, r := range history {
+		username := r.User
+		if username == "" {
+			username = "-"
+		}
+		digest := r.ConfigDigest
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		flags := strings.Join(r.Flags, ",")
+		if flags == "" {
+			flags = "-"
+		}
+		timestamp := "-"
+		if r.Timestamp != nil {
+			timestamp = r.Timestamp.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Revision, timestamp, r.Command, username, r.CliVersion, digest, flags)
+	}
+	tw.Flush()
+}
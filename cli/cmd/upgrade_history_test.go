@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestUpgradeHistory(t *testing.T) {
+	k, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config-history
+  namespace: linkerd
+data:
+  history: |
+    [{"revision":1,"timestamp":"2019-05-01T00:00:00Z","command":"install","user":"alice","cliVersion":"edge-19.4.1","flags":[],"configDigest":"3ca80d6b0ddc7f6104b7f7d84fe8a35bd3a6f1c804ffe395655dc8b0a163ab04"}]`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	history, err := fetchHistory(k)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	values := &installValues{Configs: configJSONs{Global: "{}", Proxy: "{}", Install: "{}"}}
+	flags := []*pb.Install_Flag{{Name: "ha", Value: "true"}}
+	historyJSON, err := buildHistory(history, "upgrade", values, flags, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	history = []historyRecord{}
+	if err := json.Unmarshal([]byte(historyJSON), &history); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The time and the user are only recorded when requested.
+	historyJSON, err = buildHistory(history, "upgrade", values, flags, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	history = []historyRecord{}
+	if err := json.Unmarshal([]byte(historyJSON), &history); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	printHistory(&buf, history)
+	expected := `REVISION   TIMESTAMP              COMMAND   USER    VERSION         CONFIG         FLAGS
+1          2019-05-01T00:00:00Z   install   alice   edge-19.4.1     3ca80d6b0ddc   -
+2          2019-06-01T00:00:00Z   upgrade   test    dev-undefined   78a39fc30dab   ha=true
+3          -                      upgrade   -       dev-undefined   78a39fc30dab   ha=true
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	k, _, err = k8s.NewFakeClientSets()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if history, err := fetchHistory(k); err != nil || len(history) != 0 {
+		t.Errorf("Expected no history, got %v (%v)", history, err)
+	}
+}
+
+func TestBuildHistoryKeepsTheLastRecords(t *testing.T) {
+	history := []historyRecord{}
+	for i := 1; i <= maxHistoryRecords; i++ {
+		history = append(history, historyRecord{Revision: i, Command: fmt.Sprintf("upgrade %d", i)})
+	}
+
+	historyJSON, err := buildHistory(history, "upgrade", &installValues{}, nil, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	history = []historyRecord{}
+	if err := json.Unmarshal([]byte(historyJSON), &history); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(history) != maxHistoryRecords {
+		t.Fatalf("Expected %d records, got %d", maxHistoryRecords, len(history))
+	}
+	if first, last := history[0].Revision, history[len(history)-1].Revision; first != 2 || last != maxHistoryRecords+1 {
+		t.Errorf("Expected revisions 2 to %d, got %d to %d", maxHistoryRecords+1, first, last)
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// mergeUpgrade writes the documents of the rendered upgrade with the manifests
+// last applied to their resources merged into them, so that the fields users
+// added to the control plane's resources, e.g. annotations, tolerations or
+// sidecar containers, survive the upgrade. Fields set by the upgrade take
+// precedence. Documents whose resources don't exist yet or weren't applied
+// with `kubectl apply` are written as rendered.
+func mergeUpgrade(w io.Writer, rendered io.Reader, client dynamic.Interface) error {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(rendered, 4096))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		merged, err := mergeLastApplied(client, &unstructured.Unstructured{Object: obj})
+		if err != nil {
+			return err
+		}
+		if merged != nil {
+			if doc, err = yaml.Marshal(merged.Object); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", doc); err != nil {
+			return err
+		}
+	}
+}
+
+// mergeLastApplied returns the rendered object merged on top of the manifest
+// last applied to its live resource, or nil if there is no such manifest.
+func mergeLastApplied(client dynamic.Interface, rendered *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	resource, header := resourceClient(client, rendered)
+	live, err := resource.Get(rendered.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %s", header, err)
+	}
+
+	applied := live.GetAnnotations()[lastAppliedAnnotation]
+	if applied == "" {
+		return nil, nil
+	}
+
+	patch, err := json.Marshal(rendered.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rendered object is a patch of the applied manifest, setting each of
+	// its fields.
+	merged, err := applyMergePatch(rendered.GroupVersionKind(), []byte(applied), patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge %s: %s", header, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(merged); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// threeWayMergePatch returns the patch applying the changes from original to
+// modified to current, like `kubectl apply`: lists of the built-in resources,
+// e.g. containers, are merged by key, while the ones of other resources are
+// replaced.
+func threeWayMergePatch(gvk schema.GroupVersionKind, original, modified, current []byte) ([]byte, error) {
+	if meta, ok := patchMetaFor(gvk); ok {
+		return strategicpatch.CreateThreeWayMergePatch(original, modified, current, meta, true)
+	}
+	return jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current)
+}
+
+// applyMergePatch applies a patch returned by threeWayMergePatch.
+func applyMergePatch(gvk schema.GroupVersionKind, current, patch []byte) ([]byte, error) {
+	if meta, ok := patchMetaFor(gvk); ok {
+		return strategicpatch.StrategicMergePatchUsingLookupPatchMeta(current, patch, meta)
+	}
+	return jsonpatch.MergePatch(current, patch)
+}
+
+// patchMetaFor returns the strategic merge metadata of a kind, if it's built
+// into Kubernetes.
+func patchMetaFor(gvk schema.GroupVersionKind) (strategicpatch.LookupPatchMeta, bool) {
+	obj, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, false
+	}
+	meta, err := strategicpatch.NewPatchMetaFromStruct(obj)
+	if err != nil {
+		return nil, false
+	}
+	return meta, true
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestMergeUpgrade(t *testing.T) {
+	live := `
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"annotations":{"example.com/owner":"team"},"name":"linkerd-web","namespace":"linkerd"},"spec":{"template":{"spec":{"containers":[{"image":"web:old","name":"web"},{"image":"sidecar:v1","name":"sidecar"}],"tolerations":[{"key":"dedicated","operator":"Exists"}]}}}}'
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: old
+`
+
+	rendered := `---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:new
+        args:
+        - -addr=:8084
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: new
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+`
+
+	expected := `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    example.com/owner: team
+  name: linkerd-web
+  namespace: linkerd
+spec:
+  template:
+    spec:
+      containers:
+      - args:
+        - -addr=:8084
+        image: web:new
+        name: web
+      - image: sidecar:v1
+        name: sidecar
+      tolerations:
+      - key: dedicated
+        operator: Exists
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: new
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+`
+
+	objs := []runtime.Object{}
+	err := forEachManifest(strings.NewReader(live), func(obj *unstructured.Unstructured) error {
+		objs = append(objs, obj)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...)
+
+	var buf bytes.Buffer
+	if err := mergeUpgrade(&buf, strings.NewReader(rendered), client); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
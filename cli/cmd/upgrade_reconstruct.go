@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// The environment variables of the proxies the identity context of the
+	// control plane is recovered from.
+	envTrustAnchors = "LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS"
+	envTrustDomain  = "_l5d_trustdomain"
+)
+
+// configsDamaged tells whether fetchConfigs failed because the linkerd-config
+// ConfigMap is missing or can't be parsed, rather than because the API server
+// couldn't be reached.
+func configsDamaged(err error) bool {
+	if kerrors.IsNotFound(err) {
+		return true
+	}
+	_, isStatus := err.(kerrors.APIStatus)
+	return !isStatus
+}
+
+// reconstructConfigs rebuilds the configuration of the control plane from the
+// flags of the upgrade and from what can be recovered from its deployments,
+// with --force, when the linkerd-config ConfigMap is missing or corrupt: the
+// version they were created by, the trust anchors and trust domain of their
+// proxies, the registry and images, the replicas of the controller and whether
+// the proxies are injected without an init container or automatically. The
+// recovered flags are returned as the recorded ones, so that the flags of the
+// upgrade default to them, and the ConfigMap is repaired by the upgrade. What
+// couldn't be recovered is written to w.
+func (options *upgradeOptions) reconstructConfigs(k kubernetes.Interface, flags *pflag.FlagSet, w io.Writer) (*pb.All, error) {
+	deploys, err := k.AppsV1().Deployments(controlPlaneNamespace).List(metav1.ListOptions{LabelSelector: k8s.ControllerComponentLabel})
+	if err != nil {
+		return nil, err
+	}
+	if len(deploys.Items) == 0 {
+		return nil, fmt.Errorf("no control plane deployments were found in the \"%s\" namespace to reconstruct the configuration from", controlPlaneNamespace)
+	}
+	sort.Slice(deploys.Items, func(i, j int) bool { return deploys.Items[i].Name < deploys.Items[j].Name })
+
+	configs := &pb.All{
+		Global:  &pb.Global{LinkerdNamespace: controlPlaneNamespace},
+		Proxy:   options.proxyConfig(),
+		Install: &pb.Install{},
+	}
+
+	recovered := map[string]string{}
+	salvage := func(name, value string) {
+		if f := flags.Lookup(name); f != nil && value != "" && value != f.DefValue {
+			recovered[name] = value
+		}
+	}
+
+	autoInject := false
+	for _, deploy := range deploys.Items {
+		spec := deploy.Spec.Template.Spec
+		component := deploy.Labels[k8s.ControllerComponentLabel]
+		if component == "proxy-injector" {
+			autoInject = true
+		}
+
+		if configs.Install.CliVersion == "" {
+			configs.Install.CliVersion = strings.TrimPrefix(deploy.Spec.Template.Annotations[k8s.CreatedByAnnotation], createdByVersion(""))
+		}
+
+		if component == "controller" {
+			if deploy.Spec.Replicas != nil {
+				salvage("controller-replicas", fmt.Sprint(*deploy.Spec.Replicas))
+			}
+			for _, c := range spec.Containers {
+				if c.Name == "public-api" {
+					salvage("registry", path.Dir(imageName(c.Image)))
+				}
+			}
+		}
+
+		for _, c := range spec.Containers {
+			if c.Name != k8s.ProxyContainerName {
+				continue
+			}
+			salvage("proxy-image", imageName(c.Image))
+			if configs.Global.IdentityContext == nil {
+				configs.Global.IdentityContext = recoverIdentityContext(c.Env)
+			}
+
+			initContainer := false
+			for _, ic := range spec.InitContainers {
+				if ic.Name == k8s.InitContainerName {
+					initContainer = true
+					salvage("init-image", imageName(ic.Image))
+				}
+			}
+			if !initContainer {
+				salvage("linkerd-cni-enabled", "true")
+			}
+		}
+	}
+	if autoInject {
+		salvage("proxy-auto-inject", "true")
+	}
+
+	// Images of the default registry are only recorded when they're not
+	// derived from the recovered one.
+	registry := recovered["registry"]
+	if registry == "" {
+		registry = defaultDockerRegistry
+	}
+	for name, def := range map[string]string{"proxy-image": defaultDockerRegistry + "/proxy", "init-image": defaultDockerRegistry + "/proxy-init"} {
+		if recovered[name] == registryOverride(def, registry) {
+			delete(recovered, name)
+		}
+	}
+
+	names := []string{}
+	for name := range recovered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		configs.Install.Flags = append(configs.Install.Flags, &pb.Install_Flag{Name: name, Value: recovered[name]})
+	}
+
+	if configs.Install.CliVersion == "" {
+		fmt.Fprintf(w, "%s The version of the control plane couldn't be recovered from its deployments\n", warnStatus)
+	}
+	if configs.Global.IdentityContext == nil {
+		fmt.Fprintf(w, "%s The trust anchors couldn't be recovered from the proxies of the control plane: identity is generated anew, and the meshed pods must be restarted to trust it\n", warnStatus)
+	}
+	return configs, nil
+}
+
+// recoverIdentityContext returns the identity context a proxy was injected
+// with, or nil if it wasn't injected with identity.
+func recoverIdentityContext(env []corev1.EnvVar) *pb.IdentityContext {
+	trustAnchors, trustDomain := "", ""
+	for _, e := range env {
+		switch e.Name {
+		case envTrustAnchors:
+			trustAnchors = e.Value
+		case envTrustDomain:
+			trustDomain = e.Value
+		}
+	}
+	if trustAnchors == "" || trustDomain == "" {
+		return nil
+	}
+
+	// The issuance lifetime and the clock skew allowance aren't known to the
+	// proxies; the defaults apply.
+	return &pb.IdentityContext{
+		TrustDomain:        trustDomain,
+		TrustAnchorsPem:    trustAnchors,
+		IssuanceLifetime:   ptypes.DurationProto(defaultIdentityIssuanceLifetime),
+		ClockSkewAllowance: ptypes.DurationProto(defaultIdentityClockSkewAllowance),
+	}
+}
+
+// imageName returns the name of an image, without its tag.
+func imageName(image string) string {
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+	return image
+}
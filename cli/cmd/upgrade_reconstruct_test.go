@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+const reconstructTestDeployments = `
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+spec:
+  replicas: 3
+  template:
+    metadata:
+      annotations:
+        linkerd.io/created-by: linkerd/cli stable-2.5.0
+    spec:
+      containers:
+      - name: public-api
+        image: registry.example.com/linkerd/controller:stable-2.5.0
+      - name: linkerd-proxy
+        image: registry.example.com/linkerd/proxy:stable-2.5.0
+        env:
+        - name: LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS
+          value: TRUST-ANCHORS
+        - name: _l5d_trustdomain
+          value: example.com
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-proxy-injector
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: proxy-injector
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy-injector
+        image: registry.example.com/linkerd/controller:stable-2.5.0`
+
+func TestReconstructConfigs(t *testing.T) {
+	controlPlaneNamespace = "linkerd"
+	clientset, _, err := k8s.NewFakeClientSets(strings.Split(reconstructTestDeployments, "---")...)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	options := testUpgradeOptions()
+	var out bytes.Buffer
+	configs, err := options.reconstructConfigs(clientset, options.recordableFlagSet(), &out)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.Len() > 0 {
+		t.Errorf("Expected everything to be recovered, got:\n%s", out.String())
+	}
+
+	if configs.GetGlobal().GetLinkerdNamespace() != "linkerd" {
+		t.Errorf("Expected the namespace of the control plane, got %s", configs.GetGlobal().GetLinkerdNamespace())
+	}
+	if configs.GetInstall().GetCliVersion() != "stable-2.5.0" {
+		t.Errorf("Expected the version of the deployments, got %s", configs.GetInstall().GetCliVersion())
+	}
+	idctx := configs.GetGlobal().GetIdentityContext()
+	if idctx.GetTrustAnchorsPem() != "TRUST-ANCHORS" || idctx.GetTrustDomain() != "example.com" {
+		t.Errorf("Expected the identity context of the proxies, got %v", idctx)
+	}
+
+	// The proxy image is derived from the registry.
+	expected := []*pb.Install_Flag{
+		{Name: "controller-replicas", Value: "3"},
+		{Name: "linkerd-cni-enabled", Value: "true"},
+		{Name: "proxy-auto-inject", Value: "true"},
+		{Name: "registry", Value: "registry.example.com/linkerd"},
+	}
+	if !reflect.DeepEqual(configs.GetInstall().GetFlags(), expected) {
+		t.Errorf("Expected the flags %v, got %v", expected, configs.GetInstall().GetFlags())
+	}
+}
+
+func TestUpgradeReconstructsMissingConfig(t *testing.T) {
+	controlPlaneNamespace = "linkerd"
+	clientset, _, err := k8s.NewFakeClientSets(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+spec:
+  template:
+    spec:
+      containers:
+      - name: public-api
+        image: gcr.io/linkerd-io/controller:stable-2.5.0`)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	options := testUpgradeOptions()
+	options.force = true
+	_, configs, err := options.validateAndBuild(clientset, options.recordableFlagSet())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Without proxies to recover them from, the trust anchors are generated.
+	if !options.identityGenerated || configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem() == "" {
+		t.Errorf("Expected identity to be generated, got %v", configs.GetGlobal().GetIdentityContext())
+	}
+	if configs.GetInstall().GetUuid() == "" || configs.GetGlobal().GetVersion() != upgradeVersion {
+		t.Errorf("Expected the configuration to be repaired, got %v", configs)
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const rollbackOkMessage = "You're on your way to rolling back Linkerd!\nVisit this URL for further instructions: https://linkerd.io/upgrade/#nextsteps\n"
+
+// previousConfigValues is the configuration replaced by an upgrade, rendered in
+// the ConfigPreviousConfigMapName ConfigMap.
+type previousConfigValues struct {
+	Global, Proxy, Install string
+
+	// ManifestHash is the SHA-256 of the manifests rendered from the previous
+	// configuration, to tell whether a rollback renders the same manifests.
+	ManifestHash           string
+	ManifestHashAnnotation string
+}
+
+func newCmdUpgradeRollback(options *upgradeOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback [flags]",
+		Short: "Output Kubernetes configs to roll back the last upgrade of a Linkerd control plane",
+		Long: `Output Kubernetes configs to roll back the last upgrade of a Linkerd control plane.
+
+Each upgrade that changes the configuration saves the one it replaces in the
+linkerd-config-previous ConfigMap. The control plane is rendered again from that configuration: the
+release it ran, the flags it was installed or upgraded with, and the current
+identity issuer credentials. The flags of the upgrade command are ignored, and
+--pre-install-manifest and --post-install-manifest aren't restored.
+
+Only the last upgrade can be rolled back.`,
+		Example: `  linkerd upgrade rollback | kubectl apply -f -`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, _ := options.newClients()
+
+			previous, expectedHash, err := fetchPreviousConfigs(k)
+			if err != nil {
+				upgradeErrorf("Failed to fetch the previous configuration: %s", err)
+			}
+
+			values, err := buildRollbackValues(k, previous, options.kubernetesVersion)
+			if err != nil {
+				upgradeErrorf("Failed to build rollback configuration: %s", err)
+			}
+
+			// The manifests expected when the upgrade was made don't hold the
+			// history, which is rendered afterwards. Rendering modifies the
+			// configs.
+			var buf bytes.Buffer
+			if err = values.render(&buf, proto.Clone(previous).(*pb.All)); err != nil {
+				upgradeErrorf("Could not render rollback configuration: %s", err)
+			}
+
+			if hash := manifestHash(buf.Bytes()); expectedHash != "" && hash != expectedHash {
+				fmt.Fprintf(os.Stderr, "%s the rollback differs from the manifests expected when the upgrade was made, e.g. because this CLI's version differs from the one used to upgrade\n\n", warnStatus)
+			}
+
+			history, err := fetchHistory(k)
+			if err != nil {
+				upgradeErrorf("Failed to fetch the upgrade history: %s", err)
+			}
+			values.History, err = buildHistory(history, "upgrade rollback", values, previous.GetInstall().GetFlags(), options.recordTimeAndUser)
+			if err != nil {
+				upgradeErrorf("Failed to record the rollback in the upgrade history: %s", err)
+			}
+
+			buf.Reset()
+			if err = values.render(&buf, previous); err != nil {
+				upgradeErrorf("Could not render rollback configuration: %s", err)
+			}
+
+			buf.WriteTo(os.Stdout)
+
+			fmt.Fprintf(os.Stderr, "\n%s %s\n", okStatus, rollbackOkMessage)
+
+			return nil
+		},
+	}
+}
+
+// buildPreviousConfigValues returns the configuration replaced by an upgrade,
+// to save it along with the hash of the manifests it renders to. An upgrade
+// that doesn't change the configuration keeps the one saved by the last
+// upgrade that did, so that it can still be rolled back. Configurations
+// without identity can't be rolled back to, and aren't saved.
+//
+// The configuration is saved even if its manifests can't be rendered, as the
+// upgrade doesn't depend on them; its rollback just isn't checked against
+// them.
+func buildPreviousConfigValues(k kubernetes.Interface, previous, upgraded *pb.All, kubernetesVersion string) (*previousConfigValues, error) {
+	if proto.Equal(previous, upgraded) {
+		return fetchPreviousConfigValues(k)
+	}
+	if previous.GetGlobal().GetIdentityContext() == nil {
+		return nil, nil
+	}
+
+	global, proxy, install, err := config.ToJSON(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := renderedManifestHash(k, previous, kubernetesVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s The manifests of the previous configuration couldn't be rendered (%s); a rollback to it won't be checked against them\n", warnStatus, err)
+	}
+
+	return &previousConfigValues{
+		Global:                 global,
+		Proxy:                  proxy,
+		Install:                install,
+		ManifestHash:           hash,
+		ManifestHashAnnotation: k8s.ConfigManifestHashAnnotation,
+	}, nil
+}
+
+// renderedManifestHash returns the hash of the manifests the given
+// configuration renders to.
+func renderedManifestHash(k kubernetes.Interface, configs *pb.All, kubernetesVersion string) (string, error) {
+	values, err := buildRollbackValues(k, configs, kubernetesVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// Rendering modifies the configs.
+	var buf bytes.Buffer
+	if err = values.render(&buf, proto.Clone(configs).(*pb.All)); err != nil {
+		return "", err
+	}
+	return manifestHash(buf.Bytes()), nil
+}
+
+// buildRollbackValues builds the values rendering the control plane from a
+// previous configuration, as if it were upgraded with the flags recorded in
+// it, for the release it ran.
+func buildRollbackValues(k kubernetes.Interface, previous *pb.All, kubernetesVersion string) (*installValues, error) {
+	idctx := previous.GetGlobal().GetIdentityContext()
+	if idctx == nil {
+		return nil, errors.New("the previous configuration has no identity context")
+	}
+
+	options := newInstallOptionsWithDefaults()
+	options.kubernetesVersion = kubernetesVersion
+	setFlagsFromInstall(options.recordableFlagSet(), previous.GetInstall().GetFlags())
+	if v := previous.GetGlobal().GetVersion(); v != "" {
+		options.linkerdVersion = v
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	identity, err := fetchIdentityValues(k, options.controllerReplicas, idctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the existing issuer credentials from Kubernetes: %s", err)
+	}
+
+	values, err := options.buildValuesWithoutIdentity(previous)
+	if err != nil {
+		return nil, err
+	}
+	values.Identity = identity
+
+	return values, nil
+}
+
+// fetchPreviousConfigs returns the configuration saved by the last upgrade,
+// and the hash of the manifests it rendered to at the time.
+func fetchPreviousConfigs(k kubernetes.Interface) (*pb.All, string, error) {
+	configMap, err := fetchPreviousConfigMap(k)
+	if err != nil {
+		return nil, "", err
+	}
+	if configMap == nil {
+		return nil, "", errors.New("no upgrade to roll back was found")
+	}
+
+	configs, err := config.FromConfigMap(configMap.Data)
+	if err != nil {
+		return nil, "", err
+	}
+	return configs, configMap.GetAnnotations()[k8s.ConfigManifestHashAnnotation], nil
+}
+
+// fetchPreviousConfigValues returns the configuration saved by the last
+// upgrade as it's saved, or nil if there's none.
+func fetchPreviousConfigValues(k kubernetes.Interface) (*previousConfigValues, error) {
+	configMap, err := fetchPreviousConfigMap(k)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+
+	return &previousConfigValues{
+		Global:                 strings.TrimSpace(configMap.Data["global"]),
+		Proxy:                  strings.TrimSpace(configMap.Data["proxy"]),
+		Install:                strings.TrimSpace(configMap.Data["install"]),
+		ManifestHash:           configMap.GetAnnotations()[k8s.ConfigManifestHashAnnotation],
+		ManifestHashAnnotation: k8s.ConfigManifestHashAnnotation,
+	}, nil
+}
+
+func fetchPreviousConfigMap(k kubernetes.Interface) (*corev1.ConfigMap, error) {
+	configMap, err := k.CoreV1().
+		ConfigMaps(controlPlaneNamespace).
+		Get(k8s.ConfigPreviousConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return configMap, err
+}
+
+func manifestHash(manifest []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(manifest))
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestFetchPreviousConfigs(t *testing.T) {
+	k, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config-previous
+  namespace: linkerd
+  annotations:
+    linkerd.io/manifest-sha256: 3ca80d6b
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","version":"edge-19.4.1"}
+  proxy: |
+    {"proxyUid":"2102"}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[{"name":"ha","value":"true"}]}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	configs, hash, err := fetchPreviousConfigs(k)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if hash != "3ca80d6b" {
+		t.Fatalf("Expected hash 3ca80d6b, got %s", hash)
+	}
+	if v := configs.GetGlobal().GetVersion(); v != "edge-19.4.1" {
+		t.Fatalf("Expected version edge-19.4.1, got %s", v)
+	}
+	if flags := configs.GetInstall().GetFlags(); len(flags) != 1 || flags[0].GetName() != "ha" {
+		t.Fatalf("Expected the ha flag to be recorded, got %v", flags)
+	}
+
+	k, _, err = k8s.NewFakeClientSets()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := "no upgrade to roll back was found"
+	if _, _, err := fetchPreviousConfigs(k); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%s]", expected, err)
+	}
+}
+
+func TestBuildPreviousConfigValuesWithoutIdentity(t *testing.T) {
+	k, _, err := k8s.NewFakeClientSets()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	previous := &pb.All{Global: &pb.Global{}, Proxy: &pb.Proxy{}, Install: &pb.Install{}}
+	upgraded := &pb.All{Global: &pb.Global{Version: "edge-19.4.2"}, Proxy: &pb.Proxy{}, Install: &pb.Install{}}
+	values, err := buildPreviousConfigValues(k, previous, upgraded, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if values != nil {
+		t.Fatalf("Expected configs without identity not to be saved, got %+v", values)
+	}
+}
+
+func TestBuildPreviousConfigValues(t *testing.T) {
+	k, _, err := k8s.NewFakeClientSets(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config-previous
+  namespace: linkerd
+  annotations:
+    linkerd.io/manifest-sha256: 3ca80d6b
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","version":"edge-19.4.1"}
+  proxy: |
+    {"proxyUid":"2102"}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[]}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	previous := &pb.All{
+		Global:  &pb.Global{LinkerdNamespace: "linkerd", Version: "edge-19.4.2", IdentityContext: &pb.IdentityContext{TrustDomain: "cluster.local"}},
+		Proxy:   &pb.Proxy{},
+		Install: &pb.Install{},
+	}
+
+	t.Run("keeps the saved configuration when the configuration is unchanged", func(t *testing.T) {
+		values, err := buildPreviousConfigValues(k, previous, proto.Clone(previous).(*pb.All), "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := &previousConfigValues{
+			Global:                 `{"linkerdNamespace":"linkerd","version":"edge-19.4.1"}`,
+			Proxy:                  `{"proxyUid":"2102"}`,
+			Install:                `{"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[]}`,
+			ManifestHash:           "3ca80d6b",
+			ManifestHashAnnotation: k8s.ConfigManifestHashAnnotation,
+		}
+		if !reflect.DeepEqual(values, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, values)
+		}
+	})
+
+	t.Run("saves the configuration without a hash when it can't be rendered", func(t *testing.T) {
+		// The issuer credentials of the configuration are missing.
+		upgraded := proto.Clone(previous).(*pb.All)
+		upgraded.GetGlobal().Version = "edge-19.4.3"
+		values, err := buildPreviousConfigValues(k, previous, upgraded, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if values == nil || values.ManifestHash != "" || !strings.Contains(values.Global, "edge-19.4.2") {
+			t.Fatalf("Expected the previous configuration to be saved without a hash, got %+v", values)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// configStage renders the cluster-scoped resources of the control plane,
+	// e.g. its namespace, ClusterRoles and CustomResourceDefinitions.
+	configStage = "config"
+
+	// controlPlaneStage renders the resources in the control plane's
+	// namespace.
+	controlPlaneStage = "control-plane"
+)
+
+func newCmdUpgradeStage(options *upgradeOptions, flags, outputFlags *pflag.FlagSet, stage string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  fmt.Sprintf("%s [flags]", stage),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgradeRunE(options, flags, stage)
+		},
+	}
+
+	switch stage {
+	case configStage:
+		cmd.Short = "Output the cluster-scoped Kubernetes configs of a Linkerd control plane upgrade"
+		cmd.Long = `Output the cluster-scoped Kubernetes configs of a Linkerd control plane upgrade.
+
+This is the first stage of an upgrade, rendering the resources that require
+cluster-wide permissions to apply: the control plane's namespace, ClusterRoles,
+ClusterRoleBindings and CustomResourceDefinitions. It should be applied before
+the control-plane stage.`
+	case controlPlaneStage:
+		cmd.Short = "Output the namespaced Kubernetes configs of a Linkerd control plane upgrade"
+		cmd.Long = `Output the namespaced Kubernetes configs of a Linkerd control plane upgrade.
+
+This is the second stage of an upgrade, rendering the resources in the control
+plane's namespace, which only require permissions in that namespace to apply.
+It should be applied after the config stage.`
+	}
+
+	cmd.Flags().AddFlagSet(outputFlags)
+	return cmd
+}
+
+// filterUpgradeStage writes the documents of the rendered manifest that belong
+// to the given stage: cluster-scoped resources for configStage, and namespaced
+// ones for controlPlaneStage.
+func filterUpgradeStage(w io.Writer, rendered io.Reader, stage string) error {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(rendered, 4096))
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var doc struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(bytes, &doc); err != nil {
+			return err
+		}
+		if doc.Kind == "" {
+			continue
+		}
+
+		namespaced := doc.Metadata.Namespace != ""
+		if namespaced != (stage == controlPlaneStage) {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", bytes); err != nil {
+			return err
+		}
+	}
+}
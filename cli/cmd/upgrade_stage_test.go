@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFilterUpgradeStage(t *testing.T) {
+	rendered := `---
+###
+### Linkerd Namespace
+###
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-controller
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+`
+
+	testCases := []struct {
+		stage    string
+		expected string
+	}{
+		{
+			configStage,
+			`---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-controller
+`,
+		},
+		{
+			controlPlaneStage,
+			`---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.stage, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := filterUpgradeStage(&buf, strings.NewReader(rendered), tc.stage); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("Expected:\n%s\nGot:\n%s", tc.expected, buf.String())
+			}
+		})
+	}
+}
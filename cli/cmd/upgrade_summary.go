@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/spf13/pflag"
+)
+
+// Sources of the values of the flags of an upgrade.
+const (
+	flagSourceCluster     = "cluster"
+	flagSourceCommandLine = "command-line"
+	flagSourceHelmRelease = "helm-release"
+)
+
+// flagChange reports the value of a flag of an upgrade, next to the value
+// recorded by the previous install or upgrade, if any. Changed is set when the
+// upgrade changes the configuration of the control plane.
+type flagChange struct {
+	Name     string  `json:"name"`
+	Previous *string `json:"previous"`
+	Value    string  `json:"value"`
+	Source   string  `json:"source"`
+	Changed  bool    `json:"changed"`
+}
+
+// changedFlagNames returns the names of the flags set on the command line. It
+// must be called before the recorded flags are applied to the flag set.
+func changedFlagNames(flags *pflag.FlagSet) map[string]bool {
+	names := map[string]bool{}
+	flags.Visit(func(f *pflag.Flag) {
+		names[f.Name] = true
+	})
+	return names
+}
+
+// buildFlagChanges returns the changes of the flags of an upgrade, in the order
+// of the flag set: each flag set either on the command line, by the previous
+// install or upgrade, or by the Helm release the upgrade is read from.
+func buildFlagChanges(flags *pflag.FlagSet, recorded []*pb.Install_Flag, commandLine map[string]bool, helmFlags []*pb.Install_Flag) []*flagChange {
+	previous := map[string]string{}
+	for _, f := range recorded {
+		previous[f.GetName()] = f.GetValue()
+	}
+	fromHelm := map[string]bool{}
+	for _, f := range helmFlags {
+		fromHelm[f.GetName()] = true
+	}
+
+	changes := []*flagChange{}
+	flags.VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+
+		change := &flagChange{
+			Name:   f.Name,
+			Value:  flagValue(f),
+			Source: flagSourceCluster,
+		}
+		switch {
+		case commandLine[f.Name]:
+			change.Source = flagSourceCommandLine
+		case fromHelm[f.Name]:
+			change.Source = flagSourceHelmRelease
+		}
+		if value, ok := previous[f.Name]; ok {
+			change.Previous = &value
+		}
+		change.Changed = change.Previous == nil || *change.Previous != change.Value
+		changes = append(changes, change)
+	})
+	return changes
+}
+
+// printFlagChanges writes the changes of the flags of an upgrade. The text
+// format only lists the flags whose value changed, while the json one lists all
+// of them, so that pipelines can gate on unexpected drift.
+func printFlagChanges(w io.Writer, changes []*flagChange, format string) error {
+	if format == jsonOutput {
+		out, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	}
+
+	header := false
+	for _, change := range changes {
+		if !change.Changed {
+			continue
+		}
+		if !header {
+			fmt.Fprintln(w, "Flags changed by the upgrade:")
+			header = true
+		}
+		if change.Previous == nil {
+			fmt.Fprintf(w, "  * %s: %s (from the %s)\n", change.Name, change.Value, change.Source)
+		} else {
+			fmt.Fprintf(w, "  * %s: %s -> %s (from the %s)\n", change.Name, *change.Previous, change.Value, change.Source)
+		}
+	}
+	return nil
+}
+
+// confirmUpgrade shows what the upgrade changes besides the flags, which are
+// already listed, and asks to go ahead with it.
+func (options *upgradeOptions) confirmUpgrade(in io.Reader, out io.Writer) bool {
+	from := options.fromVersion
+	if from == "" {
+		from = "an unknown version"
+	}
+	if options.canary {
+		fmt.Fprintf(out, "Rendering a canary of the control plane in the \"%s\" namespace, upgraded from %s to %s, in the \"%s\" namespace\n", controlPlaneNamespace, from, options.linkerdVersion, options.canaryNamespace)
+	} else {
+		fmt.Fprintf(out, "Upgrading the control plane in the \"%s\" namespace from %s to %s\n", controlPlaneNamespace, from, options.linkerdVersion)
+	}
+	if options.identityGenerated {
+		fmt.Fprintf(out, "%s The control plane has no identity: new trust anchors and issuer credentials will be generated, and meshed pods must be restarted to use them\n", warnStatus)
+	}
+	return confirm(in, out, "Continue?")
+}
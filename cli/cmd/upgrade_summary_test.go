@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+)
+
+func TestBuildFlagChanges(t *testing.T) {
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+
+	if err := flags.Parse([]string{"--proxy-log-level", "debug", "--ha"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	commandLine := changedFlagNames(flags)
+
+	recorded := []*pb.Install_Flag{
+		{Name: "proxy-log-level", Value: "warn"},
+		{Name: "ha", Value: "true"},
+		{Name: "controller-log-level", Value: "debug"},
+	}
+	helmFlags := []*pb.Install_Flag{
+		{Name: "controller-replicas", Value: "3"},
+	}
+	setFlagsFromInstall(flags, recorded)
+	setFlagsFromInstall(flags, helmFlags)
+
+	changes := buildFlagChanges(flags, recorded, commandLine, helmFlags)
+
+	warn, debug, yes := "warn", "debug", "true"
+	expected := map[string]*flagChange{
+		"proxy-log-level":      {Name: "proxy-log-level", Previous: &warn, Value: "debug", Source: flagSourceCommandLine, Changed: true},
+		"ha":                   {Name: "ha", Previous: &yes, Value: "true", Source: flagSourceCommandLine, Changed: false},
+		"controller-log-level": {Name: "controller-log-level", Previous: &debug, Value: "debug", Source: flagSourceCluster, Changed: false},
+		"controller-replicas":  {Name: "controller-replicas", Value: "3", Source: flagSourceHelmRelease, Changed: true},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d flags, got %d", len(expected), len(changes))
+	}
+	for _, change := range changes {
+		if !reflect.DeepEqual(change, expected[change.Name]) {
+			t.Errorf("Expected %+v, got %+v", expected[change.Name], change)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := printFlagChanges(&buf, changes, tableOutput); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedText := `Flags changed by the upgrade:
+  * controller-replicas: 3 (from the helm-release)
+  * proxy-log-level: warn -> debug (from the command-line)
+`
+	if buf.String() != expectedText {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expectedText, buf.String())
+	}
+
+	buf.Reset()
+	if err := printFlagChanges(&buf, changes, jsonOutput); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var decoded []*flagChange
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, changes) {
+		t.Errorf("Expected the json summary to list every flag, got:\n%s", buf.String())
+	}
+}
+
+func TestConfirmUpgrade(t *testing.T) {
+	testCases := []struct {
+		identityGenerated bool
+		answer            string
+		expected          bool
+		expectedOutput    string
+	}{
+		{false, "y\n", true, "from edge-19.4.1 to TEST-VERSION"},
+		{false, "\n", false, "Continue? [y/N]"},
+		{true, "yes\n", true, "new trust anchors and issuer credentials will be generated"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		options := testUpgradeOptions()
+		options.fromVersion = "edge-19.4.1"
+		options.identityGenerated = tc.identityGenerated
+
+		var buf bytes.Buffer
+		if confirmed := options.confirmUpgrade(strings.NewReader(tc.answer), &buf); confirmed != tc.expected {
+			t.Errorf("test %d: Expected confirmation to be %t", i, tc.expected)
+		}
+		if !strings.Contains(buf.String(), tc.expectedOutput) {
+			t.Errorf("test %d: Expected output to contain [%s], got [%s]", i, tc.expectedOutput, buf.String())
+		}
+		if !tc.identityGenerated && strings.Contains(buf.String(), "trust anchors") {
+			t.Errorf("test %d: Unexpected identity warning: %s", i, buf.String())
+		}
+	}
+}
@@ -2,16 +2,28 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -68,7 +80,7 @@ data:
 		{
 			[]string{},
 			"",
-			errors.New("could not fetch configs from kubernetes: configmaps \"linkerd-config\" not found"),
+			errors.New("could not fetch configs from kubernetes: configmaps \"linkerd-config\" not found; use --force to reconstruct them from the control plane's deployments"),
 		},
 	}
 
@@ -147,6 +159,9 @@ data:
 	if configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem() == "" {
 		t.Errorf("identity config not generated")
 	}
+	if !options.identityGenerated {
+		t.Errorf("identity generation not reported")
+	}
 	if configs.GetGlobal().GetAutoInjectContext() == nil {
 		t.Errorf("autoinject config not generated")
 	}
@@ -163,6 +178,91 @@ data:
 	}
 }
 
+func TestUpgradeKeepsInstallSliceFlags(t *testing.T) {
+	installOpts := testInstallOptions()
+	installFlags := installOpts.recordableFlagSet()
+	for name, value := range map[string]string{
+		"pod-metric-labels":            "version,app",
+		"injector-excluded-namespaces": "monitoring,logging",
+		"proxy-label":                  "team=books,tier=web",
+		"destination-get-suffixes":     "svc.cluster.local.,example.com.",
+	} {
+		if err := installFlags.Set(name, value); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	_, installConfigs, err := installOpts.validateAndBuild(installFlags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// Upgrade from a control plane without identity, so that the issuer
+	// isn't read from the cluster.
+	installConfigs.Global.IdentityContext = nil
+	installConfigs.Global.Version = "edge-19.4.1"
+	installConfigs.Install.CliVersion = "edge-19.4.1"
+	global, proxy, install, err := config.ToJSON(installConfigs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	clientset, _, err := k8s.NewFakeClientSets(fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    %s
+  proxy: |
+    %s
+  install: |
+    %s`, global, proxy, install))
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+	_, configs, err := options.validateAndBuild(clientset, flags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if expected := []string{"version", "app"}; !reflect.DeepEqual(options.podMetricLabels, expected) {
+		t.Errorf("Expected the pod metric labels %v, got %v", expected, options.podMetricLabels)
+	}
+	if expected := []string{"monitoring", "logging"}; !reflect.DeepEqual(options.injectorExcluded, expected) {
+		t.Errorf("Expected the excluded namespaces %v, got %v", expected, options.injectorExcluded)
+	}
+	if expected := []string{"team=books", "tier=web"}; !reflect.DeepEqual(options.proxyLabels, expected) {
+		t.Errorf("Expected the proxy labels %v, got %v", expected, options.proxyLabels)
+	}
+	if expected := []string{"svc.cluster.local.", "example.com."}; !reflect.DeepEqual(options.destinationGetSuffixes, expected) {
+		t.Errorf("Expected the destination suffixes %v, got %v", expected, options.destinationGetSuffixes)
+	}
+
+	// The flags are recorded again as they were by the install.
+	recorded := map[string]string{}
+	for _, f := range configs.GetInstall().GetFlags() {
+		recorded[f.GetName()] = f.GetValue()
+	}
+	if recorded["pod-metric-labels"] != "version,app" || recorded["proxy-label"] != "team=books,tier=web" {
+		t.Errorf("Expected the slice flags to be recorded comma-separated, got %v", recorded)
+	}
+}
+
+func TestUpgradeReplaysBracketedSliceFlags(t *testing.T) {
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+	setFlagsFromInstall(flags, []*pb.Install_Flag{{Name: "pod-metric-labels", Value: "[version,app]"}})
+
+	if expected := []string{"version", "app"}; !reflect.DeepEqual(options.podMetricLabels, expected) {
+		t.Errorf("Expected the pod metric labels %v, got %v", expected, options.podMetricLabels)
+	}
+}
+
 func TestFetchConfigs(t *testing.T) {
 	options := testInstallOptions()
 	_, exp, err := options.validateAndBuild(nil)
@@ -250,3 +350,432 @@ data:
 		})
 	}
 }
+
+func TestFetchConfigsFromManifests(t *testing.T) {
+	manifests := readTestdata(t, "install_default.golden")
+
+	// The install split in files, some of them in a subdirectory, like in Git
+	// repositories.
+	dir, err := ioutil.TempDir("", "linkerd-manifests")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "control-plane"), 0755); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for i, doc := range strings.Split(manifests, "\n---\n") {
+		path := filepath.Join(dir, fmt.Sprintf("%02d.yaml", i))
+		if i%2 == 1 {
+			path = filepath.Join(dir, "control-plane", fmt.Sprintf("%02d.yaml", i))
+		}
+		if err := ioutil.WriteFile(path, []byte(doc), 0644); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(manifests))
+	}))
+	defer server.Close()
+
+	for _, path := range []string{
+		filepath.Join("testdata", "install_default.golden"),
+		dir,
+		server.URL + "/install.yaml",
+	} {
+		path := path // pin
+		t.Run(path, func(t *testing.T) {
+			options := newUpgradeOptionsWithDefaults()
+			options.manifests = path
+
+			clientset, err := options.newFakeClientSetFromManifests()
+			if err != nil {
+				t.Fatalf("Unexpected error reading manifests: %s", err)
+			}
+
+			configs, err := fetchConfigs(clientset)
+			if err != nil {
+				t.Fatalf("Unexpected error fetching configs: %s", err)
+			}
+
+			if configs.GetGlobal().GetLinkerdNamespace() != "linkerd" {
+				t.Errorf("Expected linkerd namespace \"linkerd\", got \"%s\"", configs.GetGlobal().GetLinkerdNamespace())
+			}
+
+			// The live resources are read from the same documents.
+			live, err := options.newFakeDynamicClientFromManifests()
+			if err != nil {
+				t.Fatalf("Unexpected error reading manifests: %s", err)
+			}
+			if _, err := live.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("linkerd").Get(k8s.ConfigConfigMapName, metav1.GetOptions{}); err != nil {
+				t.Errorf("Unexpected error fetching the live config: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpgradeNamespaceMismatch(t *testing.T) {
+	k8sConfigs := []string{`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd-old","cniEnabled":false,"version":"edge-19.4.1"}
+`,
+	}
+
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+
+	clientset, _, err := k8s.NewFakeClientSets(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	expected := `the control plane in the "linkerd" namespace is configured for the "linkerd-old" namespace; re-install it to move it`
+	if _, _, err := options.validateAndBuild(clientset, flags); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestUpgradeRecordsMissingNamespace(t *testing.T) {
+	k8sConfigs := []string{`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"cniEnabled":false,"version":"edge-19.4.1"}
+  proxy: |
+    {"proxyImage":{"imageName":"gcr.io/linkerd-io/proxy","pullPolicy":"IfNotPresent"},"proxyInitImage":{"imageName":"gcr.io/linkerd-io/proxy-init","pullPolicy":"IfNotPresent"},"controlPort":{"port":4190},"inboundPort":{"port":4143},"adminPort":{"port":4191},"outboundPort":{"port":4140},"proxyUid":"2102","logLevel":{"level":"warn,linkerd2_proxy=info"}}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[]}
+`,
+	}
+
+	options := testUpgradeOptions()
+	flags := options.recordableFlagSet()
+
+	clientset, _, err := k8s.NewFakeClientSets(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	_, configs, err := options.validateAndBuild(clientset, flags)
+	if err != nil {
+		t.Fatalf("validateAndBuild failed with %s", err)
+	}
+	if ns := configs.GetGlobal().GetLinkerdNamespace(); ns != "linkerd" {
+		t.Fatalf("Expected the namespace \"linkerd\" to be recorded, got \"%s\"", ns)
+	}
+}
+
+func TestUpgradeVersionSkew(t *testing.T) {
+	k8sConfigs := []string{`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","cniEnabled":false,"version":"stable-2.2.1","identityContext":null,"autoInjectContext":null}
+  proxy: |
+    {"proxyImage":{"imageName":"gcr.io/linkerd-io/proxy","pullPolicy":"IfNotPresent"},"proxyInitImage":{"imageName":"gcr.io/linkerd-io/proxy-init","pullPolicy":"IfNotPresent"},"controlPort":{"port":4190},"ignoreInboundPorts":[],"ignoreOutboundPorts":[],"inboundPort":{"port":4143},"adminPort":{"port":4191},"outboundPort":{"port":4140},"resource":{"requestCpu":"","requestMemory":"","limitCpu":"","limitMemory":""},"proxyUid":"2102","logLevel":{"level":"warn,linkerd2_proxy=info"},"disableExternalProfiles":true}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"stable-2.2.1","flags":[]}
+`,
+	}
+
+	clientset, _, err := k8s.NewFakeClientSets(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Error mocking k8s client: %s", err)
+	}
+
+	options := testUpgradeOptions()
+	options.linkerdVersion = "stable-2.5.0"
+	expected := "upgrading from stable-2.2.1 to stable-2.5.0 would skip minor versions; upgrade to stable-2.3.x, then stable-2.4.x first, or use --force to upgrade anyway"
+	if _, _, err := options.validateAndBuild(clientset, options.recordableFlagSet()); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	options = testUpgradeOptions()
+	options.linkerdVersion = "stable-2.5.0"
+	options.force = true
+	if _, _, err := options.validateAndBuild(clientset, options.recordableFlagSet()); err != nil {
+		t.Fatalf("Expected --force to allow skipping minor versions, got %s", err)
+	}
+}
+
+func TestRunPreUpgradeChecks(t *testing.T) {
+	hc := healthcheck.NewHealthChecker([]healthcheck.CategoryID{}, &healthcheck.Options{})
+	hc.Add(healthcheck.LinkerdPreUpgradeChecks, "control plane pods are ready", "pre-upgrade-control-plane", func(context.Context) error {
+		return nil
+	})
+	hc.Add(healthcheck.LinkerdPreUpgradeChecks, "issuer credentials are valid", "pre-upgrade-issuer", func(context.Context) error {
+		return errors.New("x509: certificate has expired or is not yet valid")
+	})
+
+	var buf bytes.Buffer
+	if runPreUpgradeChecks(&buf, hc) {
+		t.Fatalf("Expected the checks to fail")
+	}
+
+	expected := fmt.Sprintf("%s issuer credentials are valid: x509: certificate has expired or is not yet valid\n    see https://linkerd.io/checks/#pre-upgrade-issuer for hints\n", failStatus)
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestUpgradeRotateIssuer(t *testing.T) {
+	trustPEM, err := ioutil.ReadFile(filepath.Join("testdata", "trust-anchors.pem"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	crtPEM, err := ioutil.ReadFile(filepath.Join("testdata", "crt.pem"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	keyPEM, err := ioutil.ReadFile(filepath.Join("testdata", "key.pem"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	newConfigs := func(trustAnchorsPEM string) string {
+		idctx, err := json.Marshal(map[string]string{
+			"trustDomain":        "cluster.local",
+			"trustAnchorsPem":    trustAnchorsPEM,
+			"issuanceLifetime":   "86400s",
+			"clockSkewAllowance": "20s",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","version":"edge-19.4.1","identityContext":%s}
+  proxy: |
+    {"proxyImage":{"imageName":"gcr.io/linkerd-io/proxy","pullPolicy":"IfNotPresent"},"proxyInitImage":{"imageName":"gcr.io/linkerd-io/proxy-init","pullPolicy":"IfNotPresent"},"controlPort":{"port":4190},"inboundPort":{"port":4143},"adminPort":{"port":4191},"outboundPort":{"port":4140},"resource":{},"proxyUid":"2102","logLevel":{"level":"warn,linkerd2_proxy=info"}}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[]}`, idctx)
+	}
+	otherRoot, err := tls.GenerateRootCAWithDefaults("cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	issuerSecret := fmt.Sprintf(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  crt.pem: %s
+  key.pem: %s`, base64.StdEncoding.EncodeToString(crtPEM), base64.StdEncoding.EncodeToString(keyPEM))
+
+	testCases := []struct {
+		name        string
+		trustPEM    string
+		keyPEMFile  string
+		expectedErr string
+		expectedCrt string
+	}{
+		{
+			name:        "replaces the issuer",
+			trustPEM:    string(trustPEM),
+			keyPEMFile:  filepath.Join("testdata", "key.pem"),
+			expectedCrt: string(crtPEM),
+		},
+		{
+			name:        "requires both files",
+			trustPEM:    string(trustPEM),
+			expectedErr: "must be set together",
+		},
+		{
+			name:        "requires the existing trust anchors",
+			trustPEM:    otherRoot.Cred.Crt.EncodeCertificatePEM(),
+			keyPEMFile:  filepath.Join("testdata", "key.pem"),
+			expectedErr: "signed by the control plane's trust anchors",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			clientset, _, err := k8s.NewFakeClientSets(newConfigs(tc.trustPEM), issuerSecret)
+			if err != nil {
+				t.Fatalf("Error mocking k8s client: %s", err)
+			}
+
+			options := testUpgradeOptions()
+			options.identityOptions.crtPEMFile = filepath.Join("testdata", "crt.pem")
+			options.identityOptions.keyPEMFile = tc.keyPEMFile
+			flags := options.recordableFlagSet()
+
+			values, configs, err := options.validateAndBuild(clientset, flags)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected error containing \"%s\", got \"%v\"", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if values.Identity.Issuer.CrtPEM != tc.expectedCrt {
+				t.Errorf("Expected the new issuer certificate, got:\n%s", values.Identity.Issuer.CrtPEM)
+			}
+			if values.Identity.TrustAnchorsPEM != tc.trustPEM {
+				t.Errorf("Expected the trust anchors to be unchanged, got:\n%s", values.Identity.TrustAnchorsPEM)
+			}
+			if configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem() != tc.trustPEM {
+				t.Errorf("Expected the identity context to be unchanged")
+			}
+		})
+	}
+}
+
+func TestUpgradeCanary(t *testing.T) {
+	root, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	issuer, err := root.GenerateCA("identity.linkerd-canary.cluster.local", root.Validity, -1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "linkerd-canary")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	crtPEMFile := filepath.Join(dir, "issuer.crt")
+	keyPEMFile := filepath.Join(dir, "issuer.key")
+	if err := ioutil.WriteFile(crtPEMFile, []byte(issuer.Cred.Crt.EncodeCertificatePEM()), 0600); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := ioutil.WriteFile(keyPEMFile, []byte(issuer.Cred.EncodePrivateKeyPEM()), 0600); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	idctx, err := json.Marshal(map[string]string{
+		"trustDomain":        "cluster.local",
+		"trustAnchorsPem":    root.Cred.Crt.EncodeCertificatePEM(),
+		"issuanceLifetime":   "86400s",
+		"clockSkewAllowance": "20s",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	configs := fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","version":"edge-19.4.1","identityContext":%s}
+  proxy: |
+    {"proxyImage":{"imageName":"gcr.io/linkerd-io/proxy","pullPolicy":"IfNotPresent"},"proxyInitImage":{"imageName":"gcr.io/linkerd-io/proxy-init","pullPolicy":"IfNotPresent"},"controlPort":{"port":4190},"inboundPort":{"port":4143},"adminPort":{"port":4191},"outboundPort":{"port":4140},"resource":{},"proxyUid":"2102","logLevel":{"level":"warn,linkerd2_proxy=info"}}
+  install: |
+    {"uuid":"57af298c-58b0-43fc-8d88-3c338789bfbc","cliVersion":"edge-19.4.1","flags":[]}`, idctx)
+
+	testCases := []struct {
+		name        string
+		crtPEMFile  string
+		keyPEMFile  string
+		expectedErr string
+	}{
+		{
+			name:       "renders the canary",
+			crtPEMFile: crtPEMFile,
+			keyPEMFile: keyPEMFile,
+		},
+		{
+			name:        "requires issuer credentials",
+			expectedErr: "a canary needs issuer credentials for identity.linkerd-canary.cluster.local",
+		},
+		{
+			name:        "requires an issuer for the canary",
+			crtPEMFile:  filepath.Join("testdata", "crt.pem"),
+			keyPEMFile:  filepath.Join("testdata", "key.pem"),
+			expectedErr: "must be valid for identity.linkerd-canary.cluster.local",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			clientset, _, err := k8s.NewFakeClientSets(configs)
+			if err != nil {
+				t.Fatalf("Error mocking k8s client: %s", err)
+			}
+
+			options := testUpgradeOptions()
+			options.canary = true
+			options.setCanaryNamespace()
+			options.identityOptions.crtPEMFile = tc.crtPEMFile
+			options.identityOptions.keyPEMFile = tc.keyPEMFile
+			flags := options.recordableFlagSet()
+
+			values, configs, err := options.validateAndBuild(clientset, flags)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("Expected error containing \"%s\", got \"%v\"", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if values.Namespace != "linkerd-canary" || configs.GetGlobal().GetLinkerdNamespace() != "linkerd-canary" {
+				t.Errorf("Expected the canary to be rendered in the linkerd-canary namespace, got %s and %s", values.Namespace, configs.GetGlobal().GetLinkerdNamespace())
+			}
+			if !values.ScopedWebhooks {
+				t.Error("Expected the webhooks of the canary to be scoped")
+			}
+			if values.PreviousConfigs != nil {
+				t.Error("Expected no previous configuration for the canary")
+			}
+			if values.Identity.TrustAnchorsPEM != root.Cred.Crt.EncodeCertificatePEM() {
+				t.Error("Expected the trust anchors to be shared with the canary")
+			}
+			if values.IdentityPeerNamespaces != "linkerd" {
+				t.Errorf("Expected the canary to give identities to the proxies of the linkerd namespace, got %q", values.IdentityPeerNamespaces)
+			}
+		})
+	}
+}
+
+func TestUpgradeOutputFlags(t *testing.T) {
+	cmd := newCmdUpgrade()
+	writesConfigs := map[string]bool{
+		"upgrade":       true,
+		"config":        true,
+		"control-plane": true,
+	}
+
+	for _, c := range append(cmd.Commands(), cmd) {
+		for _, name := range []string{"output", "output-dir"} {
+			if hasFlag := c.Flag(name) != nil; hasFlag != writesConfigs[c.Name()] {
+				t.Errorf("Expected %s to have the --%s flag: %t", c.Name(), name, writesConfigs[c.Name()])
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// verifyStep is a condition of a rolled out upgrade. It returns what the
+// upgrade is still waiting for, or an empty string once the condition holds.
+type verifyStep struct {
+	description string
+	check       func(k kubernetes.Interface, latest historyRecord) (string, error)
+}
+
+var verifySteps = []verifyStep{
+	{"the configuration of the upgrade is applied", verifyConfigDigest},
+	{"the control plane deployments run the new version", verifyDeployments},
+	{"the identity and destination services only route to the new version", verifyControlPlaneEndpoints},
+	{"the proxies are ready and only connected to the new version", verifyProxies},
+}
+
+func newCmdUpgradeVerify() *cobra.Command {
+	wait := 300 * time.Second
+
+	cmd := &cobra.Command{
+		Use:   "verify [flags]",
+		Short: "Wait for an applied upgrade to roll out to the control plane and the proxies",
+		Long: `Wait for an applied upgrade to roll out to the control plane and the proxies.
+
+The last record of the upgrade history tells the version and the configuration
+the control plane was upgraded to. The upgrade is verified once:
+
+  * the linkerd-config ConfigMap holds the configuration of the record;
+  * the control plane deployments run the pods of the new version, and all of
+    them are available;
+  * the identity and destination services only route to pods of the new
+    version, so that the proxies reconnect to them;
+  * the proxies of the meshed pods are ready, which they only are once they
+    hold a certificate from the identity service, and the pods of the previous
+    versions of the identity and destination services are gone, so that the
+    proxies can only be connected to the new version.`,
+		Example: `  linkerd upgrade | kubectl apply -f -
+  linkerd upgrade verify
+
+  # Fail if the upgrade doesn't roll out within 10 minutes.
+  linkerd upgrade verify --wait 10m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := newKubernetesClient()
+			if err != nil {
+				return err
+			}
+
+			return verifyUpgrade(k, time.Now().Add(wait), 5*time.Second, stdout)
+		},
+	}
+
+	cmd.Flags().DurationVar(&wait, "wait", wait, "Maximum allowed time for the upgrade to roll out")
+
+	return cmd
+}
+
+// verifyUpgrade polls the conditions of the last upgrade of the control plane,
+// one after the other, until they all hold or until the deadline passes.
+func verifyUpgrade(k kubernetes.Interface, deadline time.Time, interval time.Duration, out io.Writer) error {
+	history, err := fetchHistory(k)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the upgrade history: %s", err)
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("no upgrade history was recorded for the control plane in the \"%s\" namespace", controlPlaneNamespace)
+	}
+	latest := history[len(history)-1]
+	fmt.Fprintf(out, "Verifying revision %d (%s, version %s)\n", latest.Revision, latest.Command, latest.CliVersion)
+
+	for _, step := range verifySteps {
+		reported := ""
+		for {
+			pending, err := step.check(k, latest)
+			if err != nil {
+				return err
+			}
+			if pending == "" {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				fmt.Fprintf(out, "%s %s\n", failStatus, step.description)
+				return fmt.Errorf("timed out waiting for %s", pending)
+			}
+			if pending != reported {
+				fmt.Fprintf(out, "waiting for %s\n", pending)
+				reported = pending
+			}
+			time.Sleep(interval)
+		}
+		fmt.Fprintf(out, "%s %s\n", okStatus, step.description)
+	}
+
+	fmt.Fprintf(out, "\nThe control plane in the \"%s\" namespace is upgraded to %s\n", controlPlaneNamespace, latest.CliVersion)
+	return nil
+}
+
+// verifyConfigDigest compares the configuration of the linkerd-config ConfigMap
+// to the digest recorded by the upgrade.
+func verifyConfigDigest(k kubernetes.Interface, latest historyRecord) (string, error) {
+	cm, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	// The values of the ConfigMap end with the newline of their YAML block.
+	config := ""
+	for _, key := range []string{"global", "proxy", "install"} {
+		config += strings.TrimSuffix(cm.Data[key], "\n")
+	}
+	if digest := fmt.Sprintf("%x", sha256.Sum256([]byte(config))); digest != latest.ConfigDigest {
+		return fmt.Sprintf("the %s ConfigMap to hold the configuration of revision %d", k8s.ConfigConfigMapName, latest.Revision), nil
+	}
+	return "", nil
+}
+
+// verifyDeployments checks that the deployments of the control plane rolled
+// out the pod template rendered by the upgrade.
+func verifyDeployments(k kubernetes.Interface, latest historyRecord) (string, error) {
+	deploys, err := k.AppsV1().Deployments(controlPlaneNamespace).List(metav1.ListOptions{LabelSelector: k8s.ControllerComponentLabel})
+	if err != nil {
+		return "", err
+	}
+	if len(deploys.Items) == 0 {
+		return "", fmt.Errorf("no control plane deployments were found in the \"%s\" namespace", controlPlaneNamespace)
+	}
+	sort.Slice(deploys.Items, func(i, j int) bool { return deploys.Items[i].Name < deploys.Items[j].Name })
+
+	createdBy := createdByVersion(latest.CliVersion)
+	for _, deploy := range deploys.Items {
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+
+		switch {
+		case deploy.Spec.Template.Annotations[k8s.CreatedByAnnotation] != createdBy:
+			return fmt.Sprintf("deployment/%s to be upgraded to %s", deploy.Name, latest.CliVersion), nil
+		case deploy.Status.ObservedGeneration < deploy.Generation,
+			deploy.Status.UpdatedReplicas < replicas,
+			deploy.Status.Replicas > deploy.Status.UpdatedReplicas,
+			deploy.Status.AvailableReplicas < replicas:
+			return fmt.Sprintf("deployment/%s to roll out (%d/%d replicas updated and available)", deploy.Name, deploy.Status.AvailableReplicas, replicas), nil
+		}
+	}
+	return "", nil
+}
+
+// verifyControlPlaneEndpoints checks that the identity and destination services
+// only have ready endpoints of the new version. Proxies reconnect to these
+// endpoints once the connections to the previous pods are closed.
+func verifyControlPlaneEndpoints(k kubernetes.Interface, latest historyRecord) (string, error) {
+	createdBy := createdByVersion(latest.CliVersion)
+	for _, name := range []string{"linkerd-identity", "linkerd-destination"} {
+		endpoints, err := k.CoreV1().Endpoints(controlPlaneNamespace).Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return fmt.Sprintf("service/%s to have endpoints", name), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		ready := 0
+		for _, subset := range endpoints.Subsets {
+			if len(subset.NotReadyAddresses) > 0 {
+				return fmt.Sprintf("service/%s to have no unready endpoints", name), nil
+			}
+			for _, address := range subset.Addresses {
+				if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+					continue
+				}
+				pod, err := k.CoreV1().Pods(controlPlaneNamespace).Get(address.TargetRef.Name, metav1.GetOptions{})
+				if kerrors.IsNotFound(err) {
+					return fmt.Sprintf("service/%s to stop routing to the deleted pod %s", name, address.TargetRef.Name), nil
+				}
+				if err != nil {
+					return "", err
+				}
+				if pod.Annotations[k8s.CreatedByAnnotation] != createdBy {
+					return fmt.Sprintf("service/%s to stop routing to pod %s, of a previous version", name, pod.Name), nil
+				}
+				ready++
+			}
+		}
+		if ready == 0 {
+			return fmt.Sprintf("service/%s to have ready endpoints", name), nil
+		}
+	}
+	return "", nil
+}
+
+// verifyProxies checks that the proxies of the running pods meshed by the
+// control plane are ready, and that they can only be connected to the new
+// version of the control plane: the connections of the proxies to the pods of
+// the previous versions of the identity and destination services are only
+// closed once these pods exit, terminating ones included, after which the
+// proxies reconnect to the pods the services route to.
+func verifyProxies(k kubernetes.Interface, latest historyRecord) (string, error) {
+	controlPlanePods, err := k.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s in (controller, identity)", k8s.ControllerComponentLabel),
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(controlPlanePods.Items, func(i, j int) bool { return controlPlanePods.Items[i].Name < controlPlanePods.Items[j].Name })
+
+	createdBy := createdByVersion(latest.CliVersion)
+	for _, pod := range controlPlanePods.Items {
+		if pod.Annotations[k8s.CreatedByAnnotation] != createdBy {
+			return fmt.Sprintf("pod %s, of a previous version, to exit and close its connections to the proxies", pod.Name), nil
+		}
+	}
+
+	pods, err := k.CoreV1().Pods(corev1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unready := []string{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == k8s.ProxyContainerName && !status.Ready {
+				unready = append(unready, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			}
+		}
+	}
+	if len(unready) > 0 {
+		sort.Strings(unready)
+		return fmt.Sprintf("the proxies of %s to be ready", strings.Join(unready, ", ")), nil
+	}
+	return "", nil
+}
+
+// createdByVersion returns the value of the CreatedByAnnotation of the
+// resources rendered by the given version of the CLI.
+func createdByVersion(version string) string {
+	return fmt.Sprintf("linkerd/cli %s", version)
+}
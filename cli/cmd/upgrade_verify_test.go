@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func verifyTestObjects(t *testing.T, version, deployedVersion string, proxyReady bool) []runtime.Object {
+	config := map[string]string{
+		"global":  `{"linkerdNamespace":"linkerd"}` + "\n",
+		"proxy":   `{"proxyUid":"2102"}` + "\n",
+		"install": `{"cliVersion":"` + version + `"}` + "\n",
+	}
+	digest := sha256.Sum256([]byte(`{"linkerdNamespace":"linkerd"}{"proxyUid":"2102"}{"cliVersion":"` + version + `"}`))
+	history, err := json.Marshal([]historyRecord{
+		{Revision: 1, Command: "install", CliVersion: "stable-2.5.0"},
+		{Revision: 2, Command: "upgrade", CliVersion: version, ConfigDigest: fmt.Sprintf("%x", digest)},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	replicas := int32(1)
+	createdBy := map[string]string{k8s.CreatedByAnnotation: createdByVersion(deployedVersion)}
+	objs := []runtime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: k8s.ConfigConfigMapName, Namespace: "linkerd"},
+			Data:       config,
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: k8s.ConfigHistoryConfigMapName, Namespace: "linkerd"},
+			Data:       map[string]string{"history": string(history)},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: "emojivoto",
+				Labels:    map[string]string{k8s.ControllerNSLabel: "linkerd"},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: k8s.ProxyContainerName, Ready: proxyReady}},
+			},
+		},
+	}
+
+	for _, component := range []string{"controller", "identity"} {
+		name := "linkerd-" + component
+		objs = append(objs,
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  "linkerd",
+					Labels:     map[string]string{k8s.ControllerComponentLabel: component},
+					Generation: 2,
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: createdBy}},
+				},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name + "-1",
+					Namespace:   "linkerd",
+					Labels:      map[string]string{k8s.ControllerComponentLabel: component},
+					Annotations: createdBy,
+				},
+			},
+		)
+	}
+
+	for service, component := range map[string]string{"linkerd-destination": "controller", "linkerd-identity": "identity"} {
+		objs = append(objs, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: service, Namespace: "linkerd"},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{
+					IP:        "10.0.0.1",
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "linkerd-" + component + "-1"},
+				}},
+			}},
+		})
+	}
+
+	return objs
+}
+
+func TestVerifyUpgrade(t *testing.T) {
+	controlPlaneNamespace = "linkerd"
+
+	t.Run("verifies a rolled out upgrade", func(t *testing.T) {
+		client := fake.NewSimpleClientset(verifyTestObjects(t, "stable-2.6.0", "stable-2.6.0", true)...)
+
+		var out bytes.Buffer
+		if err := verifyUpgrade(client, time.Now(), 0, &out); err != nil {
+			t.Fatalf("Unexpected error: %s\n%s", err, out.String())
+		}
+		for _, step := range verifySteps {
+			if !strings.Contains(out.String(), fmt.Sprintf("%s %s\n", okStatus, step.description)) {
+				t.Errorf("Expected the output to verify that %s, got:\n%s", step.description, out.String())
+			}
+		}
+	})
+
+	testCases := []struct {
+		desc            string
+		deployedVersion string
+		proxyReady      bool
+		configVersion   string
+		expected        string
+	}{
+		{
+			desc:            "waits for the configuration",
+			deployedVersion: "stable-2.6.0",
+			proxyReady:      true,
+			configVersion:   "stable-2.5.0",
+			expected:        "timed out waiting for the linkerd-config ConfigMap to hold the configuration of revision 2",
+		},
+		{
+			desc:            "waits for the deployments",
+			deployedVersion: "stable-2.5.0",
+			proxyReady:      true,
+			expected:        "timed out waiting for deployment/linkerd-controller to be upgraded to stable-2.6.0",
+		},
+		{
+			desc:            "waits for the proxies",
+			deployedVersion: "stable-2.6.0",
+			proxyReady:      false,
+			expected:        "timed out waiting for the proxies of emojivoto/web to be ready",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			objs := verifyTestObjects(t, "stable-2.6.0", tc.deployedVersion, tc.proxyReady)
+			if tc.configVersion != "" {
+				objs[0].(*corev1.ConfigMap).Data["install"] = `{"cliVersion":"` + tc.configVersion + `"}` + "\n"
+			}
+			client := fake.NewSimpleClientset(objs...)
+
+			var out bytes.Buffer
+			err := verifyUpgrade(client, time.Now(), 0, &out)
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error \"%s\", got \"%v\"", tc.expected, err)
+			}
+		})
+	}
+
+	t.Run("waits for the services to stop routing to previous versions", func(t *testing.T) {
+		objs := verifyTestObjects(t, "stable-2.6.0", "stable-2.6.0", true)
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "linkerd-identity-0",
+				Namespace:   "linkerd",
+				Annotations: map[string]string{k8s.CreatedByAnnotation: createdByVersion("stable-2.5.0")},
+			},
+		})
+		for _, obj := range objs {
+			if endpoints, ok := obj.(*corev1.Endpoints); ok && endpoints.Name == "linkerd-identity" {
+				endpoints.Subsets[0].Addresses = append(endpoints.Subsets[0].Addresses, corev1.EndpointAddress{
+					IP:        "10.0.0.2",
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "linkerd-identity-0"},
+				})
+			}
+		}
+		client := fake.NewSimpleClientset(objs...)
+
+		var out bytes.Buffer
+		err := verifyUpgrade(client, time.Now(), 0, &out)
+		expected := "timed out waiting for service/linkerd-identity to stop routing to pod linkerd-identity-0, of a previous version"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error \"%s\", got \"%v\"", expected, err)
+		}
+	})
+
+	t.Run("waits for the pods of previous versions to exit", func(t *testing.T) {
+		objs := verifyTestObjects(t, "stable-2.6.0", "stable-2.6.0", true)
+		now := metav1.Now()
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "linkerd-controller-0",
+				Namespace:         "linkerd",
+				Labels:            map[string]string{k8s.ControllerComponentLabel: "controller"},
+				Annotations:       map[string]string{k8s.CreatedByAnnotation: createdByVersion("stable-2.5.0")},
+				DeletionTimestamp: &now,
+			},
+		})
+		client := fake.NewSimpleClientset(objs...)
+
+		var out bytes.Buffer
+		err := verifyUpgrade(client, time.Now(), 0, &out)
+		expected := "timed out waiting for pod linkerd-controller-0, of a previous version, to exit and close its connections to the proxies"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error \"%s\", got \"%v\"", expected, err)
+		}
+	})
+
+	t.Run("requires the upgrade history", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+
+		var out bytes.Buffer
+		err := verifyUpgrade(client, time.Now(), 0, &out)
+		expected := "no upgrade history was recorded for the control plane in the \"linkerd\" namespace"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error \"%s\", got \"%v\"", expected, err)
+		}
+	})
+}
@@ -12,7 +12,7 @@ import (
 // Templates that will be rendered by `linkerd install`. This is only used on
 // dev builds so we can assume GOPATH is set properly (either explicitly through
 // an env var, or defaulting to $HOME/go)
-var Templates http.FileSystem = http.Dir(path.Join(getRepoRoot(), "chart"))
+var Templates http.FileSystem = http.Dir(path.Join(getRepoRoot(), "charts", "linkerd"))
 
 // getRepoRoot returns the full path to the root of the repo. We assume this
 // function is only called from the `Templates` var above, and that this source
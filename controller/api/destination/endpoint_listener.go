@@ -94,28 +94,39 @@ func diffUpdateAddresses(oldAddrs, newAddrs []*updateAddress) ([]*updateAddress,
 
 // implements the endpointUpdateListener interface
 type endpointListener struct {
-	controllerNS,
+	// The namespaces of the control planes whose proxies participate in
+	// identity with the proxies of this one, as they share its trust anchors.
+	identityNS          map[string]bool
 	identityTrustDomain string
-	stream           pb.Destination_GetServer
-	ownerKindAndName ownerKindAndNameFn
-	labels           map[string]string
-	enableH2Upgrade  bool
-	stopCh           chan struct{}
-	log              *log.Entry
+	stream              pb.Destination_GetServer
+	ownerKindAndName    ownerKindAndNameFn
+	labels              map[string]string
+	podMetricLabels     []string
+	enableH2Upgrade     bool
+	stopCh              chan struct{}
+	log                 *log.Entry
 }
 
 func newEndpointListener(
 	stream pb.Destination_GetServer,
 	ownerKindAndName ownerKindAndNameFn,
 	enableH2Upgrade bool,
-	controllerNS, identityTrustDomain string,
+	identityNS []string,
+	identityTrustDomain string,
+	podMetricLabels []string,
 ) *endpointListener {
+	namespaces := make(map[string]bool)
+	for _, ns := range identityNS {
+		namespaces[ns] = true
+	}
+
 	return &endpointListener{
-		controllerNS:        controllerNS,
+		identityNS:          namespaces,
 		identityTrustDomain: identityTrustDomain,
 		stream:              stream,
 		ownerKindAndName:    ownerKindAndName,
 		labels:              make(map[string]string),
+		podMetricLabels:     podMetricLabels,
 		enableH2Upgrade:     enableH2Upgrade,
 		stopCh:              make(chan struct{}),
 		log: log.WithFields(log.Fields{
@@ -221,6 +232,13 @@ func (l *endpointListener) getAddrMetadata(pod *corev1.Pod) (map[string]string,
 	ok, on := l.ownerKindAndName(pod)
 	labels := pkgK8s.GetPodLabels(ok, on, pod)
 
+	// The labels selected by the allowlist don't replace the owner labels.
+	for name, value := range pkgK8s.GetPodMetricLabels(pod, l.podMetricLabels) {
+		if _, ok := labels[name]; !ok {
+			labels[name] = value
+		}
+	}
+
 	// If the pod is controlled by any Linkerd control plane, then it can be hinted
 	// that this destination knows H2 (and handles our orig-proto translation).
 	var hint *pb.ProtocolHint
@@ -232,14 +250,15 @@ func (l *endpointListener) getAddrMetadata(pod *corev1.Pod) (map[string]string,
 		}
 	}
 
-	// If the pod is controlled by the same Linkerd control plane, then it can
-	// participate in identity with peers.
+	// If the pod is controlled by the same Linkerd control plane, or by one
+	// sharing its trust anchors, e.g. a canary, then it can participate in
+	// identity with peers.
 	//
 	// TODO this should be relaxed to match a trust domain annotation so that
 	// multiple meshes can participate in identity if they share trust roots.
 	var identity *pb.TlsIdentity
 	if l.identityTrustDomain != "" &&
-		controllerNS == l.controllerNS &&
+		l.identityNS[controllerNS] &&
 		pod.Annotations[pkgK8s.IdentityModeAnnotation] == pkgK8s.IdentityModeDefault {
 
 		id := fmt.Sprintf("%s.%s.serviceaccount.identity.%s.%s", sa, ns, controllerNS, l.identityTrustDomain)
@@ -70,7 +70,7 @@ func TestEndpointListener(t *testing.T) {
 		listener := newEndpointListener(
 			mockGetServer,
 			defaultOwnerKindAndName,
-			false, "linkerd", "",
+			false, []string{"linkerd"}, "", nil,
 		)
 
 		listener.Update(add, remove)
@@ -87,7 +87,7 @@ func TestEndpointListener(t *testing.T) {
 		listener := newEndpointListener(
 			mockGetServer,
 			defaultOwnerKindAndName,
-			false, "linkerd", "",
+			false, []string{"linkerd"}, "", nil,
 		)
 
 		listener.Update(add, remove)
@@ -127,7 +127,7 @@ func TestEndpointListener(t *testing.T) {
 		listener := newEndpointListener(
 			mockGetServer,
 			defaultOwnerKindAndName,
-			false, "linkerd", "",
+			false, []string{"linkerd"}, "", nil,
 		)
 
 		completed := make(chan bool)
@@ -173,7 +173,7 @@ func TestEndpointListener(t *testing.T) {
 		listener := newEndpointListener(
 			mockGetServer,
 			ownerKindAndName,
-			false, "linkerd", "",
+			false, []string{"linkerd"}, "", nil,
 		)
 		listener.labels = map[string]string{
 			"service":   expectedServiceName,
@@ -202,6 +202,53 @@ func TestEndpointListener(t *testing.T) {
 		}
 	})
 
+	t.Run("Sends the pod labels of the allowlist as metric labels", func(t *testing.T) {
+		podForAddedAddress1 := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod1.Name,
+				Namespace: thisNS,
+				Labels: map[string]string{
+					"version":                   "v2",
+					"app.kubernetes.io/version": "2.0.0",
+					"pod":                       "not-the-pod-name",
+					"team":                      "web",
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "this-serviceaccount",
+			},
+		}
+
+		ownerKindAndName := func(pod *corev1.Pod) (string, string) {
+			return deploymentKind, podDeployment
+		}
+
+		mockGetServer := &mockDestinationGetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(
+			mockGetServer,
+			ownerKindAndName,
+			false, []string{"linkerd"}, "",
+			[]string{"version", "app.kubernetes.io/version", "pod"},
+		)
+
+		add := []*updateAddress{
+			{address: addedAddress1, pod: podForAddedAddress1},
+		}
+		listener.Update(add, nil)
+
+		actualMetricLabels := mockGetServer.updatesReceived[0].GetAdd().Addrs[0].MetricLabels
+		expectedMetricLabels := map[string]string{
+			"pod":                       pod1.Name,
+			"deployment":                podDeployment,
+			"serviceaccount":            "this-serviceaccount",
+			"version":                   "v2",
+			"app_kubernetes_io_version": "2.0.0",
+		}
+		if !reflect.DeepEqual(actualMetricLabels, expectedMetricLabels) {
+			t.Fatalf("Expected metric labels sent to be [%v] but was [%v]", expectedMetricLabels, actualMetricLabels)
+		}
+	})
+
 	t.Run("Sends TlsIdentity when enabled", func(t *testing.T) {
 		expectedPodName := pod1.Name
 		expectedPodNamespace := thisNS
@@ -240,8 +287,9 @@ func TestEndpointListener(t *testing.T) {
 			mockGetServer,
 			ownerKindAndName,
 			false,
-			expectedControllerNamespace,
+			[]string{expectedControllerNamespace},
 			"trust.domain",
+			nil,
 		)
 
 		add := []*updateAddress{
@@ -292,8 +340,9 @@ func TestEndpointListener(t *testing.T) {
 			mockGetServer,
 			ownerKindAndName,
 			false,
-			expectedControllerNamespace,
+			[]string{expectedControllerNamespace},
 			"trust.domain",
+			nil,
 		)
 
 		add := []*updateAddress{
@@ -311,6 +360,54 @@ func TestEndpointListener(t *testing.T) {
 		}
 	})
 
+	t.Run("Sends TlsIdentity for peer control planes", func(t *testing.T) {
+		expectedTLSIdentity := &pb.TlsIdentity_DnsLikeIdentity{
+			Name: "this-serviceaccount.this-namespace.serviceaccount.identity.linkerd-canary.trust.domain",
+		}
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod1.Name,
+				Namespace: thisNS,
+				Annotations: map[string]string{
+					pkgK8s.IdentityModeAnnotation: pkgK8s.IdentityModeDefault,
+				},
+				Labels: map[string]string{
+					pkgK8s.ControllerNSLabel:    "linkerd-canary",
+					pkgK8s.ProxyDeploymentLabel: podDeployment,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "this-serviceaccount",
+			},
+		}
+
+		mockGetServer := &mockDestinationGetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(
+			mockGetServer,
+			defaultOwnerKindAndName,
+			false,
+			[]string{"linkerd", "linkerd-canary"},
+			"trust.domain",
+			nil,
+		)
+
+		listener.Update([]*updateAddress{{address: addedAddress1, pod: pod}}, nil)
+
+		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
+		if len(addrs) != 1 {
+			t.Fatalf("Expected [1] address returned, got %v", addrs)
+		}
+
+		actualTLSIdentity := addrs[0].GetTlsIdentity().GetDnsLikeIdentity()
+		if !reflect.DeepEqual(actualTLSIdentity, expectedTLSIdentity) {
+			t.Fatalf("Expected TlsIdentity to be [%v] but was [%v]", expectedTLSIdentity, actualTLSIdentity)
+		}
+	})
+
 	t.Run("Does not send TlsIdentity for other meshes", func(t *testing.T) {
 		expectedPodName := "pod1"
 		expectedPodNamespace := thisNS
@@ -343,8 +440,9 @@ func TestEndpointListener(t *testing.T) {
 			mockGetServer,
 			ownerKindAndName,
 			false,
-			"linkerd-namespace",
+			[]string{"linkerd-namespace"},
 			"trust.domain",
+			nil,
 		)
 
 		add := []*updateAddress{
@@ -391,8 +489,9 @@ func TestEndpointListener(t *testing.T) {
 			mockGetServer,
 			ownerKindAndName,
 			false,
-			expectedControllerNamespace,
+			[]string{expectedControllerNamespace},
 			"",
+			nil,
 		)
 
 		add := []*updateAddress{
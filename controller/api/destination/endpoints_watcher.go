@@ -18,6 +18,9 @@ import (
 
 const (
 	kubeSystem = "kube-system"
+
+	ipFamilyV4 = "ipv4"
+	ipFamilyV6 = "ipv6"
 )
 
 // TODO: prom metrics for all the queues/caches
@@ -35,6 +38,9 @@ type endpointsWatcher struct {
 	endpointLister corelisters.EndpointsLister
 	podLister      corelisters.PodLister
 	servicePorts   servicePorts
+	// ipFamilyPreference is the IP family, if any, that address sets holding
+	// addresses of both families are filtered down to.
+	ipFamilyPreference string
 	// This mutex protects the servicePorts data structure (nested map) itself
 	// and does not protect the servicePort objects themselves.  They are locked
 	// separately.
@@ -42,13 +48,14 @@ type endpointsWatcher struct {
 	log   *log.Entry
 }
 
-func newEndpointsWatcher(k8sAPI *k8s.API) *endpointsWatcher {
+func newEndpointsWatcher(k8sAPI *k8s.API, ipFamilyPreference string) *endpointsWatcher {
 	watcher := &endpointsWatcher{
-		serviceLister:  k8sAPI.Svc().Lister(),
-		endpointLister: k8sAPI.Endpoint().Lister(),
-		podLister:      k8sAPI.Pod().Lister(),
-		servicePorts:   make(servicePorts),
-		mutex:          sync.RWMutex{},
+		serviceLister:      k8sAPI.Svc().Lister(),
+		endpointLister:     k8sAPI.Endpoint().Lister(),
+		podLister:          k8sAPI.Pod().Lister(),
+		servicePorts:       make(servicePorts),
+		ipFamilyPreference: ipFamilyPreference,
+		mutex:              sync.RWMutex{},
 		log: log.WithFields(log.Fields{
 			"component": "endpoints-watcher",
 		}),
@@ -113,7 +120,7 @@ func (e *endpointsWatcher) subscribe(service *serviceID, port uint32, listener e
 			e.log.Errorf("Error getting endpoints: %s", err)
 			return err
 		}
-		svcPort = newServicePort(svc, endpoints, port, e.podLister)
+		svcPort = newServicePort(svc, endpoints, port, e.podLister, e.ipFamilyPreference)
 		svcPorts[port] = svcPort
 	}
 
@@ -294,6 +301,7 @@ type servicePort struct {
 	targetPort intstr.IntOrString
 	addresses  []*updateAddress
 	podLister  corelisters.PodLister
+	ipFamily   string
 	// This mutex protects against concurrent modification of the listeners slice
 	// as well as prevents updates for occurring while the listeners slice is being
 	// modified.
@@ -301,7 +309,7 @@ type servicePort struct {
 	log   *log.Entry
 }
 
-func newServicePort(service *corev1.Service, endpoints *corev1.Endpoints, port uint32, podLister corelisters.PodLister) *servicePort {
+func newServicePort(service *corev1.Service, endpoints *corev1.Endpoints, port uint32, podLister corelisters.PodLister, ipFamily string) *servicePort {
 	id := serviceID{}
 	if service != nil {
 		id.namespace = service.Namespace
@@ -317,6 +325,7 @@ func newServicePort(service *corev1.Service, endpoints *corev1.Endpoints, port u
 		endpoints:  endpoints,
 		targetPort: targetPort,
 		podLister:  podLister,
+		ipFamily:   ipFamily,
 		mutex:      sync.RWMutex{},
 		log: log.WithFields(log.Fields{
 			"component":   "service-port",
@@ -481,9 +490,9 @@ func (sp *servicePort) endpointsToAddresses(endpoints *corev1.Endpoints, targetP
 
 			idStr := fmt.Sprintf("%s %s.%s", address.IP, target.Name, target.Namespace)
 
-			ip, err := addr.ParseProxyIPV4(address.IP)
+			ip, err := addr.ParseProxyIP(address.IP)
 			if err != nil {
-				sp.log.Errorf("[%s] not a valid IPV4 address", idStr)
+				sp.log.Errorf("[%s] not a valid IP address", idStr)
 				continue
 			}
 
@@ -499,7 +508,26 @@ func (sp *servicePort) endpointsToAddresses(endpoints *corev1.Endpoints, targetP
 			})
 		}
 	}
-	return addrs
+	return preferIPFamily(addrs, sp.ipFamily)
+}
+
+// preferIPFamily returns the addresses of the given IP family, or all the
+// addresses if none of them is of that family or if family is empty.
+func preferIPFamily(addrs []*updateAddress, family string) []*updateAddress {
+	if family == "" {
+		return addrs
+	}
+
+	preferred := make([]*updateAddress, 0, len(addrs))
+	for _, a := range addrs {
+		if addr.IsProxyIPV6(a.address.GetIp()) == (family == ipFamilyV6) {
+			preferred = append(preferred, a)
+		}
+	}
+	if len(preferred) == 0 {
+		return addrs
+	}
+	return preferred
 }
 
 // getTargetPort returns the port specified as an argument if no service is
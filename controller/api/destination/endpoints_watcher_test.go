@@ -1,6 +1,7 @@
 package destination
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -475,7 +476,7 @@ spec:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := newEndpointsWatcher(k8sAPI)
+			watcher := newEndpointsWatcher(k8sAPI, "")
 
 			k8sAPI.Sync()
 
@@ -515,3 +516,29 @@ spec:
 		})
 	}
 }
+
+func TestPreferIPFamily(t *testing.T) {
+	ipv4 := makeUpdateAddress("10.233.66.239", 8990, "name1-ipv4")
+	ipv6 := makeUpdateAddress("fd00:10:233::239", 8990, "name1-ipv6")
+
+	testCases := []struct {
+		family   string
+		addrs    []*updateAddress
+		expected []*updateAddress
+	}{
+		{family: "", addrs: []*updateAddress{ipv4, ipv6}, expected: []*updateAddress{ipv4, ipv6}},
+		{family: ipFamilyV4, addrs: []*updateAddress{ipv4, ipv6}, expected: []*updateAddress{ipv4}},
+		{family: ipFamilyV6, addrs: []*updateAddress{ipv4, ipv6}, expected: []*updateAddress{ipv6}},
+		{family: ipFamilyV6, addrs: []*updateAddress{ipv4}, expected: []*updateAddress{ipv4}},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: prefers %q", i, tc.family), func(t *testing.T) {
+			actual := preferIPFamily(tc.addrs, tc.family)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
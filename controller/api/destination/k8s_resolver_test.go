@@ -59,7 +59,7 @@ func TestGetState(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewFakeAPI returned an error: %s", err)
 	}
-	endpointsWatcher := newEndpointsWatcher(k8sAPI)
+	endpointsWatcher := newEndpointsWatcher(k8sAPI, "")
 
 	testCases := []struct {
 		servicePorts servicePorts
@@ -14,15 +14,22 @@ import (
 	"github.com/linkerd/linkerd2/pkg/prometheus"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var errDraining = status.Error(codes.Unavailable, "destination service is shutting down")
+
 type server struct {
 	k8sAPI          *k8s.API
 	resolver        streamingDestinationResolver
 	enableH2Upgrade bool
+	podMetricLabels []string
 	controllerNS,
 	identityTrustDomain string
-	log *log.Entry
+	identityPeerNS []string
+	draining       <-chan struct{}
+	log            *log.Entry
 }
 
 // NewServer returns a new instance of the destination server.
@@ -37,14 +44,36 @@ type server struct {
 //
 // Addresses for the given destination are fetched from the Kubernetes Endpoints
 // API.
+//
+// Once draining is closed, new Get and GetProfile streams are refused so that
+// proxies resolve against another replica, while existing streams keep
+// receiving updates until done is closed.
+//
+// When ipFamilyPreference is ipv4 or ipv6, address sets holding addresses of
+// both families are filtered down to the addresses of that family. When it's
+// empty, addresses of both families are returned.
+//
+// The proxies of the control planes in the identityPeerNS namespaces, which
+// must share the trust anchors of this one, e.g. a canary control plane, are
+// given TLS identities like the proxies of this one.
+//
+// The labels of the pods whose keys are in podMetricLabels, e.g. version, are
+// added to the metric labels of their addresses, so that proxies can label
+// their metrics with them.
+//
+// The options configure the gRPC server, e.g. to serve proxies over TLS.
 func NewServer(
-	addr, k8sDNSZone string,
+	addr, k8sDNSZone, ipFamilyPreference string,
 	controllerNS, identityTrustDomain string,
+	identityPeerNS []string,
 	enableH2Upgrade bool,
+	podMetricLabels []string,
 	k8sAPI *k8s.API,
+	draining <-chan struct{},
 	done chan struct{},
+	opts ...grpc.ServerOption,
 ) (*grpc.Server, error) {
-	resolver, err := buildResolver(k8sDNSZone, k8sAPI)
+	resolver, err := buildResolver(k8sDNSZone, ipFamilyPreference, k8sAPI)
 	if err != nil {
 		return nil, err
 	}
@@ -53,15 +82,18 @@ func NewServer(
 		k8sAPI:              k8sAPI,
 		resolver:            resolver,
 		enableH2Upgrade:     enableH2Upgrade,
+		podMetricLabels:     podMetricLabels,
 		controllerNS:        controllerNS,
 		identityTrustDomain: identityTrustDomain,
+		identityPeerNS:      identityPeerNS,
+		draining:            draining,
 		log: log.WithFields(log.Fields{
 			"addr":      addr,
 			"component": "server",
 		}),
 	}
 
-	s := prometheus.NewGrpcServer()
+	s := prometheus.NewGrpcServer(opts...)
 
 	// this server satisfies 2 gRPC interfaces:
 	// 1) linkerd2-proxy-api/destination.Destination (proxy-facing)
@@ -79,6 +111,9 @@ func NewServer(
 
 func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) error {
 	s.log.Debugf("Get(%+v)", dest)
+	if s.isDraining() {
+		return errDraining
+	}
 	host, port, err := getHostAndPort(dest)
 	if err != nil {
 		return err
@@ -89,6 +124,9 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 
 func (s *server) GetProfile(dest *pb.GetDestination, stream pb.Destination_GetProfileServer) error {
 	s.log.Debugf("GetProfile(%+v)", dest)
+	if s.isDraining() {
+		return errDraining
+	}
 	host, _, err := getHostAndPort(dest)
 	if err != nil {
 		return err
@@ -162,8 +200,17 @@ func (s *server) Endpoints(ctx context.Context, params *discoveryPb.EndpointsPar
 	return &rsp, nil
 }
 
+func (s *server) isDraining() bool {
+	select {
+	case <-s.draining:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *server) streamResolution(host string, port int, stream pb.Destination_GetServer) error {
-	listener := newEndpointListener(stream, s.k8sAPI.GetOwnerKindAndName, s.enableH2Upgrade, s.controllerNS, s.identityTrustDomain)
+	listener := newEndpointListener(stream, s.k8sAPI.GetOwnerKindAndName, s.enableH2Upgrade, append([]string{s.controllerNS}, s.identityPeerNS...), s.identityTrustDomain, s.podMetricLabels)
 
 	resolverCanResolve, err := s.resolver.canResolve(host, port)
 	if err != nil {
@@ -202,9 +249,15 @@ func getHostAndPort(dest *pb.GetDestination) (string, int, error) {
 }
 
 func buildResolver(
-	k8sDNSZone string,
+	k8sDNSZone, ipFamilyPreference string,
 	k8sAPI *k8s.API,
 ) (streamingDestinationResolver, error) {
+	switch ipFamilyPreference {
+	case "", ipFamilyV4, ipFamilyV6:
+	default:
+		return nil, fmt.Errorf("invalid IP family preference %q, must be one of: %s, %s", ipFamilyPreference, ipFamilyV4, ipFamilyV6)
+	}
+
 	k8sDNSZoneLabels := []string{}
 	if k8sDNSZone != "" {
 		var err error
@@ -214,7 +267,7 @@ func buildResolver(
 		}
 	}
 
-	k8sResolver := newK8sResolver(k8sDNSZoneLabels, newEndpointsWatcher(k8sAPI), newProfileWatcher(k8sAPI))
+	k8sResolver := newK8sResolver(k8sDNSZoneLabels, newEndpointsWatcher(k8sAPI, ipFamilyPreference), newProfileWatcher(k8sAPI))
 
 	log.Infof("Built k8s name resolver")
 
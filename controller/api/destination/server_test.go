@@ -11,8 +11,11 @@ import (
 	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
 	discoveryPb "github.com/linkerd/linkerd2/controller/gen/controller/discovery"
 	"github.com/linkerd/linkerd2/controller/k8s"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -57,7 +60,7 @@ func TestBuildResolver(t *testing.T) {
 	t.Run("Doesn't build a resolver if Kubernetes DNS zone isnt valid", func(t *testing.T) {
 		invalidK8sDNSZones := []string{"1", "-a", "a-", "-"}
 		for _, dsnZone := range invalidK8sDNSZones {
-			resolver, err := buildResolver(dsnZone, k8sAPI)
+			resolver, err := buildResolver(dsnZone, "", k8sAPI)
 			if err == nil {
 				t.Fatalf("Expecting error when k8s zone is [%s], got nothing. Resolver: %v", dsnZone, resolver)
 			}
@@ -148,6 +151,43 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 	})
 }
 
+func TestDraining(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI()
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	draining := make(chan struct{})
+	server := server{
+		k8sAPI:   k8sAPI,
+		resolver: &mockStreamingDestinationResolver{canResolveToReturn: true},
+		draining: draining,
+		log:      log.WithField("test", t.Name()),
+	}
+	dest := &pb.GetDestination{Scheme: "k8s", Path: "books.default.svc.cluster.local:8080"}
+
+	t.Run("Serves new streams before draining", func(t *testing.T) {
+		err := server.Get(dest, &mockDestinationGetServer{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	close(draining)
+
+	t.Run("Refuses new streams while draining", func(t *testing.T) {
+		err := server.Get(dest, &mockDestinationGetServer{})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("Expected Unavailable error, got: %v", err)
+		}
+
+		err = server.GetProfile(dest, &mockDestinationGetProfileServer{})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("Expected Unavailable error, got: %v", err)
+		}
+	})
+}
+
 func TestEndpoints(t *testing.T) {
 	k8sAPI, err := k8s.NewFakeAPI()
 	if err != nil {
@@ -157,8 +197,8 @@ func TestEndpoints(t *testing.T) {
 
 	lis := bufconn.Listen(1024 * 1024)
 	gRPCServer, err := NewServer(
-		"fake-addr", "", "controller-ns", "",
-		false, k8sAPI, nil,
+		"fake-addr", "", "", "controller-ns", "", nil,
+		false, nil, k8sAPI, nil, nil,
 	)
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err)
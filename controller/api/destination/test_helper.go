@@ -113,7 +113,7 @@ func equalServicePorts(servicePorts1, servicePorts2 servicePorts) error {
 }
 
 func makeUpdateAddress(ipStr string, portNum uint32, name string) *updateAddress {
-	ip, _ := addr.ParseProxyIPV4(ipStr)
+	ip, _ := addr.ParseProxyIP(ipStr)
 	return &updateAddress{
 		address: &proxyNet.TcpAddress{Ip: ip, Port: portNum},
 		pod: &corev1.Pod{
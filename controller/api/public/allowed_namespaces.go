@@ -0,0 +1,76 @@
+package public
+
+import (
+	"strings"
+
+	discoveryPb "github.com/linkerd/linkerd2/controller/gen/controller/discovery"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+const namespaceNotAllowed = "namespace %s is not allowed"
+
+// namespaceAllowed returns true if the public API answers queries about the
+// namespace. All namespaces are allowed when no allowlist is set.
+func (s *grpcServer) namespaceAllowed(namespace string) bool {
+	if len(s.allowedNamespaces) == 0 {
+		return true
+	}
+	for _, allowed := range s.allowedNamespaces {
+		if namespace == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// disallowedNamespace returns the first namespace of the resources of a
+// request that isn't allowed, if any. Resources in all namespaces are
+// accepted, and the results about other namespaces must be filtered.
+func (s *grpcServer) disallowedNamespace(resources ...*pb.Resource) string {
+	for _, resource := range resources {
+		if ns := resourceNamespace(resource); ns != "" && !s.namespaceAllowed(ns) {
+			return ns
+		}
+	}
+	return ""
+}
+
+// resourceNamespace returns the namespace of a resource, which is its name for
+// namespaces.
+func resourceNamespace(resource *pb.Resource) string {
+	if resource.GetType() == k8s.Namespace {
+		return resource.GetName()
+	}
+	return resource.GetNamespace()
+}
+
+// filterStatTable removes the rows about namespaces that aren't allowed.
+func (s *grpcServer) filterStatTable(table *pb.StatTable) {
+	podGroup := table.GetPodGroup()
+	if len(s.allowedNamespaces) == 0 || podGroup == nil {
+		return
+	}
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0, len(podGroup.Rows))
+	for _, row := range podGroup.Rows {
+		if s.namespaceAllowed(resourceNamespace(row.GetResource())) {
+			rows = append(rows, row)
+		}
+	}
+	podGroup.Rows = rows
+}
+
+// filterEndpoints removes the services in namespaces that aren't allowed. The
+// services are identified by their name and namespace, separated by a dot.
+func (s *grpcServer) filterEndpoints(rsp *discoveryPb.EndpointsResponse) {
+	if len(s.allowedNamespaces) == 0 {
+		return
+	}
+
+	for id := range rsp.GetServicePorts() {
+		if !s.namespaceAllowed(id[strings.LastIndex(id, ".")+1:]) {
+			delete(rsp.ServicePorts, id)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package public
+
+import (
+	"context"
+	"testing"
+
+	discoveryPb "github.com/linkerd/linkerd2/controller/gen/controller/discovery"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAllowedNamespaces(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  namespace: emojivoto
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: books
+  namespace: booksapp
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	s := newGrpcServer(&mockProm{}, nil, nil, k8sAPI, "linkerd", "", []string{}, []string{"emojivoto"})
+	k8sAPI.Sync()
+
+	t.Run("Filters the services of other namespaces", func(t *testing.T) {
+		rsp, err := s.ListServices(context.TODO(), &pb.ListServicesRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(rsp.Services) != 1 || rsp.Services[0].Namespace != "emojivoto" {
+			t.Fatalf("Expected the emojivoto service only, got %+v", rsp.Services)
+		}
+	})
+
+	t.Run("Denies requests about other namespaces", func(t *testing.T) {
+		_, err := s.ListServices(context.TODO(), &pb.ListServicesRequest{Namespace: "booksapp"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("Expected a PermissionDenied error, got %v", err)
+		}
+
+		if ns := s.disallowedNamespace(
+			&pb.Resource{Type: pkgK8s.Deployment, Namespace: "emojivoto"},
+			&pb.Resource{Type: pkgK8s.Namespace, Name: "booksapp"},
+		); ns != "booksapp" {
+			t.Fatalf("Expected booksapp not to be allowed, got %q", ns)
+		}
+		if ns := s.disallowedNamespace(&pb.Resource{Type: pkgK8s.Pod}); ns != "" {
+			t.Fatalf("Expected requests across all namespaces to be allowed, got %q", ns)
+		}
+	})
+
+	t.Run("Filters the stats of other namespaces", func(t *testing.T) {
+		table := &pb.StatTable{
+			Table: &pb.StatTable_PodGroup_{
+				PodGroup: &pb.StatTable_PodGroup{
+					Rows: []*pb.StatTable_PodGroup_Row{
+						{Resource: &pb.Resource{Type: pkgK8s.Namespace, Name: "emojivoto"}},
+						{Resource: &pb.Resource{Type: pkgK8s.Namespace, Name: "booksapp"}},
+						{Resource: &pb.Resource{Type: pkgK8s.Deployment, Namespace: "booksapp", Name: "books"}},
+					},
+				},
+			},
+		}
+		s.filterStatTable(table)
+
+		rows := table.GetPodGroup().GetRows()
+		if len(rows) != 1 || rows[0].GetResource().GetName() != "emojivoto" {
+			t.Fatalf("Expected the emojivoto row only, got %+v", rows)
+		}
+	})
+
+	t.Run("Filters the endpoints of other namespaces", func(t *testing.T) {
+		rsp := &discoveryPb.EndpointsResponse{
+			ServicePorts: map[string]*discoveryPb.ServicePort{
+				"web-svc.emojivoto": {},
+				"books.booksapp":    {},
+			},
+		}
+		s.filterEndpoints(rsp)
+
+		if _, ok := rsp.ServicePorts["web-svc.emojivoto"]; !ok || len(rsp.ServicePorts) != 1 {
+			t.Fatalf("Expected the emojivoto endpoints only, got %+v", rsp.ServicePorts)
+		}
+	})
+}
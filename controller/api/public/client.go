@@ -55,6 +55,12 @@ func (c *grpcOverHTTPClient) TopRoutes(ctx context.Context, req *pb.TopRoutesReq
 	return &msg, err
 }
 
+func (c *grpcOverHTTPClient) ListClients(ctx context.Context, req *pb.ListClientsRequest, _ ...grpc.CallOption) (*pb.ListClientsResponse, error) {
+	var msg pb.ListClientsResponse
+	err := c.apiRequest(ctx, "ListClients", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHTTPClient) Version(ctx context.Context, req *pb.Empty, _ ...grpc.CallOption) (*pb.VersionInfo, error) {
 	var msg pb.VersionInfo
 	err := c.apiRequest(ctx, "Version", req, &msg)
@@ -39,7 +39,9 @@ type grpcServer struct {
 	discoveryClient       discoveryPb.DiscoveryClient
 	k8sAPI                *k8s.API
 	controllerNamespace   string
+	clusterName           string
 	ignoredNamespaces     []string
+	allowedNamespaces     []string
 	mountPathGlobalConfig string
 	mountPathProxyConfig  string
 }
@@ -63,7 +65,9 @@ func newGrpcServer(
 	discoveryClient discoveryPb.DiscoveryClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
+	clusterName string,
 	ignoredNamespaces []string,
+	allowedNamespaces []string,
 ) *grpcServer {
 
 	grpcServer := &grpcServer{
@@ -72,7 +76,9 @@ func newGrpcServer(
 		discoveryClient:       discoveryClient,
 		k8sAPI:                k8sAPI,
 		controllerNamespace:   controllerNamespace,
+		clusterName:           clusterName,
 		ignoredNamespaces:     ignoredNamespaces,
+		allowedNamespaces:     allowedNamespaces,
 		mountPathGlobalConfig: pkgK8s.MountPathGlobalConfig,
 		mountPathProxyConfig:  pkgK8s.MountPathProxyConfig,
 	}
@@ -118,6 +124,9 @@ func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb
 		namespace = targetOwner.GetName()
 	}
 	if namespace != "" {
+		if !s.namespaceAllowed(namespace) {
+			return nil, status.Errorf(codes.PermissionDenied, namespaceNotAllowed, namespace)
+		}
 		nsQuery = fmt.Sprintf("namespace=\"%s\"", namespace)
 	}
 	processStartTimeQuery := fmt.Sprintf(podQuery, nsQuery)
@@ -244,6 +253,13 @@ func (s *grpcServer) Tap(req *pb.TapRequest, stream pb.Api_TapServer) error {
 
 // Pass through to tap service
 func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_TapByResourceServer) error {
+	if ns := s.disallowedNamespace(req.GetTarget().GetResource(), req.GetMatch().GetDestinations().GetResource()); ns != "" {
+		return status.Errorf(codes.PermissionDenied, namespaceNotAllowed, ns)
+	}
+	if ns := resourceNamespace(req.GetTarget().GetResource()); ns == "" && len(s.allowedNamespaces) > 0 {
+		return status.Error(codes.PermissionDenied, "a namespace must be set when namespaces are restricted")
+	}
+
 	tapStream := stream.(tapServer)
 	tapClient, err := s.tapClient.TapByResource(tapStream.Context(), req)
 	if err != nil {
@@ -265,6 +281,9 @@ func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_T
 }
 
 func (s *grpcServer) shouldIgnore(pod *corev1.Pod) bool {
+	if !s.namespaceAllowed(pod.Namespace) {
+		return true
+	}
 	for _, namespace := range s.ignoredNamespaces {
 		if pod.Namespace == namespace {
 			return true
@@ -276,6 +295,10 @@ func (s *grpcServer) shouldIgnore(pod *corev1.Pod) bool {
 func (s *grpcServer) ListServices(ctx context.Context, req *pb.ListServicesRequest) (*pb.ListServicesResponse, error) {
 	log.Debugf("ListServices request: %+v", req)
 
+	if req.Namespace != "" && !s.namespaceAllowed(req.Namespace) {
+		return nil, status.Errorf(codes.PermissionDenied, namespaceNotAllowed, req.Namespace)
+	}
+
 	services, err := s.k8sAPI.GetServices(req.Namespace, "")
 	if err != nil {
 		return nil, err
@@ -283,6 +306,9 @@ func (s *grpcServer) ListServices(ctx context.Context, req *pb.ListServicesReque
 
 	svcs := make([]*pb.Service, 0)
 	for _, svc := range services {
+		if !s.namespaceAllowed(svc.GetNamespace()) {
+			continue
+		}
 		svcs = append(svcs, &pb.Service{
 			Name:      svc.GetName(),
 			Namespace: svc.GetNamespace(),
@@ -300,6 +326,7 @@ func (s *grpcServer) Endpoints(ctx context.Context, params *discoveryPb.Endpoint
 		log.Errorf("endpoints request to destination API failed: %s", err)
 		return nil, err
 	}
+	s.filterEndpoints(rsp)
 
 	return rsp, nil
 }
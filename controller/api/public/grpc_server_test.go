@@ -405,7 +405,9 @@ status:
 				nil,
 				k8sAPI,
 				"linkerd",
+				"",
 				[]string{},
+				nil,
 			)
 
 			k8sAPI.Sync()
@@ -507,7 +509,9 @@ metadata:
 				nil,
 				k8sAPI,
 				"linkerd",
+				"",
 				[]string{},
+				nil,
 			)
 
 			k8sAPI.Sync()
@@ -557,7 +561,9 @@ func TestEndpoints(t *testing.T) {
 				discoveryClient,
 				k8sAPI,
 				"linkerd",
+				"",
 				[]string{},
+				nil,
 			)
 
 			rsp, err := fakeGrpcServer.Endpoints(context.TODO(), exp.req)
@@ -585,7 +591,9 @@ func TestConfig(t *testing.T) {
 			nil,
 			k8sAPI,
 			"linkerd",
+			"",
 			[]string{},
+			nil,
 		)
 		fakeGrpcServer.mountPathGlobalConfig = "testdata/global.conf.json"
 		fakeGrpcServer.mountPathProxyConfig = "testdata/proxy.conf.json"
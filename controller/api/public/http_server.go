@@ -20,6 +20,7 @@ import (
 var (
 	statSummaryPath   = fullURLPathFor("StatSummary")
 	topRoutesPath     = fullURLPathFor("TopRoutes")
+	listClientsPath   = fullURLPathFor("ListClients")
 	versionPath       = fullURLPathFor("Version")
 	listPodsPath      = fullURLPathFor("ListPods")
 	listServicesPath  = fullURLPathFor("ListServices")
@@ -30,13 +31,21 @@ var (
 )
 
 type handler struct {
-	grpcServer APIServer
+	grpcServer          APIServer
+	rolloutMetricsToken string
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	log.WithFields(log.Fields{
 		"req.Method": req.Method, "req.URL": req.URL, "req.Form": req.Form,
 	}).Debugf("Serving %s %s", req.Method, req.URL.Path)
+	// The rollout metrics are polled with GET requests by third-party
+	// controllers, rather than by the clients of this package.
+	if req.URL.Path == rolloutMetricsPath {
+		h.handleRolloutMetrics(w, req)
+		return
+	}
+
 	// Validate request method
 	if req.Method != http.MethodPost {
 		writeErrorToHTTPResponse(w, fmt.Errorf("POST required"))
@@ -49,6 +58,8 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.handleStatSummary(w, req)
 	case topRoutesPath:
 		h.handleTopRoutes(w, req)
+	case listClientsPath:
+		h.handleListClients(w, req)
 	case versionPath:
 		h.handleVersion(w, req)
 	case listPodsPath:
@@ -111,6 +122,27 @@ func (h *handler) handleTopRoutes(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleListClients(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.ListClientsRequest
+
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHTTPResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.ListClients(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHTTPResponse(w, err)
+		return
+	}
+	err = writeProtoToHTTPResponse(w, rsp)
+	if err != nil {
+		writeErrorToHTTPResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleVersion(w http.ResponseWriter, req *http.Request) {
 	var protoRequest pb.Empty
 	err := httpRequestToProto(req, &protoRequest)
@@ -288,7 +320,10 @@ func NewServer(
 	discoveryClient discoveryPb.DiscoveryClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
+	clusterName string,
 	ignoredNamespaces []string,
+	allowedNamespaces []string,
+	rolloutMetricsToken string,
 ) *http.Server {
 	baseHandler := &handler{
 		grpcServer: newGrpcServer(
@@ -297,8 +332,11 @@ func NewServer(
 			discoveryClient,
 			k8sAPI,
 			controllerNamespace,
+			clusterName,
 			ignoredNamespaces,
+			allowedNamespaces,
 		),
+		rolloutMetricsToken: rolloutMetricsToken,
 	}
 
 	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
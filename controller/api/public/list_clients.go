@@ -0,0 +1,155 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	clientsReqQuery = "sum(increase(request_total%s[%s])) by (client_id)"
+	clientsTCPQuery = "sum(increase(tcp_open_total%s[%s])) by (client_id)"
+	clientIDLabel   = model.LabelName("client_id")
+	podLabel        = `pod=~"%s"`
+)
+
+func (s *grpcServer) ListClients(ctx context.Context, req *pb.ListClientsRequest) (*pb.ListClientsResponse, error) {
+	log.Debugf("ListClients request: %+v", req)
+
+	resource := req.GetResource()
+	if resource == nil || resource.GetName() == "" {
+		return listClientsError(req, "ListClients request requires a named resource"), nil
+	}
+	if resource.GetType() == k8s.All || isNonK8sResourceQuery(resource.GetType()) {
+		return listClientsError(req, fmt.Sprintf("resource type '%s' is not supported for listing clients", resource.GetType())), nil
+	}
+
+	if ns := s.disallowedNamespace(resource); ns != "" {
+		return listClientsError(req, fmt.Sprintf(namespaceNotAllowed, ns)), nil
+	}
+
+	reqLabels, err := s.buildClientsLabels(resource, nil)
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+	// inbound connections are accepted from the "src" peer
+	tcpLabels, err := s.buildClientsLabels(resource, model.LabelSet{"peer": "src"})
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	reqVec, err := s.queryProm(ctx, fmt.Sprintf(clientsReqQuery, reqLabels, req.GetTimeWindow()))
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+	tcpVec, err := s.queryProm(ctx, fmt.Sprintf(clientsTCPQuery, tcpLabels, req.GetTimeWindow()))
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	byIdentity := make(map[string]*pb.Client)
+	client := func(sample *model.Sample) *pb.Client {
+		identity := string(sample.Metric[clientIDLabel])
+		if identity == "" {
+			// Traffic from clients without an identity isn't from the mesh.
+			return nil
+		}
+		if _, ok := byIdentity[identity]; !ok {
+			byIdentity[identity] = &pb.Client{
+				Identity:   identity,
+				Namespace:  identityNamespace(identity),
+				TimeWindow: req.GetTimeWindow(),
+			}
+		}
+		return byIdentity[identity]
+	}
+	for _, sample := range reqVec {
+		if c := client(sample); c != nil {
+			c.RequestCount = extractSampleValue(sample)
+		}
+	}
+	for _, sample := range tcpVec {
+		if c := client(sample); c != nil {
+			c.TcpConnectionCount = extractSampleValue(sample)
+		}
+	}
+
+	clients := make([]*pb.Client, 0, len(byIdentity))
+	for _, c := range byIdentity {
+		clients = append(clients, c)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Identity < clients[j].Identity
+	})
+
+	return &pb.ListClientsResponse{
+		Response: &pb.ListClientsResponse_Ok_{
+			Ok: &pb.ListClientsResponse_Ok{
+				Clients: clients,
+			},
+		},
+	}, nil
+}
+
+// buildClientsLabels returns the label selector, with the extra labels, for
+// the inbound traffic to the given resource sent over TLS. Inbound metrics
+// aren't labeled with the services that selected the pod, so services are
+// matched by their pods.
+func (s *grpcServer) buildClientsLabels(resource *pb.Resource, extra model.LabelSet) (string, error) {
+	labels := promDirectionLabels("inbound")
+	labels = labels.Merge(model.LabelSet{"tls": "true"}).Merge(extra)
+
+	if resource.GetType() != k8s.Service {
+		return renderLabels(labels.Merge(promQueryLabels(resource)), nil), nil
+	}
+
+	objects, err := s.k8sAPI.GetObjects(resource.GetNamespace(), resource.GetType(), resource.GetName())
+	if err != nil {
+		return "", err
+	}
+
+	pods := make([]string, 0)
+	for _, obj := range objects {
+		selected, err := s.k8sAPI.GetPodsFor(obj, false)
+		if err != nil {
+			return "", err
+		}
+		for _, pod := range selected {
+			pods = append(pods, pod.Name)
+		}
+	}
+	sort.Strings(pods)
+
+	labels = labels.Merge(model.LabelSet{namespaceLabel: model.LabelValue(resource.GetNamespace())})
+	selector := renderLabels(labels, nil)
+	return fmt.Sprintf("%s, %s}", strings.TrimSuffix(selector, "}"), fmt.Sprintf(podLabel, strings.Join(pods, "|"))), nil
+}
+
+// identityNamespace returns the namespace of the service account the given
+// proxy identity was issued to, or an empty string if the identity isn't a
+// service account identity.
+func identityNamespace(identity string) string {
+	parts := strings.Split(identity, ".")
+	if len(parts) < 3 || parts[2] != "serviceaccount" {
+		return ""
+	}
+	return parts[1]
+}
+
+func listClientsError(req *pb.ListClientsRequest, message string) *pb.ListClientsResponse {
+	return &pb.ListClientsResponse{
+		Response: &pb.ListClientsResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package public
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func clientsSample(identity string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric:    model.Metric{clientIDLabel: model.LabelValue(identity)},
+		Value:     model.SampleValue(value),
+		Timestamp: 456,
+	}
+}
+
+func TestListClients(t *testing.T) {
+	mockPromResponse := model.Vector{
+		clientsSample("web.emojivoto.serviceaccount.identity.linkerd.cluster.local", 120),
+		clientsSample("", 7),
+		clientsSample("admin.ops.serviceaccount.identity.linkerd.cluster.local", 6.4),
+	}
+	expectedClients := []*pb.Client{
+		{
+			Identity:           "admin.ops.serviceaccount.identity.linkerd.cluster.local",
+			Namespace:          "ops",
+			RequestCount:       6,
+			TimeWindow:         "1m",
+			TcpConnectionCount: 6,
+		},
+		{
+			Identity:           "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+			Namespace:          "emojivoto",
+			RequestCount:       120,
+			TimeWindow:         "1m",
+			TcpConnectionCount: 120,
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		configs  []string
+		resource *pb.Resource
+		queries  []string
+	}{
+		{
+			"Lists the clients of a deployment",
+			[]string{booksDeployConfig},
+			&pb.Resource{Namespace: "default", Type: pkgK8s.Deployment, Name: "books"},
+			[]string{
+				`sum(increase(request_total{deployment="books", direction="inbound", namespace="default", tls="true"}[1m])) by (client_id)`,
+				`sum(increase(tcp_open_total{deployment="books", direction="inbound", namespace="default", peer="src", tls="true"}[1m])) by (client_id)`,
+			},
+		},
+		{
+			"Lists the clients of the pods selected by a service",
+			booksServiceConfig,
+			&pb.Resource{Namespace: "default", Type: pkgK8s.Service, Name: "books"},
+			[]string{
+				`sum(increase(request_total{direction="inbound", namespace="default", tls="true", pod=~"books-64c68d6d46-jrmmx"}[1m])) by (client_id)`,
+				`sum(increase(tcp_open_total{direction="inbound", namespace="default", peer="src", tls="true", pod=~"books-64c68d6d46-jrmmx"}[1m])) by (client_id)`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			exp := expectedStatRPC{
+				k8sConfigs:                tc.configs,
+				mockPromResponse:          mockPromResponse,
+				expectedPrometheusQueries: tc.queries,
+			}
+			mockProm, fakeGrpcServer, err := newMockGrpcServer(exp)
+			if err != nil {
+				t.Fatalf("Error creating mock grpc server: %s", err)
+			}
+
+			rsp, err := fakeGrpcServer.ListClients(context.TODO(), &pb.ListClientsRequest{
+				Resource:   tc.resource,
+				TimeWindow: "1m",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if err := exp.verifyPromQueries(mockProm); err != nil {
+				t.Fatal(err)
+			}
+
+			clients := rsp.GetOk().GetClients()
+			if len(clients) != len(expectedClients) {
+				t.Fatalf("Expected %d clients, got %d: %+v", len(expectedClients), len(clients), clients)
+			}
+			for i, client := range clients {
+				if !proto.Equal(client, expectedClients[i]) {
+					t.Fatalf("Expected client %+v, got %+v", expectedClients[i], client)
+				}
+			}
+		})
+	}
+
+	t.Run("Rejects resources without a name", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRPC{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.ListClients(context.TODO(), &pb.ListClientsRequest{
+			Resource:   &pb.Resource{Namespace: "default", Type: pkgK8s.Deployment},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := "ListClients request requires a named resource"
+		if rsp.GetError().GetError() != expected {
+			t.Fatalf("Expected error %q, got %+v", expected, rsp)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package public
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultRolloutMetricsWindow = "1m"
+
+var rolloutMetricsPath = fullURLPathFor("RolloutMetrics")
+
+// rolloutMetrics holds the metrics of a workload that progressive delivery
+// controllers, such as Flagger or Argo Rollouts, base their analysis on.
+// SuccessRate is nil when the workload didn't serve any request in the window.
+type rolloutMetrics struct {
+	Namespace    string   `json:"namespace"`
+	Resource     string   `json:"resource"`
+	Window       string   `json:"window"`
+	RequestCount uint64   `json:"requestCount"`
+	SuccessRate  *float64 `json:"successRate"`
+	LatencyMsP50 uint64   `json:"latencyMsP50"`
+	LatencyMsP95 uint64   `json:"latencyMsP95"`
+	LatencyMsP99 uint64   `json:"latencyMsP99"`
+}
+
+// handleRolloutMetrics serves the inbound metrics of a workload as JSON, e.g.
+//
+//	GET /api/v1/RolloutMetrics?namespace=emojivoto&resource=deploy/web&window=1m
+//	Authorization: Bearer <token>
+//
+// The endpoint is disabled unless the server is given a token.
+func (h *handler) handleRolloutMetrics(w http.ResponseWriter, req *http.Request) {
+	if h.rolloutMetricsToken == "" {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodGet {
+		http.Error(w, "GET required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.rolloutMetricsToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	namespace := req.FormValue("namespace")
+	if namespace == "" {
+		namespace = corev1.NamespaceDefault
+	}
+	window := req.FormValue("window")
+	if window == "" {
+		window = defaultRolloutMetricsWindow
+	}
+	if _, err := time.ParseDuration(window); err != nil {
+		http.Error(w, fmt.Sprintf("invalid window: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	parts := strings.Split(req.FormValue("resource"), "/")
+	if len(parts) != 2 || parts[1] == "" {
+		http.Error(w, "the resource must be of the form <type>/<name>, e.g. deploy/web", http.StatusBadRequest)
+		return
+	}
+	resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(parts[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rsp, err := h.grpcServer.StatSummary(req.Context(), &pb.StatSummaryRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{Namespace: namespace, Type: resourceType, Name: parts[1]},
+		},
+		TimeWindow: window,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if e := rsp.GetError(); e != nil {
+		http.Error(w, e.GetError(), http.StatusInternalServerError)
+		return
+	}
+
+	var stats *pb.BasicStats
+	for _, table := range rsp.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			stats = row.GetStats()
+		}
+	}
+	if stats == nil {
+		http.Error(w, fmt.Sprintf("no metrics found for %s/%s in the %s namespace", resourceType, parts[1], namespace), http.StatusNotFound)
+		return
+	}
+
+	metrics := &rolloutMetrics{
+		Namespace:    namespace,
+		Resource:     resourceType + "/" + parts[1],
+		Window:       window,
+		RequestCount: stats.GetSuccessCount() + stats.GetFailureCount(),
+		LatencyMsP50: stats.GetLatencyMsP50(),
+		LatencyMsP95: stats.GetLatencyMsP95(),
+		LatencyMsP99: stats.GetLatencyMsP99(),
+	}
+	if metrics.RequestCount > 0 {
+		sr := float64(stats.GetSuccessCount()) / float64(metrics.RequestCount)
+		metrics.SuccessRate = &sr
+	}
+
+	w.Header().Set(contentTypeHeader, "application/json")
+	if err := json.NewEncoder(w).Encode(metrics); err != nil {
+		log.Errorf("Error writing rollout metrics to http response: %s", err)
+	}
+}
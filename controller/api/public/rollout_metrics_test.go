@@ -0,0 +1,149 @@
+package public
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestRolloutMetrics(t *testing.T) {
+	statSummary := &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables: []*pb.StatTable{
+					{
+						Table: &pb.StatTable_PodGroup_{
+							PodGroup: &pb.StatTable_PodGroup{
+								Rows: []*pb.StatTable_PodGroup_Row{
+									{
+										Resource: &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"},
+										Stats: &pb.BasicStats{
+											SuccessCount: 98,
+											FailureCount: 2,
+											LatencyMsP50: 5,
+											LatencyMsP95: 20,
+											LatencyMsP99: 40,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	successRate := 0.98
+
+	testCases := []struct {
+		name              string
+		token             string
+		method            string
+		path              string
+		authorization     string
+		expectedCode      int
+		expectedSelection *pb.Resource
+		expectedMetrics   *rolloutMetrics
+	}{
+		{
+			name:          "serves the metrics of a workload",
+			token:         "s3cr3t",
+			method:        http.MethodGet,
+			path:          rolloutMetricsPath + "?namespace=emojivoto&resource=deploy/web&window=30s",
+			authorization: "Bearer s3cr3t",
+			expectedCode:  http.StatusOK,
+			expectedSelection: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      "deployment",
+				Name:      "web",
+			},
+			expectedMetrics: &rolloutMetrics{
+				Namespace:    "emojivoto",
+				Resource:     "deployment/web",
+				Window:       "30s",
+				RequestCount: 100,
+				SuccessRate:  &successRate,
+				LatencyMsP50: 5,
+				LatencyMsP95: 20,
+				LatencyMsP99: 40,
+			},
+		},
+		{
+			name:          "is disabled without a token",
+			method:        http.MethodGet,
+			path:          rolloutMetricsPath + "?resource=deploy/web",
+			authorization: "Bearer ",
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "rejects invalid tokens",
+			token:         "s3cr3t",
+			method:        http.MethodGet,
+			path:          rolloutMetricsPath + "?resource=deploy/web",
+			authorization: "Bearer guess",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "rejects POST requests",
+			token:         "s3cr3t",
+			method:        http.MethodPost,
+			path:          rolloutMetricsPath + "?resource=deploy/web",
+			authorization: "Bearer s3cr3t",
+			expectedCode:  http.StatusMethodNotAllowed,
+		},
+		{
+			name:          "rejects invalid resources",
+			token:         "s3cr3t",
+			method:        http.MethodGet,
+			path:          rolloutMetricsPath + "?resource=web",
+			authorization: "Bearer s3cr3t",
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "rejects invalid windows",
+			token:         "s3cr3t",
+			method:        http.MethodGet,
+			path:          rolloutMetricsPath + "?resource=deploy/web&window=1",
+			authorization: "Bearer s3cr3t",
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			mockGrpcServer := &mockGrpcServer{}
+			mockGrpcServer.ResponseToReturn = statSummary
+			h := &handler{grpcServer: mockGrpcServer, rolloutMetricsToken: tc.token}
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", tc.authorization)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedCode {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedCode, rec.Code, rec.Body.String())
+			}
+			if tc.expectedMetrics == nil {
+				return
+			}
+
+			selection := mockGrpcServer.LastRequestReceived.(*pb.StatSummaryRequest).GetSelector().GetResource()
+			if !reflect.DeepEqual(selection, tc.expectedSelection) {
+				t.Fatalf("Expected selection %v, got %v", tc.expectedSelection, selection)
+			}
+
+			var metrics rolloutMetrics
+			if err := json.Unmarshal(rec.Body.Bytes(), &metrics); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(&metrics, tc.expectedMetrics) {
+				t.Fatalf("Expected metrics %+v, got %+v", tc.expectedMetrics, metrics)
+			}
+		})
+	}
+}
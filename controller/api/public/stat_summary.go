@@ -2,6 +2,7 @@ package public
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	proto "github.com/golang/protobuf/proto"
@@ -43,12 +44,20 @@ const (
 )
 
 type podStats struct {
-	inMesh uint64
-	total  uint64
-	failed uint64
+	inMesh     uint64
+	total      uint64
+	failed     uint64
+	proxyReady uint64
+	restarts   uint64
+	lastTermination
 	errors map[string]*pb.PodErrors
 }
 
+type lastTermination struct {
+	reason     string
+	finishedAt metav1.Time
+}
+
 func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
 
 	// check for well-formed request
@@ -57,8 +66,12 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 	}
 
 	// special case to check for services as outbound only
-	if isInvalidServiceRequest(req.Selector, req.GetFromResource()) {
-		return statSummaryError(req, "service only supported as a target on 'from' queries, or as a destination on 'to' queries"), nil
+	if isInvalidServiceRequest(req.Selector, req.GetFromResource(), req.GetToResource()) {
+		return statSummaryError(req, "service not supported as a 'from' resource, or as a target on 'to' queries"), nil
+	}
+
+	if ns := s.disallowedNamespace(req.GetSelector().GetResource(), req.GetToResource(), req.GetFromResource()); ns != "" {
+		return statSummaryError(req, fmt.Sprintf(namespaceNotAllowed, ns)), nil
 	}
 
 	switch req.Outbound.(type) {
@@ -102,6 +115,7 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		if result.err != nil {
 			return nil, util.GRPCError(result.err)
 		}
+		s.filterStatTable(result.res)
 		statTables = append(statTables, result.res)
 	}
 
@@ -116,12 +130,14 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 	return &rsp, nil
 }
 
-func isInvalidServiceRequest(selector *pb.ResourceSelection, fromResource *pb.Resource) bool {
+// isInvalidServiceRequest returns true for requests where a service would be
+// the source of traffic, which only workloads can be.
+func isInvalidServiceRequest(selector *pb.ResourceSelection, fromResource *pb.Resource, toResource *pb.Resource) bool {
 	if fromResource != nil {
 		return fromResource.Type == k8s.Service
 	}
 
-	return selector.Resource.Type == k8s.Service
+	return toResource != nil && selector.Resource.Type == k8s.Service
 }
 
 func statSummaryError(req *pb.StatSummaryRequest, message string) *pb.StatSummaryResponse {
@@ -210,9 +226,10 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 				Namespace: k8sResource.GetNamespace(),
 				Type:      req.GetSelector().GetResource().GetType(),
 			},
-			TimeWindow: req.TimeWindow,
-			Stats:      basicStats,
-			TcpStats:   tcpStats,
+			TimeWindow:  req.TimeWindow,
+			Stats:       basicStats,
+			TcpStats:    tcpStats,
+			ClusterName: s.clusterName,
 		}
 
 		podStat := objInfo.podStats
@@ -220,6 +237,9 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 		row.RunningPodCount = podStat.total
 		row.FailedPodCount = podStat.failed
 		row.ErrorsByPod = podStat.errors
+		row.ProxyReadyPodCount = podStat.proxyReady
+		row.RestartCount = podStat.restarts
+		row.LastTerminationReason = podStat.lastTermination.reason
 
 		rows = append(rows, &row)
 	}
@@ -255,8 +275,9 @@ func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummar
 				Namespace: rkey.Namespace,
 				Name:      rkey.Name,
 			},
-			TimeWindow: req.TimeWindow,
-			Stats:      metrics,
+			TimeWindow:  req.TimeWindow,
+			Stats:       metrics,
+			ClusterName: s.clusterName,
 		}
 		rows = append(rows, &row)
 	}
@@ -318,6 +339,20 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 		labels = labels.Merge(promDirectionLabels("outbound"))
 
 	default:
+		if req.Selector.Resource.Type == k8s.Service {
+			// Services don't serve traffic themselves, so their stats come from
+			// the outbound metrics of their meshed clients, attributed to the
+			// service through the dst_service label.
+			labelNames = promDstGroupByLabelNames(req.Selector.Resource)
+
+			labels = labels.Merge(promDstQueryLabels(req.Selector.Resource))
+			if req.Selector.Resource.Namespace != "" {
+				labels[dstNamespaceLabel] = model.LabelValue(req.Selector.Resource.Namespace)
+			}
+			labels = labels.Merge(promDirectionLabels("outbound"))
+			break
+		}
+
 		labelNames = promGroupByLabelNames(req.Selector.Resource)
 
 		labels = labels.Merge(promQueryLabels(req.Selector.Resource))
@@ -436,6 +471,15 @@ func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 			if k8s.IsMeshed(pod, s.controllerNamespace) {
 				meshCount.inMesh++
 			}
+			if isProxyReady(pod) {
+				meshCount.proxyReady++
+			}
+		}
+
+		for _, st := range pod.Status.ContainerStatuses {
+			meshCount.restarts += uint64(st.RestartCount)
+			meshCount.lastTermination.update(st.State.Terminated)
+			meshCount.lastTermination.update(st.LastTerminationState.Terminated)
 		}
 
 		errors := checkContainerErrors(pod.Status.ContainerStatuses)
@@ -449,6 +493,27 @@ func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 	return meshCount, nil
 }
 
+func isProxyReady(pod *corev1.Pod) bool {
+	for _, st := range pod.Status.ContainerStatuses {
+		if st.Name == k8s.ProxyContainerName {
+			return st.Ready
+		}
+	}
+	return false
+}
+
+// update records the given termination if it is more recent than the one
+// already recorded.
+func (t *lastTermination) update(terminated *corev1.ContainerStateTerminated) {
+	if terminated == nil {
+		return
+	}
+	if t.reason == "" || t.finishedAt.Before(&terminated.FinishedAt) {
+		t.reason = terminated.Reason
+		t.finishedAt = terminated.FinishedAt
+	}
+}
+
 func toPodError(container, image, reason, message string) *pb.PodErrors_PodError {
 	return &pb.PodErrors_PodError{
 		Error: &pb.PodErrors_PodError_Container{
@@ -207,6 +207,114 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Reports proxy readiness, restarts and last termination reason for pods", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+  containerStatuses:
+  - name: emoji-svc
+    ready: true
+    restartCount: 3
+    lastState:
+      terminated:
+        reason: OOMKilled
+        finishedAt: 2019-04-01T10:00:00Z
+  - name: linkerd-proxy
+    ready: true
+    restartCount: 1
+    lastState:
+      terminated:
+        reason: Error
+        finishedAt: 2019-04-01T09:00:00Z
+`,
+					},
+					mockPromResponse: prometheusMetric("emojivoto-1", "pod"),
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					MeshedPods:            1,
+					RunningPods:           1,
+					FailedPods:            0,
+					ProxyReadyPods:        1,
+					Restarts:              4,
+					LastTerminationReason: "OOMKilled",
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Reports the configured cluster name", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			&mockProm{Res: prometheusMetric("emojivoto-1", "pod")},
+			nil,
+			nil,
+			k8sAPI,
+			"linkerd",
+			"east",
+			[]string{},
+			nil,
+		)
+		k8sAPI.Sync()
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Pod,
+				},
+			},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row, got %d", len(rows))
+		}
+		if rows[0].ClusterName != "east" {
+			t.Fatalf("Expected cluster name \"east\", got \"%s\"", rows[0].ClusterName)
+		}
+	})
+
 	t.Run("Successfully performs a query based on resource type DaemonSet", func(t *testing.T) {
 		expectations := []statSumExpected{
 			{
@@ -454,6 +562,73 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Successfully performs a query based on resource type Service", func(t *testing.T) {
+		expectations := []statSumExpected{
+			{
+				expectedStatRPC: expectedStatRPC{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: emoji-svc
+  namespace: emojivoto
+spec:
+  selector:
+    app: emoji-svc
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-not-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: model.Vector{
+						genPromSample("emoji-svc", "service", "emojivoto", true),
+					},
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto"}[1m])) by (le, dst_namespace, dst_service))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto"}[1m])) by (le, dst_namespace, dst_service))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto"}[1m])) by (le, dst_namespace, dst_service))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto"}[1m])) by (dst_namespace, dst_service, classification, tls)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Service,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("emoji-svc", pkgK8s.Service, []string{"emojivoto"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 2,
+					FailedPods:  0,
+				}, true, false),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Queries prometheus for TCP stats when requested", func(t *testing.T) {
 
 		expectations := []statSumExpected{
@@ -1092,7 +1267,9 @@ status:
 				nil,
 				k8sAPI,
 				"linkerd",
+				"",
 				[]string{},
+				nil,
 			)
 
 			_, err := fakeGrpcServer.StatSummary(context.TODO(), &exp.req)
@@ -1117,22 +1294,15 @@ status:
 			nil,
 			k8sAPI,
 			"linkerd",
+			"",
 			[]string{},
+			nil,
 		)
 
 		invalidRequests := []statSumExpected{
 			{
 				req: pb.StatSummaryRequest{},
 			},
-			{
-				req: pb.StatSummaryRequest{
-					Selector: &pb.ResourceSelection{
-						Resource: &pb.Resource{
-							Type: pkgK8s.Service,
-						},
-					},
-				},
-			},
 			{
 				req: pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
@@ -1172,6 +1342,15 @@ status:
 		}
 
 		validRequests := []statSumExpected{
+			{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Service,
+						},
+					},
+				},
+			},
 			{
 				req: pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
@@ -27,6 +27,7 @@ type MockAPIClient struct {
 	ListServicesResponseToReturn   *pb.ListServicesResponse
 	StatSummaryResponseToReturn    *pb.StatSummaryResponse
 	TopRoutesResponseToReturn      *pb.TopRoutesResponse
+	ListClientsResponseToReturn    *pb.ListClientsResponse
 	SelfCheckResponseToReturn      *healthcheckPb.SelfCheckResponse
 	ConfigResponseToReturn         *configPb.All
 	APITapClientToReturn           pb.Api_TapClient
@@ -44,6 +45,11 @@ func (c *MockAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest,
 	return c.TopRoutesResponseToReturn, c.ErrorToReturn
 }
 
+// ListClients provides a mock of a Public API method.
+func (c *MockAPIClient) ListClients(ctx context.Context, in *pb.ListClientsRequest, opts ...grpc.CallOption) (*pb.ListClientsResponse, error) {
+	return c.ListClientsResponseToReturn, c.ErrorToReturn
+}
+
 // Version provides a mock of a Public API method.
 func (c *MockAPIClient) Version(ctx context.Context, in *pb.Empty, opts ...grpc.CallOption) (*pb.VersionInfo, error) {
 	return c.VersionInfoToReturn, c.ErrorToReturn
@@ -116,9 +122,12 @@ type mockProm struct {
 // PodCounts is a test helper struct that is used for representing data in a
 // StatTable.PodGroup.Row.
 type PodCounts struct {
-	MeshedPods  uint64
-	RunningPods uint64
-	FailedPods  uint64
+	MeshedPods            uint64
+	RunningPods           uint64
+	FailedPods            uint64
+	ProxyReadyPods        uint64
+	Restarts              uint64
+	LastTerminationReason string
 }
 
 func (m *mockProm) Query(ctx context.Context, query string, ts time.Time) (model.Value, error) {
@@ -198,6 +207,9 @@ func GenStatSummaryResponse(resName, resType string, resNs []string, counts *Pod
 			statTableRow.MeshedPodCount = counts.MeshedPods
 			statTableRow.RunningPodCount = counts.RunningPods
 			statTableRow.FailedPodCount = counts.FailedPods
+			statTableRow.ProxyReadyPodCount = counts.ProxyReadyPods
+			statTableRow.RestartCount = counts.Restarts
+			statTableRow.LastTerminationReason = counts.LastTerminationReason
 		}
 
 		rows = append(rows, statTableRow)
@@ -296,7 +308,9 @@ func newMockGrpcServer(exp expectedStatRPC) (*mockProm, *grpcServer, error) {
 		nil,
 		k8sAPI,
 		"linkerd",
+		"",
 		[]string{},
+		nil,
 	)
 
 	k8sAPI.Sync()
@@ -50,6 +50,10 @@ func (s *grpcServer) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest) (*
 		return errRsp, nil
 	}
 
+	if ns := s.disallowedNamespace(req.GetSelector().GetResource(), req.GetToResource()); ns != "" {
+		return topRoutesError(req, fmt.Sprintf(namespaceNotAllowed, ns)), nil
+	}
+
 	// TopRoutes will return one table for each resource object requested.
 	tables := make([]resourceTable, 0)
 	targetResource := req.GetSelector().GetResource()
@@ -132,6 +136,12 @@ func (s *grpcServer) topRoutesFor(ctx context.Context, req *pb.TopRoutesRequest,
 		if err != nil {
 			return nil, err
 		}
+	} else if requestedResource.GetType() == k8s.Namespace {
+		// Namespaces may only be a ToResource; aggregate over all of its services.
+		profiles, err = s.getProfilesForNamespace(requestedResource, clientNs)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		// Non-authority resource.
 		// Lookup individual resource objects.
@@ -221,6 +231,28 @@ func (s *grpcServer) getProfilesForAuthority(authority string, clientNs string)
 	}, nil
 }
 
+func (s *grpcServer) getProfilesForNamespace(namespace *pb.Resource, clientNs string) (map[string]*sp.ServiceProfile, error) {
+	name := namespace.GetName()
+	if name == "" {
+		name = namespace.GetNamespace()
+	}
+
+	services, err := s.k8sAPI.Svc().Lister().Services(name).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(services) == 0 {
+		return nil, fmt.Errorf("No services found in namespace %s", name)
+	}
+
+	profiles := make(map[string]*sp.ServiceProfile)
+	for _, svc := range services {
+		profiles[svc.GetName()] = s.k8sAPI.GetServiceProfileFor(svc, clientNs)
+	}
+	return profiles, nil
+}
+
 func (s *grpcServer) getRouteMetrics(ctx context.Context, req *pb.TopRoutesRequest, profiles map[string]*sp.ServiceProfile, resource *pb.Resource) (indexedTable, error) {
 	timeWindow := req.TimeWindow
 
@@ -47,6 +47,20 @@ var (
 		k8s.Pod,
 		k8s.ReplicationController,
 		k8s.Service,
+		k8s.ServiceAccount,
+		k8s.StatefulSet,
+	}
+
+	// ValidTapSources specifies resource types allowed as a tap source:
+	// source resource on an inbound or outbound 'from' query
+	ValidTapSources = []string{
+		k8s.DaemonSet,
+		k8s.Deployment,
+		k8s.Job,
+		k8s.Namespace,
+		k8s.Pod,
+		k8s.ReplicationController,
+		k8s.ServiceAccount,
 		k8s.StatefulSet,
 	}
 )
@@ -87,15 +101,17 @@ type TopRoutesRequestParams struct {
 // TapRequestParams contains parameters that are used to build a
 // TapByResourceRequest.
 type TapRequestParams struct {
-	Resource    string
-	Namespace   string
-	ToResource  string
-	ToNamespace string
-	MaxRps      float32
-	Scheme      string
-	Method      string
-	Authority   string
-	Path        string
+	Resource      string
+	Namespace     string
+	ToResource    string
+	ToNamespace   string
+	FromResource  string
+	FromNamespace string
+	MaxRps        float32
+	Scheme        string
+	Method        string
+	Authority     string
+	Path          string
 }
 
 // GRPCError generates a gRPC error code, as defined in
@@ -400,7 +416,8 @@ func buildResource(namespace string, resType string, name string) (pb.Resource,
 }
 
 // BuildTapByResourceRequest builds a Public API TapByResourceRequest from a
-// TapRequestParams.
+// TapRequestParams. The "from" resource is looked up in the target's namespace
+// when FromNamespace is empty.
 func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceRequest, error) {
 	target, err := BuildResource(params.Namespace, params.Resource)
 	if err != nil {
@@ -431,6 +448,29 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 		matches = append(matches, &match)
 	}
 
+	if params.FromResource != "" {
+		fromNamespace := params.FromNamespace
+		if fromNamespace == "" {
+			fromNamespace = params.Namespace
+		}
+		source, err := BuildResource(fromNamespace, params.FromResource)
+		if err != nil {
+			return nil, fmt.Errorf("source resource invalid: %s", err)
+		}
+		if !contains(ValidTapSources, source.Type) {
+			return nil, fmt.Errorf("unsupported resource type [%s]", source.Type)
+		}
+
+		match := pb.TapByResourceRequest_Match{
+			Match: &pb.TapByResourceRequest_Match_Sources{
+				Sources: &pb.ResourceSelection{
+					Resource: &source,
+				},
+			},
+		}
+		matches = append(matches, &match)
+	}
+
 	if params.Scheme != "" {
 		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
 			Match: &pb.TapByResourceRequest_Match_Http_Scheme{Scheme: params.Scheme},
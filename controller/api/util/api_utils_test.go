@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"google.golang.org/grpc/codes"
@@ -190,6 +191,36 @@ func TestBuildTopRoutesRequest(t *testing.T) {
 	})
 }
 
+func TestBuildTapByResourceRequest(t *testing.T) {
+	t.Run("Matches requests from service accounts", func(t *testing.T) {
+		req, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:     "deploy/web",
+			Namespace:    "emojivoto",
+			FromResource: "sa/backup",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error from BuildTapByResourceRequest: %s", err)
+		}
+
+		expected := &pb.Resource{Namespace: "emojivoto", Type: k8s.ServiceAccount, Name: "backup"}
+		sources := req.GetMatch().GetAll().GetMatches()[0].GetSources().GetResource()
+		if !proto.Equal(sources, expected) {
+			t.Fatalf("Unexpected sources match: %+v, expected: %+v", sources, expected)
+		}
+	})
+
+	t.Run("Rejects services as sources", func(t *testing.T) {
+		_, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:     "deploy/web",
+			Namespace:    "emojivoto",
+			FromResource: "svc/web",
+		})
+		if err == nil || err.Error() != "unsupported resource type [service]" {
+			t.Fatalf("Unexpected error from BuildTapByResourceRequest: %v", err)
+		}
+	})
+}
+
 func TestBuildResource(t *testing.T) {
 	type resourceExp struct {
 		namespace string
@@ -1,19 +1,33 @@
 package main
 
 import (
+	"context"
+	cryptoTls "crypto/tls"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
+	identityPb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/controller/api/destination"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	idctl "github.com/linkerd/linkerd2/controller/identity"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/identity"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
@@ -24,6 +38,13 @@ func main() {
 	enableH2Upgrade := flag.Bool("enable-h2-upgrade", true, "Enable transparently upgraded HTTP2 connections among pods in the service mesh")
 	disableIdentity := flag.Bool("disable-identity", false, "Disable identity configuration")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	ipFamilyPreference := flag.String("ip-family-preference", "", "IP family (ipv4 or ipv6) to return when a service has addresses of both families; both are returned by default")
+	identityPeerNamespaces := flag.String("identity-peer-namespaces", "", "comma-separated namespaces of the control planes sharing the trust anchors of this one, e.g. a canary, whose proxies are given TLS identities")
+	podMetricLabels := flag.String("pod-metric-labels", "", "comma-separated keys of the pod labels to add to the metric labels of their addresses, e.g. version")
+	drainTimeout := flag.Duration("drain-timeout", 10*time.Second, "how long to keep serving existing streams after receiving a shutdown signal")
+	enableTLS := flag.Bool("tls", false, "serve proxies over TLS, verifying their certificates; plaintext is only served over the loopback interface")
+	identityAddr := flag.String("identity-addr", "", "address of the identity service the certificate served with -tls is obtained from (default: the identity service of the controller namespace)")
+	serviceAccount := flag.String("service-account", "linkerd-controller", "service account whose identity is served with -tls")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -37,6 +58,7 @@ func main() {
 		log.Fatalf("Failed to initialize K8s API: %s", err)
 	}
 
+	draining := make(chan struct{})
 	done := make(chan struct{})
 
 	lis, err := net.Listen("tcp", *addr)
@@ -45,7 +67,11 @@ func main() {
 	}
 
 	trustDomain := ""
+	var opts []grpc.ServerOption
 	if *disableIdentity {
+		if *enableTLS {
+			log.Fatal("-tls requires identity")
+		}
 		log.Info("Identity is disabled")
 	} else {
 		global, err := config.Global(consts.MountPathGlobalConfig)
@@ -54,16 +80,36 @@ func main() {
 		}
 
 		trustDomain = global.GetIdentityContext().GetTrustDomain()
+
+		if *enableTLS {
+			if *identityAddr == "" {
+				*identityAddr = fmt.Sprintf("linkerd-identity.%s.svc:8080", *controllerNamespace)
+			}
+			creds, err := newServerCredentials(*identityAddr, *controllerNamespace, *serviceAccount, global.GetIdentityContext(), done)
+			if err != nil {
+				log.Fatalf("Failed to obtain the server certificate: %s", err)
+			}
+			roots, err := tls.DecodePEMCertPool(global.GetIdentityContext().GetTrustAnchorsPem())
+			if err != nil {
+				log.Fatalf("Failed to read trust anchors: %s", err)
+			}
+			opts = append(opts, grpc.Creds(identity.NewServerTransportCredentials(creds, roots, *controllerNamespace, trustDomain, true)))
+		}
 	}
 
 	server, err := destination.NewServer(
 		*addr,
 		*k8sDNSZone,
+		*ipFamilyPreference,
 		*controllerNamespace,
 		trustDomain,
+		splitList(*identityPeerNamespaces),
 		*enableH2Upgrade,
+		splitList(*podMetricLabels),
 		k8sAPI,
+		draining,
 		done,
+		opts...,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -71,6 +117,10 @@ func main() {
 
 	k8sAPI.Sync() // blocks until caches are synced
 
+	// The global config is only read on startup, so the service restarts to
+	// apply a new one.
+	go config.Watch(k8sAPI.Client, *controllerNamespace, "linkerd-destination", config.RestartOnChange(stop, filepath.Base(consts.MountPathGlobalConfig)), nil)
+
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
 		server.Serve(lis)
@@ -80,7 +130,90 @@ func main() {
 
 	<-stop
 
+	// Refuse new streams but keep existing ones updated until the drain period
+	// ends, so that proxies aren't left without resolutions mid-rollout. A
+	// second signal skips the remainder of the drain period.
+	log.Infof("draining gRPC server on %s for %s", *addr, *drainTimeout)
+	close(draining)
+	select {
+	case <-time.After(*drainTimeout):
+	case <-stop:
+	}
+
 	log.Infof("shutting down gRPC server on %s", *addr)
 	close(done)
 	server.GracefulStop()
 }
+
+// newServerCredentials obtains the certificate of the service account from the
+// identity service, as the proxies do, and keeps renewing it until done is
+// closed.
+func newServerCredentials(identityAddr, controllerNS, serviceAccount string, idctx *pb.IdentityContext, done <-chan struct{}) (*identity.ServerCredentials, error) {
+	roots, err := tls.DecodePEMCertPool(idctx.GetTrustAnchorsPem())
+	if err != nil {
+		return nil, err
+	}
+
+	dom, err := idctl.NewTrustDomain(controllerNS, idctx.GetTrustDomain())
+	if err != nil {
+		return nil, err
+	}
+	name, err := dom.Identity("serviceaccount", serviceAccount, controllerNS)
+	if err != nil {
+		return nil, err
+	}
+	identityName, err := dom.Identity("serviceaccount", "linkerd-identity", controllerNS)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(identityAddr, grpc.WithTransportCredentials(credentials.NewTLS(&cryptoTls.Config{
+		RootCAs:    roots,
+		ServerName: identityName,
+	})))
+	if err != nil {
+		return nil, err
+	}
+	client := identityPb.NewIdentityClient(conn)
+
+	issue := func() (*tls.Cred, error) {
+		tok, err := ioutil.ReadFile(consts.IdentityServiceAccountTokenPath)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return identity.Certify(ctx, client, name, tok)
+	}
+
+	// The identity service may still be starting.
+	creds := &identity.ServerCredentials{}
+	for {
+		cred, err := issue()
+		if err == nil {
+			if err := creds.Set(cred); err != nil {
+				return nil, err
+			}
+			log.Infof("certified %s until %s", name, cred.Certificate.NotAfter)
+			break
+		}
+		log.Warnf("Failed to certify %s, retrying: %s", name, err)
+		time.Sleep(5 * time.Second)
+	}
+
+	go func() {
+		identity.RenewServerCredentials(creds, issue, done)
+		conn.Close()
+	}()
+	return creds, nil
+}
+
+func splitList(list string) []string {
+	split := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			split = append(split, item)
+		}
+	}
+	return split
+}
@@ -8,12 +8,14 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	idctl "github.com/linkerd/linkerd2/controller/identity"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/events"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	"github.com/linkerd/linkerd2/pkg/identity"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
@@ -32,6 +34,7 @@ func main() {
 	issuerPath := flag.String("issuer",
 		"/var/run/linkerd/identity/issuer",
 		"path to directory containing issuer credentials")
+	enableTLS := flag.Bool("tls", false, "serve proxies over TLS, verifying their certificates if they have one")
 	flags.ConfigureAndParse()
 
 	cfg, err := config.Global(consts.MountPathGlobalConfig)
@@ -100,12 +103,24 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load kubeconfig: %s: %s", *kubeConfigPath, err)
 	}
+
+	// Record the loading of the issuer, so that its rotations show in the
+	// events of the mesh.
+	event := events.NewIssuerLoadedEvent(controllerNS, creds.Crt.Certificate.NotAfter, time.Now())
+	if _, err := k8s.CoreV1().Events(controllerNS).Create(event); err != nil {
+		log.Warnf("Failed to record the loading of the issuer: %s", err)
+	}
+	// The config is only read on startup, so the service restarts to apply a
+	// new one.
+	go config.Watch(k8s, controllerNS, "linkerd-identity", config.RestartOnChange(stop, filepath.Base(consts.MountPathGlobalConfig)), nil)
+
 	v, err := idctl.NewK8sTokenValidator(k8s, dom)
 	if err != nil {
 		log.Fatalf("Failed to initialize identity service: %s", err)
 	}
 
-	svc := identity.NewService(v, ca)
+	lifetimes := idctl.NewNamespaceLifetimes(k8s, validity.Lifetime)
+	svc := identity.NewService(v, ca).WithIssuanceLifetimes(lifetimes)
 
 	go admin.StartServer(*adminAddr)
 	lis, err := net.Listen("tcp", *addr)
@@ -113,7 +128,31 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %s", *addr, err)
 	}
 
-	srv := grpc.NewServer()
+	done := make(chan struct{})
+	var opts []grpc.ServerOption
+	if *enableTLS {
+		// The service issues its own certificate, which proxies expect to be
+		// the identity of its service account.
+		name, err := dom.Identity("serviceaccount", "linkerd-identity", controllerNS)
+		if err != nil {
+			log.Fatalf("Invalid identity: %s", err)
+		}
+		issue := func() (*tls.Cred, error) { return ca.GenerateEndEntityCred(name) }
+
+		creds := &identity.ServerCredentials{}
+		cred, err := issue()
+		if err != nil {
+			log.Fatalf("Failed to issue the certificate of %s: %s", name, err)
+		}
+		if err := creds.Set(cred); err != nil {
+			log.Fatalf("Invalid certificate for %s: %s", name, err)
+		}
+		go identity.RenewServerCredentials(creds, issue, done)
+
+		opts = append(opts, grpc.Creds(identity.NewServerTransportCredentials(creds, trustAnchors, controllerNS, trustDomain, false)))
+	}
+
+	srv := grpc.NewServer(opts...)
 	identity.Register(srv, svc)
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
@@ -121,5 +160,6 @@ func main() {
 	}()
 	<-stop
 	log.Infof("shutting down gRPC server on %s", *addr)
+	close(done)
 	srv.GracefulStop()
 }
@@ -12,6 +12,7 @@ func main() {
 	config := &webhook.Config{
 		TemplateStr: tmpl.MutatingWebhookConfigurationSpec,
 		Ops:         &injector.Ops{},
+		Background:  injector.WatchConfig,
 	}
 	webhook.Launch(
 		config,
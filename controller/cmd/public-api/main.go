@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
 	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	promApi "github.com/prometheus/client_golang/api"
 	log "github.com/sirupsen/logrus"
@@ -26,12 +28,24 @@ func main() {
 	destinationAPIAddr := flag.String("destination-addr", "127.0.0.1:8086", "address of destination service")
 	tapAddr := flag.String("tap-addr", "127.0.0.1:8088", "address of tap service")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	clusterName := flag.String("cluster-name", "", "name of the cluster reported in API responses")
 	ignoredNamespaces := flag.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
+	allowedNamespaces := flag.String("allowed-namespaces", "", "comma separated list of namespaces to answer queries about; all namespaces are allowed when empty")
+	rolloutMetricsTokenFile := flag.String("rollout-metrics-token-file", "", "path to a bearer token authenticating the requests to the rollout metrics endpoint polled by progressive delivery controllers; the endpoint is disabled when empty")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	rolloutMetricsToken := ""
+	if *rolloutMetricsTokenFile != "" {
+		token, err := ioutil.ReadFile(*rolloutMetricsTokenFile)
+		if err != nil {
+			log.Fatalf("failed to read the rollout metrics token: %s", err)
+		}
+		rolloutMetricsToken = strings.TrimSpace(string(token))
+	}
+
 	tapClient, tapConn, err := tap.NewClient(*tapAddr)
 	if err != nil {
 		log.Fatal(err.Error())
@@ -64,11 +78,17 @@ func main() {
 		discoveryClient,
 		k8sAPI,
 		*controllerNamespace,
+		*clusterName,
 		strings.Split(*ignoredNamespaces, ","),
+		splitNamespaces(*allowedNamespaces),
+		rolloutMetricsToken,
 	)
 
 	k8sAPI.Sync() // blocks until caches are synced
 
+	// The config is read on each request, so its changes only need reporting.
+	go config.Watch(k8sAPI.Client, *controllerNamespace, "linkerd-public-api", nil, nil)
+
 	go func() {
 		log.Infof("starting HTTP server on %+v", *addr)
 		server.ListenAndServe()
@@ -81,3 +101,10 @@ func main() {
 	log.Infof("shutting down HTTP server on %+v", *addr)
 	server.Shutdown(context.Background())
 }
+
+func splitNamespaces(namespaces string) []string {
+	if namespaces == "" {
+		return nil
+	}
+	return strings.Split(namespaces, ",")
+}
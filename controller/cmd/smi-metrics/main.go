@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/controller/smi"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+var apiServiceResource = schema.GroupVersionResource{
+	Group:    "apiregistration.k8s.io",
+	Version:  "v1",
+	Resource: "apiservices",
+}
+
+func main() {
+	addr := flag.String("addr", ":8443", "address to serve on")
+	metricsAddr := flag.String("metrics-addr", ":9993", "address to serve scrapable metrics on")
+	apiAddr := flag.String("api-addr", "127.0.0.1:8085", "address of the public API")
+	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	flags.ConfigureAndParse()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	client, err := public.NewInternalClient(*controllerNamespace, *apiAddr)
+	if err != nil {
+		log.Fatalf("Failed to construct client for API server URL %s: %s", *apiAddr, err)
+	}
+
+	k8sClient, err := k8s.NewClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize K8s API: %s", err)
+	}
+
+	frontProxy, err := smi.FetchFrontProxyConfig(k8sClient)
+	if err != nil {
+		log.Fatalf("Failed to read the configuration of the API aggregator: %s", err)
+	}
+
+	rootCA, err := pkgTls.GenerateRootCAWithDefaults(pkgK8s.SMIMetricsServiceName)
+	if err != nil {
+		log.Fatalf("Failed to create root CA: %s", err)
+	}
+
+	tlsConfig, err := tlsConfig(rootCA, pkgK8s.SMIMetricsServiceName, *controllerNamespace)
+	if err != nil {
+		log.Fatalf("Failed to generate the TLS certificate: %s", err)
+	}
+	// The front proxy of the API aggregator authenticates with a client
+	// certificate, which the handler requires.
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	tlsConfig.ClientCAs = frontProxy.ClientCAs
+
+	if err := setCABundle(*kubeConfigPath, rootCA); err != nil {
+		log.Fatalf("Failed to set the CA bundle of the %s APIService: %s", pkgK8s.SMIMetricsAPIServiceName, err)
+	}
+
+	server := &http.Server{
+		Addr:      *addr,
+		Handler:   smi.NewAuthHandler(k8sClient, frontProxy, smi.NewHandler(client)),
+		TLSConfig: tlsConfig,
+	}
+
+	go func() {
+		log.Infof("starting HTTPS server on %+v", *addr)
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	go admin.StartServer(*metricsAddr)
+
+	<-stop
+
+	log.Infof("shutting down HTTPS server on %+v", *addr)
+	server.Shutdown(context.Background())
+}
+
+// tlsConfig returns a certificate for the service, issued by a root CA
+// generated on startup, which setCABundle has the Kubernetes API aggregator
+// trust.
+func tlsConfig(rootCA *pkgTls.CA, name, controllerNamespace string) (*tls.Config, error) {
+	cred, err := rootCA.GenerateEndEntityCred(fmt.Sprintf("%s.%s.svc", name, controllerNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair([]byte(cred.EncodePEM()), []byte(cred.EncodePrivateKeyPEM()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// setCABundle sets the certificate of the root CA as the CA bundle of the
+// APIService registering the API, so that the Kubernetes API aggregator
+// verifies the certificate of the service.
+func setCABundle(kubeConfigPath string, rootCA *pkgTls.CA) error {
+	config, err := pkgK8s.GetConfig(kubeConfigPath, "")
+	if err != nil {
+		return err
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	caBundle := base64.StdEncoding.EncodeToString([]byte(rootCA.Cred.EncodeCertificatePEM()))
+	patch := fmt.Sprintf(`{"spec":{"caBundle":"%s"}}`, caBundle)
+	_, err = client.Resource(apiServiceResource).
+		Patch(pkgK8s.SMIMetricsAPIServiceName, types.MergePatchType, []byte(patch), metav1.UpdateOptions{})
+	return err
+}
@@ -38,6 +38,16 @@ type RouteSpec struct {
 	ResponseClasses []*ResponseClass `json:"responseClasses,omitempty"`
 	IsRetryable     bool             `json:"isRetryable,omitempty"`
 	Timeout         string           `json:"timeout,omitempty"`
+	SLO             *RouteSLO        `json:"slo,omitempty"`
+}
+
+// RouteSLO describes the service level objectives of a Route, from which
+// Prometheus alerting rules are generated.
+type RouteSLO struct {
+	MinSuccessRate *float32 `json:"minSuccessRate,omitempty"`
+	MaxLatencyP99  string   `json:"maxLatencyP99,omitempty"`
+	Window         string   `json:"window,omitempty"`
+	For            string   `json:"for,omitempty"`
 }
 
 // RequestMatch describes the conditions under which to match a Route.
@@ -168,6 +168,27 @@ func (in *RetryBudget) DeepCopy() *RetryBudget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSLO) DeepCopyInto(out *RouteSLO) {
+	*out = *in
+	if in.MinSuccessRate != nil {
+		in, out := &in.MinSuccessRate, &out.MinSuccessRate
+		*out = new(float32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSLO.
+func (in *RouteSLO) DeepCopy() *RouteSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
@@ -187,6 +208,11 @@ func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 			}
 		}
 	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = new(RouteSLO)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -32,7 +32,7 @@ func (m *All) Reset()         { *m = All{} }
 func (m *All) String() string { return proto.CompactTextString(m) }
 func (*All) ProtoMessage()    {}
 func (*All) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{0}
+	return fileDescriptor_config_ca2c60894922daf9, []int{0}
 }
 func (m *All) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_All.Unmarshal(m, b)
@@ -81,17 +81,19 @@ type Global struct {
 	IdentityContext *IdentityContext `protobuf:"bytes,4,opt,name=identity_context,json=identityContext,proto3" json:"identity_context,omitempty"`
 	// If present, indicates that the Mutating Webhook Admission Controller should
 	// be configured to automatically inject proxies.
-	AutoInjectContext    *AutoInjectContext `protobuf:"bytes,6,opt,name=auto_inject_context,json=autoInjectContext,proto3" json:"auto_inject_context,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	AutoInjectContext *AutoInjectContext `protobuf:"bytes,6,opt,name=auto_inject_context,json=autoInjectContext,proto3" json:"auto_inject_context,omitempty"`
+	// If present, identifies the cluster in metrics and API responses.
+	ClusterName          string   `protobuf:"bytes,7,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Global) Reset()         { *m = Global{} }
 func (m *Global) String() string { return proto.CompactTextString(m) }
 func (*Global) ProtoMessage()    {}
 func (*Global) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{1}
+	return fileDescriptor_config_ca2c60894922daf9, []int{1}
 }
 func (m *Global) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Global.Unmarshal(m, b)
@@ -146,6 +148,13 @@ func (m *Global) GetAutoInjectContext() *AutoInjectContext {
 	return nil
 }
 
+func (m *Global) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
 type Proxy struct {
 	ProxyImage              *Image                `protobuf:"bytes,1,opt,name=proxy_image,json=proxyImage,proto3" json:"proxy_image,omitempty"`
 	ProxyInitImage          *Image                `protobuf:"bytes,2,opt,name=proxy_init_image,json=proxyInitImage,proto3" json:"proxy_init_image,omitempty"`
@@ -159,16 +168,23 @@ type Proxy struct {
 	ProxyUid                int64                 `protobuf:"varint,10,opt,name=proxy_uid,json=proxyUid,proto3" json:"proxy_uid,omitempty"`
 	LogLevel                *LogLevel             `protobuf:"bytes,11,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
 	DisableExternalProfiles bool                  `protobuf:"varint,12,opt,name=disable_external_profiles,json=disableExternalProfiles,proto3" json:"disable_external_profiles,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}              `json:"-"`
-	XXX_unrecognized        []byte                `json:"-"`
-	XXX_sizecache           int32                 `json:"-"`
+	// Extra labels added to the pods of injected workloads, e.g. to attribute
+	// the cost of the proxies. Each label is exported as a metric label too.
+	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// DNS suffixes of the names the proxies resolve through the destination
+	// service. Other names are resolved with DNS only. When empty, the proxy's
+	// default suffixes are used.
+	DestinationGetSuffixes []string `protobuf:"bytes,14,rep,name=destination_get_suffixes,json=destinationGetSuffixes,proto3" json:"destination_get_suffixes,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *Proxy) Reset()         { *m = Proxy{} }
 func (m *Proxy) String() string { return proto.CompactTextString(m) }
 func (*Proxy) ProtoMessage()    {}
 func (*Proxy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{2}
+	return fileDescriptor_config_ca2c60894922daf9, []int{2}
 }
 func (m *Proxy) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proxy.Unmarshal(m, b)
@@ -272,6 +288,20 @@ func (m *Proxy) GetDisableExternalProfiles() bool {
 	return false
 }
 
+func (m *Proxy) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Proxy) GetDestinationGetSuffixes() []string {
+	if m != nil {
+		return m.DestinationGetSuffixes
+	}
+	return nil
+}
+
 type Image struct {
 	ImageName            string   `protobuf:"bytes,1,opt,name=image_name,json=imageName,proto3" json:"image_name,omitempty"`
 	PullPolicy           string   `protobuf:"bytes,2,opt,name=pull_policy,json=pullPolicy,proto3" json:"pull_policy,omitempty"`
@@ -284,7 +314,7 @@ func (m *Image) Reset()         { *m = Image{} }
 func (m *Image) String() string { return proto.CompactTextString(m) }
 func (*Image) ProtoMessage()    {}
 func (*Image) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{3}
+	return fileDescriptor_config_ca2c60894922daf9, []int{3}
 }
 func (m *Image) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Image.Unmarshal(m, b)
@@ -329,7 +359,7 @@ func (m *Port) Reset()         { *m = Port{} }
 func (m *Port) String() string { return proto.CompactTextString(m) }
 func (*Port) ProtoMessage()    {}
 func (*Port) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{4}
+	return fileDescriptor_config_ca2c60894922daf9, []int{4}
 }
 func (m *Port) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Port.Unmarshal(m, b)
@@ -370,7 +400,7 @@ func (m *ResourceRequirements) Reset()         { *m = ResourceRequirements{} }
 func (m *ResourceRequirements) String() string { return proto.CompactTextString(m) }
 func (*ResourceRequirements) ProtoMessage()    {}
 func (*ResourceRequirements) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{5}
+	return fileDescriptor_config_ca2c60894922daf9, []int{5}
 }
 func (m *ResourceRequirements) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResourceRequirements.Unmarshal(m, b)
@@ -429,7 +459,7 @@ func (m *AutoInjectContext) Reset()         { *m = AutoInjectContext{} }
 func (m *AutoInjectContext) String() string { return proto.CompactTextString(m) }
 func (*AutoInjectContext) ProtoMessage()    {}
 func (*AutoInjectContext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{6}
+	return fileDescriptor_config_ca2c60894922daf9, []int{6}
 }
 func (m *AutoInjectContext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AutoInjectContext.Unmarshal(m, b)
@@ -463,7 +493,7 @@ func (m *IdentityContext) Reset()         { *m = IdentityContext{} }
 func (m *IdentityContext) String() string { return proto.CompactTextString(m) }
 func (*IdentityContext) ProtoMessage()    {}
 func (*IdentityContext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{7}
+	return fileDescriptor_config_ca2c60894922daf9, []int{7}
 }
 func (m *IdentityContext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdentityContext.Unmarshal(m, b)
@@ -522,7 +552,7 @@ func (m *LogLevel) Reset()         { *m = LogLevel{} }
 func (m *LogLevel) String() string { return proto.CompactTextString(m) }
 func (*LogLevel) ProtoMessage()    {}
 func (*LogLevel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{8}
+	return fileDescriptor_config_ca2c60894922daf9, []int{8}
 }
 func (m *LogLevel) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevel.Unmarshal(m, b)
@@ -569,7 +599,7 @@ func (m *Install) Reset()         { *m = Install{} }
 func (m *Install) String() string { return proto.CompactTextString(m) }
 func (*Install) ProtoMessage()    {}
 func (*Install) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{9}
+	return fileDescriptor_config_ca2c60894922daf9, []int{9}
 }
 func (m *Install) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Install.Unmarshal(m, b)
@@ -622,7 +652,7 @@ func (m *Install_Flag) Reset()         { *m = Install_Flag{} }
 func (m *Install_Flag) String() string { return proto.CompactTextString(m) }
 func (*Install_Flag) ProtoMessage()    {}
 func (*Install_Flag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_config_60b7129b7a79a23f, []int{9, 0}
+	return fileDescriptor_config_ca2c60894922daf9, []int{9, 0}
 }
 func (m *Install_Flag) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Install_Flag.Unmarshal(m, b)
@@ -660,6 +690,7 @@ func init() {
 	proto.RegisterType((*All)(nil), "linkerd2.config.All")
 	proto.RegisterType((*Global)(nil), "linkerd2.config.Global")
 	proto.RegisterType((*Proxy)(nil), "linkerd2.config.Proxy")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.config.Proxy.LabelsEntry")
 	proto.RegisterType((*Image)(nil), "linkerd2.config.Image")
 	proto.RegisterType((*Port)(nil), "linkerd2.config.Port")
 	proto.RegisterType((*ResourceRequirements)(nil), "linkerd2.config.ResourceRequirements")
@@ -670,66 +701,72 @@ func init() {
 	proto.RegisterType((*Install_Flag)(nil), "linkerd2.config.Install.Flag")
 }
 
-func init() { proto.RegisterFile("config/config.proto", fileDescriptor_config_60b7129b7a79a23f) }
-
-var fileDescriptor_config_60b7129b7a79a23f = []byte{
-	// 918 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0xcf, 0x6f, 0x23, 0x35,
-	0x14, 0x56, 0xda, 0xa4, 0x4d, 0x5e, 0xd2, 0x6d, 0xe3, 0x76, 0xd9, 0x69, 0xd1, 0x42, 0x18, 0x69,
-	0xa5, 0x15, 0xa0, 0x04, 0x5a, 0x04, 0xab, 0x3d, 0x11, 0xf6, 0x47, 0x15, 0x6d, 0x81, 0xca, 0x08,
-	0x0e, 0x5c, 0x46, 0xce, 0x8c, 0x33, 0x6b, 0xea, 0xb1, 0xb3, 0x1e, 0xbb, 0xed, 0xfe, 0x19, 0xdc,
-	0x38, 0x71, 0xe3, 0xff, 0xe1, 0x9f, 0xe1, 0x8e, 0xfc, 0xec, 0x59, 0xba, 0x0d, 0xcd, 0x69, 0xec,
-	0xef, 0x7d, 0xdf, 0xe7, 0x37, 0xf6, 0xf3, 0x33, 0xec, 0xe7, 0x5a, 0x2d, 0x44, 0x39, 0x09, 0x9f,
-	0xf1, 0xd2, 0x68, 0xab, 0xc9, 0xae, 0x14, 0xea, 0x82, 0x9b, 0xe2, 0x78, 0x1c, 0xe0, 0xa3, 0x8f,
-	0x4a, 0xad, 0x4b, 0xc9, 0x27, 0x18, 0x9e, 0xbb, 0xc5, 0xa4, 0x70, 0x86, 0x59, 0xa1, 0x55, 0x10,
-	0xa4, 0x7f, 0xb4, 0x60, 0x73, 0x2a, 0x25, 0x99, 0xc0, 0x56, 0x29, 0xf5, 0x9c, 0xc9, 0xa4, 0x35,
-	0x6a, 0x3d, 0xee, 0x1f, 0x3f, 0x18, 0xdf, 0x72, 0x1a, 0x9f, 0x62, 0x98, 0x46, 0x1a, 0xf9, 0x1c,
-	0x3a, 0x4b, 0xa3, 0xaf, 0xdf, 0x26, 0x1b, 0xc8, 0xff, 0x60, 0x85, 0x7f, 0xee, 0xa3, 0x34, 0x90,
-	0xc8, 0x31, 0x6c, 0x0b, 0x55, 0x5b, 0x26, 0x65, 0xb2, 0x89, 0xfc, 0x64, 0x85, 0x3f, 0x0b, 0x71,
-	0xda, 0x10, 0xd3, 0xdf, 0x37, 0x60, 0x2b, 0x2c, 0x4a, 0x3e, 0x83, 0x61, 0xa4, 0x67, 0x8a, 0x55,
-	0xbc, 0x5e, 0xb2, 0x9c, 0x63, 0xa2, 0x3d, 0xba, 0x17, 0x03, 0x3f, 0x34, 0x38, 0xf9, 0x18, 0xfa,
-	0xb9, 0x12, 0x19, 0x57, 0x6c, 0x2e, 0x79, 0x81, 0xf9, 0x75, 0x29, 0xe4, 0x4a, 0xbc, 0x08, 0x08,
-	0x49, 0x60, 0xfb, 0x92, 0x9b, 0x5a, 0x68, 0x85, 0xc9, 0xf4, 0x68, 0x33, 0x25, 0xaf, 0x60, 0x4f,
-	0x14, 0x5c, 0x59, 0x61, 0xdf, 0x66, 0xb9, 0x56, 0x96, 0x5f, 0xdb, 0xa4, 0x8d, 0xf9, 0x8e, 0x56,
-	0xf3, 0x8d, 0xc4, 0x67, 0x81, 0x47, 0x77, 0xc5, 0xfb, 0x00, 0xa1, 0xb0, 0xcf, 0x9c, 0xd5, 0x99,
-	0x50, 0xbf, 0xf1, 0xdc, 0xbe, 0xf3, 0xdb, 0x42, 0xbf, 0x74, 0xc5, 0x6f, 0xea, 0xac, 0x9e, 0x21,
-	0xb5, 0x71, 0x1c, 0xb2, 0xdb, 0x50, 0xfa, 0x77, 0x07, 0x3a, 0xb8, 0xb1, 0xe4, 0x1b, 0xe8, 0xe3,
-	0xd6, 0x66, 0xa2, 0x62, 0x25, 0x8f, 0xa7, 0xb6, 0x7a, 0x0a, 0x33, 0x1f, 0xa5, 0x80, 0x54, 0x1c,
-	0x93, 0x6f, 0x61, 0x2f, 0x0a, 0x95, 0xb0, 0x51, 0xbd, 0xb1, 0x56, 0x7d, 0x2f, 0xa8, 0x95, 0xb0,
-	0xc1, 0xe1, 0x09, 0x0c, 0xfc, 0xcf, 0x18, 0x2d, 0xb3, 0xa5, 0x36, 0x36, 0x9e, 0xe8, 0xfd, 0xd5,
-	0x0a, 0xd0, 0xc6, 0xd2, 0x7e, 0xa4, 0xfa, 0x09, 0x39, 0x85, 0x03, 0x51, 0x2a, 0x6d, 0x78, 0x26,
-	0xd4, 0x5c, 0x3b, 0x55, 0xa0, 0x41, 0x9d, 0xb4, 0x47, 0x9b, 0x77, 0x3b, 0x90, 0x20, 0x99, 0x05,
-	0x85, 0x87, 0x6a, 0x32, 0x83, 0xfb, 0xd1, 0x48, 0x3b, 0x7b, 0xd3, 0xa9, 0xb3, 0xce, 0x69, 0x3f,
-	0x68, 0x7e, 0x8c, 0x92, 0x60, 0xf5, 0x04, 0x06, 0x37, 0x93, 0x89, 0xe7, 0x73, 0xd7, 0xdf, 0x88,
-	0xff, 0xb2, 0x20, 0x5f, 0x01, 0xb0, 0xa2, 0x12, 0x2a, 0xe8, 0xb6, 0xd7, 0xe9, 0x7a, 0x48, 0x44,
-	0xd5, 0x53, 0xd8, 0x79, 0x2f, 0xe7, 0xa4, 0xbb, 0x4e, 0x38, 0xd0, 0x37, 0x92, 0x25, 0x53, 0xe8,
-	0x1a, 0x5e, 0x6b, 0x67, 0x72, 0x9e, 0xf4, 0x50, 0xf6, 0x68, 0x45, 0x46, 0x23, 0x81, 0xf2, 0x37,
-	0x4e, 0x18, 0x5e, 0x71, 0x65, 0x6b, 0xfa, 0x4e, 0x46, 0x3e, 0x84, 0x5e, 0x38, 0x7e, 0x27, 0x8a,
-	0x04, 0x46, 0xad, 0xc7, 0x9b, 0xb4, 0x8b, 0xc0, 0xcf, 0xa2, 0x20, 0x5f, 0x43, 0x4f, 0xea, 0x32,
-	0x93, 0xfc, 0x92, 0xcb, 0xa4, 0x8f, 0x0b, 0x1c, 0xae, 0x2c, 0x70, 0xa6, 0xcb, 0x33, 0x4f, 0xa0,
-	0x5d, 0x19, 0x47, 0xe4, 0x29, 0x1c, 0x16, 0xa2, 0xf6, 0xb7, 0x2b, 0xe3, 0xd7, 0x96, 0x1b, 0xc5,
-	0x64, 0xb6, 0x34, 0x7a, 0x21, 0x24, 0xaf, 0x93, 0x01, 0x5e, 0xc0, 0x07, 0x91, 0xf0, 0x22, 0xc6,
-	0xcf, 0x63, 0x38, 0x3d, 0x85, 0x4e, 0x28, 0xab, 0x87, 0x00, 0x58, 0x8d, 0x78, 0xc5, 0xe3, 0xed,
-	0xee, 0x21, 0xe2, 0xef, 0xb6, 0xbf, 0xd6, 0x4b, 0x27, 0x7d, 0xc9, 0x49, 0x91, 0x87, 0xb6, 0xd3,
-	0xa3, 0xe0, 0xa1, 0x73, 0x44, 0xd2, 0x23, 0x68, 0xe3, 0x26, 0x11, 0x68, 0xe3, 0xbe, 0x7a, 0x87,
-	0x1d, 0x8a, 0xe3, 0xf4, 0xcf, 0x16, 0x1c, 0xfc, 0xdf, 0xc6, 0x78, 0x57, 0xc3, 0xdf, 0x38, 0x5e,
-	0xdb, 0x2c, 0x5f, 0xba, 0xb8, 0x2a, 0x44, 0xe8, 0xd9, 0xd2, 0x91, 0x47, 0x70, 0xaf, 0x21, 0x54,
-	0xbc, 0xd2, 0xa6, 0x59, 0x79, 0x27, 0xa2, 0xdf, 0x23, 0xe8, 0xb7, 0x55, 0x8a, 0x4a, 0x04, 0x97,
-	0xd0, 0x55, 0xba, 0x08, 0x78, 0x8f, 0x4f, 0x60, 0x10, 0x82, 0xd1, 0xa1, 0x8d, 0xf1, 0x3e, 0x62,
-	0x41, 0x9f, 0xee, 0xc3, 0x70, 0xa5, 0x01, 0xa4, 0xff, 0xb4, 0x60, 0xf7, 0x56, 0x9b, 0xf1, 0x5e,
-	0xd6, 0xb8, 0xda, 0x66, 0x85, 0xae, 0x98, 0x50, 0x31, 0xe3, 0x3e, 0x62, 0xcf, 0x11, 0x22, 0x9f,
-	0xc2, 0x30, 0x50, 0x98, 0xca, 0x5f, 0x6b, 0x53, 0x67, 0x4b, 0x5e, 0xc5, 0xac, 0x77, 0x31, 0x30,
-	0x0d, 0xf8, 0x39, 0xaf, 0xc8, 0x4b, 0x18, 0x8a, 0xba, 0x76, 0x4c, 0xe5, 0x3c, 0x93, 0x62, 0xc1,
-	0xad, 0xa8, 0x78, 0xbc, 0xd0, 0x87, 0xe3, 0xf0, 0x76, 0x8c, 0x9b, 0xb7, 0x63, 0xfc, 0x3c, 0xbe,
-	0x1d, 0x74, 0xaf, 0xd1, 0x9c, 0x45, 0x09, 0x79, 0x05, 0x07, 0xb9, 0xd4, 0xf9, 0x45, 0x56, 0x5f,
-	0xf0, 0xab, 0x8c, 0x49, 0xa9, 0xaf, 0x7c, 0x3c, 0x76, 0xcf, 0x35, 0x56, 0x04, 0x65, 0x3f, 0x5d,
-	0xf0, 0xab, 0x69, 0x23, 0x4a, 0x47, 0xd0, 0x6d, 0x8a, 0x8c, 0x1c, 0x40, 0x27, 0x94, 0x63, 0xf8,
-	0xd1, 0x30, 0x49, 0xff, 0x6a, 0xc1, 0x76, 0x7c, 0x30, 0xfc, 0x79, 0x3b, 0x5f, 0xcc, 0x81, 0x80,
-	0x63, 0x7c, 0x03, 0xa4, 0xc8, 0x9a, 0x36, 0x1f, 0x8b, 0x25, 0x97, 0xe2, 0x97, 0xd8, 0xe9, 0x4f,
-	0xa0, 0xb3, 0x90, 0xac, 0xac, 0x93, 0x4d, 0x6c, 0x18, 0x0f, 0xef, 0x7a, 0x8e, 0xc6, 0x2f, 0x25,
-	0x2b, 0x69, 0xe0, 0x1e, 0x7d, 0x01, 0x6d, 0x3f, 0xf5, 0x2b, 0xde, 0xa8, 0x51, 0x1c, 0xfb, 0x3c,
-	0x2f, 0x99, 0x74, 0x3c, 0xae, 0x15, 0x26, 0xdf, 0x9d, 0xfc, 0xfa, 0x65, 0x29, 0xec, 0x6b, 0x37,
-	0x1f, 0xe7, 0xba, 0x9a, 0xc4, 0x35, 0x9a, 0xef, 0xf1, 0x24, 0xf6, 0x46, 0xc9, 0xcd, 0xa4, 0xe4,
-	0x2a, 0x3e, 0xe5, 0xf3, 0x2d, 0xdc, 0xa5, 0x93, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x43, 0xf7,
-	0x8e, 0x9a, 0xe2, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("config/config.proto", fileDescriptor_config_ca2c60894922daf9) }
+
+var fileDescriptor_config_ca2c60894922daf9 = []byte{
+	// 1013 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x5d, 0x73, 0x1b, 0x35,
+	0x14, 0x1d, 0xc7, 0x76, 0x6c, 0x5f, 0x3b, 0x5f, 0x4a, 0xda, 0x6e, 0xc2, 0x14, 0xdc, 0x9d, 0xe9,
+	0x4c, 0x06, 0x18, 0x1b, 0x12, 0x06, 0x42, 0x9e, 0x08, 0x6d, 0x9a, 0xc9, 0x34, 0x40, 0x46, 0x1d,
+	0x78, 0xe0, 0x65, 0x67, 0xbd, 0x2b, 0x6f, 0x45, 0xb4, 0x92, 0x2b, 0x69, 0xf3, 0xf1, 0xc8, 0xbf,
+	0xe0, 0x89, 0x37, 0xfe, 0x01, 0x7f, 0x8d, 0x77, 0x46, 0x57, 0xda, 0xe2, 0xc6, 0x49, 0x78, 0xb2,
+	0x74, 0xee, 0x39, 0x47, 0x77, 0x25, 0xdd, 0x2b, 0xc3, 0x66, 0xa6, 0xe4, 0x94, 0x17, 0x63, 0xff,
+	0x33, 0x9a, 0x69, 0x65, 0x15, 0x59, 0x13, 0x5c, 0x5e, 0x30, 0x9d, 0xef, 0x8d, 0x3c, 0xbc, 0xf3,
+	0x71, 0xa1, 0x54, 0x21, 0xd8, 0x18, 0xc3, 0x93, 0x6a, 0x3a, 0xce, 0x2b, 0x9d, 0x5a, 0xae, 0xa4,
+	0x17, 0xc4, 0x7f, 0x34, 0xa0, 0x79, 0x24, 0x04, 0x19, 0xc3, 0x72, 0x21, 0xd4, 0x24, 0x15, 0x51,
+	0x63, 0xd8, 0xd8, 0xed, 0xef, 0x3d, 0x19, 0xdd, 0x72, 0x1a, 0x9d, 0x60, 0x98, 0x06, 0x1a, 0xf9,
+	0x1c, 0xda, 0x33, 0xad, 0xae, 0x6f, 0xa2, 0x25, 0xe4, 0x3f, 0x5e, 0xe0, 0x9f, 0xbb, 0x28, 0xf5,
+	0x24, 0xb2, 0x07, 0x1d, 0x2e, 0x8d, 0x4d, 0x85, 0x88, 0x9a, 0xc8, 0x8f, 0x16, 0xf8, 0xa7, 0x3e,
+	0x4e, 0x6b, 0x62, 0xfc, 0xf7, 0x12, 0x2c, 0xfb, 0x45, 0xc9, 0x67, 0xb0, 0x11, 0xe8, 0x89, 0x4c,
+	0x4b, 0x66, 0x66, 0x69, 0xc6, 0x30, 0xd1, 0x1e, 0x5d, 0x0f, 0x81, 0x1f, 0x6b, 0x9c, 0x7c, 0x02,
+	0xfd, 0x4c, 0xf2, 0x84, 0xc9, 0x74, 0x22, 0x58, 0x8e, 0xf9, 0x75, 0x29, 0x64, 0x92, 0x1f, 0x7b,
+	0x84, 0x44, 0xd0, 0xb9, 0x64, 0xda, 0x70, 0x25, 0x31, 0x99, 0x1e, 0xad, 0xa7, 0xe4, 0x35, 0xac,
+	0xf3, 0x9c, 0x49, 0xcb, 0xed, 0x4d, 0x92, 0x29, 0x69, 0xd9, 0xb5, 0x8d, 0x5a, 0x98, 0xef, 0x70,
+	0x31, 0xdf, 0x40, 0x7c, 0xe1, 0x79, 0x74, 0x8d, 0x7f, 0x08, 0x10, 0x0a, 0x9b, 0x69, 0x65, 0x55,
+	0xc2, 0xe5, 0x6f, 0x2c, 0xb3, 0xef, 0xfd, 0x96, 0xd1, 0x2f, 0x5e, 0xf0, 0x3b, 0xaa, 0xac, 0x3a,
+	0x45, 0x6a, 0xed, 0xb8, 0x91, 0xde, 0x86, 0xc8, 0x33, 0x18, 0x64, 0xa2, 0x32, 0x96, 0x69, 0xdc,
+	0x88, 0xa8, 0x83, 0xf9, 0xf7, 0x03, 0xe6, 0xf6, 0x20, 0xfe, 0xbd, 0x03, 0x6d, 0xdc, 0x7b, 0xf2,
+	0x0d, 0xf4, 0x71, 0xf7, 0x13, 0x5e, 0xa6, 0x05, 0x8b, 0x1a, 0xf7, 0x1c, 0xd4, 0xa9, 0x8b, 0x52,
+	0x40, 0x2a, 0x8e, 0xc9, 0x77, 0xb0, 0x1e, 0x84, 0x92, 0xdb, 0xa0, 0x5e, 0x7a, 0x50, 0xbd, 0xea,
+	0xd5, 0x92, 0x5b, 0xef, 0x70, 0x00, 0x03, 0xf7, 0xbd, 0x5a, 0x89, 0x64, 0xa6, 0xb4, 0x0d, 0x87,
+	0xfe, 0x68, 0xf1, 0x92, 0x28, 0x6d, 0x69, 0x3f, 0x50, 0xdd, 0x84, 0x9c, 0xc0, 0x16, 0x2f, 0xa4,
+	0xd2, 0x2c, 0xe1, 0x72, 0xa2, 0x2a, 0x99, 0xa3, 0x81, 0x89, 0x5a, 0xc3, 0xe6, 0xfd, 0x0e, 0xc4,
+	0x4b, 0x4e, 0xbd, 0xc2, 0x41, 0x86, 0x9c, 0xc2, 0xa3, 0x60, 0xa4, 0x2a, 0x3b, 0xef, 0xd4, 0x7e,
+	0xc8, 0x69, 0xd3, 0x6b, 0x7e, 0x0a, 0x12, 0x6f, 0x75, 0x00, 0x83, 0xf9, 0x64, 0xc2, 0x11, 0xde,
+	0xf7, 0x35, 0xfc, 0xbf, 0x2c, 0xc8, 0x57, 0x00, 0x69, 0x5e, 0x72, 0xe9, 0x75, 0x9d, 0x87, 0x74,
+	0x3d, 0x24, 0xa2, 0xea, 0x10, 0x56, 0x3e, 0xc8, 0x39, 0xea, 0x3e, 0x24, 0x1c, 0xa8, 0xb9, 0x64,
+	0xc9, 0x11, 0x74, 0x35, 0x33, 0xaa, 0xd2, 0x19, 0x8b, 0x7a, 0x28, 0x7b, 0xbe, 0x20, 0xa3, 0x81,
+	0x40, 0xd9, 0xbb, 0x8a, 0x6b, 0x56, 0x32, 0x69, 0x0d, 0x7d, 0x2f, 0x23, 0x1f, 0x41, 0xcf, 0x1f,
+	0x7f, 0xc5, 0xf3, 0x08, 0x86, 0x8d, 0xdd, 0x26, 0xed, 0x22, 0xf0, 0x33, 0xcf, 0xc9, 0xd7, 0xd0,
+	0x13, 0xaa, 0x48, 0x04, 0xbb, 0x64, 0x22, 0xea, 0xe3, 0x02, 0xdb, 0x0b, 0x0b, 0x9c, 0xa9, 0xe2,
+	0xcc, 0x11, 0x68, 0x57, 0x84, 0x11, 0x39, 0x84, 0xed, 0x9c, 0x1b, 0x57, 0x80, 0x09, 0xbb, 0xb6,
+	0x4c, 0xcb, 0x54, 0x24, 0x33, 0xad, 0xa6, 0x5c, 0x30, 0x13, 0x0d, 0xb0, 0x46, 0x9f, 0x04, 0xc2,
+	0x71, 0x88, 0x9f, 0x87, 0x30, 0x39, 0x84, 0x65, 0x91, 0x4e, 0x98, 0x30, 0xd1, 0xca, 0xb0, 0x79,
+	0x67, 0xf1, 0xe0, 0x85, 0x1f, 0x9d, 0x21, 0xe9, 0x58, 0x5a, 0x7d, 0x43, 0x83, 0x82, 0x1c, 0x40,
+	0x94, 0x33, 0x63, 0xb9, 0xc4, 0xae, 0x97, 0x14, 0xcc, 0x26, 0xa6, 0x9a, 0x4e, 0xf9, 0x35, 0x33,
+	0xd1, 0xea, 0xb0, 0xb9, 0xdb, 0xa3, 0x8f, 0xe7, 0xe2, 0x27, 0xcc, 0xbe, 0x09, 0xd1, 0x9d, 0x6f,
+	0xa1, 0x3f, 0x67, 0x48, 0xd6, 0xa1, 0x79, 0xc1, 0x6e, 0x42, 0xd7, 0x71, 0x43, 0xb2, 0x05, 0xed,
+	0xcb, 0x54, 0x54, 0xbe, 0x36, 0x7a, 0xd4, 0x4f, 0x0e, 0x97, 0x0e, 0x1a, 0xf1, 0x09, 0xb4, 0x7d,
+	0x1d, 0x3c, 0x05, 0xc0, 0xf2, 0xf1, 0xd5, 0xea, 0xb5, 0x3d, 0x44, 0x5c, 0xad, 0xba, 0x56, 0x35,
+	0xab, 0x84, 0xab, 0x11, 0xc1, 0xb3, 0x9b, 0xe0, 0x03, 0x0e, 0x3a, 0x47, 0x24, 0xde, 0x81, 0x16,
+	0x9e, 0x2a, 0x81, 0x16, 0x5e, 0x04, 0xe7, 0xb0, 0x42, 0x71, 0x1c, 0xff, 0xd9, 0x80, 0xad, 0xbb,
+	0x4e, 0xd2, 0xb9, 0x6a, 0xf6, 0xae, 0x62, 0xc6, 0x26, 0xd9, 0xac, 0x0a, 0xab, 0x42, 0x80, 0x5e,
+	0xcc, 0x2a, 0xf2, 0x1c, 0x56, 0x6b, 0x42, 0xc9, 0x4a, 0xa5, 0xeb, 0x95, 0x57, 0x02, 0xfa, 0x03,
+	0x82, 0xee, 0x1e, 0x08, 0x5e, 0x72, 0xef, 0xe2, 0x3b, 0x65, 0x17, 0x01, 0xe7, 0xf1, 0x0c, 0x06,
+	0x3e, 0x18, 0x1c, 0x5a, 0xbe, 0x13, 0x21, 0xe6, 0xf5, 0xf1, 0x26, 0x6c, 0x2c, 0x34, 0xb5, 0xf8,
+	0x9f, 0x06, 0xac, 0xdd, 0x6a, 0x9d, 0xce, 0xcb, 0xea, 0xca, 0xd8, 0x24, 0x57, 0x65, 0xca, 0x65,
+	0xc8, 0xb8, 0x8f, 0xd8, 0x4b, 0x84, 0xc8, 0xa7, 0xb0, 0xe1, 0x29, 0xa9, 0xcc, 0xde, 0x2a, 0x6d,
+	0x92, 0x19, 0x2b, 0x43, 0xd6, 0x6b, 0x18, 0x38, 0xf2, 0xf8, 0x39, 0x2b, 0xc9, 0x2b, 0xd8, 0xe0,
+	0xc6, 0x54, 0xa9, 0xcc, 0x58, 0x22, 0xf8, 0x94, 0x59, 0x5e, 0xb2, 0xd0, 0x81, 0xb6, 0x47, 0xfe,
+	0x3d, 0x1c, 0xd5, 0xef, 0xe1, 0xe8, 0x65, 0x78, 0x0f, 0xe9, 0x7a, 0xad, 0x39, 0x0b, 0x12, 0xf2,
+	0x1a, 0xb6, 0x32, 0xa1, 0xb2, 0x8b, 0xc4, 0x5c, 0xb0, 0xab, 0x24, 0x15, 0x42, 0x5d, 0xb9, 0x78,
+	0xd4, 0xfa, 0x3f, 0x2b, 0x82, 0xb2, 0x37, 0x17, 0xec, 0xea, 0xa8, 0x16, 0xc5, 0x43, 0xe8, 0xd6,
+	0x55, 0xe1, 0x2e, 0x8e, 0xaf, 0x1f, 0xff, 0xa1, 0x7e, 0x12, 0xff, 0xd5, 0x80, 0x4e, 0x78, 0x04,
+	0xdd, 0x79, 0x57, 0xae, 0xfa, 0x3c, 0x01, 0xc7, 0xf8, 0xae, 0x09, 0x9e, 0xd4, 0x4f, 0x57, 0xb8,
+	0x2c, 0x99, 0xe0, 0xbf, 0x78, 0x84, 0xec, 0x43, 0x7b, 0x2a, 0xd2, 0xc2, 0x44, 0x4d, 0xac, 0x92,
+	0xa7, 0xf7, 0x3d, 0xb1, 0xa3, 0x57, 0x22, 0x2d, 0xa8, 0xe7, 0xee, 0x7c, 0x01, 0x2d, 0x37, 0x75,
+	0x2b, 0xce, 0xdd, 0x51, 0x1c, 0xdf, 0x7d, 0xc1, 0xbf, 0xdf, 0xff, 0xf5, 0xcb, 0x82, 0xdb, 0xb7,
+	0xd5, 0x64, 0x94, 0xa9, 0x72, 0x1c, 0xd6, 0xa8, 0x7f, 0xf7, 0xc6, 0xa1, 0x99, 0x0b, 0xa6, 0xc7,
+	0x05, 0x93, 0xe1, 0xef, 0xc9, 0x64, 0x19, 0x77, 0x69, 0xff, 0xdf, 0x01, 0x00, 0x3a, 0xb7, 0x55,
+	0xe3, 0xb6, 0x08, 0x00, 0x00,
 }
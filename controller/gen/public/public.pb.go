@@ -67,7 +67,7 @@ func (x HttpMethod_Registered) String() string {
 	return proto.EnumName(HttpMethod_Registered_name, int32(x))
 }
 func (HttpMethod_Registered) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{10, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{10, 0}
 }
 
 type Scheme_Registered int32
@@ -90,7 +90,7 @@ func (x Scheme_Registered) String() string {
 	return proto.EnumName(Scheme_Registered_name, int32(x))
 }
 func (Scheme_Registered) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{11, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{11, 0}
 }
 
 type TapEvent_ProxyDirection int32
@@ -116,7 +116,7 @@ func (x TapEvent_ProxyDirection) String() string {
 	return proto.EnumName(TapEvent_ProxyDirection_name, int32(x))
 }
 func (TapEvent_ProxyDirection) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 0}
 }
 
 type Empty struct {
@@ -129,7 +129,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{0}
+	return fileDescriptor_public_80052394dd449abb, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -162,7 +162,7 @@ func (m *VersionInfo) Reset()         { *m = VersionInfo{} }
 func (m *VersionInfo) String() string { return proto.CompactTextString(m) }
 func (*VersionInfo) ProtoMessage()    {}
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{1}
+	return fileDescriptor_public_80052394dd449abb, []int{1}
 }
 func (m *VersionInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionInfo.Unmarshal(m, b)
@@ -214,7 +214,7 @@ func (m *ListServicesRequest) Reset()         { *m = ListServicesRequest{} }
 func (m *ListServicesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListServicesRequest) ProtoMessage()    {}
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{2}
+	return fileDescriptor_public_80052394dd449abb, []int{2}
 }
 func (m *ListServicesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListServicesRequest.Unmarshal(m, b)
@@ -252,7 +252,7 @@ func (m *ListServicesResponse) Reset()         { *m = ListServicesResponse{} }
 func (m *ListServicesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListServicesResponse) ProtoMessage()    {}
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{3}
+	return fileDescriptor_public_80052394dd449abb, []int{3}
 }
 func (m *ListServicesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListServicesResponse.Unmarshal(m, b)
@@ -291,7 +291,7 @@ func (m *Service) Reset()         { *m = Service{} }
 func (m *Service) String() string { return proto.CompactTextString(m) }
 func (*Service) ProtoMessage()    {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{4}
+	return fileDescriptor_public_80052394dd449abb, []int{4}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Service.Unmarshal(m, b)
@@ -337,7 +337,7 @@ func (m *ListPodsRequest) Reset()         { *m = ListPodsRequest{} }
 func (m *ListPodsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListPodsRequest) ProtoMessage()    {}
 func (*ListPodsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{5}
+	return fileDescriptor_public_80052394dd449abb, []int{5}
 }
 func (m *ListPodsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListPodsRequest.Unmarshal(m, b)
@@ -383,7 +383,7 @@ func (m *ListPodsResponse) Reset()         { *m = ListPodsResponse{} }
 func (m *ListPodsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListPodsResponse) ProtoMessage()    {}
 func (*ListPodsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{6}
+	return fileDescriptor_public_80052394dd449abb, []int{6}
 }
 func (m *ListPodsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListPodsResponse.Unmarshal(m, b)
@@ -439,7 +439,7 @@ func (m *Pod) Reset()         { *m = Pod{} }
 func (m *Pod) String() string { return proto.CompactTextString(m) }
 func (*Pod) ProtoMessage()    {}
 func (*Pod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{7}
+	return fileDescriptor_public_80052394dd449abb, []int{7}
 }
 func (m *Pod) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pod.Unmarshal(m, b)
@@ -776,7 +776,7 @@ func (m *TapRequest) Reset()         { *m = TapRequest{} }
 func (m *TapRequest) String() string { return proto.CompactTextString(m) }
 func (*TapRequest) ProtoMessage()    {}
 func (*TapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{8}
+	return fileDescriptor_public_80052394dd449abb, []int{8}
 }
 func (m *TapRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapRequest.Unmarshal(m, b)
@@ -979,7 +979,7 @@ func (m *TapByResourceRequest) Reset()         { *m = TapByResourceRequest{} }
 func (m *TapByResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest) ProtoMessage()    {}
 func (*TapByResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{9}
+	return fileDescriptor_public_80052394dd449abb, []int{9}
 }
 func (m *TapByResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest.Unmarshal(m, b)
@@ -1027,6 +1027,7 @@ type TapByResourceRequest_Match struct {
 	//	*TapByResourceRequest_Match_Not
 	//	*TapByResourceRequest_Match_Destinations
 	//	*TapByResourceRequest_Match_Http_
+	//	*TapByResourceRequest_Match_Sources
 	Match                isTapByResourceRequest_Match_Match `protobuf_oneof:"match"`
 	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
 	XXX_unrecognized     []byte                             `json:"-"`
@@ -1037,7 +1038,7 @@ func (m *TapByResourceRequest_Match) Reset()         { *m = TapByResourceRequest
 func (m *TapByResourceRequest_Match) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match) ProtoMessage()    {}
 func (*TapByResourceRequest_Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{9, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{9, 0}
 }
 func (m *TapByResourceRequest_Match) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match.Unmarshal(m, b)
@@ -1081,6 +1082,10 @@ type TapByResourceRequest_Match_Http_ struct {
 	Http *TapByResourceRequest_Match_Http `protobuf:"bytes,5,opt,name=http,proto3,oneof"`
 }
 
+type TapByResourceRequest_Match_Sources struct {
+	Sources *ResourceSelection `protobuf:"bytes,6,opt,name=sources,proto3,oneof"`
+}
+
 func (*TapByResourceRequest_Match_All) isTapByResourceRequest_Match_Match() {}
 
 func (*TapByResourceRequest_Match_Any) isTapByResourceRequest_Match_Match() {}
@@ -1091,6 +1096,8 @@ func (*TapByResourceRequest_Match_Destinations) isTapByResourceRequest_Match_Mat
 
 func (*TapByResourceRequest_Match_Http_) isTapByResourceRequest_Match_Match() {}
 
+func (*TapByResourceRequest_Match_Sources) isTapByResourceRequest_Match_Match() {}
+
 func (m *TapByResourceRequest_Match) GetMatch() isTapByResourceRequest_Match_Match {
 	if m != nil {
 		return m.Match
@@ -1133,6 +1140,13 @@ func (m *TapByResourceRequest_Match) GetHttp() *TapByResourceRequest_Match_Http
 	return nil
 }
 
+func (m *TapByResourceRequest_Match) GetSources() *ResourceSelection {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_Sources); ok {
+		return x.Sources
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapByResourceRequest_Match_OneofMarshaler, _TapByResourceRequest_Match_OneofUnmarshaler, _TapByResourceRequest_Match_OneofSizer, []interface{}{
@@ -1141,6 +1155,7 @@ func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *
 		(*TapByResourceRequest_Match_Not)(nil),
 		(*TapByResourceRequest_Match_Destinations)(nil),
 		(*TapByResourceRequest_Match_Http_)(nil),
+		(*TapByResourceRequest_Match_Sources)(nil),
 	}
 }
 
@@ -1173,6 +1188,11 @@ func _TapByResourceRequest_Match_OneofMarshaler(msg proto.Message, b *proto.Buff
 		if err := b.EncodeMessage(x.Http); err != nil {
 			return err
 		}
+	case *TapByResourceRequest_Match_Sources:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Sources); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TapByResourceRequest_Match.Match has unexpected type %T", x)
@@ -1223,6 +1243,14 @@ func _TapByResourceRequest_Match_OneofUnmarshaler(msg proto.Message, tag, wire i
 		err := b.DecodeMessage(msg)
 		m.Match = &TapByResourceRequest_Match_Http_{msg}
 		return true, err
+	case 6: // match.sources
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceSelection)
+		err := b.DecodeMessage(msg)
+		m.Match = &TapByResourceRequest_Match_Sources{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1257,6 +1285,11 @@ func _TapByResourceRequest_Match_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TapByResourceRequest_Match_Sources:
+		s := proto.Size(x.Sources)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1275,7 +1308,7 @@ func (m *TapByResourceRequest_Match_Seq) Reset()         { *m = TapByResourceReq
 func (m *TapByResourceRequest_Match_Seq) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match_Seq) ProtoMessage()    {}
 func (*TapByResourceRequest_Match_Seq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{9, 0, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{9, 0, 0}
 }
 func (m *TapByResourceRequest_Match_Seq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match_Seq.Unmarshal(m, b)
@@ -1318,7 +1351,7 @@ func (m *TapByResourceRequest_Match_Http) Reset()         { *m = TapByResourceRe
 func (m *TapByResourceRequest_Match_Http) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match_Http) ProtoMessage()    {}
 func (*TapByResourceRequest_Match_Http) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{9, 0, 1}
+	return fileDescriptor_public_80052394dd449abb, []int{9, 0, 1}
 }
 func (m *TapByResourceRequest_Match_Http) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match_Http.Unmarshal(m, b)
@@ -1511,7 +1544,7 @@ func (m *HttpMethod) Reset()         { *m = HttpMethod{} }
 func (m *HttpMethod) String() string { return proto.CompactTextString(m) }
 func (*HttpMethod) ProtoMessage()    {}
 func (*HttpMethod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{10}
+	return fileDescriptor_public_80052394dd449abb, []int{10}
 }
 func (m *HttpMethod) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HttpMethod.Unmarshal(m, b)
@@ -1647,7 +1680,7 @@ func (m *Scheme) Reset()         { *m = Scheme{} }
 func (m *Scheme) String() string { return proto.CompactTextString(m) }
 func (*Scheme) ProtoMessage()    {}
 func (*Scheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{11}
+	return fileDescriptor_public_80052394dd449abb, []int{11}
 }
 func (m *Scheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Scheme.Unmarshal(m, b)
@@ -1783,7 +1816,7 @@ func (m *IPAddress) Reset()         { *m = IPAddress{} }
 func (m *IPAddress) String() string { return proto.CompactTextString(m) }
 func (*IPAddress) ProtoMessage()    {}
 func (*IPAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{12}
+	return fileDescriptor_public_80052394dd449abb, []int{12}
 }
 func (m *IPAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPAddress.Unmarshal(m, b)
@@ -1921,7 +1954,7 @@ func (m *IPv6) Reset()         { *m = IPv6{} }
 func (m *IPv6) String() string { return proto.CompactTextString(m) }
 func (*IPv6) ProtoMessage()    {}
 func (*IPv6) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{13}
+	return fileDescriptor_public_80052394dd449abb, []int{13}
 }
 func (m *IPv6) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPv6.Unmarshal(m, b)
@@ -1967,7 +2000,7 @@ func (m *TcpAddress) Reset()         { *m = TcpAddress{} }
 func (m *TcpAddress) String() string { return proto.CompactTextString(m) }
 func (*TcpAddress) ProtoMessage()    {}
 func (*TcpAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{14}
+	return fileDescriptor_public_80052394dd449abb, []int{14}
 }
 func (m *TcpAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcpAddress.Unmarshal(m, b)
@@ -2015,7 +2048,7 @@ func (m *Eos) Reset()         { *m = Eos{} }
 func (m *Eos) String() string { return proto.CompactTextString(m) }
 func (*Eos) ProtoMessage()    {}
 func (*Eos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{15}
+	return fileDescriptor_public_80052394dd449abb, []int{15}
 }
 func (m *Eos) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Eos.Unmarshal(m, b)
@@ -2155,7 +2188,7 @@ func (m *TapEvent) Reset()         { *m = TapEvent{} }
 func (m *TapEvent) String() string { return proto.CompactTextString(m) }
 func (*TapEvent) ProtoMessage()    {}
 func (*TapEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16}
+	return fileDescriptor_public_80052394dd449abb, []int{16}
 }
 func (m *TapEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent.Unmarshal(m, b)
@@ -2307,7 +2340,7 @@ func (m *TapEvent_EndpointMeta) Reset()         { *m = TapEvent_EndpointMeta{} }
 func (m *TapEvent_EndpointMeta) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_EndpointMeta) ProtoMessage()    {}
 func (*TapEvent_EndpointMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 0}
 }
 func (m *TapEvent_EndpointMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_EndpointMeta.Unmarshal(m, b)
@@ -2345,7 +2378,7 @@ func (m *TapEvent_RouteMeta) Reset()         { *m = TapEvent_RouteMeta{} }
 func (m *TapEvent_RouteMeta) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_RouteMeta) ProtoMessage()    {}
 func (*TapEvent_RouteMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 1}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 1}
 }
 func (m *TapEvent_RouteMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_RouteMeta.Unmarshal(m, b)
@@ -2387,7 +2420,7 @@ func (m *TapEvent_Http) Reset()         { *m = TapEvent_Http{} }
 func (m *TapEvent_Http) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http) ProtoMessage()    {}
 func (*TapEvent_Http) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 2}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 2}
 }
 func (m *TapEvent_Http) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http.Unmarshal(m, b)
@@ -2564,7 +2597,7 @@ func (m *TapEvent_Http_StreamId) Reset()         { *m = TapEvent_Http_StreamId{}
 func (m *TapEvent_Http_StreamId) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_StreamId) ProtoMessage()    {}
 func (*TapEvent_Http_StreamId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 2, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 2, 0}
 }
 func (m *TapEvent_Http_StreamId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_StreamId.Unmarshal(m, b)
@@ -2613,7 +2646,7 @@ func (m *TapEvent_Http_RequestInit) Reset()         { *m = TapEvent_Http_Request
 func (m *TapEvent_Http_RequestInit) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_RequestInit) ProtoMessage()    {}
 func (*TapEvent_Http_RequestInit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 2, 1}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 2, 1}
 }
 func (m *TapEvent_Http_RequestInit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_RequestInit.Unmarshal(m, b)
@@ -2681,7 +2714,7 @@ func (m *TapEvent_Http_ResponseInit) Reset()         { *m = TapEvent_Http_Respon
 func (m *TapEvent_Http_ResponseInit) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_ResponseInit) ProtoMessage()    {}
 func (*TapEvent_Http_ResponseInit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 2, 2}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 2, 2}
 }
 func (m *TapEvent_Http_ResponseInit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_ResponseInit.Unmarshal(m, b)
@@ -2737,7 +2770,7 @@ func (m *TapEvent_Http_ResponseEnd) Reset()         { *m = TapEvent_Http_Respons
 func (m *TapEvent_Http_ResponseEnd) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_ResponseEnd) ProtoMessage()    {}
 func (*TapEvent_Http_ResponseEnd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{16, 2, 3}
+	return fileDescriptor_public_80052394dd449abb, []int{16, 2, 3}
 }
 func (m *TapEvent_Http_ResponseEnd) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_ResponseEnd.Unmarshal(m, b)
@@ -2803,7 +2836,7 @@ func (m *ApiError) Reset()         { *m = ApiError{} }
 func (m *ApiError) String() string { return proto.CompactTextString(m) }
 func (*ApiError) ProtoMessage()    {}
 func (*ApiError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{17}
+	return fileDescriptor_public_80052394dd449abb, []int{17}
 }
 func (m *ApiError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApiError.Unmarshal(m, b)
@@ -2841,7 +2874,7 @@ func (m *PodErrors) Reset()         { *m = PodErrors{} }
 func (m *PodErrors) String() string { return proto.CompactTextString(m) }
 func (*PodErrors) ProtoMessage()    {}
 func (*PodErrors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{18}
+	return fileDescriptor_public_80052394dd449abb, []int{18}
 }
 func (m *PodErrors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors.Unmarshal(m, b)
@@ -2881,7 +2914,7 @@ func (m *PodErrors_PodError) Reset()         { *m = PodErrors_PodError{} }
 func (m *PodErrors_PodError) String() string { return proto.CompactTextString(m) }
 func (*PodErrors_PodError) ProtoMessage()    {}
 func (*PodErrors_PodError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{18, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{18, 0}
 }
 func (m *PodErrors_PodError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors_PodError.Unmarshal(m, b)
@@ -2995,7 +3028,7 @@ func (m *PodErrors_PodError_ContainerError) Reset()         { *m = PodErrors_Pod
 func (m *PodErrors_PodError_ContainerError) String() string { return proto.CompactTextString(m) }
 func (*PodErrors_PodError_ContainerError) ProtoMessage()    {}
 func (*PodErrors_PodError_ContainerError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{18, 0, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{18, 0, 0}
 }
 func (m *PodErrors_PodError_ContainerError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors_PodError_ContainerError.Unmarshal(m, b)
@@ -3066,7 +3099,7 @@ func (m *Resource) Reset()         { *m = Resource{} }
 func (m *Resource) String() string { return proto.CompactTextString(m) }
 func (*Resource) ProtoMessage()    {}
 func (*Resource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{19}
+	return fileDescriptor_public_80052394dd449abb, []int{19}
 }
 func (m *Resource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Resource.Unmarshal(m, b)
@@ -3125,7 +3158,7 @@ func (m *ResourceSelection) Reset()         { *m = ResourceSelection{} }
 func (m *ResourceSelection) String() string { return proto.CompactTextString(m) }
 func (*ResourceSelection) ProtoMessage()    {}
 func (*ResourceSelection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{20}
+	return fileDescriptor_public_80052394dd449abb, []int{20}
 }
 func (m *ResourceSelection) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResourceSelection.Unmarshal(m, b)
@@ -3171,7 +3204,7 @@ func (m *ResourceError) Reset()         { *m = ResourceError{} }
 func (m *ResourceError) String() string { return proto.CompactTextString(m) }
 func (*ResourceError) ProtoMessage()    {}
 func (*ResourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{21}
+	return fileDescriptor_public_80052394dd449abb, []int{21}
 }
 func (m *ResourceError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResourceError.Unmarshal(m, b)
@@ -3224,7 +3257,7 @@ func (m *StatSummaryRequest) Reset()         { *m = StatSummaryRequest{} }
 func (m *StatSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryRequest) ProtoMessage()    {}
 func (*StatSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{22}
+	return fileDescriptor_public_80052394dd449abb, []int{22}
 }
 func (m *StatSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryRequest.Unmarshal(m, b)
@@ -3429,7 +3462,7 @@ func (m *StatSummaryResponse) Reset()         { *m = StatSummaryResponse{} }
 func (m *StatSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryResponse) ProtoMessage()    {}
 func (*StatSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{23}
+	return fileDescriptor_public_80052394dd449abb, []int{23}
 }
 func (m *StatSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryResponse.Unmarshal(m, b)
@@ -3571,7 +3604,7 @@ func (m *StatSummaryResponse_Ok) Reset()         { *m = StatSummaryResponse_Ok{}
 func (m *StatSummaryResponse_Ok) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryResponse_Ok) ProtoMessage()    {}
 func (*StatSummaryResponse_Ok) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{23, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{23, 0}
 }
 func (m *StatSummaryResponse_Ok) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryResponse_Ok.Unmarshal(m, b)
@@ -3615,7 +3648,7 @@ func (m *BasicStats) Reset()         { *m = BasicStats{} }
 func (m *BasicStats) String() string { return proto.CompactTextString(m) }
 func (*BasicStats) ProtoMessage()    {}
 func (*BasicStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{24}
+	return fileDescriptor_public_80052394dd449abb, []int{24}
 }
 func (m *BasicStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BasicStats.Unmarshal(m, b)
@@ -3700,7 +3733,7 @@ func (m *TcpStats) Reset()         { *m = TcpStats{} }
 func (m *TcpStats) String() string { return proto.CompactTextString(m) }
 func (*TcpStats) ProtoMessage()    {}
 func (*TcpStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{25}
+	return fileDescriptor_public_80052394dd449abb, []int{25}
 }
 func (m *TcpStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcpStats.Unmarshal(m, b)
@@ -3754,7 +3787,7 @@ func (m *StatTable) Reset()         { *m = StatTable{} }
 func (m *StatTable) String() string { return proto.CompactTextString(m) }
 func (*StatTable) ProtoMessage()    {}
 func (*StatTable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{26}
+	return fileDescriptor_public_80052394dd449abb, []int{26}
 }
 func (m *StatTable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable.Unmarshal(m, b)
@@ -3864,7 +3897,7 @@ func (m *StatTable_PodGroup) Reset()         { *m = StatTable_PodGroup{} }
 func (m *StatTable_PodGroup) String() string { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup) ProtoMessage()    {}
 func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{26, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{26, 0}
 }
 func (m *StatTable_PodGroup) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable_PodGroup.Unmarshal(m, b)
@@ -3903,17 +3936,25 @@ type StatTable_PodGroup_Row struct {
 	Stats          *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
 	TcpStats       *TcpStats   `protobuf:"bytes,8,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
 	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
-	ErrorsByPod          map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// number of pending or running pods in this resource whose linkerd-proxy container is ready
+	ProxyReadyPodCount uint64 `protobuf:"varint,9,opt,name=proxy_ready_pod_count,json=proxyReadyPodCount,proto3" json:"proxy_ready_pod_count,omitempty"`
+	// total number of container restarts across the pods in this resource
+	RestartCount uint64 `protobuf:"varint,10,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	// reason for the most recent container termination in this resource, if any
+	LastTerminationReason string `protobuf:"bytes,11,opt,name=last_termination_reason,json=lastTerminationReason,proto3" json:"last_termination_reason,omitempty"`
+	// name of the cluster this resource runs in, if configured at install time
+	ClusterName          string   `protobuf:"bytes,12,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StatTable_PodGroup_Row) Reset()         { *m = StatTable_PodGroup_Row{} }
 func (m *StatTable_PodGroup_Row) String() string { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup_Row) ProtoMessage()    {}
 func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{26, 0, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{26, 0, 0}
 }
 func (m *StatTable_PodGroup_Row) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable_PodGroup_Row.Unmarshal(m, b)
@@ -3989,6 +4030,34 @@ func (m *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
 	return nil
 }
 
+func (m *StatTable_PodGroup_Row) GetProxyReadyPodCount() uint64 {
+	if m != nil {
+		return m.ProxyReadyPodCount
+	}
+	return 0
+}
+
+func (m *StatTable_PodGroup_Row) GetRestartCount() uint64 {
+	if m != nil {
+		return m.RestartCount
+	}
+	return 0
+}
+
+func (m *StatTable_PodGroup_Row) GetLastTerminationReason() string {
+	if m != nil {
+		return m.LastTerminationReason
+	}
+	return ""
+}
+
+func (m *StatTable_PodGroup_Row) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
 type TopRoutesRequest struct {
 	Selector   *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
 	TimeWindow string             `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
@@ -4005,7 +4074,7 @@ func (m *TopRoutesRequest) Reset()         { *m = TopRoutesRequest{} }
 func (m *TopRoutesRequest) String() string { return proto.CompactTextString(m) }
 func (*TopRoutesRequest) ProtoMessage()    {}
 func (*TopRoutesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{27}
+	return fileDescriptor_public_80052394dd449abb, []int{27}
 }
 func (m *TopRoutesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopRoutesRequest.Unmarshal(m, b)
@@ -4164,7 +4233,7 @@ func (m *TopRoutesResponse) Reset()         { *m = TopRoutesResponse{} }
 func (m *TopRoutesResponse) String() string { return proto.CompactTextString(m) }
 func (*TopRoutesResponse) ProtoMessage()    {}
 func (*TopRoutesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{28}
+	return fileDescriptor_public_80052394dd449abb, []int{28}
 }
 func (m *TopRoutesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopRoutesResponse.Unmarshal(m, b)
@@ -4306,7 +4375,7 @@ func (m *TopRoutesResponse_Ok) Reset()         { *m = TopRoutesResponse_Ok{} }
 func (m *TopRoutesResponse_Ok) String() string { return proto.CompactTextString(m) }
 func (*TopRoutesResponse_Ok) ProtoMessage()    {}
 func (*TopRoutesResponse_Ok) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{28, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{28, 0}
 }
 func (m *TopRoutesResponse_Ok) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopRoutesResponse_Ok.Unmarshal(m, b)
@@ -4345,7 +4414,7 @@ func (m *RouteTable) Reset()         { *m = RouteTable{} }
 func (m *RouteTable) String() string { return proto.CompactTextString(m) }
 func (*RouteTable) ProtoMessage()    {}
 func (*RouteTable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{29}
+	return fileDescriptor_public_80052394dd449abb, []int{29}
 }
 func (m *RouteTable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RouteTable.Unmarshal(m, b)
@@ -4393,7 +4462,7 @@ func (m *RouteTable_Row) Reset()         { *m = RouteTable_Row{} }
 func (m *RouteTable_Row) String() string { return proto.CompactTextString(m) }
 func (*RouteTable_Row) ProtoMessage()    {}
 func (*RouteTable_Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_8b7d79a666eb4d6b, []int{29, 0}
+	return fileDescriptor_public_80052394dd449abb, []int{29, 0}
 }
 func (m *RouteTable_Row) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RouteTable_Row.Unmarshal(m, b)
@@ -4441,6 +4510,313 @@ func (m *RouteTable_Row) GetStats() *BasicStats {
 	return nil
 }
 
+type ListClientsRequest struct {
+	// The resource whose clients are listed. Services are resolved to the pods
+	// they select.
+	Resource             *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow           string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListClientsRequest) Reset()         { *m = ListClientsRequest{} }
+func (m *ListClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListClientsRequest) ProtoMessage()    {}
+func (*ListClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_80052394dd449abb, []int{30}
+}
+func (m *ListClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListClientsRequest.Unmarshal(m, b)
+}
+func (m *ListClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListClientsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListClientsRequest.Merge(dst, src)
+}
+func (m *ListClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListClientsRequest.Size(m)
+}
+func (m *ListClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListClientsRequest proto.InternalMessageInfo
+
+func (m *ListClientsRequest) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *ListClientsRequest) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+type ListClientsResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*ListClientsResponse_Ok_
+	//	*ListClientsResponse_Error
+	Response             isListClientsResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ListClientsResponse) Reset()         { *m = ListClientsResponse{} }
+func (m *ListClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListClientsResponse) ProtoMessage()    {}
+func (*ListClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_80052394dd449abb, []int{31}
+}
+func (m *ListClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListClientsResponse.Unmarshal(m, b)
+}
+func (m *ListClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListClientsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListClientsResponse.Merge(dst, src)
+}
+func (m *ListClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListClientsResponse.Size(m)
+}
+func (m *ListClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListClientsResponse proto.InternalMessageInfo
+
+type isListClientsResponse_Response interface {
+	isListClientsResponse_Response()
+}
+
+type ListClientsResponse_Ok_ struct {
+	Ok *ListClientsResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type ListClientsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ListClientsResponse_Ok_) isListClientsResponse_Response() {}
+
+func (*ListClientsResponse_Error) isListClientsResponse_Response() {}
+
+func (m *ListClientsResponse) GetResponse() isListClientsResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ListClientsResponse) GetOk() *ListClientsResponse_Ok {
+	if x, ok := m.GetResponse().(*ListClientsResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *ListClientsResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*ListClientsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ListClientsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ListClientsResponse_OneofMarshaler, _ListClientsResponse_OneofUnmarshaler, _ListClientsResponse_OneofSizer, []interface{}{
+		(*ListClientsResponse_Ok_)(nil),
+		(*ListClientsResponse_Error)(nil),
+	}
+}
+
+func _ListClientsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ListClientsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *ListClientsResponse_Ok_:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *ListClientsResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ListClientsResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ListClientsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ListClientsResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ListClientsResponse_Ok)
+		err := b.DecodeMessage(msg)
+		m.Response = &ListClientsResponse_Ok_{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &ListClientsResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ListClientsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ListClientsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *ListClientsResponse_Ok_:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ListClientsResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type ListClientsResponse_Ok struct {
+	Clients              []*Client `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListClientsResponse_Ok) Reset()         { *m = ListClientsResponse_Ok{} }
+func (m *ListClientsResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*ListClientsResponse_Ok) ProtoMessage()    {}
+func (*ListClientsResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_80052394dd449abb, []int{31, 0}
+}
+func (m *ListClientsResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListClientsResponse_Ok.Unmarshal(m, b)
+}
+func (m *ListClientsResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListClientsResponse_Ok.Marshal(b, m, deterministic)
+}
+func (dst *ListClientsResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListClientsResponse_Ok.Merge(dst, src)
+}
+func (m *ListClientsResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_ListClientsResponse_Ok.Size(m)
+}
+func (m *ListClientsResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListClientsResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListClientsResponse_Ok proto.InternalMessageInfo
+
+func (m *ListClientsResponse_Ok) GetClients() []*Client {
+	if m != nil {
+		return m.Clients
+	}
+	return nil
+}
+
+type Client struct {
+	// The TLS identity the client presented to the resource's inbound proxies.
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// The namespace of the client, derived from its identity.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The number of requests the client sent to the resource in the time window.
+	RequestCount uint64 `protobuf:"varint,3,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	TimeWindow   string `protobuf:"bytes,4,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// The number of TCP connections the client opened to the resource in the
+	// time window, including the connections carrying requests. Clients with
+	// connections but no requests only sent raw TCP traffic, e.g. to databases.
+	TcpConnectionCount   uint64   `protobuf:"varint,5,opt,name=tcp_connection_count,json=tcpConnectionCount,proto3" json:"tcp_connection_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Client) Reset()         { *m = Client{} }
+func (m *Client) String() string { return proto.CompactTextString(m) }
+func (*Client) ProtoMessage()    {}
+func (*Client) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_80052394dd449abb, []int{32}
+}
+func (m *Client) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Client.Unmarshal(m, b)
+}
+func (m *Client) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Client.Marshal(b, m, deterministic)
+}
+func (dst *Client) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Client.Merge(dst, src)
+}
+func (m *Client) XXX_Size() int {
+	return xxx_messageInfo_Client.Size(m)
+}
+func (m *Client) XXX_DiscardUnknown() {
+	xxx_messageInfo_Client.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Client proto.InternalMessageInfo
+
+func (m *Client) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *Client) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Client) GetRequestCount() uint64 {
+	if m != nil {
+		return m.RequestCount
+	}
+	return 0
+}
+
+func (m *Client) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *Client) GetTcpConnectionCount() uint64 {
+	if m != nil {
+		return m.TcpConnectionCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "linkerd2.public.Empty")
 	proto.RegisterType((*VersionInfo)(nil), "linkerd2.public.VersionInfo")
@@ -4492,6 +4868,10 @@ func init() {
 	proto.RegisterType((*TopRoutesResponse_Ok)(nil), "linkerd2.public.TopRoutesResponse.Ok")
 	proto.RegisterType((*RouteTable)(nil), "linkerd2.public.RouteTable")
 	proto.RegisterType((*RouteTable_Row)(nil), "linkerd2.public.RouteTable.Row")
+	proto.RegisterType((*ListClientsRequest)(nil), "linkerd2.public.ListClientsRequest")
+	proto.RegisterType((*ListClientsResponse)(nil), "linkerd2.public.ListClientsResponse")
+	proto.RegisterType((*ListClientsResponse_Ok)(nil), "linkerd2.public.ListClientsResponse.Ok")
+	proto.RegisterType((*Client)(nil), "linkerd2.public.Client")
 	proto.RegisterEnum("linkerd2.public.HttpMethod_Registered", HttpMethod_Registered_name, HttpMethod_Registered_value)
 	proto.RegisterEnum("linkerd2.public.Scheme_Registered", Scheme_Registered_name, Scheme_Registered_value)
 	proto.RegisterEnum("linkerd2.public.TapEvent_ProxyDirection", TapEvent_ProxyDirection_name, TapEvent_ProxyDirection_value)
@@ -4511,6 +4891,7 @@ const _ = grpc.SupportPackageIsVersion4
 type ApiClient interface {
 	StatSummary(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error)
+	ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error)
 	ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
 	// Superceded by `TapByResource`.
@@ -4548,6 +4929,15 @@ func (c *apiClient) TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ..
 	return out, nil
 }
 
+func (c *apiClient) ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error) {
+	out := new(ListClientsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ListClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiClient) ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error) {
 	out := new(ListPodsResponse)
 	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ListPods", in, out, opts...)
@@ -4662,6 +5052,7 @@ func (c *apiClient) Config(ctx context.Context, in *Empty, opts ...grpc.CallOpti
 type ApiServer interface {
 	StatSummary(context.Context, *StatSummaryRequest) (*StatSummaryResponse, error)
 	TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error)
+	ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error)
 	ListPods(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
 	// Superceded by `TapByResource`.
@@ -4713,6 +5104,24 @@ func _Api_TopRoutes_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_ListClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).ListClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/ListClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).ListClients(ctx, req.(*ListClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_ListPods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPodsRequest)
 	if err := dec(in); err != nil {
@@ -4857,6 +5266,10 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TopRoutes",
 			Handler:    _Api_TopRoutes_Handler,
 		},
+		{
+			MethodName: "ListClients",
+			Handler:    _Api_ListClients_Handler,
+		},
 		{
 			MethodName: "ListPods",
 			Handler:    _Api_ListPods_Handler,
@@ -4893,191 +5306,204 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 	Metadata: "public.proto",
 }
 
-func init() { proto.RegisterFile("public.proto", fileDescriptor_public_8b7d79a666eb4d6b) }
+func init() { proto.RegisterFile("public.proto", fileDescriptor_public_80052394dd449abb) }
 
-var fileDescriptor_public_8b7d79a666eb4d6b = []byte{
-	// 2922 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_public_80052394dd449abb = []byte{
+	// 3134 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x1a, 0x4d, 0x73, 0x1b, 0x49,
-	0x55, 0xdf, 0x1f, 0x4f, 0xb2, 0xad, 0x74, 0xbc, 0x41, 0x3b, 0xbb, 0x64, 0x9d, 0xc9, 0xc7, 0x9a,
-	0x2c, 0xc8, 0x5e, 0x67, 0x93, 0x8d, 0x37, 0xcb, 0x87, 0x65, 0x6b, 0x63, 0x83, 0x63, 0x6b, 0x5b,
-	0x0a, 0x5b, 0x95, 0x5a, 0x4a, 0x35, 0xd6, 0xb4, 0xed, 0xc1, 0xa3, 0xe9, 0xc9, 0x4c, 0x2b, 0x5e,
-	0x5d, 0x39, 0x51, 0x45, 0x51, 0x9c, 0x38, 0x73, 0xa4, 0xa0, 0xb8, 0x70, 0xe1, 0x4f, 0xc0, 0x95,
-	0xe2, 0x06, 0x37, 0x2e, 0x5b, 0x1c, 0xa8, 0xe2, 0xc4, 0x81, 0xa2, 0xfa, 0x6b, 0x34, 0x63, 0x49,
-	0xfe, 0x08, 0x1c, 0xe0, 0xa4, 0x7e, 0xaf, 0xdf, 0x7b, 0xfd, 0xbe, 0xfa, 0xbd, 0xee, 0x1e, 0x41,
-	0xd5, 0x1f, 0x1e, 0xb8, 0x4e, 0xbf, 0xe1, 0x07, 0x94, 0x51, 0xb4, 0xe0, 0x3a, 0xde, 0x09, 0x09,
-	0xec, 0xb5, 0x86, 0x44, 0x1b, 0x37, 0x8f, 0x28, 0x3d, 0x72, 0xc9, 0x8a, 0x98, 0x3e, 0x18, 0x1e,
-	0xae, 0xd8, 0xc3, 0xc0, 0x62, 0x0e, 0xf5, 0x24, 0x83, 0x51, 0xef, 0xd3, 0xc1, 0x80, 0x7a, 0x2b,
-	0xc7, 0xc4, 0x72, 0xd9, 0x71, 0xff, 0x98, 0xf4, 0x4f, 0xd4, 0xcc, 0xf5, 0x3e, 0xf5, 0x0e, 0x9d,
-	0xa3, 0x15, 0xf9, 0x23, 0x91, 0x66, 0x11, 0xf2, 0xad, 0x81, 0xcf, 0x46, 0xe6, 0x4b, 0xa8, 0x7c,
-	0x9f, 0x04, 0xa1, 0x43, 0xbd, 0x1d, 0xef, 0x90, 0xa2, 0xb7, 0xa1, 0x7c, 0x44, 0x15, 0xa2, 0x9e,
-	0x5e, 0x4a, 0x2f, 0x97, 0xf1, 0x18, 0xc1, 0x67, 0x0f, 0x86, 0x8e, 0x6b, 0x6f, 0x59, 0x8c, 0xd4,
-	0x33, 0x72, 0x36, 0x42, 0xa0, 0x7b, 0x30, 0x1f, 0x10, 0x97, 0x58, 0x21, 0xd1, 0x02, 0xb2, 0x82,
-	0xe4, 0x0c, 0xd6, 0x7c, 0x00, 0xd7, 0x77, 0x9d, 0x90, 0x75, 0x48, 0xf0, 0xca, 0xe9, 0x93, 0x10,
-	0x93, 0x97, 0x43, 0x12, 0x32, 0x2e, 0xdc, 0xb3, 0x06, 0x24, 0xf4, 0xad, 0x3e, 0xd1, 0x4b, 0x47,
-	0x08, 0x73, 0x17, 0x16, 0x93, 0x4c, 0xa1, 0x4f, 0xbd, 0x90, 0xa0, 0x0f, 0xa0, 0x14, 0x2a, 0x5c,
-	0x3d, 0xbd, 0x94, 0x5d, 0xae, 0xac, 0xd5, 0x1b, 0x67, 0x7c, 0xd7, 0x50, 0x4c, 0x38, 0xa2, 0x34,
-	0x9f, 0x40, 0x51, 0x21, 0x11, 0x82, 0x1c, 0x5f, 0x45, 0xad, 0x28, 0xc6, 0x49, 0x55, 0x32, 0x67,
-	0x55, 0x09, 0x61, 0x81, 0xab, 0xd2, 0xa6, 0x76, 0xa4, 0xfb, 0xd2, 0x84, 0xee, 0xcd, 0x4c, 0x3d,
-	0x1d, 0x63, 0x42, 0xdf, 0xe2, 0x7a, 0xba, 0xa4, 0xcf, 0x68, 0x20, 0x24, 0x56, 0xd6, 0xcc, 0x09,
-	0x3d, 0x31, 0x09, 0xe9, 0x30, 0xe8, 0x93, 0x8e, 0x20, 0x74, 0xa8, 0x87, 0x23, 0x1e, 0xf3, 0x63,
-	0xa8, 0x8d, 0x17, 0x55, 0xb6, 0x2f, 0x43, 0xce, 0xa7, 0xb6, 0xb6, 0x7b, 0x71, 0x42, 0x5e, 0x9b,
-	0xda, 0x58, 0x50, 0x98, 0xff, 0xcc, 0x41, 0xb6, 0x4d, 0xed, 0xa9, 0xc6, 0x2e, 0x42, 0xde, 0xa7,
-	0xf6, 0x4e, 0x5b, 0x19, 0x2a, 0x01, 0xb4, 0x04, 0x60, 0x13, 0xdf, 0xa5, 0xa3, 0x01, 0xf1, 0x98,
-	0x0c, 0xe4, 0x76, 0x0a, 0xc7, 0x70, 0xe8, 0x16, 0x54, 0x02, 0xe2, 0xbb, 0x4e, 0xdf, 0xea, 0x85,
-	0x84, 0xd5, 0x41, 0x93, 0x28, 0x64, 0x87, 0x30, 0xf4, 0x21, 0xdc, 0x50, 0x10, 0xb7, 0xa6, 0xd7,
-	0xa7, 0x1e, 0x0b, 0xa8, 0xeb, 0x92, 0xa0, 0x5e, 0x51, 0xd4, 0x6f, 0xc4, 0xe6, 0x37, 0xa3, 0x69,
-	0x74, 0x1b, 0xaa, 0x21, 0xb3, 0x18, 0x39, 0x1c, 0xba, 0x42, 0x78, 0x55, 0x91, 0x57, 0x34, 0x96,
-	0x4b, 0x7f, 0x07, 0xc0, 0xb6, 0xc8, 0x80, 0x7a, 0x82, 0x64, 0x4e, 0x91, 0x94, 0x25, 0x8e, 0x13,
-	0x20, 0xc8, 0xfe, 0x90, 0x1e, 0xd4, 0xe7, 0xd5, 0x0c, 0x07, 0xd0, 0x0d, 0x28, 0x70, 0x19, 0xc3,
-	0xb0, 0x9e, 0x13, 0xe6, 0x2a, 0x88, 0x7b, 0xc1, 0xb2, 0x6d, 0x62, 0xd7, 0xf3, 0x4b, 0xe9, 0xe5,
-	0x12, 0x96, 0x00, 0xda, 0x84, 0x85, 0xd0, 0xf1, 0xfa, 0x64, 0xd7, 0x0a, 0x19, 0x26, 0x3e, 0x0d,
-	0x58, 0xbd, 0x20, 0x82, 0xf7, 0x66, 0x43, 0xee, 0xc7, 0x86, 0xde, 0x8f, 0x8d, 0x2d, 0xb5, 0x1f,
-	0xf1, 0x59, 0x0e, 0xb4, 0x0a, 0xd7, 0xc7, 0x96, 0xef, 0x45, 0x69, 0x52, 0x14, 0xeb, 0x4f, 0x9b,
-	0x42, 0x26, 0x54, 0x15, 0xba, 0xed, 0x5a, 0x1e, 0xa9, 0x97, 0x84, 0x4e, 0x09, 0x1c, 0x7a, 0x1f,
-	0x0a, 0x43, 0x9f, 0x39, 0x03, 0x52, 0x2f, 0x5f, 0xa4, 0x91, 0x22, 0x44, 0x37, 0x01, 0xfc, 0x80,
-	0x7e, 0x31, 0xc2, 0xc4, 0xb2, 0x47, 0xf5, 0x05, 0x21, 0x34, 0x86, 0xe1, 0xcb, 0x0a, 0x48, 0x6f,
-	0xdf, 0x9a, 0xd0, 0x30, 0x81, 0x43, 0xcb, 0xb0, 0x10, 0xa8, 0x34, 0xd5, 0x64, 0xd7, 0x04, 0xd9,
-	0x59, 0x74, 0xb3, 0x08, 0x79, 0x7a, 0xea, 0x91, 0xc0, 0xfc, 0x75, 0x06, 0xa0, 0x6b, 0xf9, 0x7a,
-	0xaf, 0x20, 0xc8, 0xfa, 0xd4, 0x96, 0x29, 0xc8, 0xa3, 0xe2, 0x53, 0xfb, 0x4c, 0xb6, 0x65, 0xa6,
-	0x64, 0xdb, 0x0d, 0x28, 0x0c, 0xac, 0x2f, 0xb0, 0x1f, 0x8a, 0x5c, 0xcc, 0x60, 0x05, 0x71, 0x3c,
-	0xa3, 0x6d, 0x1e, 0x18, 0x1e, 0xcf, 0x39, 0xac, 0x20, 0x9e, 0xe9, 0x8c, 0xee, 0xb4, 0x45, 0x38,
-	0xcb, 0x58, 0x8c, 0x91, 0x01, 0xa5, 0xc3, 0x80, 0x0e, 0xda, 0x3a, 0x8c, 0x73, 0x38, 0x82, 0xb9,
-	0x1c, 0x3e, 0xde, 0x69, 0xab, 0xb8, 0x28, 0x48, 0xe4, 0x4b, 0xff, 0x98, 0x0c, 0x64, 0x10, 0x78,
-	0xbe, 0x08, 0x48, 0xe8, 0x43, 0xd8, 0x31, 0xb5, 0x85, 0xfb, 0xcb, 0x58, 0x41, 0xbc, 0x74, 0x58,
-	0x43, 0x76, 0x4c, 0x03, 0x87, 0x8d, 0xe4, 0x9e, 0xc0, 0x63, 0x04, 0xd7, 0xca, 0xb7, 0xd8, 0xb1,
-	0x4c, 0x7f, 0x2c, 0xc6, 0x1f, 0x65, 0xea, 0xe9, 0x66, 0x09, 0x0a, 0xcc, 0x0a, 0x8e, 0x08, 0x33,
-	0xff, 0x9a, 0x87, 0xc5, 0xae, 0xe5, 0x37, 0x47, 0xba, 0x18, 0x68, 0xb7, 0x7d, 0xa4, 0x49, 0x84,
-	0xe7, 0x2e, 0x57, 0x3e, 0x14, 0x07, 0xda, 0x80, 0xfc, 0xc0, 0x62, 0xfd, 0x63, 0x55, 0x79, 0xde,
-	0x9b, 0x60, 0x9d, 0xb6, 0x62, 0xe3, 0x19, 0x67, 0xc1, 0x92, 0x73, 0x96, 0xff, 0x8d, 0xdf, 0xe5,
-	0x20, 0x2f, 0x08, 0xd1, 0x26, 0x64, 0x2d, 0xd7, 0x55, 0xda, 0xad, 0x5c, 0x61, 0x89, 0x46, 0x87,
-	0xbc, 0xe4, 0x89, 0x60, 0xb9, 0xae, 0x10, 0xe2, 0x8d, 0x94, 0x9e, 0xaf, 0x25, 0xc4, 0x1b, 0xa1,
-	0x6f, 0x43, 0xd6, 0xa3, 0xb2, 0x68, 0x5d, 0xcd, 0x58, 0x2e, 0xc0, 0xa3, 0x0c, 0x6d, 0x43, 0xd5,
-	0x26, 0x21, 0x73, 0x3c, 0xb1, 0x7f, 0x64, 0xa9, 0xb8, 0x94, 0xc7, 0xb7, 0x53, 0x38, 0xc1, 0x89,
-	0x3e, 0x81, 0xdc, 0x31, 0x63, 0xbe, 0x48, 0xc3, 0xca, 0xda, 0xea, 0x55, 0x0c, 0xda, 0x66, 0xcc,
-	0xdf, 0x4e, 0x61, 0xc1, 0x6f, 0xec, 0x42, 0xb6, 0x43, 0x5e, 0xa2, 0x16, 0x14, 0x45, 0x38, 0xa2,
-	0x66, 0x77, 0xa5, 0x50, 0x6a, 0x5e, 0x63, 0x04, 0x39, 0x2e, 0x1d, 0xd5, 0xa3, 0xe4, 0xd6, 0xbb,
-	0x51, 0xa7, 0x77, 0x3d, 0x4a, 0x6f, 0xbd, 0x19, 0x75, 0x82, 0xdf, 0x8c, 0x27, 0xb8, 0xee, 0x0b,
-	0xb1, 0x14, 0x5f, 0x54, 0x29, 0x9e, 0x53, 0x53, 0x02, 0xe2, 0xc5, 0x40, 0x2c, 0x1e, 0x0d, 0xcc,
-	0x7f, 0xa4, 0x01, 0xb8, 0x12, 0xcf, 0xa4, 0xd8, 0x6d, 0x80, 0x80, 0x1c, 0x39, 0x21, 0x23, 0x01,
-	0x91, 0xc5, 0x61, 0x7e, 0xed, 0xde, 0x84, 0x71, 0x63, 0x86, 0x06, 0x8e, 0xa8, 0x65, 0xd3, 0xd1,
-	0x10, 0xba, 0x03, 0xd5, 0xa1, 0x17, 0x93, 0xa5, 0x0d, 0x48, 0x60, 0x4d, 0x0f, 0x60, 0x2c, 0x01,
-	0x15, 0x21, 0xfb, 0xb4, 0xd5, 0xad, 0xa5, 0x50, 0x09, 0x72, 0xed, 0xfd, 0x4e, 0xb7, 0x96, 0xe6,
-	0xa8, 0xf6, 0xf3, 0x6e, 0x2d, 0x83, 0x00, 0x0a, 0x5b, 0xad, 0xdd, 0x56, 0xb7, 0x55, 0xcb, 0xa2,
-	0x32, 0xe4, 0xdb, 0x1b, 0xdd, 0xcd, 0xed, 0x5a, 0x0e, 0x55, 0xa0, 0xb8, 0xdf, 0xee, 0xee, 0xec,
-	0xef, 0x75, 0x6a, 0x79, 0x0e, 0x6c, 0xee, 0xef, 0xed, 0xb5, 0x36, 0xbb, 0xb5, 0x02, 0x97, 0xb1,
-	0xdd, 0xda, 0xd8, 0xaa, 0x15, 0x39, 0x79, 0x17, 0x6f, 0x6c, 0xb6, 0x6a, 0xa5, 0x66, 0x01, 0x72,
-	0x6c, 0xe4, 0x13, 0xf3, 0x17, 0x69, 0x28, 0x74, 0xa4, 0x8f, 0xb7, 0xa6, 0x98, 0x3c, 0x99, 0x63,
-	0x92, 0xf8, 0x3f, 0x35, 0xf7, 0x56, 0xc2, 0x5c, 0xae, 0x61, 0xb7, 0xdb, 0xae, 0xa5, 0xb8, 0x86,
-	0x7c, 0xd4, 0xa9, 0xa5, 0x23, 0x0d, 0xbb, 0x50, 0xde, 0x69, 0x6f, 0xd8, 0x76, 0x40, 0x42, 0xde,
-	0x16, 0x73, 0x8e, 0xff, 0xea, 0x03, 0xa1, 0x5d, 0x91, 0x47, 0x93, 0x43, 0xe8, 0x3d, 0x81, 0x7d,
-	0xa4, 0xb6, 0xe9, 0x1b, 0x13, 0x3a, 0xef, 0xb4, 0x5f, 0x3d, 0x52, 0xc4, 0x8f, 0x9a, 0x39, 0xc8,
-	0x38, 0xbe, 0xb9, 0x0a, 0x39, 0x8e, 0xe5, 0x7d, 0xf6, 0xd0, 0x09, 0x42, 0x59, 0xc5, 0x0a, 0x58,
-	0x02, 0xbc, 0x2e, 0xba, 0x56, 0x28, 0x2b, 0x7f, 0x01, 0x8b, 0xb1, 0xb9, 0x0b, 0xd0, 0xed, 0xfb,
-	0x5a, 0x91, 0xfb, 0x5c, 0x8a, 0x2a, 0x2e, 0xc6, 0x94, 0x05, 0x15, 0x1d, 0xce, 0x38, 0xbe, 0xa8,
-	0xb2, 0xbc, 0xc6, 0x67, 0x44, 0x8d, 0x17, 0x63, 0xd3, 0x86, 0x6c, 0x8b, 0x72, 0x31, 0xb5, 0xa3,
-	0xc0, 0xef, 0xf7, 0x64, 0xd7, 0xef, 0xf5, 0xa9, 0x2d, 0x73, 0x7f, 0x6e, 0x3b, 0x85, 0xe7, 0xf9,
-	0x4c, 0x47, 0x4c, 0x6c, 0x52, 0x9b, 0x70, 0xda, 0x80, 0x84, 0x84, 0xf5, 0x48, 0x10, 0xd0, 0x40,
-	0xd2, 0x66, 0x34, 0xad, 0x98, 0x69, 0xf1, 0x09, 0x4e, 0xdb, 0xcc, 0x43, 0x96, 0x78, 0xb6, 0xf9,
-	0xc7, 0x79, 0x28, 0x75, 0x2d, 0xbf, 0xf5, 0x8a, 0xb7, 0xac, 0x07, 0x50, 0x90, 0xbb, 0x50, 0xa9,
-	0xfd, 0xd6, 0xe4, 0x5e, 0x8d, 0xec, 0xc3, 0x8a, 0x14, 0x3d, 0x85, 0x8a, 0x1c, 0xf5, 0x06, 0x84,
-	0x59, 0xaa, 0x6e, 0xdc, 0x9b, 0xb6, 0xcb, 0xc5, 0x22, 0x8d, 0x96, 0x67, 0xfb, 0xd4, 0xf1, 0xd8,
-	0x33, 0xc2, 0x2c, 0x0c, 0x92, 0x95, 0x8f, 0xd1, 0x37, 0xa1, 0x12, 0xab, 0x44, 0x2a, 0x54, 0xe7,
-	0xaa, 0x10, 0xa7, 0x47, 0x9f, 0x42, 0x2d, 0x06, 0x4a, 0x65, 0x72, 0x57, 0x52, 0x66, 0x21, 0xc6,
-	0x2f, 0x34, 0x6a, 0x02, 0x04, 0x74, 0xc8, 0x94, 0x65, 0x45, 0x21, 0xec, 0xf6, 0x6c, 0x61, 0x98,
-	0xd3, 0x0a, 0x49, 0xe5, 0x40, 0x0f, 0xd1, 0xa7, 0xb0, 0x20, 0x8e, 0x23, 0x3d, 0xdb, 0x09, 0x64,
-	0xc9, 0x15, 0x9d, 0x7c, 0x7e, 0x6d, 0x79, 0xb6, 0xa0, 0x36, 0x67, 0xd8, 0xd2, 0xf4, 0x78, 0xde,
-	0x4f, 0xc0, 0xe8, 0x03, 0x55, 0xa2, 0x65, 0xbb, 0xb8, 0x39, 0x5b, 0x4e, 0xa2, 0x20, 0xff, 0x3c,
-	0x0d, 0xd5, 0xb8, 0xb9, 0xe8, 0xbb, 0x50, 0x70, 0xad, 0x03, 0xe2, 0xea, 0xca, 0xbc, 0x76, 0x39,
-	0x37, 0x35, 0x76, 0x05, 0x53, 0xcb, 0x63, 0xc1, 0x08, 0x2b, 0x09, 0xc6, 0x3a, 0x54, 0x62, 0x68,
-	0x54, 0x83, 0xec, 0x09, 0x19, 0xa9, 0x43, 0x3b, 0x1f, 0xf2, 0x5d, 0xf4, 0xca, 0x72, 0x87, 0xfa,
-	0x72, 0x22, 0x81, 0x8f, 0x32, 0x8f, 0xd3, 0xc6, 0xcf, 0xd2, 0x50, 0x8e, 0x3c, 0x87, 0x9e, 0x9e,
-	0x51, 0x6a, 0xe5, 0x12, 0xee, 0xfe, 0x6f, 0x6b, 0xf4, 0xaf, 0xa2, 0xea, 0x36, 0xfb, 0x50, 0x0d,
-	0x64, 0x3f, 0xea, 0x39, 0x9e, 0xa3, 0xcf, 0x31, 0xf7, 0xcf, 0x77, 0x78, 0x43, 0xb5, 0xb0, 0x1d,
-	0xcf, 0x61, 0xfc, 0x02, 0x10, 0x8c, 0x41, 0x84, 0x61, 0x2e, 0x50, 0x77, 0x21, 0x29, 0xf1, 0x9c,
-	0xe3, 0x4d, 0x42, 0xa2, 0xe4, 0x51, 0x22, 0xab, 0x41, 0x0c, 0x96, 0x4a, 0x2a, 0x99, 0xc4, 0xb3,
-	0x55, 0x56, 0xdc, 0xbf, 0xa4, 0xc8, 0x96, 0x67, 0x4b, 0x25, 0x23, 0xd0, 0x78, 0x04, 0xa5, 0x0e,
-	0x0b, 0x88, 0x35, 0xd8, 0x11, 0xd7, 0xaf, 0x03, 0x2b, 0x54, 0x15, 0x07, 0x8b, 0xb1, 0xbc, 0x90,
-	0xf0, 0x79, 0xa1, 0x7d, 0x0e, 0x2b, 0xc8, 0xf8, 0x73, 0x1a, 0x2a, 0x31, 0xdb, 0xd1, 0x87, 0x90,
-	0x71, 0x6c, 0xe5, 0xb3, 0x77, 0x2f, 0x50, 0x47, 0x2f, 0x88, 0x33, 0x8e, 0xcd, 0xcb, 0x50, 0xac,
-	0x95, 0x4f, 0xab, 0x01, 0xe3, 0xae, 0x1a, 0x75, 0xf9, 0x95, 0xe8, 0x64, 0x20, 0x1d, 0xf0, 0x95,
-	0x19, 0x7d, 0x29, 0x3a, 0x30, 0x24, 0xce, 0xbd, 0xb9, 0x59, 0xe7, 0xde, 0xfc, 0xf8, 0xdc, 0x6b,
-	0xfc, 0x36, 0x0d, 0xd5, 0x78, 0x28, 0x5e, 0xdf, 0xc2, 0xa7, 0x80, 0xc4, 0x9d, 0xab, 0x97, 0x48,
-	0xaf, 0xcc, 0x45, 0xd7, 0xa2, 0x9a, 0x60, 0x8a, 0xfb, 0xf8, 0x1d, 0xa8, 0xf0, 0xcd, 0xad, 0xba,
-	0x83, 0x30, 0x7d, 0x0e, 0x03, 0x47, 0xc9, 0xb6, 0x60, 0xfc, 0x2a, 0xc3, 0x83, 0x12, 0x05, 0xf7,
-	0x7f, 0x40, 0xe5, 0x1d, 0xb8, 0xae, 0x05, 0xc5, 0x77, 0x42, 0xf6, 0x22, 0x49, 0xd7, 0x94, 0xa4,
-	0x98, 0xff, 0xef, 0xc2, 0x7c, 0x24, 0xe4, 0x60, 0xc4, 0x88, 0x3c, 0xf7, 0xe6, 0x70, 0xb4, 0xc9,
-	0x9a, 0x1c, 0x89, 0xee, 0x41, 0x96, 0xd0, 0x50, 0x75, 0xa6, 0xc9, 0x47, 0x87, 0x16, 0x0d, 0x31,
-	0x27, 0xe0, 0x27, 0x3d, 0xc2, 0xad, 0x37, 0x1f, 0xc3, 0x7c, 0xb2, 0x04, 0xf3, 0xe3, 0xd2, 0xf3,
-	0xbd, 0xef, 0xed, 0xed, 0x7f, 0xb6, 0x57, 0x4b, 0x71, 0x60, 0x67, 0xaf, 0xb9, 0xff, 0x7c, 0x6f,
-	0xab, 0x96, 0x46, 0x55, 0x28, 0xed, 0x3f, 0xef, 0x4a, 0x28, 0x33, 0x16, 0xb1, 0x04, 0xa5, 0x0d,
-	0xdf, 0x11, 0xed, 0x96, 0x57, 0x1a, 0xd1, 0x90, 0x55, 0xf5, 0x91, 0x00, 0xbf, 0x64, 0x96, 0xdb,
-	0xd4, 0x16, 0x24, 0x21, 0x7a, 0x02, 0x05, 0x81, 0xd6, 0x75, 0xef, 0xf6, 0xb4, 0xb7, 0x11, 0x49,
-	0x1b, 0x8d, 0xb0, 0x62, 0x31, 0xfe, 0x92, 0x86, 0x92, 0x46, 0x22, 0x0c, 0x65, 0x7e, 0xed, 0xb6,
-	0x1c, 0x8f, 0x04, 0x2a, 0xd0, 0x6b, 0x97, 0x10, 0xd6, 0xd8, 0xd4, 0x4c, 0x02, 0xe4, 0x47, 0xe4,
-	0x48, 0x8c, 0xf1, 0x0a, 0xe6, 0x93, 0xd3, 0xa8, 0x0e, 0xc5, 0x01, 0x09, 0x43, 0xeb, 0x48, 0x3f,
-	0xcd, 0x68, 0x90, 0xef, 0xab, 0xf1, 0xfa, 0xea, 0x29, 0x2a, 0x42, 0x70, 0x5f, 0x38, 0x03, 0xce,
-	0x25, 0x5f, 0xda, 0x24, 0xc0, 0x4b, 0x4a, 0x40, 0xac, 0x90, 0x7a, 0xfa, 0x8d, 0x43, 0x42, 0xc2,
-	0x9d, 0xc2, 0x59, 0x6d, 0x28, 0xe9, 0x1b, 0xc2, 0xf9, 0xcf, 0x6e, 0xe2, 0x1a, 0x3d, 0xf2, 0x75,
-	0x55, 0x17, 0xe3, 0xe8, 0x11, 0x29, 0x3b, 0x7e, 0x44, 0x32, 0x5f, 0xc2, 0xb5, 0x89, 0xcb, 0x10,
-	0x7a, 0x08, 0x25, 0xfd, 0x28, 0xa0, 0x5c, 0xf7, 0xe6, 0xcc, 0x2b, 0x14, 0x8e, 0x48, 0x79, 0x1e,
-	0x8a, 0xae, 0xd3, 0x4b, 0x3c, 0x98, 0x95, 0xf1, 0x9c, 0xc0, 0x76, 0xf4, 0x8b, 0xd8, 0xe7, 0x30,
-	0xa7, 0x99, 0xa5, 0x13, 0x5f, 0x73, 0xb9, 0x28, 0x9f, 0x32, 0xf1, 0x7c, 0xfa, 0x32, 0x03, 0x88,
-	0x6f, 0xfa, 0xce, 0x70, 0x30, 0xb0, 0x82, 0x91, 0xbe, 0x85, 0xc7, 0x9f, 0xf1, 0xd2, 0x57, 0x7f,
-	0xc6, 0xe3, 0x15, 0x86, 0x39, 0x03, 0xd2, 0x3b, 0x75, 0x3c, 0x9b, 0x9e, 0xaa, 0x25, 0x81, 0xa3,
-	0x3e, 0x13, 0x18, 0xf4, 0x75, 0xc8, 0x79, 0xd4, 0xd3, 0x65, 0xf7, 0xc6, 0xe4, 0xf6, 0x1a, 0xf8,
-	0x6c, 0xc4, 0x4f, 0x21, 0x9c, 0x0a, 0x7d, 0x0c, 0x15, 0x46, 0x7b, 0x91, 0xd5, 0xb9, 0x0b, 0xac,
-	0xe6, 0x57, 0x07, 0x46, 0xa3, 0xd0, 0x7f, 0x07, 0xe6, 0x0e, 0x03, 0x3a, 0x18, 0xf3, 0xe7, 0x2f,
-	0xe6, 0xaf, 0x72, 0x8e, 0x48, 0xc2, 0x57, 0x01, 0xc2, 0x13, 0x47, 0x16, 0xcc, 0x50, 0x9c, 0xc4,
-	0x4a, 0xb8, 0xcc, 0x31, 0xdc, 0x75, 0x21, 0x7a, 0x0b, 0xca, 0xac, 0xaf, 0x67, 0x8b, 0x62, 0xb6,
-	0xc4, 0xfa, 0x72, 0xb2, 0x09, 0x50, 0xa2, 0x43, 0x76, 0x40, 0x87, 0x9e, 0x6d, 0xfe, 0x29, 0x0d,
-	0xd7, 0x13, 0xde, 0x56, 0x2f, 0x9c, 0xeb, 0x90, 0xa1, 0x27, 0x33, 0xeb, 0xeb, 0x14, 0x8e, 0xc6,
-	0xfe, 0xc9, 0x76, 0x0a, 0x67, 0xe8, 0x09, 0x7a, 0x14, 0x0f, 0xeb, 0xb4, 0x73, 0x5d, 0x22, 0x79,
-	0xb6, 0x53, 0x2a, 0xf0, 0xc6, 0x06, 0x64, 0xf6, 0x4f, 0xd0, 0x13, 0x10, 0x4f, 0x8d, 0x3d, 0x66,
-	0x1d, 0xb8, 0xd1, 0x65, 0xdb, 0x98, 0xaa, 0x41, 0x97, 0x93, 0x60, 0x08, 0xf5, 0x50, 0x58, 0xa6,
-	0x4b, 0xa6, 0xf9, 0x9b, 0x0c, 0x40, 0xd3, 0x0a, 0x9d, 0xbe, 0xf4, 0xc8, 0x6d, 0x98, 0x0b, 0x87,
-	0xfd, 0x3e, 0x09, 0xf9, 0xdd, 0x63, 0xe8, 0xc9, 0x43, 0x50, 0x0e, 0x57, 0x15, 0x72, 0x93, 0xe3,
-	0x38, 0xd1, 0xa1, 0xe5, 0xb8, 0xc3, 0x80, 0x28, 0x22, 0x79, 0x32, 0xa8, 0x2a, 0xa4, 0x24, 0xba,
-	0xc3, 0x77, 0x09, 0x23, 0x5e, 0x7f, 0xd4, 0x1b, 0x84, 0x3d, 0xff, 0xe1, 0xaa, 0x48, 0x99, 0x1c,
-	0xae, 0x2a, 0xec, 0xb3, 0xb0, 0xfd, 0x70, 0xf5, 0x2c, 0xd5, 0xfa, 0x43, 0x55, 0xd3, 0x63, 0x54,
-	0xeb, 0x0f, 0x27, 0xa8, 0xd6, 0x45, 0x26, 0x24, 0xa9, 0xd6, 0xd1, 0x2a, 0x2c, 0x5a, 0x7d, 0x36,
-	0xb4, 0xdc, 0x5e, 0xd2, 0x84, 0x82, 0xa0, 0x45, 0x72, 0xae, 0x13, 0x37, 0x64, 0xcc, 0x91, 0xb4,
-	0xa7, 0x18, 0xe7, 0xf8, 0x24, 0x66, 0x95, 0xf9, 0x93, 0x34, 0x94, 0xba, 0x2a, 0x43, 0xd0, 0xd7,
-	0xa0, 0x46, 0x7d, 0x22, 0xde, 0x8d, 0x3d, 0xb9, 0x93, 0x42, 0xe5, 0xaf, 0x05, 0x8e, 0xdf, 0x1c,
-	0xa3, 0xd1, 0x32, 0xbf, 0xab, 0x59, 0xb6, 0xec, 0x5b, 0x3d, 0x46, 0x99, 0xe5, 0x2a, 0xaf, 0xcd,
-	0x73, 0xbc, 0xe8, 0x5c, 0x5d, 0x8e, 0x45, 0xf7, 0xe1, 0xda, 0x69, 0xe0, 0x30, 0x92, 0x20, 0x95,
-	0xae, 0x5b, 0x10, 0x13, 0x63, 0x5a, 0xf3, 0x97, 0x79, 0x28, 0x47, 0x21, 0x46, 0x4d, 0x28, 0xfb,
-	0xd4, 0xee, 0x1d, 0x05, 0x74, 0xa8, 0x6f, 0xa2, 0xb7, 0x67, 0x67, 0x04, 0x6f, 0x05, 0x4f, 0x39,
-	0xe9, 0x76, 0x0a, 0x97, 0x7c, 0x35, 0x36, 0xfe, 0x90, 0x13, 0xbd, 0x45, 0x00, 0xe8, 0x09, 0xe4,
-	0x02, 0x7a, 0xaa, 0xb3, 0xeb, 0xdd, 0x4b, 0xc8, 0x6a, 0x60, 0x7a, 0x8a, 0x05, 0x93, 0xf1, 0xa3,
-	0x1c, 0x64, 0x31, 0x3d, 0x7d, 0xdd, 0xaa, 0x77, 0x61, 0x21, 0x5a, 0x86, 0xda, 0x80, 0x84, 0xc7,
-	0xc4, 0xee, 0x71, 0xa3, 0x65, 0xdc, 0xa4, 0x9b, 0xe6, 0x25, 0xbe, 0x4d, 0x6d, 0x19, 0xe5, 0xfb,
-	0x70, 0x2d, 0x18, 0x7a, 0x9e, 0xe3, 0x1d, 0xc5, 0x48, 0x65, 0x9a, 0x2d, 0xa8, 0x89, 0x88, 0x76,
-	0x19, 0x6a, 0x3c, 0x15, 0x12, 0x52, 0x65, 0xfe, 0xcc, 0x4b, 0x7c, 0x44, 0xf9, 0x3e, 0xe4, 0x65,
-	0xdd, 0xc8, 0xcf, 0x38, 0xb5, 0x8e, 0x77, 0x15, 0x96, 0x94, 0xe8, 0x51, 0xbc, 0xdc, 0x94, 0x66,
-	0xf8, 0x42, 0x67, 0xd7, 0xb8, 0x12, 0xa1, 0xcf, 0x61, 0x4e, 0xb6, 0xfe, 0xde, 0xc1, 0x88, 0xeb,
-	0x55, 0x2f, 0x8a, 0x80, 0x3c, 0xbe, 0x64, 0x40, 0x1a, 0xb2, 0xf7, 0x37, 0x47, 0xbc, 0xf9, 0x8b,
-	0x5b, 0x53, 0x85, 0x8c, 0x31, 0xc6, 0x0b, 0xa8, 0x9d, 0x25, 0x98, 0x72, 0x7f, 0x5a, 0x8d, 0xdf,
-	0x9f, 0xa6, 0x95, 0x9a, 0xe8, 0x8c, 0x11, 0xbb, 0x5b, 0xf1, 0x8e, 0x2e, 0x2a, 0x94, 0xf9, 0x65,
-	0x1a, 0x6a, 0x5d, 0xea, 0x8b, 0x4b, 0x5c, 0xf8, 0xff, 0xd1, 0xac, 0x8a, 0x57, 0x6a, 0x56, 0x89,
-	0x76, 0xf1, 0xfb, 0x34, 0x5c, 0x8b, 0x59, 0xab, 0x9a, 0xc5, 0x6b, 0x56, 0x7c, 0x7e, 0x88, 0xa7,
-	0x27, 0xca, 0x86, 0xbb, 0x93, 0xf9, 0x72, 0x76, 0x9d, 0xa8, 0xc5, 0x18, 0xeb, 0xa2, 0x55, 0x3c,
-	0x80, 0x82, 0x78, 0x9f, 0xd0, 0xfb, 0x78, 0x32, 0x53, 0x05, 0xbf, 0x6c, 0x13, 0x8a, 0x34, 0xd1,
-	0x22, 0xfe, 0x96, 0x06, 0x18, 0x93, 0xa0, 0x07, 0x89, 0xaa, 0xf0, 0xce, 0x39, 0xd2, 0xc6, 0xd5,
-	0x00, 0x19, 0xb1, 0x2a, 0x20, 0xe3, 0x14, 0xc1, 0xc6, 0x4f, 0xd3, 0xb2, 0x52, 0x2c, 0x42, 0x5e,
-	0xac, 0xae, 0x0f, 0xce, 0x02, 0xb8, 0x38, 0xc8, 0x89, 0x9b, 0x5d, 0xe1, 0xec, 0xcd, 0xee, 0xea,
-	0xdb, 0x74, 0xed, 0xef, 0x79, 0xc8, 0x6e, 0xf8, 0x0e, 0x7a, 0x01, 0x95, 0x58, 0x07, 0x47, 0xb7,
-	0xcf, 0xef, 0xef, 0x22, 0xa5, 0x8d, 0x3b, 0x97, 0x39, 0x04, 0x98, 0x29, 0xd4, 0x85, 0x72, 0x14,
-	0x38, 0x74, 0xeb, 0xbc, 0xa0, 0x4a, 0xb9, 0xe6, 0xc5, 0x71, 0x37, 0x53, 0xe8, 0x53, 0x28, 0xe9,
-	0x8f, 0xb0, 0x68, 0x69, 0x82, 0xe3, 0xcc, 0x47, 0x61, 0xe3, 0xd6, 0x39, 0x14, 0x91, 0xc8, 0x1f,
-	0x40, 0x35, 0xfe, 0x5d, 0x1b, 0xdd, 0x99, 0xca, 0x74, 0xe6, 0x5b, 0xb9, 0x71, 0xf7, 0x02, 0xaa,
-	0x48, 0xfc, 0x16, 0x64, 0xbb, 0x96, 0x8f, 0xde, 0x9a, 0x76, 0x37, 0xd5, 0xc2, 0xde, 0x9c, 0x79,
-	0x71, 0x35, 0xb3, 0x3f, 0xce, 0xa4, 0x57, 0xd3, 0xe8, 0x39, 0xcc, 0x25, 0x3e, 0x2b, 0xa0, 0xbb,
-	0x97, 0xfa, 0xec, 0x70, 0x9e, 0xe4, 0xd4, 0x6a, 0x1a, 0x6d, 0x40, 0x51, 0x7f, 0x56, 0x9c, 0x51,
-	0x3b, 0x8c, 0xb7, 0x27, 0xf0, 0xb1, 0x7f, 0x2b, 0x98, 0x29, 0xe4, 0x42, 0xb9, 0x43, 0xdc, 0xc3,
-	0xcd, 0x63, 0xd2, 0x3f, 0x41, 0xdf, 0x18, 0x13, 0xcb, 0x7f, 0x43, 0x34, 0xe2, 0xff, 0x86, 0x88,
-	0xe8, 0xb4, 0x76, 0x8d, 0xcb, 0x92, 0x47, 0xde, 0x7c, 0x0c, 0x85, 0x4d, 0xf1, 0x2f, 0x8a, 0x99,
-	0xfa, 0x2e, 0xc6, 0x65, 0x8a, 0xff, 0x5b, 0x6c, 0xb8, 0xae, 0x99, 0x6a, 0x3e, 0x78, 0xf1, 0xfe,
-	0x91, 0xc3, 0x8e, 0x87, 0x07, 0x7c, 0xa9, 0x15, 0x45, 0xa3, 0x7f, 0xd7, 0x56, 0xc6, 0x1f, 0x81,
-	0x57, 0x8e, 0x88, 0xb7, 0x22, 0x45, 0x1e, 0x14, 0xc4, 0xb5, 0xfd, 0xc1, 0xbf, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x05, 0x14, 0x18, 0x26, 0x1b, 0x22, 0x00, 0x00,
+	0x55, 0xa3, 0x6f, 0x3d, 0xc9, 0xb6, 0xd2, 0x71, 0xb2, 0x5a, 0xed, 0x92, 0x4d, 0x26, 0x1f, 0x6b,
+	0xb2, 0x20, 0x3b, 0xce, 0x26, 0x9b, 0x6c, 0x96, 0x05, 0xcb, 0xd6, 0xc6, 0x86, 0xc4, 0xd6, 0x8e,
+	0x14, 0xb6, 0x6a, 0x6b, 0x29, 0xd5, 0x58, 0xd3, 0xb6, 0x07, 0x8f, 0xa6, 0x27, 0x33, 0xad, 0x64,
+	0xf5, 0x0f, 0xa8, 0xa2, 0x28, 0x4e, 0x9c, 0xb9, 0x51, 0x05, 0xc5, 0x85, 0x03, 0x17, 0xfe, 0x02,
+	0xb5, 0x47, 0x8a, 0x2a, 0x0e, 0xf0, 0x03, 0xb6, 0xb8, 0x51, 0x1c, 0x38, 0x00, 0xf5, 0xfa, 0x63,
+	0x34, 0xb2, 0xe4, 0xaf, 0x2c, 0x54, 0xc1, 0x49, 0xfd, 0x5e, 0xbf, 0xf7, 0xfa, 0xbd, 0xee, 0xf7,
+	0xd1, 0xaf, 0x35, 0x50, 0x09, 0x86, 0xbb, 0x9e, 0xdb, 0x6f, 0x04, 0x21, 0xe3, 0x8c, 0x2c, 0x78,
+	0xae, 0x7f, 0x48, 0x43, 0x67, 0xb5, 0x21, 0xd1, 0xf5, 0x2b, 0xfb, 0x8c, 0xed, 0x7b, 0x74, 0x59,
+	0x4c, 0xef, 0x0e, 0xf7, 0x96, 0x9d, 0x61, 0x68, 0x73, 0x97, 0xf9, 0x92, 0xa1, 0x5e, 0xeb, 0xb3,
+	0xc1, 0x80, 0xf9, 0xcb, 0x07, 0xd4, 0xf6, 0xf8, 0x41, 0xff, 0x80, 0xf6, 0x0f, 0xd5, 0xcc, 0xc5,
+	0x3e, 0xf3, 0xf7, 0xdc, 0xfd, 0x65, 0xf9, 0x23, 0x91, 0x66, 0x01, 0x72, 0xad, 0x41, 0xc0, 0x47,
+	0xe6, 0x73, 0x28, 0x7f, 0x9f, 0x86, 0x91, 0xcb, 0xfc, 0x2d, 0x7f, 0x8f, 0x91, 0x37, 0xa1, 0xb4,
+	0xcf, 0x14, 0xa2, 0x66, 0x5c, 0x35, 0x96, 0x4a, 0xd6, 0x18, 0x81, 0xb3, 0xbb, 0x43, 0xd7, 0x73,
+	0x36, 0x6c, 0x4e, 0x6b, 0x69, 0x39, 0x1b, 0x23, 0xc8, 0x2d, 0x98, 0x0f, 0xa9, 0x47, 0xed, 0x88,
+	0x6a, 0x01, 0x19, 0x41, 0x72, 0x04, 0x6b, 0xde, 0x85, 0x8b, 0x4f, 0xdc, 0x88, 0x77, 0x68, 0xf8,
+	0xc2, 0xed, 0xd3, 0xc8, 0xa2, 0xcf, 0x87, 0x34, 0xe2, 0x28, 0xdc, 0xb7, 0x07, 0x34, 0x0a, 0xec,
+	0x3e, 0xd5, 0x4b, 0xc7, 0x08, 0xf3, 0x09, 0x2c, 0x4e, 0x32, 0x45, 0x01, 0xf3, 0x23, 0x4a, 0xde,
+	0x85, 0x62, 0xa4, 0x70, 0x35, 0xe3, 0x6a, 0x66, 0xa9, 0xbc, 0x5a, 0x6b, 0x1c, 0xd9, 0xbb, 0x86,
+	0x62, 0xb2, 0x62, 0x4a, 0xf3, 0x11, 0x14, 0x14, 0x92, 0x10, 0xc8, 0xe2, 0x2a, 0x6a, 0x45, 0x31,
+	0x9e, 0x54, 0x25, 0x7d, 0x54, 0x95, 0x08, 0x16, 0x50, 0x95, 0x36, 0x73, 0x62, 0xdd, 0xaf, 0x4e,
+	0xe9, 0xde, 0x4c, 0xd7, 0x8c, 0x04, 0x13, 0xf9, 0x10, 0xf5, 0xf4, 0x68, 0x9f, 0xb3, 0x50, 0x48,
+	0x2c, 0xaf, 0x9a, 0x53, 0x7a, 0x5a, 0x34, 0x62, 0xc3, 0xb0, 0x4f, 0x3b, 0x82, 0xd0, 0x65, 0xbe,
+	0x15, 0xf3, 0x98, 0x1f, 0x40, 0x75, 0xbc, 0xa8, 0xb2, 0x7d, 0x09, 0xb2, 0x01, 0x73, 0xb4, 0xdd,
+	0x8b, 0x53, 0xf2, 0xda, 0xcc, 0xb1, 0x04, 0x85, 0xf9, 0x8f, 0x2c, 0x64, 0xda, 0xcc, 0x99, 0x69,
+	0xec, 0x22, 0xe4, 0x02, 0xe6, 0x6c, 0xb5, 0x95, 0xa1, 0x12, 0x20, 0x57, 0x01, 0x1c, 0x1a, 0x78,
+	0x6c, 0x34, 0xa0, 0x3e, 0x97, 0x07, 0xb9, 0x99, 0xb2, 0x12, 0x38, 0x72, 0x0d, 0xca, 0x21, 0x0d,
+	0x3c, 0xb7, 0x6f, 0xf7, 0x22, 0xca, 0x6b, 0xa0, 0x49, 0x14, 0xb2, 0x43, 0x39, 0x79, 0x0f, 0x2e,
+	0x2b, 0x08, 0xad, 0xe9, 0xf5, 0x99, 0xcf, 0x43, 0xe6, 0x79, 0x34, 0xac, 0x95, 0x15, 0xf5, 0xa5,
+	0xc4, 0xfc, 0x7a, 0x3c, 0x4d, 0xae, 0x43, 0x25, 0xe2, 0x36, 0xa7, 0x7b, 0x43, 0x4f, 0x08, 0xaf,
+	0x28, 0xf2, 0xb2, 0xc6, 0xa2, 0xf4, 0xb7, 0x00, 0x1c, 0x9b, 0x0e, 0x98, 0x2f, 0x48, 0xe6, 0x14,
+	0x49, 0x49, 0xe2, 0x90, 0x80, 0x40, 0xe6, 0x87, 0x6c, 0xb7, 0x36, 0xaf, 0x66, 0x10, 0x20, 0x97,
+	0x21, 0x8f, 0x32, 0x86, 0x51, 0x2d, 0x2b, 0xcc, 0x55, 0x10, 0xee, 0x82, 0xed, 0x38, 0xd4, 0xa9,
+	0xe5, 0xae, 0x1a, 0x4b, 0x45, 0x4b, 0x02, 0x64, 0x1d, 0x16, 0x22, 0xd7, 0xef, 0xd3, 0x27, 0x76,
+	0xc4, 0x2d, 0x1a, 0xb0, 0x90, 0xd7, 0xf2, 0xe2, 0xf0, 0x5e, 0x6f, 0xc8, 0x78, 0x6c, 0xe8, 0x78,
+	0x6c, 0x6c, 0xa8, 0x78, 0xb4, 0x8e, 0x72, 0x90, 0x15, 0xb8, 0x38, 0xb6, 0x7c, 0x3b, 0x76, 0x93,
+	0x82, 0x58, 0x7f, 0xd6, 0x14, 0x31, 0xa1, 0xa2, 0xd0, 0x6d, 0xcf, 0xf6, 0x69, 0xad, 0x28, 0x74,
+	0x9a, 0xc0, 0x91, 0x3b, 0x90, 0x1f, 0x06, 0xdc, 0x1d, 0xd0, 0x5a, 0xe9, 0x34, 0x8d, 0x14, 0x21,
+	0xb9, 0x02, 0x10, 0x84, 0xec, 0xf3, 0x91, 0x45, 0x6d, 0x67, 0x54, 0x5b, 0x10, 0x42, 0x13, 0x18,
+	0x5c, 0x56, 0x40, 0x3a, 0x7c, 0xab, 0x42, 0xc3, 0x09, 0x1c, 0x59, 0x82, 0x85, 0x50, 0xb9, 0xa9,
+	0x26, 0xbb, 0x20, 0xc8, 0x8e, 0xa2, 0x9b, 0x05, 0xc8, 0xb1, 0x97, 0x3e, 0x0d, 0xcd, 0x5f, 0xa5,
+	0x01, 0xba, 0x76, 0xa0, 0x63, 0x85, 0x40, 0x26, 0x60, 0x4e, 0xcd, 0xd0, 0xa7, 0x12, 0x30, 0xe7,
+	0x88, 0xb7, 0xa5, 0x67, 0x78, 0xdb, 0x65, 0xc8, 0x0f, 0xec, 0xcf, 0xad, 0x20, 0x12, 0xbe, 0x98,
+	0xb6, 0x14, 0x84, 0x78, 0xce, 0xda, 0x78, 0x30, 0x78, 0x9e, 0x73, 0x96, 0x82, 0xd0, 0xd3, 0x39,
+	0xdb, 0x6a, 0x8b, 0xe3, 0x2c, 0x59, 0x62, 0x4c, 0xea, 0x50, 0xdc, 0x0b, 0xd9, 0xa0, 0xad, 0x8f,
+	0x71, 0xce, 0x8a, 0x61, 0x94, 0x83, 0xe3, 0xad, 0xb6, 0x3a, 0x17, 0x05, 0x21, 0x3e, 0xea, 0x1f,
+	0xd0, 0x81, 0x3c, 0x84, 0x92, 0xa5, 0x20, 0xa1, 0x0f, 0xe5, 0x07, 0xcc, 0x11, 0xdb, 0x5f, 0xb2,
+	0x14, 0x84, 0xa9, 0xc3, 0x1e, 0xf2, 0x03, 0x16, 0xba, 0x7c, 0x24, 0x63, 0xc2, 0x1a, 0x23, 0x50,
+	0xab, 0xc0, 0xe6, 0x07, 0xd2, 0xfd, 0x2d, 0x31, 0x7e, 0x3f, 0x5d, 0x33, 0x9a, 0x45, 0xc8, 0x73,
+	0x3b, 0xdc, 0xa7, 0xdc, 0xfc, 0x45, 0x1e, 0x16, 0xbb, 0x76, 0xd0, 0x1c, 0xe9, 0x64, 0xa0, 0xb7,
+	0xed, 0x7d, 0x4d, 0x52, 0x33, 0xce, 0x9c, 0x3e, 0x14, 0x07, 0x59, 0x83, 0xdc, 0xc0, 0xe6, 0xfd,
+	0x03, 0x95, 0x79, 0xde, 0x99, 0x62, 0x9d, 0xb5, 0x62, 0xe3, 0x29, 0xb2, 0x58, 0x92, 0xf3, 0xb8,
+	0xfd, 0xaf, 0xff, 0x3d, 0x0b, 0x39, 0x41, 0x48, 0xd6, 0x21, 0x63, 0x7b, 0x9e, 0xd2, 0x6e, 0xf9,
+	0x1c, 0x4b, 0x34, 0x3a, 0xf4, 0x39, 0x3a, 0x82, 0xed, 0x79, 0x42, 0x88, 0x3f, 0xaa, 0xa5, 0x5f,
+	0x5d, 0x88, 0x3f, 0x22, 0xdf, 0x86, 0x8c, 0xcf, 0x64, 0xd2, 0x3a, 0x9f, 0xb1, 0x28, 0xc0, 0x67,
+	0x9c, 0x6c, 0x42, 0xc5, 0xa1, 0x11, 0x77, 0x7d, 0x11, 0x3f, 0x32, 0x55, 0x9c, 0x69, 0xc7, 0x37,
+	0x53, 0xd6, 0x04, 0x27, 0xf9, 0x08, 0xb2, 0x07, 0x9c, 0x07, 0xc2, 0x0d, 0xcb, 0xab, 0x2b, 0xe7,
+	0x31, 0x68, 0x93, 0xf3, 0x60, 0x33, 0x65, 0x09, 0x7e, 0xf2, 0x21, 0x14, 0x24, 0x4d, 0x54, 0xcb,
+	0x9f, 0x43, 0x19, 0xcd, 0x54, 0x7f, 0x02, 0x99, 0x0e, 0x7d, 0x4e, 0x5a, 0x50, 0x10, 0xc7, 0x19,
+	0x17, 0xcb, 0x73, 0xb9, 0x82, 0xe6, 0xad, 0x8f, 0x20, 0x8b, 0xda, 0x91, 0x5a, 0x1c, 0x1c, 0x3a,
+	0x9a, 0x15, 0x8c, 0x33, 0x2a, 0x3c, 0x74, 0x30, 0x2b, 0x98, 0x5c, 0x49, 0x06, 0x88, 0xae, 0x2b,
+	0x63, 0x14, 0x59, 0x54, 0x21, 0x92, 0x55, 0x53, 0x02, 0xc2, 0x64, 0x22, 0x16, 0x8f, 0x07, 0xe6,
+	0xdf, 0x0c, 0x00, 0x54, 0xe2, 0xa9, 0x14, 0xbb, 0x09, 0x10, 0xd2, 0x7d, 0x37, 0xe2, 0x34, 0xa4,
+	0x32, 0xb9, 0xcc, 0xaf, 0xde, 0x9a, 0x32, 0x6e, 0xcc, 0xd0, 0xb0, 0x62, 0x6a, 0x59, 0xb4, 0x34,
+	0x44, 0x6e, 0x40, 0x65, 0xe8, 0x27, 0x64, 0x69, 0x03, 0x26, 0xb0, 0xa6, 0x0f, 0x30, 0x96, 0x40,
+	0x0a, 0x90, 0x79, 0xdc, 0xea, 0x56, 0x53, 0xa4, 0x08, 0xd9, 0xf6, 0x4e, 0xa7, 0x5b, 0x35, 0x10,
+	0xd5, 0x7e, 0xd6, 0xad, 0xa6, 0x09, 0x40, 0x7e, 0xa3, 0xf5, 0xa4, 0xd5, 0x6d, 0x55, 0x33, 0xa4,
+	0x04, 0xb9, 0xf6, 0x5a, 0x77, 0x7d, 0xb3, 0x9a, 0x25, 0x65, 0x28, 0xec, 0xb4, 0xbb, 0x5b, 0x3b,
+	0xdb, 0x9d, 0x6a, 0x0e, 0x81, 0xf5, 0x9d, 0xed, 0xed, 0xd6, 0x7a, 0xb7, 0x9a, 0x47, 0x19, 0x9b,
+	0xad, 0xb5, 0x8d, 0x6a, 0x01, 0xc9, 0xbb, 0xd6, 0xda, 0x7a, 0xab, 0x5a, 0x6c, 0xe6, 0x21, 0xcb,
+	0x47, 0x01, 0x35, 0x7f, 0x6e, 0x40, 0xbe, 0x23, 0xf7, 0x78, 0x63, 0x86, 0xc9, 0xd3, 0x6e, 0x21,
+	0x89, 0xbf, 0xaa, 0xb9, 0xd7, 0x26, 0xcc, 0x45, 0x0d, 0xbb, 0xdd, 0x76, 0x35, 0x85, 0x1a, 0xe2,
+	0xa8, 0x53, 0x35, 0x62, 0x0d, 0xbb, 0x50, 0xda, 0x6a, 0xaf, 0x39, 0x4e, 0x48, 0x23, 0x2c, 0xab,
+	0x59, 0x37, 0x78, 0xf1, 0xae, 0xd0, 0xae, 0x80, 0xa7, 0x89, 0x10, 0x79, 0x47, 0x60, 0xef, 0xab,
+	0x30, 0xbf, 0x34, 0xa5, 0xf3, 0x56, 0xfb, 0xc5, 0x7d, 0x45, 0x7c, 0xbf, 0x99, 0x85, 0xb4, 0x1b,
+	0x98, 0x2b, 0x90, 0x45, 0x2c, 0xd6, 0xe9, 0x3d, 0x37, 0x8c, 0x64, 0x16, 0xcc, 0x5b, 0x12, 0xc0,
+	0xbc, 0xea, 0xd9, 0x91, 0xac, 0x1c, 0x79, 0x4b, 0x8c, 0xcd, 0x27, 0x00, 0xdd, 0x7e, 0xa0, 0x15,
+	0xb9, 0x8d, 0x52, 0x54, 0x72, 0xaa, 0xcf, 0x58, 0x50, 0xd1, 0x59, 0x69, 0x37, 0x10, 0x59, 0x9a,
+	0x85, 0x52, 0xda, 0x9c, 0x25, 0xc6, 0xa6, 0x03, 0x99, 0x16, 0x43, 0x31, 0xd5, 0xfd, 0x30, 0xe8,
+	0xf7, 0xe4, 0xad, 0xa1, 0xd7, 0x67, 0x8e, 0xf4, 0xfd, 0xb9, 0xcd, 0x94, 0x35, 0x8f, 0x33, 0x1d,
+	0x31, 0xb1, 0xce, 0x1c, 0x8a, 0xb4, 0x21, 0x8d, 0x28, 0xef, 0xd1, 0x30, 0x64, 0xa1, 0xa4, 0x4d,
+	0x6b, 0x5a, 0x31, 0xd3, 0xc2, 0x09, 0xa4, 0x6d, 0xe6, 0x20, 0x43, 0x7d, 0xc7, 0xfc, 0xc3, 0x3c,
+	0x14, 0xbb, 0x76, 0xd0, 0x7a, 0x81, 0x25, 0xef, 0x2e, 0xe4, 0x65, 0x14, 0x2a, 0xb5, 0xdf, 0x98,
+	0x8e, 0xd5, 0xd8, 0x3e, 0x4b, 0x91, 0x92, 0xc7, 0x50, 0x96, 0xa3, 0xde, 0x80, 0x72, 0x5b, 0xe5,
+	0x9d, 0x5b, 0xb3, 0xa2, 0x5c, 0x2c, 0xd2, 0x68, 0xf9, 0x4e, 0xc0, 0x5c, 0x9f, 0x3f, 0xa5, 0xdc,
+	0xb6, 0x40, 0xb2, 0xe2, 0x98, 0x7c, 0x0b, 0xca, 0x89, 0x4c, 0x56, 0x4b, 0x9f, 0xae, 0x42, 0x92,
+	0x9e, 0x7c, 0x0c, 0xd5, 0x04, 0x28, 0x95, 0xc9, 0x9e, 0x4b, 0x99, 0x85, 0x04, 0xbf, 0xd0, 0xa8,
+	0x09, 0x10, 0xb2, 0x21, 0x57, 0x96, 0x15, 0x84, 0xb0, 0xeb, 0xc7, 0x0b, 0xb3, 0x90, 0x56, 0x48,
+	0x2a, 0x85, 0x7a, 0x48, 0x3e, 0x86, 0x05, 0x71, 0x9d, 0xe9, 0x39, 0x6e, 0x28, 0xb3, 0xa4, 0xc8,
+	0xa7, 0xf3, 0xab, 0x4b, 0xc7, 0x0b, 0x6a, 0x23, 0xc3, 0x86, 0xa6, 0xb7, 0xe6, 0x83, 0x09, 0x98,
+	0xbc, 0xab, 0x52, 0xbc, 0x2c, 0x37, 0x57, 0x8e, 0x97, 0x93, 0x4c, 0xe8, 0xf5, 0x9f, 0x19, 0x50,
+	0x49, 0x9a, 0x4b, 0xbe, 0x0b, 0x79, 0xcf, 0xde, 0xa5, 0x9e, 0xce, 0xcc, 0xab, 0x67, 0xdb, 0xa6,
+	0xc6, 0x13, 0xc1, 0xd4, 0xf2, 0x79, 0x38, 0xb2, 0x94, 0x84, 0xfa, 0x43, 0x28, 0x27, 0xd0, 0xa4,
+	0x0a, 0x99, 0x43, 0x3a, 0x52, 0x97, 0x7e, 0x1c, 0x62, 0x14, 0xbd, 0xb0, 0xbd, 0xa1, 0x6e, 0x6e,
+	0x24, 0xf0, 0x7e, 0xfa, 0x81, 0x51, 0xff, 0xa9, 0x01, 0xa5, 0x78, 0xe7, 0xc8, 0xe3, 0x23, 0x4a,
+	0x2d, 0x9f, 0x61, 0xbb, 0xff, 0xd3, 0x1a, 0xfd, 0xb3, 0xa0, 0xaa, 0xcd, 0x0e, 0x54, 0x42, 0x59,
+	0x8f, 0x7a, 0xae, 0xef, 0xea, 0x7b, 0xd0, 0xed, 0x93, 0x37, 0xbc, 0xa1, 0x4a, 0xd8, 0x96, 0xef,
+	0x72, 0x6c, 0x20, 0xc2, 0x31, 0x48, 0x2c, 0x98, 0x0b, 0x55, 0x2f, 0x25, 0x25, 0x9e, 0x70, 0x3d,
+	0x9a, 0x90, 0x28, 0x79, 0x94, 0xc8, 0x4a, 0x98, 0x80, 0xa5, 0x92, 0x4a, 0x26, 0xf5, 0x9d, 0x5a,
+	0xe6, 0x8c, 0x4a, 0x4a, 0x96, 0x96, 0xef, 0x48, 0x25, 0x63, 0xb0, 0x7e, 0x1f, 0x8a, 0x1d, 0x1e,
+	0x52, 0x7b, 0xb0, 0x25, 0xda, 0xb7, 0x5d, 0x3b, 0x52, 0x19, 0xc7, 0x12, 0x63, 0xd9, 0xd0, 0xe0,
+	0xbc, 0xd0, 0x3e, 0x6b, 0x29, 0xa8, 0xfe, 0x67, 0x03, 0xca, 0x09, 0xdb, 0xc9, 0x7b, 0x90, 0x76,
+	0x1d, 0xb5, 0x67, 0x6f, 0x9f, 0xa2, 0x8e, 0x5e, 0xd0, 0x4a, 0xbb, 0x0e, 0xa6, 0xa1, 0x44, 0x29,
+	0x9f, 0x95, 0x03, 0xc6, 0x55, 0x35, 0xae, 0xf2, 0xcb, 0xf1, 0xcd, 0x40, 0x6e, 0xc0, 0x6b, 0xc7,
+	0xd4, 0xa5, 0xf8, 0xc2, 0x30, 0x71, 0x6f, 0xce, 0x1e, 0x77, 0x6f, 0xce, 0x8d, 0xef, 0xcd, 0xf5,
+	0xdf, 0x18, 0x50, 0x49, 0x1e, 0xc5, 0xab, 0x5b, 0xf8, 0x18, 0x88, 0xe8, 0xd9, 0x7a, 0x13, 0xee,
+	0x95, 0x3e, 0xad, 0xad, 0xaa, 0x0a, 0xa6, 0xe4, 0x1e, 0xbf, 0x05, 0x65, 0x0c, 0x6e, 0x55, 0x1d,
+	0x84, 0xe9, 0x73, 0x16, 0x20, 0x4a, 0x96, 0x85, 0xfa, 0x2f, 0xd3, 0x50, 0xd6, 0x3a, 0xb7, 0x7c,
+	0xe7, 0x7f, 0x40, 0xe5, 0x2d, 0xb8, 0xa8, 0x05, 0x25, 0x23, 0x21, 0x73, 0x9a, 0xa4, 0x0b, 0x4a,
+	0x52, 0x62, 0xff, 0x6f, 0xe2, 0xfb, 0x8f, 0x12, 0xb2, 0x3b, 0xe2, 0x54, 0xde, 0x9b, 0xb3, 0x56,
+	0x1c, 0x64, 0x4d, 0x44, 0x92, 0x5b, 0x90, 0xa1, 0x2c, 0x52, 0x95, 0x69, 0xfa, 0xd1, 0xa2, 0xc5,
+	0x22, 0x0b, 0x09, 0xf0, 0xa6, 0x47, 0xd1, 0x7a, 0xf3, 0x01, 0xcc, 0x4f, 0xa6, 0x60, 0xbc, 0x2e,
+	0x3d, 0xdb, 0xfe, 0xde, 0xf6, 0xce, 0x27, 0xdb, 0xd5, 0x14, 0x02, 0x5b, 0xdb, 0xcd, 0x9d, 0x67,
+	0xdb, 0x1b, 0x55, 0x83, 0x54, 0xa0, 0xb8, 0xf3, 0xac, 0x2b, 0xa1, 0xf4, 0x58, 0xc4, 0x55, 0x28,
+	0xae, 0x05, 0xae, 0x28, 0xb7, 0x98, 0x69, 0x44, 0x41, 0x56, 0xd9, 0x47, 0x02, 0xd8, 0xa4, 0x96,
+	0xda, 0xcc, 0x11, 0x24, 0x11, 0x79, 0x04, 0x79, 0x81, 0xd6, 0x79, 0xef, 0xfa, 0xac, 0xb7, 0x15,
+	0x49, 0x1b, 0x8f, 0x2c, 0xc5, 0x52, 0xff, 0x8b, 0x01, 0x45, 0x8d, 0x24, 0x16, 0x94, 0xb0, 0x6d,
+	0xb7, 0x5d, 0x9f, 0x86, 0xea, 0xa0, 0x57, 0xcf, 0x20, 0xac, 0xb1, 0xae, 0x99, 0x04, 0x88, 0x57,
+	0xe4, 0x58, 0x4c, 0xfd, 0x05, 0xcc, 0x4f, 0x4e, 0x93, 0x1a, 0x14, 0x06, 0x34, 0x8a, 0xec, 0x7d,
+	0xfd, 0xb4, 0xa3, 0x41, 0x8c, 0xab, 0xf1, 0xfa, 0xea, 0x29, 0x2b, 0x46, 0xe0, 0x5e, 0xb8, 0x03,
+	0xe4, 0x92, 0x2f, 0x75, 0x12, 0xc0, 0x94, 0x12, 0x52, 0x3b, 0x62, 0xbe, 0x7e, 0x23, 0x91, 0x90,
+	0xd8, 0x4e, 0xb1, 0x59, 0x6d, 0x28, 0xea, 0x0e, 0xe1, 0xe4, 0x67, 0x3b, 0xd1, 0x86, 0x8f, 0x02,
+	0x9d, 0xd5, 0xc5, 0x38, 0x7e, 0x84, 0xca, 0x8c, 0x1f, 0xa1, 0xcc, 0xe7, 0x70, 0x61, 0xaa, 0x7f,
+	0x21, 0xf7, 0xa0, 0xa8, 0x1f, 0x15, 0xd4, 0xd6, 0xbd, 0x7e, 0x6c, 0xd7, 0x63, 0xc5, 0xa4, 0xe8,
+	0x87, 0xa2, 0xea, 0xf4, 0x26, 0x1e, 0xdc, 0x4a, 0xd6, 0x9c, 0xc0, 0x76, 0x14, 0xd2, 0xfc, 0x0c,
+	0xe6, 0x34, 0xb3, 0xdc, 0xc4, 0x57, 0x5c, 0x2e, 0xf6, 0xa7, 0x74, 0xd2, 0x9f, 0xbe, 0x4c, 0x03,
+	0xc1, 0xa0, 0xef, 0x0c, 0x07, 0x03, 0x3b, 0x1c, 0xe9, 0x2e, 0x3e, 0xf9, 0x0c, 0x68, 0x9c, 0xff,
+	0x19, 0x10, 0x33, 0x0c, 0x3e, 0xe5, 0xf4, 0x5e, 0xba, 0xbe, 0xc3, 0x5e, 0xaa, 0x25, 0x01, 0x51,
+	0x9f, 0x08, 0x0c, 0xf9, 0x06, 0x64, 0x7d, 0xe6, 0xeb, 0xb4, 0x7b, 0x79, 0x3a, 0xbc, 0xf0, 0xd5,
+	0x17, 0x6f, 0x21, 0x48, 0x45, 0x3e, 0x80, 0x32, 0x67, 0xbd, 0xd8, 0xea, 0xec, 0x29, 0x56, 0x63,
+	0xeb, 0xc0, 0x99, 0x86, 0xc8, 0x77, 0x60, 0x0e, 0x5f, 0x49, 0xc6, 0xfc, 0xb9, 0xd3, 0xf9, 0x2b,
+	0xc8, 0x11, 0x4b, 0xf8, 0x1a, 0x40, 0x74, 0xe8, 0xca, 0x84, 0x29, 0x3b, 0xdb, 0xa2, 0x55, 0x42,
+	0x0c, 0x6e, 0x5d, 0x44, 0xde, 0x80, 0x12, 0xef, 0xeb, 0xd9, 0x82, 0x98, 0x2d, 0xf2, 0xbe, 0x9c,
+	0x6c, 0x02, 0x14, 0xd9, 0x90, 0xef, 0xb2, 0xa1, 0xef, 0x98, 0x7f, 0x34, 0xe0, 0xe2, 0xc4, 0x6e,
+	0xab, 0x17, 0xd2, 0x87, 0x90, 0x66, 0x87, 0xc7, 0xe6, 0xd7, 0x19, 0x1c, 0x8d, 0x9d, 0xc3, 0xcd,
+	0x94, 0x95, 0x66, 0x87, 0xe4, 0x7e, 0xf2, 0x58, 0x67, 0xdd, 0xeb, 0x26, 0x9c, 0x67, 0x33, 0xa5,
+	0x0e, 0xbe, 0xbe, 0x06, 0xe9, 0x9d, 0x43, 0xf2, 0x08, 0xc4, 0x53, 0x65, 0x8f, 0xdb, 0xbb, 0x5e,
+	0xdc, 0x6c, 0xd7, 0x67, 0x6a, 0xd0, 0x45, 0x12, 0x0b, 0x22, 0x3d, 0x14, 0x96, 0xe9, 0x94, 0x69,
+	0xfe, 0x3a, 0x0d, 0xd0, 0xb4, 0x23, 0xb7, 0x2f, 0x77, 0xe4, 0x3a, 0xcc, 0x45, 0xc3, 0x7e, 0x9f,
+	0x46, 0xd8, 0x7b, 0x0c, 0x7d, 0x79, 0x09, 0xca, 0x5a, 0x15, 0x85, 0x5c, 0x47, 0x1c, 0x12, 0xed,
+	0xd9, 0xae, 0x37, 0x0c, 0xa9, 0x22, 0x92, 0x37, 0x83, 0x8a, 0x42, 0x4a, 0xa2, 0x1b, 0x18, 0x25,
+	0x9c, 0xfa, 0xfd, 0x51, 0x6f, 0x10, 0xf5, 0x82, 0x7b, 0x2b, 0xc2, 0x65, 0xb2, 0x56, 0x45, 0x61,
+	0x9f, 0x46, 0xed, 0x7b, 0x2b, 0x47, 0xa9, 0x1e, 0xde, 0xab, 0x65, 0x8f, 0x52, 0x3d, 0xbc, 0x37,
+	0x45, 0xf5, 0xb0, 0x96, 0x9b, 0xa2, 0x7a, 0x48, 0x56, 0x60, 0xd1, 0xee, 0xf3, 0xa1, 0xed, 0xf5,
+	0x26, 0x4d, 0xc8, 0x0b, 0x5a, 0x22, 0xe7, 0x3a, 0x49, 0x43, 0xc6, 0x1c, 0x93, 0xf6, 0x14, 0x92,
+	0x1c, 0x1f, 0x25, 0xac, 0x32, 0x7f, 0x6c, 0x40, 0xb1, 0xab, 0x3c, 0x84, 0x7c, 0x1d, 0xaa, 0x2c,
+	0xa0, 0xe2, 0xdd, 0xd9, 0x97, 0x91, 0x14, 0xa9, 0xfd, 0x5a, 0x40, 0xfc, 0xfa, 0x18, 0x4d, 0x96,
+	0xb0, 0x57, 0xb3, 0x1d, 0x59, 0xb7, 0x7a, 0x9c, 0x71, 0xdb, 0x53, 0xbb, 0x36, 0x8f, 0x78, 0x51,
+	0xb9, 0xba, 0x88, 0x25, 0xb7, 0xe1, 0xc2, 0xcb, 0xd0, 0xe5, 0x74, 0x82, 0x54, 0x6e, 0xdd, 0x82,
+	0x98, 0x18, 0xd3, 0x9a, 0x7f, 0xca, 0x43, 0x29, 0x3e, 0x62, 0xd2, 0x84, 0x52, 0xc0, 0x9c, 0xde,
+	0x7e, 0xc8, 0x86, 0xba, 0x13, 0xbd, 0x7e, 0xbc, 0x47, 0x60, 0x29, 0x78, 0x8c, 0xa4, 0x9b, 0x29,
+	0xab, 0x18, 0xa8, 0x71, 0xfd, 0x5f, 0x39, 0x51, 0x5b, 0x04, 0x40, 0x1e, 0x41, 0x36, 0x64, 0x2f,
+	0xb5, 0x77, 0xbd, 0x7d, 0x06, 0x59, 0x0d, 0x8b, 0xbd, 0xb4, 0x04, 0x53, 0xfd, 0xb7, 0x39, 0xc8,
+	0x58, 0xec, 0xe5, 0xab, 0x66, 0xbd, 0x53, 0x13, 0xd1, 0x12, 0x54, 0x07, 0x34, 0x3a, 0xa0, 0x4e,
+	0x0f, 0x8d, 0x96, 0xe7, 0x26, 0xb7, 0x69, 0x5e, 0xe2, 0xdb, 0xcc, 0x91, 0xa7, 0x7c, 0x1b, 0x2e,
+	0x84, 0x43, 0xdf, 0x77, 0xfd, 0xfd, 0x04, 0xa9, 0x74, 0xb3, 0x05, 0x35, 0x11, 0xd3, 0x2e, 0x41,
+	0x15, 0x5d, 0x61, 0x42, 0xaa, 0xf4, 0x9f, 0x79, 0x89, 0x8f, 0x29, 0xef, 0x40, 0x4e, 0xe6, 0x8d,
+	0xdc, 0x31, 0xb7, 0xd6, 0x71, 0x54, 0x59, 0x92, 0x92, 0xdc, 0x4f, 0xa6, 0x9b, 0xe2, 0x31, 0x7b,
+	0xa1, 0xbd, 0x6b, 0x9c, 0x89, 0xc8, 0x67, 0x30, 0x27, 0x4b, 0x7f, 0x6f, 0x77, 0x84, 0x7a, 0xd5,
+	0x0a, 0xe2, 0x40, 0x1e, 0x9c, 0xf1, 0x40, 0x1a, 0xb2, 0xf6, 0x37, 0x47, 0x58, 0xfc, 0x45, 0xd7,
+	0x54, 0xa6, 0x63, 0x0c, 0xb9, 0x03, 0x97, 0x64, 0xcb, 0x8a, 0x8e, 0x38, 0x4a, 0xd8, 0x5d, 0x92,
+	0x51, 0x30, 0x7e, 0xc0, 0x8f, 0x6d, 0xbf, 0x2e, 0x1a, 0x1b, 0x6e, 0x87, 0x5c, 0x91, 0x82, 0x0c,
+	0x47, 0x85, 0x94, 0x44, 0xf7, 0xe1, 0x35, 0x7c, 0x27, 0xe9, 0x71, 0x1a, 0x0e, 0x74, 0x9b, 0xae,
+	0xca, 0xbe, 0x7c, 0x9e, 0xbe, 0x84, 0xd3, 0xdd, 0xf1, 0xac, 0x25, 0x26, 0xc9, 0x35, 0xa8, 0xf4,
+	0xbd, 0x61, 0xc4, 0x69, 0xd8, 0x13, 0x65, 0x5c, 0xfc, 0x37, 0x63, 0x95, 0x15, 0x0e, 0xff, 0xc4,
+	0xa8, 0x7f, 0x0a, 0xd5, 0xa3, 0x36, 0xcd, 0x68, 0xf9, 0x56, 0x92, 0x2d, 0xdf, 0xac, 0xec, 0x18,
+	0x5f, 0x8b, 0x12, 0xed, 0x20, 0x5e, 0x42, 0x44, 0x52, 0x35, 0xbf, 0x34, 0xa0, 0xda, 0x65, 0x81,
+	0xe8, 0x3b, 0xa3, 0xff, 0x8f, 0xfa, 0x5a, 0x38, 0x57, 0x7d, 0x9d, 0xa8, 0x70, 0xbf, 0x37, 0xe0,
+	0x42, 0xc2, 0x5a, 0x55, 0xdf, 0x5e, 0xb1, 0x48, 0x61, 0xdf, 0xc1, 0x0e, 0x95, 0x0d, 0x37, 0xa7,
+	0x5d, 0xfc, 0xe8, 0x3a, 0x71, 0x55, 0xac, 0x3f, 0x14, 0xd5, 0xed, 0x2e, 0xe4, 0xc5, 0x93, 0x8a,
+	0x4e, 0x3d, 0xd3, 0xc1, 0x25, 0xf8, 0x65, 0x65, 0x53, 0xa4, 0x13, 0x55, 0xed, 0xaf, 0x06, 0xc0,
+	0x98, 0x84, 0xdc, 0x9d, 0x48, 0x64, 0x6f, 0x9d, 0x20, 0x6d, 0x9c, 0xc0, 0xf0, 0xdf, 0x9c, 0x78,
+	0x63, 0xe5, 0x39, 0xc5, 0x70, 0xfd, 0x27, 0x86, 0x4c, 0x6e, 0x8b, 0x90, 0x13, 0xab, 0xeb, 0xbb,
+	0xbe, 0x00, 0x4e, 0x3f, 0xe4, 0x89, 0x66, 0x34, 0x7f, 0xb4, 0x19, 0x3d, 0x7f, 0x66, 0x31, 0x3d,
+	0x20, 0xf8, 0xef, 0xed, 0xba, 0xe7, 0x52, 0x9f, 0xc7, 0xce, 0xfa, 0x5f, 0x4a, 0xbd, 0xe6, 0x17,
+	0x06, 0x5c, 0x9c, 0x58, 0xee, 0x4c, 0xb7, 0xa1, 0x19, 0x1c, 0x5f, 0xfd, 0x36, 0xf4, 0x9e, 0xf0,
+	0x97, 0x3b, 0x50, 0xe8, 0x4b, 0xc9, 0xea, 0x88, 0xa7, 0x9f, 0x03, 0xe4, 0xca, 0x96, 0xa6, 0x9b,
+	0xf0, 0x96, 0xdf, 0x19, 0x90, 0x97, 0xf3, 0x78, 0xe8, 0xae, 0x43, 0x7d, 0x8e, 0x07, 0x23, 0xcf,
+	0x34, 0x86, 0x4f, 0xfe, 0xd7, 0x5e, 0xe6, 0x44, 0xd9, 0x2b, 0x27, 0x8b, 0x91, 0x7e, 0x52, 0x92,
+	0x39, 0xf1, 0xc8, 0xd6, 0x66, 0xa7, 0x3c, 0x63, 0x05, 0x16, 0xb1, 0x44, 0x8c, 0x6f, 0x14, 0x4a,
+	0x98, 0xbc, 0xef, 0x10, 0xde, 0x0f, 0xc6, 0xb7, 0x0a, 0x21, 0x72, 0xf5, 0x8b, 0x3c, 0x64, 0xd6,
+	0x02, 0x97, 0x7c, 0x0a, 0xe5, 0xc4, 0x7d, 0x93, 0x5c, 0x3f, 0xf9, 0x36, 0x2a, 0x74, 0xaa, 0xdf,
+	0x38, 0xcb, 0x95, 0xd5, 0x4c, 0x91, 0x2e, 0x94, 0xe2, 0x98, 0x25, 0xd7, 0x4e, 0x8a, 0x67, 0x29,
+	0xd7, 0x3c, 0x3d, 0xe4, 0xcd, 0x14, 0x6a, 0x9c, 0xf0, 0x89, 0x19, 0x1a, 0x4f, 0xbb, 0x74, 0xfd,
+	0xc6, 0xc9, 0x44, 0xb1, 0xec, 0x8f, 0xa1, 0xa8, 0x3f, 0x67, 0x20, 0x57, 0x67, 0xf2, 0x24, 0x3e,
+	0xaf, 0xa8, 0x5f, 0x3b, 0x81, 0x22, 0x16, 0xf9, 0x03, 0xa8, 0x24, 0xbf, 0x10, 0x21, 0xb3, 0x55,
+	0x39, 0xf2, 0xd5, 0x49, 0xfd, 0xe6, 0x29, 0x54, 0xb1, 0xf8, 0x0d, 0xc8, 0x74, 0xed, 0x80, 0xbc,
+	0x31, 0xeb, 0x95, 0x46, 0x0b, 0x7b, 0xfd, 0xd8, 0x27, 0x1c, 0x33, 0xf3, 0xa3, 0xb4, 0xb1, 0x62,
+	0x90, 0x67, 0x30, 0x37, 0xf1, 0x07, 0x1b, 0xb9, 0x79, 0xa6, 0x3f, 0xe0, 0x4e, 0x92, 0x9c, 0x5a,
+	0x31, 0xc8, 0x1a, 0x14, 0xf4, 0x1f, 0xf4, 0xc7, 0x94, 0xa4, 0xfa, 0x9b, 0x53, 0xf8, 0xc4, 0x77,
+	0x3f, 0x66, 0x8a, 0x78, 0x50, 0xea, 0x50, 0x6f, 0x6f, 0x1d, 0xbf, 0x1c, 0x22, 0xdf, 0x1c, 0x13,
+	0xcb, 0xef, 0x8a, 0x1a, 0xc9, 0xef, 0x8a, 0x62, 0x3a, 0xad, 0x5d, 0xe3, 0xac, 0xe4, 0xf1, 0x6e,
+	0x3e, 0x80, 0xfc, 0xba, 0xf8, 0x1e, 0xe9, 0x58, 0x7d, 0x17, 0x93, 0x32, 0x91, 0xb2, 0xb1, 0xe6,
+	0x79, 0x66, 0xaa, 0x79, 0xf7, 0xd3, 0x3b, 0xfb, 0x2e, 0x3f, 0x18, 0xee, 0xe2, 0x52, 0xcb, 0x8a,
+	0x46, 0xff, 0xae, 0x2e, 0x8f, 0x3f, 0xa7, 0x58, 0xde, 0xa7, 0xfe, 0xb2, 0x14, 0xb9, 0x9b, 0x17,
+	0x0f, 0x58, 0x77, 0xff, 0x3d, 0x00, 0x91, 0xee, 0xac, 0xf7, 0x65, 0x25, 0x00, 0x00,
 }
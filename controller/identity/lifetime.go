@@ -0,0 +1,59 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/identity"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	kcore "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// NamespaceLifetimes implements IssuanceLifetimes with the issuance lifetime
+// annotation of the namespaces of the identities, capped to the cluster-wide
+// issuance lifetime.
+type NamespaceLifetimes struct {
+	namespaces kcore.NamespaceInterface
+	max        time.Duration
+}
+
+// NewNamespaceLifetimes takes a kubernetes client and the cluster-wide issuance
+// lifetime to create a NamespaceLifetimes.
+func NewNamespaceLifetimes(k8s k8s.Interface, max time.Duration) identity.IssuanceLifetimes {
+	return &NamespaceLifetimes{k8s.CoreV1().Namespaces(), max}
+}
+
+// IssuanceLifetime returns the lifetime annotated on the namespace of a
+// DNS-form linkerd ID, or zero if the namespace isn't annotated. Invalid
+// annotations are ignored.
+func (n *NamespaceLifetimes) IssuanceLifetime(id string) (time.Duration, error) {
+	parts := strings.Split(id, ".")
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("invalid identity: %s", id)
+	}
+
+	ns, err := n.namespaces.Get(parts[1], metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	annotation := ns.GetAnnotations()[pkgK8s.IdentityIssuanceLifetimeAnnotation]
+	if annotation == "" {
+		return 0, nil
+	}
+
+	lifetime, err := time.ParseDuration(annotation)
+	if err != nil || lifetime <= 0 {
+		log.Warnf("invalid issuance lifetime %q on namespace %s, using the default", annotation, ns.Name)
+		return 0, nil
+	}
+	if lifetime > n.max {
+		log.Debugf("issuance lifetime %s on namespace %s exceeds the maximum, using %s", lifetime, ns.Name, n.max)
+		return n.max, nil
+	}
+	return lifetime, nil
+}
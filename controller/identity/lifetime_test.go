@@ -0,0 +1,66 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestIssuanceLifetime(t *testing.T) {
+	k8sClient, _, err := k8s.NewFakeClientSets(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: payments
+  annotations:
+    config.linkerd.io/identity-issuance-lifetime: 1h`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: legacy
+  annotations:
+    config.linkerd.io/identity-issuance-lifetime: 720h`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: broken
+  annotations:
+    config.linkerd.io/identity-issuance-lifetime: soon`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	lifetimes := NewNamespaceLifetimes(k8sClient, 24*time.Hour)
+
+	testCases := []struct {
+		id       string
+		expected time.Duration
+	}{
+		{"default.payments.serviceaccount.identity.linkerd.cluster.local", time.Hour},
+		{"default.legacy.serviceaccount.identity.linkerd.cluster.local", 24 * time.Hour},
+		{"default.broken.serviceaccount.identity.linkerd.cluster.local", 0},
+		{"default.emojivoto.serviceaccount.identity.linkerd.cluster.local", 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.id, func(t *testing.T) {
+			lifetime, err := lifetimes.IssuanceLifetime(tc.id)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if lifetime != tc.expected {
+				t.Fatalf("Expected lifetime %s, got %s", tc.expected, lifetime)
+			}
+		})
+	}
+
+	if _, err := lifetimes.IssuanceLifetime("default.missing.serviceaccount.identity.linkerd.cluster.local"); err == nil {
+		t.Fatalf("Expected an error for a missing namespace")
+	}
+}
@@ -0,0 +1,95 @@
+package injector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/config"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var configInfo = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "proxy_injector_config_info",
+		Help: "The hash of the global and proxy configs used by the proxy injector, in the hash label.",
+	},
+	[]string{"hash"},
+)
+
+func init() {
+	prometheus.MustRegister(configInfo)
+}
+
+// activeConfig holds the global and proxy configs the injector patches pods
+// with. It's loaded from the mounted linkerd-config files and reloaded by
+// WatchConfig when they change, keeping the last valid configs when the new
+// ones can't be parsed.
+type activeConfig struct {
+	sync.RWMutex
+	configs *pb.All
+	load    func() (*pb.All, error)
+}
+
+var activeConfigs = &activeConfig{load: loadConfigs}
+
+func loadConfigs() (*pb.All, error) {
+	globalConfig, err := config.Global(pkgK8s.MountPathGlobalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyConfig, err := config.Proxy(pkgK8s.MountPathProxyConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.All{Global: globalConfig, Proxy: proxyConfig}, nil
+}
+
+// get returns the active configs. Until they're loaded by reload, e.g. when the
+// injector doesn't watch its config, they're read from the files on each call.
+func (c *activeConfig) get() (*pb.All, error) {
+	c.RLock()
+	configs := c.configs
+	c.RUnlock()
+
+	if configs != nil {
+		return configs, nil
+	}
+	return c.load()
+}
+
+// reload reads the configs and makes them active if they're valid.
+func (c *activeConfig) reload() error {
+	configs, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	hash, err := configHash(configs)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	c.configs = configs
+	c.Unlock()
+
+	configInfo.Reset()
+	configInfo.WithLabelValues(hash).Set(1)
+	log.Infof("using config %s", hash)
+	return nil
+}
+
+func configHash(configs *pb.All) (string, error) {
+	configBytes, err := proto.Marshal(configs)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize configs: %s", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(configBytes)), nil
+}
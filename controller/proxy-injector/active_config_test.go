@@ -0,0 +1,54 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestActiveConfig(t *testing.T) {
+	var (
+		loaded  *pb.All
+		loadErr error
+	)
+	active := &activeConfig{load: func() (*pb.All, error) { return loaded, loadErr }}
+
+	loaded = &pb.All{Global: &pb.Global{LinkerdNamespace: "linkerd"}}
+	configs, err := active.get()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if configs != loaded {
+		t.Fatalf("Expected the configs to be loaded before the first reload, got %v", configs)
+	}
+
+	if err := active.reload(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	valid := loaded
+	hash, err := configHash(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var info dto.Metric
+	if err := configInfo.WithLabelValues(hash).Write(&info); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if info.GetGauge().GetValue() != 1 {
+		t.Fatalf("Expected the config info for %s to be 1, got %v", hash, info.GetGauge().GetValue())
+	}
+
+	loaded, loadErr = nil, errors.New("unexpected EOF")
+	if err := active.reload(); err == nil {
+		t.Fatalf("Expected an error reloading an invalid config")
+	}
+	configs, err = active.get()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if configs != valid {
+		t.Fatalf("Expected the last valid configs to stay active, got %v", configs)
+	}
+}
@@ -0,0 +1,21 @@
+package injector
+
+import (
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/config"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+)
+
+// WatchConfig watches the linkerd-config files mounted in the injector until
+// stop is closed, making the new configs active and recording an Event on the
+// linkerd-config ConfigMap each time they change.
+func WatchConfig(api *k8s.API, controllerNamespace string, stop <-chan struct{}) {
+	if err := activeConfigs.reload(); err != nil {
+		log.Errorf("failed to load the config: %s", err)
+	}
+
+	config.Watch(api.Client, controllerNamespace, pkgK8s.ProxyInjectorWebhookServiceName, func(string) error {
+		return activeConfigs.reload()
+	}, stop)
+}
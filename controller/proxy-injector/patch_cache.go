@@ -0,0 +1,89 @@
+package injector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	log "github.com/sirupsen/logrus"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultPatchCacheSize = 1024
+
+// patchCache holds the JSON patches computed for pods created from the same
+// ReplicaSet template, so that bursts of identical pods are only patched once.
+// A nil patch records that the pod required no changes.
+type patchCache struct {
+	cache *lru.Cache
+}
+
+var patches = newPatchCache(defaultPatchCacheSize)
+
+func newPatchCache(size int) *patchCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		// Programmer error: size must be positive.
+		panic(err)
+	}
+	return &patchCache{cache: cache}
+}
+
+func (c *patchCache) get(key string) ([]byte, bool) {
+	patch, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return patch.([]byte), true
+}
+
+func (c *patchCache) add(key string, patch []byte) {
+	c.cache.Add(key, patch)
+}
+
+// patchCacheKey returns the key under which the patch for the requested pod is
+// cached. The key covers everything the patch is computed from: the pod's
+// template (identified by its owner and pod-template-hash), the namespace
+// annotations and the global and proxy configs. Pods that weren't created from
+// a ReplicaSet template aren't cacheable.
+func patchCacheKey(request *admissionv1beta1.AdmissionRequest, nsAnnotations map[string]string, configs *pb.All) (string, bool) {
+	if request.Kind.Kind != "Pod" {
+		return "", false
+	}
+
+	var pod struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(request.Object.Raw, &pod); err != nil {
+		return "", false
+	}
+
+	templateHash := pod.Metadata.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	owner := metav1.GetControllerOf(&pod.Metadata)
+	if templateHash == "" || owner == nil {
+		return "", false
+	}
+
+	annotations, err := json.Marshal(nsAnnotations)
+	if err != nil {
+		return "", false
+	}
+
+	configBytes, err := proto.Marshal(configs)
+	if err != nil {
+		log.Warnf("failed to serialize configs for the patch cache: %s", err)
+		return "", false
+	}
+
+	hash := sha256.New()
+	for _, part := range []string{request.Namespace, string(owner.UID), templateHash, string(annotations), string(configBytes)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), true
+}
@@ -0,0 +1,93 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/gen/config"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func podRequest(namespace, raw string) *admissionv1beta1.AdmissionRequest {
+	return &admissionv1beta1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: namespace,
+		Object:    runtime.RawExtension{Raw: []byte(raw)},
+	}
+}
+
+func TestPatchCacheKey(t *testing.T) {
+	templatePod := `{
+  "metadata": {
+    "generateName": "books-64c68d6d46-",
+    "labels": {"app": "books", "pod-template-hash": "64c68d6d46"},
+    "ownerReferences": [{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "books-64c68d6d46", "uid": "1234", "controller": true}]
+  }
+}`
+	otherTemplatePod := `{
+  "metadata": {
+    "generateName": "books-7b6b9d8c9f-",
+    "labels": {"app": "books", "pod-template-hash": "7b6b9d8c9f"},
+    "ownerReferences": [{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "books-7b6b9d8c9f", "uid": "5678", "controller": true}]
+  }
+}`
+	barePod := `{"metadata": {"name": "books", "labels": {"app": "books"}}}`
+
+	nsAnnotations := map[string]string{"linkerd.io/inject": "enabled"}
+
+	key, ok := patchCacheKey(podRequest("default", templatePod), nsAnnotations, configs)
+	if !ok {
+		t.Fatal("Expected pod created from a ReplicaSet template to be cacheable")
+	}
+
+	t.Run("Returns the same key for pods created from the same template", func(t *testing.T) {
+		sameKey, ok := patchCacheKey(podRequest("default", templatePod), nsAnnotations, configs)
+		if !ok || sameKey != key {
+			t.Fatalf("Expected key %s, got %s", key, sameKey)
+		}
+	})
+
+	t.Run("Returns a different key when any input changes", func(t *testing.T) {
+		changedConfigs := proto.Clone(configs).(*config.All)
+		changedConfigs.Proxy.ProxyUid = 1234
+
+		testCases := []struct {
+			desc          string
+			request       *admissionv1beta1.AdmissionRequest
+			nsAnnotations map[string]string
+			configs       *config.All
+		}{
+			{"template", podRequest("default", otherTemplatePod), nsAnnotations, configs},
+			{"namespace", podRequest("other", templatePod), nsAnnotations, configs},
+			{"namespace annotations", podRequest("default", templatePod), map[string]string{}, configs},
+			{"configs", podRequest("default", templatePod), nsAnnotations, changedConfigs},
+		}
+
+		for _, tc := range testCases {
+			otherKey, ok := patchCacheKey(tc.request, tc.nsAnnotations, tc.configs)
+			if !ok {
+				t.Fatalf("Expected request with changed %s to be cacheable", tc.desc)
+			}
+			if otherKey == key {
+				t.Fatalf("Expected a different key when the %s changes", tc.desc)
+			}
+		}
+	})
+
+	t.Run("Doesn't cache pods without a template", func(t *testing.T) {
+		if _, ok := patchCacheKey(podRequest("default", barePod), nsAnnotations, configs); ok {
+			t.Fatal("Expected pod without a pod-template-hash to not be cacheable")
+		}
+	})
+
+	t.Run("Caches empty patches", func(t *testing.T) {
+		cache := newPatchCache(1)
+		cache.add(key, nil)
+		patch, ok := cache.get(key)
+		if !ok || patch != nil {
+			t.Fatalf("Expected cached empty patch, got %v (found: %t)", patch, ok)
+		}
+	})
+}
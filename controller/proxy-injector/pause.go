@@ -0,0 +1,68 @@
+package injector
+
+import (
+	k8sPkg "github.com/linkerd/linkerd2/pkg/k8s"
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PauseInjection makes the webhooks of the config match no namespace, so that
+// no pod is sent to the proxy injector, by requiring the namespaces to both
+// have and not have the ProxyInjectionPausedKey label. It returns false if the
+// webhooks were already paused.
+func PauseInjection(config *arv1beta1.MutatingWebhookConfiguration) bool {
+	if InjectionPaused(config) {
+		return false
+	}
+
+	for i := range config.Webhooks {
+		webhook := &config.Webhooks[i]
+		if webhook.NamespaceSelector == nil {
+			webhook.NamespaceSelector = &metav1.LabelSelector{}
+		}
+		webhook.NamespaceSelector.MatchExpressions = append(webhook.NamespaceSelector.MatchExpressions,
+			metav1.LabelSelectorRequirement{Key: k8sPkg.ProxyInjectionPausedKey, Operator: metav1.LabelSelectorOpExists},
+			metav1.LabelSelectorRequirement{Key: k8sPkg.ProxyInjectionPausedKey, Operator: metav1.LabelSelectorOpDoesNotExist},
+		)
+	}
+	return true
+}
+
+// ResumeInjection restores the namespace selectors of the webhooks paused by
+// PauseInjection. It returns false if the webhooks weren't paused.
+func ResumeInjection(config *arv1beta1.MutatingWebhookConfiguration) bool {
+	if !InjectionPaused(config) {
+		return false
+	}
+
+	for i := range config.Webhooks {
+		selector := config.Webhooks[i].NamespaceSelector
+		if selector == nil {
+			continue
+		}
+		var expressions []metav1.LabelSelectorRequirement
+		for _, expr := range selector.MatchExpressions {
+			if expr.Key != k8sPkg.ProxyInjectionPausedKey {
+				expressions = append(expressions, expr)
+			}
+		}
+		selector.MatchExpressions = expressions
+	}
+	return true
+}
+
+// InjectionPaused returns true if any webhook of the config was paused by
+// PauseInjection.
+func InjectionPaused(config *arv1beta1.MutatingWebhookConfiguration) bool {
+	for _, webhook := range config.Webhooks {
+		if webhook.NamespaceSelector == nil {
+			continue
+		}
+		for _, expr := range webhook.NamespaceSelector.MatchExpressions {
+			if expr.Key == k8sPkg.ProxyInjectionPausedKey {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package injector
+
+import (
+	"reflect"
+	"testing"
+
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestPauseInjection(t *testing.T) {
+	selector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "linkerd.io/is-control-plane", Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	}
+	config := &arv1beta1.MutatingWebhookConfiguration{
+		Webhooks: []arv1beta1.Webhook{
+			{Name: "linkerd-proxy-injector.linkerd.io", NamespaceSelector: selector.DeepCopy()},
+		},
+	}
+
+	if InjectionPaused(config) {
+		t.Fatal("Expected injection not to be paused")
+	}
+	if !PauseInjection(config) {
+		t.Fatal("Expected injection to be paused")
+	}
+	if PauseInjection(config) {
+		t.Fatal("Expected injection to be paused only once")
+	}
+	if !InjectionPaused(config) {
+		t.Fatal("Expected injection to be paused")
+	}
+
+	paused, err := metav1.LabelSelectorAsSelector(config.Webhooks[0].NamespaceSelector)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, nsLabels := range []labels.Set{
+		{},
+		{"linkerd.io/inject": "enabled"},
+		{"linkerd.io/injection-paused": "true"},
+	} {
+		if paused.Matches(nsLabels) {
+			t.Fatalf("Expected the paused selector not to match %v", nsLabels)
+		}
+	}
+
+	if !ResumeInjection(config) {
+		t.Fatal("Expected injection to be resumed")
+	}
+	if ResumeInjection(config) {
+		t.Fatal("Expected injection to be resumed only once")
+	}
+	if !reflect.DeepEqual(config.Webhooks[0].NamespaceSelector, selector) {
+		t.Fatalf("Expected selector %+v to be restored, got %+v", selector, config.Webhooks[0].NamespaceSelector)
+	}
+}
@@ -0,0 +1,99 @@
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/linkerd/linkerd2/pkg/inject"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// skipEventReason is the reason of the Events recorded on workloads whose
+	// pods couldn't be injected.
+	skipEventReason = "InjectionSkipped"
+
+	// skipEventInterval is the minimum time between two Events recorded on the
+	// same workload, so that a DaemonSet rolling out across many nodes gets one
+	// Event instead of one per pod.
+	skipEventInterval = 10 * time.Minute
+
+	defaultSkipEventCacheSize = 1024
+)
+
+// skipEventRecorder records Events explaining why pods that requested
+// injection were skipped on the workloads owning them, as the pods themselves
+// don't exist yet when they're admitted.
+type skipEventRecorder struct {
+	sync.Mutex
+	recorded *lru.Cache
+	now      func() time.Time
+}
+
+var skipEvents = newSkipEventRecorder(defaultSkipEventCacheSize)
+
+func newSkipEventRecorder(size int) *skipEventRecorder {
+	cache, err := lru.New(size)
+	if err != nil {
+		// Programmer error: size must be positive.
+		panic(err)
+	}
+	return &skipEventRecorder{recorded: cache, now: time.Now}
+}
+
+// record creates an Event on the controller of the requested pod with the
+// report's skip reason. Pods without a controller are only logged.
+func (r *skipEventRecorder) record(client kubernetes.Interface, request *admissionv1beta1.AdmissionRequest, report *inject.Report) {
+	var pod struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(request.Object.Raw, &pod); err != nil {
+		return
+	}
+	owner := metav1.GetControllerOf(&pod.Metadata)
+	if owner == nil {
+		return
+	}
+
+	now := r.now()
+	r.Lock()
+	if last, ok := r.recorded.Get(owner.UID); ok && now.Sub(last.(time.Time)) < skipEventInterval {
+		r.Unlock()
+		return
+	}
+	r.recorded.Add(owner.UID, now)
+	r.Unlock()
+
+	timestamp := metav1.NewTime(now)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", owner.Name, now.UnixNano()),
+			Namespace: request.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+			Namespace:  request.Namespace,
+			UID:        owner.UID,
+		},
+		Reason:         skipEventReason,
+		Message:        "linkerd proxy not injected: " + report.SkipReason(),
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: pkgK8s.ProxyInjectorWebhookServiceName},
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Count:          1,
+	}
+	if _, err := client.CoreV1().Events(request.Namespace).Create(event); err != nil {
+		log.Warnf("failed to record an event for %s/%s: %s", owner.Kind, owner.Name, err)
+	}
+}
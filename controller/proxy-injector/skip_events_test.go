@@ -0,0 +1,63 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSkipEventRecorder(t *testing.T) {
+	daemonSetPod := `{"metadata": {"name": "ingress-abcde", "ownerReferences": [{"apiVersion": "apps/v1", "kind": "DaemonSet", "name": "ingress", "uid": "1234", "controller": true}]}}`
+	barePod := `{"metadata": {"name": "ingress"}}`
+	report := &inject.Report{Kind: "pod", Name: "ingress-abcde", HostNetwork: true}
+
+	request := func(pod string) *admissionv1beta1.AdmissionRequest {
+		return &admissionv1beta1.AdmissionRequest{
+			Namespace: "ingress",
+			Object:    runtime.RawExtension{Raw: []byte(pod)},
+		}
+	}
+
+	now := time.Unix(0, 0)
+	recorder := newSkipEventRecorder(10)
+	recorder.now = func() time.Time { return now }
+	client := fake.NewSimpleClientset()
+
+	events := func() []corev1.Event {
+		list, err := client.CoreV1().Events("ingress").List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return list.Items
+	}
+
+	recorder.record(client, request(barePod), report)
+	if len(events()) != 0 {
+		t.Fatalf("Expected no events for a pod without controller, got %v", events())
+	}
+
+	recorder.record(client, request(daemonSetPod), report)
+	recorder.record(client, request(daemonSetPod), report)
+	if len(events()) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events()))
+	}
+	event := events()[0]
+	if event.InvolvedObject.Kind != "DaemonSet" || event.InvolvedObject.Name != "ingress" || event.Reason != skipEventReason || event.Type != corev1.EventTypeWarning {
+		t.Fatalf("Unexpected event: %+v", event)
+	}
+	if event.Message != "linkerd proxy not injected: "+report.SkipReason() {
+		t.Fatalf("Unexpected event message: %s", event.Message)
+	}
+
+	now = now.Add(skipEventInterval)
+	recorder.record(client, request(daemonSetPod), report)
+	if len(events()) != 2 {
+		t.Fatalf("Expected a new event after %s, got %d events", skipEventInterval, len(events()))
+	}
+}
@@ -15,6 +15,18 @@ webhooks:
       namespace: {{ .ControllerNamespace }}
       path: "/"
     caBundle: {{ .CABundle }}
+  namespaceSelector:
+    matchExpressions:
+    - key: {{ .ControllerNSLabel }}
+    {{- if .Scoped }}
+      operator: In
+      values: [ "{{ .ControllerNamespace }}" ]
+    {{- else }}
+      operator: DoesNotExist
+    {{- end }}
+    - key: {{ .AdmissionWebhooksLabel }}
+      operator: NotIn
+      values: [ "{{ .AdmissionWebhooksDisabled }}" ]
   rules:
   - operations: [ "CREATE" , "UPDATE" ]
     apiGroups: [""]
@@ -3,9 +3,7 @@ package injector
 import (
 	"fmt"
 
-	pb "github.com/linkerd/linkerd2/controller/gen/config"
 	"github.com/linkerd/linkerd2/controller/k8s"
-	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/inject"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
@@ -21,12 +19,7 @@ func Inject(api *k8s.API,
 ) (*admissionv1beta1.AdmissionResponse, error) {
 	log.Debugf("request object bytes: %s", request.Object.Raw)
 
-	globalConfig, err := config.Global(pkgK8s.MountPathGlobalConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	proxyConfig, err := config.Proxy(pkgK8s.MountPathProxyConfig)
+	configs, err := activeConfigs.get()
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +30,14 @@ func Inject(api *k8s.API,
 	}
 	nsAnnotations := namespace.GetAnnotations()
 
-	configs := &pb.All{Global: globalConfig, Proxy: proxyConfig}
+	cacheKey, cacheable := patchCacheKey(request, nsAnnotations, configs)
+	if cacheable {
+		if patchJSON, ok := patches.get(cacheKey); ok {
+			log.Debugf("using cached patch for pod in %s", request.Namespace)
+			return admissionResponse(request, patchJSON), nil
+		}
+	}
+
 	resourceConfig := inject.NewResourceConfig(configs, inject.OriginWebhook).
 		WithOwnerRetriever(ownerRetriever(api, request.Namespace)).
 		WithNsAnnotations(nsAnnotations).
@@ -48,14 +48,16 @@ func Inject(api *k8s.API,
 	}
 	log.Infof("received %s", report.ResName())
 
-	admissionResponse := &admissionv1beta1.AdmissionResponse{
-		UID:     request.UID,
-		Allowed: true,
-	}
-
 	if !report.Injectable() {
-		log.Infof("skipped %s", report.ResName())
-		return admissionResponse, nil
+		if reason := report.SkipReason(); reason != "" {
+			log.Infof("skipped %s: %s", report.ResName(), reason)
+			skipEvents.record(api.Client, request, report)
+		} else {
+			log.Infof("skipped %s", report.ResName())
+		}
+		// Skipped pods aren't cached, so that every one of them gets its skip
+		// Event recorded.
+		return admissionResponse(request, nil), nil
 	}
 
 	resourceConfig.AppendPodAnnotations(map[string]string{
@@ -66,22 +68,37 @@ func Inject(api *k8s.API,
 		return nil, err
 	}
 
-	if p.IsEmpty() {
-		return admissionResponse, nil
+	var patchJSON []byte
+	if !p.IsEmpty() {
+		patchJSON, err = p.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("patch generated for: %s", report.ResName())
+		log.Debugf("patch: %s", patchJSON)
 	}
 
-	patchJSON, err := p.Marshal()
-	if err != nil {
-		return nil, err
+	if cacheable {
+		patches.add(cacheKey, patchJSON)
 	}
-	log.Infof("patch generated for: %s", report.ResName())
-	log.Debugf("patch: %s", patchJSON)
 
-	patchType := admissionv1beta1.PatchTypeJSONPatch
-	admissionResponse.Patch = patchJSON
-	admissionResponse.PatchType = &patchType
+	return admissionResponse(request, patchJSON), nil
+}
+
+// admissionResponse allows the request, applying patchJSON if it isn't empty.
+func admissionResponse(request *admissionv1beta1.AdmissionRequest, patchJSON []byte) *admissionv1beta1.AdmissionResponse {
+	response := &admissionv1beta1.AdmissionResponse{
+		UID:     request.UID,
+		Allowed: true,
+	}
+
+	if len(patchJSON) > 0 {
+		patchType := admissionv1beta1.PatchTypeJSONPatch
+		response.Patch = patchJSON
+		response.PatchType = &patchType
+	}
 
-	return admissionResponse, nil
+	return response
 }
 
 func ownerRetriever(api *k8s.API, ns string) inject.OwnerRetrieverFunc {
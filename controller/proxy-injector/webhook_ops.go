@@ -11,11 +11,15 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-// Ops satisfies the ConfigOps interface for managing MutatingWebhook configs
-type Ops struct{}
+// Ops satisfies the ConfigOps interface for managing MutatingWebhook configs.
+// Injection paused in a deleted config stays paused in the config created
+// next, so that restarting the proxy injector doesn't resume injection.
+type Ops struct {
+	paused bool
+}
 
 // Create persists the Mutating webhook config and returns its SelfLink
-func (*Ops) Create(
+func (o *Ops) Create(
 	client clientArv1beta1.AdmissionregistrationV1beta1Interface,
 	buf *bytes.Buffer,
 ) (string, error) {
@@ -25,6 +29,11 @@ func (*Ops) Create(
 		return "", err
 	}
 
+	if o.paused {
+		log.Warn("proxy injection is paused, run `linkerd admin resume-injection` to resume it")
+		PauseInjection(&config)
+	}
+
 	obj, err := client.MutatingWebhookConfigurations().Create(&config)
 	if err != nil {
 		return "", err
@@ -32,22 +41,28 @@ func (*Ops) Create(
 	return obj.ObjectMeta.SelfLink, nil
 }
 
-// Exists returns an error if the Mutating webhook doesn't exist
-func (o *Ops) Exists(client clientArv1beta1.AdmissionregistrationV1beta1Interface) error {
+// Exists returns an error if the Mutating webhook with the given name doesn't exist
+func (*Ops) Exists(client clientArv1beta1.AdmissionregistrationV1beta1Interface, name string) error {
 	_, err := client.
 		MutatingWebhookConfigurations().
-		Get(o.Name(), metav1.GetOptions{})
+		Get(name, metav1.GetOptions{})
 	return err
 }
 
-// Delete removes the Mutating webhook from the cluster
-func (o *Ops) Delete(client clientArv1beta1.AdmissionregistrationV1beta1Interface) error {
+// Delete removes the Mutating webhook with the given name from the cluster
+func (o *Ops) Delete(client clientArv1beta1.AdmissionregistrationV1beta1Interface, name string) error {
+	config, err := client.MutatingWebhookConfigurations().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	o.paused = InjectionPaused(config)
+
 	return client.
 		MutatingWebhookConfigurations().
-		Delete(o.Name(), &metav1.DeleteOptions{})
+		Delete(name, &metav1.DeleteOptions{})
 }
 
-// Name returns name for this webhook configuration resource
+// Name returns the default name for this webhook configuration resource
 func (*Ops) Name() string {
 	return k8sPkg.ProxyInjectorWebhookConfigName
 }
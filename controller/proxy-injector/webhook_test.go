@@ -6,8 +6,10 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/proxy-injector/fake"
 	"github.com/linkerd/linkerd2/pkg/inject"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
@@ -164,6 +166,67 @@ func TestGetPatch(t *testing.T) {
 	})
 }
 
+func TestInjectSkipEvents(t *testing.T) {
+	api, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ingress
+  annotations:
+    linkerd.io/inject: enabled`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	api.Sync()
+
+	defer func(active *activeConfig, cache *patchCache, recorder *skipEventRecorder) {
+		activeConfigs, patches, skipEvents = active, cache, recorder
+	}(activeConfigs, patches, skipEvents)
+	activeConfigs = &activeConfig{configs: configs}
+	patches = newPatchCache(10)
+	now := time.Unix(0, 0)
+	skipEvents = newSkipEventRecorder(10)
+	skipEvents.now = func() time.Time { return now }
+
+	pod := []byte(`{
+  "kind": "Pod",
+  "apiVersion": "v1",
+  "metadata": {
+    "name": "ingress-abcde",
+    "labels": {"pod-template-hash": "abcde"},
+    "ownerReferences": [{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "ingress-abcde", "uid": "1234", "controller": true}]
+  },
+  "spec": {
+    "hostNetwork": true,
+    "containers": [{"name": "ingress", "image": "ingress"}]
+  }
+}`)
+	request := &admissionv1beta1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Kind: "Pod"},
+		Namespace: "ingress",
+		Object:    runtime.RawExtension{Raw: pod},
+	}
+
+	for i := 0; i < 2; i++ {
+		response, err := Inject(api, request)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(response.Patch) != 0 {
+			t.Fatalf("Expected the pod on the host network not to be patched, got %s", response.Patch)
+		}
+		now = now.Add(skipEventInterval)
+	}
+
+	events, err := api.Client.CoreV1().Events("ingress").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(events.Items) != 2 {
+		t.Fatalf("Expected an event for each admission of the template, got %d", len(events.Items))
+	}
+}
+
 func getFakeReq(b []byte) *admissionv1beta1.AdmissionRequest {
 	return &admissionv1beta1.AdmissionRequest{
 		Kind:      metav1.GroupVersionKind{Kind: "Pod"},
@@ -0,0 +1,209 @@
+package smi
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authV1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// The ConfigMap holding the configuration of the front proxy of the
+	// Kubernetes API aggregator, which the API servers it aggregates read.
+	authConfigMapNamespace = "kube-system"
+	authConfigMapName      = "extension-apiserver-authentication"
+)
+
+type (
+	// FrontProxyConfig holds the configuration of the front proxy of the
+	// Kubernetes API aggregator. It authenticates to the aggregated API servers
+	// with a client certificate, and passes the user it authenticated in
+	// request headers.
+	FrontProxyConfig struct {
+		ClientCAs           *x509.CertPool
+		AllowedNames        []string
+		UsernameHeaders     []string
+		GroupHeaders        []string
+		ExtraHeaderPrefixes []string
+	}
+
+	user struct {
+		name   string
+		groups []string
+		extra  map[string]authV1.ExtraValue
+	}
+
+	authHandler struct {
+		k8sClient kubernetes.Interface
+		config    *FrontProxyConfig
+		handler   http.Handler
+	}
+)
+
+// FetchFrontProxyConfig reads the configuration of the front proxy from the
+// extension-apiserver-authentication ConfigMap of the kube-system namespace.
+func FetchFrontProxyConfig(k8sClient kubernetes.Interface) (*FrontProxyConfig, error) {
+	cm, err := k8sClient.CoreV1().ConfigMaps(authConfigMapNamespace).Get(authConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ca := cm.Data["requestheader-client-ca-file"]
+	if ca == "" {
+		return nil, fmt.Errorf("the %s ConfigMap has no requestheader-client-ca-file; the API aggregator isn't enabled", authConfigMapName)
+	}
+	config := &FrontProxyConfig{ClientCAs: x509.NewCertPool()}
+	if !config.ClientCAs.AppendCertsFromPEM([]byte(ca)) {
+		return nil, fmt.Errorf("the requestheader-client-ca-file of the %s ConfigMap holds no certificate", authConfigMapName)
+	}
+
+	lists := map[string]*[]string{
+		"requestheader-allowed-names":        &config.AllowedNames,
+		"requestheader-username-headers":     &config.UsernameHeaders,
+		"requestheader-group-headers":        &config.GroupHeaders,
+		"requestheader-extra-headers-prefix": &config.ExtraHeaderPrefixes,
+	}
+	for key, list := range lists {
+		if value := cm.Data[key]; value != "" {
+			if err := json.Unmarshal([]byte(value), list); err != nil {
+				return nil, fmt.Errorf("invalid %s in the %s ConfigMap: %s", key, authConfigMapName, err)
+			}
+		}
+	}
+	if len(config.UsernameHeaders) == 0 {
+		return nil, fmt.Errorf("the %s ConfigMap has no requestheader-username-headers", authConfigMapName)
+	}
+	return config, nil
+}
+
+// NewAuthHandler returns a handler that only passes the requests of the front
+// proxy on to the given handler, for the users allowed to make them. The
+// front proxy is authenticated by its client certificate, which the server's
+// TLS configuration must verify against the ClientCAs of the config, and its
+// users are authorized with SubjectAccessReviews.
+func NewAuthHandler(k8sClient kubernetes.Interface, config *FrontProxyConfig, handler http.Handler) http.Handler {
+	return &authHandler{k8sClient: k8sClient, config: config, handler: handler}
+}
+
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	u, err := h.authenticate(req)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := h.authorize(u, req); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	h.handler.ServeHTTP(w, req)
+}
+
+// authenticate returns the user the front proxy authenticated, if the request
+// was made by the front proxy.
+func (h *authHandler) authenticate(req *http.Request) (*user, error) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+		return nil, errors.New("a client certificate of the front proxy is required")
+	}
+	if len(h.config.AllowedNames) > 0 {
+		cn := req.TLS.PeerCertificates[0].Subject.CommonName
+		if !contains(h.config.AllowedNames, cn) {
+			return nil, fmt.Errorf("the client certificate of %s isn't allowed", cn)
+		}
+	}
+
+	u := &user{extra: map[string]authV1.ExtraValue{}}
+	for _, header := range h.config.UsernameHeaders {
+		if u.name = req.Header.Get(header); u.name != "" {
+			break
+		}
+	}
+	if u.name == "" {
+		return nil, errors.New("the front proxy didn't set the user")
+	}
+	for _, header := range h.config.GroupHeaders {
+		u.groups = append(u.groups, req.Header[http.CanonicalHeaderKey(header)]...)
+	}
+	for name, values := range req.Header {
+		for _, prefix := range h.config.ExtraHeaderPrefixes {
+			prefix = http.CanonicalHeaderKey(prefix)
+			if strings.HasPrefix(name, prefix) {
+				key := strings.ToLower(strings.TrimPrefix(name, prefix))
+				u.extra[key] = append(u.extra[key], values...)
+			}
+		}
+	}
+	return u, nil
+}
+
+// authorize checks that the user may get the resource of the request with a
+// SubjectAccessReview.
+func (h *authHandler) authorize(u *user, req *http.Request) error {
+	sar := &authV1.SubjectAccessReview{
+		Spec: authV1.SubjectAccessReviewSpec{
+			User:   u.name,
+			Groups: u.groups,
+			Extra:  u.extra,
+		},
+	}
+	sar.Spec.ResourceAttributes, sar.Spec.NonResourceAttributes = accessAttributes(req.URL.Path)
+
+	result, err := h.k8sClient.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return fmt.Errorf("failed to authorize %s: %s", u.name, err)
+	}
+	if !result.Status.Allowed {
+		if result.Status.Reason != "" {
+			return fmt.Errorf("%s isn't allowed to get %s: %s", u.name, req.URL.Path, result.Status.Reason)
+		}
+		return fmt.Errorf("%s isn't allowed to get %s", u.name, req.URL.Path)
+	}
+	return nil
+}
+
+// accessAttributes returns the attributes of a request to the given path for
+// a SubjectAccessReview, following the paths served by the handler: the
+// resources of the API are listed and got, and the other paths, e.g. the
+// discovery of the API, are non-resource paths.
+func accessAttributes(path string) (*authV1.ResourceAttributes, *authV1.NonResourceAttributes) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, apiPrefix), "/"), "/")
+	if !strings.HasPrefix(path, apiPrefix+"/") || parts[0] == "" {
+		return nil, &authV1.NonResourceAttributes{Path: path, Verb: "get"}
+	}
+
+	attrs := &authV1.ResourceAttributes{
+		Group:   strings.Split(GroupVersion, "/")[0],
+		Version: strings.Split(GroupVersion, "/")[1],
+	}
+	switch {
+	case len(parts) == 1:
+		attrs.Verb, attrs.Resource = "list", parts[0]
+	case len(parts) == 2 && parts[0] == "namespaces":
+		attrs.Verb, attrs.Resource, attrs.Name = "get", "namespaces", parts[1]
+	case len(parts) == 3 && parts[0] == "namespaces":
+		attrs.Verb, attrs.Namespace, attrs.Resource = "list", parts[1], parts[2]
+	case len(parts) == 4 && parts[0] == "namespaces":
+		attrs.Verb, attrs.Namespace, attrs.Resource, attrs.Name = "get", parts[1], parts[2], parts[3]
+	case len(parts) == 5 && parts[0] == "namespaces":
+		attrs.Verb, attrs.Namespace, attrs.Resource, attrs.Name, attrs.Subresource = "get", parts[1], parts[2], parts[3], parts[4]
+	default:
+		return nil, &authV1.NonResourceAttributes{Path: path, Verb: "get"}
+	}
+	return attrs, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package smi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	authV1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAuthHandler(t *testing.T) {
+	config := &FrontProxyConfig{
+		AllowedNames:        []string{"front-proxy-client"},
+		UsernameHeaders:     []string{"X-Remote-User"},
+		GroupHeaders:        []string{"X-Remote-Group"},
+		ExtraHeaderPrefixes: []string{"X-Remote-Extra-"},
+	}
+
+	var reviews []*authV1.SubjectAccessReview
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authV1.SubjectAccessReview)
+		reviews = append(reviews, sar)
+		sar.Status.Allowed = sar.Spec.User == "admin"
+		return true, sar, nil
+	})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})
+	h := NewAuthHandler(k8sClient, config, next)
+
+	request := func(cn, user string) *http.Request {
+		req := httptest.NewRequest("GET", apiPrefix+"/namespaces/emojivoto/deployments/web/edges", nil)
+		if cn != "" {
+			cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+			req.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+				VerifiedChains:   [][]*x509.Certificate{{cert}},
+			}
+		}
+		req.Header.Set("X-Remote-User", user)
+		req.Header.Add("X-Remote-Group", "system:authenticated")
+		req.Header.Add("X-Remote-Group", "admins")
+		req.Header.Set("X-Remote-Extra-Scopes", "view")
+		return req
+	}
+
+	testCases := []struct {
+		id       string
+		req      *http.Request
+		expected int
+	}{
+		{"requires a client certificate", request("", "admin"), http.StatusUnauthorized},
+		{"requires the front proxy's certificate", request("kubelet", "admin"), http.StatusUnauthorized},
+		{"requires a user", request("front-proxy-client", ""), http.StatusUnauthorized},
+		{"rejects users that aren't authorized", request("front-proxy-client", "dev"), http.StatusForbidden},
+		{"serves authorized users", request("front-proxy-client", "admin"), http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.id, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, tc.req)
+			if recorder.Code != tc.expected {
+				t.Fatalf("Expected status code %d, got %d: %s", tc.expected, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+
+	expected := authV1.SubjectAccessReviewSpec{
+		User:   "admin",
+		Groups: []string{"system:authenticated", "admins"},
+		Extra:  map[string]authV1.ExtraValue{"scopes": {"view"}},
+		ResourceAttributes: &authV1.ResourceAttributes{
+			Namespace:   "emojivoto",
+			Verb:        "get",
+			Group:       "metrics.smi-spec.io",
+			Version:     "v1alpha1",
+			Resource:    "deployments",
+			Subresource: "edges",
+			Name:        "web",
+		},
+	}
+	if len(reviews) != 2 || !reflect.DeepEqual(reviews[1].Spec, expected) {
+		t.Fatalf("Expected the review %+v, got %+v", expected, reviews)
+	}
+}
+
+func TestAccessAttributes(t *testing.T) {
+	testCases := []struct {
+		path        string
+		resource    *authV1.ResourceAttributes
+		nonResource *authV1.NonResourceAttributes
+	}{
+		{
+			path:        apiPrefix,
+			nonResource: &authV1.NonResourceAttributes{Path: apiPrefix, Verb: "get"},
+		},
+		{
+			path:     apiPrefix + "/pods",
+			resource: &authV1.ResourceAttributes{Verb: "list", Group: "metrics.smi-spec.io", Version: "v1alpha1", Resource: "pods"},
+		},
+		{
+			path:     apiPrefix + "/namespaces/emojivoto",
+			resource: &authV1.ResourceAttributes{Verb: "get", Group: "metrics.smi-spec.io", Version: "v1alpha1", Resource: "namespaces", Name: "emojivoto"},
+		},
+		{
+			path:     apiPrefix + "/namespaces/emojivoto/deployments",
+			resource: &authV1.ResourceAttributes{Namespace: "emojivoto", Verb: "list", Group: "metrics.smi-spec.io", Version: "v1alpha1", Resource: "deployments"},
+		},
+		{
+			path:        "/healthz",
+			nonResource: &authV1.NonResourceAttributes{Path: "/healthz", Verb: "get"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.path, func(t *testing.T) {
+			resource, nonResource := accessAttributes(tc.path)
+			if !reflect.DeepEqual(resource, tc.resource) || !reflect.DeepEqual(nonResource, tc.nonResource) {
+				t.Fatalf("Expected %+v and %+v, got %+v and %+v", tc.resource, tc.nonResource, resource, nonResource)
+			}
+		})
+	}
+}
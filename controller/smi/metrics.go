@@ -0,0 +1,343 @@
+package smi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GroupVersion is the API group and version of the SMI TrafficMetrics API.
+	GroupVersion = "metrics.smi-spec.io/v1alpha1"
+
+	// DefaultWindow is the time window of the metrics when requests don't set
+	// the window parameter.
+	DefaultWindow = 30 * time.Second
+
+	apiPrefix = "/apis/" + GroupVersion
+
+	edgeDirectionFrom = "from"
+	edgeDirectionTo   = "to"
+
+	// Linkerd's edge metrics are the outbound metrics of the clients.
+	edgeSideClient = "client"
+)
+
+// kinds maps the plural resource names of the API to the Kubernetes kinds of
+// the resources whose metrics are served.
+var kinds = map[string]string{
+	"daemonsets":   "DaemonSet",
+	"deployments":  "Deployment",
+	"jobs":         "Job",
+	"namespaces":   "Namespace",
+	"pods":         "Pod",
+	"statefulsets": "StatefulSet",
+}
+
+type (
+	// TrafficMetrics holds the metrics of a resource, or of the traffic between
+	// a resource and another one if Edge is set, over a time window.
+	TrafficMetrics struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata"`
+
+		Timestamp metav1.Time             `json:"timestamp"`
+		Window    metav1.Duration         `json:"window"`
+		Resource  *corev1.ObjectReference `json:"resource"`
+		Edge      *Edge                   `json:"edge"`
+		Metrics   []*Metric               `json:"metrics"`
+	}
+
+	// TrafficMetricsList is a list of TrafficMetrics, about the resources of a
+	// kind, or about the edges of Resource.
+	TrafficMetricsList struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata"`
+
+		Resource *corev1.ObjectReference `json:"resource"`
+		Items    []*TrafficMetrics       `json:"items"`
+	}
+
+	// Edge describes the other resource of the traffic of a TrafficMetrics.
+	Edge struct {
+		Direction string                  `json:"direction"`
+		Side      string                  `json:"side"`
+		Resource  *corev1.ObjectReference `json:"resource"`
+	}
+
+	// Metric is a single metric of a TrafficMetrics.
+	Metric struct {
+		Name  string             `json:"name"`
+		Unit  string             `json:"unit,omitempty"`
+		Value *resource.Quantity `json:"value"`
+	}
+
+	handler struct {
+		client pb.ApiClient
+		now    func() time.Time
+	}
+)
+
+// NewHandler returns a handler serving the SMI TrafficMetrics API under
+// /apis/metrics.smi-spec.io/v1alpha1, from the stats of the public API.
+func NewHandler(client pb.ApiClient) http.Handler {
+	return &handler{client: client, now: time.Now}
+}
+
+// ServeHTTP serves the following paths, relative to the API prefix:
+//
+//	/                                       the discovery of the API
+//	/<kind>                                 the resources of a kind, in all namespaces
+//	/namespaces/<ns>                        a namespace
+//	/namespaces/<ns>/<kind>                 the resources of a kind, in a namespace
+//	/namespaces/<ns>/<kind>/<name>          a resource
+//	/namespaces/<ns>/<kind>/<name>/edges    the traffic from and to a resource
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s isn't supported", req.Method))
+		return
+	}
+	if req.URL.Path != apiPrefix && !strings.HasPrefix(req.URL.Path, apiPrefix+"/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s isn't served", req.URL.Path))
+		return
+	}
+
+	window := DefaultWindow
+	if param := req.FormValue("window"); param != "" {
+		var err error
+		window, err = time.ParseDuration(param)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid window: %s", err))
+			return
+		}
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, apiPrefix), "/")
+	parts := []string{}
+	if path != "" {
+		parts = strings.Split(path, "/")
+	}
+
+	var (
+		rsp interface{}
+		err error
+	)
+	switch {
+	case len(parts) == 0:
+		rsp = discovery()
+	case len(parts) == 1:
+		rsp, err = h.list(req.Context(), "", parts[0], window)
+	case len(parts) == 2 && parts[0] == "namespaces":
+		rsp, err = h.get(req.Context(), "", "namespaces", parts[1], window)
+	case len(parts) == 3 && parts[0] == "namespaces":
+		rsp, err = h.list(req.Context(), parts[1], parts[2], window)
+	case len(parts) == 4 && parts[0] == "namespaces":
+		rsp, err = h.get(req.Context(), parts[1], parts[2], parts[3], window)
+	case len(parts) == 5 && parts[0] == "namespaces" && parts[4] == "edges":
+		rsp, err = h.edges(req.Context(), parts[1], parts[2], parts[3], window)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s isn't served", req.URL.Path))
+		return
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(notFoundError); ok {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rsp); err != nil {
+		log.Errorf("failed to write the response: %s", err)
+	}
+}
+
+type notFoundError string
+
+func (e notFoundError) Error() string { return string(e) }
+
+func (h *handler) get(ctx context.Context, namespace, plural, name string, window time.Duration) (*TrafficMetrics, error) {
+	rows, err := h.stats(ctx, &pb.Resource{Namespace: namespace, Type: resourceType(plural), Name: name}, nil, window, plural)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, notFoundError(fmt.Sprintf("%s %s not found", plural, name))
+	}
+	return h.trafficMetrics(objectReference(rows[0].GetResource()), rows[0].GetStats(), window, nil), nil
+}
+
+func (h *handler) list(ctx context.Context, namespace, plural string, window time.Duration) (*TrafficMetricsList, error) {
+	rows, err := h.stats(ctx, &pb.Resource{Namespace: namespace, Type: resourceType(plural)}, nil, window, plural)
+	if err != nil {
+		return nil, err
+	}
+
+	list := newList(&corev1.ObjectReference{Kind: kinds[plural], Namespace: namespace})
+	for _, row := range rows {
+		list.Items = append(list.Items, h.trafficMetrics(objectReference(row.GetResource()), row.GetStats(), window, nil))
+	}
+	return list, nil
+}
+
+// edges returns the metrics of the traffic between a resource and the other
+// resources of its kind in its namespace, in both directions.
+func (h *handler) edges(ctx context.Context, namespace, plural, name string, window time.Duration) (*TrafficMetricsList, error) {
+	target := &pb.Resource{Namespace: namespace, Type: resourceType(plural), Name: name}
+	peers := &pb.Resource{Namespace: namespace, Type: resourceType(plural)}
+
+	list := newList(objectReference(target))
+	for _, direction := range []string{edgeDirectionFrom, edgeDirectionTo} {
+		req := &pb.StatSummaryRequest{}
+		if direction == edgeDirectionFrom {
+			req.Outbound = &pb.StatSummaryRequest_ToResource{ToResource: target}
+		} else {
+			req.Outbound = &pb.StatSummaryRequest_FromResource{FromResource: target}
+		}
+
+		rows, err := h.stats(ctx, peers, req, window, plural)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if row.GetStats() == nil {
+				continue
+			}
+			list.Items = append(list.Items, h.trafficMetrics(list.Resource, row.GetStats(), window, &Edge{
+				Direction: direction,
+				Side:      edgeSideClient,
+				Resource:  objectReference(row.GetResource()),
+			}))
+		}
+	}
+	return list, nil
+}
+
+// stats returns the stat rows of the selected resources. The request may set
+// the other resource of the traffic.
+func (h *handler) stats(ctx context.Context, selected *pb.Resource, req *pb.StatSummaryRequest, window time.Duration, plural string) ([]*pb.StatTable_PodGroup_Row, error) {
+	if selected.Type == "" {
+		return nil, notFoundError(fmt.Sprintf("the %s resource type isn't supported", plural))
+	}
+
+	if req == nil {
+		req = &pb.StatSummaryRequest{}
+	}
+	req.Selector = &pb.ResourceSelection{Resource: selected}
+	req.TimeWindow = window.String()
+
+	rsp, err := h.client.StatSummary(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if e := rsp.GetError(); e != nil {
+		return nil, fmt.Errorf("failed to fetch the stats: %s", e.GetError())
+	}
+
+	rows := []*pb.StatTable_PodGroup_Row{}
+	for _, table := range rsp.GetOk().GetStatTables() {
+		rows = append(rows, table.GetPodGroup().GetRows()...)
+	}
+	return rows, nil
+}
+
+func (h *handler) trafficMetrics(ref *corev1.ObjectReference, stats *pb.BasicStats, window time.Duration, edge *Edge) *TrafficMetrics {
+	return &TrafficMetrics{
+		TypeMeta: metav1.TypeMeta{Kind: "TrafficMetrics", APIVersion: GroupVersion},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              ref.Name,
+			Namespace:         ref.Namespace,
+			CreationTimestamp: metav1.NewTime(h.now()),
+		},
+		Timestamp: metav1.NewTime(h.now()),
+		Window:    metav1.Duration{Duration: window},
+		Resource:  ref,
+		Edge:      edge,
+		Metrics: []*Metric{
+			latencyMetric("p99_response_latency", stats.GetLatencyMsP99()),
+			latencyMetric("p95_response_latency", stats.GetLatencyMsP95()),
+			latencyMetric("p50_response_latency", stats.GetLatencyMsP50()),
+			countMetric("success_count", stats.GetSuccessCount()),
+			countMetric("failure_count", stats.GetFailureCount()),
+		},
+	}
+}
+
+func latencyMetric(name string, ms uint64) *Metric {
+	return &Metric{Name: name, Unit: "seconds", Value: resource.NewMilliQuantity(int64(ms), resource.DecimalSI)}
+}
+
+func countMetric(name string, count uint64) *Metric {
+	return &Metric{Name: name, Value: resource.NewQuantity(int64(count), resource.DecimalSI)}
+}
+
+func newList(ref *corev1.ObjectReference) *TrafficMetricsList {
+	return &TrafficMetricsList{
+		TypeMeta: metav1.TypeMeta{Kind: "TrafficMetricsList", APIVersion: GroupVersion},
+		Resource: ref,
+		Items:    []*TrafficMetrics{},
+	}
+}
+
+func objectReference(r *pb.Resource) *corev1.ObjectReference {
+	for plural, kind := range kinds {
+		if resourceType(plural) == r.GetType() {
+			return &corev1.ObjectReference{Kind: kind, Namespace: r.GetNamespace(), Name: r.GetName()}
+		}
+	}
+	return &corev1.ObjectReference{Kind: r.GetType(), Namespace: r.GetNamespace(), Name: r.GetName()}
+}
+
+// resourceType returns the public API resource type of a plural resource name
+// of the API, or an empty string if the resources aren't served.
+func resourceType(plural string) string {
+	if _, ok := kinds[plural]; !ok {
+		return ""
+	}
+	t, err := k8s.CanonicalResourceNameFromFriendlyName(plural)
+	if err != nil {
+		return ""
+	}
+	return t
+}
+
+// discovery describes the resources of the API, so that kubectl and the
+// Kubernetes API aggregator can discover them.
+func discovery() *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: GroupVersion,
+	}
+	for _, plural := range []string{"daemonsets", "deployments", "jobs", "namespaces", "pods", "statefulsets"} {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       plural,
+			Namespaced: plural != "namespaces",
+			Kind:       kinds[plural],
+			Verbs:      metav1.Verbs{"get", "list"},
+		})
+	}
+	return list
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  err.Error(),
+		Code:     int32(code),
+	})
+}
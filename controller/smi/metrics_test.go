@@ -0,0 +1,193 @@
+package smi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+// recordingClient returns the stats of the mock, and records the requests.
+type recordingClient struct {
+	public.MockAPIClient
+	requests []*pb.StatSummaryRequest
+}
+
+func (c *recordingClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	c.requests = append(c.requests, in)
+	return c.MockAPIClient.StatSummary(ctx, in, opts...)
+}
+
+func statSummaryResponse(resourceType string, names ...string) *pb.StatSummaryResponse {
+	rows := []*pb.StatTable_PodGroup_Row{}
+	for _, name := range names {
+		rows = append(rows, &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{Namespace: "emojivoto", Type: resourceType, Name: name},
+			Stats: &pb.BasicStats{
+				SuccessCount: 90,
+				FailureCount: 10,
+				LatencyMsP50: 12,
+				LatencyMsP95: 45,
+				LatencyMsP99: 120,
+			},
+		})
+	}
+	return &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables: []*pb.StatTable{
+					{
+						Table: &pb.StatTable_PodGroup_{
+							PodGroup: &pb.StatTable_PodGroup{Rows: rows},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func serve(client pb.ApiClient, path string) (int, []byte) {
+	h := &handler{
+		client: client,
+		now:    func() time.Time { return time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code, rec.Body.Bytes()
+}
+
+func TestServeResources(t *testing.T) {
+	client := &recordingClient{
+		MockAPIClient: public.MockAPIClient{
+			StatSummaryResponseToReturn: statSummaryResponse("deployment", "web", "voting"),
+		},
+	}
+
+	code, body := serve(client, apiPrefix+"/namespaces/emojivoto/deployments/web?window=1m")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, code, body)
+	}
+
+	var tm TrafficMetrics
+	if err := json.Unmarshal(body, &tm); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if tm.Kind != "TrafficMetrics" || tm.Resource.Kind != "Deployment" || tm.Resource.Name != "web" {
+		t.Fatalf("Unexpected TrafficMetrics %+v", tm)
+	}
+	if tm.Window.Duration != time.Minute {
+		t.Fatalf("Expected a window of 1m, got %s", tm.Window.Duration)
+	}
+
+	metrics := map[string]string{}
+	for _, m := range tm.Metrics {
+		metrics[m.Name] = m.Value.String()
+	}
+	expectedMetrics := map[string]string{
+		"p99_response_latency": "120m",
+		"p95_response_latency": "45m",
+		"p50_response_latency": "12m",
+		"success_count":        "90",
+		"failure_count":        "10",
+	}
+	if !reflect.DeepEqual(metrics, expectedMetrics) {
+		t.Fatalf("Expected metrics %v, got %v", expectedMetrics, metrics)
+	}
+
+	expectedSelector := &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"}
+	if len(client.requests) != 1 || !reflect.DeepEqual(client.requests[0].GetSelector().GetResource(), expectedSelector) || client.requests[0].GetTimeWindow() != "1m0s" {
+		t.Fatalf("Unexpected requests %v", client.requests)
+	}
+
+	code, body = serve(client, apiPrefix+"/namespaces/emojivoto/deployments")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, code, body)
+	}
+
+	var list TrafficMetricsList
+	if err := json.Unmarshal(body, &list); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(list.Items) != 2 || list.Items[1].Resource.Name != "voting" || list.Items[0].Window.Duration != DefaultWindow {
+		t.Fatalf("Unexpected TrafficMetricsList %+v", list)
+	}
+}
+
+func TestServeEdges(t *testing.T) {
+	client := &recordingClient{
+		MockAPIClient: public.MockAPIClient{
+			StatSummaryResponseToReturn: statSummaryResponse("deployment", "voting"),
+		},
+	}
+
+	code, body := serve(client, apiPrefix+"/namespaces/emojivoto/deployments/web/edges")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, code, body)
+	}
+
+	var list TrafficMetricsList
+	if err := json.Unmarshal(body, &list); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if list.Resource.Name != "web" || len(list.Items) != 2 {
+		t.Fatalf("Unexpected TrafficMetricsList %+v", list)
+	}
+	for i, direction := range []string{edgeDirectionFrom, edgeDirectionTo} {
+		item := list.Items[i]
+		if item.Resource.Name != "web" || item.Edge.Direction != direction || item.Edge.Side != edgeSideClient || item.Edge.Resource.Name != "voting" {
+			t.Fatalf("Unexpected edge %+v", item)
+		}
+	}
+
+	target := &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"}
+	if len(client.requests) != 2 ||
+		!reflect.DeepEqual(client.requests[0].GetToResource(), target) ||
+		!reflect.DeepEqual(client.requests[1].GetFromResource(), target) {
+		t.Fatalf("Unexpected requests %v", client.requests)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	testCases := []struct {
+		path     string
+		response *pb.StatSummaryResponse
+		expected int
+	}{
+		{"/apis/metrics.k8s.io/v1beta1", nil, http.StatusNotFound},
+		{apiPrefix + "/namespaces/emojivoto/services", nil, http.StatusNotFound},
+		{apiPrefix + "/namespaces/emojivoto/deployments/web?window=1", nil, http.StatusBadRequest},
+		{apiPrefix + "/namespaces/emojivoto/deployments/web", statSummaryResponse("deployment"), http.StatusNotFound},
+		{apiPrefix + "/namespaces/emojivoto/deployments/web/pods", nil, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.path, func(t *testing.T) {
+			client := &public.MockAPIClient{StatSummaryResponseToReturn: tc.response}
+			code, body := serve(client, tc.path)
+			if code != tc.expected {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expected, code, body)
+			}
+		})
+	}
+}
+
+func TestServeDiscovery(t *testing.T) {
+	code, body := serve(&public.MockAPIClient{}, apiPrefix)
+	if code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, code, body)
+	}
+
+	list := discovery()
+	if list.GroupVersion != GroupVersion || len(list.APIResources) != len(kinds) {
+		t.Fatalf("Unexpected APIResourceList %+v", list)
+	}
+}
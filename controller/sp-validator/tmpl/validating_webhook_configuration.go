@@ -15,6 +15,15 @@ webhooks:
       namespace: {{ .ControllerNamespace }}
       path: "/"
     caBundle: {{ .CABundle }}
+  namespaceSelector:
+    matchExpressions:
+    - key: {{ .ControllerNSLabel }}
+    {{- if .Scoped }}
+      operator: In
+      values: [ "{{ .ControllerNamespace }}" ]
+    {{- else }}
+      operator: DoesNotExist
+    {{- end }}
   rules:
   - operations: [ "CREATE" , "UPDATE" ]
     apiGroups: ["linkerd.io"]
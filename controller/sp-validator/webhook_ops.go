@@ -32,22 +32,22 @@ func (*Ops) Create(
 	return obj.ObjectMeta.SelfLink, nil
 }
 
-// Exists returns an error if the Validating webhook doesn't exist
-func (o *Ops) Exists(client clientArv1beta1.AdmissionregistrationV1beta1Interface) error {
+// Exists returns an error if the Validating webhook with the given name doesn't exist
+func (*Ops) Exists(client clientArv1beta1.AdmissionregistrationV1beta1Interface, name string) error {
 	_, err := client.
 		ValidatingWebhookConfigurations().
-		Get(o.Name(), metav1.GetOptions{})
+		Get(name, metav1.GetOptions{})
 	return err
 }
 
-// Delete removes the Validating webhook from the cluster
-func (o *Ops) Delete(client clientArv1beta1.AdmissionregistrationV1beta1Interface) error {
+// Delete removes the Validating webhook with the given name from the cluster
+func (*Ops) Delete(client clientArv1beta1.AdmissionregistrationV1beta1Interface, name string) error {
 	return client.
 		ValidatingWebhookConfigurations().
-		Delete(o.Name(), &metav1.DeleteOptions{})
+		Delete(name, &metav1.DeleteOptions{})
 }
 
-// Name returns name for this webhook configuration resource
+// Name returns the default name for this webhook configuration resource
 func (*Ops) Name() string {
 	return k8sPkg.SPValidatorWebhookConfigName
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	httpPb "github.com/linkerd/linkerd2-proxy-api/go/http_types"
@@ -33,6 +34,17 @@ type (
 		tapPort             uint
 		k8sAPI              *k8s.API
 		controllerNamespace string
+		podNotifier         *podNotifier
+	}
+
+	// podNotifier notifies its subscribers of the pods being added, updated or
+	// deleted in their namespace, so that taps can follow the pods of their
+	// target.
+	podNotifier struct {
+		// subscribers maps each subscriber to its namespace, empty for all
+		// namespaces
+		subscribers map[chan struct{}]string
+		mutex       sync.Mutex
 	}
 )
 
@@ -55,56 +67,85 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 		req.MaxRps = defaultMaxRps
 	}
 
-	objects, err := s.k8sAPI.GetObjects(req.Target.Resource.Namespace, req.Target.Resource.Type, req.Target.Resource.Name)
+	res := req.GetTarget().GetResource()
+	pods, err := s.targetPods(res)
 	if err != nil {
 		return apiUtil.GRPCError(err)
 	}
 
-	pods := []*corev1.Pod{}
-	for _, object := range objects {
-		podsFor, err := s.k8sAPI.GetPodsFor(object, false)
-		if err != nil {
-			return apiUtil.GRPCError(err)
-		}
-
-		for _, pod := range podsFor {
-			if pkgK8s.IsMeshed(pod, s.controllerNamespace) {
-				pods = append(pods, pod)
-			}
-		}
-	}
-
 	if len(pods) == 0 {
 		return status.Errorf(codes.NotFound, "no pods found for %s/%s",
-			req.GetTarget().GetResource().GetType(), req.GetTarget().GetResource().GetName())
+			res.GetType(), res.GetName())
 	}
 
 	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
 
-	events := make(chan *public.TapEvent)
-
-	// divide the rps evenly between all pods to tap
-	rpsPerPod := req.MaxRps / float32(len(pods))
-	if rpsPerPod < 1 {
-		rpsPerPod = 1
-	}
-
 	match, err := makeByResourceMatch(req.Match)
 	if err != nil {
 		return apiUtil.GRPCError(err)
 	}
+	sources := makeSourceMatches(req.Match)
+
+	// subscribe before starting the taps, so that pods replaced in between
+	// aren't missed
+	updates := s.podNotifier.subscribe(res.GetNamespace())
+	defer s.podNotifier.unsubscribe(updates)
 
-	for _, pod := range pods {
-		// initiate a tap on the pod
-		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events)
+	events := make(chan *public.TapEvent)
+
+	// taps holds the cancel funcs of the running taps, keyed by pod IP
+	taps := make(map[string]context.CancelFunc)
+	retarget := func(pods []*corev1.Pod) {
+		targets := make(map[string]struct{})
+		for _, pod := range pods {
+			// pods are tapped once they've been assigned an IP
+			if pod.Status.PodIP != "" {
+				targets[pod.Status.PodIP] = struct{}{}
+			}
+		}
+
+		for ip, cancel := range taps {
+			if _, ok := targets[ip]; !ok {
+				log.Infof("Stopping tap on %s", ip)
+				cancel()
+				delete(taps, ip)
+			}
+		}
+
+		// divide the rps evenly between all pods to tap
+		rpsPerPod := req.MaxRps / float32(len(targets))
+		if rpsPerPod < 1 {
+			rpsPerPod = 1
+		}
+
+		for ip := range targets {
+			if _, ok := taps[ip]; !ok {
+				// initiate a tap on the pod
+				ctx, cancel := context.WithCancel(stream.Context())
+				taps[ip] = cancel
+				go s.tapProxy(ctx, rpsPerPod, match, ip, events)
+			}
+		}
 	}
+	retarget(pods)
 
-	// read events from the taps and send them back
+	// read events from the taps and send them back, following the pods of the
+	// target as they're replaced, e.g. during a rollout
 	for {
 		select {
 		case <-stream.Context().Done():
 			return nil
+		case <-updates:
+			pods, err := s.targetPods(res)
+			if err != nil {
+				log.Warnf("Failed to update the pods tapped for target %+v: %s", *res, err)
+				continue
+			}
+			retarget(pods)
 		case event := <-events:
+			if !matchesSources(event, sources) {
+				continue
+			}
 			err := stream.Send(event)
 			if err != nil {
 				return apiUtil.GRPCError(err)
@@ -113,6 +154,30 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 	}
 }
 
+// targetPods returns the meshed pods of a tap target, leaving out the pods
+// that are terminating, as their proxies are shutting down.
+func (s *server) targetPods(res *public.Resource) ([]*corev1.Pod, error) {
+	objects, err := s.k8sAPI.GetObjects(res.GetNamespace(), res.GetType(), res.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []*corev1.Pod{}
+	for _, object := range objects {
+		podsFor, err := s.k8sAPI.GetPodsFor(object, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range podsFor {
+			if pod.DeletionTimestamp == nil && pkgK8s.IsMeshed(pod, s.controllerNamespace) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
 func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.ObserveRequest_Match, error) {
 	// TODO: for now assume it's always a single, flat `All` match list
 	seq := match.GetAll()
@@ -126,7 +191,7 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 		switch typed := reqMatch.Match.(type) {
 		case *public.TapByResourceRequest_Match_Destinations:
 
-			for k, v := range destinationLabels(typed.Destinations.Resource) {
+			for k, v := range resourceLabels(typed.Destinations.Resource) {
 				matches = append(matches, &proxy.ObserveRequest_Match{
 					Match: &proxy.ObserveRequest_Match_DestinationLabel{
 						DestinationLabel: &proxy.ObserveRequest_Match_Label{
@@ -137,6 +202,11 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 				})
 			}
 
+		case *public.TapByResourceRequest_Match_Sources:
+			// proxies can't match on the labels of the sources, so they're
+			// matched against the events by matchesSources instead
+			continue
+
 		case *public.TapByResourceRequest_Match_Http_:
 
 			httpMatch := proxy.ObserveRequest_Match_Http{}
@@ -198,17 +268,43 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 	}, nil
 }
 
+// makeSourceMatches returns the labels that the sources of the events must
+// have for each of the sources matches.
+func makeSourceMatches(match *public.TapByResourceRequest_Match) []map[string]string {
+	sources := []map[string]string{}
+	for _, reqMatch := range match.GetAll().GetMatches() {
+		if typed, ok := reqMatch.Match.(*public.TapByResourceRequest_Match_Sources); ok {
+			sources = append(sources, resourceLabels(typed.Sources.GetResource()))
+		}
+	}
+	return sources
+}
+
+// matchesSources returns true if the source of the event has all the labels
+// of every source match.
+func matchesSources(event *public.TapEvent, sources []map[string]string) bool {
+	sourceLabels := event.GetSourceMeta().GetLabels()
+	for _, labels := range sources {
+		for k, v := range labels {
+			if sourceLabels[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // TODO: factor out with `promLabels` in public-api
-func destinationLabels(resource *public.Resource) map[string]string {
-	dstLabels := map[string]string{}
+func resourceLabels(resource *public.Resource) map[string]string {
+	labels := map[string]string{}
 	if resource.Name != "" {
 		l5dLabel := pkgK8s.KindToL5DLabel(resource.Type)
-		dstLabels[l5dLabel] = resource.Name
+		labels[l5dLabel] = resource.Name
 	}
 	if resource.Type != pkgK8s.Namespace && resource.Namespace != "" {
-		dstLabels["namespace"] = resource.Namespace
+		labels["namespace"] = resource.Namespace
 	}
-	return dstLabels
+	return labels
 }
 
 // Tap a pod.
@@ -434,6 +530,8 @@ func NewServer(
 	k8sAPI *k8s.API,
 ) (*grpc.Server, net.Listener, error) {
 	k8sAPI.Pod().Informer().AddIndexers(cache.Indexers{podIPIndex: indexPodByIP})
+	podNotifier := newPodNotifier()
+	k8sAPI.Pod().Informer().AddEventHandler(podNotifier.eventHandler())
 
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -445,12 +543,71 @@ func NewServer(
 		tapPort:             tapPort,
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
+		podNotifier:         podNotifier,
 	}
 	pb.RegisterTapServer(s, &srv)
 
 	return s, lis, nil
 }
 
+func newPodNotifier() *podNotifier {
+	return &podNotifier{
+		subscribers: make(map[chan struct{}]string),
+	}
+}
+
+// subscribe returns a channel receiving a value whenever pods change in the
+// given namespace, or in any namespace if empty. Notifications are coalesced
+// while the subscriber isn't receiving them.
+func (n *podNotifier) subscribe(namespace string) chan struct{} {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	updates := make(chan struct{}, 1)
+	n.subscribers[updates] = namespace
+	return updates
+}
+
+func (n *podNotifier) unsubscribe(updates chan struct{}) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.subscribers, updates)
+}
+
+func (n *podNotifier) notify(namespace string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for updates, ns := range n.subscribers {
+		if ns != "" && ns != namespace {
+			continue
+		}
+		select {
+		case updates <- struct{}{}:
+		default:
+			// a notification is already pending
+		}
+	}
+}
+
+func (n *podNotifier) eventHandler() cache.ResourceEventHandler {
+	notify := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if pod, ok := obj.(*corev1.Pod); ok {
+			n.notify(pod.Namespace)
+		}
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, obj interface{}) { notify(obj) },
+		DeleteFunc: notify,
+	}
+}
+
 func indexPodByIP(obj interface{}) ([]string, error) {
 	if pod, ok := obj.(*corev1.Pod); ok {
 		return []string{pod.Status.PodIP}, nil
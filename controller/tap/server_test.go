@@ -8,6 +8,9 @@ import (
 	"github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 type tapExpected struct {
@@ -134,6 +137,34 @@ metadata:
     linkerd.io/proxy-version: testinjectversion
 status:
   phase: Finished
+`,
+				},
+				req: public.TapByResourceRequest{
+					Target: &public.ResourceSelection{
+						Resource: &public.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+							Name:      "emojivoto-meshed",
+						},
+					},
+				},
+			},
+			{
+				msg: "rpc error: code = NotFound desc = no pods found for pod/emojivoto-meshed",
+				k8sRes: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: controller-ns
+  annotations:
+    linkerd.io/proxy-version: testinjectversion
+  deletionTimestamp: 2019-10-01T00:00:00Z
+status:
+  phase: Running
 `,
 				},
 				req: public.TapByResourceRequest{
@@ -222,3 +253,105 @@ status:
 		}
 	})
 }
+
+func TestPodNotifier(t *testing.T) {
+	notifier := newPodNotifier()
+	emojivoto := notifier.subscribe("emojivoto")
+	all := notifier.subscribe("")
+
+	handler := notifier.eventHandler()
+	handler.OnAdd(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "books"}})
+
+	select {
+	case <-emojivoto:
+		t.Fatalf("Unexpected notification for a pod in another namespace")
+	default:
+	}
+	select {
+	case <-all:
+	default:
+		t.Fatalf("Expected a notification for a pod in any namespace")
+	}
+
+	// notifications are coalesced until they're received
+	handler.OnAdd(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "emojivoto"}})
+	handler.OnDelete(cache.DeletedFinalStateUnknown{
+		Obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "emojivoto"}},
+	})
+	select {
+	case <-emojivoto:
+	default:
+		t.Fatalf("Expected a notification for a pod in the namespace")
+	}
+	select {
+	case <-emojivoto:
+		t.Fatalf("Expected notifications to be coalesced")
+	default:
+	}
+
+	notifier.unsubscribe(emojivoto)
+	if len(notifier.subscribers) != 1 {
+		t.Fatalf("Expected 1 subscriber left, got %d", len(notifier.subscribers))
+	}
+}
+
+func TestMatchesSources(t *testing.T) {
+	match := &public.TapByResourceRequest_Match{
+		Match: &public.TapByResourceRequest_Match_All{
+			All: &public.TapByResourceRequest_Match_Seq{
+				Matches: []*public.TapByResourceRequest_Match{
+					{
+						Match: &public.TapByResourceRequest_Match_Sources{
+							Sources: &public.ResourceSelection{
+								Resource: &public.Resource{
+									Namespace: "emojivoto",
+									Type:      pkgK8s.ServiceAccount,
+									Name:      "backup",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	sources := makeSourceMatches(match)
+
+	testCases := []struct {
+		labels   map[string]string
+		expected bool
+	}{
+		{
+			labels:   map[string]string{"namespace": "emojivoto", "serviceaccount": "backup", "pod": "backup-1"},
+			expected: true,
+		},
+		{
+			labels:   map[string]string{"namespace": "emojivoto", "serviceaccount": "default"},
+			expected: false,
+		},
+		{
+			labels:   map[string]string{"namespace": "books", "serviceaccount": "backup"},
+			expected: false,
+		},
+		{
+			labels:   nil,
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		event := &public.TapEvent{SourceMeta: &public.TapEvent_EndpointMeta{Labels: tc.labels}}
+		if actual := matchesSources(event, sources); actual != tc.expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.expected, actual)
+		}
+	}
+
+	// the proxies aren't asked to match on sources
+	proxyMatch, err := makeByResourceMatch(match)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if matches := proxyMatch.GetAll().GetMatches(); len(matches) != 0 {
+		t.Fatalf("Expected no proxy matches, got %+v", matches)
+	}
+}
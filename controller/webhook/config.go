@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"html/template"
 
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	log "github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -14,17 +16,21 @@ import (
 // ConfigOps declares the methods used to manage the webhook configs in the cluster
 type ConfigOps interface {
 	Create(clientArv1beta1.AdmissionregistrationV1beta1Interface, *bytes.Buffer) (string, error)
-	Delete(clientArv1beta1.AdmissionregistrationV1beta1Interface) error
-	Exists(clientArv1beta1.AdmissionregistrationV1beta1Interface) error
+	Delete(clientArv1beta1.AdmissionregistrationV1beta1Interface, string) error
+	Exists(clientArv1beta1.AdmissionregistrationV1beta1Interface, string) error
 	Name() string
 }
 
 // Config contains all the necessary data to build and persist the webhook resource
 type Config struct {
-	TemplateStr         string
-	Ops                 ConfigOps
+	TemplateStr string
+	Ops         ConfigOps
+	// Background, if set, is run alongside the webhook server until it shuts
+	// down.
+	Background          func(api *k8s.API, controllerNamespace string, stop <-chan struct{})
 	client              clientArv1beta1.AdmissionregistrationV1beta1Interface
 	controllerNamespace string
+	scoped              bool
 	rootCA              *tls.CA
 }
 
@@ -38,7 +44,7 @@ func (c *Config) Create() (string, error) {
 
 	if exists {
 		log.Info("deleting existing webhook configuration")
-		if err := c.Ops.Delete(c.client); err != nil {
+		if err := c.Ops.Delete(c.client, c.name()); err != nil {
 			return "", err
 		}
 	}
@@ -47,13 +53,21 @@ func (c *Config) Create() (string, error) {
 		buf         = &bytes.Buffer{}
 		trustAnchor = []byte(c.rootCA.Cred.EncodeCertificatePEM())
 		spec        = struct {
-			WebhookConfigName   string
-			ControllerNamespace string
-			CABundle            string
+			WebhookConfigName         string
+			ControllerNamespace       string
+			ControllerNSLabel         string
+			AdmissionWebhooksLabel    string
+			AdmissionWebhooksDisabled string
+			Scoped                    bool
+			CABundle                  string
 		}{
-			WebhookConfigName:   c.Ops.Name(),
-			ControllerNamespace: c.controllerNamespace,
-			CABundle:            base64.StdEncoding.EncodeToString(trustAnchor),
+			WebhookConfigName:         c.name(),
+			ControllerNamespace:       c.controllerNamespace,
+			ControllerNSLabel:         pkgK8s.ControllerNSLabel,
+			AdmissionWebhooksLabel:    pkgK8s.AdmissionWebhooksLabel,
+			AdmissionWebhooksDisabled: pkgK8s.AdmissionWebhooksDisabled,
+			Scoped:                    c.scoped,
+			CABundle:                  base64.StdEncoding.EncodeToString(trustAnchor),
 		}
 	)
 	t := template.Must(template.New("webhook").Parse(c.TemplateStr))
@@ -66,7 +80,7 @@ func (c *Config) Create() (string, error) {
 
 // Exists returns true if the webhook already exists
 func (c *Config) Exists() (bool, error) {
-	if err := c.Ops.Exists(c.client); err != nil {
+	if err := c.Ops.Exists(c.client, c.name()); err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, nil
 		}
@@ -76,3 +90,12 @@ func (c *Config) Exists() (bool, error) {
 
 	return true, nil
 }
+
+// name returns the name of the webhook configuration, which is specific to the
+// control plane's namespace when its webhooks are scoped.
+func (c *Config) name() string {
+	if c.scoped {
+		return pkgK8s.ScopedWebhookConfigName(c.controllerNamespace, c.Ops.Name())
+	}
+	return c.Ops.Name()
+}
@@ -27,16 +27,34 @@ func TestCreate(t *testing.T) {
 		testName    string
 		templateStr string
 		ops         ConfigOps
+		scoped      bool
+		name        string
 	}{
 		{
 			testName:    "Mutating webhook",
 			templateStr: injectorTmpl.MutatingWebhookConfigurationSpec,
 			ops:         &injector.Ops{},
+			name:        "linkerd-proxy-injector-webhook-config",
 		},
 		{
 			testName:    "Validating webhook",
 			templateStr: validatorTmpl.ValidatingWebhookConfigurationSpec,
 			ops:         &validator.Ops{},
+			name:        "linkerd-sp-validator-webhook-config",
+		},
+		{
+			testName:    "Scoped mutating webhook",
+			templateStr: injectorTmpl.MutatingWebhookConfigurationSpec,
+			ops:         &injector.Ops{},
+			scoped:      true,
+			name:        "linkerd-linkerd-proxy-injector-webhook-config",
+		},
+		{
+			testName:    "Scoped validating webhook",
+			templateStr: validatorTmpl.ValidatingWebhookConfigurationSpec,
+			ops:         &validator.Ops{},
+			scoped:      true,
+			name:        "linkerd-linkerd-sp-validator-webhook-config",
 		},
 	}
 
@@ -48,6 +66,7 @@ func TestCreate(t *testing.T) {
 				Ops:                 tc.ops,
 				client:              k8sAPI.Client.AdmissionregistrationV1beta1(),
 				controllerNamespace: "linkerd",
+				scoped:              tc.scoped,
 				rootCA:              rootCA,
 			}
 
@@ -73,6 +92,9 @@ func TestCreate(t *testing.T) {
 			if !exists {
 				t.Error("Expected webhook configuration to exist")
 			}
+			if err := tc.ops.Exists(webhookConfig.client, tc.name); err != nil {
+				t.Errorf("Expected webhook configuration %s to exist, got %s", tc.name, err)
+			}
 		})
 	}
 }
@@ -22,6 +22,7 @@ func Launch(config *Config, APIResources []k8s.APIResource, metricsPort uint32,
 	addr := flag.String("addr", ":8443", "address to serve on")
 	kubeconfig := flag.String("kubeconfig", "", "path to kubeconfig")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	scoped := flag.Bool("scoped-webhooks", false, "only handle requests from namespaces labeled with the controller namespace")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -40,6 +41,7 @@ func Launch(config *Config, APIResources []k8s.APIResource, metricsPort uint32,
 
 	config.client = k8sAPI.Client.AdmissionregistrationV1beta1()
 	config.controllerNamespace = *controllerNamespace
+	config.scoped = *scoped
 	config.rootCA = rootCA
 
 	selfLink, err := config.Create()
@@ -58,7 +60,13 @@ func Launch(config *Config, APIResources []k8s.APIResource, metricsPort uint32,
 	go s.Start()
 	go admin.StartServer(*metricsAddr)
 
+	done := make(chan struct{})
+	if config.Background != nil {
+		go config.Background(k8sAPI, *controllerNamespace, done)
+	}
+
 	<-stop
+	close(done)
 	log.Info("shutting down webhook server")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	pkgPrometheus "github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	failureDecode  = "decode"
+	failureHandler = "handler"
+	failureTimeout = "timeout"
+
+	// defaultReviewTimeout is the kube-apiserver's default timeout for calls to
+	// admission webhooks, used when the request doesn't specify one.
+	defaultReviewTimeout = 30 * time.Second
+)
+
+var (
+	reviewDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "webhook_admission_review_duration_seconds",
+			Help:    "A histogram of the time taken to process admission reviews in seconds.",
+			Buckets: pkgPrometheus.RequestLatencyBucketsSeconds,
+		},
+		[]string{"webhook"},
+	)
+
+	reviewFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_admission_review_failures_total",
+			Help: "A counter for admission reviews that failed or took longer than the kube-apiserver's timeout.",
+		},
+		[]string{"webhook", "reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(reviewDuration, reviewFailures)
+}
+
+// reviewTimeout returns the timeout the kube-apiserver applies to the given
+// admission review, as passed in the request's timeout query parameter.
+func reviewTimeout(req *http.Request) time.Duration {
+	if req.URL == nil {
+		return defaultReviewTimeout
+	}
+	timeout, err := time.ParseDuration(req.URL.Query().Get("timeout"))
+	if err != nil || timeout <= 0 {
+		return defaultReviewTimeout
+	}
+	return timeout
+}
+
+func (s *Server) observeReview(start time.Time, timeout time.Duration) {
+	elapsed := time.Since(start)
+	reviewDuration.WithLabelValues(s.name).Observe(elapsed.Seconds())
+	if elapsed > timeout {
+		s.reviewFailed(failureTimeout)
+	}
+}
+
+func (s *Server) reviewFailed(reason string) {
+	reviewFailures.WithLabelValues(s.name, reason).Inc()
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
@@ -24,6 +25,7 @@ type Server struct {
 	api                 *k8s.API
 	handler             handlerFunc
 	controllerNamespace string
+	name                string
 }
 
 // NewServer returns a new instance of Server
@@ -38,7 +40,7 @@ func NewServer(api *k8s.API, addr, name, controllerNamespace string, rootCA *pkg
 		TLSConfig: c,
 	}
 
-	s := &Server{server, api, handler, controllerNamespace}
+	s := &Server{server, api, handler, controllerNamespace, name}
 	s.Handler = http.HandlerFunc(s.serve)
 	return s, nil
 }
@@ -55,6 +57,7 @@ func (s *Server) Start() {
 }
 
 func (s *Server) serve(res http.ResponseWriter, req *http.Request) {
+	start := time.Now()
 	var (
 		data []byte
 		err  error
@@ -71,6 +74,7 @@ func (s *Server) serve(res http.ResponseWriter, req *http.Request) {
 		log.Warn("received empty payload")
 		return
 	}
+	defer s.observeReview(start, reviewTimeout(req))
 
 	response := s.processReq(data)
 	responseJSON, err := json.Marshal(response)
@@ -89,6 +93,7 @@ func (s *Server) processReq(data []byte) *admissionv1beta1.AdmissionReview {
 	admissionReview, err := decode(data)
 	if err != nil {
 		log.Errorf("failed to decode data. Reason: %s", err)
+		s.reviewFailed(failureDecode)
 		admissionReview.Response = &admissionv1beta1.AdmissionResponse{
 			UID:     admissionReview.Request.UID,
 			Allowed: false,
@@ -104,6 +109,7 @@ func (s *Server) processReq(data []byte) *admissionv1beta1.AdmissionReview {
 	admissionResponse, err := s.handler(s.api, admissionReview.Request)
 	if err != nil {
 		log.Error("failed to inject sidecar. Reason: ", err)
+		s.reviewFailed(failureHandler)
 		admissionReview.Response = &admissionv1beta1.AdmissionResponse{
 			UID:     admissionReview.Request.UID,
 			Allowed: false,
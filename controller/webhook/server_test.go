@@ -3,6 +3,7 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -10,6 +11,9 @@ import (
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 )
 
 func TestServe(t *testing.T) {
@@ -18,7 +22,7 @@ func TestServe(t *testing.T) {
 		if err != nil {
 			panic(err)
 		}
-		testServer := &Server{nil, k8sAPI, nil, "linkerd"}
+		testServer := &Server{nil, k8sAPI, nil, "linkerd", "linkerd-proxy-injector"}
 
 		in := bytes.NewReader(nil)
 		request := httptest.NewRequest(http.MethodGet, "/", in)
@@ -38,7 +42,7 @@ func TestServe(t *testing.T) {
 
 func TestShutdown(t *testing.T) {
 	server := &http.Server{Addr: ":0"}
-	testServer := &Server{server, nil, nil, "linkerd"}
+	testServer := &Server{server, nil, nil, "linkerd", "linkerd-proxy-injector"}
 
 	go func() {
 		if err := testServer.ListenAndServe(); err != nil {
@@ -54,3 +58,48 @@ func TestShutdown(t *testing.T) {
 		t.Fatal("Unexpected error: ", err)
 	}
 }
+
+func TestServeMetrics(t *testing.T) {
+	review := `{"kind": "AdmissionReview", "apiVersion": "admission.k8s.io/v1beta1", "request": {"uid": "1234"}}`
+	failingHandler := func(*k8s.API, *admissionv1beta1.AdmissionRequest) (*admissionv1beta1.AdmissionResponse, error) {
+		return nil, errors.New("injection failed")
+	}
+	allowingHandler := func(_ *k8s.API, request *admissionv1beta1.AdmissionRequest) (*admissionv1beta1.AdmissionResponse, error) {
+		return &admissionv1beta1.AdmissionResponse{UID: request.UID, Allowed: true}, nil
+	}
+
+	testCases := []struct {
+		name    string
+		url     string
+		handler handlerFunc
+		reason  string
+	}{
+		{"test-handler-failure", "/", failingHandler, failureHandler},
+		{"test-timeout", "/?timeout=1ns", allowingHandler, failureTimeout},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.reason, func(t *testing.T) {
+			testServer := &Server{nil, nil, tc.handler, "linkerd", tc.name}
+			request := httptest.NewRequest(http.MethodPost, tc.url, bytes.NewReader([]byte(review)))
+			testServer.serve(httptest.NewRecorder(), request)
+
+			var failures dto.Metric
+			if err := reviewFailures.WithLabelValues(tc.name, tc.reason).Write(&failures); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if failures.GetCounter().GetValue() != 1 {
+				t.Errorf("Expected 1 %s failure, got %v", tc.reason, failures.GetCounter().GetValue())
+			}
+
+			var duration dto.Metric
+			if err := reviewDuration.WithLabelValues(tc.name).(prometheus.Histogram).Write(&duration); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if duration.GetHistogram().GetSampleCount() != 1 {
+				t.Errorf("Expected 1 observed review, got %d", duration.GetHistogram().GetSampleCount())
+			}
+		})
+	}
+}
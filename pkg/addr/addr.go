@@ -1,7 +1,9 @@
 package addr
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -13,22 +15,25 @@ import (
 // to the Linkerd proxies.
 const DefaultWeight = 1
 
-// PublicAddressToString formats a Public API TCPAddress as a string.
+// PublicAddressToString formats a Public API TCPAddress as a string. IPv6
+// addresses are enclosed in brackets.
 func PublicAddressToString(addr *public.TcpAddress) string {
-	octects := decodeIPToOctets(addr.GetIp().GetIpv4())
-	return fmt.Sprintf("%d.%d.%d.%d:%d", octects[0], octects[1], octects[2], octects[3], addr.GetPort())
+	return net.JoinHostPort(PublicIPToString(addr.GetIp()), strconv.FormatUint(uint64(addr.GetPort()), 10))
 }
 
 // PublicIPToString formats a Public API IPAddress as a string.
 func PublicIPToString(ip *public.IPAddress) string {
+	if ipv6 := ip.GetIpv6(); ipv6 != nil {
+		return decodeIPv6(ipv6.GetFirst(), ipv6.GetLast()).String()
+	}
 	octets := decodeIPToOctets(ip.GetIpv4())
 	return fmt.Sprintf("%d.%d.%d.%d", octets[0], octets[1], octets[2], octets[3])
 }
 
-// ProxyAddressToString formats a Proxy API TCPAddress as a string.
+// ProxyAddressToString formats a Proxy API TCPAddress as a string. IPv6
+// addresses are enclosed in brackets.
 func ProxyAddressToString(addr *pb.TcpAddress) string {
-	octects := decodeIPToOctets(addr.GetIp().GetIpv4())
-	return fmt.Sprintf("%d.%d.%d.%d:%d", octects[0], octects[1], octects[2], octects[3], addr.GetPort())
+	return net.JoinHostPort(ProxyIPToString(addr.GetIp()), strconv.FormatUint(uint64(addr.GetPort()), 10))
 }
 
 // ProxyAddressesToString formats a list of Proxy API TCPAddresses as a string.
@@ -42,6 +47,9 @@ func ProxyAddressesToString(addrs []pb.TcpAddress) string {
 
 // ProxyIPToString formats a Proxy API IPAddress as a string.
 func ProxyIPToString(ip *pb.IPAddress) string {
+	if ipv6 := ip.GetIpv6(); ipv6 != nil {
+		return decodeIPv6(ipv6.GetFirst(), ipv6.GetLast()).String()
+	}
 	octets := decodeIPToOctets(ip.GetIpv4())
 	return fmt.Sprintf("%d.%d.%d.%d", octets[0], octets[1], octets[2], octets[3])
 }
@@ -73,6 +81,32 @@ func ParseProxyIPV4(ip string) (*pb.IPAddress, error) {
 	return ProxyIPV4(octets[0], octets[1], octets[2], octets[3]), nil
 }
 
+// ParseProxyIP parses an IPv4 or IPv6 address string into a Proxy API
+// IPAddress.
+func ParseProxyIP(ip string) (*pb.IPAddress, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("Invalid IP address: %s", ip)
+	}
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return ProxyIPV4(ipv4[0], ipv4[1], ipv4[2], ipv4[3]), nil
+	}
+	first, last := encodeIPv6(parsed)
+	return &pb.IPAddress{
+		Ip: &pb.IPAddress_Ipv6{
+			Ipv6: &pb.IPv6{
+				First: first,
+				Last:  last,
+			},
+		},
+	}, nil
+}
+
+// IsProxyIPV6 returns true if ip is an IPv6 address.
+func IsProxyIPV6(ip *pb.IPAddress) bool {
+	return ip.GetIpv6() != nil
+}
+
 // PublicIPV4 encodes 4 octets as a Public API IPAddress.
 func PublicIPV4(a1, a2, a3, a4 uint8) *public.IPAddress {
 	ip := (uint32(a1) << 24) | (uint32(a2) << 16) | (uint32(a3) << 8) | uint32(a4)
@@ -100,6 +134,27 @@ func ParsePublicIPV4(ip string) (*public.IPAddress, error) {
 	return PublicIPV4(octets[0], octets[1], octets[2], octets[3]), nil
 }
 
+// ParsePublicIP parses an IPv4 or IPv6 address string into a Public API
+// IPAddress.
+func ParsePublicIP(ip string) (*public.IPAddress, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("Invalid IP address: %s", ip)
+	}
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return PublicIPV4(ipv4[0], ipv4[1], ipv4[2], ipv4[3]), nil
+	}
+	first, last := encodeIPv6(parsed)
+	return &public.IPAddress{
+		Ip: &public.IPAddress_Ipv6{
+			Ipv6: &public.IPv6{
+				First: first,
+				Last:  last,
+			},
+		},
+	}, nil
+}
+
 // NetToPublic converts a Proxy API TCPAddress to a Public API
 // TCPAddress
 func NetToPublic(net *pb.TcpAddress) *public.TcpAddress {
@@ -137,3 +192,17 @@ func decodeIPToOctets(ip uint32) [4]uint8 {
 		uint8(ip & 255),
 	}
 }
+
+// encodeIPv6 splits a 16-byte IP address into its first and last 8 bytes, in
+// network byte order.
+func encodeIPv6(ip net.IP) (uint64, uint64) {
+	ip = ip.To16()
+	return binary.BigEndian.Uint64(ip[:8]), binary.BigEndian.Uint64(ip[8:])
+}
+
+func decodeIPv6(first, last uint64) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	binary.BigEndian.PutUint64(ip[:8], first)
+	binary.BigEndian.PutUint64(ip[8:], last)
+	return ip
+}
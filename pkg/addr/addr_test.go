@@ -67,3 +67,50 @@ func TestNetToPublic(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProxyIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		expected string
+		ipv6     bool
+	}{
+		{ip: "10.1.2.3", expected: "10.1.2.3"},
+		{ip: "fd00:10:244::3", expected: "fd00:10:244::3", ipv6: true},
+		{ip: "::ffff:10.1.2.3", expected: "10.1.2.3"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.ip, func(t *testing.T) {
+			ip, err := ParseProxyIP(tc.ip)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := ProxyIPToString(ip); actual != tc.expected {
+				t.Fatalf("Expected %s, got %s", tc.expected, actual)
+			}
+			if actual := IsProxyIPV6(ip); actual != tc.ipv6 {
+				t.Fatalf("Expected IsProxyIPV6 to be %t, got %t", tc.ipv6, actual)
+			}
+			if actual := PublicIPToString(NetToPublic(&proxy.TcpAddress{Ip: ip}).GetIp()); actual != tc.expected {
+				t.Fatalf("Expected public IP %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+
+	if _, err := ParseProxyIP("10.1.2"); err == nil {
+		t.Fatalf("Expected an error parsing an invalid IP")
+	}
+}
+
+func TestProxyAddressToString(t *testing.T) {
+	ipv4, _ := ParseProxyIP("10.1.2.3")
+	ipv6, _ := ParseProxyIP("fd00::3")
+
+	if actual := ProxyAddressToString(&proxy.TcpAddress{Ip: ipv4, Port: 8080}); actual != "10.1.2.3:8080" {
+		t.Fatalf("Unexpected IPv4 address: %s", actual)
+	}
+	if actual := ProxyAddressToString(&proxy.TcpAddress{Ip: ipv6, Port: 8080}); actual != "[fd00::3]:8080" {
+		t.Fatalf("Unexpected IPv6 address: %s", actual)
+	}
+}
@@ -1,36 +1,89 @@
 package admin
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 )
 
 type handler struct {
 	promHandler http.Handler
+
+	// localAddr is the address of the local admin server, which is the only
+	// one to serve the endpoints changing the state of the process.
+	localAddr string
+	local     bool
 }
 
-// StartServer starts an admin server listening on a given address.
+// StartServer starts an admin server listening on a given address, along with
+// a local admin server listening on the loopback interface, at the address
+// returned by LocalAddr. The admin server isn't authenticated, and the proxy
+// of a meshed pod forwards the requests of any client from the loopback
+// interface, so the endpoints changing the state of the process are only
+// served by the local server, whose port the proxy doesn't intercept.
 func StartServer(addr string) {
-	log.Infof("starting admin server on %s", addr)
+	localAddr, err := LocalAddr(addr)
+	if err != nil {
+		log.Fatalf("invalid admin server address %s: %s", addr, err)
+	}
+
+	log.Infof("starting admin server on %s, and local admin server on %s", addr, localAddr)
+
+	go func() {
+		log.Fatal(newServer(localAddr, &handler{localAddr: localAddr, local: true}).ListenAndServe())
+	}()
 
 	h := &handler{
 		promHandler: promhttp.Handler(),
+		localAddr:   localAddr,
 	}
+	log.Fatal(newServer(addr, h).ListenAndServe())
+}
+
+// LocalAddr returns the address of the local admin server of the admin server
+// listening on the given address: the loopback interface, at the port of the
+// admin server shifted by k8s.ControllerAdminLocalPortOffset.
+func LocalAddr(addr string) (string, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("127.0.0.1:%d", p+k8s.ControllerAdminLocalPortOffset), nil
+}
 
-	s := &http.Server{
+func newServer(addr string, h http.Handler) *http.Server {
+	return &http.Server{
 		Addr:         addr,
 		Handler:      h,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
-
-	log.Fatal(s.ListenAndServe())
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if h.local {
+		if req.URL.Path != "/log-level" {
+			http.NotFound(w, req)
+			return
+		}
+		h.serveLogLevel(w, req)
+		return
+	}
+
 	switch req.URL.Path {
 	case "/metrics":
 		h.promHandler.ServeHTTP(w, req)
@@ -38,6 +91,8 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.servePing(w)
 	case "/ready":
 		h.serveReady(w)
+	case "/log-level":
+		h.serveLogLevel(w, req)
 	default:
 		http.NotFound(w, req)
 	}
@@ -50,3 +105,36 @@ func (h *handler) servePing(w http.ResponseWriter) {
 func (h *handler) serveReady(w http.ResponseWriter) {
 	w.Write([]byte("ok\n"))
 }
+
+// serveLogLevel returns the current log level on GET requests, and changes it
+// to the level given in the request body on PUT requests, so that a running
+// component can be debugged without restarting it. PUT requests are only
+// accepted by the local admin server, which "kubectl port-forward" reaches.
+func (h *handler) serveLogLevel(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if !h.local {
+			http.Error(w, fmt.Sprintf("the log level can only be changed through the local admin server on %s, e.g. through a port-forward", h.localAddr), http.StatusForbidden)
+			return
+		}
+		body, err := ioutil.ReadAll(io.LimitReader(req.Body, 64))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		level, err := log.ParseLevel(strings.TrimSpace(string(body)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		flags.SetLogLevel(level)
+		log.Infof("log level set to %s", level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n", log.GetLevel())
+}
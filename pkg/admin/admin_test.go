@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestServeLogLevel(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+	log.SetLevel(log.InfoLevel)
+
+	h := &handler{localAddr: "127.0.0.1:10995"}
+	local := &handler{localAddr: "127.0.0.1:10995", local: true}
+
+	testCases := []struct {
+		name           string
+		handler        *handler
+		method         string
+		remoteAddr     string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedLevel  log.Level
+	}{
+		{"admin GET", h, http.MethodGet, "10.1.2.3:41234", "", http.StatusOK, "info\n", log.InfoLevel},
+		{"local PUT", local, http.MethodPut, "127.0.0.1:41234", "debug\n", http.StatusOK, "debug\n", log.DebugLevel},
+		{"local PUT invalid", local, http.MethodPut, "127.0.0.1:41234", "verbose", http.StatusBadRequest, "not a valid logrus Level: \"verbose\"\n", log.DebugLevel},
+		{"local POST", local, http.MethodPost, "127.0.0.1:41234", "warn", http.StatusMethodNotAllowed, "method POST not allowed\n", log.DebugLevel},
+		{"admin PUT", h, http.MethodPut, "10.1.2.3:41234", "warn", http.StatusForbidden, "the log level can only be changed through the local admin server on 127.0.0.1:10995, e.g. through a port-forward\n", log.DebugLevel},
+		// the proxy forwards the requests of any client from the loopback
+		// interface
+		{"admin PUT through the proxy", h, http.MethodPut, "127.0.0.1:41234", "warn", http.StatusForbidden, "the log level can only be changed through the local admin server on 127.0.0.1:10995, e.g. through a port-forward\n", log.DebugLevel},
+		{"local GET", local, http.MethodGet, "[::1]:41234", "", http.StatusOK, "debug\n", log.DebugLevel},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/log-level", strings.NewReader(tc.body))
+			req.RemoteAddr = tc.remoteAddr
+			rec := httptest.NewRecorder()
+			tc.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if rec.Body.String() != tc.expectedBody {
+				t.Fatalf("Expected body %q, got %q", tc.expectedBody, rec.Body.String())
+			}
+			if log.GetLevel() != tc.expectedLevel {
+				t.Fatalf("Expected log level %s, got %s", tc.expectedLevel, log.GetLevel())
+			}
+		})
+	}
+}
+
+func TestLocalServer(t *testing.T) {
+	local := &handler{localAddr: "127.0.0.1:10995", local: true}
+	for _, path := range []string{"/metrics", "/ping", "/ready"} {
+		rec := httptest.NewRecorder()
+		local.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("Expected the local admin server not to serve %s, got status %d", path, rec.Code)
+		}
+	}
+}
+
+func TestLocalAddr(t *testing.T) {
+	testCases := []struct {
+		addr     string
+		expected string
+		err      bool
+	}{
+		{":9995", "127.0.0.1:10995", false},
+		{"0.0.0.0:9990", "127.0.0.1:10990", false},
+		{"9995", "", true},
+		{":admin", "", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.addr, func(t *testing.T) {
+			addr, err := LocalAddr(tc.addr)
+			if tc.err != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if addr != tc.expected {
+				t.Fatalf("Expected %s, got %s", tc.expected, addr)
+			}
+		})
+	}
+}
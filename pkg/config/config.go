@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -11,35 +12,42 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// Global returns the Global protobuf config from the linkerd-config ConfigMap
+// Global returns the Global protobuf config from the linkerd-config ConfigMap,
+// overridden by the LINKERD_CONFIG_GLOBAL_* environment variables
 func Global(filepath string) (*pb.Global, error) {
 	config := &pb.Global{}
-	err := unmarshalFile(filepath, config)
+	err := unmarshalFile(filepath, "global", config)
 	return config, err
 }
 
-// Proxy returns the Proxy protobuf config from the linkerd-config ConfigMap
+// Proxy returns the Proxy protobuf config from the linkerd-config ConfigMap,
+// overridden by the LINKERD_CONFIG_PROXY_* environment variables
 func Proxy(filepath string) (*pb.Proxy, error) {
 	config := &pb.Proxy{}
-	err := unmarshalFile(filepath, config)
+	err := unmarshalFile(filepath, "proxy", config)
 	return config, err
 }
 
-// Install returns the Install protobuf config from the linkerd-config ConfigMap
+// Install returns the Install protobuf config from the linkerd-config ConfigMap,
+// overridden by the LINKERD_CONFIG_INSTALL_* environment variables
 func Install(filepath string) (*pb.Install, error) {
 	config := &pb.Install{}
-	err := unmarshalFile(filepath, config)
+	err := unmarshalFile(filepath, "install", config)
 	return config, err
 }
 
-func unmarshalFile(filepath string, msg proto.Message) error {
+func unmarshalFile(filepath, section string, msg proto.Message) error {
 	configJSON, err := ioutil.ReadFile(filepath)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %s", err)
 	}
 
 	log.Debugf("%s config JSON: %s", filepath, configJSON)
-	if err = unmarshal(string(configJSON), msg); err != nil {
+	overridden, err := applyEnvOverrides(section, string(configJSON), os.Environ())
+	if err != nil {
+		return fmt.Errorf("failed to override %s config: %s", section, err)
+	}
+	if err = unmarshal(overridden, msg); err != nil {
 		return fmt.Errorf("failed to unmarshal JSON from: %s: %s", filepath, err)
 	}
 
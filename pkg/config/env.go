@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EnvPrefix prefixes the environment variables overriding the values of the
+// config files read by the control plane components, so that a single value
+// can be hotfixed by editing a component's Deployment, without rendering the
+// install again. The variables take precedence over the linkerd-config
+// ConfigMap, and are named after the path of the value in its JSON, e.g.
+//
+//	LINKERD_CONFIG_PROXY_LOGLEVEL_LEVEL=debug
+//
+// overrides the level of the logLevel of the proxy config. Names are matched
+// case-insensitively, and only values present in the ConfigMap can be
+// overridden. Values replacing strings are taken as is, and others are parsed
+// as JSON.
+const EnvPrefix = "LINKERD_CONFIG_"
+
+// applyEnvOverrides returns the JSON of a config section with the values set
+// by the environment variables of environ overriding it.
+func applyEnvOverrides(section, configJSON string, environ []string) (string, error) {
+	prefix := EnvPrefix + strings.ToUpper(section) + "_"
+
+	overrides := map[string]string{}
+	names := []string{}
+	for _, env := range environ {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], prefix) {
+			continue
+		}
+		overrides[parts[0]] = parts[1]
+		names = append(names, parts[0])
+	}
+	if len(names) == 0 {
+		return configJSON, nil
+	}
+	sort.Strings(names)
+
+	config := map[string]interface{}{}
+	if configJSON != "" {
+		if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+			return "", err
+		}
+	}
+
+	for _, name := range names {
+		path := strings.Split(strings.TrimPrefix(name, prefix), "_")
+		if err := setValue(config, path, overrides[name]); err != nil {
+			return "", fmt.Errorf("invalid %s: %s", name, err)
+		}
+		log.Infof("overriding the %s config with %s", section, name)
+	}
+
+	overridden, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(overridden), nil
+}
+
+func setValue(obj map[string]interface{}, path []string, value string) error {
+	key, ok := findKey(obj, path[0])
+	if !ok {
+		return fmt.Errorf("unknown config field %s", path[0])
+	}
+
+	if len(path) > 1 {
+		child, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("config field %s has no fields", key)
+		}
+		return setValue(child, path[1:], value)
+	}
+
+	if _, ok := obj[key].(string); ok {
+		obj[key] = value
+		return nil
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return fmt.Errorf("the value of config field %s must be JSON: %s", key, err)
+	}
+	obj[key] = parsed
+	return nil
+}
+
+func findKey(obj map[string]interface{}, name string) (string, bool) {
+	for key := range obj {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
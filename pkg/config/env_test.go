@@ -0,0 +1,102 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	configJSON := `{"logLevel":{"level":"warn"},"proxyUid":"2102","adminPort":{"port":4191},"disableExternalProfiles":true}`
+
+	testCases := []struct {
+		environ  []string
+		expected string
+		err      bool
+	}{
+		{
+			environ:  []string{"HOME=/root", "LINKERD_CONFIG_GLOBAL_LINKERDNAMESPACE=linkerd"},
+			expected: configJSON,
+		},
+		{
+			environ:  []string{"LINKERD_CONFIG_PROXY_LOGLEVEL_LEVEL=debug"},
+			expected: `{"adminPort":{"port":4191},"disableExternalProfiles":true,"logLevel":{"level":"debug"},"proxyUid":"2102"}`,
+		},
+		{
+			environ: []string{
+				"LINKERD_CONFIG_PROXY_ADMINPORT_PORT=4192",
+				"LINKERD_CONFIG_PROXY_DISABLEEXTERNALPROFILES=false",
+				"LINKERD_CONFIG_PROXY_PROXYUID=2103",
+			},
+			expected: `{"adminPort":{"port":4192},"disableExternalProfiles":false,"logLevel":{"level":"warn"},"proxyUid":"2103"}`,
+		},
+		{
+			environ:  []string{"LINKERD_CONFIG_PROXY_LOGLEVEL_LEVEL=debug=1"},
+			expected: `{"adminPort":{"port":4191},"disableExternalProfiles":true,"logLevel":{"level":"debug=1"},"proxyUid":"2102"}`,
+		},
+		{
+			environ: []string{"LINKERD_CONFIG_PROXY_LOGLEVEL=debug"},
+			err:     true,
+		},
+		{
+			environ: []string{"LINKERD_CONFIG_PROXY_ADMINPORT_PORT=admin"},
+			err:     true,
+		},
+		{
+			environ: []string{"LINKERD_CONFIG_PROXY_PROXYUID_VALUE=2103"},
+			err:     true,
+		},
+		{
+			environ: []string{"LINKERD_CONFIG_PROXY_UNKNOWN=true"},
+			err:     true,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.environ[len(tc.environ)-1], func(t *testing.T) {
+			overridden, err := applyEnvOverrides("proxy", configJSON, tc.environ)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Test case #%d: expected an error", i)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test case #%d: unexpected error: %s", i, err)
+			}
+			if overridden != tc.expected {
+				t.Fatalf("Test case #%d: expected %s, got %s", i, tc.expected, overridden)
+			}
+		})
+	}
+}
+
+func TestProxyWithEnvOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-config")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "proxy")
+	if err := ioutil.WriteFile(path, []byte(`{"logLevel":{"level":"warn,linkerd2_proxy=info"}}`), 0644); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	os.Setenv("LINKERD_CONFIG_PROXY_LOGLEVEL_LEVEL", "debug")
+	defer os.Unsetenv("LINKERD_CONFIG_PROXY_LOGLEVEL_LEVEL")
+
+	proxy, err := Proxy(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := &pb.Proxy{LogLevel: &pb.LogLevel{Level: "debug"}}
+	if !proto.Equal(proxy, expected) {
+		t.Fatalf("Expected %v, got %v", expected, proxy)
+	}
+}
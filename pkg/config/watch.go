@@ -0,0 +1,178 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/events"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultWatchInterval is how often a Watcher reads the config files. The
+// kubelet syncs updated ConfigMaps into pods about once a minute.
+const DefaultWatchInterval = 10 * time.Second
+
+var (
+	reloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "config_reloads_total",
+			Help: "A counter for the changes of the linkerd-config files read by the component, by whether they could be parsed.",
+		},
+		[]string{"file", "result"},
+	)
+
+	valid = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "config_valid",
+			Help: "Whether the last linkerd-config file read by the component could be parsed (1) or not (0).",
+		},
+		[]string{"file"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(reloads, valid)
+}
+
+// ReloadObserver is notified by a Watcher of the config files that changed,
+// with the error parsing their new contents, if any.
+type ReloadObserver func(file string, err error)
+
+// Watcher reads the config files mounted from the linkerd-config ConfigMap,
+// so that a config that can't be parsed, e.g. because it was written by a
+// broken upgrade, is reported as soon as it's mounted rather than the next
+// time the component uses it.
+type Watcher struct {
+	files    map[string]func() proto.Message
+	observer ReloadObserver
+	digests  map[string][sha256.Size]byte
+}
+
+// NewWatcher returns a Watcher for the global, proxy and install config files
+// mounted at their default paths.
+func NewWatcher(observer ReloadObserver) *Watcher {
+	return newWatcher(map[string]func() proto.Message{
+		k8s.MountPathGlobalConfig:  func() proto.Message { return &pb.Global{} },
+		k8s.MountPathProxyConfig:   func() proto.Message { return &pb.Proxy{} },
+		k8s.MountPathInstallConfig: func() proto.Message { return &pb.Install{} },
+	}, observer)
+}
+
+func newWatcher(files map[string]func() proto.Message, observer ReloadObserver) *Watcher {
+	return &Watcher{
+		files:    files,
+		observer: observer,
+		digests:  map[string][sha256.Size]byte{},
+	}
+}
+
+// Run reads the config files every interval until stop is closed.
+func (w *Watcher) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.check()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// check reads every config file once. The observer is notified of the files
+// whose contents changed since the last read, except for files that could be
+// parsed when they were first read.
+func (w *Watcher) check() {
+	for path, newMsg := range w.files {
+		file := filepath.Base(path)
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			// Files that can't be read are reported once per error.
+			err = fmt.Errorf("failed to read config file: %s", err)
+			contents = []byte(err.Error())
+		}
+		digest := sha256.Sum256(contents)
+		last, seen := w.digests[path]
+		if seen && digest == last {
+			continue
+		}
+		w.digests[path] = digest
+
+		if err == nil {
+			err = unmarshal(string(contents), newMsg())
+		}
+
+		if err != nil {
+			log.Errorf("invalid %s config: %s", file, err)
+			reloads.WithLabelValues(file, "failure").Inc()
+			valid.WithLabelValues(file).Set(0)
+		} else {
+			if seen {
+				log.Infof("reloaded the %s config", file)
+			}
+			reloads.WithLabelValues(file, "success").Inc()
+			valid.WithLabelValues(file).Set(1)
+		}
+
+		if w.observer != nil && (seen || err != nil) {
+			w.observer(file, err)
+		}
+	}
+}
+
+// Watch watches the linkerd-config files mounted in a component of the
+// control plane until stop is closed. Each time one of them changes, reload is
+// called with its name, if it could be parsed, and an Event is recorded on the
+// linkerd-config ConfigMap of the controller namespace for the component.
+func Watch(client kubernetes.Interface, controllerNamespace, component string, reload func(file string) error, stop <-chan struct{}) {
+	record := EventRecorder(client, controllerNamespace, component, time.Now)
+	NewWatcher(func(file string, err error) {
+		if err == nil && reload != nil {
+			err = reload(file)
+		}
+		record(file, err)
+	}).Run(DefaultWatchInterval, stop)
+}
+
+// EventRecorder returns a ReloadObserver recording the Events of the reloads
+// of the component.
+func EventRecorder(client kubernetes.Interface, controllerNamespace, component string, now func() time.Time) ReloadObserver {
+	return func(file string, err error) {
+		event := events.NewConfigEvent(controllerNamespace, component, file, err, now())
+		if _, err := client.CoreV1().Events(controllerNamespace).Create(event); err != nil {
+			log.Warnf("failed to record an event for the %s config: %s", file, err)
+		}
+	}
+}
+
+// RestartOnChange returns a reload function for Watch, for the components
+// that only read their config on startup: when one of the given config files
+// changes, e.g. filepath.Base(k8s.MountPathGlobalConfig), it signals stop so
+// that the component shuts down and is restarted with the new config.
+func RestartOnChange(stop chan<- os.Signal, files ...string) func(file string) error {
+	return func(file string) error {
+		for _, f := range files {
+			if f == file {
+				log.Infof("restarting to apply the new %s config", file)
+				select {
+				case stop <- os.Interrupt:
+				default:
+				}
+				return nil
+			}
+		}
+		return nil
+	}
+}
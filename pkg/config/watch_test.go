@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/events"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-config")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "global")
+	write := func(contents string) {
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	var reloads []error
+	watcher := newWatcher(map[string]func() proto.Message{
+		path: func() proto.Message { return &pb.Global{} },
+	}, func(file string, err error) {
+		if file != "global" {
+			t.Fatalf("Expected a reload of global, got %s", file)
+		}
+		reloads = append(reloads, err)
+	})
+
+	write(`{"linkerdNamespace": "linkerd"}`)
+	watcher.check()
+	if len(reloads) != 0 {
+		t.Fatalf("Expected no reload on the first read, got %v", reloads)
+	}
+
+	watcher.check()
+	if len(reloads) != 0 {
+		t.Fatalf("Expected no reload for unchanged contents, got %v", reloads)
+	}
+
+	write(`{"linkerdNamespace": "linkerd-upgraded"}`)
+	watcher.check()
+	if len(reloads) != 1 || reloads[0] != nil {
+		t.Fatalf("Expected a successful reload, got %v", reloads)
+	}
+
+	write(`{"linkerdNamespace": `)
+	watcher.check()
+	watcher.check()
+	if len(reloads) != 2 || reloads[1] == nil {
+		t.Fatalf("Expected a single failed reload, got %v", reloads)
+	}
+}
+
+func TestEventRecorder(t *testing.T) {
+	now := time.Unix(0, 0)
+	client := fake.NewSimpleClientset()
+	observer := EventRecorder(client, "linkerd", "linkerd-destination", func() time.Time { return now })
+
+	observer("proxy", nil)
+	now = now.Add(time.Second)
+	observer("proxy", errors.New("unexpected EOF"))
+
+	list, err := client.CoreV1().Events("linkerd").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(list.Items))
+	}
+
+	for _, event := range list.Items {
+		if event.InvolvedObject.Kind != "ConfigMap" || event.InvolvedObject.Name != k8s.ConfigConfigMapName {
+			t.Fatalf("Unexpected involved object: %+v", event.InvolvedObject)
+		}
+		if event.Source.Component != "linkerd-destination" {
+			t.Fatalf("Expected the event to be recorded by linkerd-destination, got %s", event.Source.Component)
+		}
+
+		switch event.Reason {
+		case events.ConfigReloadedReason:
+			if event.Type != corev1.EventTypeNormal {
+				t.Fatalf("Expected a Normal event, got %s", event.Type)
+			}
+		case events.ConfigInvalidReason:
+			if event.Type != corev1.EventTypeWarning || !strings.HasSuffix(event.Message, ": unexpected EOF") {
+				t.Fatalf("Unexpected event: %+v", event)
+			}
+		default:
+			t.Fatalf("Unexpected event reason: %s", event.Reason)
+		}
+	}
+}
+
+func TestRestartOnChange(t *testing.T) {
+	stop := make(chan os.Signal, 1)
+	reload := RestartOnChange(stop, "global")
+
+	if err := reload("proxy"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	select {
+	case <-stop:
+		t.Fatal("Expected no restart for a config the component doesn't read")
+	default:
+	}
+
+	// A second change before the component stops doesn't block.
+	reload("global")
+	reload("global")
+	select {
+	case <-stop:
+	default:
+		t.Fatal("Expected a restart for a config the component reads")
+	}
+}
@@ -0,0 +1,236 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Categories of the events of the mesh.
+const (
+	CategoryInjection    = "injection"
+	CategoryIdentity     = "identity"
+	CategoryWebhook      = "webhook"
+	CategoryConfig       = "config"
+	CategoryControlPlane = "control-plane"
+)
+
+// IssuerLoadedReason is the reason of the Events recorded on the identity
+// issuer's Secret each time the identity service loads its credentials, so that
+// rotations of the issuer show in the timeline.
+const IssuerLoadedReason = "IssuerLoaded"
+
+// ConfigReloadedReason and ConfigInvalidReason are the reasons of the Events
+// recorded on the linkerd-config ConfigMap when the copy of a component
+// changes, and whether it could be loaded.
+const (
+	ConfigReloadedReason = "ConfigReloaded"
+	ConfigInvalidReason  = "ConfigInvalid"
+)
+
+// reasonCategories maps the reasons of the Events recorded by the components
+// of the control plane and the CLI to their categories.
+var reasonCategories = map[string]string{
+	"InjectionSkipped":   CategoryInjection,
+	"InjectionPaused":    CategoryInjection,
+	"InjectionResumed":   CategoryInjection,
+	ConfigReloadedReason: CategoryConfig,
+	ConfigInvalidReason:  CategoryConfig,
+	IssuerLoadedReason:   CategoryIdentity,
+}
+
+// webhookNames are the names of the control plane's admission webhooks, which
+// the API server reports in the Events of the requests they failed.
+var webhookNames = []string{
+	"linkerd-proxy-injector.linkerd.io",
+	"linkerd-sp-validator.linkerd.io",
+}
+
+// Event is a Kubernetes Event relevant to the mesh.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Category  string    `json:"category"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Namespace string    `json:"namespace"`
+	Object    string    `json:"object"`
+	Message   string    `json:"message"`
+	Source    string    `json:"source"`
+	Count     int32     `json:"count"`
+}
+
+// FromKubernetes returns the Event of the mesh for a Kubernetes Event, or nil
+// if it isn't relevant to the mesh: it was either recorded by linkerd, about a
+// failure of one of its webhooks, or a warning about its control plane.
+func FromKubernetes(e *corev1.Event, controllerNamespace string) *Event {
+	category := reasonCategories[e.Reason]
+	if category == "" {
+		for _, name := range webhookNames {
+			if strings.Contains(e.Message, name) {
+				category = CategoryWebhook
+				break
+			}
+		}
+	}
+	if category == "" {
+		fromLinkerd := strings.HasPrefix(e.Source.Component, "linkerd-")
+		aboutControlPlane := e.InvolvedObject.Namespace == controllerNamespace && e.Type == corev1.EventTypeWarning
+		if fromLinkerd || aboutControlPlane {
+			category = CategoryControlPlane
+		}
+	}
+	if category == "" {
+		return nil
+	}
+
+	return &Event{
+		Timestamp: timestamp(e),
+		Category:  category,
+		Type:      e.Type,
+		Reason:    e.Reason,
+		Namespace: e.Namespace,
+		Object:    fmt.Sprintf("%s/%s", strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name),
+		Message:   e.Message,
+		Source:    e.Source.Component,
+		Count:     e.Count,
+	}
+}
+
+// Stream calls fn with the events of the mesh that occurred after since, oldest
+// first. If follow is set, fn is then called with each new or updated event,
+// until ctx is done or fn returns an error.
+func Stream(ctx context.Context, client kubernetes.Interface, controllerNamespace string, since time.Time, follow bool, fn func(*Event) error) error {
+	list, err := client.CoreV1().Events(corev1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	events := []*Event{}
+	for i := range list.Items {
+		if e := FromKubernetes(&list.Items[i], controllerNamespace); e != nil && e.Timestamp.After(since) {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	for _, e := range events {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	w, err := client.CoreV1().Events(corev1.NamespaceAll).Watch(metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			if update.Type != watch.Added && update.Type != watch.Modified {
+				continue
+			}
+			event, ok := update.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			if e := FromKubernetes(event, controllerNamespace); e != nil {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// NewIssuerLoadedEvent returns the Event recorded when the identity service
+// loads the issuer credentials of the control plane in the given namespace.
+func NewIssuerLoadedEvent(controllerNamespace string, expiry, now time.Time) *corev1.Event {
+	ts := metav1.NewTime(now)
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", k8s.IdentityIssuerSecretName, now.UnixNano()),
+			Namespace: controllerNamespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Name:       k8s.IdentityIssuerSecretName,
+			Namespace:  controllerNamespace,
+		},
+		Reason:         IssuerLoadedReason,
+		Message:        fmt.Sprintf("linkerd identity loaded the issuer certificate, valid until %s", expiry.UTC().Format(time.RFC3339)),
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: "linkerd-identity"},
+		FirstTimestamp: ts,
+		LastTimestamp:  ts,
+		Count:          1,
+	}
+}
+
+// NewConfigEvent returns the Event recorded when a component of the control
+// plane in the given namespace reads a changed linkerd-config file, with the
+// error loading it, if any.
+func NewConfigEvent(controllerNamespace, component, file string, err error, now time.Time) *corev1.Event {
+	reason := ConfigReloadedReason
+	eventType := corev1.EventTypeNormal
+	message := fmt.Sprintf("%s loaded the new %s config", component, file)
+	if err != nil {
+		reason = ConfigInvalidReason
+		eventType = corev1.EventTypeWarning
+		message = fmt.Sprintf("%s failed to load the %s config, the previous config stays active: %s", component, file, err)
+	}
+
+	ts := metav1.NewTime(now)
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", k8s.ConfigConfigMapName, now.UnixNano()),
+			Namespace: controllerNamespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       k8s.ConfigConfigMapName,
+			Namespace:  controllerNamespace,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: component},
+		FirstTimestamp: ts,
+		LastTimestamp:  ts,
+		Count:          1,
+	}
+}
+
+func timestamp(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
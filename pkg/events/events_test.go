@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEvent(name, namespace, reason, message, eventType, source string, ts time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Name:      name,
+			Namespace: namespace,
+		},
+		Reason:        reason,
+		Message:       message,
+		Type:          eventType,
+		Source:        corev1.EventSource{Component: source},
+		LastTimestamp: metav1.NewTime(ts),
+		Count:         1,
+	}
+}
+
+func TestFromKubernetes(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		event    *corev1.Event
+		category string
+	}{
+		{
+			name:     "injection skips",
+			event:    newEvent("web", "emojivoto", "InjectionSkipped", "pod uses the host network", corev1.EventTypeNormal, "linkerd-proxy-injector", now),
+			category: CategoryInjection,
+		},
+		{
+			name:     "config reloads",
+			event:    newEvent("linkerd-config", "linkerd", "ConfigReloaded", "reloaded", corev1.EventTypeNormal, "linkerd-proxy-injector", now),
+			category: CategoryConfig,
+		},
+		{
+			name:     "issuer loads",
+			event:    newEvent("linkerd-identity-issuer", "linkerd", IssuerLoadedReason, "loaded", corev1.EventTypeNormal, "linkerd-identity", now),
+			category: CategoryIdentity,
+		},
+		{
+			name:     "webhook failures",
+			event:    newEvent("web-1234", "emojivoto", "FailedCreate", `Internal error occurred: failed calling webhook "linkerd-proxy-injector.linkerd.io"`, corev1.EventTypeWarning, "replicaset-controller", now),
+			category: CategoryWebhook,
+		},
+		{
+			name:     "control plane warnings",
+			event:    newEvent("linkerd-controller", "linkerd", "BackOff", "Back-off restarting failed container", corev1.EventTypeWarning, "kubelet", now),
+			category: CategoryControlPlane,
+		},
+		{
+			name:  "control plane normal events",
+			event: newEvent("linkerd-controller", "linkerd", "Pulled", "Container image pulled", corev1.EventTypeNormal, "kubelet", now),
+		},
+		{
+			name:  "unrelated warnings",
+			event: newEvent("web", "emojivoto", "BackOff", "Back-off restarting failed container", corev1.EventTypeWarning, "kubelet", now),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			e := FromKubernetes(tc.event, "linkerd")
+			if tc.category == "" {
+				if e != nil {
+					t.Fatalf("Expected no event, got %+v", e)
+				}
+				return
+			}
+			if e == nil {
+				t.Fatalf("Expected a %s event, got none", tc.category)
+			}
+			if e.Category != tc.category {
+				t.Fatalf("Expected category %s, got %s", tc.category, e.Category)
+			}
+			if e.Object != "pod/"+tc.event.InvolvedObject.Name {
+				t.Fatalf("Unexpected object %s", e.Object)
+			}
+			if !e.Timestamp.Equal(now) {
+				t.Fatalf("Expected timestamp %s, got %s", now, e.Timestamp)
+			}
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		newEvent("b", "emojivoto", "InjectionSkipped", "skipped", corev1.EventTypeNormal, "linkerd-proxy-injector", now.Add(-time.Minute)),
+		newEvent("a", "linkerd", "ConfigReloaded", "reloaded", corev1.EventTypeNormal, "linkerd-proxy-injector", now.Add(-2*time.Minute)),
+		newEvent("old", "linkerd", "ConfigReloaded", "reloaded", corev1.EventTypeNormal, "linkerd-proxy-injector", now.Add(-2*time.Hour)),
+		newEvent("unrelated", "emojivoto", "Pulled", "pulled", corev1.EventTypeNormal, "kubelet", now),
+	)
+
+	objects := []string{}
+	err := Stream(context.Background(), client, "linkerd", now.Add(-time.Hour), false, func(e *Event) error {
+		objects = append(objects, e.Object)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{"pod/a", "pod/b"}
+	if !reflect.DeepEqual(objects, expected) {
+		t.Fatalf("Expected %v, got %v", expected, objects)
+	}
+}
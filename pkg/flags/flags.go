@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/klog"
@@ -22,10 +23,15 @@ func ConfigureAndParse() {
 	logLevel := flag.String("log-level", log.InfoLevel.String(),
 		"log level, must be one of: panic, fatal, error, warn, info, debug")
 	printVersion := flag.Bool("version", false, "print version and exit")
+	kubeAPIQPS := flag.Float64("kube-api-qps", 0,
+		"maximum queries per second to the Kubernetes API (0 uses the client-go default)")
+	kubeAPIBurst := flag.Int("kube-api-burst", 0,
+		"maximum burst of queries to the Kubernetes API (0 uses the client-go default)")
 
 	flag.Parse()
 
 	setLogLevel(*logLevel)
+	k8s.SetClientRateLimit(float32(*kubeAPIQPS), *kubeAPIBurst)
 	maybePrintVersionAndExit(*printVersion)
 }
 
@@ -34,12 +40,22 @@ func setLogLevel(logLevel string) {
 	if err != nil {
 		log.Fatalf("invalid log-level: %s", logLevel)
 	}
+	SetLogLevel(level)
+}
+
+// SetLogLevel sets the level of the process' logger. At the debug level,
+// client-go's klog output is enabled as well.
+func SetLogLevel(level log.Level) {
 	log.SetLevel(level)
 
 	if level == log.DebugLevel {
 		flag.Set("stderrthreshold", "INFO")
 		flag.Set("logtostderr", "true")
 		flag.Set("v", "6") // At 7 and higher, authorization tokens get logged.
+	} else {
+		flag.Set("stderrthreshold", "FATAL")
+		flag.Set("logtostderr", "false")
+		flag.Set("v", "0")
 	}
 }
 
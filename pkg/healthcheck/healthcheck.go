@@ -5,18 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	configPb "github.com/linkerd/linkerd2/controller/gen/config"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
+	arv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sVersion "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
@@ -52,6 +58,21 @@ const (
 	// These checks are no run when the `--linkerd-cni-enabled` flag is set.
 	LinkerdPreInstallCapabilityChecks CategoryID = "pre-kubernetes-capability"
 
+	// LinkerdPreInstallMeshConflictChecks adds checks to warn when the cluster
+	// already runs another service mesh, whose sidecar injector, mutating
+	// webhooks or iptables rules would conflict with Linkerd's. These checks are
+	// dependent on the output of KubernetesAPIChecks, so those checks must be
+	// added first.
+	LinkerdPreInstallMeshConflictChecks CategoryID = "pre-kubernetes-mesh-conflicts"
+
+	// LinkerdPreUpgradeChecks adds checks run by `linkerd upgrade` before it
+	// renders the upgrade, to validate that the control plane pods are ready,
+	// that its configuration and issuer credentials can be read and are valid,
+	// and that the user can update the control plane's resources. These checks
+	// are dependent on the output of KubernetesAPIChecks, so those checks must
+	// be added first.
+	LinkerdPreUpgradeChecks CategoryID = "pre-upgrade"
+
 	// LinkerdControlPlaneExistenceChecks adds a series of checks to validate that
 	// the control plane namespace and controller pod exist.
 	// These checks are dependent on the output of KubernetesAPIChecks, so those
@@ -64,6 +85,14 @@ const (
 	// checks must be added first.
 	LinkerdAPIChecks CategoryID = "linkerd-api"
 
+	// LinkerdControlPlaneProxyChecks adds a series of checks to validate that
+	// the proxies of the control plane pods are healthy, trust the anchors of
+	// the control plane's configuration, and report metrics to Prometheus.
+	// These checks are dependent on the output of KubernetesAPIChecks and
+	// `apiClient` from LinkerdControlPlaneExistenceChecks, so those checks must
+	// be added first.
+	LinkerdControlPlaneProxyChecks CategoryID = "linkerd-control-plane-proxy"
+
 	// LinkerdVersionChecks adds a series of checks to query for the latest
 	// version, and validate the the CLI is up to date.
 	LinkerdVersionChecks CategoryID = "linkerd-version"
@@ -83,6 +112,31 @@ const (
 	// `apiClient` from LinkerdControlPlaneExistenceChecks, and `latestVersions`
 	// from LinkerdVersionChecks, so those checks must be added first.
 	LinkerdDataPlaneChecks CategoryID = "linkerd-data-plane"
+
+	// LinkerdDataPlaneRolloutChecks adds a check that waits for the injected
+	// pods of the data plane namespace to become ready, reporting why the pods
+	// that aren't ready are stuck. This check is dependent on the output of
+	// KubernetesAPIChecks, so those checks must be added first.
+	LinkerdDataPlaneRolloutChecks CategoryID = "linkerd-data-plane-rollout"
+)
+
+// FailureKind classifies why a check failed, so that callers can tell
+// failures to reach the cluster or the control plane, and missing
+// permissions, apart from other failures.
+type FailureKind string
+
+const (
+	// CheckFailure is the default kind of failure: the check ran, but the
+	// installation didn't pass it.
+	CheckFailure FailureKind = "check"
+
+	// ConnectivityFailure indicates that the Kubernetes API or the control
+	// plane API couldn't be reached.
+	ConnectivityFailure FailureKind = "connectivity"
+
+	// RBACFailure indicates that the caller isn't authorized to perform an
+	// operation the check requires.
+	RBACFailure FailureKind = "rbac"
 )
 
 // HintBaseURL is the base URL on the linkerd.io website that all check hints
@@ -90,6 +144,20 @@ const (
 // page.
 const HintBaseURL = "https://linkerd.io/checks/#"
 
+const (
+	// crashLoopBackOffReason is the reason Kubernetes gives for not restarting
+	// a container that keeps terminating yet.
+	crashLoopBackOffReason = "CrashLoopBackOff"
+
+	// crashLoopLogLines is the number of log lines of crash-looping proxies
+	// included in the output of checks.
+	crashLoopLogLines = 20
+
+	// proxyTrustAnchorsEnv is the environment variable the proxies are given
+	// the trust anchors of the control plane in.
+	proxyTrustAnchorsEnv = "LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS"
+)
+
 var (
 	retryWindow    = 5 * time.Second
 	requestTimeout = 30 * time.Second
@@ -113,6 +181,10 @@ type checker struct {
 	// should not impact the overall outcome of the health check (default false)
 	warning bool
 
+	// failureKind classifies the failures of this check (default: CheckFailure,
+	// or RBACFailure for Forbidden errors)
+	failureKind FailureKind
+
 	// retryDeadline establishes a deadline before which this check should be
 	// retried; if the deadline has passed, the check fails (default: no retries)
 	retryDeadline time.Time
@@ -135,6 +207,9 @@ type CheckResult struct {
 	Retry       bool
 	Warning     bool
 	Err         error
+
+	// FailureKind is set when Err is set and Retry isn't.
+	FailureKind FailureKind
 }
 
 type checkObserver func(*CheckResult)
@@ -168,6 +243,7 @@ type HealthChecker struct {
 	clientset        kubernetes.Interface
 	kubeVersion      *k8sVersion.Info
 	controlPlanePods []corev1.Pod
+	linkerdConfig    *configPb.All
 	apiClient        public.APIClient
 	latestVersions   version.Channels
 	serverVersion    string
@@ -210,6 +286,7 @@ func (hc *HealthChecker) allCategories() []category {
 					description: "can initialize the client",
 					hintAnchor:  "k8s-api",
 					fatal:       true,
+					failureKind: ConnectivityFailure,
 					check: func(context.Context) (err error) {
 						hc.kubeAPI, err = k8s.NewAPI(hc.KubeConfig, hc.KubeContext)
 						if err != nil {
@@ -231,6 +308,7 @@ func (hc *HealthChecker) allCategories() []category {
 					description: "can query the Kubernetes API",
 					hintAnchor:  "k8s-api",
 					fatal:       true,
+					failureKind: ConnectivityFailure,
 					check: func(ctx context.Context) (err error) {
 						hc.httpClient, err = hc.kubeAPI.NewClient()
 						if err != nil {
@@ -274,6 +352,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create Namespaces",
 					hintAnchor:  "pre-k8s-cluster-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate("", "", "v1", "namespaces")
 					},
@@ -281,6 +360,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create ClusterRoles",
 					hintAnchor:  "pre-k8s-cluster-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate("", "rbac.authorization.k8s.io", "v1beta1", "clusterroles")
 					},
@@ -288,6 +368,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create ClusterRoleBindings",
 					hintAnchor:  "pre-k8s-cluster-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate("", "rbac.authorization.k8s.io", "v1beta1", "clusterrolebindings")
 					},
@@ -295,6 +376,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create CustomResourceDefinitions",
 					hintAnchor:  "pre-k8s-cluster-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate("", "apiextensions.k8s.io", "v1beta1", "customresourcedefinitions")
 					},
@@ -302,6 +384,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create ServiceAccounts",
 					hintAnchor:  "pre-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "serviceaccounts")
 					},
@@ -309,6 +392,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create Services",
 					hintAnchor:  "pre-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "services")
 					},
@@ -316,6 +400,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create Deployments",
 					hintAnchor:  "pre-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate(hc.ControlPlaneNamespace, "extensions", "v1beta1", "deployments")
 					},
@@ -323,6 +408,7 @@ func (hc *HealthChecker) allCategories() []category {
 				{
 					description: "can create ConfigMaps",
 					hintAnchor:  "pre-k8s",
+					failureKind: RBACFailure,
 					check: func(context.Context) error {
 						return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "configmaps")
 					},
@@ -341,6 +427,103 @@ func (hc *HealthChecker) allCategories() []category {
 				},
 			},
 		},
+		{
+			id: LinkerdPreInstallMeshConflictChecks,
+			checkers: []checker{
+				{
+					description: "no other service mesh sidecar injectors",
+					hintAnchor:  "pre-mesh-injectors",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkMeshInjectors()
+					},
+				},
+				{
+					description: "no conflicting pod mutating webhooks",
+					hintAnchor:  "pre-mesh-webhooks",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkConflictingWebhooks()
+					},
+				},
+				{
+					description: "no pods with iptables managed by another mesh",
+					hintAnchor:  "pre-mesh-iptables",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkForeignIptables()
+					},
+				},
+			},
+		},
+		{
+			id: LinkerdPreUpgradeChecks,
+			checkers: []checker{
+				{
+					description: "control plane pods are ready",
+					hintAnchor:  "pre-upgrade-control-plane",
+					check: func(context.Context) error {
+						return hc.checkControlPlanePodsReady()
+					},
+				},
+				{
+					description: "can read the control plane configuration",
+					hintAnchor:  "pre-upgrade-config",
+					fatal:       true,
+					check: func(context.Context) (err error) {
+						hc.linkerdConfig, err = hc.fetchLinkerdConfig()
+						return
+					},
+				},
+				{
+					description: "issuer credentials are valid",
+					hintAnchor:  "pre-upgrade-issuer",
+					check: func(context.Context) error {
+						return hc.checkIssuerCredentials()
+					},
+				},
+				{
+					description: "can update ConfigMaps",
+					hintAnchor:  "pre-upgrade-k8s",
+					failureKind: RBACFailure,
+					check: func(context.Context) error {
+						return hc.checkCan("update", hc.ControlPlaneNamespace, "", "v1", "configmaps")
+					},
+				},
+				{
+					description: "can update Secrets",
+					hintAnchor:  "pre-upgrade-k8s",
+					failureKind: RBACFailure,
+					check: func(context.Context) error {
+						return hc.checkCan("update", hc.ControlPlaneNamespace, "", "v1", "secrets")
+					},
+				},
+				{
+					description: "can update Deployments",
+					hintAnchor:  "pre-upgrade-k8s",
+					failureKind: RBACFailure,
+					check: func(context.Context) error {
+						return hc.checkCan("update", hc.ControlPlaneNamespace, "extensions", "v1beta1", "deployments")
+					},
+				},
+				{
+					description: "can update ClusterRoles",
+					hintAnchor:  "pre-upgrade-k8s",
+					failureKind: RBACFailure,
+					check: func(context.Context) error {
+						return hc.checkCan("update", "", "rbac.authorization.k8s.io", "v1beta1", "clusterroles")
+					},
+				},
+				{
+					description: "can update ClusterRoleBindings",
+					hintAnchor:  "pre-upgrade-k8s",
+					failureKind: RBACFailure,
+					check: func(context.Context) error {
+						return hc.checkCan("update", "", "rbac.authorization.k8s.io", "v1beta1", "clusterrolebindings")
+					},
+				},
+			},
+		},
 		{
 			id: LinkerdControlPlaneExistenceChecks,
 			checkers: []checker{
@@ -366,10 +549,19 @@ func (hc *HealthChecker) allCategories() []category {
 						return checkControllerRunning(hc.controlPlanePods)
 					},
 				},
+				{
+					description: "control plane namespace matches the config",
+					hintAnchor:  "l5d-existence-config-ns",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkControlPlaneNamespace()
+					},
+				},
 				{
 					description: "can initialize the client",
 					hintAnchor:  "l5d-existence-client",
 					fatal:       true,
+					failureKind: ConnectivityFailure,
 					check: func(context.Context) (err error) {
 						if hc.APIAddr != "" {
 							hc.apiClient, err = public.NewInternalClient(hc.ControlPlaneNamespace, hc.APIAddr)
@@ -384,6 +576,7 @@ func (hc *HealthChecker) allCategories() []category {
 					hintAnchor:    "l5d-existence-api",
 					retryDeadline: hc.RetryDeadline,
 					fatal:         true,
+					failureKind:   ConnectivityFailure,
 					check: func(ctx context.Context) (err error) {
 						hc.serverVersion, err = GetServerVersion(ctx, hc.apiClient)
 						return
@@ -427,6 +620,64 @@ func (hc *HealthChecker) allCategories() []category {
 				},
 			},
 		},
+		{
+			id: LinkerdControlPlaneProxyChecks,
+			checkers: []checker{
+				{
+					description:   "control plane proxies are healthy",
+					hintAnchor:    "l5d-cp-proxy-healthy",
+					retryDeadline: hc.RetryDeadline,
+					check: func(context.Context) error {
+						pods, err := hc.clientset.CoreV1().Pods(hc.ControlPlaneNamespace).List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						if err := validateProxyCrashLoops(pods.Items, hc.previousProxyLogs); err != nil {
+							return err
+						}
+						return validateControlPlaneProxies(pods.Items)
+					},
+				},
+				{
+					description: "control plane proxies certificates match configuration",
+					hintAnchor:  "l5d-cp-proxy-cert-match",
+					check: func(context.Context) error {
+						var err error
+						hc.linkerdConfig, err = hc.fetchLinkerdConfig()
+						if err != nil {
+							return err
+						}
+						pods, err := hc.clientset.CoreV1().Pods(hc.ControlPlaneNamespace).List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						trustPEM := hc.linkerdConfig.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()
+						return validateControlPlaneProxyTrustAnchors(pods.Items, trustPEM, time.Now())
+					},
+				},
+				{
+					description: "control plane proxies issuer certificate is valid",
+					hintAnchor:  "l5d-cp-proxy-issuer",
+					check: func(context.Context) error {
+						return hc.checkIssuerCredentials()
+					},
+				},
+				{
+					description:   "control plane proxy metrics are present in Prometheus",
+					hintAnchor:    "l5d-cp-proxy-prom",
+					retryDeadline: hc.RetryDeadline,
+					warning:       true,
+					check: func(ctx context.Context) error {
+						pods, err := hc.getProxyPods(ctx, hc.ControlPlaneNamespace)
+						if err != nil {
+							return err
+						}
+
+						return validateControlPlanePodReporting(pods)
+					},
+				},
+			},
+		},
 		{
 			id: LinkerdVersionChecks,
 			checkers: []checker{
@@ -492,6 +743,24 @@ func (hc *HealthChecker) allCategories() []category {
 				},
 			},
 		},
+		{
+			id: LinkerdDataPlaneRolloutChecks,
+			checkers: []checker{
+				{
+					description:   "data plane rollout is complete",
+					hintAnchor:    "l5d-data-plane-ready",
+					retryDeadline: hc.RetryDeadline,
+					check: func(context.Context) error {
+						pods, err := hc.clientset.CoreV1().Pods(hc.DataPlaneNamespace).List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+
+						return validateDataPlaneRollout(pods.Items, hc.ControlPlaneNamespace)
+					},
+				},
+			},
+		},
 		{
 			id: LinkerdDataPlaneChecks,
 			checkers: []checker{
@@ -503,6 +772,18 @@ func (hc *HealthChecker) allCategories() []category {
 						return hc.checkNamespace(ctx, hc.DataPlaneNamespace, true)
 					},
 				},
+				{
+					description: "data plane proxies are not crash-looping",
+					hintAnchor:  "l5d-data-plane-crash-loop",
+					check: func(context.Context) error {
+						pods, err := hc.clientset.CoreV1().Pods(hc.DataPlaneNamespace).List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+
+						return validateProxyCrashLoops(pods.Items, hc.previousProxyLogs)
+					},
+				},
 				{
 					description:   "data plane proxies are ready",
 					hintAnchor:    "l5d-data-plane-ready",
@@ -647,11 +928,13 @@ func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer ch
 			HintAnchor:  c.hintAnchor,
 			Warning:     c.warning,
 			Err:         err,
+			FailureKind: c.failureKindOf(err),
 		}
 
 		if err != nil && time.Now().Before(c.retryDeadline) {
 			checkResult.Retry = true
 			checkResult.Err = errors.New("waiting for check to complete")
+			checkResult.FailureKind = ""
 			log.Debugf("Retrying on error: %s", err)
 
 			observer(checkResult)
@@ -664,16 +947,37 @@ func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer ch
 	}
 }
 
+// failureKindOf returns the kind of failure the given error of the check is,
+// or an empty kind if the check passed.
+func (c *checker) failureKindOf(err error) FailureKind {
+	switch {
+	case err == nil:
+		return ""
+	case c.failureKind != "":
+		return c.failureKind
+	case kerrors.IsForbidden(err):
+		return RBACFailure
+	default:
+		return CheckFailure
+	}
+}
+
 func (hc *HealthChecker) runCheckRPC(categoryID CategoryID, c *checker, observer checkObserver) bool {
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	defer cancel()
 	checkRsp, err := c.checkRPC(ctx)
+	var failureKind FailureKind
+	if err != nil {
+		// The control plane didn't answer the self-check at all.
+		failureKind = ConnectivityFailure
+	}
 	observer(&CheckResult{
 		Category:    categoryID,
 		Description: c.description,
 		HintAnchor:  c.hintAnchor,
 		Warning:     c.warning,
 		Err:         err,
+		FailureKind: failureKind,
 	})
 	if err != nil {
 		return false
@@ -690,6 +994,7 @@ func (hc *HealthChecker) runCheckRPC(categoryID CategoryID, c *checker, observer
 			HintAnchor:  c.hintAnchor,
 			Warning:     c.warning,
 			Err:         err,
+			FailureKind: c.failureKindOf(err),
 		})
 		if err != nil {
 			return false
@@ -721,11 +1026,17 @@ func (hc *HealthChecker) checkNamespace(ctx context.Context, namespace string, s
 }
 
 func (hc *HealthChecker) getDataPlanePods(ctx context.Context) ([]*pb.Pod, error) {
+	return hc.getProxyPods(ctx, hc.DataPlaneNamespace)
+}
+
+// getProxyPods returns the pods of the given namespace, or of all namespaces
+// if it's empty, whose proxies were injected by the control plane.
+func (hc *HealthChecker) getProxyPods(ctx context.Context, namespace string) ([]*pb.Pod, error) {
 	req := &pb.ListPodsRequest{}
-	if hc.DataPlaneNamespace != "" {
+	if namespace != "" {
 		req.Selector = &pb.ResourceSelection{
 			Resource: &pb.Resource{
-				Namespace: hc.DataPlaneNamespace,
+				Namespace: namespace,
 			},
 		}
 	}
@@ -746,6 +1057,10 @@ func (hc *HealthChecker) getDataPlanePods(ctx context.Context) ([]*pb.Pod, error
 }
 
 func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource string) error {
+	return hc.checkCan("create", namespace, group, version, resource)
+}
+
+func (hc *HealthChecker) checkCan(verb, namespace, group, version, resource string) error {
 	if hc.clientset == nil {
 		// we should never get here
 		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
@@ -754,7 +1069,7 @@ func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource stri
 	return k8s.ResourceAuthz(
 		hc.clientset,
 		namespace,
-		"create",
+		verb,
 		group,
 		version,
 		resource,
@@ -762,6 +1077,103 @@ func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource stri
 	)
 }
 
+func (hc *HealthChecker) checkControlPlanePodsReady() error {
+	if hc.clientset == nil {
+		// we should never get here
+		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	pods, err := hc.clientset.CoreV1().Pods(hc.ControlPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	return validateControlPlanePods(pods.Items)
+}
+
+func (hc *HealthChecker) fetchLinkerdConfig() (*configPb.All, error) {
+	if hc.clientset == nil {
+		// we should never get here
+		return nil, fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	cm, err := hc.clientset.CoreV1().ConfigMaps(hc.ControlPlaneNamespace).Get(k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return config.FromConfigMap(cm.Data)
+}
+
+// checkIssuerCredentials validates that the issuer credentials are signed by
+// the trust anchors of the control plane's configuration, and haven't expired.
+// Control planes without a trust anchor are given new credentials on upgrade,
+// so they pass this check.
+func (hc *HealthChecker) checkIssuerCredentials() error {
+	trustPEM := hc.linkerdConfig.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()
+	if trustPEM == "" {
+		return nil
+	}
+
+	roots, err := tls.DecodePEMCertPool(trustPEM)
+	if err != nil {
+		return fmt.Errorf("invalid trust anchors: %s", err)
+	}
+
+	secret, err := hc.clientset.CoreV1().Secrets(hc.ControlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	key, err := tls.DecodePEMKey(string(secret.Data[k8s.IdentityIssuerKeyName]))
+	if err != nil {
+		return fmt.Errorf("invalid issuer key: %s", err)
+	}
+	crt, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerCrtName]))
+	if err != nil {
+		return fmt.Errorf("invalid issuer certificate: %s", err)
+	}
+
+	cred := &tls.Cred{PrivateKey: key, Crt: *crt}
+	return cred.Verify(roots, "")
+}
+
+// checkControlPlaneNamespace validates that the control plane was installed
+// for the namespace it runs in: the namespace recorded in linkerd-config,
+// which injected proxies use to reach the control plane, and the namespace
+// label of the control plane pods must both match it. Control planes installed
+// before the namespace was recorded don't have it until they're upgraded.
+func (hc *HealthChecker) checkControlPlaneNamespace() error {
+	if hc.clientset == nil {
+		// we should never get here
+		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	cm, err := hc.clientset.CoreV1().ConfigMaps(hc.ControlPlaneNamespace).Get(k8s.ConfigConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	configs, err := config.FromConfigMap(cm.Data)
+	if err != nil {
+		return err
+	}
+
+	ns := configs.GetGlobal().GetLinkerdNamespace()
+	if ns == "" {
+		return fmt.Errorf("%s doesn't record the control plane namespace; run 'linkerd upgrade' to record it", k8s.ConfigConfigMapName)
+	}
+	if ns != hc.ControlPlaneNamespace {
+		return fmt.Errorf("%s is configured for the \"%s\" namespace", k8s.ConfigConfigMapName, ns)
+	}
+
+	for _, pod := range hc.controlPlanePods {
+		if ns, ok := pod.Labels[k8s.ControllerNSLabel]; ok && ns != hc.ControlPlaneNamespace {
+			return fmt.Errorf("The \"%s\" pod is labeled for the \"%s\" namespace", pod.Name, ns)
+		}
+	}
+
+	return nil
+}
+
 func (hc *HealthChecker) checkNetAdmin() error {
 	if hc.clientset == nil {
 		// we should never get here
@@ -804,6 +1216,153 @@ func (hc *HealthChecker) checkNetAdmin() error {
 	return fmt.Errorf("found %d PodSecurityPolicies, but none provide NET_ADMIN", len(pspList.Items))
 }
 
+// meshInjectors maps substrings of the webhook configuration or service names
+// used by other meshes' sidecar injectors to the name of the mesh.
+var meshInjectors = []struct {
+	pattern string
+	mesh    string
+}{
+	{"istio-sidecar-injector", "Istio"},
+	{"consul-connect-injector", "Consul"},
+	{"appmesh-inject", "AWS App Mesh"},
+}
+
+// meshInitContainers maps the names of the init containers other meshes use to
+// rewrite a pod's iptables rules to the name of the mesh.
+var meshInitContainers = map[string]string{
+	"istio-init": "Istio",
+	"proxyinit":  "AWS App Mesh",
+}
+
+func (hc *HealthChecker) checkMeshInjectors() error {
+	if hc.clientset == nil {
+		// we should never get here
+		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	mwcList, err := hc.clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	found := []string{}
+	for _, mwc := range mwcList.Items {
+		names := []string{mwc.GetName()}
+		for _, webhook := range mwc.Webhooks {
+			if webhook.ClientConfig.Service != nil {
+				names = append(names, webhook.ClientConfig.Service.Name)
+			}
+		}
+
+	injectors:
+		for _, injector := range meshInjectors {
+			for _, name := range names {
+				if strings.Contains(name, injector.pattern) {
+					found = append(found, fmt.Sprintf("%s (%s)", injector.mesh, mwc.GetName()))
+					break injectors
+				}
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		return fmt.Errorf("found sidecar injectors for other service meshes: %s", strings.Join(found, ", "))
+	}
+	return nil
+}
+
+func (hc *HealthChecker) checkConflictingWebhooks() error {
+	if hc.clientset == nil {
+		// we should never get here
+		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	mwcList, err := hc.clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	// the proxy injector's own webhook configs can't conflict with it
+	conflicts := []string{}
+	for _, mwc := range mwcList.Items {
+		if mwc.GetName() == k8s.ProxyInjectorWebhookConfigName ||
+			mwc.GetName() == k8s.ScopedWebhookConfigName(hc.ControlPlaneNamespace, k8s.ProxyInjectorWebhookConfigName) {
+			continue
+		}
+		for _, webhook := range mwc.Webhooks {
+			if selectsAllNamespaces(webhook.NamespaceSelector) && mutatesPodCreation(webhook.Rules) {
+				conflicts = append(conflicts, fmt.Sprintf("%s/%s", mwc.GetName(), webhook.Name))
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("found webhooks mutating pods in all namespaces: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+func (hc *HealthChecker) checkForeignIptables() error {
+	if hc.clientset == nil {
+		// we should never get here
+		return fmt.Errorf("unexpected error: Kubernetes ClientSet not initialized")
+	}
+
+	podList, err := hc.clientset.CoreV1().Pods("").List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	pods := map[string][]string{}
+	for _, pod := range podList.Items {
+		for _, container := range pod.Spec.InitContainers {
+			if mesh, ok := meshInitContainers[container.Name]; ok {
+				pods[mesh] = append(pods[mesh], fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+				break
+			}
+		}
+	}
+
+	if len(pods) == 0 {
+		return nil
+	}
+
+	meshes := []string{}
+	for mesh, names := range pods {
+		meshes = append(meshes, fmt.Sprintf("%s (%d pods, e.g. %s)", mesh, len(names), names[0]))
+	}
+	sort.Strings(meshes)
+	return fmt.Errorf("found pods whose iptables rules are managed by other service meshes: %s", strings.Join(meshes, ", "))
+}
+
+func selectsAllNamespaces(selector *metav1.LabelSelector) bool {
+	return selector == nil ||
+		(len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0)
+}
+
+func mutatesPodCreation(rules []arv1beta1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		if !matchesAny(rule.APIGroups, "") || !matchesAny(rule.Resources, "pods") {
+			continue
+		}
+		for _, op := range rule.Operations {
+			if op == arv1beta1.Create || op == arv1beta1.OperationAll {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesAny(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 func (hc *HealthChecker) validateServiceProfiles() error {
 	spClientset, err := spclient.NewForConfig(hc.kubeAPI.Config)
 	if err != nil {
@@ -908,6 +1467,205 @@ func validateDataPlanePods(pods []*pb.Pod, targetNamespace string) error {
 	return nil
 }
 
+// validateDataPlaneRollout returns an error listing the pods injected by the
+// given control plane that aren't ready, and why.
+func validateDataPlaneRollout(pods []corev1.Pod, controlPlaneNamespace string) error {
+	stuck := []string{}
+	for _, pod := range pods {
+		if pod.Labels[k8s.ControllerNSLabel] != controlPlaneNamespace ||
+			pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded ||
+			pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if issue := podRolloutIssue(pod); issue != "" {
+			stuck = append(stuck, fmt.Sprintf("%s (%s)", pod.Name, issue))
+		}
+	}
+
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		return fmt.Errorf("pods not ready: %s", strings.Join(stuck, ", "))
+	}
+	return nil
+}
+
+// podRolloutIssue describes why the given pod isn't ready, or returns an empty
+// string if it's ready.
+func podRolloutIssue(pod corev1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			return fmt.Sprintf("unschedulable: %s", condition.Message)
+		}
+	}
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		switch {
+		case status.State.Waiting != nil:
+			return fmt.Sprintf("%s: %s", status.Name, status.State.Waiting.Reason)
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("%s: exited with code %d", status.Name, status.State.Terminated.ExitCode)
+		case status.State.Running != nil:
+			return fmt.Sprintf("%s: running", status.Name)
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			continue
+		}
+		switch {
+		case status.State.Waiting != nil:
+			return fmt.Sprintf("%s: %s", status.Name, status.State.Waiting.Reason)
+		case status.State.Terminated != nil:
+			return fmt.Sprintf("%s: %s", status.Name, status.State.Terminated.Reason)
+		case status.Name == k8s.ProxyContainerName:
+			return fmt.Sprintf("%s: not ready, the proxy may be waiting for its identity certificate", status.Name)
+		default:
+			return fmt.Sprintf("%s: not ready", status.Name)
+		}
+	}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return strings.ToLower(string(pod.Status.Phase))
+	}
+	return ""
+}
+
+// validateProxyCrashLoops returns an error listing the pods whose proxy is
+// crash-looping, along with the last lines logged by the proxy before it last
+// terminated, as returned by previousLogs.
+func validateProxyCrashLoops(pods []corev1.Pod, previousLogs func(corev1.Pod) (string, error)) error {
+	crashing := []string{}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != k8s.ProxyContainerName ||
+				status.State.Waiting == nil ||
+				status.State.Waiting.Reason != crashLoopBackOffReason {
+				continue
+			}
+
+			report := fmt.Sprintf("    * %s/%s restarted %d times", pod.Namespace, pod.Name, status.RestartCount)
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				report += fmt.Sprintf(", last exit code %d", terminated.ExitCode)
+			}
+
+			logs, err := previousLogs(pod)
+			if err != nil {
+				report += fmt.Sprintf(" (logs unavailable: %s)", err)
+			} else if logs = strings.TrimSpace(logs); logs != "" {
+				report += ", last logs:"
+				for _, line := range strings.Split(logs, "\n") {
+					report += "\n        " + line
+				}
+			}
+			crashing = append(crashing, report)
+		}
+	}
+
+	if len(crashing) > 0 {
+		sort.Strings(crashing)
+		return fmt.Errorf("The \"%s\" container is crash-looping in %d pods:\n%s",
+			k8s.ProxyContainerName, len(crashing), strings.Join(crashing, "\n"))
+	}
+	return nil
+}
+
+// previousProxyLogs returns the last lines logged by the proxy of the given pod
+// before it last terminated.
+func (hc *HealthChecker) previousProxyLogs(pod corev1.Pod) (string, error) {
+	tailLines := int64(crashLoopLogLines)
+	logs, err := hc.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: k8s.ProxyContainerName,
+		Previous:  true,
+		TailLines: &tailLines,
+	}).DoRaw()
+	return string(logs), err
+}
+
+// validateControlPlaneProxies returns an error listing the running control
+// plane pods whose proxy isn't ready.
+func validateControlPlaneProxies(pods []corev1.Pod) error {
+	notReady := []string{}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == k8s.ProxyContainerName && !status.Ready {
+				notReady = append(notReady, pod.Name)
+			}
+		}
+	}
+
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		return fmt.Errorf("The \"%s\" container is not ready in the pods: %s",
+			k8s.ProxyContainerName, strings.Join(notReady, ", "))
+	}
+	return nil
+}
+
+// validateControlPlaneProxyTrustAnchors validates that the trust anchors of
+// the control plane's configuration are valid at the given time, and that the
+// proxies of the control plane pods were injected with them: proxies that
+// trust other anchors can't validate the certificates issued by identity.
+// Control planes without identity pass this check.
+func validateControlPlaneProxyTrustAnchors(pods []corev1.Pod, trustPEM string, now time.Time) error {
+	if trustPEM == "" {
+		return nil
+	}
+
+	anchors, err := tls.DecodePEMCertificates(trustPEM)
+	if err != nil {
+		return fmt.Errorf("invalid trust anchors: %s", err)
+	}
+	for _, anchor := range anchors {
+		if now.Before(anchor.NotBefore) || now.After(anchor.NotAfter) {
+			return fmt.Errorf("trust anchor \"%s\" is only valid from %s to %s",
+				anchor.Subject.CommonName, anchor.NotBefore.Format(time.RFC3339), anchor.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	mismatched := []string{}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if container.Name != k8s.ProxyContainerName {
+				continue
+			}
+			for _, env := range container.Env {
+				if env.Name == proxyTrustAnchorsEnv && strings.TrimSpace(env.Value) != strings.TrimSpace(trustPEM) {
+					mismatched = append(mismatched, pod.Name)
+				}
+			}
+		}
+	}
+
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return fmt.Errorf("The \"%s\" container of the pods %s trusts anchors that don't match the control plane's configuration; restart the pods to update them",
+			k8s.ProxyContainerName, strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+// validateControlPlanePodReporting returns an error listing the control plane
+// pods whose proxy metrics weren't found in Prometheus.
+func validateControlPlanePodReporting(pods []*pb.Pod) error {
+	notInPrometheus := []string{}
+	for _, p := range pods {
+		if !p.Added {
+			notInPrometheus = append(notInPrometheus, p.Name)
+		}
+	}
+
+	if len(notInPrometheus) > 0 {
+		return fmt.Errorf("Control plane proxy metrics not found for %s.", strings.Join(notInPrometheus, ", "))
+	}
+	return nil
+}
+
 func validateDataPlanePodReporting(pods []*pb.Pod) error {
 	notInPrometheus := []string{}
 
@@ -2,6 +2,9 @@ package healthcheck
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -12,8 +15,11 @@ import (
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestHealthChecker(t *testing.T) {
@@ -289,6 +295,56 @@ func TestHealthChecker(t *testing.T) {
 			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
 		}
 	})
+
+	t.Run("Classifies failures", func(t *testing.T) {
+		classifiedChecks := category{
+			id: "cat8",
+			checkers: []checker{
+				{
+					description: "connectivity",
+					failureKind: ConnectivityFailure,
+					check: func(context.Context) error {
+						return fmt.Errorf("unreachable")
+					},
+				},
+				{
+					description: "forbidden",
+					check: func(context.Context) error {
+						return kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", fmt.Errorf("denied"))
+					},
+				},
+			},
+		}
+
+		hc := NewHealthChecker(
+			[]CategoryID{},
+			&Options{},
+		)
+		hc.addCategory(passingCheck1)
+		hc.addCategory(failingCheck)
+		hc.addCategory(classifiedChecks)
+		hc.addCategory(failingRPCCheck)
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s: %q", result.Category, result.Description, result.FailureKind))
+		}
+
+		expectedResults := []string{
+			`cat1 desc1: ""`,
+			`cat3 desc3: "check"`,
+			`cat8 connectivity: "connectivity"`,
+			`cat8 forbidden: "rbac"`,
+			`cat5 desc5: ""`,
+			`cat5 [rpc2] rpc desc2: "check"`,
+		}
+
+		hc.RunChecks(observer)
+
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
 }
 
 func TestCheckCanCreate(t *testing.T) {
@@ -310,6 +366,183 @@ func TestCheckCanCreate(t *testing.T) {
 	}
 }
 
+func TestCheckControlPlaneNamespace(t *testing.T) {
+	configMap := func(ns string) string {
+		return fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"%s"}
+`, ns)
+	}
+	pod := func(ns string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "linkerd-controller-6f78cbd47-bc557",
+				Labels: map[string]string{k8s.ControllerNSLabel: ns},
+			},
+		}
+	}
+
+	testCases := []struct {
+		k8sConfigs []string
+		pods       []corev1.Pod
+		expected   string
+	}{
+		{
+			[]string{configMap("linkerd")},
+			[]corev1.Pod{pod("linkerd")},
+			"",
+		},
+		{
+			[]string{},
+			[]corev1.Pod{pod("linkerd")},
+			`configmaps "linkerd-config" not found`,
+		},
+		{
+			[]string{configMap("linkerd-old")},
+			[]corev1.Pod{pod("linkerd")},
+			`linkerd-config is configured for the "linkerd-old" namespace`,
+		},
+		{
+			[]string{configMap("")},
+			[]corev1.Pod{pod("linkerd")},
+			`linkerd-config doesn't record the control plane namespace; run 'linkerd upgrade' to record it`,
+		},
+		{
+			[]string{configMap("linkerd")},
+			[]corev1.Pod{pod("linkerd-old")},
+			`The "linkerd-controller-6f78cbd47-bc557" pod is labeled for the "linkerd-old" namespace`,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{},
+				&Options{ControlPlaneNamespace: "linkerd"},
+			)
+			var err error
+			hc.clientset, _, err = k8s.NewFakeClientSets(tc.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			hc.controlPlanePods = tc.pods
+
+			err = hc.checkControlPlaneNamespace()
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestCheckIssuerCredentials(t *testing.T) {
+	newCA := func() *tls.CA {
+		ca, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return ca
+	}
+	configMap := func(trustAnchorsPEM string) string {
+		identityContext, err := json.Marshal(map[string]string{"trustAnchorsPem": trustAnchorsPEM})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return fmt.Sprintf(`
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","identityContext":%s}
+`, identityContext)
+	}
+	secret := func(ca *tls.CA) string {
+		return fmt.Sprintf(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  crt.pem: %s
+  key.pem: %s
+`,
+			base64.StdEncoding.EncodeToString([]byte(ca.Cred.Crt.EncodeCertificatePEM())),
+			base64.StdEncoding.EncodeToString([]byte(ca.Cred.EncodePrivateKeyPEM())))
+	}
+
+	issuer := newCA()
+	trustAnchorsPEM := issuer.Cred.Crt.EncodeCertificatePEM()
+
+	testCases := []struct {
+		k8sConfigs []string
+		expected   string
+	}{
+		{
+			[]string{configMap(trustAnchorsPEM), secret(issuer)},
+			"",
+		},
+		{
+			[]string{configMap("")},
+			"",
+		},
+		{
+			[]string{configMap(trustAnchorsPEM)},
+			`secrets "linkerd-identity-issuer" not found`,
+		},
+		{
+			[]string{configMap(trustAnchorsPEM), secret(newCA())},
+			"x509: certificate signed by unknown authority",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{},
+				&Options{ControlPlaneNamespace: "linkerd"},
+			)
+			var err error
+			hc.clientset, _, err = k8s.NewFakeClientSets(tc.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			hc.linkerdConfig, err = hc.fetchLinkerdConfig()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkIssuerCredentials()
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
 func TestCheckNetAdmin(t *testing.T) {
 	tests := []struct {
 		k8sConfigs []string
@@ -357,6 +590,114 @@ spec:
 	}
 }
 
+func TestCheckMeshConflicts(t *testing.T) {
+	istioInjector := `apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: istio-sidecar-injector
+webhooks:
+- name: sidecar-injector.istio.io
+  clientConfig:
+    service:
+      name: istio-sidecar-injector
+      namespace: istio-system
+  rules:
+  - operations: ["CREATE"]
+    apiGroups: [""]
+    apiVersions: ["v1"]
+    resources: ["pods"]
+  namespaceSelector:
+    matchLabels:
+      istio-injection: enabled`
+
+	globalInjector := `apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: pod-defaults
+webhooks:
+- name: pod-defaults.example.com
+  clientConfig:
+    service:
+      name: pod-defaults
+      namespace: default
+  rules:
+  - operations: ["*"]
+    apiGroups: ["*"]
+    apiVersions: ["v1"]
+    resources: ["pods"]`
+
+	istioPod := `apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  initContainers:
+  - name: istio-init
+    image: docker.io/istio/proxy_init:1.1.0`
+
+	tests := []struct {
+		k8sConfigs []string
+		check      func(*HealthChecker) error
+		err        error
+	}{
+		{
+			[]string{},
+			(*HealthChecker).checkMeshInjectors,
+			nil,
+		},
+		{
+			[]string{istioInjector, globalInjector},
+			(*HealthChecker).checkMeshInjectors,
+			fmt.Errorf("found sidecar injectors for other service meshes: Istio (istio-sidecar-injector)"),
+		},
+		{
+			[]string{istioInjector},
+			(*HealthChecker).checkConflictingWebhooks,
+			nil,
+		},
+		{
+			[]string{istioInjector, globalInjector},
+			(*HealthChecker).checkConflictingWebhooks,
+			fmt.Errorf("found webhooks mutating pods in all namespaces: pod-defaults/pod-defaults.example.com"),
+		},
+		{
+			[]string{},
+			(*HealthChecker).checkForeignIptables,
+			nil,
+		},
+		{
+			[]string{istioPod},
+			(*HealthChecker).checkForeignIptables,
+			fmt.Errorf("found pods whose iptables rules are managed by other service meshes: Istio (1 pods, e.g. emojivoto/emoji)"),
+		},
+	}
+
+	for i, test := range tests {
+		test := test // pin
+		t.Run(fmt.Sprintf("%d: returns expected mesh conflict result", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{},
+				&Options{},
+			)
+
+			var err error
+			hc.clientset, _, err = k8s.NewFakeClientSets(test.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			err = test.check(hc)
+			if err != nil || test.err != nil {
+				if (err == nil && test.err != nil) ||
+					(err != nil && test.err == nil) ||
+					(err.Error() != test.err.Error()) {
+					t.Fatalf("Unexpected error (Expected: %s, Got: %s)", test.err, err)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateControlPlanePods(t *testing.T) {
 	pod := func(name string, phase corev1.PodPhase, ready bool) corev1.Pod {
 		return corev1.Pod{
@@ -506,6 +847,247 @@ func TestValidateDataPlanePods(t *testing.T) {
 	})
 }
 
+func TestValidateDataPlaneRollout(t *testing.T) {
+	injectedPod := func(name string, status corev1.PodStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{k8s.ControllerNSLabel: "linkerd"},
+			},
+			Status: status,
+		}
+	}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	completed := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}}
+
+	t.Run("Returns nil if all injected pods are ready", func(t *testing.T) {
+		pods := []corev1.Pod{
+			injectedPod("emoji-d9c7866bb-7v74n", corev1.PodStatus{
+				Phase:                 corev1.PodRunning,
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: k8s.InitContainerName, State: completed}},
+				ContainerStatuses:     []corev1.ContainerStatus{{Name: k8s.ProxyContainerName, State: running, Ready: true}},
+			}),
+			injectedPod("vote-bot-644b8cb6b4-g8nlr", corev1.PodStatus{Phase: corev1.PodSucceeded}),
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "uninjected"},
+				Status:     corev1.PodStatus{Phase: corev1.PodPending},
+			},
+		}
+
+		if err := validateDataPlaneRollout(pods, "linkerd"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Reports why pods are stuck", func(t *testing.T) {
+		pods := []corev1.Pod{
+			injectedPod("web-6cfbccc48-5g8px", corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					Name:  k8s.InitContainerName,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			}),
+			injectedPod("voting-65b9fffd77-rlwsd", corev1.PodStatus{
+				Phase:                 corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: k8s.InitContainerName, State: completed}},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "voting-svc",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				}},
+			}),
+			injectedPod("emoji-d9c7866bb-7v74n", corev1.PodStatus{
+				Phase:                 corev1.PodRunning,
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: k8s.InitContainerName, State: completed}},
+				ContainerStatuses:     []corev1.ContainerStatus{{Name: k8s.ProxyContainerName, State: running}},
+			}),
+			injectedPod("vote-bot-644b8cb6b4-g8nlr", corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Message: "0/3 nodes are available: 3 Insufficient cpu.",
+				}},
+			}),
+		}
+
+		err := validateDataPlaneRollout(pods, "linkerd")
+		expected := "pods not ready: " +
+			"emoji-d9c7866bb-7v74n (linkerd-proxy: not ready, the proxy may be waiting for its identity certificate), " +
+			"vote-bot-644b8cb6b4-g8nlr (unschedulable: 0/3 nodes are available: 3 Insufficient cpu.), " +
+			"voting-65b9fffd77-rlwsd (voting-svc: ImagePullBackOff), " +
+			"web-6cfbccc48-5g8px (linkerd-init: CrashLoopBackOff)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error:\n%s\nGot:\n%v", expected, err)
+		}
+	})
+}
+
+func TestValidateProxyCrashLoops(t *testing.T) {
+	crashLooping := func(namespace, name string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 k8s.ProxyContainerName,
+					RestartCount:         5,
+					State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 101}},
+				}},
+			},
+		}
+	}
+	logs := func(pod corev1.Pod) (string, error) {
+		if pod.Name == "voting-65b9fffd77-rlwsd" {
+			return "", errors.New("forbidden")
+		}
+		return "ERR! proxy failed to start\nthread 'main' panicked\n", nil
+	}
+
+	t.Run("Returns nil if no proxy is crash-looping", func(t *testing.T) {
+		pods := []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "emoji-d9c7866bb-7v74n", Namespace: "emojivoto"},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: k8s.ProxyContainerName, RestartCount: 1, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "emoji-svc", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					},
+				},
+			},
+		}
+
+		if err := validateProxyCrashLoops(pods, logs); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Reports crash-looping proxies with their logs", func(t *testing.T) {
+		pods := []corev1.Pod{
+			crashLooping("emojivoto", "web-6cfbccc48-5g8px"),
+			crashLooping("emojivoto", "voting-65b9fffd77-rlwsd"),
+		}
+
+		err := validateProxyCrashLoops(pods, logs)
+		expected := `The "linkerd-proxy" container is crash-looping in 2 pods:
+    * emojivoto/voting-65b9fffd77-rlwsd restarted 5 times, last exit code 101 (logs unavailable: forbidden)
+    * emojivoto/web-6cfbccc48-5g8px restarted 5 times, last exit code 101, last logs:
+        ERR! proxy failed to start
+        thread 'main' panicked`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error:\n%s\nGot:\n%v", expected, err)
+		}
+	})
+}
+
+func TestValidateControlPlaneProxies(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, proxyReady bool) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "linkerd"},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "identity", Ready: true},
+					{Name: k8s.ProxyContainerName, Ready: proxyReady},
+				},
+			},
+		}
+	}
+
+	pods := []corev1.Pod{
+		pod("linkerd-identity-6849948664-27982", corev1.PodRunning, true),
+		pod("linkerd-web-98c9ddbcd-7b5lh", corev1.PodRunning, false),
+		pod("linkerd-controller-5bd7b8675c-9mjnr", corev1.PodRunning, false),
+		pod("linkerd-grafana-5b7d796646-hh46d", corev1.PodPending, false),
+	}
+
+	err := validateControlPlaneProxies(pods)
+	expected := `The "linkerd-proxy" container is not ready in the pods: linkerd-controller-5bd7b8675c-9mjnr, linkerd-web-98c9ddbcd-7b5lh`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	if err := validateControlPlaneProxies(pods[:1]); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestValidateControlPlaneProxyTrustAnchors(t *testing.T) {
+	ca, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	trustPEM := ca.Cred.Crt.EncodeCertificatePEM()
+	now := time.Now()
+
+	pod := func(name, trustAnchors string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "linkerd"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: k8s.ProxyContainerName,
+					Env:  []corev1.EnvVar{{Name: proxyTrustAnchorsEnv, Value: trustAnchors}},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		pods     []corev1.Pod
+		trustPEM string
+		now      time.Time
+		expected string
+	}{
+		{
+			desc:     "proxies trust the configured anchors",
+			pods:     []corev1.Pod{pod("linkerd-controller-5bd7b8675c-9mjnr", trustPEM+"\n")},
+			trustPEM: trustPEM,
+			now:      now,
+		},
+		{
+			desc: "identity is disabled",
+			pods: []corev1.Pod{pod("linkerd-controller-5bd7b8675c-9mjnr", trustPEM)},
+			now:  now,
+		},
+		{
+			desc:     "proxies trust other anchors",
+			pods:     []corev1.Pod{pod("linkerd-web-98c9ddbcd-7b5lh", "other"), pod("linkerd-controller-5bd7b8675c-9mjnr", trustPEM)},
+			trustPEM: trustPEM,
+			now:      now,
+			expected: `The "linkerd-proxy" container of the pods linkerd-web-98c9ddbcd-7b5lh trusts anchors`,
+		},
+		{
+			desc:     "trust anchors are invalid",
+			trustPEM: "invalid",
+			now:      now,
+			expected: "invalid trust anchors",
+		},
+		{
+			desc:     "trust anchors have expired",
+			trustPEM: trustPEM,
+			now:      ca.Cred.Crt.Certificate.NotAfter.Add(time.Minute),
+			expected: `trust anchor "identity.linkerd.cluster.local" is only valid from`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validateControlPlaneProxyTrustAnchors(tc.pods, tc.trustPEM, tc.now)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
 func TestValidateDataPlanePodReporting(t *testing.T) {
 	t.Run("Returns success if no pods present", func(t *testing.T) {
 		err := validateDataPlanePodReporting([]*pb.Pod{})
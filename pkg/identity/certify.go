@@ -0,0 +1,92 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"time"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// renewalRetryInterval is how long to wait before retrying a failed
+	// renewal of the server credentials.
+	renewalRetryInterval = 10 * time.Second
+)
+
+// Certify obtains a certificate for identity from the identity service, like
+// proxies do, authenticating with the service account token tok.
+func Certify(ctx context.Context, client pb.IdentityClient, identity string, tok []byte) (*tls.Cred, error) {
+	key, err := tls.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{identity}}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := client.Certify(ctx, &pb.CertifyRequest{
+		Identity:                  identity,
+		Token:                     tok,
+		CertificateSigningRequest: csr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	leaf, err := x509.ParseCertificate(rsp.GetLeafCertificate())
+	if err != nil {
+		return nil, err
+	}
+
+	// The intermediates are sent from the leaf's issuer to the root, while the
+	// trust chain goes from the root to the issuer.
+	chain := []*x509.Certificate{}
+	for i := len(rsp.GetIntermediateCertificates()) - 1; i >= 0; i-- {
+		crt, err := x509.ParseCertificate(rsp.GetIntermediateCertificates()[i])
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, crt)
+	}
+
+	return &tls.Cred{PrivateKey: key, Crt: tls.Crt{Certificate: leaf, TrustChain: chain}}, nil
+}
+
+// RenewServerCredentials replaces the certificate of creds with the one
+// returned by issue once two thirds of its remaining validity have passed,
+// until stop is closed. Failed renewals are retried, as the certificate remains
+// valid for a while.
+func RenewServerCredentials(creds *ServerCredentials, issue func() (*tls.Cred, error), stop <-chan struct{}) {
+	retry := false
+	for {
+		wait := renewalRetryInterval
+		if expiry := creds.Expiry(); !retry && !expiry.IsZero() {
+			if untilRenewal := time.Until(expiry) * 2 / 3; untilRenewal > wait {
+				wait = untilRenewal
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+
+		cred, err := issue()
+		if err == nil {
+			err = creds.Set(cred)
+		}
+		retry = err != nil
+		if retry {
+			log.Errorf("failed to renew the server certificate, retrying in %s: %s", renewalRetryInterval, err)
+			continue
+		}
+		log.Infof("renewed the server certificate until %s", cred.Certificate.NotAfter)
+	}
+}
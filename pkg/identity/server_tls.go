@@ -0,0 +1,182 @@
+package identity
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsRecordTypeHandshake is the first byte of a TLS ClientHello.
+const tlsRecordTypeHandshake = 0x16
+
+type (
+	// ServerCredentials holds the certificate a control plane server presents
+	// to proxies, which is replaced as it's renewed.
+	ServerCredentials struct {
+		sync.RWMutex
+		crt    *tls.Certificate
+		expiry time.Time
+	}
+
+	// serverTransportCredentials serves gRPC over TLS to the proxies, whose
+	// certificates must be issued by the trust anchors for an identity of the
+	// control plane's trust domain. Plaintext connections are only accepted
+	// from the loopback interface, i.e. from the other containers of the pod.
+	serverTransportCredentials struct {
+		credentials.TransportCredentials
+	}
+
+	// peekedConn replays the bytes read to detect TLS.
+	peekedConn struct {
+		net.Conn
+		r *bufio.Reader
+	}
+)
+
+// Set replaces the certificate presented to proxies.
+func (c *ServerCredentials) Set(cred *pkgTls.Cred) error {
+	crt, err := tls.X509KeyPair([]byte(cred.EncodePEM()), []byte(cred.EncodePrivateKeyPEM()))
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.crt = &crt
+	c.expiry = cred.Certificate.NotAfter
+	return nil
+}
+
+// Expiry returns the expiry of the certificate presented to proxies.
+func (c *ServerCredentials) Expiry() time.Time {
+	c.RLock()
+	defer c.RUnlock()
+	return c.expiry
+}
+
+func (c *ServerCredentials) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.crt == nil {
+		return nil, errors.New("no certificate was issued yet")
+	}
+	return c.crt, nil
+}
+
+// NewServerTransportCredentials returns the gRPC credentials of a control plane
+// server presenting creds. The certificates of the proxies are verified with
+// roots and must be issued for an identity of the control plane in
+// controllerNS, either in the DNS form or as a SPIFFE ID. Unless
+// requireClientCert is set, e.g. for the identity service that proxies
+// connect to before they have a certificate, proxies may connect without one.
+func NewServerTransportCredentials(creds *ServerCredentials, roots *x509.CertPool, controllerNS, trustDomain string, requireClientCert bool) credentials.TransportCredentials {
+	clientAuth := tls.RequestClientCert
+	if requireClientCert {
+		clientAuth = tls.RequireAnyClientCert
+	}
+
+	config := &tls.Config{
+		GetCertificate: creds.getCertificate,
+		ClientAuth:     clientAuth,
+		MinVersion:     tls.VersionTLS12,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+			_, err := verifyProxyCertificate(rawCerts, roots, controllerNS, trustDomain)
+			return err
+		},
+	}
+	return &serverTransportCredentials{credentials.NewTLS(config)}
+}
+
+// ServerHandshake serves TLS to the connections starting with a ClientHello,
+// and plaintext to the other ones when they come from the loopback interface.
+func (c *serverTransportCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn := &peekedConn{rawConn, bufio.NewReader(rawConn)}
+	first, err := conn.r.Peek(1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if first[0] == tlsRecordTypeHandshake {
+		return c.TransportCredentials.ServerHandshake(conn)
+	}
+
+	if addr, ok := rawConn.RemoteAddr().(*net.TCPAddr); ok && addr.IP.IsLoopback() {
+		return conn, nil, nil
+	}
+	log.Debugf("refusing plaintext connection from %s", rawConn.RemoteAddr())
+	return nil, nil, fmt.Errorf("plaintext connections are only accepted from the loopback interface, got one from %s", rawConn.RemoteAddr())
+}
+
+func (c *serverTransportCredentials) Clone() credentials.TransportCredentials {
+	return &serverTransportCredentials{c.TransportCredentials.Clone()}
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// verifyProxyCertificate verifies the chain of a proxy's certificate with
+// roots, and returns the proxy's identity.
+func verifyProxyCertificate(rawCerts [][]byte, roots *x509.CertPool, controllerNS, trustDomain string) (string, error) {
+	crts := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		crt, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid client certificate: %s", err)
+		}
+		crts[i] = crt
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, crt := range crts[1:] {
+		intermediates.AddCert(crt)
+	}
+	_, err := crts[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return "", fmt.Errorf("client certificate isn't issued by the trust anchors: %s", err)
+	}
+
+	return ProxyIdentity(crts[0], controllerNS, trustDomain)
+}
+
+// ProxyIdentity returns the identity of a proxy's certificate: its DNS name,
+// e.g. web.emojivoto.serviceaccount.identity.linkerd.cluster.local, or its
+// SPIFFE ID, e.g. spiffe://cluster.local/ns/emojivoto/sa/web. The identity
+// must belong to the trust domain of the control plane in controllerNS.
+func ProxyIdentity(crt *x509.Certificate, controllerNS, trustDomain string) (string, error) {
+	suffix := fmt.Sprintf(".serviceaccount.identity.%s.%s", controllerNS, trustDomain)
+	for _, name := range crt.DNSNames {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		if parts := strings.Split(strings.TrimSuffix(name, suffix), "."); len(parts) == 2 {
+			return name, nil
+		}
+	}
+
+	for _, uri := range crt.URIs {
+		parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+		if uri.Scheme == "spiffe" && uri.Host == trustDomain && len(parts) == 4 && parts[0] == "ns" && parts[2] == "sa" {
+			return uri.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("client certificate has no identity of the %s trust domain", strings.TrimPrefix(suffix, "."))
+}
@@ -0,0 +1,140 @@
+package identity
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/tls"
+)
+
+func newCA(t *testing.T) *tls.CA {
+	ca, err := tls.GenerateRootCAWithDefaults(t.Name())
+	if err != nil {
+		t.Fatalf("failed to create CA: %s", err)
+	}
+	return ca
+}
+
+func issue(t *testing.T, ca *tls.CA, dnsName string, uri string) [][]byte {
+	key, err := tls.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	csr := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "proxy"}, PublicKey: &key.PublicKey}
+	if dnsName != "" {
+		csr.DNSNames = []string{dnsName}
+	}
+	if uri != "" {
+		u, err := url.Parse(uri)
+		if err != nil {
+			t.Fatalf("invalid URI %s: %s", uri, err)
+		}
+		csr.URIs = []*url.URL{u}
+	}
+
+	crt, err := ca.IssueEndEntityCrt(csr)
+	if err != nil {
+		t.Fatalf("failed to issue certificate: %s", err)
+	}
+	return crt.ExtractRaw()
+}
+
+func TestVerifyProxyCertificate(t *testing.T) {
+	ca := newCA(t)
+	roots := ca.Cred.Crt.CertPool()
+
+	testCases := []struct {
+		desc     string
+		rawCerts [][]byte
+		identity string
+		err      bool
+	}{
+		{
+			desc:     "DNS identity",
+			rawCerts: issue(t, ca, "web.emojivoto.serviceaccount.identity.linkerd.cluster.local", ""),
+			identity: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+		},
+		{
+			desc:     "SPIFFE identity",
+			rawCerts: issue(t, ca, "", "spiffe://cluster.local/ns/emojivoto/sa/web"),
+			identity: "spiffe://cluster.local/ns/emojivoto/sa/web",
+		},
+		{
+			desc:     "identity of another control plane",
+			rawCerts: issue(t, ca, "web.emojivoto.serviceaccount.identity.linkerd-canary.cluster.local", ""),
+			err:      true,
+		},
+		{
+			desc:     "SPIFFE identity of another trust domain",
+			rawCerts: issue(t, ca, "", "spiffe://example.com/ns/emojivoto/sa/web"),
+			err:      true,
+		},
+		{
+			desc:     "certificate issued by other trust anchors",
+			rawCerts: issue(t, newCA(t), "web.emojivoto.serviceaccount.identity.linkerd.cluster.local", ""),
+			err:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			identity, err := verifyProxyCertificate(tc.rawCerts, roots, "linkerd", "cluster.local")
+			if tc.err {
+				if err == nil {
+					t.Fatalf("Expected an error, got identity %s", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if identity != tc.identity {
+				t.Fatalf("Expected identity %s, got %s", tc.identity, identity)
+			}
+		})
+	}
+}
+
+func TestServerHandshakeAcceptsPlaintextFromLoopback(t *testing.T) {
+	creds := NewServerTransportCredentials(&ServerCredentials{}, x509.NewCertPool(), "linkerd", "cluster.local", true)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := net.Dial("tcp", lis.Addr().String())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"))
+	}()
+
+	rawConn, err := lis.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept: %s", err)
+	}
+	defer rawConn.Close()
+
+	conn, _, err := creds.ServerHandshake(rawConn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The peeked byte must be replayed.
+	buf := make([]byte, 3)
+	if _, err := conn.Read(buf); err != nil {
+		t.Fatalf("Failed to read: %s", err)
+	}
+	if string(buf) != "PRI" {
+		t.Fatalf("Expected the connection to start with PRI, got %q", buf)
+	}
+}
@@ -27,6 +27,14 @@ type (
 	Service struct {
 		Validator
 		tls.Issuer
+
+		lifetimes IssuanceLifetimes
+	}
+
+	// IssuanceLifetimes implementors return the lifetime of the certificates
+	// issued to an identity. A zero lifetime selects the issuer's default.
+	IssuanceLifetimes interface {
+		IssuanceLifetime(identity string) (time.Duration, error)
 	}
 
 	// Validator implementors accept a bearer token, validates it, and returns a
@@ -54,7 +62,14 @@ type (
 
 // NewService creates a new identity service.
 func NewService(v Validator, i tls.Issuer) *Service {
-	return &Service{v, i}
+	return &Service{Validator: v, Issuer: i}
+}
+
+// WithIssuanceLifetimes configures the service to issue certificates with the
+// lifetimes returned by l, when the issuer supports it.
+func (svc *Service) WithIssuanceLifetimes(l IssuanceLifetimes) *Service {
+	svc.lifetimes = l
+	return svc
 }
 
 // Register registers an identity service implementation in the provided gRPC
@@ -102,7 +117,7 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 	}
 
 	// Create a certificate
-	crt, err := svc.IssueEndEntityCrt(csr)
+	crt, err := svc.issue(tokIdentity, csr)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -128,6 +143,26 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 	return rsp, nil
 }
 
+// issue creates a certificate for the identity, with the lifetime configured
+// for it if any. Failing to determine the lifetime isn't fatal, the issuer's
+// default lifetime is used instead.
+func (svc *Service) issue(identity string, csr *x509.CertificateRequest) (tls.Crt, error) {
+	issuer, ok := svc.Issuer.(tls.LifetimeIssuer)
+	if svc.lifetimes == nil || !ok {
+		return svc.IssueEndEntityCrt(csr)
+	}
+
+	lifetime, err := svc.lifetimes.IssuanceLifetime(identity)
+	if err != nil {
+		log.Warnf("failed to determine the issuance lifetime for %s, using the default: %s", identity, err)
+		return svc.IssueEndEntityCrt(csr)
+	}
+	if lifetime == 0 {
+		return svc.IssueEndEntityCrt(csr)
+	}
+	return issuer.IssueEndEntityCrtWithLifetime(csr, lifetime)
+}
+
 func checkRequest(req *pb.CertifyRequest) (string, []byte, *x509.CertificateRequest, error) {
 	reqIdentity := req.GetIdentity()
 	if reqIdentity == "" {
@@ -3,6 +3,7 @@ package inject
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,9 +19,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 )
 
+// archLabels are the node labels holding the CPU architecture of the nodes.
+var archLabels = []string{"kubernetes.io/arch", "beta.kubernetes.io/arch"}
+
 const (
 	// localhostDNSOverride allows override of the destinationDNS. This
 	// must be in absolute form for the proxy to special-case it.
@@ -45,6 +50,7 @@ const (
 	envOutboundConnectKeepAlive = "LINKERD2_PROXY_OUTBOUND_CONNECT_KEEPALIVE"
 
 	envDestinationContext         = "LINKERD2_PROXY_DESTINATION_CONTEXT"
+	envDestinationGetSuffixes     = "LINKERD2_PROXY_DESTINATION_GET_SUFFIXES"
 	envDestinationProfileSuffixes = "LINKERD2_PROXY_DESTINATION_PROFILE_SUFFIXES"
 	envDestinationSvcAddr         = "LINKERD2_PROXY_DESTINATION_SVC_ADDR"
 	envDestinationSvcName         = "LINKERD2_PROXY_DESTINATION_SVC_NAME"
@@ -464,6 +470,13 @@ func (conf *ResourceConfig) injectPodSpec(patch *Patch) {
 		LivenessProbe:  conf.proxyLivenessProbe(),
 	}
 
+	if suffixes := conf.proxyDestinationGetSuffixes(); suffixes != "" {
+		sidecar.Env = append(sidecar.Env, v1.EnvVar{
+			Name:  envDestinationGetSuffixes,
+			Value: suffixes,
+		})
+	}
+
 	// Special case if the caller specifies that
 	// LINKERD2_PROXY_OUTBOUND_ROUTER_CAPACITY be set on the pod.
 	// We key off of any container image in the pod. Ideally we would instead key
@@ -613,6 +626,10 @@ func (conf *ResourceConfig) injectObjectMeta(patch *Patch) {
 		patch.addPodAnnotation(k8s.IdentityModeAnnotation, k8s.IdentityModeDisabled)
 	}
 
+	for k, v := range conf.proxyLabels() {
+		conf.pod.labels[k8s.ProxyLabelPrefix+k] = v
+	}
+
 	if len(conf.pod.labels) > 0 {
 		if len(conf.pod.meta.Labels) == 0 {
 			patch.addPodLabelsRoot()
@@ -644,12 +661,73 @@ func (conf *ResourceConfig) taggedProxyInitImage() string {
 }
 
 func (conf *ResourceConfig) proxyImage() string {
+	if image := conf.archImage(k8s.ProxyArchImagesAnnotation); image != "" {
+		return image
+	}
 	if override := conf.getOverride(k8s.ProxyImageAnnotation); override != "" {
 		return override
 	}
 	return conf.configs.GetProxy().GetProxyImage().GetImageName()
 }
 
+// archImage returns the image the annotation sets for the CPU architecture the
+// pod is constrained to, if any. An invalid annotation is ignored.
+func (conf *ResourceConfig) archImage(annotation string) string {
+	override := conf.getOverride(annotation)
+	if override == "" {
+		return ""
+	}
+
+	images := map[string]string{}
+	for _, pair := range strings.Split(override, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Warnf("invalid image %q, expected arch=image, ignoring %s", pair, annotation)
+			return ""
+		}
+		images[parts[0]] = parts[1]
+	}
+
+	return images[podArch(conf.pod.spec)]
+}
+
+// podArch returns the CPU architecture the pod is constrained to by its node
+// selector, or by its required node affinity when all of its terms select the
+// same architecture. It returns an empty string otherwise.
+func podArch(spec *v1.PodSpec) string {
+	if spec == nil {
+		return ""
+	}
+
+	for _, label := range archLabels {
+		if arch := spec.NodeSelector[label]; arch != "" {
+			return arch
+		}
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+
+	// The terms are ORed, so the pod may land on any of their architectures.
+	arch := ""
+	for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termArch := ""
+		for _, expr := range term.MatchExpressions {
+			for _, label := range archLabels {
+				if expr.Key == label && expr.Operator == v1.NodeSelectorOpIn && len(expr.Values) == 1 {
+					termArch = expr.Values[0]
+				}
+			}
+		}
+		if termArch == "" || (arch != "" && termArch != arch) {
+			return ""
+		}
+		arch = termArch
+	}
+	return arch
+}
+
 func (conf *ResourceConfig) proxyImagePullPolicy() v1.PullPolicy {
 	if override := conf.getOverride(k8s.ProxyImagePullPolicyAnnotation); override != "" {
 		return v1.PullPolicy(override)
@@ -715,6 +793,50 @@ func (conf *ResourceConfig) proxyLogLevel() string {
 	return conf.configs.GetProxy().GetLogLevel().GetLevel()
 }
 
+// proxyLabels returns the extra labels of the labels config, updated with the
+// labels of the ProxyLabelsAnnotation annotation. An invalid annotation is
+// ignored.
+func (conf *ResourceConfig) proxyLabels() map[string]string {
+	labels := map[string]string{}
+	for k, v := range conf.configs.GetProxy().GetLabels() {
+		labels[k] = v
+	}
+
+	if override := conf.getOverride(k8s.ProxyLabelsAnnotation); override != "" {
+		overrides, err := ParseProxyLabels(strings.Split(override, ","))
+		if err != nil {
+			log.Warnf("%s, ignoring %s", err, k8s.ProxyLabelsAnnotation)
+			return labels
+		}
+		for k, v := range overrides {
+			labels[k] = v
+		}
+	}
+
+	return labels
+}
+
+// ParseProxyLabels parses key=value pairs into a map of extra proxy labels.
+// Each key must be usable as the name of a pod label once prefixed with
+// k8s.ProxyLabelPrefix, and each value as the value of a pod label.
+func ParseProxyLabels(pairs []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range pairs {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid proxy label %q, expected key=value", pair)
+		}
+		if errs := validation.IsQualifiedName(k8s.ProxyLabelPrefix + parts[0]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid proxy label key %q: %s", parts[0], strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(parts[1]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid proxy label value %q: %s", parts[1], strings.Join(errs, "; "))
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
+
 func (conf *ResourceConfig) proxyResourceRequirements() v1.ResourceRequirements {
 	resources := v1.ResourceRequirements{
 		Requests: v1.ResourceList{},
@@ -798,19 +920,49 @@ func (conf *ResourceConfig) proxyIdentityAddr() string {
 }
 
 func (conf *ResourceConfig) proxyControlListenAddr() string {
-	return fmt.Sprintf("0.0.0.0:%d", conf.proxyControlPort())
+	return joinHostPort(conf.proxyUnspecifiedIP(), conf.proxyControlPort())
 }
 
 func (conf *ResourceConfig) proxyInboundListenAddr() string {
-	return fmt.Sprintf("0.0.0.0:%d", conf.proxyInboundPort())
+	return joinHostPort(conf.proxyUnspecifiedIP(), conf.proxyInboundPort())
 }
 
 func (conf *ResourceConfig) proxyAdminListenAddr() string {
-	return fmt.Sprintf("0.0.0.0:%d", conf.proxyAdminPort())
+	return joinHostPort(conf.proxyUnspecifiedIP(), conf.proxyAdminPort())
 }
 
 func (conf *ResourceConfig) proxyOutboundListenAddr() string {
-	return fmt.Sprintf("127.0.0.1:%d", conf.proxyOutboundPort())
+	return joinHostPort(conf.proxyLoopbackIP(), conf.proxyOutboundPort())
+}
+
+// joinHostPort formats an address, enclosing IPv6 hosts in brackets.
+func joinHostPort(host string, port int32) string {
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+func (conf *ResourceConfig) proxyIPFamily() string {
+	if override := conf.getOverride(k8s.ProxyIPFamilyAnnotation); override != "" {
+		switch override {
+		case k8s.ProxyIPFamilyIPv4, k8s.ProxyIPFamilyIPv6:
+			return override
+		}
+		log.Warnf("unsupported value %q, using %q (%s)", override, k8s.ProxyIPFamilyIPv4, k8s.ProxyIPFamilyAnnotation)
+	}
+	return k8s.ProxyIPFamilyIPv4
+}
+
+func (conf *ResourceConfig) proxyUnspecifiedIP() string {
+	if conf.proxyIPFamily() == k8s.ProxyIPFamilyIPv6 {
+		return "::"
+	}
+	return "0.0.0.0"
+}
+
+func (conf *ResourceConfig) proxyLoopbackIP() string {
+	if conf.proxyIPFamily() == k8s.ProxyIPFamilyIPv6 {
+		return "::1"
+	}
+	return "127.0.0.1"
 }
 
 func (conf *ResourceConfig) proxyUID() int64 {
@@ -864,7 +1016,46 @@ func (conf *ResourceConfig) proxyDestinationProfileSuffixes() string {
 	return defaultProfileSuffix
 }
 
+// proxyDestinationGetSuffixes returns the suffixes of the names the proxy
+// resolves through the destination service, or an empty string to keep the
+// proxy's default.
+func (conf *ResourceConfig) proxyDestinationGetSuffixes() string {
+	suffixes := conf.configs.GetProxy().GetDestinationGetSuffixes()
+	if override := conf.getOverride(k8s.ProxyDestinationGetSuffixesAnnotation); override != "" {
+		parsed, err := ParseDestinationGetSuffixes(strings.Split(override, ","))
+		if err != nil {
+			log.Warnf("%s (%s)", err, k8s.ProxyDestinationGetSuffixesAnnotation)
+		} else {
+			suffixes = parsed
+		}
+	}
+	return strings.Join(suffixes, ",")
+}
+
+// ParseDestinationGetSuffixes validates a list of DNS suffixes, such as
+// "svc.cluster.local.", trimming their whitespace. The root suffix "." matches
+// every name.
+func ParseDestinationGetSuffixes(suffixes []string) ([]string, error) {
+	parsed := make([]string, 0, len(suffixes))
+	for _, suffix := range suffixes {
+		suffix = strings.TrimSpace(suffix)
+		if suffix != "." {
+			if !strings.HasSuffix(suffix, ".") {
+				return nil, fmt.Errorf("invalid destination get suffix %q, must be fully qualified (end with a dot)", suffix)
+			}
+			if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(suffix, ".")); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid destination get suffix %q: %s", suffix, strings.Join(errs, "; "))
+			}
+		}
+		parsed = append(parsed, suffix)
+	}
+	return parsed, nil
+}
+
 func (conf *ResourceConfig) proxyInitImage() string {
+	if image := conf.archImage(k8s.ProxyInitArchImagesAnnotation); image != "" {
+		return image
+	}
 	if override := conf.getOverride(k8s.ProxyInitImageAnnotation); override != "" {
 		return override
 	}
@@ -893,6 +1084,9 @@ func (conf *ResourceConfig) proxyInitArgs() []string {
 		inboundSkipPorts += ","
 	}
 	inboundSkipPorts += fmt.Sprintf("%d,%d", controlPort, adminPort)
+	if probePorts := conf.probePorts(); len(probePorts) > 0 {
+		inboundSkipPorts += "," + probePorts
+	}
 
 	initArgs := []string{
 		"--incoming-proxy-port", fmt.Sprintf("%d", inboundPort),
@@ -921,6 +1115,80 @@ func (conf *ResourceConfig) proxyInboundSkipPorts() string {
 	return strings.Join(ports, ",")
 }
 
+func (conf *ResourceConfig) proxyProbeMode() string {
+	if override := conf.getOverride(k8s.ProxyProbeModeAnnotation); override != "" {
+		switch override {
+		case k8s.ProxyProbeModeProxy, k8s.ProxyProbeModeBypass:
+			return override
+		}
+		log.Warnf("unsupported value %q, using %q (%s)", override, k8s.ProxyProbeModeProxy, k8s.ProxyProbeModeAnnotation)
+	}
+	return k8s.ProxyProbeModeProxy
+}
+
+// probePorts returns the ports targeted by the httpGet and tcpSocket probes
+// of the application containers, when the proxy is configured to be bypassed
+// by probes.
+func (conf *ResourceConfig) probePorts() string {
+	if conf.proxyProbeMode() != k8s.ProxyProbeModeBypass || conf.pod.spec == nil {
+		return ""
+	}
+
+	seen := map[int32]struct{}{}
+	ports := []int{}
+	for _, container := range conf.pod.spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			continue
+		}
+		for _, probe := range []*v1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+			port, ok := probePort(probe, container)
+			if !ok {
+				continue
+			}
+			if _, exists := seen[port]; exists {
+				continue
+			}
+			seen[port] = struct{}{}
+			ports = append(ports, int(port))
+		}
+	}
+
+	sort.Ints(ports)
+	strs := make([]string, len(ports))
+	for i, port := range ports {
+		strs[i] = strconv.Itoa(port)
+	}
+	return strings.Join(strs, ",")
+}
+
+// probePort returns the port number targeted by the given httpGet or
+// tcpSocket probe, resolving named ports against the container's ports.
+func probePort(probe *v1.Probe, container v1.Container) (int32, bool) {
+	if probe == nil {
+		return 0, false
+	}
+
+	var port intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		port = probe.HTTPGet.Port
+	case probe.TCPSocket != nil:
+		port = probe.TCPSocket.Port
+	default:
+		return 0, false
+	}
+
+	if port.Type == intstr.Int {
+		return port.IntVal, port.IntVal > 0
+	}
+	for _, p := range container.Ports {
+		if p.Name == port.StrVal {
+			return p.ContainerPort, true
+		}
+	}
+	return 0, false
+}
+
 func (conf *ResourceConfig) proxyOutboundSkipPorts() string {
 	if override := conf.getOverride(k8s.ProxyIgnoreOutboundPortsAnnotation); override != "" {
 		return override
@@ -1,7 +1,9 @@
 package inject
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/gen/config"
@@ -403,3 +405,441 @@ func TestConfigAccessors(t *testing.T) {
 		})
 	}
 }
+
+func TestProbePorts(t *testing.T) {
+	configs := &config.All{
+		Global: &config.Global{LinkerdNamespace: "linkerd"},
+		Proxy: &config.Proxy{
+			ControlPort: &config.Port{Port: 4190},
+			AdminPort:   &config.Port{Port: 4191},
+		},
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				LivenessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+					},
+				},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(9090)},
+					},
+				},
+			},
+			{
+				Name:  "sidecar",
+				Ports: []corev1.ContainerPort{{Name: "admin", ContainerPort: 8081}},
+				LivenessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						Exec: &corev1.ExecAction{Command: []string{"true"}},
+					},
+				},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(8081)},
+					},
+				},
+			},
+			{
+				Name: "worker",
+				LivenessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("grpc")},
+					},
+				},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(8080)},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		id          string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			id:       "probes go through the proxy by default",
+			expected: "4190,4191",
+		},
+		{
+			id:          "probes go through the proxy when requested",
+			annotations: map[string]string{k8s.ProxyProbeModeAnnotation: k8s.ProxyProbeModeProxy},
+			expected:    "4190,4191",
+		},
+		{
+			id:          "named, numeric and undeclared probe ports are skipped in bypass mode",
+			annotations: map[string]string{k8s.ProxyProbeModeAnnotation: k8s.ProxyProbeModeBypass},
+			expected:    "4190,4191,8080,8081,9090",
+		},
+		{
+			id:          "unsupported modes fall back to the default",
+			annotations: map[string]string{k8s.ProxyProbeModeAnnotation: "invalid"},
+			expected:    "4190,4191",
+		},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc // pin
+		t.Run(testCase.id, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Annotations: testCase.annotations},
+						Spec:       podSpec,
+					},
+				},
+			}
+			data, err := yaml.Marshal(deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment")
+			if err := resourceConfig.parse(data); err != nil {
+				t.Fatal(err)
+			}
+
+			args := resourceConfig.proxyInitArgs()
+			var actual string
+			for i, arg := range args {
+				if arg == "--inbound-ports-to-ignore" {
+					actual = args[i+1]
+				}
+			}
+			if actual != testCase.expected {
+				t.Errorf("Expected: %v Actual: %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProxyListenAddrs(t *testing.T) {
+	configs := &config.All{
+		Global: &config.Global{LinkerdNamespace: "linkerd"},
+		Proxy: &config.Proxy{
+			InboundPort:  &config.Port{Port: 4143},
+			OutboundPort: &config.Port{Port: 4140},
+		},
+	}
+
+	testCases := []struct {
+		id          string
+		annotations map[string]string
+		inbound     string
+		outbound    string
+	}{
+		{
+			id:       "IPv4 addresses are used by default",
+			inbound:  "0.0.0.0:4143",
+			outbound: "127.0.0.1:4140",
+		},
+		{
+			id:          "IPv6 addresses are used when requested",
+			annotations: map[string]string{k8s.ProxyIPFamilyAnnotation: k8s.ProxyIPFamilyIPv6},
+			inbound:     "[::]:4143",
+			outbound:    "[::1]:4140",
+		},
+		{
+			id:          "unsupported families fall back to the default",
+			annotations: map[string]string{k8s.ProxyIPFamilyAnnotation: "ipx"},
+			inbound:     "0.0.0.0:4143",
+			outbound:    "127.0.0.1:4140",
+		},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc // pin
+		t.Run(testCase.id, func(t *testing.T) {
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment")
+			resourceConfig.pod.meta = &metav1.ObjectMeta{Annotations: testCase.annotations}
+
+			if actual := resourceConfig.proxyInboundListenAddr(); actual != testCase.inbound {
+				t.Errorf("Expected: %v Actual: %v", testCase.inbound, actual)
+			}
+			if actual := resourceConfig.proxyOutboundListenAddr(); actual != testCase.outbound {
+				t.Errorf("Expected: %v Actual: %v", testCase.outbound, actual)
+			}
+		})
+	}
+}
+
+func TestProxyLabels(t *testing.T) {
+	configs := &config.All{
+		Global: &config.Global{LinkerdNamespace: "linkerd"},
+		Proxy: &config.Proxy{
+			Labels: map[string]string{"cost-center": "eng", "team": "web"},
+		},
+	}
+
+	testCases := []struct {
+		id          string
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		{
+			id:       "labels come from the config",
+			expected: map[string]string{"cost-center": "eng", "team": "web"},
+		},
+		{
+			id:          "the annotation adds to and overrides the config",
+			annotations: map[string]string{k8s.ProxyLabelsAnnotation: "team=payments, env=prod"},
+			expected:    map[string]string{"cost-center": "eng", "team": "payments", "env": "prod"},
+		},
+		{
+			id:          "an invalid annotation is ignored",
+			annotations: map[string]string{k8s.ProxyLabelsAnnotation: "team=payments,env"},
+			expected:    map[string]string{"cost-center": "eng", "team": "web"},
+		},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc // pin
+		t.Run(testCase.id, func(t *testing.T) {
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment")
+			resourceConfig.pod.meta = &metav1.ObjectMeta{Annotations: testCase.annotations}
+
+			actual := resourceConfig.proxyLabels()
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected: %v Actual: %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseProxyLabels(t *testing.T) {
+	testCases := []struct {
+		pairs    []string
+		expected map[string]string
+		err      string
+	}{
+		{
+			pairs:    []string{"cost-center=eng", "team="},
+			expected: map[string]string{"cost-center": "eng", "team": ""},
+		},
+		{
+			pairs: []string{"team"},
+			err:   `invalid proxy label "team", expected key=value`,
+		},
+		{
+			pairs: []string{"team/name=web"},
+			err:   `invalid proxy label key "team/name"`,
+		},
+		{
+			pairs: []string{"team=web app"},
+			err:   `invalid proxy label value "web app"`,
+		},
+	}
+
+	for i, tc := range testCases {
+		testCase := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			actual, err := ParseProxyLabels(testCase.pairs)
+			if testCase.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), testCase.err) {
+					t.Fatalf("Expected error starting with %q, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected: %v Actual: %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProxyDestinationGetSuffixes(t *testing.T) {
+	testCases := []struct {
+		id          string
+		configured  []string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			id:       "the proxy default is kept when nothing is configured",
+			expected: "",
+		},
+		{
+			id:         "suffixes come from the config",
+			configured: []string{"svc.cluster.local.", "example.com."},
+			expected:   "svc.cluster.local.,example.com.",
+		},
+		{
+			id:          "the annotation overrides the config",
+			configured:  []string{"svc.cluster.local."},
+			annotations: map[string]string{k8s.ProxyDestinationGetSuffixesAnnotation: "cluster.local., ."},
+			expected:    "cluster.local.,.",
+		},
+		{
+			id:          "an invalid annotation is ignored",
+			configured:  []string{"svc.cluster.local."},
+			annotations: map[string]string{k8s.ProxyDestinationGetSuffixesAnnotation: "cluster.local"},
+			expected:    "svc.cluster.local.",
+		},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc // pin
+		t.Run(testCase.id, func(t *testing.T) {
+			configs := &config.All{
+				Global: &config.Global{LinkerdNamespace: "linkerd"},
+				Proxy:  &config.Proxy{DestinationGetSuffixes: testCase.configured},
+			}
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment")
+			resourceConfig.pod.meta = &metav1.ObjectMeta{Annotations: testCase.annotations}
+
+			if actual := resourceConfig.proxyDestinationGetSuffixes(); actual != testCase.expected {
+				t.Errorf("Expected: %v Actual: %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseDestinationGetSuffixes(t *testing.T) {
+	testCases := []struct {
+		suffixes []string
+		expected []string
+		err      string
+	}{
+		{
+			suffixes: []string{" svc.cluster.local.", "."},
+			expected: []string{"svc.cluster.local.", "."},
+		},
+		{
+			suffixes: []string{"svc.cluster.local"},
+			err:      `invalid destination get suffix "svc.cluster.local", must be fully qualified (end with a dot)`,
+		},
+		{
+			suffixes: []string{"svc_cluster.local."},
+			err:      `invalid destination get suffix "svc_cluster.local."`,
+		},
+	}
+
+	for i, tc := range testCases {
+		testCase := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			actual, err := ParseDestinationGetSuffixes(testCase.suffixes)
+			if testCase.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), testCase.err) {
+					t.Fatalf("Expected error %q, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("Expected: %v Actual: %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestArchImages(t *testing.T) {
+	configs := &config.All{
+		Global: &config.Global{LinkerdNamespace: "linkerd", Version: "stable-2.6.0"},
+		Proxy: &config.Proxy{
+			ProxyImage:     &config.Image{ImageName: "gcr.io/linkerd-io/proxy"},
+			ProxyInitImage: &config.Image{ImageName: "gcr.io/linkerd-io/proxy-init"},
+		},
+	}
+	archImages := map[string]string{
+		k8s.ProxyArchImagesAnnotation:     "arm64=example.com/proxy-arm64, amd64=example.com/proxy-amd64",
+		k8s.ProxyInitArchImagesAnnotation: "arm64=example.com/proxy-init-arm64",
+	}
+	archAffinity := func(archs ...string) *corev1.Affinity {
+		terms := []corev1.NodeSelectorTerm{}
+		for _, arch := range archs {
+			terms = append(terms, corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}},
+					{Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{arch}},
+				},
+			})
+		}
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}
+	}
+
+	testCases := []struct {
+		id          string
+		annotations map[string]string
+		spec        *corev1.PodSpec
+		proxy       string
+		proxyInit   string
+	}{
+		{
+			id:          "the configured images are used without an architecture",
+			annotations: archImages,
+			spec:        &corev1.PodSpec{},
+			proxy:       "gcr.io/linkerd-io/proxy:stable-2.6.0",
+			proxyInit:   "gcr.io/linkerd-io/proxy-init:stable-2.6.0",
+		},
+		{
+			id:          "the node selector selects the images",
+			annotations: archImages,
+			spec:        &corev1.PodSpec{NodeSelector: map[string]string{"beta.kubernetes.io/arch": "arm64"}},
+			proxy:       "example.com/proxy-arm64:stable-2.6.0",
+			proxyInit:   "example.com/proxy-init-arm64:stable-2.6.0",
+		},
+		{
+			id:          "the required node affinity selects the images",
+			annotations: archImages,
+			spec:        &corev1.PodSpec{Affinity: archAffinity("amd64", "amd64")},
+			proxy:       "example.com/proxy-amd64:stable-2.6.0",
+			proxyInit:   "gcr.io/linkerd-io/proxy-init:stable-2.6.0",
+		},
+		{
+			id:          "node affinities of several architectures are ignored",
+			annotations: archImages,
+			spec:        &corev1.PodSpec{Affinity: archAffinity("amd64", "arm64")},
+			proxy:       "gcr.io/linkerd-io/proxy:stable-2.6.0",
+			proxyInit:   "gcr.io/linkerd-io/proxy-init:stable-2.6.0",
+		},
+		{
+			id: "the architecture images take precedence over the image annotations",
+			annotations: map[string]string{
+				k8s.ProxyArchImagesAnnotation: "arm64=example.com/proxy-arm64",
+				k8s.ProxyImageAnnotation:      "example.com/proxy",
+			},
+			spec:      &corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}},
+			proxy:     "example.com/proxy-arm64:stable-2.6.0",
+			proxyInit: "gcr.io/linkerd-io/proxy-init:stable-2.6.0",
+		},
+		{
+			id:          "invalid annotations are ignored",
+			annotations: map[string]string{k8s.ProxyArchImagesAnnotation: "arm64"},
+			spec:        &corev1.PodSpec{NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"}},
+			proxy:       "gcr.io/linkerd-io/proxy:stable-2.6.0",
+			proxyInit:   "gcr.io/linkerd-io/proxy-init:stable-2.6.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		testCase := tc // pin
+		t.Run(testCase.id, func(t *testing.T) {
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment")
+			resourceConfig.pod.meta = &metav1.ObjectMeta{Annotations: testCase.annotations}
+			resourceConfig.pod.spec = testCase.spec
+
+			if actual := resourceConfig.taggedProxyImage(); actual != testCase.proxy {
+				t.Errorf("Expected: %v Actual: %v", testCase.proxy, actual)
+			}
+			if actual := resourceConfig.taggedProxyInitImage(); actual != testCase.proxyInit {
+				t.Errorf("Expected: %v Actual: %v", testCase.proxyInit, actual)
+			}
+		})
+	}
+}
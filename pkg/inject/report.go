@@ -72,6 +72,17 @@ func (r *Report) Injectable() bool {
 	return !r.HostNetwork && !r.Sidecar && !r.UnsupportedResource && !r.InjectDisabled
 }
 
+// SkipReason describes why the workload's pods aren't injected even though
+// injection wasn't disabled for them, or returns an empty string if there's no
+// such reason. Pods that already have a proxy or another sidecar aren't
+// reported, as they're commonly created from manifests injected by the CLI.
+func (r *Report) SkipReason() string {
+	if r.InjectDisabled || r.UnsupportedResource || !r.HostNetwork {
+		return ""
+	}
+	return "pods with \"hostNetwork: true\" aren't injected, as the proxy-init iptables rules would redirect the traffic of the whole node"
+}
+
 func checkUDPPorts(t *v1.PodSpec) bool {
 	// Check for ports with `protocol: UDP`, which will not be routed by Linkerd
 	for _, container := range t.Containers {
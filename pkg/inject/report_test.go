@@ -242,3 +242,25 @@ func TestDisableByAnnotation(t *testing.T) {
 		}
 	})
 }
+
+func TestSkipReason(t *testing.T) {
+	var testCases = []struct {
+		report     Report
+		skipReason bool
+	}{
+		{Report{}, false},
+		{Report{HostNetwork: true}, true},
+		{Report{HostNetwork: true, InjectDisabled: true}, false},
+		{Report{HostNetwork: true, UnsupportedResource: true}, false},
+		{Report{Sidecar: true}, false},
+	}
+
+	for i, testCase := range testCases {
+		testCase := testCase
+		t.Run(fmt.Sprintf("test case #%d", i), func(t *testing.T) {
+			if actual := testCase.report.SkipReason() != ""; testCase.skipReason != actual {
+				t.Errorf("Expected a skip reason: %t. Actual: %q", testCase.skipReason, testCase.report.SkipReason())
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ const (
 	ReplicationController = "replicationcontroller"
 	ReplicaSet            = "replicaset"
 	Service               = "service"
+	ServiceAccount        = "serviceaccount"
 	ServiceProfile        = "serviceprofile"
 	StatefulSet           = "statefulset"
 
@@ -42,6 +43,7 @@ var AllResources = []string{
 	ReplicationController,
 	ReplicaSet,
 	Service,
+	ServiceAccount,
 	ServiceProfile,
 	StatefulSet,
 }
@@ -76,6 +78,22 @@ func generateKubernetesURL(serverURL, path string) (*url.URL, error) {
 	return url.Parse(strings.TrimSuffix(serverURL, "/") + path)
 }
 
+// clientQPS and clientBurst configure the client-side rate limiting of every
+// config returned by GetConfig. Zero values keep the client-go defaults.
+var (
+	clientQPS   float32
+	clientBurst int
+)
+
+// SetClientRateLimit configures the client-side rate limiting (queries per
+// second and burst) applied to all Kubernetes clients built from GetConfig. It
+// should be called before any clients are created; zero values keep the
+// client-go defaults.
+func SetClientRateLimit(qps float32, burst int) {
+	clientQPS = qps
+	clientBurst = burst
+}
+
 // GetConfig returns kubernetes config based on the current environment.
 // If fpath is provided, loads configuration from that file. Otherwise,
 // GetConfig uses default strategy to load configuration from $KUBECONFIG,
@@ -86,9 +104,21 @@ func GetConfig(fpath, kubeContext string) (*rest.Config, error) {
 		rules.ExplicitPath = fpath
 	}
 	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
-	return clientcmd.
+	config, err := clientcmd.
 		NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).
 		ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if clientQPS > 0 {
+		config.QPS = clientQPS
+	}
+	if clientBurst > 0 {
+		config.Burst = clientBurst
+	}
+
+	return config, nil
 }
 
 // CanonicalResourceNameFromFriendlyName returns a canonical name from common shorthands used in command line tools.
@@ -114,6 +144,8 @@ func CanonicalResourceNameFromFriendlyName(friendlyName string) (string, error)
 		return ReplicaSet, nil
 	case "svc", "service", "services":
 		return Service, nil
+	case "sa", "serviceaccount", "serviceaccounts":
+		return ServiceAccount, nil
 	case "sp", "serviceprofile", "serviceprofiles":
 		return ServiceProfile, nil
 	case "sts", "statefulset", "statefulsets":
@@ -147,6 +179,8 @@ func ShortNameFromCanonicalResourceName(canonicalName string) string {
 		return "rs"
 	case Service:
 		return "svc"
+	case ServiceAccount:
+		return "sa"
 	case ServiceProfile:
 		return "sp"
 	case StatefulSet:
@@ -71,6 +71,23 @@ func TestGetConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("Applies the configured client rate limit", func(t *testing.T) {
+		SetClientRateLimit(50, 100)
+		defer SetClientRateLimit(0, 0)
+
+		config, err := GetConfig("testdata/config.test", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if config.QPS != 50 {
+			t.Fatalf("Expected QPS to be [50] got [%v]", config.QPS)
+		}
+		if config.Burst != 100 {
+			t.Fatalf("Expected burst to be [100] got [%d]", config.Burst)
+		}
+	})
+
 	t.Run("Returns error if configuration cannot be found", func(t *testing.T) {
 		_, err := GetConfig("/this/doest./not/exist.config", "")
 		if err == nil {
@@ -7,6 +7,7 @@ package k8s
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/version"
 	appsv1 "k8s.io/api/apps/v1"
@@ -53,6 +54,12 @@ const (
 	// StatefulSet that this proxy belongs to.
 	ProxyStatefulSetLabel = Prefix + "/proxy-statefulset"
 
+	// ProxyLabelPrefix prefixes the extra labels configured with
+	// ProxyLabelsAnnotation, which are injected into mesh-enabled apps. The
+	// Linkerd Prometheus exports a pod label `linkerd.io/proxy-label-foo` as
+	// the metric label `label_foo`.
+	ProxyLabelPrefix = Prefix + "/proxy-label-"
+
 	/*
 	 * Annotations
 	 */
@@ -65,6 +72,11 @@ const (
 	// issuer credentials will cease to be valid.
 	IdentityIssuerExpiryAnnotation = Prefix + "/identity-issuer-expiry"
 
+	// ConfigManifestHashAnnotation holds the SHA-256 of the manifests rendered
+	// from the configuration saved in the ConfigPreviousConfigMapName ConfigMap,
+	// at the time of the upgrade that saved it.
+	ConfigManifestHashAnnotation = Prefix + "/manifest-sha256"
+
 	// ProxyVersionAnnotation indicates the version of the injected data plane
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = Prefix + "/proxy-version"
@@ -104,6 +116,18 @@ const (
 	// config.
 	ProxyInitImageAnnotation = ProxyConfigAnnotationsPrefix + "/init-image"
 
+	// ProxyArchImagesAnnotation can be used to override the proxyImage config
+	// and ProxyImageAnnotation for the CPU architecture the pod is constrained
+	// to by its kubernetes.io/arch node selector or required node affinity, for
+	// registries without multi-architecture images. Its value is a
+	// comma-separated list of arch=image pairs, e.g.
+	// arm64=example.com/linkerd/proxy-arm64.
+	ProxyArchImagesAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-arch-images"
+
+	// ProxyInitArchImagesAnnotation is ProxyArchImagesAnnotation for the
+	// proxyInitImage config.
+	ProxyInitArchImagesAnnotation = ProxyConfigAnnotationsPrefix + "/init-arch-images"
+
 	// ProxyControlPortAnnotation can be used to override the controlPort config.
 	ProxyControlPortAnnotation = ProxyConfigAnnotationsPrefix + "/control-port"
 
@@ -148,9 +172,66 @@ const (
 	// disableExternalProfilesAnnotation config.
 	ProxyEnableExternalProfilesAnnotation = ProxyConfigAnnotationsPrefix + "/enable-external-profiles"
 
+	// ProxyLabelsAnnotation can be used to add to the labels config. Its value
+	// is a comma-separated list of key=value pairs.
+	ProxyLabelsAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-labels"
+
+	// ProxyDestinationGetSuffixesAnnotation can be used to override the
+	// destinationGetSuffixes config. Its value is a comma-separated list of
+	// DNS suffixes, e.g. "svc.cluster.local.".
+	ProxyDestinationGetSuffixesAnnotation = ProxyConfigAnnotationsPrefix + "/destination-get-suffixes"
+
 	// ProxyVersionOverrideAnnotation can be used to override the proxy version config.
 	ProxyVersionOverrideAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-version"
 
+	// ProxyProbeModeAnnotation can be used to configure how the kubelet's
+	// httpGet and tcpSocket probes of the application containers are handled
+	// by the proxy. Exec probes run inside the container and are unaffected.
+	ProxyProbeModeAnnotation = ProxyConfigAnnotationsPrefix + "/probe-mode"
+
+	// ProxyProbeModeProxy is assigned to ProxyProbeModeAnnotation to route
+	// probes through the proxy. This is the default.
+	ProxyProbeModeProxy = "proxy"
+
+	// ProxyProbeModeBypass is assigned to ProxyProbeModeAnnotation to skip the
+	// proxy for the ports targeted by probes, so that probes reach the
+	// application containers directly.
+	ProxyProbeModeBypass = "bypass"
+
+	// ProxyIPFamilyAnnotation can be used to configure the IP family of the
+	// addresses the proxy listens on. IPv6-only pods need it set to
+	// ProxyIPFamilyIPv6.
+	ProxyIPFamilyAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-ip-family"
+
+	// ProxyIPFamilyIPv4 is assigned to ProxyIPFamilyAnnotation to have the
+	// proxy listen on IPv4 addresses. This is the default.
+	ProxyIPFamilyIPv4 = "ipv4"
+
+	// ProxyIPFamilyIPv6 is assigned to ProxyIPFamilyAnnotation to have the
+	// proxy listen on IPv6 addresses.
+	ProxyIPFamilyIPv6 = "ipv6"
+
+	// IdentityIssuanceLifetimeAnnotation can be set on a namespace to shorten
+	// the lifetime of the certificates issued by the identity service to its
+	// pods. Lifetimes longer than the one configured for the cluster are
+	// capped to it.
+	IdentityIssuanceLifetimeAnnotation = ProxyConfigAnnotationsPrefix + "/identity-issuance-lifetime"
+
+	// ProxyInjectionPausedKey is the namespace label key that the namespace
+	// selector of the proxy injector's webhook requires to both exist and not
+	// exist while injection is paused, so that it matches no namespace.
+	ProxyInjectionPausedKey = Prefix + "/injection-paused"
+
+	// AdmissionWebhooksLabel is the namespace label that excludes the
+	// namespace from the namespace selector of the proxy injector's webhook
+	// when set to AdmissionWebhooksDisabled, so that the API server doesn't
+	// send its pods to the proxy injector at all.
+	AdmissionWebhooksLabel = ProxyConfigAnnotationsPrefix + "/admission-webhooks"
+
+	// AdmissionWebhooksDisabled is assigned to AdmissionWebhooksLabel to
+	// exclude a namespace from the proxy injector's webhook.
+	AdmissionWebhooksDisabled = "disabled"
+
 	// IdentityModeDefault is assigned to IdentityModeAnnotation to
 	// use the control plane's default identity scheme.
 	IdentityModeDefault = "default"
@@ -166,6 +247,15 @@ const (
 	// ConfigConfigMapName is the name of the ConfigMap containing the linkerd controller configuration.
 	ConfigConfigMapName = "linkerd-config"
 
+	// ConfigPreviousConfigMapName is the name of the ConfigMap in which upgrades
+	// save the configuration they replace, for `linkerd upgrade rollback`.
+	ConfigPreviousConfigMapName = "linkerd-config-previous"
+
+	// ConfigHistoryConfigMapName is the name of the ConfigMap holding a record
+	// of each install and upgrade of the control plane, for `linkerd upgrade
+	// history`.
+	ConfigHistoryConfigMapName = "linkerd-config-history"
+
 	// InitContainerName is the name assigned to the injected init container.
 	InitContainerName = "linkerd-init"
 
@@ -191,6 +281,16 @@ const (
 	// ProxyAdminPortName is the name of the Linkerd Proxy's metrics port.
 	ProxyAdminPortName = "linkerd-admin"
 
+	// ControllerAdminPortName is the name of the admin port of the control
+	// plane containers.
+	ControllerAdminPortName = "admin-http"
+
+	// ControllerAdminLocalPortOffset is the offset from the admin port of a
+	// control plane container to the port of its local admin server, which
+	// only listens on the loopback interface and changes the state of the
+	// container, e.g. its log level. The proxies must not intercept it.
+	ControllerAdminLocalPortOffset = 1000
+
 	// ProxyInjectorWebhookServiceName is the name of the mutating webhook service
 	ProxyInjectorWebhookServiceName = "linkerd-proxy-injector"
 
@@ -203,6 +303,14 @@ const (
 	// SPValidatorWebhookConfigName is the name of the validating webhook configuration
 	SPValidatorWebhookConfigName = SPValidatorWebhookServiceName + "-webhook-config"
 
+	// SMIMetricsServiceName is the name of the service serving the SMI
+	// TrafficMetrics API
+	SMIMetricsServiceName = "linkerd-smi-metrics"
+
+	// SMIMetricsAPIServiceName is the name of the APIService registering the
+	// SMI TrafficMetrics API with the Kubernetes API aggregator
+	SMIMetricsAPIServiceName = "v1alpha1.metrics.smi-spec.io"
+
 	/*
 	 * Mount paths
 	 */
@@ -240,6 +348,14 @@ func CreatedByAnnotationValue() string {
 	return fmt.Sprintf("linkerd/cli %s", version.Version)
 }
 
+// ScopedWebhookConfigName returns the name of a webhook configuration of the
+// control plane in the given namespace when its webhooks are scoped to the
+// namespaces labeled for it, e.g. linkerd-foo-proxy-injector-webhook-config,
+// so that it doesn't replace the configuration of another control plane.
+func ScopedWebhookConfigName(controllerNS, name string) string {
+	return fmt.Sprintf("linkerd-%s-%s", controllerNS, strings.TrimPrefix(name, "linkerd-"))
+}
+
 // GetServiceAccountAndNS returns the pod's serviceaccount and namespace.
 func GetServiceAccountAndNS(pod *corev1.Pod) (sa string, ns string) {
 	sa = pod.Spec.ServiceAccountName
@@ -275,6 +391,31 @@ func GetPodLabels(ownerKind, ownerName string, pod *corev1.Pod) map[string]strin
 	return labels
 }
 
+// GetPodMetricLabels returns the labels of the pod whose keys are in allowlist,
+// e.g. version, as prometheus labels. The characters of the keys that aren't
+// valid in prometheus label names are replaced with underscores, so that
+// app.kubernetes.io/version becomes app_kubernetes_io_version.
+func GetPodMetricLabels(pod *corev1.Pod, allowlist []string) map[string]string {
+	labels := map[string]string{}
+	for _, key := range allowlist {
+		if value, ok := pod.Labels[key]; ok {
+			labels[ToMetricLabelName(key)] = value
+		}
+	}
+	return labels
+}
+
+// ToMetricLabelName returns a prometheus label name for a Kubernetes label key.
+func ToMetricLabelName(key string) string {
+	name := []rune(key)
+	for i, r := range name {
+		if !(r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9')) {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
 // IsMeshed returns whether a given Pod is in a given controller's service mesh.
 func IsMeshed(pod *corev1.Pod, controllerNS string) bool {
 	return pod.Labels[ControllerNSLabel] == controllerNS
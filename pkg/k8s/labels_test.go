@@ -43,3 +43,27 @@ func TestGetPodLabels(t *testing.T) {
 		}
 	})
 }
+
+func TestGetPodMetricLabels(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-pod",
+			Labels: map[string]string{
+				"version":                   "v2",
+				"app.kubernetes.io/version": "2.0.0",
+				"team":                      "web",
+			},
+		},
+	}
+
+	expectedLabels := map[string]string{
+		"version":                   "v2",
+		"app_kubernetes_io_version": "2.0.0",
+	}
+
+	podLabels := GetPodMetricLabels(pod, []string{"version", "app.kubernetes.io/version", "missing"})
+
+	if !reflect.DeepEqual(podLabels, expectedLabels) {
+		t.Fatalf("Expected pod labels [%v] but got [%v]", expectedLabels, podLabels)
+	}
+}
@@ -42,6 +42,44 @@ func NewProxyMetricsForward(
 	clientset kubernetes.Interface,
 	pod corev1.Pod,
 	emitLogs bool,
+) (*PortForward, error) {
+	return newContainerPortForward(config, clientset, pod, ProxyContainerName, ProxyAdminPortName, 0, emitLogs)
+}
+
+// NewControllerAdminForward returns an instance of the PortForward struct that
+// can be used to establish a port-forward connection to the admin server of
+// the given control plane container.
+func NewControllerAdminForward(
+	config *rest.Config,
+	clientset kubernetes.Interface,
+	pod corev1.Pod,
+	containerName string,
+	emitLogs bool,
+) (*PortForward, error) {
+	return newContainerPortForward(config, clientset, pod, containerName, ControllerAdminPortName, 0, emitLogs)
+}
+
+// NewControllerAdminLocalForward returns an instance of the PortForward struct
+// that can be used to establish a port-forward connection to the local admin
+// server of the given control plane container, which only listens on the
+// loopback interface of the pod.
+func NewControllerAdminLocalForward(
+	config *rest.Config,
+	clientset kubernetes.Interface,
+	pod corev1.Pod,
+	containerName string,
+	emitLogs bool,
+) (*PortForward, error) {
+	return newContainerPortForward(config, clientset, pod, containerName, ControllerAdminPortName, ControllerAdminLocalPortOffset, emitLogs)
+}
+
+func newContainerPortForward(
+	config *rest.Config,
+	clientset kubernetes.Interface,
+	pod corev1.Pod,
+	containerName, portName string,
+	portOffset int,
+	emitLogs bool,
 ) (*PortForward, error) {
 	if pod.Status.Phase != corev1.PodRunning {
 		return nil, fmt.Errorf("pod not running: %s", pod.GetName())
@@ -49,27 +87,27 @@ func NewProxyMetricsForward(
 
 	var container corev1.Container
 	for _, c := range pod.Spec.Containers {
-		if c.Name == ProxyContainerName {
+		if c.Name == containerName {
 			container = c
 			break
 		}
 	}
-	if container.Name != ProxyContainerName {
-		return nil, fmt.Errorf("no %s container found for pod %s", ProxyContainerName, pod.GetName())
+	if container.Name != containerName {
+		return nil, fmt.Errorf("no %s container found for pod %s", containerName, pod.GetName())
 	}
 
 	var port corev1.ContainerPort
 	for _, p := range container.Ports {
-		if p.Name == ProxyAdminPortName {
+		if p.Name == portName {
 			port = p
 			break
 		}
 	}
-	if port.Name != ProxyAdminPortName {
-		return nil, fmt.Errorf("no %s port found for container %s/%s", ProxyAdminPortName, pod.GetName(), container.Name)
+	if port.Name != portName {
+		return nil, fmt.Errorf("no %s port found for container %s/%s", portName, pod.GetName(), container.Name)
 	}
 
-	return newPortForward(config, clientset, pod.GetNamespace(), pod.GetName(), 0, int(port.ContainerPort), emitLogs)
+	return newPortForward(config, clientset, pod.GetNamespace(), pod.GetName(), 0, int(port.ContainerPort)+portOffset, emitLogs)
 }
 
 // NewPortForward returns an instance of the PortForward struct that can be used
@@ -1,34 +1,113 @@
 package k8s
 
 import (
+	"bufio"
+	"io"
 	"strings"
 
 	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	spfake "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
 	spscheme "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
 )
 
 // NewFakeClientSets provides a mock Kubernetes ClientSet for testing.
 func NewFakeClientSets(configs ...string) (kubernetes.Interface, spclient.Interface, error) {
+	k8sClient, spClient, _, err := newFakeClientSets(configs)
+	return k8sClient, spClient, err
+}
+
+// NewFakeClientSetsFromManifests reads multi-document YAML manifests, such as
+// the output of `linkerd install`, and returns mock clients serving the
+// objects they contain. Objects whose kinds are not known to the Kubernetes
+// or ServiceProfile clientsets, such as CustomResourceDefinitions and other
+// custom resources, are served by the returned dynamic client.
+func NewFakeClientSetsFromManifests(readers []io.Reader) (kubernetes.Interface, spclient.Interface, dynamic.Interface, error) {
+	configs := []string{}
+
+	for _, reader := range readers {
+		r := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(reader, 4096))
+		for {
+			bytes, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			// skip documents consisting only of whitespace and comments
+			var doc map[string]interface{}
+			if err := yaml.Unmarshal(bytes, &doc); err != nil {
+				return nil, nil, nil, err
+			}
+			if len(doc) == 0 {
+				continue
+			}
+
+			configs = append(configs, string(bytes))
+		}
+	}
+
+	return newFakeClientSets(configs)
+}
+
+func newFakeClientSets(configs []string) (kubernetes.Interface, spclient.Interface, dynamic.Interface, error) {
 	objs := []runtime.Object{}
 	spObjs := []runtime.Object{}
-	for _, config := range configs {
+	dynamicObjs := []runtime.Object{}
+
+	var add func(config string) error
+	add = func(config string) error {
 		obj, err := ToRuntimeObject(config)
+		if runtime.IsNotRegisteredError(err) {
+			obj, err = toUnstructured(config)
+			if err != nil {
+				return err
+			}
+			dynamicObjs = append(dynamicObjs, obj)
+			return nil
+		}
 		if err != nil {
-			return nil, nil, err
+			return err
+		}
+
+		if list, ok := obj.(*corev1.List); ok {
+			for _, item := range list.Items {
+				if err := add(string(item.Raw)); err != nil {
+					return err
+				}
+			}
+			return nil
 		}
+
 		if strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind) == ServiceProfile {
 			spObjs = append(spObjs, obj)
 		} else {
 			objs = append(objs, obj)
 		}
+		return nil
+	}
+
+	for _, config := range configs {
+		if err := add(config); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
-	return fake.NewSimpleClientset(objs...), spfake.NewSimpleClientset(spObjs...), nil
+	return fake.NewSimpleClientset(objs...),
+		spfake.NewSimpleClientset(spObjs...),
+		dynamicfake.NewSimpleDynamicClient(scheme.Scheme, dynamicObjs...),
+		nil
 }
 
 // ToRuntimeObject deserializes Kubernetes YAML into a Runtime Object
@@ -38,3 +117,14 @@ func ToRuntimeObject(config string) (runtime.Object, error) {
 	obj, _, err := decode([]byte(config), nil, nil)
 	return obj, err
 }
+
+// toUnstructured deserializes Kubernetes YAML of any kind into an Unstructured
+// object
+func toUnstructured(config string) (runtime.Object, error) {
+	json, err := yaml.YAMLToJSON([]byte(config))
+	if err != nil {
+		return nil, err
+	}
+	obj, _, err := unstructured.UnstructuredJSONScheme.Decode(json, nil, nil)
+	return obj, err
+}
@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewFakeClientSetsFromManifests(t *testing.T) {
+	manifest := `
+###
+### Control Plane
+###
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: List
+apiVersion: v1
+items:
+- kind: ConfigMap
+  apiVersion: v1
+  metadata:
+    name: linkerd-config
+    namespace: linkerd
+---
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: linkerd-controller-api.linkerd.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - name: GET /
+    condition:
+      method: GET
+      pathRegex: /
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: trafficsplits.split.smi-spec.io
+spec:
+  group: split.smi-spec.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: trafficsplits
+    singular: trafficsplit
+    kind: TrafficSplit
+---
+apiVersion: split.smi-spec.io/v1alpha1
+kind: TrafficSplit
+metadata:
+  name: web-split
+  namespace: linkerd
+spec:
+  service: web
+  backends:
+  - service: web-v1
+    weight: 500m
+`
+
+	k8sClient, spClient, dynamicClient, err := NewFakeClientSetsFromManifests([]io.Reader{strings.NewReader(manifest)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, err := k8sClient.CoreV1().Namespaces().Get("linkerd", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected Namespace to be served, got: %s", err)
+	}
+
+	if _, err := k8sClient.CoreV1().ConfigMaps("linkerd").Get("linkerd-config", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected ConfigMap from List to be served, got: %s", err)
+	}
+
+	if _, err := spClient.LinkerdV1alpha1().ServiceProfiles("linkerd").Get("linkerd-controller-api.linkerd.svc.cluster.local", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected ServiceProfile to be served, got: %s", err)
+	}
+
+	crds := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1beta1", Resource: "customresourcedefinitions"}
+	if _, err := dynamicClient.Resource(crds).Get("trafficsplits.split.smi-spec.io", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected CustomResourceDefinition to be served, got: %s", err)
+	}
+
+	splits := schema.GroupVersionResource{Group: "split.smi-spec.io", Version: "v1alpha1", Resource: "trafficsplits"}
+	split, err := dynamicClient.Resource(splits).Namespace("linkerd").Get("web-split", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TrafficSplit to be served, got: %s", err)
+	}
+	if split.GetKind() != "TrafficSplit" {
+		t.Errorf("Expected kind TrafficSplit, got: %s", split.GetKind())
+	}
+}
+
+func TestNewFakeClientSetsFromManifestsInvalid(t *testing.T) {
+	manifest := `
+kind: Namespace
+apiVersion: v1
+metadata: [
+`
+
+	_, _, _, err := NewFakeClientSetsFromManifests([]io.Reader{strings.NewReader(manifest)})
+	if err == nil {
+		t.Fatal("Expected an error parsing an invalid manifest")
+	}
+}
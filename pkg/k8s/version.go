@@ -45,3 +45,13 @@ func isCompatibleVersion(minimalRequirementVersion [3]int, actualVersion [3]int)
 
 	return false
 }
+
+// IsVersionAtLeast returns true if the given Kubernetes version string is the
+// same as, or more recent than, the minimum version.
+func IsVersionAtLeast(versionString string, minimum [3]int) (bool, error) {
+	version, err := getK8sVersion(versionString)
+	if err != nil {
+		return false, err
+	}
+	return isCompatibleVersion(minimum, version), nil
+}
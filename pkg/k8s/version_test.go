@@ -82,3 +82,29 @@ func TestIsCompatibleVersion(t *testing.T) {
 		}
 	})
 }
+
+func TestIsVersionAtLeast(t *testing.T) {
+	minimum := [3]int{1, 16, 0}
+
+	versions := map[string]bool{
+		"v1.15.3":        false,
+		"v1.16.0":        true,
+		"v1.16.2-gke.1":  true,
+		"v1.18.0+k3s.1":  true,
+		"v0.0.0-master+": false,
+	}
+
+	for v, expected := range versions {
+		actual, err := IsVersionAtLeast(v, minimum)
+		if err != nil {
+			t.Fatalf("Error parsing string: %v", err)
+		}
+		if actual != expected {
+			t.Fatalf("Expected IsVersionAtLeast(%s) to be %t, got %t", v, expected, actual)
+		}
+	}
+
+	if _, err := IsVersionAtLeast("1.16", minimum); err == nil {
+		t.Fatal("Expected error parsing string: 1.16")
+	}
+}
@@ -0,0 +1,115 @@
+package profiles
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultSLOWindow is the window over which route metrics are aggregated
+	// when a RouteSLO does not specify one.
+	DefaultSLOWindow = "1m"
+
+	successRateAlertQuery = `sum(rate(route_response_total{%s, classification="success"}[%s])) / sum(rate(route_response_total{%s}[%s])) < %s`
+	latencyAlertQuery     = `histogram_quantile(0.99, sum(rate(route_response_latency_ms_bucket{%s}[%s])) by (le)) > %d`
+)
+
+type alertRules struct {
+	Groups []alertRuleGroup `json:"groups"`
+}
+
+type alertRuleGroup struct {
+	Name  string      `json:"name"`
+	Rules []alertRule `json:"rules"`
+}
+
+type alertRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// RenderAlertRules renders Prometheus alerting rules for the service level
+// objectives of the routes in the given ServiceProfile. Routes without an SLO
+// are skipped.
+func RenderAlertRules(profile *sp.ServiceProfile, w io.Writer) error {
+	rules, err := toAlertRules(profile)
+	if err != nil {
+		return err
+	}
+
+	output, err := yaml.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("Error writing alert rules: %s", err)
+	}
+	_, err = w.Write(output)
+	return err
+}
+
+func toAlertRules(profile *sp.ServiceProfile) (*alertRules, error) {
+	group := alertRuleGroup{
+		Name:  profile.Name,
+		Rules: []alertRule{},
+	}
+
+	for _, route := range profile.Spec.Routes {
+		slo := route.SLO
+		if slo == nil {
+			continue
+		}
+		if err := ValidateRouteSLO(slo); err != nil {
+			return nil, fmt.Errorf("ServiceProfile \"%s\" has a route with an invalid SLO: %s", profile.Name, err)
+		}
+
+		window := slo.Window
+		if window == "" {
+			window = DefaultSLOWindow
+		}
+		selector := fmt.Sprintf(`direction="inbound", dst=~%s, rt_route=%s`,
+			strconv.Quote(fmt.Sprintf(`(%s)(:\d+)?`, profile.Name)),
+			strconv.Quote(route.Name),
+		)
+		labels := map[string]string{
+			"dst":      profile.Name,
+			"rt_route": route.Name,
+		}
+
+		if slo.MinSuccessRate != nil {
+			minSuccessRate := strconv.FormatFloat(float64(*slo.MinSuccessRate), 'f', -1, 32)
+			group.Rules = append(group.Rules, alertRule{
+				Alert:  "RouteSuccessRateLow",
+				Expr:   fmt.Sprintf(successRateAlertQuery, selector, window, selector, window, minSuccessRate),
+				For:    slo.For,
+				Labels: labels,
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("Success rate of route %s on %s is below %s over %s", route.Name, profile.Name, minSuccessRate, window),
+				},
+			})
+		}
+
+		if slo.MaxLatencyP99 != "" {
+			maxLatency, err := time.ParseDuration(slo.MaxLatencyP99)
+			if err != nil {
+				return nil, err
+			}
+			group.Rules = append(group.Rules, alertRule{
+				Alert:  "RouteLatencyHigh",
+				Expr:   fmt.Sprintf(latencyAlertQuery, selector, window, maxLatency/time.Millisecond),
+				For:    slo.For,
+				Labels: labels,
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("P99 latency of route %s on %s is above %s over %s", route.Name, profile.Name, slo.MaxLatencyP99, window),
+				},
+			})
+		}
+	}
+
+	return &alertRules{Groups: []alertRuleGroup{group}}, nil
+}
@@ -0,0 +1,96 @@
+package profiles
+
+import (
+	"bytes"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderAlertRules(t *testing.T) {
+	minSuccessRate := float32(0.99)
+
+	profile := &sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-svc.emojivoto.svc.cluster.local",
+			Namespace: "emojivoto",
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name:      "GET /api/list",
+					Condition: &sp.RequestMatch{Method: "GET", PathRegex: "/api/list"},
+					SLO: &sp.RouteSLO{
+						MinSuccessRate: &minSuccessRate,
+						MaxLatencyP99:  "250ms",
+						For:            "5m",
+					},
+				},
+				{
+					Name:      "GET /api/vote",
+					Condition: &sp.RequestMatch{Method: "GET", PathRegex: "/api/vote"},
+				},
+			},
+		},
+	}
+
+	expected := `groups:
+- name: web-svc.emojivoto.svc.cluster.local
+  rules:
+  - alert: RouteSuccessRateLow
+    annotations:
+      summary: Success rate of route GET /api/list on web-svc.emojivoto.svc.cluster.local
+        is below 0.99 over 1m
+    expr: sum(rate(route_response_total{direction="inbound", dst=~"(web-svc.emojivoto.svc.cluster.local)(:\\d+)?",
+      rt_route="GET /api/list", classification="success"}[1m])) / sum(rate(route_response_total{direction="inbound",
+      dst=~"(web-svc.emojivoto.svc.cluster.local)(:\\d+)?", rt_route="GET /api/list"}[1m]))
+      < 0.99
+    for: 5m
+    labels:
+      dst: web-svc.emojivoto.svc.cluster.local
+      rt_route: GET /api/list
+  - alert: RouteLatencyHigh
+    annotations:
+      summary: P99 latency of route GET /api/list on web-svc.emojivoto.svc.cluster.local
+        is above 250ms over 1m
+    expr: histogram_quantile(0.99, sum(rate(route_response_latency_ms_bucket{direction="inbound",
+      dst=~"(web-svc.emojivoto.svc.cluster.local)(:\\d+)?", rt_route="GET /api/list"}[1m]))
+      by (le)) > 250
+    for: 5m
+    labels:
+      dst: web-svc.emojivoto.svc.cluster.local
+      rt_route: GET /api/list
+`
+
+	var buf bytes.Buffer
+	if err := RenderAlertRules(profile, &buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRenderAlertRulesInvalidSLO(t *testing.T) {
+	profile := &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-svc.emojivoto.svc.cluster.local"},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name:      "GET /api/list",
+					Condition: &sp.RequestMatch{Method: "GET"},
+					SLO:       &sp.RouteSLO{MaxLatencyP99: "fast"},
+				},
+			},
+		},
+	}
+
+	err := RenderAlertRules(profile, &bytes.Buffer{})
+	expected := "ServiceProfile \"web-svc.emojivoto.svc.cluster.local\" has a route with an invalid SLO: invalid maxLatencyP99: time: invalid duration fast"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
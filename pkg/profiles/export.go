@@ -0,0 +1,126 @@
+package profiles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Rewrite describes how ServiceProfiles are changed when they're moved
+// between clusters.
+type Rewrite struct {
+	// Namespaces maps the namespaces of the source cluster to the namespaces
+	// the profiles should be moved to.
+	Namespaces map[string]string
+
+	// ClusterDomain, when set, replaces the cluster domain in the names of the
+	// profiles.
+	ClusterDomain string
+}
+
+// RewriteProfile returns a copy of the given ServiceProfile without any of
+// the metadata set by the source cluster, moved to the namespace and cluster
+// domain given by the rewrite. Profiles whose names aren't fully-qualified
+// service names only have their namespace changed.
+func RewriteProfile(profile *sp.ServiceProfile, rewrite Rewrite) *sp.ServiceProfile {
+	namespace := profile.Namespace
+	if to, ok := rewrite.Namespaces[namespace]; ok {
+		namespace = to
+	}
+
+	name := profile.Name
+	if parts := strings.SplitN(name, ".", 3); len(parts) == 3 && strings.HasPrefix(parts[2], "svc.") {
+		service, serviceNamespace, clusterDomain := parts[0], parts[1], strings.TrimPrefix(parts[2], "svc.")
+		if to, ok := rewrite.Namespaces[serviceNamespace]; ok {
+			serviceNamespace = to
+		}
+		if rewrite.ClusterDomain != "" {
+			clusterDomain = rewrite.ClusterDomain
+		}
+		name = fmt.Sprintf("%s.%s.svc.%s", service, serviceNamespace, clusterDomain)
+	}
+
+	return &sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    profile.Labels,
+		},
+		Spec: *profile.Spec.DeepCopy(),
+	}
+}
+
+// RenderProfiles writes the given ServiceProfiles to w as a YAML stream, with
+// the rewrite applied. Profiles are sorted by namespace and name so that
+// exports of the same cluster can be diffed.
+func RenderProfiles(profiles []*sp.ServiceProfile, rewrite Rewrite, w io.Writer) error {
+	rewritten := make([]*sp.ServiceProfile, len(profiles))
+	for i, profile := range profiles {
+		rewritten[i] = RewriteProfile(profile, rewrite)
+	}
+	sort.Slice(rewritten, func(i, j int) bool {
+		if rewritten[i].Namespace != rewritten[j].Namespace {
+			return rewritten[i].Namespace < rewritten[j].Namespace
+		}
+		return rewritten[i].Name < rewritten[j].Name
+	})
+
+	for _, profile := range rewritten {
+		if _, err := w.Write([]byte("---\n")); err != nil {
+			return err
+		}
+		if err := writeProfile(*profile, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadProfiles reads and validates the ServiceProfiles in the given YAML
+// stream, applying the rewrite to each of them.
+func ReadProfiles(r io.Reader, rewrite Rewrite) ([]*sp.ServiceProfile, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(r, 4096))
+
+	profiles := []*sp.ServiceProfile{}
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var meta metav1.TypeMeta
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			return nil, err
+		}
+		if meta.Kind == "" {
+			// Skip empty documents.
+			continue
+		}
+		if meta != serviceProfileMeta {
+			return nil, fmt.Errorf("expected only %s resources, found a %s", serviceProfileMeta.Kind, meta.Kind)
+		}
+
+		if err := Validate(data); err != nil {
+			return nil, err
+		}
+
+		var profile sp.ServiceProfile
+		if err := yaml.Unmarshal(data, &profile); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, RewriteProfile(&profile, rewrite))
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,85 @@
+package profiles
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRewriteProfile(t *testing.T) {
+	profile := GenServiceProfile("books", "staging")
+	profile.ResourceVersion = "1234"
+	profile.UID = types.UID("5678")
+	profile.CreationTimestamp = metav1.Now()
+
+	t.Run("Strips cluster-specific metadata", func(t *testing.T) {
+		expected := GenServiceProfile("books", "staging")
+
+		err := ServiceProfileYamlEquals(*RewriteProfile(&profile, Rewrite{}), expected)
+		if err != nil {
+			t.Fatalf("ServiceProfiles are not equal: %v", err)
+		}
+	})
+
+	t.Run("Rewrites namespaces and cluster domains", func(t *testing.T) {
+		expected := GenServiceProfile("books", "prod")
+		expected.Name = "books.prod.svc.example.org"
+
+		rewrite := Rewrite{
+			Namespaces:    map[string]string{"staging": "prod"},
+			ClusterDomain: "example.org",
+		}
+		err := ServiceProfileYamlEquals(*RewriteProfile(&profile, rewrite), expected)
+		if err != nil {
+			t.Fatalf("ServiceProfiles are not equal: %v", err)
+		}
+	})
+
+	t.Run("Keeps the names of external service profiles", func(t *testing.T) {
+		external := GenServiceProfile("books", "staging")
+		external.Name = "api.example.com"
+
+		rewritten := RewriteProfile(&external, Rewrite{Namespaces: map[string]string{"staging": "prod"}})
+		if rewritten.Name != "api.example.com" || rewritten.Namespace != "prod" {
+			t.Fatalf("Expected api.example.com in prod, got %s in %s", rewritten.Name, rewritten.Namespace)
+		}
+	})
+}
+
+func TestRenderAndReadProfiles(t *testing.T) {
+	books := GenServiceProfile("books", "staging")
+	authors := GenServiceProfile("authors", "staging")
+
+	var buf bytes.Buffer
+	err := RenderProfiles([]*v1alpha1.ServiceProfile{&books, &authors}, Rewrite{}, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	read, err := ReadProfiles(&buf, Rewrite{Namespaces: map[string]string{"staging": "prod"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(read) != 2 {
+		t.Fatalf("Expected 2 profiles, got %d", len(read))
+	}
+
+	// Profiles are rendered sorted by name.
+	for i, service := range []string{"authors", "books"} {
+		err := ServiceProfileYamlEquals(*read[i], GenServiceProfile(service, "prod"))
+		if err != nil {
+			t.Fatalf("ServiceProfiles are not equal: %v", err)
+		}
+	}
+
+	t.Run("Rejects resources other than ServiceProfiles", func(t *testing.T) {
+		_, err := ReadProfiles(bytes.NewBufferString("apiVersion: v1\nkind: Service\nmetadata:\n  name: books\n"), Rewrite{})
+		expected := "expected only ServiceProfile resources, found a Service"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+}
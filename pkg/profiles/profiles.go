@@ -361,6 +361,12 @@ func Validate(data []byte) error {
 				return fmt.Errorf("ServiceProfile \"%s\" has a route with an invalid timeout: %s", serviceProfile.Name, err)
 			}
 		}
+		if route.SLO != nil {
+			err := ValidateRouteSLO(route.SLO)
+			if err != nil {
+				return fmt.Errorf("ServiceProfile \"%s\" has a route with an invalid SLO: %s", serviceProfile.Name, err)
+			}
+		}
 		if route.Condition == nil {
 			return fmt.Errorf("ServiceProfile \"%s\" has a route with no condition", serviceProfile.Name)
 		}
@@ -398,6 +404,34 @@ func Validate(data []byte) error {
 	return nil
 }
 
+// ValidateRouteSLO validates whether a ServiceProfile RouteSLO has at least one
+// objective set, and sanity checks its values.
+func ValidateRouteSLO(slo *sp.RouteSLO) error {
+	if slo.MinSuccessRate == nil && slo.MaxLatencyP99 == "" {
+		return errors.New("an SLO must set minSuccessRate or maxLatencyP99")
+	}
+	if slo.MinSuccessRate != nil && (*slo.MinSuccessRate < 0 || *slo.MinSuccessRate > 1) {
+		return fmt.Errorf("minSuccessRate must be between 0 and 1: %f", *slo.MinSuccessRate)
+	}
+	durations := []struct {
+		field string
+		value string
+	}{
+		{"maxLatencyP99", slo.MaxLatencyP99},
+		{"window", slo.Window},
+		{"for", slo.For},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(d.value); err != nil {
+			return fmt.Errorf("invalid %s: %s", d.field, err)
+		}
+	}
+	return nil
+}
+
 // ValidateRequestMatch validates whether a ServiceProfile RequestMatch has at
 // least one field set.
 func ValidateRequestMatch(reqMatch *sp.RequestMatch) error {
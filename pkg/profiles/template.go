@@ -73,11 +73,34 @@ spec:
       # successes or failures.
       isFailure: true
 
+    # Response classes are checked in order and the first match wins, so a
+    # class that isn't a failure can override a later one.  For example, to
+    # count every 4xx response except 404s as a failure:
+    # - condition:
+    #     status:
+    #       min: 404
+    #   isFailure: false
+    # - condition:
+    #     status:
+    #       min: 400
+    #       max: 499
+    #   isFailure: true
+
     # A route can define a request timeout.  Any requests to this route that
     # exceed the timeout will be canceled.  If unspecified, the default timeout
     # is '10s' (ten seconds).
     # timeout: 250ms
 
+    # A route can define service level objectives.  These are used by
+    # 'linkerd profile --alert-rules' to generate Prometheus alerting rules
+    # that fire when the route's success rate or p99 latency, measured over
+    # the window, violate the objective for the given duration.
+    # slo:
+    #   minSuccessRate: 0.99
+    #   maxLatencyP99: 500ms
+    #   window: 1m
+    #   for: 5m
+
   # A service profile can also define a retry budget.  This specifies the
   # maximum total number of retries that should be sent to this service as a
   # ratio of the original request volume.
@@ -0,0 +1,271 @@
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// virtualService holds the fields of an Istio VirtualService that are
+// translated to a ServiceProfile, or reported when they can't be.
+type virtualService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Hosts []string    `json:"hosts"`
+		HTTP  []httpRoute `json:"http"`
+		TCP   []struct{}  `json:"tcp"`
+		TLS   []struct{}  `json:"tls"`
+	} `json:"spec"`
+}
+
+type httpRoute struct {
+	Name     string             `json:"name"`
+	Match    []httpMatchRequest `json:"match"`
+	Route    []struct{}         `json:"route"`
+	Timeout  string             `json:"timeout"`
+	Retries  *httpRetry         `json:"retries"`
+	Redirect *struct{}          `json:"redirect"`
+	Rewrite  *struct{}          `json:"rewrite"`
+	Fault    *struct{}          `json:"fault"`
+	Mirror   *struct{}          `json:"mirror"`
+}
+
+type httpMatchRequest struct {
+	URI    *stringMatch `json:"uri"`
+	Method *stringMatch `json:"method"`
+
+	// These criteria can't be expressed by a RequestMatch.
+	Authority    *stringMatch            `json:"authority"`
+	Headers      map[string]*stringMatch `json:"headers"`
+	QueryParams  map[string]*stringMatch `json:"queryParams"`
+	Port         uint32                  `json:"port"`
+	SourceLabels map[string]string       `json:"sourceLabels"`
+	Gateways     []string                `json:"gateways"`
+}
+
+type stringMatch struct {
+	Exact  string `json:"exact"`
+	Prefix string `json:"prefix"`
+	Regex  string `json:"regex"`
+}
+
+type httpRetry struct {
+	Attempts      int    `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout"`
+	RetryOn       string `json:"retryOn"`
+}
+
+// RenderVirtualService reads an Istio VirtualService and renders the
+// ServiceProfile with its HTTP routes, timeouts and retries. The rules that
+// can't be translated are reported to warnings.
+func RenderVirtualService(fileName string, w, warnings io.Writer) error {
+	input, err := readFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	profile, untranslated, err := virtualServiceToServiceProfile(data)
+	if err != nil {
+		return err
+	}
+	for _, msg := range untranslated {
+		fmt.Fprintf(warnings, "Warning: %s\n", msg)
+	}
+
+	return writeProfile(*profile, w)
+}
+
+// virtualServiceToServiceProfile converts a VirtualService to a
+// ServiceProfile, and returns descriptions of the rules it couldn't convert.
+func virtualServiceToServiceProfile(data []byte) (*sp.ServiceProfile, []string, error) {
+	var vs virtualService
+	if err := yaml.Unmarshal(data, &vs); err != nil {
+		return nil, nil, err
+	}
+	if vs.Kind != "VirtualService" {
+		return nil, nil, fmt.Errorf("expected a VirtualService, got %q", vs.Kind)
+	}
+	if len(vs.Spec.Hosts) == 0 {
+		return nil, nil, errors.New("the VirtualService has no hosts")
+	}
+
+	untranslated := []string{}
+
+	// Short host names are relative to the namespace of the VirtualService.
+	parts := strings.Split(vs.Spec.Hosts[0], ".")
+	name, namespace := parts[0], vs.Namespace
+	if len(parts) > 1 {
+		namespace = parts[1]
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	if name == "*" {
+		return nil, nil, fmt.Errorf("the %s host doesn't name a service", vs.Spec.Hosts[0])
+	}
+	if len(vs.Spec.Hosts) > 1 {
+		untranslated = append(untranslated, fmt.Sprintf("only the first host is converted, the routes of %s need a profile each", strings.Join(vs.Spec.Hosts[1:], ", ")))
+	}
+	if len(vs.Spec.TCP) > 0 || len(vs.Spec.TLS) > 0 {
+		untranslated = append(untranslated, "TCP and TLS routes aren't supported by service profiles")
+	}
+
+	routes := []*sp.RouteSpec{}
+	for i, r := range vs.Spec.HTTP {
+		routeName := r.Name
+		if routeName == "" {
+			routeName = fmt.Sprintf("route-%d", i+1)
+		}
+
+		route, notes := toRouteSpec(routeName, r)
+		for _, note := range notes {
+			untranslated = append(untranslated, fmt.Sprintf("route %s: %s", routeName, note))
+		}
+		if route != nil {
+			routes = append(routes, route)
+		}
+	}
+
+	return &sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%s.%s", name, namespace, clusterZoneSuffix),
+			Namespace: namespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: routes,
+		},
+	}, untranslated, nil
+}
+
+// toRouteSpec converts an HTTP route of a VirtualService to a RouteSpec. The
+// matches that can't be translated are dropped, so that the route doesn't
+// apply to more requests than it did; if none is left, no RouteSpec is
+// returned.
+func toRouteSpec(name string, r httpRoute) (*sp.RouteSpec, []string) {
+	notes := []string{}
+
+	conditions := []*sp.RequestMatch{}
+	for _, match := range r.Match {
+		condition, err := toRequestMatch(match)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("dropped a match: %s", err))
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+
+	var condition *sp.RequestMatch
+	switch {
+	case len(r.Match) == 0:
+		condition = &sp.RequestMatch{PathRegex: ".*"}
+	case len(conditions) == 0:
+		return nil, append(notes, "skipped, as none of its matches could be converted")
+	case len(conditions) == 1:
+		condition = conditions[0]
+	default:
+		condition = &sp.RequestMatch{Any: conditions}
+	}
+
+	route := &sp.RouteSpec{
+		Name:      name,
+		Condition: condition,
+	}
+
+	if r.Timeout != "" {
+		if _, err := time.ParseDuration(r.Timeout); err != nil {
+			notes = append(notes, fmt.Sprintf("invalid timeout %q", r.Timeout))
+		} else {
+			route.Timeout = r.Timeout
+		}
+	}
+
+	if r.Retries != nil && r.Retries.Attempts > 0 {
+		route.IsRetryable = true
+		notes = append(notes, fmt.Sprintf("retries are bounded by the retry budget of the profile rather than %d attempts", r.Retries.Attempts))
+		if r.Retries.PerTryTimeout != "" {
+			notes = append(notes, "per-try timeouts aren't supported, the route timeout bounds all the attempts")
+		}
+		if r.Retries.RetryOn != "" {
+			notes = append(notes, fmt.Sprintf("the retry conditions %q aren't supported, failed responses are retried", r.Retries.RetryOn))
+		}
+	}
+
+	if len(r.Route) > 1 {
+		notes = append(notes, "traffic splits aren't supported by service profiles")
+	}
+	for feature, set := range map[string]bool{
+		"redirects":        r.Redirect != nil,
+		"rewrites":         r.Rewrite != nil,
+		"fault injections": r.Fault != nil,
+		"traffic mirrors":  r.Mirror != nil,
+	} {
+		if set {
+			notes = append(notes, fmt.Sprintf("%s aren't supported by service profiles", feature))
+		}
+	}
+	sort.Strings(notes)
+
+	return route, notes
+}
+
+func toRequestMatch(match httpMatchRequest) (*sp.RequestMatch, error) {
+	unsupported := []string{}
+	if match.Authority != nil {
+		unsupported = append(unsupported, "authority")
+	}
+	if len(match.Headers) > 0 {
+		unsupported = append(unsupported, "headers")
+	}
+	if len(match.QueryParams) > 0 {
+		unsupported = append(unsupported, "queryParams")
+	}
+	if match.Port != 0 {
+		unsupported = append(unsupported, "port")
+	}
+	if len(match.SourceLabels) > 0 {
+		unsupported = append(unsupported, "sourceLabels")
+	}
+	if len(match.Gateways) > 0 {
+		unsupported = append(unsupported, "gateways")
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("matching on %s isn't supported", strings.Join(unsupported, ", "))
+	}
+
+	condition := &sp.RequestMatch{PathRegex: ".*"}
+	if match.URI != nil {
+		switch {
+		case match.URI.Exact != "":
+			condition.PathRegex = regexp.QuoteMeta(match.URI.Exact)
+		case match.URI.Prefix != "":
+			condition.PathRegex = regexp.QuoteMeta(match.URI.Prefix) + ".*"
+		case match.URI.Regex != "":
+			condition.PathRegex = match.URI.Regex
+		}
+	}
+
+	if match.Method != nil {
+		if match.Method.Exact == "" {
+			return nil, errors.New("only exact method matches are supported")
+		}
+		condition.Method = strings.ToUpper(match.Method.Exact)
+	}
+
+	return condition, nil
+}
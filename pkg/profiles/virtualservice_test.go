@@ -0,0 +1,149 @@
+package profiles
+
+import (
+	"reflect"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVirtualServiceToServiceProfile(t *testing.T) {
+	vs := `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  hosts:
+  - reviews
+  - ratings.bookinfo.svc.cluster.local
+  http:
+  - name: get-reviews
+    match:
+    - uri:
+        prefix: /reviews/
+      method:
+        exact: get
+    - uri:
+        exact: /reviews
+    timeout: 2s
+    retries:
+      attempts: 3
+    route:
+    - destination:
+        host: reviews
+  - name: canary
+    match:
+    - headers:
+        end-user:
+          exact: jason
+    route:
+    - destination:
+        host: reviews
+        subset: v2
+  - match:
+    - uri:
+        regex: /api/v[0-9]+/.*
+    - queryParams:
+        debug:
+          exact: "true"
+    fault:
+      delay:
+        fixedDelay: 5s
+    route:
+    - destination:
+        host: reviews
+        subset: v1
+      weight: 90
+    - destination:
+        host: reviews
+        subset: v2
+      weight: 10
+  - route:
+    - destination:
+        host: reviews
+`
+
+	expectedProfile := sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews.bookinfo.svc.cluster.local",
+			Namespace: "bookinfo",
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name: "get-reviews",
+					Condition: &sp.RequestMatch{
+						Any: []*sp.RequestMatch{
+							{PathRegex: "/reviews/.*", Method: "GET"},
+							{PathRegex: "/reviews"},
+						},
+					},
+					Timeout:     "2s",
+					IsRetryable: true,
+				},
+				{
+					Name:      "route-3",
+					Condition: &sp.RequestMatch{PathRegex: "/api/v[0-9]+/.*"},
+				},
+				{
+					Name:      "route-4",
+					Condition: &sp.RequestMatch{PathRegex: ".*"},
+				},
+			},
+		},
+	}
+	expectedUntranslated := []string{
+		"only the first host is converted, the routes of ratings.bookinfo.svc.cluster.local need a profile each",
+		"route get-reviews: retries are bounded by the retry budget of the profile rather than 3 attempts",
+		"route canary: dropped a match: matching on headers isn't supported",
+		"route canary: skipped, as none of its matches could be converted",
+		"route route-3: dropped a match: matching on queryParams isn't supported",
+		"route route-3: fault injections aren't supported by service profiles",
+		"route route-3: traffic splits aren't supported by service profiles",
+	}
+
+	profile, untranslated, err := virtualServiceToServiceProfile([]byte(vs))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := ServiceProfileYamlEquals(*profile, expectedProfile); err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+	if !reflect.DeepEqual(untranslated, expectedUntranslated) {
+		t.Fatalf("Expected untranslated rules %v, got %v", expectedUntranslated, untranslated)
+	}
+}
+
+func TestVirtualServiceToServiceProfileErrors(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"kind: DestinationRule\n",
+			`expected a VirtualService, got "DestinationRule"`,
+		},
+		{
+			"kind: VirtualService\nspec:\n  http: []\n",
+			"the VirtualService has no hosts",
+		},
+		{
+			"kind: VirtualService\nspec:\n  hosts:\n  - '*'\n",
+			"the * host doesn't name a service",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.expected, func(t *testing.T) {
+			_, _, err := virtualServiceToServiceProfile([]byte(tc.input))
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
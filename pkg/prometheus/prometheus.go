@@ -90,12 +90,13 @@ func init() {
 	)
 }
 
-// NewGrpcServer returns a grpc server pre-configured with prometheus interceptors
-func NewGrpcServer() *grpc.Server {
-	server := grpc.NewServer(
+// NewGrpcServer returns a grpc server pre-configured with prometheus
+// interceptors, and the given options, e.g. its transport credentials
+func NewGrpcServer(opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
 		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-	)
+	}, opts...)...)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(server)
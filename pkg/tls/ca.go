@@ -58,6 +58,13 @@ type (
 	Issuer interface {
 		IssueEndEntityCrt(*x509.CertificateRequest) (Crt, error)
 	}
+
+	// LifetimeIssuer implementors sign certificate requests with a lifetime
+	// other than their default.
+	LifetimeIssuer interface {
+		Issuer
+		IssueEndEntityCrtWithLifetime(*x509.CertificateRequest, time.Duration) (Crt, error)
+	}
 )
 
 const (
@@ -86,7 +93,7 @@ func NewCA(cred Cred, validity Validity) *CA {
 
 func init() {
 	// Assert that the struct implements the interface.
-	var _ Issuer = &CA{}
+	var _ LifetimeIssuer = &CA{}
 }
 
 // CreateRootCA configures a new root CA with the given settings
@@ -184,12 +191,21 @@ func (ca *CA) GenerateEndEntityCred(dnsName string) (*Cred, error) {
 // IssueEndEntityCrt creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCrt(csr *x509.CertificateRequest) (Crt, error) {
+	return ca.IssueEndEntityCrtWithLifetime(csr, ca.Validity.Lifetime)
+}
+
+// IssueEndEntityCrtWithLifetime is like IssueEndEntityCrt but the certificate
+// is valid for the given lifetime instead of the CA's.
+func (ca *CA) IssueEndEntityCrtWithLifetime(csr *x509.CertificateRequest, lifetime time.Duration) (Crt, error) {
 	pubkey, ok := csr.PublicKey.(*ecdsa.PublicKey)
 	if !ok {
 		return Crt{}, fmt.Errorf("CSR must contain an ECDSA public key: %+v", csr.PublicKey)
 	}
 
-	t := ca.createTemplate(pubkey)
+	validity := ca.Validity
+	validity.Lifetime = lifetime
+	t := createTemplate(ca.nextSerialNumber, pubkey, validity)
+	ca.nextSerialNumber++
 	t.Issuer = ca.Cred.Crt.Certificate.Subject
 	t.Subject = csr.Subject
 	t.Extensions = csr.Extensions
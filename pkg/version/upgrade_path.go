@@ -0,0 +1,52 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const stableChannel = "stable"
+
+// UpgradePath returns the stable releases an upgrade from fromVersion to
+// toVersion has to go through, one for each minor version it would skip, e.g.
+// [stable-2.4.x] from stable-2.3.2 to stable-2.5.0. It returns no releases
+// unless both versions are stable releases of the same major version, as edge
+// releases don't have minor versions.
+func UpgradePath(fromVersion, toVersion string) []string {
+	fromMajor, fromMinor, ok := parseStableMinor(fromVersion)
+	if !ok {
+		return nil
+	}
+	toMajor, toMinor, ok := parseStableMinor(toVersion)
+	if !ok || fromMajor != toMajor {
+		return nil
+	}
+
+	path := []string{}
+	for minor := fromMinor + 1; minor < toMinor; minor++ {
+		path = append(path, fmt.Sprintf("%s-%d.%d.x", stableChannel, fromMajor, minor))
+	}
+	return path
+}
+
+func parseStableMinor(v string) (int, int, bool) {
+	cv, err := parseChannelVersion(v)
+	if err != nil || cv.channel != stableChannel {
+		return 0, 0, false
+	}
+
+	parts := strings.Split(cv.version, ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
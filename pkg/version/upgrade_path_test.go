@@ -0,0 +1,36 @@
+package version
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradePath(t *testing.T) {
+	testCases := []struct {
+		from     string
+		to       string
+		expected []string
+	}{
+		{"stable-2.3.2", "stable-2.4.0", []string{}},
+		{"stable-2.3.2", "stable-2.3.3", []string{}},
+		{"stable-2.3.2", "stable-2.5.0", []string{"stable-2.4.x"}},
+		{"stable-2.1.0", "stable-2.5.1", []string{"stable-2.2.x", "stable-2.3.x", "stable-2.4.x"}},
+		{"stable-2.5.0", "stable-2.3.0", []string{}},
+		{"stable-2.3.0", "stable-3.0.0", nil},
+		{"edge-19.4.1", "stable-2.5.0", nil},
+		{"stable-2.3.0", "edge-19.6.1", nil},
+		{"stable-2.3.0", "dev-undefined", nil},
+		{"", "stable-2.5.0", nil},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("test %d UpgradePath(%s, %s)", i, tc.from, tc.to), func(t *testing.T) {
+			path := UpgradePath(tc.from, tc.to)
+			if !reflect.DeepEqual(path, tc.expected) {
+				t.Fatalf("Expected %v, got %v", tc.expected, path)
+			}
+		})
+	}
+}
@@ -142,9 +142,15 @@ func (h *KubernetesHelper) CheckDeployment(namespace string, deploymentName stri
 // CheckPods checks that a deployment in a namespace contains the expected
 // number of pods in the Running state, and that no pods have been restarted.
 func (h *KubernetesHelper) CheckPods(namespace string, deploymentName string, replicas int) error {
+	return h.CheckPodsFor(3*time.Minute, namespace, deploymentName, replicas)
+}
+
+// CheckPodsFor is like CheckPods, waiting up to the given timeout for the pods
+// to be running.
+func (h *KubernetesHelper) CheckPodsFor(timeout time.Duration, namespace string, deploymentName string, replicas int) error {
 	var checkedPods []corev1.Pod
 
-	err := h.retryFor(3*time.Minute, func() error {
+	err := h.retryFor(timeout, func() error {
 		checkedPods = []corev1.Pod{}
 		pods, err := h.clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{})
 		if err != nil {
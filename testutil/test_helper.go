@@ -66,22 +66,34 @@ func NewTestHelper() *TestHelper {
 		log.SetLevel(log.PanicLevel)
 	}
 
+	testHelper, err := NewGenericTestHelper(*linkerd, *namespace, *k8sContext, *autoInject)
+	if err != nil {
+		exit(1, err.Error())
+	}
+	testHelper.upgradeFromVersion = *upgradeFromVersion
+
+	return testHelper
+}
+
+// NewGenericTestHelper creates a new instance of TestHelper without reading
+// command line flags, so that the integration test helpers can be reused
+// outside of `go test`, e.g. by the `linkerd alpha testkit` command.
+func NewGenericTestHelper(linkerd, namespace, k8sContext string, autoInject bool) (*TestHelper, error) {
 	testHelper := &TestHelper{
-		linkerd:            *linkerd,
-		namespace:          *namespace,
-		autoInject:         *autoInject,
-		upgradeFromVersion: *upgradeFromVersion,
+		linkerd:    linkerd,
+		namespace:  namespace,
+		autoInject: autoInject,
 	}
 
 	version, _, err := testHelper.LinkerdRun("version", "--client", "--short")
 	if err != nil {
-		exit(1, "error getting linkerd version: "+err.Error())
+		return nil, fmt.Errorf("error getting linkerd version: %s", err)
 	}
 	testHelper.version = strings.TrimSpace(version)
 
-	kubernetesHelper, err := NewKubernetesHelper(*k8sContext, testHelper.RetryFor)
+	kubernetesHelper, err := NewKubernetesHelper(k8sContext, testHelper.RetryFor)
 	if err != nil {
-		exit(1, "error creating kubernetes helper: "+err.Error())
+		return nil, fmt.Errorf("error creating kubernetes helper: %s", err)
 	}
 	testHelper.KubernetesHelper = *kubernetesHelper
 
@@ -89,7 +101,7 @@ func NewTestHelper() *TestHelper {
 		Timeout: 10 * time.Second,
 	}
 
-	return testHelper
+	return testHelper, nil
 }
 
 // GetVersion returns the version of linkerd to test. This version corresponds
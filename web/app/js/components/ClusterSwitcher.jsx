@@ -0,0 +1,91 @@
+import FormControl from '@material-ui/core/FormControl';
+import InputLabel from '@material-ui/core/InputLabel';
+import MenuItem from '@material-ui/core/MenuItem';
+import PropTypes from 'prop-types';
+import React from 'react';
+import ReactRouterPropTypes from 'react-router-prop-types';
+import Select from '@material-ui/core/Select';
+import _isEmpty from 'lodash/isEmpty';
+import { withContext } from './util/AppContext.jsx';
+import { withStyles } from '@material-ui/core/styles';
+
+const localClusterName = "local";
+
+const styles = theme => ({
+  clusterSwitcher: {
+    padding: `${theme.spacing.unit}px ${3 * theme.spacing.unit}px`,
+  },
+  select: {
+    minWidth: 20 * theme.spacing.unit,
+  },
+});
+
+// ClusterSwitcher lets the user route the dashboard's API requests to one of
+// the clusters linked to the web deployment via `-linked-clusters`.
+class ClusterSwitcher extends React.Component {
+  static defaultProps = {
+    clusterName: "",
+    history: null,
+    linkedClusters: "",
+  }
+
+  static propTypes = {
+    api: PropTypes.shape({
+      getCluster: PropTypes.func.isRequired,
+      prefixLink: PropTypes.func.isRequired,
+      setCluster: PropTypes.func.isRequired,
+    }).isRequired,
+    classes: PropTypes.shape({}).isRequired,
+    clusterName: PropTypes.string,
+    history: ReactRouterPropTypes.history,
+    linkedClusters: PropTypes.string,
+  }
+
+  constructor(props) {
+    super(props);
+    this.state = {
+      cluster: this.props.api.getCluster() || this.localName(),
+    };
+  }
+
+  localName = () => this.props.clusterName || localClusterName;
+
+  handleChange = event => {
+    let cluster = event.target.value;
+    this.props.api.setCluster(cluster === this.localName() ? "" : cluster);
+    this.setState({ cluster });
+
+    // reload the overview so every table is refreshed from the new cluster
+    if (this.props.history) {
+      this.props.history.push(this.props.api.prefixLink("/overview"));
+    }
+  }
+
+  render() {
+    const { classes, linkedClusters } = this.props;
+    if (_isEmpty(linkedClusters)) {
+      return null;
+    }
+
+    let clusters = [this.localName()].concat(linkedClusters.split(","));
+
+    return (
+      <FormControl className={classes.clusterSwitcher}>
+        <InputLabel htmlFor="cluster-switcher">Cluster</InputLabel>
+        <Select
+          value={this.state.cluster}
+          onChange={this.handleChange}
+          inputProps={{ name: "cluster", id: "cluster-switcher" }}
+          className={classes.select}>
+          {
+            clusters.map(cluster => (
+              <MenuItem key={`cluster-${cluster}`} value={cluster}>{cluster}</MenuItem>
+            ))
+          }
+        </Select>
+      </FormControl>
+    );
+  }
+}
+
+export default withContext(withStyles(styles)(ClusterSwitcher));
@@ -0,0 +1,142 @@
+import BaseTable from './BaseTable.jsx';
+import ErrorBanner from './ErrorBanner.jsx';
+import PropTypes from 'prop-types';
+import React from 'react';
+import _throttle from 'lodash/throttle';
+import { withContext } from './util/AppContext.jsx';
+import { wsCloseCodes } from './util/TapUtils.jsx';
+
+const maxEventsToDisplay = 500;
+
+const columns = [
+  {
+    title: "Time",
+    dataIndex: "timestamp",
+    sorter: (a, b) => a.timestamp.localeCompare(b.timestamp)
+  },
+  {
+    title: "Category",
+    dataIndex: "category",
+    filter: d => d.category,
+    sorter: (a, b) => a.category.localeCompare(b.category)
+  },
+  {
+    title: "Type",
+    dataIndex: "type"
+  },
+  {
+    title: "Namespace",
+    dataIndex: "namespace",
+    filter: d => d.namespace,
+    sorter: (a, b) => a.namespace.localeCompare(b.namespace)
+  },
+  {
+    title: "Object",
+    dataIndex: "object",
+    filter: d => d.object
+  },
+  {
+    title: "Reason",
+    dataIndex: "reason",
+    filter: d => d.reason
+  },
+  {
+    title: "Message",
+    dataIndex: "message",
+    filter: d => d.message
+  }
+];
+
+// Events streams the timeline of the events of the mesh, newest first.
+class Events extends React.Component {
+  static propTypes = {
+    api: PropTypes.shape({
+      withCluster: PropTypes.func.isRequired,
+    }).isRequired,
+    pathPrefix: PropTypes.string.isRequired
+  }
+
+  constructor(props) {
+    super(props);
+    this.events = [];
+    this.throttledUpdate = _throttle(this.updateEvents, 500);
+
+    this.state = {
+      events: [],
+      error: null
+    };
+  }
+
+  componentDidMount() {
+    this._isMounted = true; // https://reactjs.org/blog/2015/12/16/ismounted-antipattern.html
+
+    let protocol = window.location.protocol === "https:" ? "wss" : "ws";
+    let eventsWebSocket = `${protocol}://${window.location.host}${this.props.api.withCluster(`${this.props.pathPrefix}/api/events`)}`;
+
+    this.ws = new WebSocket(eventsWebSocket);
+    this.ws.onmessage = this.onWebsocketRecv;
+    this.ws.onclose = this.onWebsocketClose;
+    this.ws.onerror = this.onWebsocketError;
+  }
+
+  componentWillUnmount() {
+    this._isMounted = false;
+    this.throttledUpdate.cancel();
+    if (this.ws) {
+      this.ws.close(1000);
+    }
+  }
+
+  onWebsocketRecv = e => {
+    let event = JSON.parse(e.data);
+    event.key = `${event.namespace}-${event.object}-${event.reason}-${event.timestamp}`;
+    this.events = [event, ...this.events].slice(0, maxEventsToDisplay);
+    this.throttledUpdate();
+  }
+
+  onWebsocketClose = e => {
+    // See Tap.jsx for why abnormal closures are ignored.
+    if (!e.wasClean && e.code !== 1006 && this._isMounted) {
+      this.setState({
+        error: {
+          error: `Websocket close error [${e.code}: ${wsCloseCodes[e.code]}] ${e.reason ? ":" : ""} ${e.reason}`
+        }
+      });
+    }
+  }
+
+  onWebsocketError = e => {
+    if (this._isMounted) {
+      this.setState({
+        error: { error: `Websocket error: ${e.message}` }
+      });
+    }
+  }
+
+  updateEvents = () => {
+    if (this._isMounted) {
+      this.setState({ events: this.events });
+    }
+  }
+
+  render() {
+    return (
+      <div>
+        {!this.state.error ? null :
+        <ErrorBanner message={this.state.error} onHideMessage={() => this.setState({ error: null })} />}
+
+        <BaseTable
+          title="Mesh events"
+          enableFilter={true}
+          tableRows={this.state.events}
+          tableColumns={columns}
+          tableClassName="metric-table"
+          defaultOrderBy="timestamp"
+          defaultOrder="desc"
+          rowKey={e => e.key} />
+      </div>
+    );
+  }
+}
+
+export default withContext(Events);
@@ -0,0 +1,108 @@
+import Card from '@material-ui/core/Card';
+import CardContent from '@material-ui/core/CardContent';
+import Grid from '@material-ui/core/Grid';
+import PropTypes from 'prop-types';
+import React from 'react';
+import _isEmpty from 'lodash/isEmpty';
+import _isEqual from 'lodash/isEqual';
+import { withContext } from './util/AppContext.jsx';
+
+// the panels of the Grafana dashboards of resources served by
+// /api/grafana-panel, and the resource types that have them
+const panels = ["success-rate", "request-rate", "latency"];
+const panelResources = ["daemonset", "deployment", "job", "pod", "replicationcontroller", "statefulset"];
+
+// GrafanaPanels embeds the panels of the Grafana dashboard of a resource. Their
+// URLs are signed by the web server, so that the panels load even when Grafana
+// requires authentication.
+class GrafanaPanels extends React.Component {
+  static propTypes = {
+    api: PropTypes.shape({
+      fetch: PropTypes.func.isRequired,
+      getCluster: PropTypes.func.isRequired,
+      prefixedUrl: PropTypes.func.isRequired,
+    }).isRequired,
+    namespace: PropTypes.string.isRequired,
+    resourceName: PropTypes.string.isRequired,
+    resourceType: PropTypes.string.isRequired,
+  }
+
+  constructor(props) {
+    super(props);
+    this.state = { urls: {} };
+  }
+
+  componentDidMount() {
+    this.loadPanelUrls();
+  }
+
+  componentDidUpdate(prevProps) {
+    if (!_isEqual(prevProps, this.props)) {
+      this.loadPanelUrls();
+    }
+  }
+
+  componentWillUnmount() {
+    this.cancelRequests();
+  }
+
+  cancelRequests() {
+    (this.requests || []).forEach(r => r.cancel());
+  }
+
+  loadPanelUrls() {
+    const { api, namespace, resourceName, resourceType } = this.props;
+    this.cancelRequests();
+    this.setState({ urls: {} });
+    // the panels are only signed for the local cluster's Grafana
+    if (!panelResources.includes(resourceType) || !_isEmpty(api.getCluster())) {
+      return;
+    }
+
+    this.requests = panels.map(panel => api.fetch(
+      `/api/grafana-panel?resource_type=${resourceType}&namespace=${namespace}&resource_name=${resourceName}&panel=${panel}`
+    ));
+    Promise.all(this.requests.map(r => r.promise))
+      .then(responses => {
+        let urls = {};
+        panels.forEach((panel, i) => {
+          urls[panel] = responses[i].url;
+        });
+        this.setState({ urls });
+      })
+      .catch(() => {
+        // the panels are left out when Grafana isn't available
+      });
+  }
+
+  render() {
+    const { api } = this.props;
+    const { urls } = this.state;
+    if (_isEmpty(urls)) {
+      return null;
+    }
+
+    return (
+      <Grid container spacing={16}>
+        {
+          panels.map(panel => (
+            <Grid item xs={4} key={panel}>
+              <Card>
+                <CardContent>
+                  <iframe
+                    title={panel}
+                    src={api.prefixedUrl(urls[panel])}
+                    width="100%"
+                    height="200"
+                    frameBorder="0" />
+                </CardContent>
+              </Card>
+            </Grid>
+          ))
+        }
+      </Grid>
+    );
+  }
+}
+
+export default withContext(GrafanaPanels);
@@ -4,12 +4,14 @@ import ErrorBanner from './ErrorBanner.jsx';
 import MetricsTable from './MetricsTable.jsx';
 import NetworkGraph from './NetworkGraph.jsx';
 import PropTypes from 'prop-types';
+import ProxyQuotaTable from './ProxyQuotaTable.jsx';
 import React from 'react';
 import Spinner from './util/Spinner.jsx';
 import _filter from 'lodash/filter';
 import _get from 'lodash/get';
 import _isEmpty from 'lodash/isEmpty';
 import _isEqual from 'lodash/isEqual';
+import _isNil from 'lodash/isNil';
 import { friendlyTitle } from './util/Utils.js';
 import { processMultiResourceRollup } from './util/MetricUtils.jsx';
 import { withContext } from './util/AppContext.jsx';
@@ -26,7 +28,9 @@ class Namespaces extends React.Component {
   static propTypes = {
     api: PropTypes.shape({
       cancelCurrentRequests: PropTypes.func.isRequired,
+      fetch: PropTypes.func.isRequired,
       fetchMetrics: PropTypes.func.isRequired,
+      getCluster: PropTypes.func.isRequired,
       getCurrentPromises: PropTypes.func.isRequired,
       setCurrentRequests: PropTypes.func.isRequired,
       urlsForResource: PropTypes.func.isRequired,
@@ -53,6 +57,7 @@ class Namespaces extends React.Component {
       ns: ns,
       pollingInterval: 2000,
       metrics: {},
+      quota: null,
       pendingRequests: false,
       loaded: false,
       error: null
@@ -83,14 +88,20 @@ class Namespaces extends React.Component {
     }
     this.setState({ pendingRequests: true });
 
-    this.api.setCurrentRequests([this.api.fetchMetrics(this.api.urlsForResource("all", this.state.ns, true))]);
+    let requests = [this.api.fetchMetrics(this.api.urlsForResource("all", this.state.ns, true))];
+    // resource quotas are only served for the cluster the dashboard runs in
+    if (_isEmpty(this.api.getCluster())) {
+      requests.push(this.api.fetch(`/api/namespace-quota?namespace=${this.state.ns}`));
+    }
+    this.api.setCurrentRequests(requests);
 
     Promise.all(this.api.getCurrentPromises())
-      .then(([allRollup]) => {
+      .then(([allRollup, quota]) => {
         let metrics = processMultiResourceRollup(allRollup);
 
         this.setState({
           metrics: metrics,
+          quota: quota || null,
           loaded: true,
           pendingRequests: false,
           error: null
@@ -148,6 +159,13 @@ class Namespaces extends React.Component {
             {this.renderResourceSection("job", metrics.job)}
             {this.renderResourceSection("authority", metrics.authority)}
 
+            {
+              _isNil(this.state.quota) ? null :
+              <div className="page-section">
+                <ProxyQuotaTable quota={this.state.quota} />
+              </div>
+            }
+
             {
               noMetrics ? null :
               <div className="page-section">
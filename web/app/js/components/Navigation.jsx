@@ -5,6 +5,7 @@ import BreadcrumbHeader from './BreadcrumbHeader.jsx';
 import BuildIcon from '@material-ui/icons/Build';
 import ChevronLeftIcon from '@material-ui/icons/ChevronLeft';
 import CloudQueueIcon from '@material-ui/icons/CloudQueue';
+import ClusterSwitcher from './ClusterSwitcher.jsx';
 import Divider from '@material-ui/core/Divider';
 import Drawer from '@material-ui/core/Drawer';
 import EmailIcon from '@material-ui/icons/Email';
@@ -283,6 +284,8 @@ class NavigationBase extends React.Component {
 
           <Divider />
 
+          { !this.state.drawerOpen ? null : <ClusterSwitcher history={this.props.history} /> }
+
           <MenuList>
             { this.menuItem("/overview", "Overview", <HomeIcon />) }
             { this.menuItem("/tap", "Tap", <Icon className={classNames("fas fa-microscope", classes.shrinkIcon)} />) }
@@ -290,6 +293,7 @@ class NavigationBase extends React.Component {
             { this.menuItem("/routes", "Top Routes", <Icon className={classNames("fas fa-random", classes.shrinkIcon)} />) }
             { this.menuItem("/servicemesh", "Service Mesh", <CloudQueueIcon className={classes.shrinkIcon} />) }
             <NavigationResources />
+            { this.menuItem("/events", "Events", <Icon className={classNames("fas fa-history", classes.shrinkIcon)} />) }
             { this.menuItem("/debug", "Debug", <BuildIcon className={classes.shrinkIcon} />) }
           </MenuList>
 
@@ -347,6 +351,7 @@ NavigationBase.propTypes = {
   api: PropTypes.shape({}).isRequired,
   ChildComponent: PropTypes.func.isRequired,
   classes: PropTypes.shape({}).isRequired,
+  history: ReactRouterPropTypes.history,
   location: ReactRouterPropTypes.location.isRequired,
   pathPrefix: PropTypes.string.isRequired,
   releaseVersion: PropTypes.string.isRequired,
@@ -354,4 +359,8 @@ NavigationBase.propTypes = {
   uuid: PropTypes.string.isRequired,
 };
 
+NavigationBase.defaultProps = {
+  history: null,
+};
+
 export default withContext(withStyles(styles, { withTheme: true })(NavigationBase));
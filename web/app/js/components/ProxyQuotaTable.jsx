@@ -0,0 +1,129 @@
+import BaseTable from './BaseTable.jsx';
+import PropTypes from 'prop-types';
+import React from 'react';
+import _isEmpty from 'lodash/isEmpty';
+import _isNil from 'lodash/isNil';
+
+const resources = [
+  {
+    name: "CPU",
+    key: "cpuMillicores",
+    format: m => `${m}m`
+  },
+  {
+    name: "Memory",
+    key: "memoryBytes",
+    format: b => `${Math.round(b / (1024 * 1024))}Mi`
+  }
+];
+
+const formatAmount = (amount, format) => _isNil(amount) ? "---" : format(amount);
+
+const formatPercent = (part, total) => {
+  if (_isNil(part) || !total) {
+    return "---";
+  }
+  return `${Math.round(100 * part / total)}%`;
+};
+
+const columns = [
+  {
+    title: "Quota",
+    dataIndex: "quota"
+  },
+  {
+    title: "Resource",
+    dataIndex: "resource"
+  },
+  {
+    title: "Proxy Requests",
+    key: "proxy",
+    isNumeric: true,
+    render: d => formatAmount(d.proxy, d.format)
+  },
+  {
+    title: "Quota Used",
+    key: "used",
+    isNumeric: true,
+    render: d => formatAmount(d.used, d.format)
+  },
+  {
+    title: "Quota Hard",
+    key: "hard",
+    isNumeric: true,
+    render: d => formatAmount(d.hard, d.format)
+  },
+  {
+    title: "Proxy Share",
+    key: "share",
+    isNumeric: true,
+    render: d => formatPercent(d.proxy, d.hard)
+  },
+  {
+    title: "Headroom",
+    key: "headroom",
+    isNumeric: true,
+    render: d => formatPercent(d.hard - d.used, d.hard)
+  }
+];
+
+// rows returns a row for each resource limited by each of the namespace's
+// quotas. Without quotas, the proxy requests are shown on their own.
+const rows = quota => {
+  let proxy = quota.proxyRequests || {};
+
+  if (_isEmpty(quota.quotas)) {
+    return resources.map(r => ({
+      key: `none-${r.key}`,
+      quota: "none",
+      resource: r.name,
+      format: r.format,
+      proxy: proxy[r.key] || 0,
+    }));
+  }
+
+  let result = [];
+  quota.quotas.forEach(q => {
+    resources.forEach(r => {
+      if (_isNil(q.hard[r.key])) {
+        return;
+      }
+      result.push({
+        key: `${q.name}-${r.key}`,
+        quota: q.name,
+        resource: r.name,
+        format: r.format,
+        proxy: proxy[r.key] || 0,
+        used: q.used[r.key] || 0,
+        hard: q.hard[r.key],
+      });
+    });
+  });
+  return result;
+};
+
+// ProxyQuotaTable shows how much of a namespace's ResourceQuotas is taken up by
+// the CPU and memory requests of its proxy sidecars.
+const ProxyQuotaTable = ({ quota }) => (
+  <BaseTable
+    title={`Proxy resource requests (${quota.meshedPods} of ${quota.pods} pods meshed)`}
+    tableRows={rows(quota)}
+    tableColumns={columns}
+    tableClassName="metric-table"
+    rowKey={r => r.key} />
+);
+
+ProxyQuotaTable.propTypes = {
+  quota: PropTypes.shape({
+    meshedPods: PropTypes.number,
+    pods: PropTypes.number,
+    proxyRequests: PropTypes.shape({}),
+    quotas: PropTypes.arrayOf(PropTypes.shape({
+      name: PropTypes.string,
+      hard: PropTypes.shape({}),
+      used: PropTypes.shape({}),
+    })),
+  }).isRequired,
+};
+
+export default ProxyQuotaTable;
@@ -3,6 +3,7 @@ import { emptyMetric, processMultiResourceRollup, processSingleResourceRollup }
 import { resourceTypeToCamelCase, singularResource } from './util/Utils.js';
 import AddResources from './AddResources.jsx';
 import ErrorBanner from './ErrorBanner.jsx';
+import GrafanaPanels from './GrafanaPanels.jsx';
 import Grid from '@material-ui/core/Grid';
 import MetricsTable from './MetricsTable.jsx';
 import Octopus from './Octopus.jsx';
@@ -313,6 +314,12 @@ export class ResourceDetailBase extends React.Component {
           unmeshedSources={Object.values(unmeshedSources)}
           api={this.api} />
 
+        {isTcpOnly ? null : <GrafanaPanels
+          namespace={namespace}
+          resourceName={resourceName}
+          resourceType={resourceType} />
+        }
+
         {isTcpOnly ? null : <TopRoutesTabs
           query={query}
           pathPrefix={this.props.pathPrefix}
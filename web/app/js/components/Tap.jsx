@@ -24,6 +24,7 @@ class Tap extends React.Component {
   static propTypes = {
     api: PropTypes.shape({
       PrefixedLink: PropTypes.func.isRequired,
+      withCluster: PropTypes.func.isRequired,
     }).isRequired,
     autostart: PropTypes.string,
     pathPrefix: PropTypes.string.isRequired
@@ -184,7 +185,7 @@ class Tap extends React.Component {
     });
 
     let protocol = window.location.protocol === "https:" ? "wss" : "ws";
-    let tapWebSocket = `${protocol}://${window.location.host}${this.props.pathPrefix}/api/tap`;
+    let tapWebSocket = `${protocol}://${window.location.host}${this.props.api.withCluster(`${this.props.pathPrefix}/api/tap`)}`;
 
     this.ws = new WebSocket(tapWebSocket);
     this.ws.onmessage = this.onWebsocketRecv;
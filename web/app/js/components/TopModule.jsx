@@ -24,6 +24,9 @@ const WS_ABNORMAL_CLOSURE = 1006;
 
 class TopModule extends React.Component {
   static propTypes = {
+    api: PropTypes.shape({
+      withCluster: PropTypes.func.isRequired,
+    }).isRequired,
     maxRowsToDisplay: PropTypes.number,
     maxRowsToStore: PropTypes.number,
     pathPrefix: PropTypes.string.isRequired,
@@ -344,7 +347,7 @@ class TopModule extends React.Component {
     this.clearTopTable();
 
     let protocol = window.location.protocol === "https:" ? "wss" : "ws";
-    let tapWebSocket = `${protocol}://${window.location.host}${this.props.pathPrefix}/api/tap`;
+    let tapWebSocket = `${protocol}://${window.location.host}${this.props.api.withCluster(`${this.props.pathPrefix}/api/tap`)}`;
 
     this.ws = new WebSocket(tapWebSocket);
     this.ws.onmessage = this.onWebsocketRecv;
@@ -6,9 +6,12 @@ import React from 'react';
 import Spinner from './util/Spinner.jsx';
 import TopRoutesTable from './TopRoutesTable.jsx';
 import Typography from '@material-ui/core/Typography';
+import _flatten from 'lodash/flatten';
 import _get from 'lodash/get';
 import _isEmpty from 'lodash/isEmpty';
 import _sortBy from 'lodash/sortBy';
+import _tail from 'lodash/tail';
+import _uniq from 'lodash/uniq';
 import { apiErrorPropType } from './util/ApiHelpers.jsx';
 import { processTopRoutesResults } from './util/MetricUtils.jsx';
 import withREST from './util/withREST.jsx';
@@ -43,14 +46,16 @@ class TopRoutesBase extends React.Component {
   }
 
   render() {
-    const {data, loading} = this.props;
+    const {data, loading, query} = this.props;
     let results = _get(data, '[0].ok.routes', []);
     results = _sortBy(results, o => o.resource);
+    let routeConfigs = _flatten(_tail(data));
+    let namespace = query.to_namespace || query.namespace;
 
     let metricsByResource = results.map(r => {
       return {
         resource: r.resource,
-        rows: processTopRoutesResults(r.rows)
+        rows: processTopRoutesResults(r.rows, routeConfigs, namespace)
       };
     });
 
@@ -74,10 +79,29 @@ class TopRoutesBase extends React.Component {
   }
 }
 
+// the route table doesn't depend on the configurations of the routes, so
+// they're shown as missing when they can't be fetched, e.g. when the
+// dashboard isn't allowed to read the service profiles
+const fetchRouteConfigs = (api, namespace) => {
+  let request = api.fetch(`/api/route-configs?namespace=${namespace}`);
+  request.promise = request.promise.catch(e => e.isCanceled ? Promise.reject(e) : []);
+  return request;
+};
+
 export default withREST(
   TopRoutesBase,
   ({api, query}) => {
     let queryParams = new URLSearchParams(query).toString();
-    return [api.fetchMetrics(`/api/routes?${queryParams}`)];
+    let requests = [api.fetchMetrics(`/api/routes?${queryParams}`)];
+    // the service profiles are only served for the cluster the dashboard
+    // runs in; like the proxies, the profiles of the clients' namespace are
+    // looked up before the ones of the destination's namespace
+    if (_isEmpty(api.getCluster())) {
+      let namespaces = _uniq([query.namespace, query.to_namespace || query.namespace]);
+      namespaces.filter(ns => !_isEmpty(ns)).forEach(ns => {
+        requests.push(fetchRouteConfigs(api, ns));
+      });
+    }
+    return requests;
   }
 );
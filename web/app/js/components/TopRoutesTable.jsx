@@ -4,6 +4,14 @@ import React from 'react';
 import SuccessRateMiniChart from './util/SuccessRateMiniChart.jsx';
 import { metricToFormatter } from './util/Utils.js';
 
+const describeResponseClasses = responseClasses => {
+  return responseClasses.map(rc => (
+    <div key={rc.condition + rc.isFailure}>
+      {rc.isFailure ? "failure" : "success"}: {rc.condition}
+    </div>
+  ));
+};
+
 const routesColumns = [
   {
     title: "Route",
@@ -52,6 +60,25 @@ const routesColumns = [
     isNumeric: true,
     render: d => metricToFormatter["LATENCY"](d.latency.P99),
     sorter: d => d.latency.P99
+  },
+  {
+    title: "Retryable",
+    tooltip: "Whether failed requests are retried, as configured by the service profile",
+    dataIndex: "config.isRetryable",
+    render: d => !d.config ? "---" : (d.config.isRetryable ? "yes" : "no"),
+    sorter: d => !d.config ? -1 : Number(d.config.isRetryable)
+  },
+  {
+    title: "Timeout",
+    tooltip: "Timeout of the requests, as configured by the service profile",
+    dataIndex: "config.timeout",
+    render: d => !d.config ? "---" : d.config.timeout
+  },
+  {
+    title: "Response Classes",
+    tooltip: "Classification of the responses by the proxies, in order: the first matching class applies",
+    dataIndex: "config.responseClasses",
+    render: d => !d.config ? "---" : describeResponseClasses(d.config.responseClasses)
   }
 ];
 
@@ -28,7 +28,38 @@ describe("Tests for <TopRoutesTable>", () => {
     expect(table).toBeDefined();
     expect(table.html()).toContain("[DEFAULT]");
     expect(table.props().tableRows).toHaveLength(1);
-    expect(table.props().tableColumns).toHaveLength(7);
+    expect(table.props().tableColumns).toHaveLength(10);
+  });
+
+  it("renders the configuration of the route next to its metrics", () => {
+    let extraProps = _merge({}, defaultProps, {
+      rows: [{
+        route: "GET /books",
+        latency: {
+          P50: 133,
+          P95: 291,
+          P99: 188
+        },
+        authority: "books",
+        config: {
+          authority: "books.booksapp.svc.cluster.local",
+          service: "books",
+          route: "GET /books",
+          isRetryable: true,
+          timeout: "300ms",
+          responseClasses: [
+            { condition: "status 404", isFailure: false },
+            { condition: "status 500-599", isFailure: true }
+          ]
+        }
+      }],
+    });
+    const component = mount(routerWrap(TopRoutesTable, extraProps));
+
+    const table = component.find("BaseTable");
+    expect(table.html()).toContain("300ms");
+    expect(table.html()).toContain("success: status 404");
+    expect(table.html()).toContain("failure: status 500-599");
   });
 
   it("if enableFilter is true, user can filter rows by search term", () => {
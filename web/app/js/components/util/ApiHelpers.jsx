@@ -57,6 +57,7 @@ export const apiErrorPropType = PropTypes.shape({
 
 const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
   let metricsWindow = defaultMetricsWindow;
+  let cluster = "";
   const podsPath = `/api/pods`;
   const servicesPath = `/api/services`;
 
@@ -67,13 +68,22 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     "1h": "1 hour"
   };
 
+  // route api requests to the selected linked cluster, if any
+  const withCluster = path => {
+    if (_isEmpty(cluster)) {
+      return path;
+    }
+    let separator = path.indexOf("?") === -1 ? "?" : "&";
+    return `${path}${separator}cluster=${cluster}`;
+  };
+
   // for getting json api results
   const apiFetch = path => {
     if (!_isEmpty(pathPrefix)) {
       path = `${pathPrefix}${path}`;
     }
 
-    return makeCancelable(fetch(path), r => r.json());
+    return makeCancelable(fetch(withCluster(path)), r => r.json());
   };
 
   // for getting non-json results
@@ -118,6 +128,11 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     metricsWindow = window;
   };
 
+  const getCluster = () => cluster;
+  const setCluster = name => {
+    cluster = name || "";
+  };
+
   const urlsForResource = (type, namespace, includeTcp) => {
     // Traffic Performance Summary. This retrieves stats for the given resource.
     let resourceUrl = '/api/tps-reports?resource_type=' + type;
@@ -215,6 +230,9 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     setMetricsWindow,
     getValidMetricsWindows: () => Object.keys(validMetricsWindows),
     getMetricsWindowDisplayText,
+    getCluster,
+    setCluster,
+    withCluster,
     urlsForResource,
     PrefixedLink,
     prefixLink,
@@ -258,6 +258,29 @@ describe('ApiHelpers', () => {
     });
   });
 
+  describe('getCluster/setCluster', () => {
+    it('does not add a cluster param by default', () => {
+      api.fetchMetrics('/api/tps-reports');
+
+      expect(api.getCluster()).toEqual('');
+      expect(fetchStub.args[0][0]).toEqual('/api/tps-reports?window=1m');
+    });
+
+    it('adds the selected cluster to api requests', () => {
+      api.setCluster('east');
+      api.fetchMetrics('/api/tps-reports');
+
+      expect(api.getCluster()).toEqual('east');
+      expect(fetchStub.args[0][0]).toEqual('/api/tps-reports?window=1m&cluster=east');
+    });
+
+    it('adds the selected cluster to a path without params', () => {
+      api.setCluster('east');
+
+      expect(api.withCluster('/api/tap')).toEqual('/api/tap?cluster=east');
+    });
+  });
+
   describe('fetchPods', () => {
     it('fetches the pods from the api', () => {
       api = ApiHelpers("/random/prefix");
@@ -136,7 +136,31 @@ const processStatTable = table => {
 };
 
 export const DefaultRoute = "[DEFAULT]";
-export const processTopRoutesResults = rows => {
+// routeConfigs are the configurations of the routes of the service profiles,
+// as returned by /api/route-configs, for the namespace of the clients first
+// and then for the namespace of the services: like the proxies, the profile
+// of a service in the clients' namespace takes precedence over the one in the
+// service's namespace. The authority of a row is the name of the service, in
+// the given namespace, or the name of the profile itself.
+export const processTopRoutesResults = (rows, routeConfigs = [], namespace = null) => {
+  let profiles = {};
+  let addConfig = (authority, config) => {
+    let profile = profiles[authority];
+    if (!profile) {
+      profile = profiles[authority] = { namespace: config.namespace, routes: {} };
+    }
+    if (profile.namespace === config.namespace) {
+      profile.routes[config.route] = config;
+    }
+  };
+  _each(routeConfigs, config => {
+    addConfig(config.authority, config);
+    if (!_isEmpty(config.service) && config.serviceNamespace === namespace) {
+      addConfig(config.service, config);
+    }
+  });
+  let configFor = row => _get(profiles, [row.authority, "routes", row.route], null);
+
   return _map(rows, row => ({
     route: row.route || DefaultRoute,
     tooltip: !_isEmpty(row.route) ? null : "Traffic does not match any configured routes",
@@ -145,6 +169,7 @@ export const processTopRoutesResults = rows => {
     requestRate: getRequestRate(row),
     successRate: getSuccessRate(row),
     latency: getLatency(row),
+    config: configFor(row),
   }
   ));
 };
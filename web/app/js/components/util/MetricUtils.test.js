@@ -4,7 +4,8 @@ import multiResourceRollupFixtures from '../../../test/fixtures/allRollup.json';
 import Percentage from './Percentage';
 import {
   processMultiResourceRollup,
-  processSingleResourceRollup
+  processSingleResourceRollup,
+  processTopRoutesResults
 } from './MetricUtils.jsx';
 
 describe('MetricUtils', () => {
@@ -65,4 +66,42 @@ describe('MetricUtils', () => {
       expect(result["replicationcontroller"]).toBeUndefined;
     });
   });
+
+  describe('processTopRoutesResults', () => {
+    it('Adds the configuration of the routes to their metrics', () => {
+      let routeConfigs = [
+        { authority: "authors.example.com", namespace: "booksapp", route: "GET /authors", timeout: "10s" },
+        { authority: "books.booksapp.svc.cluster.local", namespace: "booksapp", service: "books", serviceNamespace: "booksapp", route: "GET /books", timeout: "300ms" }
+      ];
+      let result = processTopRoutesResults([
+        { authority: "books", route: "GET /books" },
+        { authority: "authors.example.com", route: "GET /authors" },
+        { authority: "books", route: "" }
+      ], routeConfigs, "booksapp");
+
+      expect(result).toHaveLength(3);
+      expect(result[0].config).toEqual(routeConfigs[1]);
+      expect(result[1].config).toEqual(routeConfigs[0]);
+      expect(result[2].route).toEqual("[DEFAULT]");
+      expect(result[2].config).toBeNull();
+    });
+
+    it('Prefers the profiles of the clients\' namespace', () => {
+      let routeConfigs = [
+        { authority: "books.booksapp.svc.cluster.local", namespace: "webapp", service: "books", serviceNamespace: "booksapp", route: "GET /books", timeout: "1s" },
+        { authority: "web.other.svc.cluster.local", namespace: "webapp", service: "web", serviceNamespace: "other", route: "GET /", timeout: "1s" },
+        { authority: "books.booksapp.svc.cluster.local", namespace: "booksapp", service: "books", serviceNamespace: "booksapp", route: "GET /books", timeout: "300ms" },
+        { authority: "books.booksapp.svc.cluster.local", namespace: "booksapp", service: "books", serviceNamespace: "booksapp", route: "POST /books", timeout: "300ms" }
+      ];
+      let result = processTopRoutesResults([
+        { authority: "books", route: "GET /books" },
+        { authority: "books", route: "POST /books" },
+        { authority: "web", route: "GET /" }
+      ], routeConfigs, "booksapp");
+
+      expect(result[0].config).toEqual(routeConfigs[0]);
+      expect(result[1].config).toBeNull();
+      expect(result[2].config).toBeNull();
+    });
+  });
 });
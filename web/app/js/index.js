@@ -9,6 +9,7 @@ import AppContext from './components/util/AppContext.jsx';
 import Community from './components/Community.jsx';
 import CssBaseline from '@material-ui/core/CssBaseline';
 import Debug from './components/Debug.jsx';
+import Events from './components/Events.jsx';
 import Namespace from './components/Namespace.jsx';
 import NamespaceLanding from './components/NamespaceLanding.jsx';
 import Navigation from './components/Navigation.jsx';
@@ -112,6 +113,9 @@ let applicationHtml = (
               <Route
                 path={`${pathPrefix}/authorities`}
                 render={props => <Navigation {...props} ChildComponent={ResourceList} resource="authority" />} />
+              <Route
+                path={`${pathPrefix}/events`}
+                render={props => <Navigation {...props} ChildComponent={Events} />} />
               <Route
                 path={`${pathPrefix}/debug`}
                 render={props => <Navigation {...props} ChildComponent={Debug} />} />
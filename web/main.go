@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"flag"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/flags"
@@ -21,12 +25,17 @@ import (
 func main() {
 	addr := flag.String("addr", ":8084", "address to serve on")
 	metricsAddr := flag.String("metrics-addr", ":9994", "address to serve scrapable metrics on")
+	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
 	apiAddr := flag.String("api-addr", "127.0.0.1:8085", "address of the linkerd-controller-api service")
 	grafanaAddr := flag.String("grafana-addr", "127.0.0.1:3000", "address of the linkerd-grafana service")
+	grafanaAPIKeyFile := flag.String("grafana-api-key-file", "", "path to a Grafana API key used to authenticate the Grafana panels embedded in the dashboard, when Grafana requires authentication")
 	templateDir := flag.String("template-dir", "templates", "directory to search for template files")
 	staticDir := flag.String("static-dir", "app/dist", "directory to search for static files")
 	reload := flag.Bool("reload", true, "reloading set to true or false")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	clusterName := flag.String("cluster-name", "", "name of the cluster in which the dashboard is running")
+	linkedClusters := flag.String("linked-clusters", "", "comma separated list of name=host:port addresses of the public APIs of linked clusters")
+	allowedNamespaces := flag.String("allowed-namespaces", "", "comma separated list of namespaces the dashboard is restricted to; all namespaces are allowed when empty")
 	flags.ConfigureAndParse()
 
 	_, _, err := net.SplitHostPort(*apiAddr) // Verify apiAddr is of the form host:port.
@@ -38,22 +47,67 @@ func main() {
 		log.Fatalf("failed to construct client for API server URL %s", *apiAddr)
 	}
 
+	linkedClients := map[string]public.APIClient{}
+	if *linkedClusters != "" {
+		for _, linked := range strings.Split(*linkedClusters, ",") {
+			parts := strings.SplitN(linked, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				log.Fatalf("failed to parse linked cluster, expected name=host:port: %s", linked)
+			}
+			if _, _, err := net.SplitHostPort(parts[1]); err != nil {
+				log.Fatalf("failed to parse API server address for cluster %s: %s", parts[0], parts[1])
+			}
+			linkedClients[parts[0]], err = public.NewInternalClient(*controllerNamespace, parts[1])
+			if err != nil {
+				log.Fatalf("failed to construct client for API server URL %s", parts[1])
+			}
+		}
+	}
+
+	grafanaAPIKey := ""
+	if *grafanaAPIKeyFile != "" {
+		key, err := ioutil.ReadFile(*grafanaAPIKeyFile)
+		if err != nil {
+			log.Fatalf("failed to read Grafana API key: %s", err)
+		}
+		grafanaAPIKey = strings.TrimSpace(string(key))
+	}
+
+	k8sClient, err := k8s.NewClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatalf("failed to construct Kubernetes client: %s", err)
+	}
+
+	spClient, err := k8s.NewSpClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatalf("failed to construct ServiceProfile client: %s", err)
+	}
+
 	installConfig, err := config.Install(pkgK8s.MountPathInstallConfig)
 	if err != nil {
 		log.Warnf("failed to load uuid from install config: %s", err)
 	}
 	uuid := installConfig.GetUuid()
 
+	var allowed []string
+	if *allowedNamespaces != "" {
+		allowed = strings.Split(*allowedNamespaces, ",")
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
-	server := srv.NewServer(*addr, *grafanaAddr, *templateDir, *staticDir, uuid, *controllerNamespace, *reload, client)
+	server := srv.NewServer(*addr, *grafanaAddr, grafanaAPIKey, *templateDir, *staticDir, uuid, *controllerNamespace, *clusterName, *reload, client, linkedClients, k8sClient, spClient, allowed)
 
 	go func() {
 		log.Infof("starting HTTP server on %+v", *addr)
 		server.ListenAndServe()
 	}()
 
+	// The install config is only read on startup, so the dashboard restarts
+	// to apply a new one.
+	go config.Watch(k8sClient, *controllerNamespace, "linkerd-web", config.RestartOnChange(stop, filepath.Base(pkgK8s.MountPathInstallConfig)), nil)
+
 	go admin.StartServer(*metricsAddr)
 
 	<-stop
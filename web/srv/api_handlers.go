@@ -59,7 +59,13 @@ func renderJSONPb(w http.ResponseWriter, msg proto.Message) {
 }
 
 func (h *handler) handleAPIVersion(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
-	version, err := h.apiClient.Version(req.Context(), &pb.Empty{})
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	version, err := client.Version(req.Context(), &pb.Empty{})
 
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
@@ -72,7 +78,13 @@ func (h *handler) handleAPIVersion(w http.ResponseWriter, req *http.Request, p h
 }
 
 func (h *handler) handleAPIPods(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
-	pods, err := h.apiClient.ListPods(req.Context(), &pb.ListPodsRequest{
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	pods, err := client.ListPods(req.Context(), &pb.ListPodsRequest{
 		Selector: &pb.ResourceSelection{
 			Resource: &pb.Resource{
 				Namespace: req.FormValue("namespace"),
@@ -89,7 +101,13 @@ func (h *handler) handleAPIPods(w http.ResponseWriter, req *http.Request, p http
 }
 
 func (h *handler) handleAPIServices(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
-	services, err := h.apiClient.ListServices(req.Context(), &pb.ListServicesRequest{
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	services, err := client.ListServices(req.Context(), &pb.ListServicesRequest{
 		Namespace: req.FormValue("namespace"),
 	})
 
@@ -102,6 +120,12 @@ func (h *handler) handleAPIServices(w http.ResponseWriter, req *http.Request, p
 }
 
 func (h *handler) handleAPIStat(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	trueStr := fmt.Sprintf("%t", true)
 
 	requestParams := util.StatsSummaryRequestParams{
@@ -133,7 +157,7 @@ func (h *handler) handleAPIStat(w http.ResponseWriter, req *http.Request, p http
 		return
 	}
 
-	result, err := h.apiClient.StatSummary(req.Context(), statRequest)
+	result, err := client.StatSummary(req.Context(), statRequest)
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
 		return
@@ -142,6 +166,12 @@ func (h *handler) handleAPIStat(w http.ResponseWriter, req *http.Request, p http
 }
 
 func (h *handler) handleAPITopRoutes(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	requestParams := util.TopRoutesRequestParams{
 		StatsBaseRequestParams: util.StatsBaseRequestParams{
 			TimeWindow:   req.FormValue("window"),
@@ -160,7 +190,7 @@ func (h *handler) handleAPITopRoutes(w http.ResponseWriter, req *http.Request, p
 		return
 	}
 
-	result, err := h.apiClient.TopRoutes(req.Context(), topReq)
+	result, err := client.TopRoutes(req.Context(), topReq)
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
 		return
@@ -176,6 +206,12 @@ func websocketError(ws *websocket.Conn, wsError int, msg string) {
 }
 
 func (h *handler) handleAPITap(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	ws, err := websocketUpgrader.Upgrade(w, req, nil)
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
@@ -208,7 +244,7 @@ func (h *handler) handleAPITap(w http.ResponseWriter, req *http.Request, p httpr
 	}
 
 	go func() {
-		tapClient, err := h.apiClient.TapByResource(req.Context(), tapReq)
+		tapClient, err := client.TapByResource(req.Context(), tapReq)
 		if err != nil {
 			websocketError(ws, websocket.CloseInternalServerErr, err.Error())
 			return
@@ -254,10 +290,24 @@ func (h *handler) handleAPITap(w http.ResponseWriter, req *http.Request, p httpr
 }
 
 func (h *handler) handleAPIEndpoints(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
-	result, err := h.apiClient.Endpoints(req.Context(), &discovery.EndpointsParams{})
+	client, err := h.clientFor(req)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := client.Endpoints(req.Context(), &discovery.EndpointsParams{})
 	if err != nil {
 		renderJSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 	renderJSONPb(w, result)
 }
+
+func (h *handler) handleAPIClusters(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	resp := map[string]interface{}{
+		"local":  h.clusterName,
+		"linked": h.linkedClusterNames(),
+	}
+	renderJSON(w, resp)
+}
@@ -53,3 +53,49 @@ func TestHandleApiVersion(t *testing.T) {
 		t.Errorf("Expected to find: %+v", expectedVersionJSON)
 	}
 }
+
+func TestHandleApiVersionForLinkedCluster(t *testing.T) {
+	localClient := &public.MockAPIClient{
+		VersionInfoToReturn: &pb.VersionInfo{ReleaseVersion: "local"},
+	}
+	eastClient := &public.MockAPIClient{
+		VersionInfoToReturn: &pb.VersionInfo{ReleaseVersion: "east"},
+	}
+	server := FakeServer()
+
+	handler := &handler{
+		render:        server.RenderTemplate,
+		apiClient:     localClient,
+		clusterName:   "west",
+		linkedClients: map[string]public.APIClient{"east": eastClient},
+	}
+
+	testCases := []struct {
+		query    string
+		code     int
+		expected string
+	}{
+		{"", http.StatusOK, "\"releaseVersion\":\"local\""},
+		{"?cluster=west", http.StatusOK, "\"releaseVersion\":\"local\""},
+		{"?cluster=east", http.StatusOK, "\"releaseVersion\":\"east\""},
+		{"?cluster=north", http.StatusBadRequest, "unknown cluster: north"},
+	}
+
+	for _, tc := range testCases {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/version"+tc.query, nil)
+		handler.handleAPIVersion(recorder, req, httprouter.Params{})
+
+		if recorder.Code != tc.code {
+			t.Errorf("Incorrect StatusCode for %q: %+v", tc.query, recorder.Code)
+			t.Errorf("Expected                     %+v", tc.code)
+		}
+
+		jsonResult := recorder.Body.String()
+		if !strings.Contains(jsonResult, tc.expected) {
+			t.Errorf("incorrect api result for %q", tc.query)
+			t.Errorf("Got: %+v", jsonResult)
+			t.Errorf("Expected to find: %+v", tc.expected)
+		}
+	}
+}
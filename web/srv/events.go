@@ -0,0 +1,72 @@
+package srv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/events"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultEventsSince = time.Hour
+
+// handleAPIEvents streams the events of the mesh over a websocket, starting
+// with the ones more recent than the `since` parameter. Events of namespaces
+// the dashboard isn't allowed to show are left out.
+func (h *handler) handleAPIEvents(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if cluster := req.FormValue("cluster"); cluster != "" && cluster != h.clusterName {
+		renderJSONError(w, errors.New("events are only available for the local cluster"), http.StatusBadRequest)
+		return
+	}
+	since := defaultEventsSince
+	if s := req.FormValue("since"); s != "" {
+		var err error
+		if since, err = time.ParseDuration(s); err != nil {
+			renderJSONError(w, fmt.Errorf("invalid since: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ws, err := websocketUpgrader.Upgrade(w, req, nil)
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	go func() {
+		err := events.Stream(ctx, h.k8sClient, h.controllerNamespace, time.Now().Add(-since), true, func(e *events.Event) error {
+			if !h.namespaceAllowed(e.Namespace) {
+				return nil
+			}
+			msg, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			return ws.WriteMessage(websocket.TextMessage, msg)
+		})
+		if err != nil {
+			websocketError(ws, websocket.CloseInternalServerErr, err.Error())
+		}
+	}()
+
+	for {
+		_, _, err := ws.ReadMessage()
+		if err != nil {
+			log.Debugf("Received close frame: %v", err)
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
+				log.Errorf("Unexpected close error: %s", err)
+			}
+			return
+		}
+	}
+}
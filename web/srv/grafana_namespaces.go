@@ -0,0 +1,262 @@
+package srv
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	// grafanaQueryPathRegexp matches the paths of the Prometheus queries
+	// Grafana's datasource proxy forwards, and the parameter holding them.
+	grafanaQueryPathRegexp = regexp.MustCompile(`^/grafana/api/datasources/proxy/[0-9]+/api/v1/(query|query_range|series)$`)
+
+	grafanaDatasourcePathPrefix = "/grafana/api/datasources/"
+
+	// namespaceLabels are the labels of the metrics of the proxies that hold
+	// the namespace of a resource.
+	namespaceLabels = map[string]bool{"namespace": true, "dst_namespace": true}
+
+	// namespaceNameRegexp matches the names of namespaces, which a regular
+	// expression matches literally.
+	namespaceNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	// namespaceVariable is the template variable of the namespace in the
+	// dashboards, which Grafana replaces with the selected namespaces.
+	namespaceVariable = "$namespace"
+
+	// promQLKeywords are the identifiers of PromQL that aren't metric names,
+	// when they aren't followed by parentheses, e.g. aggregations followed by
+	// by or without.
+	promQLKeywords = map[string]bool{
+		"and": true, "or": true, "unless": true, "bool": true, "offset": true,
+		"group_left": true, "group_right": true, "inf": true, "nan": true,
+		"sum": true, "min": true, "max": true, "avg": true, "count": true,
+		"stddev": true, "stdvar": true, "topk": true, "bottomk": true,
+		"count_values": true, "quantile": true,
+	}
+
+	// promQLLabelLists are the keywords of PromQL followed by a list of labels.
+	promQLLabelLists = map[string]bool{
+		"by": true, "without": true, "on": true, "ignoring": true,
+		"group_left": true, "group_right": true,
+	}
+)
+
+// grafanaNamespacesAllowed checks that a request to Grafana only reads the
+// metrics of the allowed namespaces, when the dashboard is restricted to a
+// list of namespaces. Grafana's dashboards don't restrict what they show, so
+// the Prometheus queries of its datasource are checked instead: each of their
+// selectors must select an allowed namespace. The other requests to the
+// datasource, e.g. for the values of labels, are rejected.
+func (h *handler) grafanaNamespacesAllowed(req *http.Request) error {
+	if len(h.allowedNamespaces) == 0 {
+		return nil
+	}
+
+	query := req.URL.Query()
+	for _, ns := range query["var-namespace"] {
+		if !h.namespaceAllowed(ns) {
+			return fmt.Errorf("namespace %s is not allowed", ns)
+		}
+	}
+
+	if !strings.HasPrefix(req.URL.Path, grafanaDatasourcePathPrefix) {
+		return nil
+	}
+	return datasourceQueriesAllowed(req, h.namespaceAllowed)
+}
+
+// datasourceQueriesAllowed checks that a request to Grafana's datasource is a
+// Prometheus query whose selectors each select a namespace for which allowed
+// returns true.
+func datasourceQueriesAllowed(req *http.Request, allowed func(string) bool) error {
+	match := grafanaQueryPathRegexp.FindStringSubmatch(req.URL.Path)
+	if match == nil || req.Method != http.MethodGet {
+		return fmt.Errorf("%s %s is not allowed, only the Prometheus queries of the datasource are", req.Method, req.URL.Path)
+	}
+
+	query := req.URL.Query()
+	param := "query"
+	if match[1] == "series" {
+		param = "match[]"
+	}
+	if len(query[param]) == 0 {
+		return fmt.Errorf("the %s parameter is required", param)
+	}
+	for _, q := range query[param] {
+		if err := promQLNamespacesAllowed(q, allowed); err != nil {
+			return fmt.Errorf("invalid query %q: %s", q, err)
+		}
+	}
+	return nil
+}
+
+// promQLNamespacesAllowed checks that every selector of a PromQL expression
+// selects an allowed namespace with one of namespaceLabels. Since the
+// matchers of a selector must all match, the other matchers can only narrow
+// the selection down.
+func promQLNamespacesAllowed(expr string, allowed func(string) bool) error {
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '{':
+			end, err := checkSelector(expr, i, allowed)
+			if err != nil {
+				return err
+			}
+			i = end
+
+		case c == '"' || c == '\'' || c == '`':
+			end, err := skipString(expr, i)
+			if err != nil {
+				return err
+			}
+			i = end
+
+		case c == '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return fmt.Errorf("unterminated range at %d", i)
+			}
+			i += end + 1
+
+		case isIdentifierStart(c):
+			start := i
+			for i < len(expr) && isIdentifierChar(expr[i]) {
+				i++
+			}
+			word := strings.ToLower(expr[start:i])
+			next := skipSpaces(expr, i)
+
+			if promQLLabelLists[word] && next < len(expr) && expr[next] == '(' {
+				end := strings.IndexByte(expr[next:], ')')
+				if end < 0 {
+					return fmt.Errorf("unterminated label list at %d", next)
+				}
+				i = next + end + 1
+				continue
+			}
+			if promQLKeywords[word] || (next < len(expr) && (expr[next] == '(' || expr[next] == '{')) {
+				// functions and aggregations are followed by parentheses, and
+				// the matchers of a metric's selector are checked next.
+				continue
+			}
+			return fmt.Errorf("the selector of %s must select a namespace", expr[start:i])
+
+		case c >= '0' && c <= '9' || c == '.':
+			// numbers and durations, e.g. 0.95 or 5m
+			for i < len(expr) && (isIdentifierChar(expr[i]) || expr[i] == '.') {
+				i++
+			}
+
+		default:
+			i++
+		}
+	}
+	return nil
+}
+
+// checkSelector checks the matchers of the selector starting at the given
+// brace, and returns the index following it.
+func checkSelector(expr string, start int, allowed func(string) bool) (int, error) {
+	selectsAllowed := false
+	i := start + 1
+	for {
+		i = skipSpaces(expr, i)
+		if i < len(expr) && expr[i] == ',' {
+			i = skipSpaces(expr, i+1)
+		}
+		if i >= len(expr) {
+			return 0, fmt.Errorf("unterminated selector at %d", start)
+		}
+		if expr[i] == '}' {
+			break
+		}
+
+		nameStart := i
+		for i < len(expr) && isIdentifierChar(expr[i]) {
+			i++
+		}
+		name := expr[nameStart:i]
+
+		i = skipSpaces(expr, i)
+		opStart := i
+		for i < len(expr) && strings.IndexByte("=!~", expr[i]) >= 0 {
+			i++
+		}
+		op := expr[opStart:i]
+
+		i = skipSpaces(expr, i)
+		if name == "" || op == "" || i >= len(expr) || strings.IndexByte("\"'`", expr[i]) < 0 {
+			return 0, fmt.Errorf("invalid selector at %d", start)
+		}
+		end, err := skipString(expr, i)
+		if err != nil {
+			return 0, err
+		}
+		value := expr[i+1 : end-1]
+		i = end
+
+		if namespaceLabels[name] && (op == "=" && allowed(value) || op == "=~" && regexpNamespacesAllowed(value, allowed)) {
+			selectsAllowed = true
+		}
+	}
+
+	if !selectsAllowed {
+		return 0, fmt.Errorf("the selector at %d must select an allowed namespace", start)
+	}
+	return i + 1, nil
+}
+
+// regexpNamespacesAllowed checks that a regular expression matching a
+// namespace only matches allowed namespaces: it's either the template
+// variable of the namespace, which doesn't match any namespace when Grafana
+// doesn't replace it, or an alternation of allowed namespaces, which is how
+// Grafana replaces the variable.
+func regexpNamespacesAllowed(value string, allowed func(string) bool) bool {
+	if value == namespaceVariable {
+		return true
+	}
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		value = value[1 : len(value)-1]
+	}
+	for _, ns := range strings.Split(value, "|") {
+		if !namespaceNameRegexp.MatchString(ns) || !allowed(ns) {
+			return false
+		}
+	}
+	return true
+}
+
+// skipString returns the index following the quoted string starting at the
+// given index.
+func skipString(expr string, start int) (int, error) {
+	quote := expr[start]
+	for i := start + 1; i < len(expr); i++ {
+		if expr[i] == '\\' && quote != '`' {
+			i++
+			continue
+		}
+		if expr[i] == quote {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at %d", start)
+}
+
+func skipSpaces(expr string, i int) int {
+	for i < len(expr) && strings.IndexByte(" \t\r\n", expr[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+func isIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':'
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || c >= '0' && c <= '9'
+}
@@ -0,0 +1,152 @@
+package srv
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGrafanaNamespacesAllowed(t *testing.T) {
+	h := &handler{allowedNamespaces: []string{"emojivoto", "booksapp"}}
+	queryPath := "/grafana/api/datasources/proxy/1/api/v1/query_range?query="
+
+	testCases := []struct {
+		method  string
+		path    string
+		allowed bool
+	}{
+		{"GET", "/grafana/public/build/app.js", true},
+		{"GET", "/grafana/d/abc/linkerd-deployment?var-namespace=emojivoto&var-deployment=web", true},
+		{"GET", "/grafana/d/abc/linkerd-deployment?var-namespace=kube-system&var-deployment=dns", false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace="emojivoto", deployment="web", direction="inbound"}[30s])) by (deployment)`), true},
+		{"GET", queryPath + url.QueryEscape(`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{dst_namespace="booksapp", direction="outbound"}[30s] offset 5m)) by (le, namespace))`), true},
+		{"GET", queryPath + url.QueryEscape(`sum by (namespace) (rate(request_total{namespace='emojivoto'}[1m])) / on (namespace) group_left sum(rate(request_total{namespace="booksapp"}[1m]))`), true},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace="kube-system"}[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace=~"emojivoto|kube-system"}[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace=~"emojivoto|booksapp"}[30s]))`), true},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace=~"(emojivoto|booksapp)"}[30s]))`), true},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace=~"$namespace"}[30s]))`), true},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace=~"emojivoto.*"}[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{namespace="$namespace"}[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total{deployment="web"}[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`sum(irate(response_total[30s]))`), false},
+		{"GET", queryPath + url.QueryEscape(`response_total{namespace="emojivoto"} or up`), false},
+		{"GET", queryPath + url.QueryEscape(`response_total{namespace="emojivoto"`), false},
+		{"GET", "/grafana/api/datasources/proxy/1/api/v1/query_range", false},
+		{"GET", "/grafana/api/datasources/proxy/1/api/v1/series?match[]=" + url.QueryEscape(`process_start_time_seconds{namespace="emojivoto"}`), true},
+		{"GET", "/grafana/api/datasources/proxy/1/api/v1/label/namespace/values", false},
+		{"POST", queryPath + url.QueryEscape(`up{namespace="emojivoto"}`), false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			err := h.grafanaNamespacesAllowed(req)
+			if tc.allowed && err != nil {
+				t.Fatalf("Expected the request to be allowed, got %s", err)
+			}
+			if !tc.allowed && err == nil {
+				t.Fatal("Expected the request to be rejected")
+			}
+		})
+	}
+
+	t.Run("Allows every request without an allowlist", func(t *testing.T) {
+		h := &handler{}
+		req := httptest.NewRequest("GET", "/grafana/api/datasources/proxy/1/api/v1/label/namespace/values", nil)
+		if err := h.grafanaNamespacesAllowed(req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+}
+
+func TestGrafanaDashboardQueriesAllowed(t *testing.T) {
+	allowed := func(ns string) bool { return ns == "emojivoto" || ns == "booksapp" }
+	variableRegexp := regexp.MustCompile(`\$[a-zA-Z_]+`)
+	namespaceSelectorRegexp := regexp.MustCompile(`namespace=~?"\$namespace"`)
+
+	// replace replaces the template variables of a query the way Grafana
+	// does, with the given namespaces and a placeholder for the other
+	// variables.
+	replace := func(expr, namespaces string) string {
+		return variableRegexp.ReplaceAllStringFunc(expr, func(variable string) string {
+			if variable == namespaceVariable {
+				return namespaces
+			}
+			return "web"
+		})
+	}
+
+	files, err := filepath.Glob("../../grafana/dashboards/*.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("Expected to find the dashboards")
+	}
+
+	for _, file := range files {
+		file := file // pin
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			var dashboard interface{}
+			if err := json.Unmarshal(data, &dashboard); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			for _, expr := range dashboardQueries(dashboard) {
+				if !namespaceSelectorRegexp.MatchString(expr) {
+					// The queries that don't select the namespace of a
+					// resource, e.g. those of the control plane, are only
+					// available without an allowlist.
+					continue
+				}
+
+				for _, namespaces := range []string{"emojivoto", "(emojivoto|booksapp)"} {
+					query := replace(expr, namespaces)
+					if namespaces != "emojivoto" && !strings.Contains(expr, `=~"`+namespaceVariable+`"`) {
+						// Grafana only replaces the variable with several
+						// namespaces in regular expressions.
+						continue
+					}
+					if err := promQLNamespacesAllowed(query, allowed); err != nil {
+						t.Errorf("Expected the query to be allowed, got %s: %s", err, query)
+					}
+				}
+				if err := promQLNamespacesAllowed(replace(expr, "kube-system"), allowed); err == nil {
+					t.Errorf("Expected the query of another namespace to be rejected: %s", expr)
+				}
+			}
+		})
+	}
+}
+
+// dashboardQueries returns the Prometheus queries of the panels of a
+// dashboard.
+func dashboardQueries(node interface{}) []string {
+	var queries []string
+	switch node := node.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			if expr, ok := value.(string); ok && key == "expr" {
+				queries = append(queries, expr)
+				continue
+			}
+			queries = append(queries, dashboardQueries(value)...)
+		}
+	case []interface{}:
+		for _, value := range node {
+			queries = append(queries, dashboardQueries(value)...)
+		}
+	}
+	return queries
+}
@@ -0,0 +1,230 @@
+package srv
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+const (
+	// grafanaPanelTTL is how long a signed panel URL, and the cookie set when
+	// it's loaded, authorize requests to Grafana.
+	grafanaPanelTTL = 5 * time.Minute
+
+	// grafanaPanelCookie prefixes the names of the panel cookies, which also
+	// hold the namespace and the dashboard of their panel.
+	grafanaPanelCookie = "linkerd-grafana-panel"
+	signatureParam     = "signature"
+	expiresParam       = "expires"
+)
+
+var (
+	// grafanaWindowRegexp matches the time windows understood by both the
+	// dashboard and Grafana, e.g. 10m.
+	grafanaWindowRegexp = regexp.MustCompile(`^[0-9]+[smh]$`)
+
+	// grafanaPanelPathRegexp matches the paths of the panels signed by
+	// panelURL.
+	grafanaPanelPathRegexp = regexp.MustCompile(`^/grafana/dashboard-solo/db/linkerd-[a-z]+$`)
+
+	// grafanaPanelAPIPathRegexp matches the paths of the requests an embedded
+	// panel sends to fetch the definition of its dashboard and to query its
+	// datasource.
+	grafanaPanelAPIPathRegexp = regexp.MustCompile(`^/grafana/api/(?:dashboards/db/(linkerd-[a-z]+)|datasources/proxy/[0-9]+/api/v1/query(?:_range)?)$`)
+
+	// grafanaPanelIDs are the IDs of the panels of the resource dashboards that
+	// can be embedded, by name.
+	grafanaPanelIDs = map[string]int{
+		"success-rate": 67,
+		"request-rate": 2,
+		"latency":      68,
+	}
+
+	// grafanaPanelResources are the resource types with a dashboard holding the
+	// panels of grafanaPanelIDs.
+	grafanaPanelResources = map[string]bool{
+		k8s.DaemonSet:             true,
+		k8s.Deployment:            true,
+		k8s.Job:                   true,
+		k8s.Pod:                   true,
+		k8s.ReplicationController: true,
+		k8s.StatefulSet:           true,
+	}
+)
+
+// grafanaPanelSigner signs the URLs of the Grafana panels embedded in the
+// dashboard, so that the Grafana proxy can authenticate them with Grafana's
+// API key. The API key is never sent to the browser: requests with a valid
+// signature get a short-lived cookie, as the embedded panel fetches its data
+// with further requests. Without an API key, the URLs aren't signed, as
+// Grafana is expected to allow anonymous access.
+type grafanaPanelSigner struct {
+	apiKey string
+	now    func() time.Time
+}
+
+func newGrafanaPanelSigner(apiKey string) *grafanaPanelSigner {
+	return &grafanaPanelSigner{apiKey: apiKey, now: time.Now}
+}
+
+// panelURL returns the URL of a panel of the dashboard of a resource, for the
+// given time window.
+func (s *grafanaPanelSigner) panelURL(resourceType, namespace, name, panel, window string) (string, error) {
+	id, ok := grafanaPanelIDs[panel]
+	if !ok {
+		return "", fmt.Errorf("unknown panel: %s", panel)
+	}
+	if !grafanaPanelResources[resourceType] {
+		return "", fmt.Errorf("no panels for resource type: %s", resourceType)
+	}
+
+	path := fmt.Sprintf("/grafana/dashboard-solo/db/linkerd-%s", resourceType)
+	query := url.Values{
+		"panelId":                           {strconv.Itoa(id)},
+		"var-namespace":                     {namespace},
+		fmt.Sprintf("var-%s", resourceType): {name},
+		"from":                              {fmt.Sprintf("now-%s", window)},
+		"to":                                {"now"},
+	}
+
+	if s.apiKey != "" {
+		query.Set(expiresParam, strconv.FormatInt(s.now().Add(grafanaPanelTTL).Unix(), 10))
+		query.Set(signatureParam, s.sign(path+"?"+query.Encode()))
+	}
+
+	return path + "?" + query.Encode(), nil
+}
+
+// authorize adds Grafana's API key to the GET requests of embedded panels:
+// requests for a panel with a valid signature, which are also given a panel
+// cookie for the namespace and the dashboard of the panel, and the requests
+// the panel fetches its dashboard and data with that carry a valid panel
+// cookie. The queries of the datasource must only select the namespaces of
+// the panel cookies, and the dashboards must be the ones of the cookies.
+// Other requests to Grafana, e.g. to its settings or its other dashboards,
+// are never authorized.
+func (s *grafanaPanelSigner) authorize(w http.ResponseWriter, req *http.Request) {
+	if s.apiKey == "" || req.Method != http.MethodGet {
+		return
+	}
+
+	if grafanaPanelPathRegexp.MatchString(req.URL.Path) && s.validSignature(req) {
+		name := panelCookieName(req.URL.Query().Get("var-namespace"), path.Base(req.URL.Path))
+		expires := strconv.FormatInt(s.now().Add(grafanaPanelTTL).Unix(), 10)
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    expires + "." + s.sign(name+expires),
+			Path:     "/grafana/api/",
+			MaxAge:   int(grafanaPanelTTL.Seconds()),
+			HttpOnly: true,
+		})
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+		return
+	}
+
+	match := grafanaPanelAPIPathRegexp.FindStringSubmatch(req.URL.Path)
+	if match == nil {
+		return
+	}
+	namespaces, dashboards := s.panelCookies(req)
+	if dashboard := match[1]; dashboard != "" {
+		if !dashboards[dashboard] {
+			return
+		}
+	} else if len(namespaces) == 0 || datasourceQueriesAllowed(req, func(ns string) bool { return namespaces[ns] }) != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+}
+
+// panelCookies returns the namespaces and the dashboards of the valid panel
+// cookies of a request.
+func (s *grafanaPanelSigner) panelCookies(req *http.Request) (map[string]bool, map[string]bool) {
+	namespaces := map[string]bool{}
+	dashboards := map[string]bool{}
+	for _, cookie := range req.Cookies() {
+		parts := strings.SplitN(cookie.Name, ".", 3)
+		if len(parts) != 3 || parts[0] != grafanaPanelCookie || !s.validCookie(cookie) {
+			continue
+		}
+		namespaces[parts[1]] = true
+		dashboards[parts[2]] = true
+	}
+	return namespaces, dashboards
+}
+
+func panelCookieName(namespace, dashboard string) string {
+	return fmt.Sprintf("%s.%s.%s", grafanaPanelCookie, namespace, dashboard)
+}
+
+func (s *grafanaPanelSigner) validSignature(req *http.Request) bool {
+	query := req.URL.Query()
+	signature := query.Get(signatureParam)
+	if signature == "" || s.expired(query.Get(expiresParam)) {
+		return false
+	}
+	query.Del(signatureParam)
+	return hmac.Equal([]byte(signature), []byte(s.sign(req.URL.Path+"?"+query.Encode())))
+}
+
+func (s *grafanaPanelSigner) validCookie(cookie *http.Cookie) bool {
+	parts := strings.SplitN(cookie.Value, ".", 2)
+	if len(parts) != 2 || s.expired(parts[0]) {
+		return false
+	}
+	return hmac.Equal([]byte(parts[1]), []byte(s.sign(cookie.Name+parts[0])))
+}
+
+func (s *grafanaPanelSigner) expired(expires string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	return err != nil || s.now().After(time.Unix(unix, 0))
+}
+
+func (s *grafanaPanelSigner) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(s.apiKey))
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (h *handler) handleAPIGrafanaPanel(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	resourceType := req.FormValue("resource_type")
+	namespace := req.FormValue("namespace")
+	name := req.FormValue("resource_name")
+	if resourceType == "" || namespace == "" || name == "" {
+		renderJSONError(w, errors.New("the resource_type, namespace and resource_name parameters are required"), http.StatusBadRequest)
+		return
+	}
+	if !h.namespaceAllowed(namespace) {
+		renderJSONError(w, fmt.Errorf("namespace %s is not allowed", namespace), http.StatusForbidden)
+		return
+	}
+
+	window := req.FormValue("window")
+	if window == "" {
+		window = "1h"
+	}
+	if !grafanaWindowRegexp.MatchString(window) {
+		renderJSONError(w, fmt.Errorf("invalid window: %s", window), http.StatusBadRequest)
+		return
+	}
+
+	panelURL, err := h.grafanaPanels.panelURL(resourceType, namespace, name, req.FormValue("panel"), window)
+	if err != nil {
+		renderJSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	renderJSON(w, map[string]string{"url": panelURL})
+}
@@ -0,0 +1,174 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func newTestGrafanaPanelSigner(apiKey string, now *time.Time) *grafanaPanelSigner {
+	return &grafanaPanelSigner{apiKey: apiKey, now: func() time.Time { return *now }}
+}
+
+func TestHandleAPIGrafanaPanel(t *testing.T) {
+	now := time.Unix(1560000000, 0)
+
+	testCases := []struct {
+		apiKey         string
+		query          string
+		expectedStatus int
+		expectedURL    string
+	}{
+		{
+			"",
+			"resource_type=deployment&namespace=emojivoto&resource_name=web&panel=latency",
+			http.StatusOK,
+			"/grafana/dashboard-solo/db/linkerd-deployment?from=now-1h&panelId=68&to=now&var-deployment=web&var-namespace=emojivoto",
+		},
+		{
+			"key",
+			"resource_type=pod&namespace=emojivoto&resource_name=web-0&panel=success-rate&window=10m",
+			http.StatusOK,
+			"/grafana/dashboard-solo/db/linkerd-pod?expires=1560000300&from=now-10m&panelId=67&signature=",
+		},
+		{
+			"",
+			"resource_type=authority&namespace=emojivoto&resource_name=web&panel=latency",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"",
+			"resource_type=deployment&namespace=emojivoto&resource_name=web&panel=heatmap",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"",
+			"resource_type=deployment&namespace=emojivoto&resource_name=web&panel=latency&window=1h30m",
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"",
+			"resource_type=deployment&panel=latency",
+			http.StatusBadRequest,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.query, func(t *testing.T) {
+			h := &handler{grafanaPanels: newTestGrafanaPanelSigner(tc.apiKey, &now)}
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/grafana-panel?"+tc.query, nil)
+			h.handleAPIGrafanaPanel(recorder, req, httprouter.Params{})
+
+			if recorder.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, recorder.Code, recorder.Body.String())
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp map[string]string
+			if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !strings.HasPrefix(resp["url"], tc.expectedURL) {
+				t.Errorf("Expected URL starting with %s, got %s", tc.expectedURL, resp["url"])
+			}
+		})
+	}
+}
+
+func TestGrafanaPanelSignerAuthorize(t *testing.T) {
+	now := time.Unix(1560000000, 0)
+	signer := newTestGrafanaPanelSigner("key", &now)
+
+	panelURL, err := signer.panelURL("deployment", "emojivoto", "web", "latency", "1h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	authorize := func(target string, cookies ...*http.Cookie) (string, []*http.Cookie) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", target, nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		signer.authorize(recorder, req)
+		return req.Header.Get("Authorization"), recorder.Result().Cookies()
+	}
+
+	auth, cookies := authorize(panelURL)
+	if auth != "Bearer key" {
+		t.Fatalf("Expected the signed URL to be authorized, got %q", auth)
+	}
+	if len(cookies) != 1 || cookies[0].Name != grafanaPanelCookie+".emojivoto.linkerd-deployment" {
+		t.Fatalf("Expected a panel cookie, got %v", cookies)
+	}
+
+	queryPath := "/grafana/api/datasources/proxy/1/api/v1/query_range?query="
+	emojivotoQuery := queryPath + url.QueryEscape(`sum(irate(response_total{namespace="emojivoto", deployment="web"}[30s]))`)
+	if auth, _ := authorize(emojivotoQuery, cookies[0]); auth != "Bearer key" {
+		t.Errorf("Expected a query of the namespace of the panel cookie to be authorized, got %q", auth)
+	}
+
+	for _, target := range []string{
+		queryPath + url.QueryEscape(`sum(irate(response_total{namespace="kube-system"}[30s]))`),
+		queryPath + url.QueryEscape(`sum(irate(response_total[30s]))`),
+		"/grafana/api/datasources/proxy/1/api/v1/query_range",
+	} {
+		if auth, _ := authorize(target, cookies[0]); auth != "" {
+			t.Errorf("Expected %s not to be authorized by the panel cookie of another namespace, got %q", target, auth)
+		}
+	}
+
+	if auth, _ := authorize("/grafana/api/dashboards/db/linkerd-deployment", cookies[0]); auth != "Bearer key" {
+		t.Errorf("Expected the dashboard of the panel to be authorized, got %q", auth)
+	}
+
+	for _, target := range []string{"/grafana/api/dashboards/db/linkerd-pod", "/grafana/api/users", "/grafana/api/datasources", "/grafana/dashboard/db/linkerd-deployment"} {
+		if auth, _ := authorize(target, cookies[0]); auth != "" {
+			t.Errorf("Expected %s not to be authorized by the panel cookie, got %q", target, auth)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/grafana/api/datasources/proxy/1/api/v1/query_range", nil)
+	req.AddCookie(cookies[0])
+	signer.authorize(recorder, req)
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Expected a POST not to be authorized, got %q", auth)
+	}
+
+	if auth, _ := authorize(strings.Replace(panelURL, "var-deployment=web", "var-deployment=voting", 1)); auth != "" {
+		t.Errorf("Expected a URL that doesn't match its signature not to be authorized, got %q", auth)
+	}
+
+	if auth, _ := authorize(emojivotoQuery, &http.Cookie{Name: cookies[0].Name, Value: "1560000300.forged"}); auth != "" {
+		t.Errorf("Expected a forged cookie not to be authorized, got %q", auth)
+	}
+
+	renamed := &http.Cookie{Name: grafanaPanelCookie + ".kube-system.linkerd-deployment", Value: cookies[0].Value}
+	if auth, _ := authorize(queryPath+url.QueryEscape(`up{namespace="kube-system"}`), renamed); auth != "" {
+		t.Errorf("Expected a cookie renamed to another namespace not to be authorized, got %q", auth)
+	}
+
+	now = now.Add(grafanaPanelTTL + time.Second)
+	if auth, _ := authorize(panelURL); auth != "" {
+		t.Errorf("Expected an expired URL not to be authorized, got %q", auth)
+	}
+	if auth, _ := authorize(emojivotoQuery, cookies[0]); auth != "" {
+		t.Errorf("Expected an expired cookie not to be authorized, got %q", auth)
+	}
+}
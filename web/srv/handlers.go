@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/controller/api/public"
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	profiles "github.com/linkerd/linkerd2/pkg/profiles"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 )
 
 var proxyPathRegexp = regexp.MustCompile("/api/v1/namespaces/.*/proxy/")
@@ -21,12 +25,58 @@ type (
 	handler struct {
 		render              renderTemplate
 		apiClient           public.APIClient
+		linkedClients       map[string]public.APIClient
+		k8sClient           kubernetes.Interface
+		spClient            spclient.Interface
+		allowedNamespaces   []string
+		clusterName         string
 		uuid                string
 		controllerNamespace string
 		grafanaProxy        *grafanaProxy
+		grafanaPanels       *grafanaPanelSigner
 	}
 )
 
+// namespaceAllowed returns true if the dashboard isn't restricted to a list of
+// namespaces, or if the namespace is in the list. The public API enforces the
+// same list, so this only guards the endpoints that don't go through it.
+func (h *handler) namespaceAllowed(namespace string) bool {
+	if len(h.allowedNamespaces) == 0 {
+		return true
+	}
+	for _, allowed := range h.allowedNamespaces {
+		if namespace == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// clientFor returns the public API client for the cluster named by the
+// request's `cluster` parameter, defaulting to the local cluster.
+func (h *handler) clientFor(req *http.Request) (public.APIClient, error) {
+	cluster := req.FormValue("cluster")
+	if cluster == "" || cluster == h.clusterName {
+		return h.apiClient, nil
+	}
+
+	client, ok := h.linkedClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster: %s", cluster)
+	}
+	return client, nil
+}
+
+// linkedClusterNames returns the sorted names of the linked clusters.
+func (h *handler) linkedClusterNames() []string {
+	names := make([]string, 0, len(h.linkedClients))
+	for name := range h.linkedClients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (h *handler) handleIndex(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
 	// when running the dashboard via `linkerd dashboard`, serve the index bundle at the right path
 	pathPfx := proxyPathRegexp.FindString(req.URL.Path)
@@ -37,6 +87,8 @@ func (h *handler) handleIndex(w http.ResponseWriter, req *http.Request, p httpro
 	params := appParams{
 		UUID:                h.uuid,
 		ControllerNamespace: h.controllerNamespace,
+		ClusterName:         h.clusterName,
+		LinkedClusters:      strings.Join(h.linkedClusterNames(), ","),
 		PathPrefix:          pathPfx,
 	}
 
@@ -85,5 +137,10 @@ func (h *handler) handleProfileDownload(w http.ResponseWriter, req *http.Request
 }
 
 func (h *handler) handleGrafana(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if err := h.grafanaNamespacesAllowed(req); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	h.grafanaPanels.authorize(w, req)
 	h.grafanaProxy.ServeHTTP(w, req)
 }
@@ -0,0 +1,412 @@
+package srv
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// openAPISpecPath is the route serving openAPISpec.
+const openAPISpecPath = "/api/openapi.json"
+
+// openAPISpec is the OpenAPI description of the routes in apiRoutes. Responses
+// holding public API messages are described by the name of the message, as
+// they're protobuf messages rendered with jsonpb. TestOpenAPISpec checks that
+// every API route is described.
+const openAPISpec = `{
+  "openapi": "3.0.0",
+  "info": {
+    "title": "Linkerd dashboard API",
+    "description": "The HTTP API served by the web component and used by the Linkerd dashboard.",
+    "version": "v1"
+  },
+  "paths": {
+    "/api/version": {
+      "get": {
+        "summary": "Returns the version of the control plane",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The version of the control plane",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "version": {"$ref": "#/components/schemas/VersionInfo"}
+                  }
+                }
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/tps-reports": {
+      "get": {
+        "summary": "Returns traffic metrics for resources",
+        "description": "Renamed from /api/stat to avoid triggering ad blockers.",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"$ref": "#/components/parameters/window"},
+          {"$ref": "#/components/parameters/resource_name"},
+          {"$ref": "#/components/parameters/resource_type"},
+          {"$ref": "#/components/parameters/namespace"},
+          {"name": "all_namespaces", "in": "query", "schema": {"type": "boolean"}, "description": "Return metrics for resources in all namespaces"},
+          {"$ref": "#/components/parameters/to_name"},
+          {"$ref": "#/components/parameters/to_type"},
+          {"$ref": "#/components/parameters/to_namespace"},
+          {"name": "from_name", "in": "query", "schema": {"type": "string"}, "description": "Only count requests from the resource with this name"},
+          {"name": "from_type", "in": "query", "schema": {"type": "string"}, "description": "Only count requests from resources of this type"},
+          {"name": "from_namespace", "in": "query", "schema": {"type": "string"}, "description": "Namespace of the from resource"},
+          {"name": "skip_stats", "in": "query", "schema": {"type": "boolean"}, "description": "Only return the resources, without metrics"},
+          {"name": "tcp_stats", "in": "query", "schema": {"type": "boolean"}, "description": "Include TCP connection metrics"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/StatSummaryResponse"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/pods": {
+      "get": {
+        "summary": "Lists pods",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"$ref": "#/components/parameters/namespace"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/ListPodsResponse"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/services": {
+      "get": {
+        "summary": "Lists services",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"$ref": "#/components/parameters/namespace"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/ListServicesResponse"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/tap": {
+      "get": {
+        "summary": "Streams live requests to and from a resource over a websocket",
+        "description": "The client sends a single TapRequestParams text message once the websocket is open. Each request seen is then sent as a linkerd2.public.TapEvent text message. Errors close the websocket with a close frame describing them.",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"}
+        ],
+        "requestBody": {
+          "description": "The first websocket message",
+          "content": {
+            "application/json": {
+              "schema": {"$ref": "#/components/schemas/TapRequestParams"}
+            }
+          }
+        },
+        "responses": {
+          "101": {"description": "The websocket was opened"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/routes": {
+      "get": {
+        "summary": "Returns traffic metrics for the routes of a resource",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"$ref": "#/components/parameters/window"},
+          {"$ref": "#/components/parameters/resource_name"},
+          {"$ref": "#/components/parameters/resource_type"},
+          {"$ref": "#/components/parameters/namespace"},
+          {"$ref": "#/components/parameters/to_name"},
+          {"$ref": "#/components/parameters/to_type"},
+          {"$ref": "#/components/parameters/to_namespace"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/TopRoutesResponse"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/endpoints": {
+      "get": {
+        "summary": "Returns the endpoints known to the destination service",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/EndpointsInfo"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/clusters": {
+      "get": {
+        "summary": "Lists the local cluster and the linked clusters the dashboard can query",
+        "responses": {
+          "200": {
+            "description": "The names of the clusters",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "local": {"type": "string"},
+                    "linked": {"type": "array", "items": {"type": "string"}}
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/api/namespace-quota": {
+      "get": {
+        "summary": "Returns the CPU and memory requests of the proxies in a namespace, next to its resource quotas",
+        "description": "Only available for the local cluster. Terminated pods are not counted.",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"name": "namespace", "in": "query", "required": true, "schema": {"type": "string"}, "description": "Namespace of the pods and resource quotas"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The proxy requests and resource quotas of the namespace",
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/NamespaceQuota"}
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/route-configs": {
+      "get": {
+        "summary": "Returns the configuration the service profiles of a namespace apply to their routes",
+        "description": "Only available for the local cluster. The timeouts default to 10s, and the last response class of each route classifies the responses that match none of the others.",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"name": "namespace", "in": "query", "required": true, "schema": {"type": "string"}, "description": "Namespace of the service profiles"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The routes of the service profiles, sorted by authority and route",
+            "content": {
+              "application/json": {
+                "schema": {"type": "array", "items": {"$ref": "#/components/schemas/RouteConfig"}}
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/events": {
+      "get": {
+        "summary": "Streams the events of the mesh over a websocket",
+        "description": "Only available for the local cluster. The events more recent than since are sent first, oldest first, followed by the new ones as they occur. Each event is sent as a MeshEvent text message.",
+        "parameters": [
+          {"$ref": "#/components/parameters/cluster"},
+          {"name": "since", "in": "query", "schema": {"type": "string", "default": "1h"}, "description": "Duration of the recent events to send first"}
+        ],
+        "responses": {
+          "101": {"description": "The websocket was opened"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "500": {"$ref": "#/components/responses/InternalError"}
+        }
+      }
+    },
+    "/api/grafana-panel": {
+      "get": {
+        "summary": "Returns the URL of a Grafana panel of a resource, to embed in the dashboard",
+        "description": "When the web component is given a Grafana API key, the URL is signed and authorizes the panel's requests to Grafana for a few minutes.",
+        "parameters": [
+          {"name": "resource_type", "in": "query", "required": true, "schema": {"type": "string", "enum": ["daemonset", "deployment", "job", "pod", "replicationcontroller", "statefulset"]}, "description": "Type of the resource"},
+          {"name": "namespace", "in": "query", "required": true, "schema": {"type": "string"}, "description": "Namespace of the resource"},
+          {"name": "resource_name", "in": "query", "required": true, "schema": {"type": "string"}, "description": "Name of the resource"},
+          {"name": "panel", "in": "query", "required": true, "schema": {"type": "string", "enum": ["success-rate", "request-rate", "latency"]}, "description": "Panel to embed"},
+          {"name": "window", "in": "query", "schema": {"type": "string"}, "description": "Time range of the panel, e.g. 10m (default: 1h)"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The URL of the panel",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "url": {"type": "string"}
+                  }
+                }
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "cluster": {"name": "cluster", "in": "query", "schema": {"type": "string"}, "description": "Name of the linked cluster to query (default: the local cluster)"},
+      "window": {"name": "window", "in": "query", "schema": {"type": "string"}, "description": "Time window of the metrics, e.g. 1m"},
+      "resource_name": {"name": "resource_name", "in": "query", "schema": {"type": "string"}, "description": "Name of the resource (default: all resources of the type)"},
+      "resource_type": {"name": "resource_type", "in": "query", "schema": {"type": "string"}, "description": "Type of the resource, e.g. deployment"},
+      "namespace": {"name": "namespace", "in": "query", "schema": {"type": "string"}, "description": "Namespace of the resources (default: all namespaces)"},
+      "to_name": {"name": "to_name", "in": "query", "schema": {"type": "string"}, "description": "Only count requests to the resource with this name"},
+      "to_type": {"name": "to_type", "in": "query", "schema": {"type": "string"}, "description": "Only count requests to resources of this type"},
+      "to_namespace": {"name": "to_namespace", "in": "query", "schema": {"type": "string"}, "description": "Namespace of the to resource"}
+    },
+    "responses": {
+      "BadRequest": {
+        "description": "The request is invalid, or names an unknown cluster",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      },
+      "InternalError": {
+        "description": "The public API failed to serve the request",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      },
+      "StatSummaryResponse": {
+        "description": "A linkerd2.public.StatSummaryResponse",
+        "content": {"application/json": {"schema": {"type": "object"}}}
+      },
+      "ListPodsResponse": {
+        "description": "A linkerd2.public.ListPodsResponse",
+        "content": {"application/json": {"schema": {"type": "object"}}}
+      },
+      "ListServicesResponse": {
+        "description": "A linkerd2.public.ListServicesResponse",
+        "content": {"application/json": {"schema": {"type": "object"}}}
+      },
+      "TopRoutesResponse": {
+        "description": "A linkerd2.public.TopRoutesResponse",
+        "content": {"application/json": {"schema": {"type": "object"}}}
+      },
+      "EndpointsInfo": {
+        "description": "A linkerd2.controller.discovery.EndpointsInfo",
+        "content": {"application/json": {"schema": {"type": "object"}}}
+      }
+    },
+    "schemas": {
+      "Error": {
+        "type": "object",
+        "properties": {
+          "error": {"type": "string"}
+        }
+      },
+      "VersionInfo": {
+        "type": "object",
+        "properties": {
+          "goVersion": {"type": "string"},
+          "buildDate": {"type": "string"},
+          "releaseVersion": {"type": "string"}
+        }
+      },
+      "TapRequestParams": {
+        "type": "object",
+        "properties": {
+          "resource": {"type": "string"},
+          "namespace": {"type": "string"},
+          "toResource": {"type": "string"},
+          "toNamespace": {"type": "string"},
+          "fromResource": {"type": "string"},
+          "fromNamespace": {"type": "string"},
+          "maxRps": {"type": "number"},
+          "scheme": {"type": "string"},
+          "method": {"type": "string"},
+          "authority": {"type": "string"},
+          "path": {"type": "string"}
+        }
+      },
+      "MeshEvent": {
+        "type": "object",
+        "properties": {
+          "timestamp": {"type": "string", "format": "date-time"},
+          "category": {"type": "string", "enum": ["injection", "identity", "webhook", "config", "control-plane"]},
+          "type": {"type": "string"},
+          "reason": {"type": "string"},
+          "namespace": {"type": "string"},
+          "object": {"type": "string"},
+          "message": {"type": "string"},
+          "source": {"type": "string"},
+          "count": {"type": "integer"}
+        }
+      },
+      "ResourceAmounts": {
+        "type": "object",
+        "properties": {
+          "cpuMillicores": {"type": "integer"},
+          "memoryBytes": {"type": "integer"}
+        }
+      },
+      "NamespaceQuota": {
+        "type": "object",
+        "properties": {
+          "namespace": {"type": "string"},
+          "pods": {"type": "integer"},
+          "meshedPods": {"type": "integer"},
+          "proxyRequests": {"$ref": "#/components/schemas/ResourceAmounts"},
+          "quotas": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "name": {"type": "string"},
+                "hard": {"$ref": "#/components/schemas/ResourceAmounts"},
+                "used": {"$ref": "#/components/schemas/ResourceAmounts"}
+              }
+            }
+          }
+        }
+      },
+      "RouteConfig": {
+        "type": "object",
+        "properties": {
+          "authority": {"type": "string", "description": "Name of the service profile"},
+          "namespace": {"type": "string", "description": "Namespace of the service profile"},
+          "service": {"type": "string", "description": "Name of the service of the service profile, if any"},
+          "serviceNamespace": {"type": "string", "description": "Namespace of the service of the service profile, if any"},
+          "route": {"type": "string"},
+          "isRetryable": {"type": "boolean"},
+          "timeout": {"type": "string"},
+          "responseClasses": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "condition": {"type": "string"},
+                "isFailure": {"type": "boolean"}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+func (h *handler) handleAPISpec(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(openAPISpec))
+}
@@ -0,0 +1,76 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+var openAPIRefRegexp = regexp.MustCompile(`"\$ref": "#/([^"]+)"`)
+
+func TestOpenAPISpec(t *testing.T) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(openAPISpec), &spec); err != nil {
+		t.Fatalf("Failed to parse the OpenAPI spec: %s", err)
+	}
+
+	t.Run("Describes every API route", func(t *testing.T) {
+		described := []string{}
+		for path, item := range spec["paths"].(map[string]interface{}) {
+			if _, ok := item.(map[string]interface{})["get"]; !ok {
+				t.Errorf("Expected a GET operation for %s", path)
+			}
+			described = append(described, path)
+		}
+		sort.Strings(described)
+
+		routes := []string{}
+		for _, route := range (&handler{}).apiRoutes() {
+			routes = append(routes, route.path)
+		}
+		sort.Strings(routes)
+
+		if strings.Join(described, ",") != strings.Join(routes, ",") {
+			t.Fatalf("Expected the spec to describe the API routes %v, got %v", routes, described)
+		}
+	})
+
+	t.Run("Resolves every reference", func(t *testing.T) {
+		for _, match := range openAPIRefRegexp.FindAllStringSubmatch(openAPISpec, -1) {
+			var node interface{} = spec
+			for _, key := range strings.Split(match[1], "/") {
+				object, ok := node.(map[string]interface{})
+				if !ok {
+					node = nil
+					break
+				}
+				node = object[key]
+			}
+			if node == nil {
+				t.Errorf("Unresolved reference #/%s", match[1])
+			}
+		}
+	})
+}
+
+func TestHandleAPISpec(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", openAPISpecPath, nil)
+	(&handler{}).handleAPISpec(recorder, req, httprouter.Params{})
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("Expected Content-Type application/json, got %s", contentType)
+	}
+	if recorder.Body.String() != openAPISpec {
+		t.Fatalf("Expected the OpenAPI spec, got:\n%s", recorder.Body.String())
+	}
+}
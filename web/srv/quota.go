@@ -0,0 +1,123 @@
+package srv
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// namespaceQuota holds the resources requested by the proxy sidecars in a
+	// namespace, next to the namespace's ResourceQuotas.
+	namespaceQuota struct {
+		Namespace     string          `json:"namespace"`
+		Pods          int             `json:"pods"`
+		MeshedPods    int             `json:"meshedPods"`
+		ProxyRequests resourceAmounts `json:"proxyRequests"`
+		Quotas        []quotaUsage    `json:"quotas"`
+	}
+
+	// quotaUsage holds the hard limit and the usage of the CPU and memory
+	// requests of a ResourceQuota. Resources the quota doesn't limit are
+	// empty.
+	quotaUsage struct {
+		Name string          `json:"name"`
+		Hard resourceAmounts `json:"hard"`
+		Used resourceAmounts `json:"used"`
+	}
+
+	resourceAmounts struct {
+		CPUMillicores int64 `json:"cpuMillicores,omitempty"`
+		MemoryBytes   int64 `json:"memoryBytes,omitempty"`
+	}
+)
+
+func (h *handler) handleAPINamespaceQuota(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	namespace := req.FormValue("namespace")
+	if namespace == "" {
+		renderJSONError(w, errors.New("the namespace parameter is required"), http.StatusBadRequest)
+		return
+	}
+	if !h.namespaceAllowed(namespace) {
+		renderJSONError(w, fmt.Errorf("namespace %s is not allowed", namespace), http.StatusForbidden)
+		return
+	}
+	if cluster := req.FormValue("cluster"); cluster != "" && cluster != h.clusterName {
+		renderJSONError(w, errors.New("resource quotas are only available for the local cluster"), http.StatusBadRequest)
+		return
+	}
+
+	pods, err := h.k8sClient.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	quotas, err := h.k8sClient.CoreV1().ResourceQuotas(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	renderJSON(w, buildNamespaceQuota(namespace, pods.Items, quotas.Items))
+}
+
+// buildNamespaceQuota sums the CPU and memory requests of the proxy
+// containers of the running and pending pods in a namespace. Terminated pods
+// don't count against a ResourceQuota, so they're skipped.
+func buildNamespaceQuota(namespace string, pods []corev1.Pod, quotas []corev1.ResourceQuota) namespaceQuota {
+	nsQuota := namespaceQuota{
+		Namespace: namespace,
+		Quotas:    []quotaUsage{},
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		nsQuota.Pods++
+
+		for _, container := range pod.Spec.Containers {
+			if container.Name != k8s.ProxyContainerName {
+				continue
+			}
+			nsQuota.MeshedPods++
+			nsQuota.ProxyRequests.add(container.Resources.Requests)
+		}
+	}
+
+	for _, quota := range quotas {
+		usage := quotaUsage{Name: quota.Name}
+		usage.Hard.add(quota.Status.Hard)
+		usage.Used.add(quota.Status.Used)
+		nsQuota.Quotas = append(nsQuota.Quotas, usage)
+	}
+
+	return nsQuota
+}
+
+// add adds the CPU and memory requests in resources. A ResourceQuota may
+// limit requests either as `requests.cpu` or as `cpu`, which are equivalent.
+func (r *resourceAmounts) add(resources corev1.ResourceList) {
+	if cpu, ok := requested(resources, corev1.ResourceRequestsCPU, corev1.ResourceCPU); ok {
+		r.CPUMillicores += cpu.MilliValue()
+	}
+	if memory, ok := requested(resources, corev1.ResourceRequestsMemory, corev1.ResourceMemory); ok {
+		r.MemoryBytes += memory.Value()
+	}
+}
+
+func requested(resources corev1.ResourceList, names ...corev1.ResourceName) (resource.Quantity, bool) {
+	for _, name := range names {
+		if quantity, ok := resources[name]; ok {
+			return quantity, true
+		}
+	}
+	return resource.Quantity{}, false
+}
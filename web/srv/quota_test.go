@@ -0,0 +1,178 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestHandleAPINamespaceQuota(t *testing.T) {
+	k8sConfigs := []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: meshed
+  namespace: emojivoto
+spec:
+  containers:
+  - name: web
+    resources:
+      requests:
+        cpu: 500m
+        memory: 256Mi
+  - name: linkerd-proxy
+    resources:
+      requests:
+        cpu: 100m
+        memory: 20Mi
+status:
+  phase: Running`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: meshed-pending
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+    resources:
+      requests:
+        cpu: 100m
+        memory: 20Mi
+status:
+  phase: Pending`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: meshed-completed
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+    resources:
+      requests:
+        cpu: 100m
+        memory: 20Mi
+status:
+  phase: Succeeded`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: unmeshed
+  namespace: emojivoto
+spec:
+  containers:
+  - name: web
+status:
+  phase: Running`,
+		`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: other-namespace
+  namespace: books
+spec:
+  containers:
+  - name: linkerd-proxy
+    resources:
+      requests:
+        cpu: 100m
+status:
+  phase: Running`,
+		`
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: compute
+  namespace: emojivoto
+status:
+  hard:
+    requests.cpu: "2"
+    memory: 1Gi
+  used:
+    requests.cpu: 700m
+    memory: 296Mi`,
+	}
+
+	k8sClient, _, err := k8s.NewFakeClientSets(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	t.Run("Sums the proxy requests and returns the quotas of the namespace", func(t *testing.T) {
+		h := &handler{k8sClient: k8sClient}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/namespace-quota?namespace=emojivoto", nil)
+		h.handleAPINamespaceQuota(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+		}
+
+		var actual namespaceQuota
+		if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := namespaceQuota{
+			Namespace:  "emojivoto",
+			Pods:       3,
+			MeshedPods: 2,
+			ProxyRequests: resourceAmounts{
+				CPUMillicores: 200,
+				MemoryBytes:   40 * 1024 * 1024,
+			},
+			Quotas: []quotaUsage{
+				{
+					Name: "compute",
+					Hard: resourceAmounts{CPUMillicores: 2000, MemoryBytes: 1024 * 1024 * 1024},
+					Used: resourceAmounts{CPUMillicores: 700, MemoryBytes: 296 * 1024 * 1024},
+				},
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, actual)
+		}
+	})
+
+	t.Run("Requires a namespace", func(t *testing.T) {
+		h := &handler{k8sClient: k8sClient}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/namespace-quota", nil)
+		h.handleAPINamespaceQuota(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("Rejects linked clusters", func(t *testing.T) {
+		h := &handler{k8sClient: k8sClient, clusterName: "east"}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/namespace-quota?namespace=emojivoto&cluster=west", nil)
+		h.handleAPINamespaceQuota(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("Rejects namespaces that aren't allowed", func(t *testing.T) {
+		h := &handler{k8sClient: k8sClient, allowedNamespaces: []string{"booksapp"}}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/namespace-quota?namespace=emojivoto", nil)
+		h.handleAPINamespaceQuota(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("Expected status code %d, got %d", http.StatusForbidden, recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package srv
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// routeConfig holds the configuration a ServiceProfile applies to one of
+	// its routes, as the proxies apply it: routes without a timeout get the
+	// default one, and responses that match none of the response classes are
+	// classified by defaultFailureClass.
+	routeConfig struct {
+		Authority        string                `json:"authority"`
+		Namespace        string                `json:"namespace"`
+		Service          string                `json:"service,omitempty"`
+		ServiceNamespace string                `json:"serviceNamespace,omitempty"`
+		Route            string                `json:"route"`
+		IsRetryable      bool                  `json:"isRetryable"`
+		Timeout          string                `json:"timeout"`
+		ResponseClasses  []responseClassConfig `json:"responseClasses"`
+	}
+
+	// responseClassConfig holds a response class in the order the proxies
+	// evaluate them, with its condition rendered for display.
+	responseClassConfig struct {
+		Condition string `json:"condition"`
+		IsFailure bool   `json:"isFailure"`
+	}
+)
+
+// defaultFailureClass classifies the responses that match none of the
+// response classes of a route.
+var defaultFailureClass = responseClassConfig{Condition: "status 500-599", IsFailure: true}
+
+func (h *handler) handleAPIRouteConfigs(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	namespace := req.FormValue("namespace")
+	if namespace == "" {
+		renderJSONError(w, errors.New("the namespace parameter is required"), http.StatusBadRequest)
+		return
+	}
+	if !h.namespaceAllowed(namespace) {
+		renderJSONError(w, fmt.Errorf("namespace %s is not allowed", namespace), http.StatusForbidden)
+		return
+	}
+	if cluster := req.FormValue("cluster"); cluster != "" && cluster != h.clusterName {
+		renderJSONError(w, errors.New("route configurations are only available for the local cluster"), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.spClient.LinkerdV1alpha1().ServiceProfiles(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		renderJSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	renderJSON(w, buildRouteConfigs(list.Items))
+}
+
+// buildRouteConfigs returns the configuration of the routes of the given
+// ServiceProfiles, sorted by authority and route. The profiles of services
+// are named after the FQDN of the service, so their routes also carry the
+// name of the service, which is the authority of the rows of the route table,
+// and its namespace, which differs from the profile's when the profile is
+// in the namespace of the service's clients.
+func buildRouteConfigs(serviceProfiles []sp.ServiceProfile) []routeConfig {
+	configs := []routeConfig{}
+	for _, profile := range serviceProfiles {
+		service, serviceNamespace := "", ""
+		if parts := strings.SplitN(profile.Name, ".", 4); len(parts) == 4 && parts[2] == "svc" {
+			service, serviceNamespace = parts[0], parts[1]
+		}
+
+		for _, route := range profile.Spec.Routes {
+			timeout := profiles.DefaultRouteTimeout
+			if route.Timeout != "" {
+				// Invalid timeouts are rejected by the validating webhook, and
+				// replaced with the default one by the destination service.
+				if d, err := time.ParseDuration(route.Timeout); err == nil {
+					timeout = d
+				}
+			}
+
+			classes := []responseClassConfig{}
+			for _, rc := range route.ResponseClasses {
+				classes = append(classes, responseClassConfig{
+					Condition: describeResponseMatch(rc.Condition),
+					IsFailure: rc.IsFailure,
+				})
+			}
+			classes = append(classes, defaultFailureClass)
+
+			configs = append(configs, routeConfig{
+				Authority:        profile.Name,
+				Namespace:        profile.Namespace,
+				Service:          service,
+				ServiceNamespace: serviceNamespace,
+				Route:            route.Name,
+				IsRetryable:      route.IsRetryable,
+				Timeout:          timeout.String(),
+				ResponseClasses:  classes,
+			})
+		}
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Authority != configs[j].Authority {
+			return configs[i].Authority < configs[j].Authority
+		}
+		return configs[i].Route < configs[j].Route
+	})
+	return configs
+}
+
+// describeResponseMatch renders a response match as a condition on the status
+// of the response, e.g. "status 500-599 and not status 503". The fields of a
+// match must all match.
+func describeResponseMatch(match *sp.ResponseMatch) string {
+	if match == nil {
+		return ""
+	}
+
+	conditions := []string{}
+	if match.Status != nil {
+		min, max := match.Status.Min, match.Status.Max
+		if min == 0 {
+			min = 100
+		}
+		if max == 0 {
+			max = 599
+		}
+		if min == max {
+			conditions = append(conditions, fmt.Sprintf("status %d", min))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("status %d-%d", min, max))
+		}
+	}
+	if match.Not != nil {
+		conditions = append(conditions, fmt.Sprintf("not %s", describeResponseMatches([]*sp.ResponseMatch{match.Not}, "")))
+	}
+	if len(match.All) > 0 {
+		conditions = append(conditions, describeResponseMatches(match.All, " and "))
+	}
+	if len(match.Any) > 0 {
+		conditions = append(conditions, describeResponseMatches(match.Any, " or "))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// describeResponseMatches joins the conditions of nested matches, grouping
+// them in parentheses unless there's a single one made of a single field.
+func describeResponseMatches(matches []*sp.ResponseMatch, sep string) string {
+	conditions := make([]string, len(matches))
+	for i, m := range matches {
+		conditions[i] = describeResponseMatch(m)
+	}
+	joined := strings.Join(conditions, sep)
+	if len(conditions) == 1 && !strings.Contains(joined, " and ") && !strings.Contains(joined, " or ") {
+		return joined
+	}
+	return fmt.Sprintf("(%s)", joined)
+}
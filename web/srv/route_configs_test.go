@@ -0,0 +1,204 @@
+package srv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestHandleAPIRouteConfigs(t *testing.T) {
+	k8sConfigs := []string{`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books
+    isRetryable: true
+    timeout: 300ms
+    responseClasses:
+    - condition:
+        all:
+        - status:
+            min: 500
+        - not:
+            status:
+              min: 503
+              max: 503
+      isFailure: true
+    - condition:
+        any:
+        - status:
+            min: 404
+            max: 404
+        - status:
+            min: 409
+            max: 409
+  - name: POST /books
+    condition:
+      method: POST
+      pathRegex: /books`,
+		`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: authors.example.com
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /authors
+    condition:
+      method: GET`,
+		`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: web.emojivoto.svc.cluster.local
+  namespace: emojivoto
+spec:
+  routes:
+  - name: GET /
+    condition:
+      method: GET`,
+		`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: webapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books
+    timeout: 1s`,
+	}
+
+	_, spClient, err := k8s.NewFakeClientSets(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	t.Run("Returns the configuration of the routes of the namespace", func(t *testing.T) {
+		h := &handler{spClient: spClient}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/route-configs?namespace=booksapp", nil)
+		h.handleAPIRouteConfigs(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+		}
+
+		var actual []routeConfig
+		if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []routeConfig{
+			{
+				Authority:       "authors.example.com",
+				Namespace:       "booksapp",
+				Route:           "GET /authors",
+				Timeout:         "10s",
+				ResponseClasses: []responseClassConfig{defaultFailureClass},
+			},
+			{
+				Authority:        "books.booksapp.svc.cluster.local",
+				Namespace:        "booksapp",
+				Service:          "books",
+				ServiceNamespace: "booksapp",
+				Route:            "GET /books",
+				IsRetryable:      true,
+				Timeout:          "300ms",
+				ResponseClasses: []responseClassConfig{
+					{Condition: "(status 500-599 and not status 503)", IsFailure: true},
+					{Condition: "(status 404 or status 409)"},
+					defaultFailureClass,
+				},
+			},
+			{
+				Authority:        "books.booksapp.svc.cluster.local",
+				Namespace:        "booksapp",
+				Service:          "books",
+				ServiceNamespace: "booksapp",
+				Route:            "POST /books",
+				Timeout:          "10s",
+				ResponseClasses:  []responseClassConfig{defaultFailureClass},
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, actual)
+		}
+	})
+
+	t.Run("Returns the service of the profiles of the clients' namespace", func(t *testing.T) {
+		h := &handler{spClient: spClient}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/route-configs?namespace=webapp", nil)
+		h.handleAPIRouteConfigs(recorder, req, httprouter.Params{})
+
+		var actual []routeConfig
+		if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []routeConfig{
+			{
+				Authority:        "books.booksapp.svc.cluster.local",
+				Namespace:        "webapp",
+				Service:          "books",
+				ServiceNamespace: "booksapp",
+				Route:            "GET /books",
+				Timeout:          "1s",
+				ResponseClasses:  []responseClassConfig{defaultFailureClass},
+			},
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, actual)
+		}
+	})
+
+	t.Run("Requires a namespace", func(t *testing.T) {
+		h := &handler{spClient: spClient}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/route-configs", nil)
+		h.handleAPIRouteConfigs(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("Rejects linked clusters", func(t *testing.T) {
+		h := &handler{spClient: spClient, clusterName: "east"}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/route-configs?namespace=booksapp&cluster=west", nil)
+		h.handleAPIRouteConfigs(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+
+	t.Run("Rejects namespaces that aren't allowed", func(t *testing.T) {
+		h := &handler{spClient: spClient, allowedNamespaces: []string{"emojivoto"}}
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/route-configs?namespace=booksapp", nil)
+		h.handleAPIRouteConfigs(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("Expected status code %d, got %d", http.StatusForbidden, recorder.Code)
+		}
+	})
+}
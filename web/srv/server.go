@@ -9,10 +9,12 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/controller/api/public"
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/filesonly"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -28,6 +30,11 @@ type (
 		router      *httprouter.Router
 	}
 
+	apiRoute struct {
+		path   string
+		handle httprouter.Handle
+	}
+
 	templatePayload struct {
 		Contents interface{}
 	}
@@ -35,6 +42,8 @@ type (
 		Data                pb.VersionInfo
 		UUID                string
 		ControllerNamespace string
+		ClusterName         string
+		LinkedClusters      string
 		Error               bool
 		ErrorMessage        string
 		PathPrefix          string
@@ -52,12 +61,18 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 func NewServer(
 	addr string,
 	grafanaAddr string,
+	grafanaAPIKey string,
 	templateDir string,
 	staticDir string,
 	uuid string,
 	controllerNamespace string,
+	clusterName string,
 	reload bool,
 	apiClient public.APIClient,
+	linkedClients map[string]public.APIClient,
+	k8sClient kubernetes.Interface,
+	spClient spclient.Interface,
+	allowedNamespaces []string,
 ) *http.Server {
 	server := &Server{
 		templateDir: templateDir,
@@ -73,10 +88,16 @@ func NewServer(
 	wrappedServer := prometheus.WithTelemetry(server)
 	handler := &handler{
 		apiClient:           apiClient,
+		linkedClients:       linkedClients,
+		k8sClient:           k8sClient,
+		spClient:            spClient,
+		allowedNamespaces:   allowedNamespaces,
+		clusterName:         clusterName,
 		render:              server.RenderTemplate,
 		uuid:                uuid,
 		controllerNamespace: controllerNamespace,
 		grafanaProxy:        newGrafanaProxy(grafanaAddr),
+		grafanaPanels:       newGrafanaPanelSigner(grafanaAPIKey),
 	}
 
 	httpServer := &http.Server{
@@ -116,16 +137,10 @@ func NewServer(
 	server.router.GET("/dist/*filepath", mkStaticHandler(staticDir))
 
 	// webapp api routes
-	server.router.GET("/api/version", handler.handleAPIVersion)
-	// Traffic Performance Summary.  This route used to be called /api/stat
-	// but was renamed to avoid triggering ad blockers.
-	// See: https://github.com/linkerd/linkerd2/issues/970
-	server.router.GET("/api/tps-reports", handler.handleAPIStat)
-	server.router.GET("/api/pods", handler.handleAPIPods)
-	server.router.GET("/api/services", handler.handleAPIServices)
-	server.router.GET("/api/tap", handler.handleAPITap)
-	server.router.GET("/api/routes", handler.handleAPITopRoutes)
-	server.router.GET("/api/endpoints", handler.handleAPIEndpoints)
+	for _, route := range handler.apiRoutes() {
+		server.router.GET(route.path, route.handle)
+	}
+	server.router.GET(openAPISpecPath, handler.handleAPISpec)
 
 	// grafana proxy
 	server.router.DELETE("/grafana/*grafanapath", handler.handleGrafana)
@@ -139,6 +154,28 @@ func NewServer(
 	return httpServer
 }
 
+// apiRoutes returns the GET routes of the webapp api, which are described by
+// openAPISpec.
+func (h *handler) apiRoutes() []apiRoute {
+	return []apiRoute{
+		{"/api/version", h.handleAPIVersion},
+		// Traffic Performance Summary.  This route used to be called /api/stat
+		// but was renamed to avoid triggering ad blockers.
+		// See: https://github.com/linkerd/linkerd2/issues/970
+		{"/api/tps-reports", h.handleAPIStat},
+		{"/api/pods", h.handleAPIPods},
+		{"/api/services", h.handleAPIServices},
+		{"/api/tap", h.handleAPITap},
+		{"/api/routes", h.handleAPITopRoutes},
+		{"/api/endpoints", h.handleAPIEndpoints},
+		{"/api/clusters", h.handleAPIClusters},
+		{"/api/namespace-quota", h.handleAPINamespaceQuota},
+		{"/api/route-configs", h.handleAPIRouteConfigs},
+		{"/api/events", h.handleAPIEvents},
+		{"/api/grafana-panel", h.handleAPIGrafanaPanel},
+	}
+}
+
 // RenderTemplate writes a rendered template into a buffer, given an HTTP
 // request and template information.
 func (s *Server) RenderTemplate(w http.ResponseWriter, templateFile, templateName string, args interface{}) error {